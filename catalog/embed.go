@@ -6,3 +6,8 @@ import "embed"
 //
 //go:embed engines/*/*.yaml
 var EngineFS embed.FS
+
+// ModelFS contains embedded model asset YAML files from catalog/models/.
+//
+//go:embed models/*/*.yaml
+var ModelFS embed.FS
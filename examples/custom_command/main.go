@@ -103,7 +103,14 @@ func (c *CustomCommand) OutputSchema() unit.Schema {
 				Name: "request_id",
 				Schema: unit.Schema{
 					Type:        "string",
-					Description: "请求 ID",
+					Description: "请求关联 ID (trace ID)",
+				},
+			},
+			"caller": {
+				Name: "caller",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "认证后的调用方,未认证时为 anonymous",
 				},
 			},
 		},
@@ -171,17 +178,21 @@ func (c *CustomCommand) Execute(ctx context.Context, input any) (any, error) {
 		greeting = fmt.Sprintf("Hello, %s!", name)
 	}
 
-	// 获取请求 ID（如果有）
-	requestID := "unknown"
-	if ctx != nil {
-		// 这里可以添加从 context 获取 request_id 的逻辑
-		requestID = generateRequestID()
+	// 从 context 获取网关注入的关联 ID (即 trace ID) 和调用方
+	requestID, ok := gateway.CorrelationIDFromContext(ctx)
+	if !ok {
+		requestID = "unknown"
+	}
+	caller, ok := gateway.PrincipalFromContext(ctx)
+	if !ok {
+		caller = "anonymous"
 	}
 
 	return map[string]any{
 		"greeting":   greeting,
 		"timestamp":  time.Now().UTC().Format(time.RFC3339),
 		"request_id": requestID,
+		"caller":     caller,
 	}, nil
 }
 
@@ -190,11 +201,6 @@ func intPtr(i int) *int {
 	return &i
 }
 
-// generateRequestID 生成请求 ID
-func generateRequestID() string {
-	return fmt.Sprintf("req_%d", time.Now().UnixNano())
-}
-
 func main() {
 	fmt.Println("=== AIMA 自定义 Command 示例 ===")
 	fmt.Println()
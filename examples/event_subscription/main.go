@@ -143,9 +143,14 @@ func main() {
 		{
 			"inference.request_completed",
 			map[string]any{
-				"request_id": "req_001",
-				"duration":   2345,
-				"tokens":     150,
+				"request_id":        "req_001",
+				"correlation_id":    "trace_002",
+				"model":             "llama3.2",
+				"finish_reason":     "stop",
+				"duration_ms":       2345,
+				"prompt_tokens":     100,
+				"completion_tokens": 50,
+				"total_tokens":      150,
 			},
 		},
 	}
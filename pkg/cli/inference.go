@@ -19,6 +19,7 @@ This includes chat completions, text embeddings, and other inference tasks.`,
 
 	cmd.AddCommand(NewInferenceChatCommand(root))
 	cmd.AddCommand(NewInferenceEmbedCommand(root))
+	cmd.AddCommand(NewInferenceBenchmarkCommand(root))
 
 	return cmd
 }
@@ -169,3 +170,69 @@ func runInferenceEmbed(ctx context.Context, root *RootCommand, model, inputText
 
 	return PrintOutput(resp.Data, opts)
 }
+
+func NewInferenceBenchmarkCommand(root *RootCommand) *cobra.Command {
+	var (
+		model     string
+		prompt    string
+		runs      int
+		warmup    int
+		maxTokens int
+		stream    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Measure model latency and throughput",
+		Long: `Run a number of short generations against a model and report p50/p95
+latency, tokens per second and, with --stream, time to first token.`,
+		Example: `  # Five runs after one warmup
+  aima inference benchmark --model qwen2.5-7b
+
+  # Twenty streamed runs
+  aima inference benchmark --model qwen2.5-7b --runs 20 --stream`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInferenceBenchmark(cmd.Context(), root, map[string]any{
+				"model":      model,
+				"prompt":     prompt,
+				"runs":       runs,
+				"warmup":     warmup,
+				"max_tokens": maxTokens,
+				"stream":     stream,
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Model name (required)")
+	cmd.Flags().StringVar(&prompt, "prompt", "", "Prompt sent on every run")
+	cmd.Flags().IntVar(&runs, "runs", 5, "Number of measured runs")
+	cmd.Flags().IntVar(&warmup, "warmup", 1, "Number of unmeasured runs made first")
+	cmd.Flags().IntVar(&maxTokens, "max-tokens", 64, "Maximum tokens to generate per run")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Stream runs to measure time to first token")
+
+	_ = cmd.MarkFlagRequired("model")
+
+	return cmd
+}
+
+func runInferenceBenchmark(ctx context.Context, root *RootCommand, input map[string]any) error {
+	if input["model"] == "" {
+		return fmt.Errorf("model is required")
+	}
+
+	gw := root.Gateway()
+	opts := root.OutputOptions()
+
+	resp := gw.Handle(ctx, &gateway.Request{
+		Type:  gateway.TypeCommand,
+		Unit:  "inference.benchmark",
+		Input: input,
+	})
+
+	if !resp.Success {
+		PrintError(fmt.Errorf("%s: %s", resp.Error.Code, resp.Error.Message), opts)
+		return fmt.Errorf("benchmark failed: %s", resp.Error.Message)
+	}
+
+	return PrintOutput(resp.Data, opts)
+}
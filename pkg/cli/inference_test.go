@@ -27,7 +27,7 @@ func TestNewInferenceCommand(t *testing.T) {
 	assert.Equal(t, "inference", cmd.Use)
 
 	subCommands := cmd.Commands()
-	assert.Len(t, subCommands, 2)
+	assert.Len(t, subCommands, 3)
 }
 
 func TestInferenceChatCommand_Flags(t *testing.T) {
@@ -154,3 +154,21 @@ func TestInferenceEmbedCommand_MissingInput(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "input is required")
 }
+
+func TestInferenceBenchmarkCommand_Flags(t *testing.T) {
+	registry := unit.NewRegistry()
+	gw := gateway.NewGateway(registry)
+
+	root := &RootCommand{
+		gateway:  gw,
+		registry: registry,
+		opts:     NewOutputOptions(),
+	}
+
+	cmd := NewInferenceBenchmarkCommand(root)
+
+	for _, name := range []string{"model", "prompt", "runs", "warmup", "max-tokens", "stream"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), name)
+	}
+	assert.Equal(t, "5", cmd.Flags().Lookup("runs").DefValue)
+}
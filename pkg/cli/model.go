@@ -23,6 +23,7 @@ and managing model lifecycle.`,
 	cmd.AddCommand(NewModelGetCommand(root))
 	cmd.AddCommand(NewModelDeleteCommand(root))
 	cmd.AddCommand(NewModelCreateCommand(root))
+	cmd.AddCommand(NewModelPruneCommand(root))
 
 	return cmd
 }
@@ -252,6 +253,56 @@ func runModelDelete(ctx context.Context, root *RootCommand, modelID string, forc
 	return nil
 }
 
+func NewModelPruneCommand(root *RootCommand) *cobra.Command {
+	var (
+		olderThanDays int
+		dryRun        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete unused models",
+		Long: `Delete models that no service references and that have served no
+inference request for the given number of days.`,
+		Example: `  # Show what would be removed
+  aima model prune --dry-run
+
+  # Remove models unused for two weeks
+  aima model prune --older-than-days 14`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModelPrune(cmd.Context(), root, olderThanDays, dryRun)
+		},
+	}
+
+	cmd.Flags().IntVar(&olderThanDays, "older-than-days", 30, "Prune models unused for at least this many days")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the models that would be removed without deleting them")
+
+	return cmd
+}
+
+func runModelPrune(ctx context.Context, root *RootCommand, olderThanDays int, dryRun bool) error {
+	gw := root.Gateway()
+	opts := root.OutputOptions()
+
+	req := &gateway.Request{
+		Type: gateway.TypeCommand,
+		Unit: "model.prune",
+		Input: map[string]any{
+			"older_than_days": olderThanDays,
+			"dry_run":         dryRun,
+		},
+	}
+
+	resp := gw.Handle(ctx, req)
+
+	if !resp.Success {
+		PrintError(fmt.Errorf("%s: %s", resp.Error.Code, resp.Error.Message), opts)
+		return fmt.Errorf("prune models failed: %s", resp.Error.Message)
+	}
+
+	return PrintOutput(resp.Data, opts)
+}
+
 func NewModelCreateCommand(root *RootCommand) *cobra.Command {
 	var (
 		name      string
@@ -28,7 +28,7 @@ func TestNewModelCommand(t *testing.T) {
 	assert.Equal(t, "model", cmd.Use)
 
 	subCommands := cmd.Commands()
-	assert.Len(t, subCommands, 5)
+	assert.Len(t, subCommands, 6)
 
 	commandNames := make([]string, len(subCommands))
 	for i, c := range subCommands {
@@ -39,6 +39,7 @@ func TestNewModelCommand(t *testing.T) {
 	assert.Contains(t, commandNames, "get")
 	assert.Contains(t, commandNames, "delete")
 	assert.Contains(t, commandNames, "create")
+	assert.Contains(t, commandNames, "prune")
 }
 
 func TestModelPullCommand_Flags(t *testing.T) {
@@ -136,6 +137,18 @@ func TestModelDeleteCommand(t *testing.T) {
 	assert.Equal(t, "f", forceFlag.Shorthand)
 }
 
+func TestModelPruneCommand_Flags(t *testing.T) {
+	root := &RootCommand{opts: NewOutputOptions()}
+
+	cmd := NewModelPruneCommand(root)
+	assert.Equal(t, "prune", cmd.Use)
+
+	days := cmd.Flags().Lookup("older-than-days")
+	require.NotNil(t, days)
+	assert.Equal(t, "30", days.DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("dry-run"))
+}
+
 func TestModelCreateCommand_Flags(t *testing.T) {
 	registry := unit.NewRegistry()
 	gw := gateway.NewGateway(registry)
@@ -25,10 +25,15 @@ import (
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/provider/huggingface"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/store"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/registry"
+	coreservice "github.com/jguan/ai-inference-managed-by-ai/pkg/service"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/service/billing"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/catalog"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/device"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
 )
 
@@ -49,6 +54,8 @@ type RootCommand struct {
 	formatStr    string
 	agent        *coreagent.Agent
 	dataDir      string
+	// engineReconciler is nil when engine health checks are disabled.
+	engineReconciler *coreservice.EngineReconciler
 }
 
 func NewRootCommand() *RootCommand {
@@ -149,14 +156,33 @@ func (r *RootCommand) persistentPreRunE(cmd *cobra.Command, args []string) error
 		}
 	}
 
+	modelPaths := model.NewPathResolver(r.cfg.ModelsDir())
+	if err := modelPaths.Validate(); err != nil {
+		return fmt.Errorf("invalid models directory: %w", err)
+	}
+
 	// Create providers
 	modelProvider := huggingface.NewProvider(
 		huggingface.WithDownloadDir(r.cfg.Model.StorageDir),
+		huggingface.WithPathResolver(modelPaths),
 	)
 
 	// Create hybrid engine provider (supports Docker + Native modes)
 	slog.Info("initializing hybrid engine provider", "mode", "Docker + Native")
 	serviceProvider := provider.NewHybridServiceProvider(modelStore, serviceStore)
+	if err := serviceProvider.SetGPUMemoryUtilization(r.cfg.Engine.GPUMemoryUtilization); err != nil {
+		slog.Warn("invalid engine.gpu_memory_utilization, using default", "error", err)
+	}
+	if err := serviceProvider.SetPortRange(r.cfg.Engine.PortMin, r.cfg.Engine.PortMax); err != nil {
+		slog.Warn("invalid engine port range, using default", "error", err)
+	}
+	serviceProvider.SetDrainTimeout(r.cfg.Engine.DrainTimeoutD)
+	if r.cfg.Docker.CLIFallback {
+		serviceProvider.EnableDockerCLIFallback()
+	}
+	if r.cfg.Docker.ModelCacheVolume != "" {
+		serviceProvider.SetModelCacheVolume(r.cfg.Docker.ModelCacheVolume)
+	}
 	engineProvider := serviceProvider.GetEngineProvider()
 
 	// Create event bus and wire it to the engine provider for progress events
@@ -170,7 +196,9 @@ func (r *RootCommand) persistentPreRunE(cmd *cobra.Command, args []string) error
 	engineStore := engine.NewMemoryStore()
 
 	// Seed engine store with available engine types from loaded assets.
+	var assetReloader catalog.EngineAssetReloader
 	if hep, ok := engineProvider.(*provider.HybridEngineProvider); ok {
+		assetReloader = hep
 		for _, assetType := range hep.AssetTypes() {
 			now := time.Now().Unix()
 			if err := engineStore.Create(context.Background(), &engine.Engine{
@@ -186,6 +214,17 @@ func (r *RootCommand) persistentPreRunE(cmd *cobra.Command, args []string) error
 		}
 	}
 
+	// Probe running engines over HTTP so dead ones stop receiving requests.
+	// The reconciler is started by the server, the only long-lived command.
+	if interval := r.cfg.Engine.HealthCheckIntervalD; interval > 0 {
+		var defaultPort func(string) int
+		if hep, ok := engineProvider.(*provider.HybridEngineProvider); ok {
+			defaultPort = hep.DefaultPort
+		}
+		probe := coreservice.NewHTTPEngineProbe(engineStore, defaultPort)
+		r.engineReconciler = coreservice.NewEngineReconciler(engineStore, probe, interval)
+	}
+
 	// Create device provider (NVIDIA GPU detection via nvidia-smi)
 	deviceProvider := nvidia.NewProvider()
 
@@ -195,15 +234,26 @@ func (r *RootCommand) persistentPreRunE(cmd *cobra.Command, args []string) error
 	r.dataDir = dataDir
 
 	// Create inference provider that proxies to running services
+	// Requests are tracked per service so stopping one drains them first.
 	inferenceProvider := provider.NewProxyInferenceProvider(serviceStore, modelStore)
+	inferenceProvider.SetRequestTracker(serviceProvider)
 
 	// Create resource provider that reads system memory/storage metrics (Bug #49)
 	resourceProvider := provider.NewSystemResourceProvider()
 
+	// Serialize service admission so concurrent starts cannot overcommit the
+	// GPUs. SystemResourceProvider measures this process rather than the host,
+	// so memory claims are not checked against it.
+	serviceProvider.SetReservationLedger(resource.NewLedger(nil).WithGPUCount(detectGPUCount(deviceProvider)))
+
+	// Shared between inference.cancel and the gateway's streaming path.
+	streams := unit.NewStreamTracker()
+
 	// Register all atomic units with providers
 	if err := registry.RegisterAll(r.registry,
 		registry.WithModelProvider(modelProvider),
 		registry.WithModelStore(modelStore),
+		registry.WithModelPathResolver(modelPaths),
 		registry.WithServiceProvider(serviceProvider),
 		registry.WithServiceStore(serviceStore),
 		registry.WithEngineProvider(engineProvider),
@@ -213,11 +263,31 @@ func (r *RootCommand) persistentPreRunE(cmd *cobra.Command, args []string) error
 		registry.WithResourceProvider(resourceProvider),
 		registry.WithCatalogStore(catalogStore),
 		registry.WithEventBus(eventbus.NewEventPublisherAdapter(r.eventBus)),
+		registry.WithStreamTracker(streams),
+		registry.WithBillingLedger(billing.NewLedger(billingPrices(r.cfg.Billing)).WithMaxRecords(r.cfg.Billing.MaxRecords)),
+		registry.WithMaxTokensCap(inference.MaxTokensCap{
+			Default: r.cfg.Inference.MaxTokens,
+			Models:  r.cfg.Inference.ModelMaxTokens,
+		}),
+		registry.WithStopTrimming(r.cfg.Inference.TrimStopSequences),
+		registry.WithEngineAssetReloader(assetReloader),
 	); err != nil {
 		return fmt.Errorf("register units: %w", err)
 	}
 
-	r.gateway = gateway.NewGateway(r.registry, gateway.WithTimeout(r.cfg.Gateway.RequestTimeoutD))
+	r.gateway = gateway.NewGateway(r.registry,
+		gateway.WithMiddleware(
+			gateway.RecoverPanics(slog.Default()),
+			gateway.LogRequests(slog.Default(), r.registry),
+		),
+		gateway.WithTimeout(r.cfg.Gateway.RequestTimeoutD),
+		gateway.WithStreamTracker(streams),
+		gateway.WithConcurrencyLimits(gateway.ConcurrencyLimits{
+			PerDomain: r.cfg.Gateway.DomainConcurrency,
+			Queue:     r.cfg.Gateway.QueueWhenBusy,
+		}),
+		gateway.WithSystemPrompts(r.cfg.Gateway.SystemPrompts),
+	)
 
 	// Two-phase agent setup: create Agent after Gateway so MCPAdapter can be used
 	// as the ToolExecutor (it needs the Gateway to dispatch tool calls).
@@ -287,6 +357,18 @@ func (r *RootCommand) setupAgent(ctx context.Context) error {
 	return nil
 }
 
+// billingPrices converts the configured prices into a billing price table.
+func billingPrices(cfg config.BillingConfig) billing.PriceTable {
+	table := billing.PriceTable{
+		Models:  make(map[string]billing.Price, len(cfg.Prices)),
+		Default: billing.Price(cfg.DefaultPrice),
+	}
+	for model, price := range cfg.Prices {
+		table.Models[model] = billing.Price(price)
+	}
+	return table
+}
+
 // listRunningServices queries the service store for running services.
 // Returns nil if the store is unavailable or returns an error.
 func (r *RootCommand) listRunningServices(ctx context.Context) []service.ModelService {
@@ -302,6 +384,19 @@ func (r *RootCommand) listRunningServices(ctx context.Context) []service.ModelSe
 	return svcs
 }
 
+// detectGPUCount returns how many GPUs devices reports, or 1 when detection
+// fails or finds none, as on unified memory systems without nvidia-smi.
+func detectGPUCount(devices device.DeviceProvider) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	found, err := devices.Detect(ctx)
+	if err != nil || len(found) == 0 {
+		slog.Debug("GPU detection found no devices, assuming one GPU", "error", err)
+		return 1
+	}
+	return len(found)
+}
+
 func (r *RootCommand) addSubCommands() {
 	r.cmd.AddCommand(NewVersionCommand(r))
 	r.cmd.AddCommand(NewExecCommand(r))
@@ -346,6 +441,12 @@ func (r *RootCommand) EventBus() eventbus.EventBus {
 	return r.eventBus
 }
 
+// EngineReconciler returns the engine health reconciler, or nil when
+// engine.health_check_interval is "0s".
+func (r *RootCommand) EngineReconciler() *coreservice.EngineReconciler {
+	return r.engineReconciler
+}
+
 func (r *RootCommand) Agent() *coreagent.Agent {
 	return r.agent
 }
@@ -81,6 +81,7 @@ func TestRootCommand_PersistentPreRunE(t *testing.T) {
 	assert.NotNil(t, root.Config())
 	assert.NotNil(t, root.Gateway())
 	assert.NotNil(t, root.Registry())
+	assert.NotNil(t, root.EngineReconciler(), "engine health checks are on by default")
 }
 
 func TestRootCommand_Execute(t *testing.T) {
@@ -13,6 +13,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/config"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/gateway"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/gateway/middleware"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/metrics"
@@ -71,31 +72,13 @@ func runStart(ctx context.Context, root *RootCommand, addr string, port int, tls
 	sysCollector.Start(ctx)
 	defer sysCollector.Stop()
 
-	router := gateway.NewRouter(gw)
-
-	mux := http.NewServeMux()
-	mux.HandleFunc("/api/v2/execute", instrumentHandler(handleExecute(gw), reqMetrics))
-	mux.HandleFunc("/api/v2/health", instrumentHandler(handleHealth(gw), reqMetrics))
-	mux.HandleFunc("/api/v2/metrics", handlePrometheusMetrics(reqMetrics, sysCollector))
-	mux.Handle("/api/v2/", router)
-
-	// Build the root handler, applying auth and rate-limit middleware when configured.
-	var handler http.Handler = mux
-
-	// Auth middleware — wire auth.Enabled + api_keys from config.
-	// OFF by default; activates only when [auth] enabled = true in config.
-	authCfg := middleware.DefaultAuthConfig()
-	authCfg.Enabled = cfg.Auth.Enabled
-	authCfg.APIKeys = cfg.Auth.APIKeys
-	handler = middleware.Auth(authCfg)(handler)
-
-	// Rate-limit middleware — only active when rate_limit_per_min > 0.
-	if cfg.Security.RateLimitPerMin > 0 {
-		ratePerSec := float64(cfg.Security.RateLimitPerMin) / 60.0
-		limiter := ratelimit.New(ratePerSec, int64(cfg.Security.RateLimitPerMin))
-		handler = middleware.RateLimit(limiter)(handler)
+	if reconciler := root.EngineReconciler(); reconciler != nil {
+		reconciler.Start(ctx)
+		defer reconciler.Stop()
 	}
 
+	handler := newAPIHandler(cfg, gw, reqMetrics, sysCollector)
+
 	server := &http.Server{
 		Addr:         listenAddr,
 		Handler:      handler,
@@ -152,8 +135,79 @@ func runStart(ctx context.Context, root *RootCommand, addr string, port int, tls
 	return nil
 }
 
+// newAPIHandler builds the server's root handler: the API routes wrapped in
+// the auth, rate-limit and CORS middleware the config enables.
+func newAPIHandler(cfg *config.Config, gw *gateway.Gateway, reqMetrics *metrics.RequestMetrics, sysCollector metrics.Collector) http.Handler {
+	cors, corsEnabled := apiCORSConfig(cfg.API)
+
+	// With CORS configured the middleware decides which origins are allowed,
+	// so the router must not allow every origin on its own.
+	router := gateway.NewRouter(gw).WithCORSHeaders(!corsEnabled)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/execute", instrumentHandler(handleExecute(gw), reqMetrics))
+	mux.HandleFunc("/api/v2/health", instrumentHandler(handleHealth(gw), reqMetrics))
+	mux.HandleFunc("/api/v2/metrics", handlePrometheusMetrics(reqMetrics, sysCollector))
+	mux.Handle("/api/v2/", router)
+	mux.Handle("/v1/", gateway.NewOpenAIAdapter(gw).WithModelMap(cfg.API.OpenAIModels).WithKeepAlive(cfg.API.SSEKeepAliveD))
+
+	// Build the root handler, applying auth and rate-limit middleware when configured.
+	var handler http.Handler = mux
+
+	// Auth middleware — wire auth.Enabled + api_keys from config.
+	// OFF by default; activates only when [auth] enabled = true in config.
+	authCfg := middleware.DefaultAuthConfig()
+	authCfg.Enabled = cfg.Auth.Enabled
+	authCfg.APIKeys = cfg.Auth.APIKeys
+	authCfg.ResolveUnit = gw.Registry().CanonicalName
+	authCfg.RouteUnit = router.UnitFor
+	handler = middleware.Auth(authCfg)(handler)
+
+	// Rate-limit middleware — only active when rate_limit_per_min > 0.
+	if cfg.Security.RateLimitPerMin > 0 {
+		ratePerSec := float64(cfg.Security.RateLimitPerMin) / 60.0
+		limiter := ratelimit.New(ratePerSec, int64(cfg.Security.RateLimitPerMin))
+		handler = middleware.RateLimit(limiter)(handler)
+	}
+
+	// CORS is outermost so preflight requests are answered before auth and
+	// rate limiting, and rejected responses still carry CORS headers.
+	if corsEnabled {
+		handler = middleware.CORS(cors)(handler)
+	}
+
+	return handler
+}
+
+// apiCORSConfig returns the CORS middleware config for the [api] section and
+// whether CORS is enabled: either enable_cors, which allows every origin
+// unless allowed_origins narrows it, or a non-empty allowed_origins.
+func apiCORSConfig(api config.APIConfig) (middleware.CORSConfig, bool) {
+	cors := middleware.DefaultCORSConfig()
+	if !api.EnableCORS && len(api.AllowedOrigins) == 0 {
+		return cors, false
+	}
+	if len(api.AllowedOrigins) > 0 {
+		cors.AllowedOrigins = api.AllowedOrigins
+	}
+	if len(api.AllowedMethods) > 0 {
+		cors.AllowedMethods = api.AllowedMethods
+	}
+	if len(api.AllowedHeaders) > 0 {
+		cors.AllowedHeaders = api.AllowedHeaders
+	}
+	cors.AllowCredentials = api.AllowCredentials
+	return cors, true
+}
+
 func handleExecute(gw *gateway.Gateway) http.HandlerFunc {
+	queries := gateway.NewHTTPAdapter(gw)
 	return func(w http.ResponseWriter, r *http.Request) {
+		// GET runs a query given as query parameters.
+		if r.Method == http.MethodGet {
+			queries.ServeHTTP(w, r)
+			return
+		}
 		if r.Method != http.MethodPost {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
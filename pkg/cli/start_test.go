@@ -92,7 +92,7 @@ func TestHandleExecute_InvalidMethod(t *testing.T) {
 
 	handler := handleExecute(gw)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v2/execute", nil)
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/execute", nil)
 	rec := httptest.NewRecorder()
 
 	handler(rec, req)
@@ -138,6 +138,104 @@ func TestHandleExecute_ValidRequest(t *testing.T) {
 	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
 }
 
+func TestAPIHandler_ForcedRouteNeedsToken(t *testing.T) {
+	// Auth is off by default, and REST clients send no X-Unit.
+	handler := newAPIHandler(config.Default(), gateway.NewGateway(unit.NewRegistry()), metrics.NewRequestMetrics(), metrics.NewCollector())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/models/prune", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func newCORSTestHandler(t *testing.T) http.Handler {
+	t.Helper()
+	cfg := config.Default()
+	cfg.API.AllowedOrigins = []string{"https://ui.example.com"}
+	cfg.API.AllowCredentials = true
+	cfg.API.AllowedMethods = []string{"GET", "POST", "OPTIONS"}
+	cfg.API.AllowedHeaders = []string{"Content-Type", "Authorization"}
+	cfg.Auth.Enabled = true
+	cfg.Auth.APIKeys = []string{"secret"}
+	return newAPIHandler(cfg, gateway.NewGateway(unit.NewRegistry()), metrics.NewRequestMetrics(), metrics.NewCollector())
+}
+
+func TestAPIHandler_CORSAllowedOrigin(t *testing.T) {
+	handler := newCORSTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/health", nil)
+	req.Header.Set("Origin", "https://ui.example.com")
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "https://ui.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Contains(t, rec.Header().Values("Vary"), "Origin")
+}
+
+func TestAPIHandler_CORSDisallowedOrigin(t *testing.T) {
+	handler := newCORSTestHandler(t)
+
+	// The router's own allow-all headers must not leak through either.
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/models", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	req = httptest.NewRequest(http.MethodOptions, "/api/v2/execute", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec = httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestAPIHandler_CORSPreflight(t *testing.T) {
+	handler := newCORSTestHandler(t)
+
+	// Preflights carry no credentials, so they must be answered before auth.
+	req := httptest.NewRequest(http.MethodOptions, "/api/v2/execute", nil)
+	req.Header.Set("Origin", "https://ui.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "Content-Type, Authorization")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://ui.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, Authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestAPICORSConfig(t *testing.T) {
+	_, enabled := apiCORSConfig(config.APIConfig{})
+	assert.False(t, enabled, "CORS should be off by default")
+
+	cors, enabled := apiCORSConfig(config.APIConfig{EnableCORS: true})
+	assert.True(t, enabled)
+	assert.Equal(t, []string{"*"}, cors.AllowedOrigins)
+
+	cors, enabled = apiCORSConfig(config.APIConfig{AllowedOrigins: []string{"https://ui.example.com"}})
+	assert.True(t, enabled, "allowed_origins alone should enable CORS")
+	assert.Equal(t, []string{"https://ui.example.com"}, cors.AllowedOrigins)
+	assert.NotEmpty(t, cors.AllowedMethods)
+}
+
 func TestWriteJSONError(t *testing.T) {
 	rec := httptest.NewRecorder()
 
@@ -14,23 +14,32 @@ import (
 type DockerConfig struct {
 	Host    string `toml:"host"`    // e.g. "unix:///var/run/docker.sock" or "tcp://remote:2375"; empty means auto-detect from env
 	Timeout string `toml:"timeout"` // e.g. "30s"
+	// CLIFallback retries Docker operations that fail on the SDK client
+	// (API unreachable or version mismatch) through the docker CLI.
+	CLIFallback bool `toml:"cli_fallback"`
+	// ModelCacheVolume is a named volume mounted as the Hugging Face cache of
+	// vLLM and transformers containers; empty disables the shared cache.
+	ModelCacheVolume string `toml:"model_cache_volume"`
 }
 
 type Config struct {
-	General  GeneralConfig  `toml:"general"`
-	API      APIConfig      `toml:"api"`
-	Gateway  GatewayConfig  `toml:"gateway"`
-	Resource ResourceConfig `toml:"resource"`
-	Model    ModelConfig    `toml:"model"`
-	Engine   EngineConfig   `toml:"engine"`
-	Workflow WorkflowConfig `toml:"workflow"`
-	Alert    AlertConfig    `toml:"alert"`
-	Remote   RemoteConfig   `toml:"remote"`
-	Security SecurityConfig `toml:"security"`
-	Auth     AuthConfig     `toml:"auth"`
-	Logging  LoggingConfig  `toml:"logging"`
-	Agent    AgentConfig    `toml:"agent"`
-	Docker   DockerConfig   `toml:"docker"`
+	General   GeneralConfig   `toml:"general"`
+	API       APIConfig       `toml:"api"`
+	Gateway   GatewayConfig   `toml:"gateway"`
+	Resource  ResourceConfig  `toml:"resource"`
+	Model     ModelConfig     `toml:"model"`
+	Storage   StorageConfig   `toml:"storage"`
+	Engine    EngineConfig    `toml:"engine"`
+	Workflow  WorkflowConfig  `toml:"workflow"`
+	Alert     AlertConfig     `toml:"alert"`
+	Remote    RemoteConfig    `toml:"remote"`
+	Security  SecurityConfig  `toml:"security"`
+	Auth      AuthConfig      `toml:"auth"`
+	Logging   LoggingConfig   `toml:"logging"`
+	Agent     AgentConfig     `toml:"agent"`
+	Docker    DockerConfig    `toml:"docker"`
+	Billing   BillingConfig   `toml:"billing"`
+	Inference InferenceConfig `toml:"inference"`
 }
 
 type GeneralConfig struct {
@@ -44,6 +53,24 @@ type APIConfig struct {
 	EnableCORS bool   `toml:"enable_cors"`
 	TLSCert    string `toml:"tls_cert"`
 	TLSKey     string `toml:"tls_key"`
+	// AllowedOrigins lists the browser origins allowed to call the API,
+	// e.g. ["https://ui.example.com", "*.example.com"]. Setting it enables
+	// CORS; enable_cors alone allows every origin.
+	AllowedOrigins []string `toml:"allowed_origins"`
+	// AllowCredentials lets allowed origins send cookies and Authorization.
+	// It requires AllowedOrigins to list explicit origins, not "*".
+	AllowCredentials bool `toml:"allow_credentials"`
+	// AllowedMethods and AllowedHeaders answer CORS preflight requests.
+	// Empty means the middleware defaults.
+	AllowedMethods []string `toml:"allowed_methods"`
+	AllowedHeaders []string `toml:"allowed_headers"`
+	// OpenAIModels maps model names sent to the OpenAI-compatible /v1
+	// endpoints to AIMA models, e.g. {"gpt-4o" = "qwen2.5:72b"}.
+	OpenAIModels map[string]string `toml:"openai_models"`
+	// SSEKeepAlive is how often streams send an SSE keepalive comment while
+	// waiting for the first token, e.g. "15s". "0s" disables it.
+	SSEKeepAlive  string        `toml:"sse_keepalive"`
+	SSEKeepAliveD time.Duration `toml:"-"`
 }
 
 type GatewayConfig struct {
@@ -51,6 +78,15 @@ type GatewayConfig struct {
 	MaxRequestSize  string        `toml:"max_request_size"`
 	EnableTracing   bool          `toml:"enable_tracing"`
 	RequestTimeoutD time.Duration `toml:"-"`
+	// DomainConcurrency caps concurrent executions per unit domain,
+	// e.g. {inference = 4}. Domains not listed are unlimited.
+	DomainConcurrency map[string]int `toml:"domain_concurrency"`
+	// QueueWhenBusy makes requests over a domain's limit wait for a slot
+	// instead of failing with "overloaded".
+	QueueWhenBusy bool `toml:"queue_when_busy"`
+	// SystemPrompts maps a unit domain to a system message prepended to its
+	// chat requests that carry none, e.g. {inference = "..."}.
+	SystemPrompts map[string]string `toml:"system_prompts"`
 }
 
 type ResourceConfig struct {
@@ -66,9 +102,28 @@ type ModelConfig struct {
 	MaxCacheGB    int    `toml:"max_cache_gb"`
 }
 
+// StorageConfig holds on-disk storage locations.
+type StorageConfig struct {
+	// ModelsDir is where pulled and copied models are stored, one directory
+	// per model. Empty means model.storage_dir.
+	ModelsDir string `toml:"models_dir"`
+}
+
 type EngineConfig struct {
-	AutoStart  bool   `toml:"auto_start"`
-	OllamaAddr string `toml:"ollama_addr"`
+	AutoStart            bool    `toml:"auto_start"`
+	OllamaAddr           string  `toml:"ollama_addr"`
+	GPUMemoryUtilization float64 `toml:"gpu_memory_utilization"`
+	// PortMin and PortMax bound the host ports assigned to new services.
+	PortMin int `toml:"port_min"`
+	PortMax int `toml:"port_max"`
+	// DrainTimeout is how long stopping a service waits for its in-flight
+	// requests, e.g. "30s". "0s" stops immediately.
+	DrainTimeout  string        `toml:"drain_timeout"`
+	DrainTimeoutD time.Duration `toml:"-"`
+	// HealthCheckInterval is how often running engines are probed so dead
+	// ones are marked as errored, e.g. "30s". "0s" disables the probes.
+	HealthCheckInterval  string        `toml:"health_check_interval"`
+	HealthCheckIntervalD time.Duration `toml:"-"`
 }
 
 type WorkflowConfig struct {
@@ -84,6 +139,36 @@ type AlertConfig struct {
 	CheckIntervalD time.Duration `toml:"-"`
 }
 
+// BillingConfig prices inference usage for billing.usage.
+type BillingConfig struct {
+	// DefaultPrice applies to models without an entry in Prices.
+	DefaultPrice ModelPrice `toml:"default_price"`
+	// Prices maps a model name, or a prefix such as "llama3", to its price.
+	Prices map[string]ModelPrice `toml:"prices"`
+	// MaxRecords caps the priced requests kept in memory; the oldest are
+	// folded into daily per-principal totals. Zero keeps every record.
+	MaxRecords int `toml:"max_records"`
+}
+
+// InferenceConfig holds server-side limits on inference requests.
+type InferenceConfig struct {
+	// MaxTokens caps the max_tokens of chat and completion requests for
+	// models without an entry in ModelMaxTokens. Zero means no cap.
+	MaxTokens int `toml:"max_tokens"`
+	// ModelMaxTokens maps a model name, or a prefix such as "llama3", to its
+	// max_tokens cap.
+	ModelMaxTokens map[string]int `toml:"model_max_tokens"`
+	// TrimStopSequences cuts chat and completion output at the first stop
+	// sequence on the server, for engines that ignore stop.
+	TrimStopSequences bool `toml:"trim_stop_sequences"`
+}
+
+// ModelPrice is the cost of one million prompt and completion tokens.
+type ModelPrice struct {
+	PromptPerMillion     float64 `toml:"prompt_per_million"`
+	CompletionPerMillion float64 `toml:"completion_per_million"`
+}
+
 type RemoteConfig struct {
 	Enabled  bool   `toml:"enabled"`
 	Provider string `toml:"provider"`
@@ -138,10 +223,11 @@ func Default() *Config {
 			DeviceID: "",
 		},
 		API: APIConfig{
-			ListenAddr: "127.0.0.1:9090",
-			EnableCORS: false,
-			TLSCert:    "",
-			TLSKey:     "",
+			ListenAddr:   "127.0.0.1:9090",
+			EnableCORS:   false,
+			TLSCert:      "",
+			TLSKey:       "",
+			SSEKeepAlive: "15s",
 		},
 		Gateway: GatewayConfig{
 			RequestTimeout: "30s",
@@ -160,8 +246,13 @@ func Default() *Config {
 			MaxCacheGB:    50,
 		},
 		Engine: EngineConfig{
-			AutoStart:  true,
-			OllamaAddr: "localhost:11434",
+			AutoStart:            true,
+			OllamaAddr:           "localhost:11434",
+			GPUMemoryUtilization: 0.75,
+			PortMin:              8000,
+			PortMax:              8999,
+			DrainTimeout:         "30s",
+			HealthCheckInterval:  "30s",
 		},
 		Workflow: WorkflowConfig{
 			MaxConcurrentSteps: 10,
@@ -200,6 +291,9 @@ func Default() *Config {
 			Host:    "",    // auto-detect from DOCKER_HOST env or platform default
 			Timeout: "30s",
 		},
+		Billing: BillingConfig{
+			MaxRecords: 100000,
+		},
 	}
 }
 
@@ -233,6 +327,18 @@ func (c *Config) postProcess() error {
 		return fmt.Errorf("parse gateway.request_timeout: %w", err)
 	}
 
+	if c.API.SSEKeepAliveD, err = time.ParseDuration(c.API.SSEKeepAlive); err != nil {
+		return fmt.Errorf("parse api.sse_keepalive: %w", err)
+	}
+
+	if c.Engine.DrainTimeoutD, err = time.ParseDuration(c.Engine.DrainTimeout); err != nil {
+		return fmt.Errorf("parse engine.drain_timeout: %w", err)
+	}
+
+	if c.Engine.HealthCheckIntervalD, err = time.ParseDuration(c.Engine.HealthCheckInterval); err != nil {
+		return fmt.Errorf("parse engine.health_check_interval: %w", err)
+	}
+
 	if c.Workflow.StepTimeoutD, err = time.ParseDuration(c.Workflow.StepTimeout); err != nil {
 		return fmt.Errorf("parse workflow.step_timeout: %w", err)
 	}
@@ -251,6 +357,11 @@ func (c *Config) postProcess() error {
 		return fmt.Errorf("expand model.storage_dir: %w", err)
 	}
 
+	c.Storage.ModelsDir, err = expandPath(c.Storage.ModelsDir)
+	if err != nil {
+		return fmt.Errorf("expand storage.models_dir: %w", err)
+	}
+
 	c.Logging.File, err = expandPath(c.Logging.File)
 	if err != nil {
 		return fmt.Errorf("expand logging.file: %w", err)
@@ -292,14 +403,65 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("pressure_threshold must be between 0 and 1, got %.2f", c.Resource.PressureThreshold)
 	}
 
+	if c.Engine.GPUMemoryUtilization <= 0 || c.Engine.GPUMemoryUtilization > 1 {
+		return fmt.Errorf("gpu_memory_utilization must be in (0, 1], got %.2f", c.Engine.GPUMemoryUtilization)
+	}
+
+	if c.Engine.PortMin < 1 || c.Engine.PortMax > 65535 || c.Engine.PortMin > c.Engine.PortMax {
+		return fmt.Errorf("engine port range must be within 1-65535 with port_min <= port_max, got %d-%d", c.Engine.PortMin, c.Engine.PortMax)
+	}
+
+	if c.Engine.DrainTimeoutD < 0 {
+		return fmt.Errorf("engine drain_timeout cannot be negative, got %s", c.Engine.DrainTimeout)
+	}
+
+	if c.Engine.HealthCheckIntervalD < 0 {
+		return fmt.Errorf("engine health_check_interval cannot be negative, got %s", c.Engine.HealthCheckInterval)
+	}
+
 	if c.Workflow.MaxConcurrentSteps < 1 {
 		return fmt.Errorf("max_concurrent_steps must be at least 1, got %d", c.Workflow.MaxConcurrentSteps)
 	}
 
+	for domain, limit := range c.Gateway.DomainConcurrency {
+		if limit < 0 {
+			return fmt.Errorf("domain_concurrency for %s cannot be negative, got %d", domain, limit)
+		}
+	}
+
+	if c.Billing.DefaultPrice.PromptPerMillion < 0 || c.Billing.DefaultPrice.CompletionPerMillion < 0 {
+		return fmt.Errorf("billing default_price cannot be negative")
+	}
+	for model, price := range c.Billing.Prices {
+		if price.PromptPerMillion < 0 || price.CompletionPerMillion < 0 {
+			return fmt.Errorf("billing price for %s cannot be negative", model)
+		}
+	}
+	if c.Billing.MaxRecords < 0 {
+		return fmt.Errorf("billing max_records cannot be negative, got %d", c.Billing.MaxRecords)
+	}
+
+	if c.Inference.MaxTokens < 0 {
+		return fmt.Errorf("inference max_tokens cannot be negative, got %d", c.Inference.MaxTokens)
+	}
+	for model, limit := range c.Inference.ModelMaxTokens {
+		if limit < 0 {
+			return fmt.Errorf("inference max_tokens for %s cannot be negative, got %d", model, limit)
+		}
+	}
+
+	if strings.ContainsAny(c.Docker.ModelCacheVolume, "/:") {
+		return fmt.Errorf("docker model_cache_volume must be a volume name, not a path: %s", c.Docker.ModelCacheVolume)
+	}
+
 	if c.Security.RateLimitPerMin < 0 {
 		return fmt.Errorf("rate_limit_per_min cannot be negative, got %d", c.Security.RateLimitPerMin)
 	}
 
+	if c.API.AllowCredentials && !hasExplicitOrigins(c.API.AllowedOrigins) {
+		return fmt.Errorf("api allow_credentials requires allowed_origins to list explicit origins, not \"*\"")
+	}
+
 	validLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
 	if !validLevels[strings.ToLower(c.Logging.Level)] {
 		return fmt.Errorf("invalid logging level: %s (valid: debug, info, warn, error)", c.Logging.Level)
@@ -313,6 +475,17 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// hasExplicitOrigins reports whether origins is non-empty and does not allow
+// every origin with "*".
+func hasExplicitOrigins(origins []string) bool {
+	for _, o := range origins {
+		if o == "*" {
+			return false
+		}
+	}
+	return len(origins) > 0
+}
+
 func ApplyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("AIMA_DATA_DIR"); v != "" {
 		cfg.General.DataDir = v
@@ -332,6 +505,16 @@ func ApplyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("AIMA_API_TLS_KEY"); v != "" {
 		cfg.API.TLSKey = v
 	}
+	if v := os.Getenv("AIMA_API_ALLOWED_ORIGINS"); v != "" {
+		// Comma-separated list of origins.
+		var origins []string
+		for _, p := range strings.Split(v, ",") {
+			if o := strings.TrimSpace(p); o != "" {
+				origins = append(origins, o)
+			}
+		}
+		cfg.API.AllowedOrigins = origins
+	}
 	if v := os.Getenv("AIMA_API_KEY"); v != "" {
 		cfg.Security.APIKey = v
 	}
@@ -360,6 +543,9 @@ func ApplyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("AIMA_MODEL_STORAGE_DIR"); v != "" {
 		cfg.Model.StorageDir = v
 	}
+	if v := os.Getenv("AIMA_MODELS_DIR"); v != "" {
+		cfg.Storage.ModelsDir = v
+	}
 	if v := os.Getenv("AIMA_REMOTE_ENABLED"); v != "" {
 		cfg.Remote.Enabled = strings.ToLower(v) == "true" || v == "1"
 	}
@@ -402,6 +588,21 @@ func ApplyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("AIMA_DOCKER_TIMEOUT"); v != "" {
 		cfg.Docker.Timeout = v
 	}
+	if v := os.Getenv("AIMA_DOCKER_CLI_FALLBACK"); v != "" {
+		cfg.Docker.CLIFallback = strings.ToLower(v) == "true" || v == "1"
+	}
+	if v := os.Getenv("AIMA_MODEL_CACHE"); v != "" {
+		cfg.Docker.ModelCacheVolume = v
+	}
+}
+
+// ModelsDir returns the directory models are stored in: storage.models_dir
+// when set, model.storage_dir otherwise.
+func (c *Config) ModelsDir() string {
+	if c.Storage.ModelsDir != "" {
+		return c.Storage.ModelsDir
+	}
+	return c.Model.StorageDir
 }
 
 func expandPath(path string) (string, error) {
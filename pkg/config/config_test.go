@@ -148,6 +148,21 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid gpu_memory_utilization",
+			modify: func(c *Config) {
+				c.Engine.GPUMemoryUtilization = 1.2
+			},
+			wantErr: true,
+		},
+		{
+			name: "inverted engine port range",
+			modify: func(c *Config) {
+				c.Engine.PortMin = 9000
+				c.Engine.PortMax = 8000
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid max_concurrent_steps",
 			modify: func(c *Config) {
@@ -169,6 +184,27 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative domain concurrency",
+			modify: func(c *Config) {
+				c.Gateway.DomainConcurrency = map[string]int{"inference": -1}
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative billing price",
+			modify: func(c *Config) {
+				c.Billing.Prices = map[string]ModelPrice{"llama3": {PromptPerMillion: -1}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative billing max records",
+			modify: func(c *Config) {
+				c.Billing.MaxRecords = -1
+			},
+			wantErr: true,
+		},
 		{
 			name: "negative rate limit",
 			modify: func(c *Config) {
@@ -176,6 +212,30 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "cors credentials without origins",
+			modify: func(c *Config) {
+				c.API.EnableCORS = true
+				c.API.AllowCredentials = true
+			},
+			wantErr: true,
+		},
+		{
+			name: "cors credentials with wildcard origin",
+			modify: func(c *Config) {
+				c.API.AllowedOrigins = []string{"https://ui.example.com", "*"}
+				c.API.AllowCredentials = true
+			},
+			wantErr: true,
+		},
+		{
+			name: "cors credentials with explicit origins",
+			modify: func(c *Config) {
+				c.API.AllowedOrigins = []string{"https://ui.example.com"}
+				c.API.AllowCredentials = true
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -456,6 +516,56 @@ api_keys = ["shared-key", "another-key"]
 	}
 }
 
+func TestApplyEnvOverrides_DockerCLIFallback(t *testing.T) {
+	cfg := Default()
+	if cfg.Docker.CLIFallback {
+		t.Fatal("Docker.CLIFallback should be off by default")
+	}
+
+	t.Setenv("AIMA_DOCKER_CLI_FALLBACK", "true")
+	ApplyEnvOverrides(cfg)
+
+	if !cfg.Docker.CLIFallback {
+		t.Error("Docker.CLIFallback should be true after AIMA_DOCKER_CLI_FALLBACK=true")
+	}
+}
+
+func TestApplyEnvOverrides_ModelCacheVolume(t *testing.T) {
+	cfg := Default()
+	if cfg.Docker.ModelCacheVolume != "" {
+		t.Fatal("Docker.ModelCacheVolume should be empty by default")
+	}
+
+	t.Setenv("AIMA_MODEL_CACHE", "aima-hf-cache")
+	ApplyEnvOverrides(cfg)
+
+	if cfg.Docker.ModelCacheVolume != "aima-hf-cache" {
+		t.Errorf("Docker.ModelCacheVolume = %q, want aima-hf-cache", cfg.Docker.ModelCacheVolume)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+
+	cfg.Docker.ModelCacheVolume = "/var/cache/hf"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject a host path as model_cache_volume")
+	}
+}
+
+func TestConfig_ModelsDir(t *testing.T) {
+	cfg := Default()
+	if cfg.ModelsDir() != cfg.Model.StorageDir {
+		t.Errorf("ModelsDir() = %q, want model.storage_dir %q", cfg.ModelsDir(), cfg.Model.StorageDir)
+	}
+
+	t.Setenv("AIMA_MODELS_DIR", "/srv/aima/models")
+	ApplyEnvOverrides(cfg)
+
+	if cfg.ModelsDir() != "/srv/aima/models" {
+		t.Errorf("ModelsDir() = %q, want /srv/aima/models", cfg.ModelsDir())
+	}
+}
+
 func TestApplyEnvOverrides_Auth(t *testing.T) {
 	cfg := Default()
 
@@ -479,6 +589,25 @@ func TestApplyEnvOverrides_Auth(t *testing.T) {
 	}
 }
 
+func TestApplyEnvOverrides_AllowedOrigins(t *testing.T) {
+	cfg := Default()
+
+	_ = os.Setenv("AIMA_API_ALLOWED_ORIGINS", "https://ui.example.com, *.example.com,")
+	defer func() { _ = os.Unsetenv("AIMA_API_ALLOWED_ORIGINS") }()
+
+	ApplyEnvOverrides(cfg)
+
+	want := []string{"https://ui.example.com", "*.example.com"}
+	if len(cfg.API.AllowedOrigins) != len(want) {
+		t.Fatalf("API.AllowedOrigins = %v, want %v", cfg.API.AllowedOrigins, want)
+	}
+	for i := range want {
+		if cfg.API.AllowedOrigins[i] != want[i] {
+			t.Errorf("API.AllowedOrigins[%d] = %q, want %q", i, cfg.API.AllowedOrigins[i], want[i])
+		}
+	}
+}
+
 func TestApplyEnvOverrides_AuthDisabled(t *testing.T) {
 	cfg := Default()
 	cfg.Auth.Enabled = true // start enabled
@@ -495,8 +624,22 @@ func TestApplyEnvOverrides_AuthDisabled(t *testing.T) {
 
 func TestPostProcess_DurationParsing(t *testing.T) {
 	content := `
+[api]
+sse_keepalive = "5s"
+
 [gateway]
 request_timeout = "60s"
+queue_when_busy = true
+
+[gateway.domain_concurrency]
+inference = 2
+
+[gateway.system_prompts]
+inference = "Be concise."
+
+[engine]
+drain_timeout = "45s"
+health_check_interval = "15s"
 
 [workflow]
 step_timeout = "10m"
@@ -520,9 +663,24 @@ check_interval = "30s"
 		t.Fatalf("LoadFromFile: %v", err)
 	}
 
+	if cfg.API.SSEKeepAliveD.Seconds() != 5 {
+		t.Errorf("API.SSEKeepAliveD = %v, want 5s", cfg.API.SSEKeepAliveD)
+	}
 	if cfg.Gateway.RequestTimeoutD.Seconds() != 60 {
 		t.Errorf("Gateway.RequestTimeoutD = %v, want 60s", cfg.Gateway.RequestTimeoutD)
 	}
+	if cfg.Gateway.DomainConcurrency["inference"] != 2 || !cfg.Gateway.QueueWhenBusy {
+		t.Errorf("Gateway concurrency = %v queue=%v, want inference=2 queue=true", cfg.Gateway.DomainConcurrency, cfg.Gateway.QueueWhenBusy)
+	}
+	if cfg.Gateway.SystemPrompts["inference"] != "Be concise." {
+		t.Errorf("Gateway.SystemPrompts = %v, want inference prompt", cfg.Gateway.SystemPrompts)
+	}
+	if cfg.Engine.DrainTimeoutD.Seconds() != 45 {
+		t.Errorf("Engine.DrainTimeoutD = %v, want 45s", cfg.Engine.DrainTimeoutD)
+	}
+	if cfg.Engine.HealthCheckIntervalD.Seconds() != 15 {
+		t.Errorf("Engine.HealthCheckIntervalD = %v, want 15s", cfg.Engine.HealthCheckIntervalD)
+	}
 	if cfg.Workflow.StepTimeoutD.Minutes() != 10 {
 		t.Errorf("Workflow.StepTimeoutD = %v, want 10m", cfg.Workflow.StepTimeoutD)
 	}
@@ -530,3 +688,80 @@ check_interval = "30s"
 		t.Errorf("Alert.CheckIntervalD = %v, want 30s", cfg.Alert.CheckIntervalD)
 	}
 }
+
+func TestLoadFromFile_BillingPrices(t *testing.T) {
+	content := `
+[billing]
+max_records = 500
+
+[billing.default_price]
+prompt_per_million = 1.5
+
+[billing.prices]
+"llama3" = { prompt_per_million = 0.2, completion_per_million = 0.4 }
+`
+	tmpFile, err := os.CreateTemp("", "config-*.toml")
+	if err != nil {
+		t.Fatalf("create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	cfg, err := LoadFromFile(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+
+	if cfg.Billing.DefaultPrice.PromptPerMillion != 1.5 {
+		t.Errorf("Billing.DefaultPrice = %+v, want prompt 1.5", cfg.Billing.DefaultPrice)
+	}
+	if cfg.Billing.MaxRecords != 500 {
+		t.Errorf("Billing.MaxRecords = %d, want 500", cfg.Billing.MaxRecords)
+	}
+	want := ModelPrice{PromptPerMillion: 0.2, CompletionPerMillion: 0.4}
+	if cfg.Billing.Prices["llama3"] != want {
+		t.Errorf("Billing.Prices[llama3] = %+v, want %+v", cfg.Billing.Prices["llama3"], want)
+	}
+}
+
+func TestLoadFromFile_InferenceMaxTokens(t *testing.T) {
+	content := `
+[inference]
+max_tokens = 4096
+trim_stop_sequences = true
+
+[inference.model_max_tokens]
+"llama3:70b" = 1024
+`
+	tmpFile, err := os.CreateTemp("", "config-*.toml")
+	if err != nil {
+		t.Fatalf("create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	cfg, err := LoadFromFile(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+
+	if cfg.Inference.MaxTokens != 4096 || cfg.Inference.ModelMaxTokens["llama3:70b"] != 1024 {
+		t.Errorf("Inference = %+v, want max_tokens 4096 and llama3:70b 1024", cfg.Inference)
+	}
+	if !cfg.Inference.TrimStopSequences {
+		t.Error("expected trim_stop_sequences to be loaded")
+	}
+
+	cfg.Inference.ModelMaxTokens["llama3"] = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected a negative max_tokens cap to fail validation")
+	}
+}
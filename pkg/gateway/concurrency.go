@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// ConcurrencyLimits caps how many executions of each unit domain may run at
+// once, so expensive GPU-bound units cannot starve cheap queries.
+type ConcurrencyLimits struct {
+	// PerDomain maps a domain (e.g. "inference") to its maximum number of
+	// concurrent executions. Domains not listed, or with a limit <= 0, are
+	// unlimited.
+	PerDomain map[string]int
+	// Queue makes requests over the limit wait for a free slot until their
+	// context ends. When false they are rejected with ErrCodeOverloaded.
+	Queue bool
+}
+
+// domainLimiter enforces ConcurrencyLimits with one semaphore per domain.
+type domainLimiter struct {
+	slots map[string]chan struct{}
+	queue bool
+}
+
+func newDomainLimiter(limits ConcurrencyLimits) *domainLimiter {
+	l := &domainLimiter{
+		slots: make(map[string]chan struct{}),
+		queue: limits.Queue,
+	}
+	for domain, limit := range limits.PerDomain {
+		if limit > 0 {
+			l.slots[domain] = make(chan struct{}, limit)
+		}
+	}
+	return l
+}
+
+// acquire reserves an execution slot for domain and returns the function
+// that releases it. A nil limiter or an unlimited domain always succeeds.
+func (l *domainLimiter) acquire(ctx context.Context, domain string) (func(), *ErrorInfo) {
+	if l == nil {
+		return func() {}, nil
+	}
+	sem, ok := l.slots[domain]
+	if !ok {
+		return func() {}, nil
+	}
+	release := func() { <-sem }
+
+	select {
+	case sem <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	if !l.queue {
+		return nil, NewErrorInfo(ErrCodeOverloaded,
+			fmt.Sprintf("domain %s is at its concurrency limit of %d", domain, cap(sem)))
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, NewErrorInfo(ErrCodeOverloaded,
+			fmt.Sprintf("timed out waiting for a %s execution slot: %v", domain, ctx.Err()))
+	}
+}
+
+// unitDomain returns the domain part of a unit name such as
+// "inference.chat" or "inference.chat@v2".
+func unitDomain(name string) string {
+	name, _ = unit.SplitVersion(name)
+	domain, _, _ := strings.Cut(name, ".")
+	return domain
+}
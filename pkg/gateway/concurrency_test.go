@@ -0,0 +1,142 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// newSaturatedGateway returns a gateway whose inference domain allows one
+// execution, already occupied by a blocked inference.generate_image call.
+// Closing the returned channel lets the blocked call finish.
+func newSaturatedGateway(t *testing.T, queue bool) (*Gateway, chan struct{}, *sync.WaitGroup) {
+	t.Helper()
+
+	started := make(chan struct{}, 1)
+	unblock := make(chan struct{})
+
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(&mockCommand{
+		name:   "inference.generate_image",
+		domain: "inference",
+		execute: func(ctx context.Context, input any) (any, error) {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-unblock
+			return map[string]any{"ok": true}, nil
+		},
+	})
+	_ = registry.RegisterQuery(&mockQuery{name: "model.list", domain: "model"})
+
+	g := NewGateway(registry, WithConcurrencyLimits(ConcurrencyLimits{
+		PerDomain: map[string]int{"inference": 1},
+		Queue:     queue,
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if resp := g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "inference.generate_image"}); !resp.Success {
+			t.Errorf("first generate_image failed: %+v", resp.Error)
+		}
+	}()
+	<-started
+
+	return g, unblock, &wg
+}
+
+func TestGateway_ConcurrencyLimit_RejectsExcess(t *testing.T) {
+	g, unblock, wg := newSaturatedGateway(t, false)
+
+	resp := g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "inference.generate_image"})
+	if resp.Success {
+		t.Fatal("expected excess inference request to be rejected")
+	}
+	if resp.Error.Code != ErrCodeOverloaded {
+		t.Errorf("expected error code %q, got %q", ErrCodeOverloaded, resp.Error.Code)
+	}
+	if status := ErrorCodeToHTTPStatus(resp.Error.Code); status != 503 {
+		t.Errorf("expected HTTP 503 for overloaded, got %d", status)
+	}
+
+	// Other domains are unaffected by the saturated inference semaphore.
+	if resp := g.Handle(context.Background(), &Request{Type: TypeQuery, Unit: "model.list"}); !resp.Success {
+		t.Errorf("model.list should not be limited: %+v", resp.Error)
+	}
+
+	close(unblock)
+	wg.Wait()
+
+	// The slot is released once the running execution finishes.
+	if resp := g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "inference.generate_image"}); !resp.Success {
+		t.Errorf("expected request to succeed after slot release: %+v", resp.Error)
+	}
+}
+
+func TestGateway_ConcurrencyLimit_QueuesExcess(t *testing.T) {
+	g, unblock, wg := newSaturatedGateway(t, true)
+
+	done := make(chan *Response, 1)
+	go func() {
+		done <- g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "inference.generate_image"})
+	}()
+
+	select {
+	case resp := <-done:
+		t.Fatalf("queued request returned before a slot was free: %+v", resp)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if resp := g.Handle(context.Background(), &Request{Type: TypeQuery, Unit: "model.list"}); !resp.Success {
+		t.Errorf("model.list should not be limited: %+v", resp.Error)
+	}
+
+	close(unblock)
+	wg.Wait()
+
+	select {
+	case resp := <-done:
+		if !resp.Success {
+			t.Errorf("queued request failed: %+v", resp.Error)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued request did not run after the slot was released")
+	}
+}
+
+func TestGateway_ConcurrencyLimit_QueueTimesOut(t *testing.T) {
+	g, unblock, wg := newSaturatedGateway(t, true)
+	defer func() {
+		close(unblock)
+		wg.Wait()
+	}()
+
+	resp := g.Handle(context.Background(), &Request{
+		Type:    TypeCommand,
+		Unit:    "inference.generate_image",
+		Options: RequestOptions{Timeout: 20 * time.Millisecond},
+	})
+	if resp.Success || resp.Error.Code != ErrCodeOverloaded {
+		t.Errorf("expected overloaded after queue timeout, got %+v", resp)
+	}
+}
+
+func TestUnitDomain(t *testing.T) {
+	tests := map[string]string{
+		"inference.chat":    "inference",
+		"inference.chat@v2": "inference",
+		"model.list":        "model",
+		"standalone":        "standalone",
+	}
+	for name, want := range tests {
+		if got := unitDomain(name); got != want {
+			t.Errorf("unitDomain(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// PrincipalFromContext returns the authenticated caller of the request a unit
+// is executing for, as set by the auth middleware, e.g. "apikey:3f9a2c1b7d0e".
+// It reports false for unauthenticated requests.
+func PrincipalFromContext(ctx context.Context) (string, bool) {
+	principal := unit.GetPrincipal(ctx)
+	return principal, principal != ""
+}
+
+// CorrelationIDFromContext returns the ID correlating everything done for a
+// request: the trace ID the caller sent in X-Trace-ID or that Handle
+// generated, which is also returned as meta.trace_id. It reports false
+// outside a gateway request.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id := unit.GetTraceID(ctx)
+	return id, id != ""
+}
@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/gateway/middleware"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// whoamiCommand reports the principal and correlation ID it sees in ctx.
+func whoamiCommand() *mockCommand {
+	return &mockCommand{
+		name:   "test.whoami",
+		domain: "test",
+		execute: func(ctx context.Context, input any) (any, error) {
+			principal, hasPrincipal := PrincipalFromContext(ctx)
+			correlationID, hasCorrelationID := CorrelationIDFromContext(ctx)
+			return map[string]any{
+				"principal":          principal,
+				"has_principal":      hasPrincipal,
+				"correlation_id":     correlationID,
+				"has_correlation_id": hasCorrelationID,
+			}, nil
+		},
+	}
+}
+
+func TestContextValues_ThroughAuthMiddleware(t *testing.T) {
+	reg := unit.NewRegistry()
+	if err := reg.RegisterCommand(whoamiCommand()); err != nil {
+		t.Fatalf("register command: %v", err)
+	}
+	handler := middleware.Auth(middleware.AuthConfig{Enabled: true, APIKeys: []string{"secret"}})(NewHTTPAdapter(NewGateway(reg)))
+
+	body := `{"type": "command", "unit": "test.whoami", "input": {}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set(HeaderTraceID, "trc_from_client")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	if resp.Data["has_principal"] != true || !strings.HasPrefix(resp.Data["principal"].(string), "apikey:") {
+		t.Errorf("expected the API key principal, got %v", resp.Data)
+	}
+	if resp.Data["has_correlation_id"] != true || resp.Data["correlation_id"] != "trc_from_client" {
+		t.Errorf("expected the client's trace ID as correlation ID, got %v", resp.Data)
+	}
+}
+
+func TestContextValues_Unauthenticated(t *testing.T) {
+	reg := unit.NewRegistry()
+	if err := reg.RegisterCommand(whoamiCommand()); err != nil {
+		t.Fatalf("register command: %v", err)
+	}
+
+	resp := NewGateway(reg).Handle(context.Background(), &Request{Type: TypeCommand, Unit: "test.whoami"})
+	if !resp.Success {
+		t.Fatalf("expected success, got %+v", resp.Error)
+	}
+	data := resp.Data.(map[string]any)
+
+	if data["has_principal"] != false {
+		t.Errorf("expected no principal without auth, got %v", data["principal"])
+	}
+	// Handle generates a correlation ID when the caller sends none.
+	if data["has_correlation_id"] != true || data["correlation_id"] != resp.Meta.TraceID {
+		t.Errorf("expected correlation ID %q, got %v", resp.Meta.TraceID, data["correlation_id"])
+	}
+}
+
+func TestHandle_ProviderOption(t *testing.T) {
+	reg := unit.NewRegistry()
+	var got string
+	_ = reg.RegisterCommand(&mockCommand{
+		name:   "test.provider",
+		domain: "test",
+		execute: func(ctx context.Context, input any) (any, error) {
+			got = unit.GetProvider(ctx)
+			return nil, nil
+		},
+	})
+	gw := NewGateway(reg)
+
+	resp := gw.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "test.provider", Options: RequestOptions{Provider: "openai"}})
+	if !resp.Success {
+		t.Fatalf("expected success, got %+v", resp.Error)
+	}
+	if got != "openai" {
+		t.Errorf("expected the provider option in the unit's context, got %q", got)
+	}
+
+	gw.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "test.provider"})
+	if got != "" {
+		t.Errorf("expected no provider without the option, got %q", got)
+	}
+}
@@ -19,6 +19,8 @@ const (
 	ErrCodeUnauthorized     = "UNAUTHORIZED"
 	ErrCodeRateLimited      = "RATE_LIMITED"
 	ErrCodeInternalError    = "INTERNAL_ERROR"
+	// ErrCodeOverloaded means the unit's domain is at its concurrency limit.
+	ErrCodeOverloaded = "overloaded"
 )
 
 type ErrorInfo struct {
@@ -116,6 +118,8 @@ func ErrorCodeToHTTPStatus(code string) int {
 		return http.StatusUnauthorized
 	case ErrCodeRateLimited:
 		return http.StatusTooManyRequests
+	case ErrCodeOverloaded:
+		return http.StatusServiceUnavailable
 	case ErrCodeInternalError:
 		return http.StatusInternalServerError
 	default:
@@ -0,0 +1,136 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// queryInputPrefix marks the query parameters of GET /api/v2/execute that
+// make up the unit input, e.g. input.limit=10 or input.filter.type=llm.
+const queryInputPrefix = "input."
+
+// serveQueryString executes a query given as GET /api/v2/execute query
+// parameters, so read-only queries can be tried from a browser. Only
+// queries are accepted; commands change state and must be POSTed.
+func (a *HTTPAdapter) serveQueryString(w http.ResponseWriter, r *http.Request) {
+	req, status, err := requestFromQuery(a.gateway.Registry(), r.URL.Query())
+	if err != nil {
+		writeJSONError(w, status, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+	if traceID := r.Header.Get(HeaderTraceID); traceID != "" {
+		req.Options.TraceID = traceID
+	}
+	a.writeResponse(w, a.gateway.Handle(r.Context(), req))
+}
+
+// requestFromQuery builds a query Request from URL query parameters: type
+// (which defaults to, and must be, "query"), unit, and input.* keys, dotted
+// for nested objects. Values are converted to the number, boolean or array
+// types the unit's input schema declares; repeating a key gives an array.
+// The status returned with an error is the HTTP status to answer with.
+func requestFromQuery(registry *unit.Registry, values url.Values) (*Request, int, error) {
+	req := &Request{Type: TypeQuery, Unit: values.Get("unit"), Input: map[string]any{}}
+	if t := values.Get("type"); t != "" && t != TypeQuery {
+		return nil, http.StatusMethodNotAllowed, fmt.Errorf("only queries can be executed with GET, got type %q", t)
+	}
+	if req.Unit == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("unit is required")
+	}
+
+	var schema unit.Schema
+	if registry != nil {
+		schema, _ = inputSchema(registry, req)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "type" || key == "unit" {
+			continue
+		}
+		path, ok := strings.CutPrefix(key, queryInputPrefix)
+		if !ok || path == "" {
+			return nil, http.StatusBadRequest, fmt.Errorf("unknown query parameter %q", key)
+		}
+		if err := setQueryInput(req.Input, schema, strings.Split(path, "."), values[key]); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return req, http.StatusOK, nil
+}
+
+// setQueryInput stores raw under the dotted path in input, creating nested
+// objects as needed and converting it to the type schema gives the field.
+func setQueryInput(input map[string]any, schema unit.Schema, path []string, raw []string) error {
+	field := schema.Properties[path[0]].Schema
+	if len(path) > 1 {
+		nested, ok := input[path[0]].(map[string]any)
+		if !ok {
+			if _, exists := input[path[0]]; exists {
+				return fmt.Errorf("%s is both a value and an object", path[0])
+			}
+			nested = map[string]any{}
+			input[path[0]] = nested
+		}
+		return setQueryInput(nested, field, path[1:], raw)
+	}
+	if _, exists := input[path[0]]; exists {
+		return fmt.Errorf("%s is both a value and an object", path[0])
+	}
+
+	if field.Type == "array" || len(raw) > 1 {
+		var items unit.Schema
+		if field.Items != nil {
+			items = *field.Items
+		}
+		list := make([]any, 0, len(raw))
+		for _, s := range raw {
+			v, err := queryValue(items.Type, s)
+			if err != nil {
+				return err
+			}
+			list = append(list, v)
+		}
+		input[path[0]] = list
+		return nil
+	}
+
+	v, err := queryValue(field.Type, raw[0])
+	if err != nil {
+		return err
+	}
+	input[path[0]] = v
+	return nil
+}
+
+// queryValue converts s to the JSON schema type typ, leaving it a string for
+// string and unknown types.
+func queryValue(typ, s string) (any, error) {
+	switch typ {
+	case "number", "integer":
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", s)
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean, got %q", s)
+		}
+		return b, nil
+	default:
+		return s, nil
+	}
+}
@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+func newExecuteQueryAdapter(t *testing.T) *HTTPAdapter {
+	t.Helper()
+	store := model.NewMemoryStore()
+	for _, m := range []*model.Model{
+		{ID: "m1", Name: "llama3", Type: model.ModelTypeLLM},
+		{ID: "m2", Name: "qwen2.5", Type: model.ModelTypeLLM},
+		{ID: "m3", Name: "whisper", Type: model.ModelTypeASR},
+	} {
+		if err := store.Create(context.Background(), m); err != nil {
+			t.Fatalf("create model: %v", err)
+		}
+	}
+
+	reg := unit.NewRegistry()
+	if err := reg.RegisterQuery(model.NewListQuery(store)); err != nil {
+		t.Fatalf("register query: %v", err)
+	}
+	if err := reg.RegisterCommand(&mockCommand{name: "model.delete", domain: "model"}); err != nil {
+		t.Fatalf("register command: %v", err)
+	}
+	return NewHTTPAdapter(NewGateway(reg))
+}
+
+func TestHTTPAdapter_GetQuery(t *testing.T) {
+	adapter := newExecuteQueryAdapter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/execute?type=query&unit=model.list&input.type=llm&input.limit=1", nil)
+	rec := httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			Items []map[string]any `json:"items"`
+			Total int              `json:"total"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !resp.Success || resp.Data.Total != 2 {
+		t.Fatalf("expected the two LLMs to match, got %s", rec.Body.String())
+	}
+	if len(resp.Data.Items) != 1 {
+		t.Errorf("expected input.limit to be applied as a number, got %d items", len(resp.Data.Items))
+	}
+}
+
+func TestHTTPAdapter_GetRejectsCommands(t *testing.T) {
+	adapter := newExecuteQueryAdapter(t)
+
+	for _, typ := range []string{TypeCommand, TypeResource, TypeWorkflow} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2/execute?type="+typ+"&unit=model.delete&input.model_id=m1", nil)
+		rec := httptest.NewRecorder()
+		adapter.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("type %s: expected 405, got %d: %s", typ, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestRequestFromQuery(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterQuery(model.NewListQuery(model.NewMemoryStore()))
+
+	tests := []struct {
+		name   string
+		query  string
+		want   map[string]any
+		status int
+	}{
+		{
+			name:  "schema types",
+			query: "unit=model.list&input.limit=10&input.type=llm",
+			want:  map[string]any{"limit": 10.0, "type": "llm"},
+		},
+		{
+			name:  "nested and repeated keys",
+			query: "unit=model.list&input.filter.tag=a&input.filter.tag=b&input.filter.name=x",
+			want:  map[string]any{"filter": map[string]any{"tag": []any{"a", "b"}, "name": "x"}},
+		},
+		{name: "missing unit", query: "type=query", status: http.StatusBadRequest},
+		{name: "command type", query: "type=command&unit=model.list", status: http.StatusMethodNotAllowed},
+		{name: "unknown parameter", query: "unit=model.list&limit=10", status: http.StatusBadRequest},
+		{name: "bad number", query: "unit=model.list&input.limit=ten", status: http.StatusBadRequest},
+		{name: "value and object", query: "unit=model.list&input.a=1&input.a.b=2", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parse query: %v", err)
+			}
+			req, status, err := requestFromQuery(reg, values)
+			if tt.status != 0 {
+				if err == nil || status != tt.status {
+					t.Errorf("expected status %d with an error, got %d, %v", tt.status, status, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if req.Type != TypeQuery || req.Unit != "model.list" {
+				t.Errorf("unexpected request: %+v", req)
+			}
+			if !reflect.DeepEqual(req.Input, tt.want) {
+				t.Errorf("input = %#v, want %#v", req.Input, tt.want)
+			}
+		})
+	}
+}
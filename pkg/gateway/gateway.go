@@ -2,6 +2,10 @@ package gateway
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
@@ -28,6 +32,11 @@ type RequestOptions struct {
 	Timeout time.Duration `json:"timeout,omitempty"`
 	Async   bool          `json:"async,omitempty"`
 	TraceID string        `json:"trace_id,omitempty"`
+	// Version selects a command version (e.g. "v1"); empty means latest.
+	Version string `json:"version,omitempty"`
+	// Provider names the inference provider to serve the request, e.g.
+	// "openai"; empty means the configured default.
+	Provider string `json:"provider,omitempty"`
 }
 
 type Response struct {
@@ -38,10 +47,11 @@ type Response struct {
 }
 
 type ResponseMeta struct {
-	RequestID  string      `json:"request_id"`
-	Duration   int64       `json:"duration_ms"`
-	TraceID    string      `json:"trace_id,omitempty"`
-	Pagination *Pagination `json:"pagination,omitempty"`
+	RequestID   string            `json:"request_id"`
+	Duration    int64             `json:"duration_ms"`
+	TraceID     string            `json:"trace_id,omitempty"`
+	Pagination  *Pagination       `json:"pagination,omitempty"`
+	Deprecation *unit.Deprecation `json:"deprecation,omitempty"`
 }
 
 type Pagination struct {
@@ -54,10 +64,38 @@ type Gateway struct {
 	registry       *unit.Registry
 	workflowEngine *workflow.WorkflowEngine
 	requestTimeout time.Duration
+	streams        *unit.StreamTracker
+	limiter        *domainLimiter
+	strictInput    bool
+	// systemPrompts maps a unit domain to the system prompt injected into
+	// its chat inputs.
+	systemPrompts map[string]string
+
+	// deprecationWarned records units whose deprecation was already logged.
+	deprecationWarned sync.Map
+
+	// handler is handle wrapped in the configured middleware.
+	handler    Handler
+	middleware []Middleware
 }
 
 type GatewayOption func(*Gateway)
 
+// Handler executes a gateway request.
+type Handler func(ctx context.Context, req *Request) *Response
+
+// Middleware wraps a Handler, e.g. to observe every request and response
+// passing through Gateway.Handle.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware wraps Gateway.Handle in mws. The first middleware is the
+// outermost one.
+func WithMiddleware(mws ...Middleware) GatewayOption {
+	return func(g *Gateway) {
+		g.middleware = append(g.middleware, mws...)
+	}
+}
+
 func WithTimeout(timeout time.Duration) GatewayOption {
 	return func(g *Gateway) {
 		g.requestTimeout = timeout
@@ -70,6 +108,31 @@ func WithWorkflowEngine(engine *workflow.WorkflowEngine) GatewayOption {
 	}
 }
 
+// WithStreamTracker shares t with the gateway so in-flight streams can be
+// cancelled by request ID from elsewhere, e.g. the inference.cancel command.
+func WithStreamTracker(t *unit.StreamTracker) GatewayOption {
+	return func(g *Gateway) {
+		g.streams = t
+	}
+}
+
+// WithConcurrencyLimits caps concurrent command and query executions per
+// unit domain.
+func WithConcurrencyLimits(limits ConcurrencyLimits) GatewayOption {
+	return func(g *Gateway) {
+		g.limiter = newDomainLimiter(limits)
+	}
+}
+
+// WithStrictInput makes the gateway reject command and query requests whose
+// input has top-level keys the unit's input schema does not declare, so typos
+// such as "promt" fail instead of being silently ignored.
+func WithStrictInput(strict bool) GatewayOption {
+	return func(g *Gateway) {
+		g.strictInput = strict
+	}
+}
+
 func NewGateway(registry *unit.Registry, opts ...GatewayOption) *Gateway {
 	if registry == nil {
 		registry = unit.NewRegistry()
@@ -84,10 +147,26 @@ func NewGateway(registry *unit.Registry, opts ...GatewayOption) *Gateway {
 		opt(g)
 	}
 
+	if g.streams == nil {
+		g.streams = unit.NewStreamTracker()
+	}
+
+	g.handler = g.handle
+	for i := len(g.middleware) - 1; i >= 0; i-- {
+		g.handler = g.middleware[i](g.handler)
+	}
+
 	return g
 }
 
 func (g *Gateway) Handle(ctx context.Context, req *Request) *Response {
+	if g.handler == nil {
+		return g.handle(ctx, req)
+	}
+	return g.handler(ctx, req)
+}
+
+func (g *Gateway) handle(ctx context.Context, req *Request) *Response {
 	start := time.Now()
 	requestID := unit.GenerateRequestID()
 
@@ -106,6 +185,20 @@ func (g *Gateway) Handle(ctx context.Context, req *Request) *Response {
 		return resp
 	}
 
+	if err := g.checkUnknownFields(req); err != nil {
+		resp.Success = false
+		resp.Error = err
+		return resp
+	}
+	req = g.injectSystemPrompt(req)
+
+	if req.Type == TypeCommand || req.Type == TypeQuery {
+		if dep := g.registry.DeprecationOf(unitRef(req)); dep != nil {
+			resp.Meta.Deprecation = dep
+			g.warnDeprecated(req.Unit, dep)
+		}
+	}
+
 	traceID := req.Options.TraceID
 	if traceID == "" {
 		traceID = unit.GenerateTraceID()
@@ -115,6 +208,9 @@ func (g *Gateway) Handle(ctx context.Context, req *Request) *Response {
 	ctx = unit.WithRequestID(ctx, requestID)
 	ctx = unit.WithTraceID(ctx, traceID)
 	ctx = unit.WithStartTime(ctx, start)
+	if req.Options.Provider != "" {
+		ctx = unit.WithProvider(ctx, req.Options.Provider)
+	}
 
 	timeout := req.Options.Timeout
 	if timeout <= 0 {
@@ -125,6 +221,16 @@ func (g *Gateway) Handle(ctx context.Context, req *Request) *Response {
 	ctx, cancel = context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	if req.Type == TypeCommand || req.Type == TypeQuery {
+		release, errInfo := g.limiter.acquire(ctx, unitDomain(req.Unit))
+		if errInfo != nil {
+			resp.Success = false
+			resp.Error = errInfo
+			return resp
+		}
+		defer release()
+	}
+
 	result, err := g.execute(ctx, req)
 	if err != nil {
 		resp.Success = false
@@ -137,6 +243,14 @@ func (g *Gateway) Handle(ctx context.Context, req *Request) *Response {
 	return resp
 }
 
+// warnDeprecated logs the first call to each deprecated unit.
+func (g *Gateway) warnDeprecated(name string, dep *unit.Deprecation) {
+	if _, loaded := g.deprecationWarned.LoadOrStore(name, struct{}{}); loaded {
+		return
+	}
+	slog.Warn("deprecated unit called", "unit", name, "replaced_by", dep.ReplacedBy, "message", dep.Message)
+}
+
 func (g *Gateway) validateRequest(req *Request) *ErrorInfo {
 	if req == nil {
 		return NewErrorInfo(ErrCodeInvalidRequest, "request is nil")
@@ -170,10 +284,69 @@ func (g *Gateway) execute(ctx context.Context, req *Request) (any, error) {
 	}
 }
 
+// checkUnknownFields rejects input keys missing from the unit's input schema
+// when strict input is enabled. Unknown units are left for execute to report.
+func (g *Gateway) checkUnknownFields(req *Request) *ErrorInfo {
+	if !g.strictInput || len(req.Input) == 0 {
+		return nil
+	}
+
+	schema, ok := inputSchema(g.registry, req)
+	if !ok {
+		return nil
+	}
+
+	unknown := schema.UnknownFields(req.Input)
+	if len(unknown) == 0 {
+		return nil
+	}
+	return NewValidationError("unknown input fields: "+strings.Join(unknown, ", "),
+		map[string]any{"unknown_fields": unknown})
+}
+
+// inputSchema returns the input schema of the command or query req targets.
+// It reports false for other request types and unknown units.
+func inputSchema(registry *unit.Registry, req *Request) (unit.Schema, bool) {
+	switch req.Type {
+	case TypeCommand:
+		if cmd := registry.GetCommand(unitRef(req)); cmd != nil {
+			return cmd.InputSchema(), true
+		}
+	case TypeQuery:
+		if q := registry.GetQuery(req.Unit); q != nil {
+			return q.InputSchema(), true
+		}
+	}
+	return unit.Schema{}, false
+}
+
+// redactInput returns req's input with the fields its unit's schema marks
+// Sensitive replaced, for logging and recording.
+func redactInput(registry *unit.Registry, req *Request) map[string]any {
+	if registry == nil {
+		return req.Input
+	}
+	schema, ok := inputSchema(registry, req)
+	if !ok {
+		return req.Input
+	}
+	return schema.RedactSensitive(req.Input)
+}
+
+// unitRef returns the registry reference for req, appending the requested
+// version as "name@version" when one is set.
+func unitRef(req *Request) string {
+	if req.Options.Version == "" {
+		return req.Unit
+	}
+	name, _ := unit.SplitVersion(req.Unit)
+	return name + "@" + req.Options.Version
+}
+
 func (g *Gateway) executeCommand(ctx context.Context, req *Request) (any, error) {
-	cmd := g.registry.GetCommand(req.Unit)
+	cmd := g.registry.GetCommand(unitRef(req))
 	if cmd == nil {
-		return nil, NewErrorInfo(ErrCodeUnitNotFound, "command not found: "+req.Unit)
+		return nil, NewErrorInfo(ErrCodeUnitNotFound, "command not found: "+unitRef(req))
 	}
 
 	result, err := cmd.Execute(ctx, req.Input)
@@ -202,6 +375,15 @@ func (g *Gateway) executeResource(ctx context.Context, req *Request) (any, error
 	// First try direct lookup
 	res := g.registry.GetResource(req.Unit)
 	if res == nil {
+		// Then templated URIs like asms://model/{id}
+		if handler, params, ok := g.registry.MatchResourceTemplate(req.Unit); ok {
+			result, err := handler(ctx, params)
+			if err != nil {
+				return nil, NewErrorInfoWithDetails(ErrCodeExecutionFailed, "resource get failed", err.Error())
+			}
+			return result, nil
+		}
+
 		// Try with factory (for dynamic URIs like asms://model/{id})
 		res = g.registry.GetResourceWithFactory(req.Unit)
 	}
@@ -217,6 +399,24 @@ func (g *Gateway) executeResource(ctx context.Context, req *Request) (any, error
 	return result, nil
 }
 
+// WatchResource resolves uri to a Resource and returns its update stream,
+// e.g. the live events of asms://events/{correlation_id}.
+func (g *Gateway) WatchResource(ctx context.Context, uri string) (<-chan unit.ResourceUpdate, error) {
+	res := g.registry.GetResource(uri)
+	if res == nil {
+		res = g.registry.GetResourceWithFactory(uri)
+	}
+	if res == nil {
+		return nil, NewErrorInfo(ErrCodeResourceNotFound, "resource not found: "+uri)
+	}
+
+	updates, err := res.Watch(ctx)
+	if err != nil {
+		return nil, NewErrorInfoWithDetails(ErrCodeExecutionFailed, "resource watch failed", err.Error())
+	}
+	return updates, nil
+}
+
 func (g *Gateway) executeWorkflow(ctx context.Context, req *Request) (any, error) {
 	if g.workflowEngine == nil {
 		return nil, NewErrorInfo(ErrCodeInternalError, "workflow engine not configured")
@@ -243,12 +443,20 @@ func (g *Gateway) Timeout() time.Duration {
 	return g.requestTimeout
 }
 
+// Streams returns the tracker holding the gateway's in-flight streams.
+func (g *Gateway) Streams() *unit.StreamTracker {
+	return g.streams
+}
+
 // StreamResponse represents a single chunk in a streaming response
 type StreamResponse struct {
 	Data     any        `json:"data,omitempty"`
 	Metadata any        `json:"metadata,omitempty"`
 	Done     bool       `json:"done,omitempty"`
 	Error    *ErrorInfo `json:"error,omitempty"`
+	// Cancelled marks the terminal chunk of a stream stopped by request ID.
+	Cancelled bool   `json:"cancelled,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // HandleStream executes a streaming command and returns a channel of chunks
@@ -262,10 +470,15 @@ func (g *Gateway) HandleStream(ctx context.Context, req *Request) (<-chan Stream
 		return nil, NewErrorInfo(ErrCodeInvalidRequest, "streaming only supports commands")
 	}
 
+	if err := g.checkUnknownFields(req); err != nil {
+		return nil, err
+	}
+	req = g.injectSystemPrompt(req)
+
 	// Check if command supports streaming
-	cmd := g.registry.GetCommand(req.Unit)
+	cmd := g.registry.GetCommand(unitRef(req))
 	if cmd == nil {
-		return nil, NewErrorInfo(ErrCodeUnitNotFound, "command not found: "+req.Unit)
+		return nil, NewErrorInfo(ErrCodeUnitNotFound, "command not found: "+unitRef(req))
 	}
 
 	streamingCmd, ok := cmd.(unit.StreamingCommand)
@@ -273,23 +486,47 @@ func (g *Gateway) HandleStream(ctx context.Context, req *Request) (<-chan Stream
 		return nil, NewErrorInfo(ErrCodeInvalidRequest, "command does not support streaming: "+req.Unit)
 	}
 
-	requestID := unit.GenerateRequestID()
-	ctx = unit.WithRequestID(ctx, requestID)
+	// Callers that already announced a request ID to the client (the HTTP
+	// adapter sets X-Request-ID) keep it so the stream can be cancelled by it.
+	requestID := unit.GetRequestID(ctx)
+	if requestID == "" {
+		requestID = unit.GenerateRequestID()
+		ctx = unit.WithRequestID(ctx, requestID)
+	}
+	if req.Options.Provider != "" {
+		ctx = unit.WithProvider(ctx, req.Options.Provider)
+	}
 
 	timeout := req.Options.Timeout
 	if timeout <= 0 {
 		timeout = g.requestTimeout
 	}
 
+	// Terminal chunks are delivered under parent so a cancelled stream can
+	// still report that it was cancelled.
+	parent := ctx
+	ctx, cancelCause := context.WithCancelCause(ctx)
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 
+	// The slot is held for the whole stream, not just until it starts.
+	release, errInfo := g.limiter.acquire(ctx, unitDomain(req.Unit))
+	if errInfo != nil {
+		cancel()
+		cancelCause(nil)
+		return nil, errInfo
+	}
+	untrack := g.streams.Track(requestID, cancelCause)
+
 	// Create output channel
 	stream := make(chan StreamResponse, 10)
 
 	// Run command in goroutine
 	go func() {
 		defer close(stream)
+		defer release()
+		defer cancelCause(nil)
 		defer cancel()
+		defer untrack()
 
 		// Internal channel to receive chunks from command
 		unitStream := make(chan unit.StreamChunk, 10)
@@ -297,12 +534,34 @@ func (g *Gateway) HandleStream(ctx context.Context, req *Request) (<-chan Stream
 		// Execute streaming command in separate goroutine
 		errChan := make(chan error, 1)
 		go func() {
+			defer close(unitStream)
+			defer func() {
+				if r := recover(); r != nil {
+					errChan <- recoveredPanic(ctx, nil, req, r)
+				}
+			}()
 			errChan <- streamingCmd.ExecuteStream(ctx, req.Input, unitStream)
-			close(unitStream)
 		}()
 
-		// Forward chunks from unit stream to gateway stream
+		sendCancelled := func() {
+			select {
+			case stream <- StreamResponse{Cancelled: true, RequestID: requestID, Done: true}:
+			case <-parent.Done():
+			}
+		}
+
+		// Forward chunks from unit stream to gateway stream. An "error"
+		// chunk ends the stream; it is reported after the loop together
+		// with the command's result.
+		var failed *unit.StreamError
 		for chunk := range unitStream {
+			if chunk.Type == "error" {
+				failed = chunk.Error
+				if failed == nil {
+					failed = &unit.StreamError{Code: ErrCodeInternalError, Message: "stream failed"}
+				}
+				continue
+			}
 			resp := StreamResponse{
 				Data:     chunk.Data,
 				Metadata: chunk.Metadata,
@@ -313,15 +572,28 @@ func (g *Gateway) HandleStream(ctx context.Context, req *Request) (<-chan Stream
 			select {
 			case stream <- resp:
 			case <-ctx.Done():
+				if errors.Is(context.Cause(ctx), unit.ErrStreamCancelled) {
+					sendCancelled()
+				}
 				return
 			}
 		}
 
+		err := <-errChan
+		if errors.Is(context.Cause(ctx), unit.ErrStreamCancelled) {
+			sendCancelled()
+			return
+		}
+
 		// Check for execution error
-		if err := <-errChan; err != nil {
+		if err != nil || failed != nil {
+			errInfo := ToErrorInfo(err)
+			if errInfo == nil {
+				errInfo = NewErrorInfo(failed.Code, failed.Message)
+			}
 			select {
 			case stream <- StreamResponse{
-				Error: ToErrorInfo(err),
+				Error: errInfo,
 				Done:  true,
 			}:
 			case <-ctx.Done():
@@ -1,8 +1,12 @@
 package gateway
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,12 +16,13 @@ import (
 type mockCommand struct {
 	name    string
 	domain  string
+	input   unit.Schema
 	execute func(ctx context.Context, input any) (any, error)
 }
 
 func (m *mockCommand) Name() string              { return m.name }
 func (m *mockCommand) Domain() string            { return m.domain }
-func (m *mockCommand) InputSchema() unit.Schema  { return unit.Schema{} }
+func (m *mockCommand) InputSchema() unit.Schema  { return m.input }
 func (m *mockCommand) OutputSchema() unit.Schema { return unit.Schema{} }
 func (m *mockCommand) Execute(ctx context.Context, input any) (any, error) {
 	if m.execute != nil {
@@ -514,3 +519,233 @@ func TestResponse_Pagination(t *testing.T) {
 		t.Errorf("expected total 100, got %d", resp.Meta.Pagination.Total)
 	}
 }
+
+func TestHandle_Alias(t *testing.T) {
+	var logs bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(prev)
+
+	reg := unit.NewRegistry()
+	called := 0
+	_ = reg.RegisterCommand(&mockCommand{
+		name:   "inference.generate",
+		domain: "inference",
+		execute: func(ctx context.Context, input any) (any, error) {
+			called++
+			return map[string]any{"text": "hi"}, nil
+		},
+	})
+	if err := reg.RegisterAlias("inference.complete", "inference.generate"); err != nil {
+		t.Fatalf("RegisterAlias: %v", err)
+	}
+	g := NewGateway(reg)
+
+	for i := 0; i < 2; i++ {
+		resp := g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "inference.complete"})
+		if !resp.Success {
+			t.Fatalf("expected success via alias, got %+v", resp.Error)
+		}
+		if resp.Meta.Deprecation == nil || resp.Meta.Deprecation.ReplacedBy != "inference.generate" {
+			t.Errorf("expected deprecation reported in meta, got %+v", resp.Meta.Deprecation)
+		}
+	}
+	if called != 2 {
+		t.Errorf("expected new unit to run twice, got %d", called)
+	}
+	if n := strings.Count(logs.String(), "deprecated unit called"); n != 1 {
+		t.Errorf("expected deprecation warning logged once, got %d", n)
+	}
+
+	resp := g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "inference.generate"})
+	if resp.Meta.Deprecation != nil {
+		t.Errorf("expected no deprecation for the current name, got %+v", resp.Meta.Deprecation)
+	}
+}
+
+type versionedMockCommand struct {
+	mockCommand
+	version string
+}
+
+func (m *versionedMockCommand) Version() string { return m.version }
+
+func TestHandle_CommandVersion(t *testing.T) {
+	reg := unit.NewRegistry()
+	for _, v := range []string{"v1", "v2"} {
+		version := v
+		_ = reg.RegisterCommand(&versionedMockCommand{
+			mockCommand: mockCommand{
+				name:   "inference.chat",
+				domain: "inference",
+				execute: func(ctx context.Context, input any) (any, error) {
+					return map[string]any{"version": version}, nil
+				},
+			},
+			version: version,
+		})
+	}
+	g := NewGateway(reg)
+
+	tests := []struct {
+		name    string
+		req     *Request
+		want    string
+		wantErr bool
+	}{
+		{"default is latest", &Request{Type: TypeCommand, Unit: "inference.chat"}, "v2", false},
+		{"explicit option", &Request{Type: TypeCommand, Unit: "inference.chat", Options: RequestOptions{Version: "v1"}}, "v1", false},
+		{"version in unit name", &Request{Type: TypeCommand, Unit: "inference.chat@v1"}, "v1", false},
+		{"option overrides unit suffix", &Request{Type: TypeCommand, Unit: "inference.chat@v1", Options: RequestOptions{Version: "v2"}}, "v2", false},
+		{"unknown version", &Request{Type: TypeCommand, Unit: "inference.chat", Options: RequestOptions{Version: "v9"}}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := g.Handle(context.Background(), tt.req)
+			if tt.wantErr {
+				if resp.Success || resp.Error.Code != ErrCodeUnitNotFound {
+					t.Fatalf("expected unit not found, got %+v", resp)
+				}
+				return
+			}
+			if !resp.Success {
+				t.Fatalf("unexpected error: %+v", resp.Error)
+			}
+			if got := resp.Data.(map[string]any)["version"]; got != tt.want {
+				t.Errorf("expected %s to run, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHandle_ResourceTemplate(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterResourceTemplate("asms://model/{id}", func(ctx context.Context, params map[string]string) (any, error) {
+		if params["id"] == "missing" {
+			return nil, errors.New("model not found")
+		}
+		return map[string]any{"id": params["id"]}, nil
+	})
+	g := NewGateway(reg)
+
+	resp := g.Handle(context.Background(), &Request{Type: TypeResource, Unit: "asms://model/abc123"})
+	if !resp.Success {
+		t.Fatalf("unexpected error: %+v", resp.Error)
+	}
+	if got := resp.Data.(map[string]any)["id"]; got != "abc123" {
+		t.Errorf("expected id abc123 extracted from URI, got %v", got)
+	}
+
+	resp = g.Handle(context.Background(), &Request{Type: TypeResource, Unit: "asms://model/missing"})
+	if resp.Success || resp.Error.Code != ErrCodeExecutionFailed {
+		t.Errorf("expected handler error to surface, got %+v", resp)
+	}
+
+	resp = g.Handle(context.Background(), &Request{Type: TypeResource, Unit: "asms://engine/abc123"})
+	if resp.Success || resp.Error.Code != ErrCodeResourceNotFound {
+		t.Errorf("expected resource not found, got %+v", resp)
+	}
+}
+
+func TestHandle_StrictInput(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&mockCommand{
+		name:   "test.generate",
+		domain: "test",
+		input: unit.Schema{
+			Type: "object",
+			Properties: map[string]unit.Field{
+				"prompt": {Name: "prompt", Schema: unit.Schema{Type: "string"}},
+			},
+		},
+	})
+	req := &Request{
+		Type:  TypeCommand,
+		Unit:  "test.generate",
+		Input: map[string]any{"promt": "hello", "bogus": true},
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		resp := NewGateway(reg, WithStrictInput(true)).Handle(context.Background(), req)
+		if resp.Success {
+			t.Fatal("expected unknown fields to be rejected")
+		}
+		if resp.Error.Code != ErrCodeValidationFailed {
+			t.Errorf("expected %s, got %s", ErrCodeValidationFailed, resp.Error.Code)
+		}
+		if !strings.Contains(resp.Error.Message, "bogus, promt") {
+			t.Errorf("expected the unknown keys in the message, got %q", resp.Error.Message)
+		}
+
+		ok := NewGateway(reg, WithStrictInput(true)).Handle(context.Background(), &Request{
+			Type:  TypeCommand,
+			Unit:  "test.generate",
+			Input: map[string]any{"prompt": "hello"},
+		})
+		if !ok.Success {
+			t.Errorf("expected declared fields to pass, got %+v", ok.Error)
+		}
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		resp := NewGateway(reg).Handle(context.Background(), req)
+		if !resp.Success {
+			t.Errorf("expected unknown fields to be ignored, got %+v", resp.Error)
+		}
+	})
+}
+
+func TestHandle_UnregisteredUnit(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&mockCommand{name: "test.ping", domain: "test"})
+	_ = reg.RegisterQuery(&mockQuery{name: "test.status", domain: "test"})
+	g := NewGateway(reg)
+	ctx := context.Background()
+
+	if resp := g.Handle(ctx, &Request{Type: TypeCommand, Unit: "test.ping"}); !resp.Success {
+		t.Fatalf("expected command to run before unregistering, got %+v", resp.Error)
+	}
+	if resp := g.Handle(ctx, &Request{Type: TypeQuery, Unit: "test.status"}); !resp.Success {
+		t.Fatalf("expected query to run before unregistering, got %+v", resp.Error)
+	}
+
+	reg.UnregisterCommand("test.ping")
+	reg.UnregisterQuery("test.status")
+
+	if resp := g.Handle(ctx, &Request{Type: TypeCommand, Unit: "test.ping"}); resp.Success || resp.Error.Code != ErrCodeUnitNotFound {
+		t.Errorf("expected command not found after unregistering, got %+v", resp)
+	}
+	if resp := g.Handle(ctx, &Request{Type: TypeQuery, Unit: "test.status"}); resp.Success || resp.Error.Code != ErrCodeUnitNotFound {
+		t.Errorf("expected query not found after unregistering, got %+v", resp)
+	}
+}
+
+func TestHandle_ConcurrentReplace(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&mockCommand{name: "test.ping", domain: "test"})
+	g := NewGateway(reg, WithStrictInput(true))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				resp := g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "test.ping", Input: map[string]any{"n": j}})
+				if !resp.Success && resp.Error.Code != ErrCodeUnitNotFound {
+					t.Errorf("unexpected error during hot swap: %+v", resp.Error)
+					return
+				}
+			}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		_ = reg.ReplaceCommand(&mockCommand{name: "test.ping", domain: "test"})
+		if j%10 == 0 {
+			reg.UnregisterCommand("test.ping")
+			_ = reg.RegisterCommand(&mockCommand{name: "test.ping", domain: "test"})
+		}
+	}
+	wg.Wait()
+}
@@ -7,28 +7,51 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 )
 
 const (
-	ContentTypeJSON = "application/json"
-	ContentTypeSSE  = "text/event-stream"
-	HeaderRequestID = "X-Request-ID"
-	HeaderTraceID   = "X-Trace-ID"
+	ContentTypeJSON   = "application/json"
+	ContentTypeSSE    = "text/event-stream"
+	ContentTypeNDJSON = "application/x-ndjson"
+	HeaderRequestID   = "X-Request-ID"
+	HeaderTraceID     = "X-Trace-ID"
 )
 
+// DefaultSSEKeepAlive is how often SSE streams send a keepalive comment
+// while waiting for their first chunk, so proxies do not drop connections
+// that stay idle through a long model load.
+const DefaultSSEKeepAlive = 15 * time.Second
+
 type HTTPAdapter struct {
-	gateway *Gateway
+	gateway   *Gateway
+	keepAlive time.Duration
 }
 
 func NewHTTPAdapter(gateway *Gateway) *HTTPAdapter {
 	return &HTTPAdapter{
-		gateway: gateway,
+		gateway:   gateway,
+		keepAlive: DefaultSSEKeepAlive,
 	}
 }
 
+// WithKeepAlive sets how often SSE streams send a keepalive comment before
+// their first chunk. Zero or less disables the heartbeats.
+func (a *HTTPAdapter) WithKeepAlive(interval time.Duration) *HTTPAdapter {
+	a.keepAlive = interval
+	return a
+}
+
 func (a *HTTPAdapter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if r.Method == http.MethodGet {
+		a.serveQueryString(w, r)
+		return
+	}
 	if r.Method != http.MethodPost {
 		writeJSONError(w, http.StatusMethodNotAllowed, ErrCodeInvalidRequest, "method not allowed")
 		return
@@ -151,19 +174,51 @@ func isStreamingRequest(req *Request) bool {
 	return false
 }
 
-// handleStreamRequest handles streaming requests using Server-Sent Events (SSE)
-func (a *HTTPAdapter) handleStreamRequest(ctx context.Context, w http.ResponseWriter, r *http.Request, req *Request) {
-	// Set SSE headers
-	w.Header().Set("Content-Type", ContentTypeSSE)
+// acceptsNDJSON reports whether the client asked for newline-delimited JSON
+// instead of SSE.
+func acceptsNDJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), ContentTypeNDJSON)
+}
+
+// setStreamHeaders sets the headers shared by all streaming formats and
+// announces the request ID up front, so the client can cancel the stream
+// through inference.cancel while it is still running.
+func setStreamHeaders(ctx context.Context, w http.ResponseWriter, contentType string) context.Context {
+	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
 
+	requestID := unit.GetRequestID(ctx)
+	if requestID == "" {
+		requestID = unit.GenerateRequestID()
+		ctx = unit.WithRequestID(ctx, requestID)
+	}
+	w.Header().Set(HeaderRequestID, requestID)
+	return ctx
+}
+
+// handleStreamRequest handles streaming requests using Server-Sent Events
+// (SSE), or newline-delimited JSON when the client accepts it.
+func (a *HTTPAdapter) handleStreamRequest(ctx context.Context, w http.ResponseWriter, r *http.Request, req *Request) {
+	if req.Type != TypeResource && acceptsNDJSON(r) {
+		a.handleNDJSONStream(ctx, w, req)
+		return
+	}
+
+	// Set SSE headers
+	ctx = setStreamHeaders(ctx, w, ContentTypeSSE)
+
 	// Flush headers
 	if f, ok := w.(http.Flusher); ok {
 		f.Flush()
 	}
 
+	if req.Type == TypeResource {
+		a.handleResourceWatch(ctx, w, req)
+		return
+	}
+
 	// Get streaming response channel
 	stream, err := a.gateway.HandleStream(ctx, req)
 	if err != nil {
@@ -174,8 +229,15 @@ func (a *HTTPAdapter) handleStreamRequest(ctx context.Context, w http.ResponseWr
 	// Create buffered writer for SSE
 	writer := bufio.NewWriter(w)
 
-	// Stream chunks to client
-	for resp := range stream {
+	// Stream chunks to client, with heartbeats until the first one arrives
+	keepAlive := a.keepAlive
+	for {
+		resp, ok := awaitStreamResponse(w, writer, stream, keepAlive)
+		if !ok {
+			return
+		}
+		keepAlive = 0
+
 		if resp.Error != nil {
 			writeSSEEvent(writer, "error", resp.Error)
 			writer.Flush()
@@ -185,6 +247,10 @@ func (a *HTTPAdapter) handleStreamRequest(ctx context.Context, w http.ResponseWr
 			return
 		}
 
+		if resp.Cancelled {
+			writeSSEEvent(writer, "cancelled", map[string]any{"request_id": resp.RequestID})
+		}
+
 		if resp.Done {
 			// Send [DONE] marker in OpenAI-compatible format
 			writeSSEData(writer, "[DONE]")
@@ -206,11 +272,108 @@ func (a *HTTPAdapter) handleStreamRequest(ctx context.Context, w http.ResponseWr
 	}
 }
 
+// handleNDJSONStream writes each chunk of a streaming command as one JSON
+// object per line and finishes with a {"done":true} line. Errors and
+// cancellation are reported on that final line.
+func (a *HTTPAdapter) handleNDJSONStream(ctx context.Context, w http.ResponseWriter, req *Request) {
+	ctx = setStreamHeaders(ctx, w, ContentTypeNDJSON)
+
+	writer := bufio.NewWriter(w)
+	enc := json.NewEncoder(writer)
+	writeLine := func(v any) {
+		_ = enc.Encode(v)
+		writer.Flush()
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	stream, err := a.gateway.HandleStream(ctx, req)
+	if err != nil {
+		errInfo, ok := err.(*ErrorInfo)
+		if !ok {
+			errInfo = ToErrorInfo(err)
+		}
+		writeLine(StreamResponse{Error: errInfo, Done: true})
+		return
+	}
+
+	for resp := range stream {
+		if !resp.Done {
+			writeLine(resp)
+			continue
+		}
+		// A "done" chunk may still carry a final payload; emit it on its own
+		// line so the terminal line is always {"done":true,...}.
+		if resp.Data != nil || resp.Metadata != nil {
+			writeLine(StreamResponse{Data: resp.Data, Metadata: resp.Metadata})
+			resp.Data, resp.Metadata = nil, nil
+		}
+		writeLine(resp)
+		return
+	}
+}
+
+// handleResourceWatch streams resource updates as SSE events named after
+// the update operation until the resource closes the stream or the client
+// disconnects.
+func (a *HTTPAdapter) handleResourceWatch(ctx context.Context, w http.ResponseWriter, req *Request) {
+	updates, err := a.gateway.WatchResource(ctx, req.Unit)
+	if err != nil {
+		writeSSEError(w, err)
+		return
+	}
+
+	writer := bufio.NewWriter(w)
+	for update := range updates {
+		writeSSEEvent(writer, update.Operation, update)
+		writer.Flush()
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	writeSSEData(writer, "[DONE]")
+	writer.Flush()
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // writeSSEData writes a data event in SSE format
 func writeSSEData(w *bufio.Writer, data string) {
 	fmt.Fprintf(w, "data: %s\n\n", data)
 }
 
+// writeSSEComment writes an SSE comment line, which clients ignore
+func writeSSEComment(w *bufio.Writer, comment string) {
+	fmt.Fprintf(w, ": %s\n\n", comment)
+}
+
+// awaitStreamResponse receives the next response from stream, writing a
+// keepalive comment to w every interval until it arrives. An interval of
+// zero or less waits without heartbeats.
+func awaitStreamResponse(w http.ResponseWriter, writer *bufio.Writer, stream <-chan StreamResponse, interval time.Duration) (StreamResponse, bool) {
+	if interval <= 0 {
+		resp, ok := <-stream
+		return resp, ok
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case resp, ok := <-stream:
+			return resp, ok
+		case <-ticker.C:
+			writeSSEComment(writer, "keepalive")
+			writer.Flush()
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+		}
+	}
+}
+
 // writeSSEEvent writes a named event in SSE format
 func writeSSEEvent(w *bufio.Writer, event string, data any) {
 	jsonData, _ := json.Marshal(data)
@@ -1,15 +1,19 @@
 package gateway
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
 )
 
 func TestHTTPAdapter_ServeHTTP(t *testing.T) {
@@ -49,7 +53,7 @@ func TestHTTPAdapter_ServeHTTP(t *testing.T) {
 	})
 
 	t.Run("method not allowed", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/api/v2/execute", nil)
+		req := httptest.NewRequest(http.MethodPut, "/api/v2/execute", nil)
 		rec := httptest.NewRecorder()
 
 		adapter.ServeHTTP(rec, req)
@@ -251,3 +255,220 @@ func TestHTTPAdapter_Timeout(t *testing.T) {
 		t.Errorf("expected status 500, got %d", rec.Code)
 	}
 }
+
+func TestHTTPAdapter_ResourceWatchSSE(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterResource(&mockResource{
+		uri: "asms://events/pull-1",
+		watch: func(ctx context.Context) (<-chan unit.ResourceUpdate, error) {
+			ch := make(chan unit.ResourceUpdate, 2)
+			ch <- unit.ResourceUpdate{URI: "asms://events/pull-1", Operation: "model.pull_progress", Data: map[string]any{"progress": 0.5}}
+			ch <- unit.ResourceUpdate{URI: "asms://events/pull-1", Operation: "execution_completed"}
+			close(ch)
+			return ch, nil
+		},
+	})
+	adapter := NewHTTPAdapter(NewGateway(reg))
+
+	body := `{"type":"resource","unit":"asms://events/pull-1","input":{"stream":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	rec := httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentTypeSSE {
+		t.Errorf("expected SSE content type, got %s", ct)
+	}
+	out := rec.Body.String()
+	progress := strings.Index(out, "event: model.pull_progress")
+	completed := strings.Index(out, "event: execution_completed")
+	if progress < 0 || completed < progress {
+		t.Errorf("expected progress then completion events, got %q", out)
+	}
+	if !strings.HasSuffix(out, "data: [DONE]\n\n") {
+		t.Errorf("expected stream to end with [DONE], got %q", out)
+	}
+}
+
+func TestHTTPAdapter_StreamNDJSON(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&testAdapterStreamingCommand{testAdapterCommand{name: "test.stream", domain: "test"}})
+	adapter := NewHTTPAdapter(NewGateway(reg))
+
+	body := `{"type":"command","unit":"test.stream","input":{"stream":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	req.Header.Set("Accept", ContentTypeNDJSON)
+	rec := httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentTypeNDJSON {
+		t.Errorf("expected NDJSON content type, got %s", ct)
+	}
+	if rec.Header().Get(HeaderRequestID) == "" {
+		t.Error("expected request ID header")
+	}
+
+	var frames []map[string]any
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var frame map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		frames = append(frames, frame)
+	}
+
+	if len(frames) != 4 {
+		t.Fatalf("expected 3 data frames and a done frame, got %d: %v", len(frames), frames)
+	}
+	for i, frame := range frames[:3] {
+		data, _ := frame["data"].(map[string]any)
+		if data["chunk"] != float64(i) {
+			t.Errorf("frame %d: expected chunk %d, got %v", i, i, frame)
+		}
+	}
+	if last := frames[3]; len(last) != 1 || last["done"] != true {
+		t.Errorf(`expected final {"done":true}, got %v`, last)
+	}
+}
+
+func TestHTTPAdapter_StreamNDJSON_Error(t *testing.T) {
+	adapter := NewHTTPAdapter(NewGateway(unit.NewRegistry()))
+
+	body := `{"type":"command","unit":"missing.stream","input":{"stream":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", bytes.NewBufferString(body))
+	req.Header.Set("Accept", ContentTypeNDJSON)
+	rec := httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+
+	var frame StreamResponse
+	if err := json.Unmarshal(bytes.TrimSpace(rec.Body.Bytes()), &frame); err != nil {
+		t.Fatalf("expected a single JSON line, got %q: %v", rec.Body.String(), err)
+	}
+	if !frame.Done || frame.Error == nil || frame.Error.Code != ErrCodeUnitNotFound {
+		t.Errorf("expected done frame with UNIT_NOT_FOUND error, got %+v", frame)
+	}
+}
+
+// newMidStreamFailureAdapter serves inference.chat from a provider that sends
+// two chunks and then fails.
+func newMidStreamFailureAdapter() *HTTPAdapter {
+	provider := inference.NewMockProvider()
+	provider.SetChatStreamChunks([]inference.ChatStreamChunk{{Content: "Hel"}, {Content: "lo"}})
+	provider.SetChatStreamError(errors.New("connection reset by upstream"))
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(inference.NewChatCommand(provider))
+	return NewHTTPAdapter(NewGateway(reg))
+}
+
+const midStreamChatBody = `{"type":"command","unit":"inference.chat","input":{"model":"llama3","messages":[{"role":"user","content":"Hi"}],"stream":true}}`
+
+func TestHTTPAdapter_StreamSSE_MidStreamError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", bytes.NewBufferString(midStreamChatBody))
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	rec := httptest.NewRecorder()
+	newMidStreamFailureAdapter().ServeHTTP(rec, req)
+
+	out := rec.Body.String()
+	if n := strings.Count(out, "chat.completion.chunk"); n != 2 {
+		t.Errorf("expected two data events before the error, got %d: %q", n, out)
+	}
+	errAt := strings.Index(out, "event: error\n")
+	if errAt < 0 || errAt < strings.LastIndex(out, "chat.completion.chunk") {
+		t.Fatalf("expected a final error event, got %q", out)
+	}
+	if !strings.Contains(out[errAt:], `"code":"`+ErrCodeInternalError+`"`) || !strings.Contains(out[errAt:], "connection reset by upstream") {
+		t.Errorf("expected the error event to carry a code and message, got %q", out[errAt:])
+	}
+	if strings.Contains(out, "[DONE]") {
+		t.Errorf("expected no [DONE] marker after a failure, got %q", out)
+	}
+}
+
+func TestHTTPAdapter_StreamNDJSON_MidStreamError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", bytes.NewBufferString(midStreamChatBody))
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	req.Header.Set("Accept", ContentTypeNDJSON)
+	rec := httptest.NewRecorder()
+	newMidStreamFailureAdapter().ServeHTTP(rec, req)
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected two data lines and a final error line, got %d: %q", len(lines), rec.Body.String())
+	}
+	var last StreamResponse
+	if err := json.Unmarshal([]byte(lines[2]), &last); err != nil {
+		t.Fatalf("last line %q is not JSON: %v", lines[2], err)
+	}
+	if !last.Done || last.Error == nil || last.Error.Code != ErrCodeInternalError || last.Error.Message != "connection reset by upstream" {
+		t.Errorf("expected a done line with the provider error, got %+v", last)
+	}
+}
+
+// slowStreamCommand waits before each of its two chunks, like a model that
+// is still loading and then generates slowly.
+type slowStreamCommand struct {
+	testAdapterCommand
+	delay time.Duration
+}
+
+func (c *slowStreamCommand) SupportsStreaming() bool { return true }
+
+func (c *slowStreamCommand) ExecuteStream(ctx context.Context, input any, output chan<- unit.StreamChunk) error {
+	for _, content := range []string{"Hel", "lo"} {
+		select {
+		case <-time.After(c.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		select {
+		case output <- unit.StreamChunk{Type: "content", Data: content}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func TestHTTPAdapter_StreamSSE_KeepAlive(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&slowStreamCommand{testAdapterCommand{name: "test.slow", domain: "test"}, 100 * time.Millisecond})
+	adapter := NewHTTPAdapter(NewGateway(reg)).WithKeepAlive(10 * time.Millisecond)
+
+	body := `{"type":"command","unit":"test.slow","input":{"stream":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	rec := httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+
+	out := rec.Body.String()
+	first := strings.Index(out, "data: ")
+	if first < 0 {
+		t.Fatalf("expected data events, got %q", out)
+	}
+	if n := strings.Count(out[:first], ": keepalive\n\n"); n < 2 {
+		t.Errorf("expected heartbeats while waiting for the first chunk, got %d in %q", n, out[:first])
+	}
+	if strings.Contains(out[first:], "keepalive") {
+		t.Errorf("expected heartbeats to stop once content flows, got %q", out[first:])
+	}
+	if !strings.HasSuffix(out, "data: [DONE]\n\n") {
+		t.Errorf("expected stream to end with [DONE], got %q", out)
+	}
+}
+
+func TestHTTPAdapter_StreamSSE_KeepAliveDisabled(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&slowStreamCommand{testAdapterCommand{name: "test.slow", domain: "test"}, 30 * time.Millisecond})
+	adapter := NewHTTPAdapter(NewGateway(reg)).WithKeepAlive(0)
+
+	body := `{"type":"command","unit":"test.slow","input":{"stream":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+
+	if out := rec.Body.String(); strings.Contains(out, "keepalive") {
+		t.Errorf("expected no heartbeats when disabled, got %q", out)
+	}
+}
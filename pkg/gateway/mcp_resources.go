@@ -98,12 +98,15 @@ func (a *MCPAdapter) ReadResource(ctx context.Context, uri string) (*MCPResource
 		return nil, fmt.Errorf("resource not found: %s", uri)
 	}
 
-	res := registry.GetResource(uri)
-	if res == nil {
+	var data any
+	var err error
+	if res := registry.GetResource(uri); res != nil {
+		data, err = res.Get(ctx)
+	} else if handler, params, ok := registry.MatchResourceTemplate(uri); ok {
+		data, err = handler(ctx, params)
+	} else {
 		return nil, fmt.Errorf("resource not found: %s", uri)
 	}
-
-	data, err := res.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
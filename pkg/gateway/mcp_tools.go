@@ -77,17 +77,35 @@ func (a *MCPAdapter) GenerateToolDefinitions() []MCPToolDefinition {
 
 	for _, cmd := range registry.ListCommands() {
 		tool := a.commandToToolDefinition(cmd)
+		tool.Description = deprecatedDescription(tool.Description, registry.DeprecationOf(cmd.Name()))
 		tools = append(tools, tool)
 	}
 
 	for _, q := range registry.ListQueries() {
 		tool := a.queryToToolDefinition(q)
+		tool.Description = deprecatedDescription(tool.Description, registry.DeprecationOf(q.Name()))
 		tools = append(tools, tool)
 	}
 
 	return tools
 }
 
+// deprecatedDescription prefixes a tool description so agents avoid
+// deprecated units.
+func deprecatedDescription(desc string, dep *unit.Deprecation) string {
+	if dep == nil {
+		return desc
+	}
+	notice := "[DEPRECATED]"
+	if dep.ReplacedBy != "" {
+		notice = "[DEPRECATED: use " + unitNameToToolName(dep.ReplacedBy) + "]"
+	}
+	if desc == "" {
+		return notice
+	}
+	return notice + " " + desc
+}
+
 func (a *MCPAdapter) commandToToolDefinition(cmd unit.Command) MCPToolDefinition {
 	inputSchema := schemaToMCPInputSchema(cmd.InputSchema())
 
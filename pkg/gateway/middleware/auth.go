@@ -1,11 +1,17 @@
 package middleware
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 )
 
 // AuthLevel defines how strictly authentication is enforced.
@@ -36,6 +42,15 @@ type AuthConfig struct {
 	// unit whose name is in this map, that level is used. Unknown units fall back
 	// to AuthLevelRecommended.
 	UnitAuthLevels map[string]AuthLevel
+
+	// ResolveUnit maps a unit name to the unit it runs, following aliases
+	// (see unit.Registry.CanonicalName). May be nil, in which case names are
+	// only stripped of their "@version".
+	ResolveUnit func(name string) string
+
+	// RouteUnit names the unit a REST route runs (see gateway.Router.UnitFor),
+	// or "" for requests no route matches. May be nil.
+	RouteUnit func(r *http.Request) string
 }
 
 // DefaultAuthConfig returns a sensible default: auth disabled, no keys, and the
@@ -61,7 +76,10 @@ func DefaultAuthConfig() AuthConfig {
 			"remote.exec":    AuthLevelForced,
 			"app.uninstall":  AuthLevelForced,
 			"model.delete":   AuthLevelForced,
+			"model.move":     AuthLevelForced,
+			"model.prune":    AuthLevelForced,
 			"service.delete": AuthLevelForced,
+			"service.exec":   AuthLevelForced,
 		},
 	}
 	// Units not listed here fall back to AuthLevelRecommended:
@@ -86,8 +104,18 @@ func DefaultAuthConfig() AuthConfig {
 // (POST/PUT/DELETE/PATCH), the auth level is floored at AuthLevelRecommended so
 // that a client cannot spoof X-Unit to downgrade a mutation to Optional.  The
 // X-Unit header may still upgrade the level (e.g., to Forced for remote.exec).
-// For GET requests, X-Unit is used as-is.  If the unit cannot be determined, the
+// Write requests naming a unit in their JSON body (as /api/v2/execute does) are
+// held to that unit's level as well, so omitting or faking X-Unit cannot skip a
+// Forced unit such as service.exec. For GET requests, X-Unit is used as-is.  If the unit cannot be determined, the
 // request falls back to AuthLevelRecommended.
+//
+// Unit names are looked up without their "@version" and with aliases resolved
+// through cfg.ResolveUnit, so "service.exec@v1" is held to service.exec's level.
+// Requests to REST routes are likewise held to the level of the unit
+// cfg.RouteUnit says the route runs, since they need not send X-Unit.
+//
+// Requests made with a valid token carry its principal in their context (see
+// unit.GetPrincipal), so units can tell which key called them.
 func Auth(cfg AuthConfig) func(http.Handler) http.Handler {
 	validKeys := buildKeySet(cfg.APIKeys)
 
@@ -100,7 +128,7 @@ func Auth(cfg AuthConfig) func(http.Handler) http.Handler {
 				return
 			}
 
-			unit := r.Header.Get("X-Unit")
+			unit := canonicalUnit(r.Header.Get("X-Unit"), cfg.ResolveUnit)
 			level := resolveAuthLevel(unit, cfg.UnitAuthLevels)
 
 			// Security: write operations have a floor of AuthLevelRecommended.
@@ -110,6 +138,25 @@ func Auth(cfg AuthConfig) func(http.Handler) http.Handler {
 				level = AuthLevelRecommended
 			}
 
+			// Security: a REST route runs its own unit whatever X-Unit claims.
+			if cfg.RouteUnit != nil {
+				if name := canonicalUnit(cfg.RouteUnit(r), cfg.ResolveUnit); name != "" && name != unit {
+					if routeLevel := resolveAuthLevel(name, cfg.UnitAuthLevels); routeLevel > level {
+						unit, level = name, routeLevel
+					}
+				}
+			}
+
+			// Security: the unit actually executed is the one in the body,
+			// so its level applies whatever X-Unit claims.
+			if isWriteMethod(r.Method) {
+				if name := canonicalUnit(bodyUnit(r), cfg.ResolveUnit); name != "" && name != unit {
+					if bodyLevel := resolveAuthLevel(name, cfg.UnitAuthLevels); bodyLevel > level {
+						unit, level = name, bodyLevel
+					}
+				}
+			}
+
 			token := extractBearerToken(r)
 
 			switch level {
@@ -125,7 +172,7 @@ func Auth(cfg AuthConfig) func(http.Handler) http.Handler {
 					writeAuthError(w, "invalid API key")
 					return
 				}
-				next.ServeHTTP(w, r)
+				next.ServeHTTP(w, withPrincipal(r, token))
 
 			case AuthLevelForced:
 				// Always require a valid token.
@@ -139,7 +186,7 @@ func Auth(cfg AuthConfig) func(http.Handler) http.Handler {
 					writeAuthError(w, "invalid API key")
 					return
 				}
-				next.ServeHTTP(w, r)
+				next.ServeHTTP(w, withPrincipal(r, token))
 
 			default: // AuthLevelRecommended
 				if !cfg.Enabled {
@@ -157,7 +204,7 @@ func Auth(cfg AuthConfig) func(http.Handler) http.Handler {
 					writeAuthError(w, "invalid API key")
 					return
 				}
-				next.ServeHTTP(w, r)
+				next.ServeHTTP(w, withPrincipal(r, token))
 			}
 		})
 	}
@@ -186,6 +233,43 @@ func resolveAuthLevel(unit string, levels map[string]AuthLevel) AuthLevel {
 	return AuthLevelRecommended
 }
 
+// canonicalUnit strips any "@version" from name, including an empty one, and
+// resolves it through resolve when set.
+func canonicalUnit(name string, resolve func(string) string) string {
+	name, _, _ = strings.Cut(name, "@")
+	if name != "" && resolve != nil {
+		name = resolve(name)
+	}
+	return name
+}
+
+// maxAuthBodyPeek bounds how much of a request body bodyUnit reads; it matches
+// the body limit of the execute handlers.
+const maxAuthBodyPeek = 10 << 20
+
+// bodyUnit returns the "unit" field of a JSON request body, or "" when the body
+// is absent or not such an object. The body is restored for the next handler.
+func bodyUnit(r *http.Request) string {
+	if r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxAuthBodyPeek))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), r.Body), r.Body}
+	if err != nil {
+		return ""
+	}
+	var body struct {
+		Unit string `json:"unit"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		return ""
+	}
+	return body.Unit
+}
+
 // isWriteMethod returns true for HTTP methods that represent mutations.
 func isWriteMethod(method string) bool {
 	switch method {
@@ -226,6 +310,18 @@ func isValidToken(token string, validKeys map[string]struct{}) bool {
 	return false
 }
 
+// withPrincipal returns r with the principal of the valid token in its context.
+func withPrincipal(r *http.Request, token string) *http.Request {
+	return r.WithContext(unit.WithPrincipal(r.Context(), keyPrincipal(token)))
+}
+
+// keyPrincipal names the caller holding an API key without revealing the key:
+// "apikey:" followed by the first 12 hex digits of its SHA-256.
+func keyPrincipal(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "apikey:" + hex.EncodeToString(sum[:])[:12]
+}
+
 // logUnauthorized logs an auth failure at warn level.
 func logUnauthorized(logger *slog.Logger, r *http.Request, unit, reason string) {
 	if logger == nil {
@@ -1,11 +1,14 @@
 package middleware
 
 import (
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 )
 
 // okHandler is a simple handler that always responds 200.
@@ -124,7 +127,7 @@ func TestDefaultAuthConfig(t *testing.T) {
 	}
 
 	// High-risk units must be forced.
-	forced := []string{"remote.exec", "app.uninstall", "model.delete", "service.delete"}
+	forced := []string{"remote.exec", "app.uninstall", "model.delete", "model.move", "model.prune", "service.delete", "service.exec"}
 	for _, u := range forced {
 		if level, ok := cfg.UnitAuthLevels[u]; !ok || level != AuthLevelForced {
 			t.Errorf("unit %q should be AuthLevelForced", u)
@@ -140,6 +143,39 @@ func TestDefaultAuthConfig(t *testing.T) {
 	}
 }
 
+func TestDefaultAuthConfigForcesModelPrune(t *testing.T) {
+	cfg := DefaultAuthConfig()
+	cfg.APIKeys = []string{"secret"}
+	cfg.RouteUnit = func(r *http.Request) string {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v2/models/prune" {
+			return "model.prune"
+		}
+		return ""
+	}
+	handler := Auth(cfg)(okHandler)
+
+	// Auth is disabled globally and REST clients send no X-Unit, yet pruning
+	// models still needs a key.
+	for _, xunit := range []string{"", "model.list"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v2/models/prune", nil)
+		if xunit != "" {
+			req = withUnit(req, xunit)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("X-Unit %q: expected 401 without a token, got %d", xunit, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/models/prune", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, withBearer(req, "secret"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 with a valid token, got %d", rec.Code)
+	}
+}
+
 // ---------- Auth middleware — optional level ----------
 
 func TestAuthOptional(t *testing.T) {
@@ -440,6 +476,99 @@ func TestAuthXUnitSpoofingPrevention(t *testing.T) {
 	})
 }
 
+func TestAuthBodyUnitOverridesXUnit(t *testing.T) {
+	cfg := DefaultAuthConfig()
+	cfg.APIKeys = []string{"secret"}
+	var got string
+	handler := Auth(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+	}))
+	const body = `{"type":"command","unit":"service.exec","input":{"service_id":"svc-1","command":["ls"]}}`
+
+	t.Run("missing or spoofed X-Unit is rejected", func(t *testing.T) {
+		for _, xunit := range []string{"", "model.list", "service.create"} {
+			req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", strings.NewReader(body))
+			if xunit != "" {
+				req = withUnit(req, xunit)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("X-Unit %q: expected 401, got %d", xunit, rec.Code)
+			}
+		}
+	})
+
+	t.Run("valid token passes the body through", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, withBearer(req, "secret"))
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", rec.Code)
+		}
+		if got != body {
+			t.Errorf("expected the handler to read the original body, got %q", got)
+		}
+	})
+}
+
+func TestAuthForcedUnitVersionsAndAliases(t *testing.T) {
+	cfg := DefaultAuthConfig()
+	cfg.APIKeys = []string{"secret"}
+	aliases := make(map[string]string)
+	cfg.ResolveUnit = func(name string) string {
+		if target, ok := aliases[name]; ok {
+			return target
+		}
+		return name
+	}
+
+	var forced []string
+	for name, level := range cfg.UnitAuthLevels {
+		if level == AuthLevelForced {
+			forced = append(forced, name)
+			aliases["old."+name] = name
+		}
+	}
+
+	ran := false
+	handler := Auth(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ran = true
+	}))
+
+	for _, name := range forced {
+		for _, ref := range []string{name + "@", name + "@v1", name + "@@", "old." + name, "old." + name + "@v2"} {
+			t.Run(ref+" in body", func(t *testing.T) {
+				ran = false
+				req := httptest.NewRequest(http.MethodPost, "/api/v2/execute", strings.NewReader(`{"type":"command","unit":"`+ref+`"}`))
+				rec := httptest.NewRecorder()
+
+				handler.ServeHTTP(rec, req)
+
+				if rec.Code != http.StatusUnauthorized || ran {
+					t.Errorf("expected 401 without running the handler, got %d (ran=%v)", rec.Code, ran)
+				}
+			})
+			t.Run(ref+" in X-Unit", func(t *testing.T) {
+				ran = false
+				req := withUnit(httptest.NewRequest(http.MethodPost, "/api/v2/execute", nil), ref)
+				rec := httptest.NewRecorder()
+
+				handler.ServeHTTP(rec, req)
+
+				if rec.Code != http.StatusUnauthorized || ran {
+					t.Errorf("expected 401 without running the handler, got %d (ran=%v)", rec.Code, ran)
+				}
+			})
+		}
+	}
+}
+
 // ---------- Auth middleware — multiple valid keys ----------
 
 func TestAuthMultipleKeys(t *testing.T) {
@@ -466,3 +595,40 @@ func TestAuthMultipleKeys(t *testing.T) {
 		})
 	}
 }
+
+// ---------- Auth middleware — principal ----------
+
+func TestAuthSetsPrincipal(t *testing.T) {
+	cfg := AuthConfig{
+		Enabled: true,
+		APIKeys: []string{"key1", "key2"},
+		UnitAuthLevels: map[string]AuthLevel{
+			"query": AuthLevelOptional,
+		},
+	}
+	var principal string
+	handler := Auth(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = unit.GetPrincipal(r.Context())
+	}))
+
+	principals := map[string]bool{}
+	for _, key := range []string{"key1", "key2"} {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), withBearer(req, key))
+
+		if !strings.HasPrefix(principal, "apikey:") || strings.Contains(principal, key) {
+			t.Errorf("expected a principal naming but not revealing %q, got %q", key, principal)
+		}
+		principals[principal] = true
+	}
+	if len(principals) != 2 {
+		t.Errorf("expected distinct principals per key, got %v", principals)
+	}
+
+	principal = "unset"
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), withUnit(req, "query"))
+	if principal != "" {
+		t.Errorf("expected no principal without a token, got %q", principal)
+	}
+}
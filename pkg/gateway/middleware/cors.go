@@ -34,9 +34,15 @@ func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
 				return
 			}
 
+			// A "*" entry is answered with a literal "*", never the reflected
+			// origin, so browsers will not send credentials to every site.
 			allowedOrigin := ""
 			for _, o := range cfg.AllowedOrigins {
-				if o == "*" || o == origin {
+				if o == "*" {
+					allowedOrigin = "*"
+					break
+				}
+				if o == origin {
 					allowedOrigin = origin
 					break
 				}
@@ -46,7 +52,16 @@ func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
 				}
 			}
 
+			// The response depends on the origin, so caches must key on it.
+			w.Header().Add("Vary", "Origin")
+
 			if allowedOrigin == "" {
+				// Refuse preflights from other origins rather than letting
+				// them reach handlers that do not know about OPTIONS.
+				if isPreflight(r) {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -56,7 +71,7 @@ func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
 			w.Header().Set("Access-Control-Allow-Headers", strings.Join(cfg.AllowedHeaders, ", "))
 			w.Header().Set("Access-Control-Expose-Headers", strings.Join(cfg.ExposedHeaders, ", "))
 
-			if cfg.AllowCredentials {
+			if cfg.AllowCredentials && allowedOrigin != "*" {
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
 			}
 
@@ -74,6 +89,11 @@ func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
 	}
 }
 
+// isPreflight reports whether r is a CORS preflight request.
+func isPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+}
+
 func intToStr(n int) string {
 	if n <= 0 {
 		return "0"
@@ -25,8 +25,8 @@ func TestCORS(t *testing.T) {
 			t.Errorf("expected status 204, got %d", rec.Code)
 		}
 
-		if rec.Header().Get("Access-Control-Allow-Origin") != "http://example.com" {
-			t.Errorf("expected Allow-Origin header, got %s", rec.Header().Get("Access-Control-Allow-Origin"))
+		if rec.Header().Get("Access-Control-Allow-Origin") != "*" {
+			t.Errorf("expected a wildcard Allow-Origin header, got %s", rec.Header().Get("Access-Control-Allow-Origin"))
 		}
 	})
 
@@ -49,8 +49,8 @@ func TestCORS(t *testing.T) {
 			t.Errorf("expected status 200, got %d", rec.Code)
 		}
 
-		if rec.Header().Get("Access-Control-Allow-Origin") != "http://example.com" {
-			t.Errorf("expected Allow-Origin header, got %s", rec.Header().Get("Access-Control-Allow-Origin"))
+		if rec.Header().Get("Access-Control-Allow-Origin") != "*" {
+			t.Errorf("expected a wildcard Allow-Origin header, got %s", rec.Header().Get("Access-Control-Allow-Origin"))
 		}
 	})
 
@@ -101,6 +101,35 @@ func TestCORS(t *testing.T) {
 		}
 	})
 
+	t.Run("rejects preflight from disallowed origin", func(t *testing.T) {
+		cfg := CORSConfig{
+			AllowedOrigins: []string{"http://allowed.com"},
+		}
+		called := false
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+
+		wrappedHandler := CORS(cfg)(handler)
+
+		req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+		req.Header.Set("Origin", "http://blocked.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		rec := httptest.NewRecorder()
+
+		wrappedHandler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", rec.Code)
+		}
+		if called {
+			t.Error("expected preflight not to reach the handler")
+		}
+		if rec.Header().Get("Access-Control-Allow-Origin") != "" {
+			t.Error("expected no Allow-Origin header for blocked origin")
+		}
+	})
+
 	t.Run("allows wildcard origin", func(t *testing.T) {
 		cfg := CORSConfig{
 			AllowedOrigins: []string{"*"},
@@ -117,8 +146,33 @@ func TestCORS(t *testing.T) {
 
 		wrappedHandler.ServeHTTP(rec, req)
 
-		if rec.Header().Get("Access-Control-Allow-Origin") != "http://any.com" {
-			t.Errorf("expected Allow-Origin header, got %s", rec.Header().Get("Access-Control-Allow-Origin"))
+		if rec.Header().Get("Access-Control-Allow-Origin") != "*" {
+			t.Errorf("expected a wildcard Allow-Origin header, got %s", rec.Header().Get("Access-Control-Allow-Origin"))
+		}
+	})
+
+	t.Run("wildcard never allows credentials", func(t *testing.T) {
+		cfg := CORSConfig{
+			AllowedOrigins:   []string{"*"},
+			AllowCredentials: true,
+		}
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		wrappedHandler := CORS(cfg)(handler)
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Origin", "http://evil.com")
+		rec := httptest.NewRecorder()
+
+		wrappedHandler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Errorf("expected a wildcard Allow-Origin header, got %s", got)
+		}
+		if rec.Header().Get("Access-Control-Allow-Credentials") != "" {
+			t.Error("expected no Allow-Credentials header for a wildcard origin")
 		}
 	})
 
@@ -0,0 +1,426 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// OpenAIAdapter serves the OpenAI-compatible /v1/chat/completions and
+// /v1/embeddings endpoints by translating them to inference.chat and
+// inference.embed executions, so existing OpenAI SDKs can talk to AIMA
+// unchanged.
+type OpenAIAdapter struct {
+	gateway *Gateway
+	now     func() time.Time
+	// models maps OpenAI model names sent by clients to AIMA model names.
+	models    map[string]string
+	keepAlive time.Duration
+}
+
+// HeaderModelOverride names the AIMA model to run, taking precedence over
+// the request body's model and the configured model mapping.
+const HeaderModelOverride = "X-AIMA-Model"
+
+func NewOpenAIAdapter(gateway *Gateway) *OpenAIAdapter {
+	return &OpenAIAdapter{gateway: gateway, now: time.Now, keepAlive: DefaultSSEKeepAlive}
+}
+
+// WithKeepAlive sets how often streamed chat completions send a keepalive
+// comment before the first token. Zero or less disables the heartbeats.
+func (a *OpenAIAdapter) WithKeepAlive(interval time.Duration) *OpenAIAdapter {
+	a.keepAlive = interval
+	return a
+}
+
+// WithModelMap routes OpenAI model names, e.g. "gpt-4o", to AIMA models.
+// Names without an entry are passed through unchanged.
+func (a *OpenAIAdapter) WithModelMap(models map[string]string) *OpenAIAdapter {
+	a.models = models
+	return a
+}
+
+// resolveModel returns the AIMA model for a request asking for requested.
+func (a *OpenAIAdapter) resolveModel(r *http.Request, requested string) string {
+	if override := r.Header.Get(HeaderModelOverride); override != "" {
+		return override
+	}
+	if mapped, ok := a.models[requested]; ok && mapped != "" {
+		return mapped
+	}
+	return requested
+}
+
+type openAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model            string              `json:"model"`
+	Messages         []openAIChatMessage `json:"messages"`
+	Temperature      *float64            `json:"temperature,omitempty"`
+	MaxTokens        *int                `json:"max_tokens,omitempty"`
+	TopP             *float64            `json:"top_p,omitempty"`
+	FrequencyPenalty *float64            `json:"frequency_penalty,omitempty"`
+	PresencePenalty  *float64            `json:"presence_penalty,omitempty"`
+	// Stop is a single string or a list of strings.
+	Stop   any  `json:"stop,omitempty"`
+	Stream bool `json:"stream,omitempty"`
+}
+
+type openAIEmbeddingRequest struct {
+	Model string `json:"model"`
+	// Input is a single string or a list of strings.
+	Input any `json:"input"`
+}
+
+type openAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens,omitempty"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// chatOutput is the part of the inference.chat output the facade reads.
+type chatOutput struct {
+	ID           string      `json:"id"`
+	Model        string      `json:"model"`
+	Content      string      `json:"content"`
+	FinishReason string      `json:"finish_reason"`
+	Usage        openAIUsage `json:"usage"`
+}
+
+// embedOutput is the part of the inference.embed output the facade reads.
+type embedOutput struct {
+	Embeddings []json.RawMessage `json:"embeddings"`
+	Usage      openAIUsage       `json:"usage"`
+}
+
+func (a *OpenAIAdapter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v1/chat/completions", "/v1/embeddings":
+	default:
+		writeOpenAIError(w, http.StatusNotFound, "invalid_request_error", "unknown endpoint: "+r.URL.Path)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeOpenAIError(w, http.StatusMethodNotAllowed, "invalid_request_error", "method not allowed: "+r.Method)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+	if r.URL.Path == "/v1/embeddings" {
+		a.handleEmbeddings(w, r)
+		return
+	}
+	a.handleChat(w, r)
+}
+
+func (a *OpenAIAdapter) handleChat(w http.ResponseWriter, r *http.Request) {
+	var req openAIChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "invalid JSON body: "+err.Error())
+		return
+	}
+	req.Model = a.resolveModel(r, req.Model)
+
+	input, err := req.toInput()
+	if err != nil {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	gwReq := &Request{
+		Type:    TypeCommand,
+		Unit:    "inference.chat",
+		Input:   input,
+		Options: RequestOptions{TraceID: r.Header.Get(HeaderTraceID)},
+	}
+	if req.Stream {
+		a.streamChat(r.Context(), w, gwReq, req.Model)
+		return
+	}
+
+	resp := a.gateway.Handle(r.Context(), gwReq)
+	if !resp.Success {
+		writeOpenAIGatewayError(w, resp.Error)
+		return
+	}
+	var out chatOutput
+	if err := decodeOutput(resp.Data, &out); err != nil {
+		writeOpenAIError(w, http.StatusInternalServerError, "api_error", "unexpected inference.chat output: "+err.Error())
+		return
+	}
+
+	writeOpenAIJSON(w, http.StatusOK, map[string]any{
+		"id":      completionID(out.ID, resp),
+		"object":  "chat.completion",
+		"created": a.now().Unix(),
+		"model":   firstNonEmpty(out.Model, req.Model),
+		"choices": []map[string]any{{
+			"index":         0,
+			"message":       openAIChatMessage{Role: "assistant", Content: out.Content},
+			"finish_reason": firstNonEmpty(out.FinishReason, "stop"),
+		}},
+		"usage": out.Usage,
+	})
+}
+
+// streamChat relays an inference.chat stream as OpenAI chat.completion.chunk
+// events, ending with the [DONE] sentinel. The first chunk announces the
+// assistant role and the last one carries the finish reason.
+func (a *OpenAIAdapter) streamChat(ctx context.Context, w http.ResponseWriter, req *Request, model string) {
+	ctx = setStreamHeaders(ctx, w, ContentTypeSSE)
+	stream, err := a.gateway.HandleStream(ctx, req)
+	if err != nil {
+		errInfo, ok := err.(*ErrorInfo)
+		if !ok {
+			errInfo = ToErrorInfo(err)
+		}
+		writeOpenAIGatewayError(w, errInfo)
+		return
+	}
+
+	id := "chatcmpl-" + unit.GetRequestID(ctx)
+	created := a.now().Unix()
+	writer := bufio.NewWriter(w)
+	send := func(delta map[string]any, finishReason any) {
+		chunk := map[string]any{
+			"id":      id,
+			"object":  "chat.completion.chunk",
+			"created": created,
+			"model":   model,
+			"choices": []map[string]any{{
+				"index":         0,
+				"delta":         delta,
+				"finish_reason": finishReason,
+			}},
+		}
+		data, _ := json.Marshal(chunk)
+		writeSSEData(writer, string(data))
+		writer.Flush()
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	send(map[string]any{"role": "assistant", "content": ""}, nil)
+
+	finishReason := "stop"
+	keepAlive := a.keepAlive
+	for {
+		resp, ok := awaitStreamResponse(w, writer, stream, keepAlive)
+		if !ok {
+			break
+		}
+		keepAlive = 0
+
+		if resp.Error != nil {
+			data, _ := json.Marshal(openAIErrorBody(resp.Error.Message, "api_error", resp.Error.Code))
+			writeSSEData(writer, string(data))
+			break
+		}
+		if resp.Cancelled {
+			finishReason = "cancelled"
+		}
+		if meta, ok := resp.Metadata.(map[string]any); ok {
+			if m, ok := meta["model"].(string); ok && m != "" {
+				model = m
+			}
+			if fr, ok := meta["finish_reason"].(string); ok && fr != "" {
+				finishReason = fr
+			}
+		}
+		if content, ok := resp.Data.(string); ok && content != "" {
+			send(map[string]any{"content": content}, nil)
+		}
+		if resp.Done {
+			break
+		}
+	}
+
+	send(map[string]any{}, finishReason)
+	writeSSEData(writer, "[DONE]")
+	writer.Flush()
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (a *OpenAIAdapter) handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+	var req openAIEmbeddingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "invalid JSON body: "+err.Error())
+		return
+	}
+	req.Model = a.resolveModel(r, req.Model)
+	if req.Model == "" {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "model is required")
+		return
+	}
+	input, err := stringList(req.Input, "input")
+	if err != nil || len(input) == 0 {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "input must be a string or an array of strings")
+		return
+	}
+
+	resp := a.gateway.Handle(r.Context(), &Request{
+		Type:    TypeCommand,
+		Unit:    "inference.embed",
+		Input:   map[string]any{"model": req.Model, "input": input},
+		Options: RequestOptions{TraceID: r.Header.Get(HeaderTraceID)},
+	})
+	if !resp.Success {
+		writeOpenAIGatewayError(w, resp.Error)
+		return
+	}
+	var out embedOutput
+	if err := decodeOutput(resp.Data, &out); err != nil {
+		writeOpenAIError(w, http.StatusInternalServerError, "api_error", "unexpected inference.embed output: "+err.Error())
+		return
+	}
+
+	data := make([]map[string]any, len(out.Embeddings))
+	for i, embedding := range out.Embeddings {
+		data[i] = map[string]any{"object": "embedding", "index": i, "embedding": embedding}
+	}
+	writeOpenAIJSON(w, http.StatusOK, map[string]any{
+		"object": "list",
+		"data":   data,
+		"model":  req.Model,
+		"usage":  out.Usage,
+	})
+}
+
+// toInput maps the OpenAI request onto the inference.chat input.
+func (req *openAIChatRequest) toInput() (map[string]any, error) {
+	if req.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("messages are required")
+	}
+
+	messages := make([]any, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = map[string]any{"role": m.Role, "content": m.Content}
+	}
+	input := map[string]any{"model": req.Model, "messages": messages}
+	if req.Stream {
+		input["stream"] = true
+	}
+	if req.Temperature != nil {
+		input["temperature"] = *req.Temperature
+	}
+	if req.MaxTokens != nil {
+		input["max_tokens"] = *req.MaxTokens
+	}
+	if req.TopP != nil {
+		input["top_p"] = *req.TopP
+	}
+	if req.FrequencyPenalty != nil {
+		input["frequency_penalty"] = *req.FrequencyPenalty
+	}
+	if req.PresencePenalty != nil {
+		input["presence_penalty"] = *req.PresencePenalty
+	}
+	if req.Stop != nil {
+		stop, err := stringList(req.Stop, "stop")
+		if err != nil {
+			return nil, err
+		}
+		list := make([]any, len(stop))
+		for i, s := range stop {
+			list[i] = s
+		}
+		input["stop"] = list
+	}
+	return input, nil
+}
+
+// stringList accepts a JSON string or array of strings.
+func stringList(v any, field string) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		out := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s[%d] must be a string", field, i)
+			}
+			out[i] = s
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%s must be a string or an array of strings", field)
+	}
+}
+
+// decodeOutput converts a unit output into v through its JSON form.
+func decodeOutput(data any, v any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func completionID(id string, resp *Response) string {
+	if id != "" {
+		return id
+	}
+	if resp.Meta != nil && resp.Meta.RequestID != "" {
+		return "chatcmpl-" + resp.Meta.RequestID
+	}
+	return "chatcmpl-" + unit.GenerateRequestID()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func openAIErrorBody(message, errType, code string) map[string]any {
+	return map[string]any{"error": map[string]any{"message": message, "type": errType, "code": code}}
+}
+
+func writeOpenAIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeOpenAIError(w http.ResponseWriter, status int, errType, message string) {
+	writeOpenAIJSON(w, status, openAIErrorBody(message, errType, ""))
+}
+
+// writeOpenAIGatewayError reports a gateway failure in the OpenAI error shape
+// with the status code the envelope API would use.
+func writeOpenAIGatewayError(w http.ResponseWriter, errInfo *ErrorInfo) {
+	status := errorToStatusCode(errInfo)
+	errType := "api_error"
+	if status >= 400 && status < 500 {
+		errType = "invalid_request_error"
+	}
+	message, code := "request failed", ""
+	if errInfo != nil {
+		message, code = errInfo.Message, errInfo.Code
+		if details, ok := errInfo.Details.(string); ok && details != "" {
+			message += ": " + details
+		}
+	}
+	writeOpenAIJSON(w, status, openAIErrorBody(message, errType, code))
+}
@@ -0,0 +1,295 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+)
+
+func newOpenAITestAdapter(t *testing.T) (*OpenAIAdapter, *inference.MockProvider) {
+	t.Helper()
+	provider := inference.NewMockProvider()
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(inference.NewChatCommand(provider))
+	_ = registry.RegisterCommand(inference.NewEmbedCommand(provider))
+	return NewOpenAIAdapter(NewGateway(registry)), provider
+}
+
+func postOpenAI(adapter *OpenAIAdapter, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	rec := httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOpenAIAdapter_ChatCompletion(t *testing.T) {
+	adapter, provider := newOpenAITestAdapter(t)
+	provider.SetChatResponse(&inference.ChatResponse{
+		ID:           "chatcmpl-123",
+		Model:        "llama3",
+		Content:      "Hello there",
+		FinishReason: "stop",
+		Usage:        inference.Usage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+	})
+
+	rec := postOpenAI(adapter, "/v1/chat/completions", `{
+		"model": "llama3",
+		"messages": [{"role": "system", "content": "Be brief"}, {"role": "user", "content": "Hi"}],
+		"temperature": 0.2,
+		"max_tokens": 16,
+		"stop": "\n"
+	}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		Model   string `json:"model"`
+		Choices []struct {
+			Index        int               `json:"index"`
+			Message      openAIChatMessage `json:"message"`
+			FinishReason string            `json:"finish_reason"`
+		} `json:"choices"`
+		Usage openAIUsage `json:"usage"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.ID != "chatcmpl-123" || resp.Object != "chat.completion" || resp.Model != "llama3" {
+		t.Errorf("unexpected envelope fields: %+v", resp)
+	}
+	if len(resp.Choices) != 1 || resp.Choices[0].Message.Role != "assistant" || resp.Choices[0].Message.Content != "Hello there" || resp.Choices[0].FinishReason != "stop" {
+		t.Errorf("unexpected choices: %+v", resp.Choices)
+	}
+	if resp.Usage.PromptTokens != 5 || resp.Usage.CompletionTokens != 2 || resp.Usage.TotalTokens != 7 {
+		t.Errorf("unexpected usage: %+v", resp.Usage)
+	}
+
+	last := provider.LastChatRequest()
+	if last == nil || len(last.Messages) != 2 || last.Messages[0].Role != "system" {
+		t.Fatalf("expected both messages to reach the provider, got %+v", last)
+	}
+	if last.Options.Temperature == nil || *last.Options.Temperature != 0.2 {
+		t.Errorf("expected temperature 0.2, got %v", last.Options.Temperature)
+	}
+	if last.Options.MaxTokens == nil || *last.Options.MaxTokens != 16 {
+		t.Errorf("expected max_tokens 16, got %v", last.Options.MaxTokens)
+	}
+	if len(last.Options.Stop) != 1 || last.Options.Stop[0] != "\n" {
+		t.Errorf("expected a single stop sequence, got %v", last.Options.Stop)
+	}
+}
+
+func TestOpenAIAdapter_ChatCompletionStream(t *testing.T) {
+	adapter, provider := newOpenAITestAdapter(t)
+	provider.SetChatStreamChunks([]inference.ChatStreamChunk{
+		{Model: "llama3", Content: "Hel"},
+		{Model: "llama3", Content: "lo"},
+		{Model: "llama3", FinishReason: "length"},
+	})
+
+	rec := postOpenAI(adapter, "/v1/chat/completions", `{"model":"llama3","messages":[{"role":"user","content":"Hi"}],"stream":true}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ContentTypeSSE {
+		t.Errorf("expected SSE content type, got %q", ct)
+	}
+
+	type chunk struct {
+		Object  string `json:"object"`
+		Choices []struct {
+			Delta        map[string]string `json:"delta"`
+			FinishReason *string           `json:"finish_reason"`
+		} `json:"choices"`
+	}
+	var chunks []chunk
+	var sawDone bool
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(line, "data: ")
+		if data == "[DONE]" {
+			sawDone = true
+			continue
+		}
+		if sawDone {
+			t.Fatalf("data after [DONE]: %s", data)
+		}
+		var c chunk
+		if err := json.Unmarshal([]byte(data), &c); err != nil {
+			t.Fatalf("decode chunk %q: %v", data, err)
+		}
+		chunks = append(chunks, c)
+	}
+
+	if !sawDone {
+		t.Fatal("expected the stream to end with [DONE]")
+	}
+	if len(chunks) != 4 {
+		t.Fatalf("expected role, two content and final chunks, got %d: %s", len(chunks), rec.Body.String())
+	}
+	for _, c := range chunks {
+		if c.Object != "chat.completion.chunk" || len(c.Choices) != 1 {
+			t.Fatalf("unexpected chunk shape: %+v", c)
+		}
+	}
+	if chunks[0].Choices[0].Delta["role"] != "assistant" {
+		t.Errorf("expected the first chunk to announce the assistant role, got %+v", chunks[0])
+	}
+	var content string
+	for _, c := range chunks[1:3] {
+		content += c.Choices[0].Delta["content"]
+	}
+	if content != "Hello" {
+		t.Errorf("expected streamed content Hello, got %q", content)
+	}
+	if fr := chunks[3].Choices[0].FinishReason; fr == nil || *fr != "length" {
+		t.Errorf("expected the final chunk to carry finish_reason length, got %v", fr)
+	}
+}
+
+func TestOpenAIAdapter_Embeddings(t *testing.T) {
+	adapter, _ := newOpenAITestAdapter(t)
+
+	rec := postOpenAI(adapter, "/v1/embeddings", `{"model":"bge-m3","input":["first text","second text"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Object string `json:"object"`
+		Model  string `json:"model"`
+		Data   []struct {
+			Object    string    `json:"object"`
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+		Usage openAIUsage `json:"usage"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Object != "list" || resp.Model != "bge-m3" || len(resp.Data) != 2 {
+		t.Fatalf("unexpected response: %s", rec.Body.String())
+	}
+	for i, d := range resp.Data {
+		if d.Object != "embedding" || d.Index != i || len(d.Embedding) == 0 {
+			t.Errorf("unexpected embedding %d: object=%s index=%d len=%d", i, d.Object, d.Index, len(d.Embedding))
+		}
+	}
+
+	rec = postOpenAI(adapter, "/v1/embeddings", `{"model":"bge-m3","input":"single"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected a single string input to be accepted, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestOpenAIAdapter_ModelRouting(t *testing.T) {
+	adapter, provider := newOpenAITestAdapter(t)
+	adapter.WithModelMap(map[string]string{"gpt-4o": "qwen2.5:72b"})
+	body := `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}`
+
+	rec := postOpenAI(adapter, "/v1/chat/completions", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if last := provider.LastChatRequest(); last == nil || last.Model != "qwen2.5:72b" {
+		t.Errorf("expected the mapped model to be used, got %+v", last)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewBufferString(body))
+	req.Header.Set(HeaderModelOverride, "llama3:8b")
+	rec = httptest.NewRecorder()
+	adapter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if last := provider.LastChatRequest(); last == nil || last.Model != "llama3:8b" {
+		t.Errorf("expected the header to override the mapping, got %+v", last)
+	}
+
+	rec = postOpenAI(adapter, "/v1/chat/completions", `{"model":"llama3","messages":[{"role":"user","content":"Hi"}]}`)
+	if last := provider.LastChatRequest(); rec.Code != http.StatusOK || last.Model != "llama3" {
+		t.Errorf("expected unmapped models to pass through, got %d %+v", rec.Code, last)
+	}
+}
+
+func TestOpenAIAdapter_Errors(t *testing.T) {
+	adapter, provider := newOpenAITestAdapter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"invalid json", http.MethodPost, "/v1/chat/completions", `{`, http.StatusBadRequest},
+		{"missing model", http.MethodPost, "/v1/chat/completions", `{"messages":[{"role":"user","content":"Hi"}]}`, http.StatusBadRequest},
+		{"missing messages", http.MethodPost, "/v1/chat/completions", `{"model":"llama3"}`, http.StatusBadRequest},
+		{"bad embedding input", http.MethodPost, "/v1/embeddings", `{"model":"bge-m3","input":42}`, http.StatusBadRequest},
+		{"wrong method", http.MethodGet, "/v1/chat/completions", ``, http.StatusMethodNotAllowed},
+		{"unknown endpoint", http.MethodPost, "/v1/completions", `{}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			adapter.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			var body map[string]map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"]["message"] == "" {
+				t.Errorf("expected an OpenAI error object, got %s", rec.Body.String())
+			}
+		})
+	}
+
+	provider.SetChatError(errors.New("engine exploded"))
+	rec := postOpenAI(adapter, "/v1/chat/completions", `{"model":"llama3","messages":[{"role":"user","content":"Hi"}]}`)
+	if rec.Code < 500 {
+		t.Errorf("expected a server error for a failed inference, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "engine exploded") {
+		t.Errorf("expected the provider error in the message, got %s", rec.Body.String())
+	}
+}
+
+func TestOpenAIAdapter_ChatCompletionStream_KeepAlive(t *testing.T) {
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(&slowStreamCommand{testAdapterCommand{name: "inference.chat", domain: "inference"}, 100 * time.Millisecond})
+	adapter := NewOpenAIAdapter(NewGateway(registry)).WithKeepAlive(10 * time.Millisecond)
+
+	rec := postOpenAI(adapter, "/v1/chat/completions", `{"model":"llama3","messages":[{"role":"user","content":"Hi"}],"stream":true}`)
+	out := rec.Body.String()
+
+	// The role chunk goes out immediately; heartbeats cover the wait for the
+	// first token and stop once it arrives.
+	first := strings.Index(out, `"content":"Hel"`)
+	if first < 0 {
+		t.Fatalf("expected the first token, got %q", out)
+	}
+	if n := strings.Count(out[:first], ": keepalive\n\n"); n < 2 {
+		t.Errorf("expected heartbeats before the first token, got %d in %q", n, out[:first])
+	}
+	if strings.Contains(out[first:], "keepalive") {
+		t.Errorf("expected heartbeats to stop once content flows, got %q", out[first:])
+	}
+}
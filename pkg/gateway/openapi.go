@@ -34,6 +34,8 @@ type OpenAPIPath struct {
 	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
 	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
 	Responses   map[string]OpenAPIResponse `json:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Versions    []string                   `json:"x-versions,omitempty"`
 }
 
 type OpenAPIParameter struct {
@@ -98,6 +100,48 @@ func GenerateOpenAPI(registry *unit.Registry) []byte {
 }
 
 func (s *OpenAPISpec) addExecuteEndpoint() {
+	responses := map[string]OpenAPIResponse{
+		"200": {
+			Description: "Successful response",
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {
+					Schema: OpenAPISchema{
+						Ref: "#/components/schemas/ExecuteResponse",
+					},
+				},
+			},
+		},
+		"400": {
+			Description: "Invalid request",
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {
+					Schema: OpenAPISchema{
+						Ref: "#/components/schemas/ErrorResponse",
+					},
+				},
+			},
+		},
+		"404": {
+			Description: "Unit not found",
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {
+					Schema: OpenAPISchema{
+						Ref: "#/components/schemas/ErrorResponse",
+					},
+				},
+			},
+		},
+		"500": {
+			Description: "Internal error",
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {
+					Schema: OpenAPISchema{
+						Ref: "#/components/schemas/ErrorResponse",
+					},
+				},
+			},
+		},
+	}
 	s.Paths["/api/v2/execute"] = map[string]OpenAPIPath{
 		"post": {
 			Summary:     "Execute atomic unit",
@@ -114,48 +158,18 @@ func (s *OpenAPISpec) addExecuteEndpoint() {
 					},
 				},
 			},
-			Responses: map[string]OpenAPIResponse{
-				"200": {
-					Description: "Successful response",
-					Content: map[string]OpenAPIMediaType{
-						"application/json": {
-							Schema: OpenAPISchema{
-								Ref: "#/components/schemas/ExecuteResponse",
-							},
-						},
-					},
-				},
-				"400": {
-					Description: "Invalid request",
-					Content: map[string]OpenAPIMediaType{
-						"application/json": {
-							Schema: OpenAPISchema{
-								Ref: "#/components/schemas/ErrorResponse",
-							},
-						},
-					},
-				},
-				"404": {
-					Description: "Unit not found",
-					Content: map[string]OpenAPIMediaType{
-						"application/json": {
-							Schema: OpenAPISchema{
-								Ref: "#/components/schemas/ErrorResponse",
-							},
-						},
-					},
-				},
-				"500": {
-					Description: "Internal error",
-					Content: map[string]OpenAPIMediaType{
-						"application/json": {
-							Schema: OpenAPISchema{
-								Ref: "#/components/schemas/ErrorResponse",
-							},
-						},
-					},
-				},
+			Responses: responses,
+		},
+		"get": {
+			Summary:     "Execute query from query parameters",
+			Description: "Runs a read-only query given as query parameters, e.g. ?type=query&unit=model.list&input.limit=10. Nested input fields use dotted keys; commands are rejected.",
+			OperationID: "executeQuery",
+			Tags:        []string{"execute"},
+			Parameters: []OpenAPIParameter{
+				{Name: "unit", In: "query", Required: true, Description: "Query name", Schema: OpenAPISchema{Type: "string"}},
+				{Name: "type", In: "query", Description: "Must be query", Schema: OpenAPISchema{Type: "string"}},
 			},
+			Responses: responses,
 		},
 	}
 }
@@ -175,6 +189,8 @@ func (s *OpenAPISpec) addUnitEndpoints(registry *unit.Registry) {
 			Description: cmd.Description(),
 			OperationID: sanitizeOperationID(cmd.Name()),
 			Tags:        []string{cmd.Domain()},
+			Deprecated:  registry.DeprecationOf(cmd.Name()) != nil,
+			Versions:    registry.CommandVersions(cmd.Name()),
 			RequestBody: schemaToRequestBody(cmd.InputSchema()),
 			Responses: map[string]OpenAPIResponse{
 				"200": {
@@ -201,6 +217,7 @@ func (s *OpenAPISpec) addUnitEndpoints(registry *unit.Registry) {
 			Description: q.Description(),
 			OperationID: sanitizeOperationID(q.Name()),
 			Tags:        []string{q.Domain()},
+			Deprecated:  registry.DeprecationOf(q.Name()) != nil,
 			Responses: map[string]OpenAPIResponse{
 				"200": {
 					Description: "Successful response",
@@ -291,6 +308,10 @@ func (s *OpenAPISpec) addCommonSchemas() {
 				Type:        "string",
 				Description: "Trace ID for distributed tracing",
 			},
+			"version": {
+				Type:        "string",
+				Description: "Command version (e.g. v1); defaults to latest",
+			},
 		},
 	}
 
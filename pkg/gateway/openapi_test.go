@@ -304,3 +304,56 @@ func (m *mockOpenAPIUnit) Examples() []unit.Example  { return nil }
 func (m *mockOpenAPIUnit) Execute(ctx context.Context, input any) (any, error) {
 	return map[string]any{"success": true}, nil
 }
+
+type deprecatedOpenAPIUnit struct {
+	mockOpenAPIUnit
+}
+
+func (m *deprecatedOpenAPIUnit) Deprecation() *unit.Deprecation {
+	return &unit.Deprecation{ReplacedBy: "inference.generate"}
+}
+
+func TestGenerateOpenAPI_MarksDeprecatedUnits(t *testing.T) {
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(&deprecatedOpenAPIUnit{mockOpenAPIUnit{name: "inference.complete", domain: "inference"}})
+	_ = registry.RegisterCommand(&mockOpenAPIUnit{name: "inference.generate", domain: "inference"})
+
+	var openapi OpenAPISpec
+	if err := json.Unmarshal(GenerateOpenAPI(registry), &openapi); err != nil {
+		t.Fatalf("failed to parse openapi spec: %v", err)
+	}
+
+	for _, op := range openapi.Paths[unitToPath("inference", "inference.complete")] {
+		if !op.Deprecated {
+			t.Error("expected inference.complete to be marked deprecated")
+		}
+	}
+	for _, op := range openapi.Paths[unitToPath("inference", "inference.generate")] {
+		if op.Deprecated {
+			t.Error("expected inference.generate not to be deprecated")
+		}
+	}
+}
+
+type versionedOpenAPIUnit struct {
+	mockOpenAPIUnit
+	version string
+}
+
+func (m *versionedOpenAPIUnit) Version() string { return m.version }
+
+func TestGenerateOpenAPI_ListsCommandVersions(t *testing.T) {
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(&versionedOpenAPIUnit{mockOpenAPIUnit{name: "inference.chat", domain: "inference"}, "v2"})
+	_ = registry.RegisterCommand(&versionedOpenAPIUnit{mockOpenAPIUnit{name: "inference.chat", domain: "inference"}, "v1"})
+
+	var openapi OpenAPISpec
+	if err := json.Unmarshal(GenerateOpenAPI(registry), &openapi); err != nil {
+		t.Fatalf("failed to parse openapi spec: %v", err)
+	}
+
+	op := openapi.Paths[unitToPath("inference", "inference.chat")]["post"]
+	if len(op.Versions) != 2 || op.Versions[0] != "v1" || op.Versions[1] != "v2" {
+		t.Errorf("expected x-versions [v1 v2], got %v", op.Versions)
+	}
+}
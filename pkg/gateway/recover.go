@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// panicMessage is returned to callers in place of the panic value, which may
+// carry internal details.
+const panicMessage = "internal error"
+
+// RecoverPanics returns a middleware that turns a panic in a unit into an
+// internal-error response instead of crashing the caller's goroutine. The
+// panic is logged with its stack and the request's correlation IDs.
+func RecoverPanics(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (resp *Response) {
+			defer func() {
+				if r := recover(); r != nil {
+					errInfo := recoveredPanic(ctx, logger, req, r)
+					resp = &Response{
+						Success: false,
+						Error:   errInfo,
+						Meta:    &ResponseMeta{RequestID: unit.GetRequestID(ctx), TraceID: unit.GetTraceID(ctx)},
+					}
+				}
+			}()
+			return next(ctx, req)
+		}
+	}
+}
+
+// recoveredPanic logs a recovered panic value and returns the error reported
+// to the caller.
+func recoveredPanic(ctx context.Context, logger *slog.Logger, req *Request, r any) *ErrorInfo {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	var unitName string
+	if req != nil {
+		unitName = req.Unit
+	}
+	logger.Error("panic recovered",
+		slog.String("unit", unitName),
+		slog.String("request_id", unit.GetRequestID(ctx)),
+		slog.String("trace_id", unit.GetTraceID(ctx)),
+		slog.String("error", fmt.Sprint(r)),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return NewErrorInfo(ErrCodeInternalError, panicMessage)
+}
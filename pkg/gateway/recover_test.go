@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+func TestRecoverPanics(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&mockCommand{
+		name:   "test.boom",
+		domain: "test",
+		execute: func(ctx context.Context, input any) (any, error) {
+			var m map[string]any
+			return m["role"].(string), nil
+		},
+	})
+	var logs bytes.Buffer
+	g := NewGateway(reg, WithMiddleware(RecoverPanics(slog.New(slog.NewTextHandler(&logs, nil)))))
+
+	resp := g.Handle(unit.WithRequestID(context.Background(), "req-boom"), &Request{Type: TypeCommand, Unit: "test.boom"})
+	if resp.Success || resp.Error == nil {
+		t.Fatalf("expected an error response, got %+v", resp)
+	}
+	if resp.Error.Code != ErrCodeInternalError || resp.Error.Message != panicMessage {
+		t.Errorf("expected a safe internal error, got %+v", resp.Error)
+	}
+	if !strings.Contains(logs.String(), "request_id=req-boom") || !strings.Contains(logs.String(), "stack=") {
+		t.Errorf("expected the panic to be logged with request id and stack, got %s", logs.String())
+	}
+
+	rec := httptest.NewRecorder()
+	body := `{"type":"command","unit":"test.boom"}`
+	NewHTTPAdapter(g).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v2/execute", bytes.NewBufferString(body)))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var httpResp Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &httpResp); err != nil || httpResp.Error == nil || httpResp.Error.Code != ErrCodeInternalError {
+		t.Errorf("expected an internal error body, got %s", rec.Body.String())
+	}
+}
+
+// panickingStreamCommand panics after sending one chunk.
+type panickingStreamCommand struct {
+	testAdapterCommand
+}
+
+func (c *panickingStreamCommand) SupportsStreaming() bool { return true }
+
+func (c *panickingStreamCommand) ExecuteStream(ctx context.Context, input any, output chan<- unit.StreamChunk) error {
+	output <- unit.StreamChunk{Type: "content", Data: "partial"}
+	panic("stream exploded")
+}
+
+func TestHandleStream_RecoversPanic(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&panickingStreamCommand{testAdapterCommand{name: "test.stream_boom"}})
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer slog.SetDefault(prev)
+	g := NewGateway(reg)
+
+	stream, err := g.HandleStream(context.Background(), &Request{Type: TypeCommand, Unit: "test.stream_boom"})
+	if err != nil {
+		t.Fatalf("HandleStream failed: %v", err)
+	}
+	var last StreamResponse
+	for resp := range stream {
+		last = resp
+	}
+	if !last.Done || last.Error == nil || last.Error.Code != ErrCodeInternalError {
+		t.Errorf("expected the stream to end with an internal error, got %+v", last)
+	}
+}
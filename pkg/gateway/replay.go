@@ -0,0 +1,248 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// Redacted replaces the value of every redacted field in a recording.
+const Redacted = unit.Redacted
+
+// Recording is one line of a recording file: a request and the response the
+// gateway gave it. Redact lists the field names that were redacted, so a
+// replay can redact the live response the same way before comparing.
+type Recording struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+	Redact   []string  `json:"redact,omitempty"`
+}
+
+// Recorder writes every request and response passing through the gateway as
+// a JSON line. Fields whose name matches a redacted name, at any depth of the
+// request input or response data, are written as Redacted.
+type Recorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	enc    *json.Encoder
+	redact []string
+	// schemas, when set, supplies unit input schemas whose Sensitive fields
+	// are redacted as well.
+	schemas *unit.Registry
+	err     error
+}
+
+// NewRecorder records to w. redact names fields, such as "api_key" or
+// "token", whose values must not be written; names match case-insensitively.
+func NewRecorder(w io.Writer, redact ...string) *Recorder {
+	return &Recorder{w: w, enc: json.NewEncoder(w), redact: redact}
+}
+
+// NewFileRecorder records to the JSONL file at path, appending to it if it
+// exists.
+func NewFileRecorder(path string, redact ...string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open recording %s: %w", path, err)
+	}
+	return NewRecorder(f, redact...), nil
+}
+
+// WithSchemas also redacts the request input fields that the unit's input
+// schema in registry marks Sensitive.
+func (r *Recorder) WithSchemas(registry *unit.Registry) *Recorder {
+	r.schemas = registry
+	return r
+}
+
+// Middleware returns the gateway middleware that feeds the recorder.
+func (r *Recorder) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) *Response {
+			resp := next(ctx, req)
+			r.record(req, resp)
+			return resp
+		}
+	}
+}
+
+// Err returns the first error met while writing, if any. Recording never
+// fails a request.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (r *Recorder) Close() error {
+	if c, ok := r.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (r *Recorder) record(req *Request, resp *Response) {
+	rec := Recording{Redact: r.redact}
+	if req != nil {
+		recReq := *req
+		recReq.Input, _ = redactValue(normalize(redactInput(r.schemas, req)), r.redact).(map[string]any)
+		rec.Request = &recReq
+	}
+	if resp != nil {
+		recResp := *resp
+		recResp.Data = redactValue(normalize(resp.Data), r.redact)
+		rec.Response = &recResp
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(rec); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// ReplayMismatch describes a replayed request whose response differs from the
+// recorded one.
+type ReplayMismatch struct {
+	// Line is the 1-based line of the recording in the file.
+	Line int
+	Unit string
+	// Field is "success", "data" or "error".
+	Field string
+	Want  string
+	Got   string
+}
+
+func (m ReplayMismatch) String() string {
+	return fmt.Sprintf("line %d (%s): %s differs: want %s, got %s", m.Line, m.Unit, m.Field, m.Want, m.Got)
+}
+
+// Replay re-issues every request recorded in the file at path against g and
+// reports the responses that differ from the recorded ones. Response metadata
+// such as request IDs and durations is not compared. Redacted request fields
+// are sent as Redacted, so units that depend on them should be replayed
+// against a registry that does not.
+func Replay(ctx context.Context, g *Gateway, path string) ([]ReplayMismatch, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open recording %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var mismatches []ReplayMismatch
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var rec Recording
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return mismatches, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		if rec.Request == nil || rec.Response == nil {
+			return mismatches, fmt.Errorf("recording line %d: missing request or response", line)
+		}
+
+		got := g.Handle(ctx, rec.Request)
+		mismatches = append(mismatches, diffResponses(line, rec.Request.Unit, rec.Response, got, rec.Redact)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return mismatches, fmt.Errorf("read recording %s: %w", path, err)
+	}
+	return mismatches, nil
+}
+
+func diffResponses(line int, unitName string, want, got *Response, redact []string) []ReplayMismatch {
+	var out []ReplayMismatch
+	add := func(field string, w, g any) {
+		wj, _ := json.Marshal(w)
+		gj, _ := json.Marshal(g)
+		out = append(out, ReplayMismatch{Line: line, Unit: unitName, Field: field, Want: string(wj), Got: string(gj)})
+	}
+
+	if want.Success != got.Success {
+		add("success", want.Success, got.Success)
+	}
+	wantData := normalize(want.Data)
+	gotData := redactValue(normalize(got.Data), redact)
+	if !reflect.DeepEqual(wantData, gotData) {
+		add("data", wantData, gotData)
+	}
+	if !sameError(want.Error, got.Error) {
+		add("error", want.Error, got.Error)
+	}
+	return out
+}
+
+func sameError(a, b *ErrorInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Code == b.Code && a.Message == b.Message
+}
+
+// normalize converts v to its generic JSON form so values decoded from a
+// recording compare equal to the live values they came from.
+func normalize(v any) any {
+	if v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// redactValue returns v with the value of every field named in redact
+// replaced. v must be in the generic JSON form returned by normalize.
+func redactValue(v any, redact []string) any {
+	if len(redact) == 0 {
+		return v
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if isRedacted(k, redact) {
+				out[k] = Redacted
+				continue
+			}
+			out[k] = redactValue(val, redact)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = redactValue(val, redact)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isRedacted(key string, redact []string) bool {
+	for _, r := range redact {
+		if strings.EqualFold(key, r) {
+			return true
+		}
+	}
+	return false
+}
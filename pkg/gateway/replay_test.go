@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+func deterministicRegistry(greeting string) *unit.Registry {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&mockCommand{name: "test.greet", domain: "test", execute: func(ctx context.Context, input any) (any, error) {
+		in, _ := input.(map[string]any)
+		return map[string]any{"message": greeting + " " + in["name"].(string), "token": "issued-secret"}, nil
+	}})
+	_ = reg.RegisterQuery(&mockQuery{name: "test.fail", domain: "test", execute: func(ctx context.Context, input any) (any, error) {
+		return nil, errors.New("always fails")
+	}})
+	return reg
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "traffic.jsonl")
+
+	recorder, err := NewFileRecorder(path, "api_key", "token")
+	if err != nil {
+		t.Fatalf("NewFileRecorder: %v", err)
+	}
+	g := NewGateway(deterministicRegistry("hello"), WithMiddleware(recorder.Middleware()))
+
+	resp := g.Handle(ctx, &Request{Type: TypeCommand, Unit: "test.greet", Input: map[string]any{"name": "ada", "api_key": "sk-live"}})
+	if !resp.Success {
+		t.Fatalf("greet failed: %+v", resp.Error)
+	}
+	if data := resp.Data.(map[string]any); data["token"] != "issued-secret" {
+		t.Errorf("recording must not alter the live response, got %v", data)
+	}
+	_ = g.Handle(ctx, &Request{Type: TypeQuery, Unit: "test.fail"})
+	if err := recorder.Err(); err != nil {
+		t.Fatalf("recorder error: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read recording: %v", err)
+	}
+	if lines := strings.Count(string(raw), "\n"); lines != 2 {
+		t.Errorf("expected 2 recorded lines, got %d", lines)
+	}
+	if strings.Contains(string(raw), "sk-live") || strings.Contains(string(raw), "issued-secret") {
+		t.Errorf("expected secrets to be redacted, got %s", raw)
+	}
+
+	t.Run("same registry matches", func(t *testing.T) {
+		mismatches, err := Replay(ctx, NewGateway(deterministicRegistry("hello")), path)
+		if err != nil {
+			t.Fatalf("Replay: %v", err)
+		}
+		if len(mismatches) != 0 {
+			t.Errorf("expected no mismatches, got %v", mismatches)
+		}
+	})
+
+	t.Run("changed behavior is flagged", func(t *testing.T) {
+		mismatches, err := Replay(ctx, NewGateway(deterministicRegistry("goodbye")), path)
+		if err != nil {
+			t.Fatalf("Replay: %v", err)
+		}
+		if len(mismatches) != 1 {
+			t.Fatalf("expected 1 mismatch, got %v", mismatches)
+		}
+		m := mismatches[0]
+		if m.Line != 1 || m.Unit != "test.greet" || m.Field != "data" || !strings.Contains(m.Got, "goodbye ada") {
+			t.Errorf("unexpected mismatch: %s", m)
+		}
+	})
+}
+
+func TestReplay_InvalidRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.jsonl")
+	_ = os.WriteFile(path, []byte("{not json}\n"), 0600)
+
+	if _, err := Replay(context.Background(), NewGateway(nil), path); err == nil {
+		t.Error("expected an error for a malformed recording")
+	}
+	if _, err := Replay(context.Background(), NewGateway(nil), filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("expected an error for a missing recording")
+	}
+}
+
+func TestWithMiddleware_Order(t *testing.T) {
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) *Response {
+				order = append(order, name)
+				return next(ctx, req)
+			}
+		}
+	}
+
+	g := NewGateway(deterministicRegistry("hi"), WithMiddleware(mw("outer"), mw("inner")))
+	g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "test.greet", Input: map[string]any{"name": "x"}})
+
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("expected outer then inner, got %v", order)
+	}
+}
@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// LogRequests returns a middleware that logs every request at debug level
+// with its input and outcome. Input fields marked Sensitive in the schema of
+// the unit found in registry are logged as unit.Redacted.
+func LogRequests(logger *slog.Logger, registry *unit.Registry) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) *Response {
+			start := time.Now()
+			resp := next(ctx, req)
+			if req == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+				return resp
+			}
+
+			attrs := []slog.Attr{
+				slog.String("type", req.Type),
+				slog.String("unit", req.Unit),
+				slog.Any("input", redactInput(registry, req)),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("request_id", unit.GetRequestID(ctx)),
+			}
+			if resp != nil {
+				attrs = append(attrs, slog.Bool("success", resp.Success))
+				if resp.Error != nil {
+					attrs = append(attrs, slog.String("error_code", resp.Error.Code))
+				}
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "gateway request", attrs...)
+			return resp
+		}
+	}
+}
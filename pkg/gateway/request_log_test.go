@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+func sensitiveRegistry() *unit.Registry {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&mockCommand{
+		name:   "test.pull",
+		domain: "test",
+		input: unit.Schema{
+			Type: "object",
+			Properties: map[string]unit.Field{
+				"repo":  {Name: "repo", Schema: unit.Schema{Type: "string"}},
+				"token": {Name: "token", Schema: unit.Schema{Type: "string", Sensitive: true}},
+			},
+		},
+	})
+	return reg
+}
+
+func TestLogRequests_RedactsSensitiveFields(t *testing.T) {
+	reg := sensitiveRegistry()
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	g := NewGateway(reg, WithMiddleware(LogRequests(logger, reg)))
+
+	resp := g.Handle(context.Background(), &Request{
+		Type:  TypeCommand,
+		Unit:  "test.pull",
+		Input: map[string]any{"repo": "meta-llama/Llama-3-8B", "token": "hf_live_secret"},
+	})
+	if !resp.Success {
+		t.Fatalf("expected success, got %+v", resp.Error)
+	}
+
+	out := logs.String()
+	if strings.Contains(out, "hf_live_secret") {
+		t.Errorf("expected the token to be redacted, got %s", out)
+	}
+	if !strings.Contains(out, unit.Redacted) || !strings.Contains(out, "meta-llama/Llama-3-8B") {
+		t.Errorf("expected the redacted input with non-sensitive fields, got %s", out)
+	}
+}
+
+func TestRecorder_RedactsSensitiveFields(t *testing.T) {
+	reg := sensitiveRegistry()
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf).WithSchemas(reg)
+	g := NewGateway(reg, WithMiddleware(recorder.Middleware()))
+
+	_ = g.Handle(context.Background(), &Request{
+		Type:  TypeCommand,
+		Unit:  "test.pull",
+		Input: map[string]any{"repo": "meta-llama/Llama-3-8B", "token": "hf_live_secret"},
+	})
+	if strings.Contains(buf.String(), "hf_live_secret") || !strings.Contains(buf.String(), unit.Redacted) {
+		t.Errorf("expected the token to be redacted in the recording, got %s", buf.String())
+	}
+}
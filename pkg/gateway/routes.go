@@ -16,12 +16,18 @@ type Route struct {
 	Unit        string
 	Type        string
 	InputMapper func(r *http.Request, pathParams map[string]string) map[string]any
+	// Plain routes answer with the unit's output as the response body instead
+	// of the gateway envelope. Failures are written as {"error": {...}}.
+	Plain bool
 }
 
 type Router struct {
 	routes             []Route
 	gateway            *Gateway
 	pathParamExtractor *pathParamExtractor
+	// corsHeaders makes the router allow every origin itself. Servers that
+	// apply the CORS middleware turn it off so the middleware decides.
+	corsHeaders bool
 }
 
 func NewRouter(gateway *Gateway) *Router {
@@ -29,9 +35,17 @@ func NewRouter(gateway *Gateway) *Router {
 		routes:             defaultRoutes(),
 		gateway:            gateway,
 		pathParamExtractor: newPathParamExtractor(),
+		corsHeaders:        true,
 	}
 }
 
+// WithCORSHeaders sets whether the router writes its own allow-all CORS
+// headers and answers OPTIONS requests. It returns the router.
+func (r *Router) WithCORSHeaders(enabled bool) *Router {
+	r.corsHeaders = enabled
+	return r
+}
+
 func (r *Router) AddRoute(route Route) {
 	r.routes = append(r.routes, route)
 }
@@ -40,6 +54,25 @@ func (r *Router) Routes() []Route {
 	return r.routes
 }
 
+// UnitFor returns the unit the route matching req runs, or "" when no route
+// matches. HEAD requests match GET routes, as in ServeHTTP. Auth uses it to
+// hold REST requests to their unit's level without trusting X-Unit.
+func (r *Router) UnitFor(req *http.Request) string {
+	method := req.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	for _, route := range r.routes {
+		if route.Method != method {
+			continue
+		}
+		if _, ok := r.pathParamExtractor.match(route.Path, req.URL.Path); ok {
+			return route.Unit
+		}
+	}
+	return ""
+}
+
 // corsHeaders writes CORS headers to every response so that browser clients
 // can call the REST API without requiring a separate proxy.
 func corsHeaders(w http.ResponseWriter) {
@@ -50,7 +83,9 @@ func corsHeaders(w http.ResponseWriter) {
 
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	// Bug #46: handle OPTIONS preflight and add CORS headers to all responses.
-	corsHeaders(w)
+	if r.corsHeaders {
+		corsHeaders(w)
+	}
 	if req.Method == http.MethodOptions {
 		w.WriteHeader(http.StatusOK)
 		return
@@ -136,22 +171,53 @@ func (r *Router) handleRoute(w http.ResponseWriter, httpReq *http.Request, route
 
 	resp := r.gateway.Handle(ctx, req)
 
+	if route.Plain {
+		writePlainResponse(w, resp)
+		return
+	}
+
 	// Bug #44: use the existing errorToStatusCode logic (already in http_adapter.go)
 	// via writeResponse, which already maps error codes to HTTP statuses.
 	NewHTTPAdapter(r.gateway).writeResponse(w, resp)
 }
 
+// writePlainResponse writes resp for a Plain route: the unit output on
+// success, or only the error object with the mapped status code on failure.
+func writePlainResponse(w http.ResponseWriter, resp *Response) {
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	if resp.Meta != nil {
+		if resp.Meta.RequestID != "" {
+			w.Header().Set(HeaderRequestID, resp.Meta.RequestID)
+		}
+		if resp.Meta.TraceID != "" {
+			w.Header().Set(HeaderTraceID, resp.Meta.TraceID)
+		}
+	}
+
+	if !resp.Success {
+		w.WriteHeader(errorToStatusCode(resp.Error))
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": resp.Error})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp.Data)
+}
+
 func defaultRoutes() []Route {
 	return []Route{
 		{Method: http.MethodPost, Path: "/api/v2/models/pull", Unit: "model.pull", Type: TypeCommand, InputMapper: bodyInputMapper},
 		{Method: http.MethodPost, Path: "/api/v2/models/create", Unit: "model.create", Type: TypeCommand, InputMapper: bodyInputMapper},
-		{Method: http.MethodDelete, Path: "/api/v2/models/{id}", Unit: "model.delete", Type: TypeCommand, InputMapper: modelIDInputMapper},
-		{Method: http.MethodGet, Path: "/api/v2/models", Unit: "model.list", Type: TypeQuery, InputMapper: queryInputMapper},
-		{Method: http.MethodGet, Path: "/api/v2/models/{id}", Unit: "model.get", Type: TypeQuery, InputMapper: modelIDInputMapper},
+		{Method: http.MethodPost, Path: "/api/v2/models/prune", Unit: "model.prune", Type: TypeCommand, InputMapper: bodyInputMapper},
+		// REST-style model CRUD answers with plain JSON rather than the envelope.
+		{Method: http.MethodPost, Path: "/api/v2/models", Unit: "model.pull", Type: TypeCommand, InputMapper: bodyInputMapper, Plain: true},
+		{Method: http.MethodDelete, Path: "/api/v2/models/{id}", Unit: "model.delete", Type: TypeCommand, InputMapper: modelIDInputMapper, Plain: true},
+		{Method: http.MethodGet, Path: "/api/v2/models", Unit: "model.list", Type: TypeQuery, InputMapper: queryInputMapper, Plain: true},
+		{Method: http.MethodGet, Path: "/api/v2/models/{id}", Unit: "model.get", Type: TypeQuery, InputMapper: modelIDInputMapper, Plain: true},
 
 		{Method: http.MethodPost, Path: "/api/v2/inference/chat", Unit: "inference.chat", Type: TypeCommand, InputMapper: bodyInputMapper},
 		{Method: http.MethodPost, Path: "/api/v2/inference/complete", Unit: "inference.complete", Type: TypeCommand, InputMapper: bodyInputMapper},
 		{Method: http.MethodPost, Path: "/api/v2/inference/embed", Unit: "inference.embed", Type: TypeCommand, InputMapper: bodyInputMapper},
+		{Method: http.MethodPost, Path: "/api/v2/inference/benchmark", Unit: "inference.benchmark", Type: TypeCommand, InputMapper: bodyInputMapper},
 
 		{Method: http.MethodGet, Path: "/api/v2/devices", Unit: "device.detect", Type: TypeCommand, InputMapper: emptyInputMapper},
 		{Method: http.MethodGet, Path: "/api/v2/devices/{id}", Unit: "device.info", Type: TypeQuery, InputMapper: deviceIDInputMapper},
@@ -183,6 +249,7 @@ func defaultRoutes() []Route {
 		{Method: http.MethodGet, Path: "/api/v2/catalog/recipes", Unit: "catalog.list", Type: TypeQuery, InputMapper: queryInputMapper},
 		{Method: http.MethodGet, Path: "/api/v2/catalog/recipes/{id}/status", Unit: "catalog.check_status", Type: TypeQuery, InputMapper: recipeIDInputMapper},
 		{Method: http.MethodGet, Path: "/api/v2/catalog/recipes/{id}", Unit: "catalog.get", Type: TypeQuery, InputMapper: recipeIDInputMapper},
+		{Method: http.MethodPost, Path: "/api/v2/catalog/reload", Unit: "catalog.reload", Type: TypeCommand, InputMapper: bodyInputMapper},
 
 		// Skill domain
 		{Method: http.MethodPost, Path: "/api/v2/skills", Unit: "skill.add", Type: TypeCommand, InputMapper: bodyInputMapper},
@@ -226,7 +293,9 @@ func defaultRoutes() []Route {
 		{Method: http.MethodPost, Path: "/api/v2/inference/generate-video", Unit: "inference.generate_video", Type: TypeCommand, InputMapper: bodyInputMapper},
 		{Method: http.MethodPost, Path: "/api/v2/inference/rerank", Unit: "inference.rerank", Type: TypeCommand, InputMapper: bodyInputMapper},
 		{Method: http.MethodPost, Path: "/api/v2/inference/detect", Unit: "inference.detect", Type: TypeCommand, InputMapper: bodyInputMapper},
+		{Method: http.MethodPost, Path: "/api/v2/inference/cancel", Unit: "inference.cancel", Type: TypeCommand, InputMapper: bodyInputMapper},
 		{Method: http.MethodGet, Path: "/api/v2/inference/voices", Unit: "inference.voices", Type: TypeQuery, InputMapper: emptyInputMapper},
+		{Method: http.MethodGet, Path: "/api/v2/providers/health", Unit: "provider.health", Type: TypeQuery, InputMapper: emptyInputMapper},
 
 		// model — additional operations
 		{Method: http.MethodPost, Path: "/api/v2/models/import", Unit: "model.import", Type: TypeCommand, InputMapper: bodyInputMapper},
@@ -242,6 +311,7 @@ func defaultRoutes() []Route {
 		// resource — additional queries and update
 		{Method: http.MethodGet, Path: "/api/v2/resource/budget", Unit: "resource.budget", Type: TypeQuery, InputMapper: emptyInputMapper},
 		{Method: http.MethodGet, Path: "/api/v2/resource/allocations", Unit: "resource.allocations", Type: TypeQuery, InputMapper: emptyInputMapper},
+		{Method: http.MethodGet, Path: "/api/v2/resource/summary", Unit: "resource.summary", Type: TypeQuery, InputMapper: emptyInputMapper},
 		{Method: http.MethodGet, Path: "/api/v2/resource/can-allocate", Unit: "resource.can_allocate", Type: TypeQuery, InputMapper: queryInputMapper},
 		{Method: http.MethodPut, Path: "/api/v2/resource/slots/{id}", Unit: "resource.update_slot", Type: TypeCommand, InputMapper: slotIDInputMapper},
 
@@ -254,9 +324,12 @@ func defaultRoutes() []Route {
 		{Method: http.MethodPost, Path: "/api/v2/services/{id}/scale", Unit: "service.scale", Type: TypeCommand, InputMapper: serviceIDBodyMapper},
 		{Method: http.MethodPost, Path: "/api/v2/services/{id}/start", Unit: "service.start", Type: TypeCommand, InputMapper: serviceIDInputMapper},
 		{Method: http.MethodPost, Path: "/api/v2/services/{id}/stop", Unit: "service.stop", Type: TypeCommand, InputMapper: serviceIDBodyMapper},
+		{Method: http.MethodPost, Path: "/api/v2/services/{id}/pin", Unit: "service.pin", Type: TypeCommand, InputMapper: serviceIDBodyMapper},
+		{Method: http.MethodPost, Path: "/api/v2/services/{id}/unpin", Unit: "service.unpin", Type: TypeCommand, InputMapper: serviceIDBodyMapper},
 		{Method: http.MethodGet, Path: "/api/v2/services/{id}/recommend", Unit: "service.recommend", Type: TypeQuery, InputMapper: serviceIDInputMapper},
 		{Method: http.MethodGet, Path: "/api/v2/services/{id}/status", Unit: "service.status", Type: TypeQuery, InputMapper: serviceIDInputMapper},
 		{Method: http.MethodGet, Path: "/api/v2/services/{id}/logs", Unit: "service.logs", Type: TypeQuery, InputMapper: serviceIDInputMapper},
+		{Method: http.MethodGet, Path: "/api/v2/services/{id}/describe", Unit: "service.describe", Type: TypeQuery, InputMapper: serviceIDInputMapper},
 
 		// app — lifecycle, logs and templates
 		{Method: http.MethodDelete, Path: "/api/v2/apps/{id}", Unit: "app.uninstall", Type: TypeCommand, InputMapper: appIDInputMapper},
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -146,12 +147,10 @@ func TestRouter_QueryParams(t *testing.T) {
 		t.Errorf("expected status 200, got %d", rec.Code)
 	}
 
-	var resp Response
-	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
-
-	data, ok := resp.Data.(map[string]any)
-	if !ok {
-		t.Fatal("expected data to be map")
+	// /api/v2/models is a plain route: the body is the unit output itself.
+	var data map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &data); err != nil {
+		t.Fatalf("expected a JSON object, got %s", rec.Body.String())
 	}
 
 	if data["type"] != "llm" {
@@ -407,3 +406,116 @@ func TestInputMappers(t *testing.T) {
 		}
 	})
 }
+
+func TestRouter_ModelsREST(t *testing.T) {
+	reg := unit.NewRegistry()
+
+	var calls []string
+	record := func(name string) func(ctx context.Context, input any) (any, error) {
+		return func(ctx context.Context, input any) (any, error) {
+			calls = append(calls, name)
+			in, _ := input.(map[string]any)
+			switch name {
+			case "model.list":
+				return map[string]any{"items": []any{map[string]any{"id": "llama3"}}, "total": 1}, nil
+			case "model.get":
+				if in["model_id"] == "broken" {
+					return nil, errors.New("store unavailable")
+				}
+				return map[string]any{"id": in["model_id"], "name": "Llama 3"}, nil
+			case "model.pull":
+				return map[string]any{"model_id": "pulled", "source": in["source"], "repo": in["repo"]}, nil
+			default:
+				return map[string]any{"success": true, "model_id": in["model_id"]}, nil
+			}
+		}
+	}
+	_ = reg.RegisterQuery(&mockQuery{name: "model.list", domain: "model", execute: record("model.list")})
+	_ = reg.RegisterQuery(&mockQuery{name: "model.get", domain: "model", execute: record("model.get")})
+	_ = reg.RegisterCommand(&mockCommand{name: "model.pull", domain: "model", execute: record("model.pull")})
+	_ = reg.RegisterCommand(&mockCommand{name: "model.delete", domain: "model", execute: record("model.delete")})
+
+	router := NewRouter(NewGateway(reg))
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantUnit string
+		wantCode int
+		check    func(t *testing.T, body map[string]any)
+	}{
+		{
+			name: "list", method: http.MethodGet, path: "/api/v2/models",
+			wantUnit: "model.list", wantCode: http.StatusOK,
+			check: func(t *testing.T, body map[string]any) {
+				if body["total"] != float64(1) {
+					t.Errorf("expected plain list output, got %v", body)
+				}
+			},
+		},
+		{
+			name: "get", method: http.MethodGet, path: "/api/v2/models/llama3",
+			wantUnit: "model.get", wantCode: http.StatusOK,
+			check: func(t *testing.T, body map[string]any) {
+				if body["id"] != "llama3" || body["name"] != "Llama 3" {
+					t.Errorf("expected plain model output, got %v", body)
+				}
+			},
+		},
+		{
+			name: "get failure", method: http.MethodGet, path: "/api/v2/models/broken",
+			wantUnit: "model.get", wantCode: http.StatusInternalServerError,
+			check: func(t *testing.T, body map[string]any) {
+				if _, ok := body["error"].(map[string]any); !ok {
+					t.Errorf("expected an error object, got %v", body)
+				}
+				if _, ok := body["success"]; ok {
+					t.Errorf("expected no envelope, got %v", body)
+				}
+			},
+		},
+		{
+			name: "pull", method: http.MethodPost, path: "/api/v2/models", body: `{"source":"ollama","repo":"llama3"}`,
+			wantUnit: "model.pull", wantCode: http.StatusOK,
+			check: func(t *testing.T, body map[string]any) {
+				if body["source"] != "ollama" || body["repo"] != "llama3" {
+					t.Errorf("expected body to be passed to model.pull, got %v", body)
+				}
+			},
+		},
+		{
+			name: "delete", method: http.MethodDelete, path: "/api/v2/models/llama3",
+			wantUnit: "model.delete", wantCode: http.StatusOK,
+			check: func(t *testing.T, body map[string]any) {
+				if body["model_id"] != "llama3" {
+					t.Errorf("expected model_id from the path, got %v", body)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", ContentTypeJSON)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if len(calls) != 1 || calls[0] != tt.wantUnit {
+				t.Errorf("expected %s to run, got %v", tt.wantUnit, calls)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("expected a JSON object, got %s", rec.Body.String())
+			}
+			tt.check(t, body)
+		})
+	}
+}
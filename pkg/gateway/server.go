@@ -21,6 +21,12 @@ type ServerConfig struct {
 	EnableAuth      bool
 	AuthConfig      middleware.AuthConfig
 	Logger          *slog.Logger
+	// OpenAIModels maps model names sent to the OpenAI facade to AIMA
+	// models.
+	OpenAIModels map[string]string
+	// SSEKeepAlive is how often SSE streams send a keepalive comment before
+	// their first chunk. Zero uses DefaultSSEKeepAlive; negative disables.
+	SSEKeepAlive time.Duration
 }
 
 // longOperationTimeout is the maximum duration allowed for long-running HTTP
@@ -44,6 +50,7 @@ func DefaultServerConfig() ServerConfig {
 		WriteTimeout:    longOperationTimeout,
 		IdleTimeout:     defaultIdleTimeout,
 		ShutdownTimeout: 10 * time.Second,
+		SSEKeepAlive:    DefaultSSEKeepAlive,
 		EnableCORS:      false,
 		CORSConfig:      middleware.DefaultCORSConfig(),
 		EnableAuth:      false,
@@ -76,8 +83,11 @@ func NewServer(gateway *Gateway, config ServerConfig) *Server {
 	if config.ShutdownTimeout == 0 {
 		config.ShutdownTimeout = 10 * time.Second
 	}
+	if config.SSEKeepAlive == 0 {
+		config.SSEKeepAlive = DefaultSSEKeepAlive
+	}
 
-	router := NewRouter(gateway)
+	router := NewRouter(gateway).WithCORSHeaders(!config.EnableCORS)
 
 	s := &Server{
 		gateway: gateway,
@@ -91,6 +101,7 @@ func NewServer(gateway *Gateway, config ServerConfig) *Server {
 	mux := http.NewServeMux()
 	handler := s.buildHandler()
 	mux.Handle("/api/v2/", handler)
+	mux.Handle("/v1/", s.withMiddleware(NewOpenAIAdapter(gateway).WithModelMap(config.OpenAIModels).WithKeepAlive(config.SSEKeepAlive)))
 	mux.HandleFunc("/openapi.json", s.handleOpenAPI)
 	mux.HandleFunc("/health", s.handleHealth)
 
@@ -118,23 +129,31 @@ func (s *Server) Start() error {
 }
 
 func (s *Server) buildHandler() http.Handler {
-	var handler http.Handler
-
-	executeHandler := NewHTTPAdapter(s.gateway)
+	executeHandler := NewHTTPAdapter(s.gateway).WithKeepAlive(s.config.SSEKeepAlive)
 	routerHandler := s.router
 
-	handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/api/v2/execute" && r.Method == http.MethodPost {
+	return s.withMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/execute" && (r.Method == http.MethodPost || r.Method == http.MethodGet) {
 			executeHandler.ServeHTTP(w, r)
 			return
 		}
 		routerHandler.ServeHTTP(w, r)
-	})
+	}))
+}
 
+// withMiddleware wraps handler in the server's auth, CORS, logging and
+// recovery middleware.
+func (s *Server) withMiddleware(handler http.Handler) http.Handler {
 	// Auth middleware: wire the logger and the global Enabled flag from server config.
 	authCfg := s.config.AuthConfig
 	authCfg.Enabled = s.config.EnableAuth
 	authCfg.Logger = s.logger
+	if authCfg.ResolveUnit == nil {
+		authCfg.ResolveUnit = s.gateway.Registry().CanonicalName
+	}
+	if authCfg.RouteUnit == nil {
+		authCfg.RouteUnit = s.router.UnitFor
+	}
 	handler = middleware.Auth(authCfg)(handler)
 
 	// CORS must run before Auth so that browser preflight OPTIONS requests
@@ -136,6 +136,35 @@ func TestServer_BuildHandler(t *testing.T) {
 	})
 }
 
+func TestServer_ForcedRouteNeedsToken(t *testing.T) {
+	reg := unit.NewRegistry()
+	ran := false
+	_ = reg.RegisterCommand(&mockCommand{name: "model.prune", domain: "model", execute: func(ctx context.Context, input any) (any, error) {
+		ran = true
+		return map[string]any{"removed": 0}, nil
+	}})
+
+	cfg := DefaultServerConfig()
+	cfg.AuthConfig.APIKeys = []string{"secret"}
+	handler := NewServer(NewGateway(reg), cfg).buildHandler()
+
+	// REST clients send no X-Unit; the route alone must select Forced.
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/models/prune", bytes.NewBufferString(`{}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || ran {
+		t.Fatalf("expected 401 without running model.prune, got %d (ran=%v)", rec.Code, ran)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v2/models/prune", bytes.NewBufferString(`{}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !ran {
+		t.Errorf("expected model.prune to run with a valid token, got %d", rec.Code)
+	}
+}
+
 func TestServer_Accessors(t *testing.T) {
 	g := NewGateway(nil)
 	config := ServerConfig{
@@ -269,3 +298,31 @@ func TestServer_PanicRecovery(t *testing.T) {
 		t.Errorf("expected internal error in response, got %s", respBody)
 	}
 }
+
+func TestServer_OpenAIRoutes(t *testing.T) {
+	reg := unit.NewRegistry()
+	_ = reg.RegisterCommand(&mockCommand{name: "inference.chat", domain: "inference", execute: func(ctx context.Context, input any) (any, error) {
+		return map[string]any{"content": "pong", "finish_reason": "stop"}, nil
+	}})
+
+	s := NewServer(NewGateway(reg), ServerConfig{
+		EnableAuth: true,
+		AuthConfig: middleware.AuthConfig{APIKeys: []string{"secret"}},
+	})
+
+	body := `{"model":"llama3","messages":[{"role":"user","content":"ping"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	s.http.Handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected the OpenAI routes to be behind auth, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	s.http.Handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"pong"`) {
+		t.Errorf("expected chat completion through the server mux, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
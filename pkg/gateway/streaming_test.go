@@ -270,3 +270,85 @@ func containsInternal(s, substr string) bool {
 	}
 	return false
 }
+
+// endlessStreamCommand emits content chunks until its context is cancelled.
+type endlessStreamCommand struct {
+	testAdapterCommand
+}
+
+func (c *endlessStreamCommand) SupportsStreaming() bool { return true }
+
+func (c *endlessStreamCommand) ExecuteStream(ctx context.Context, input any, output chan<- unit.StreamChunk) error {
+	for i := 0; ; i++ {
+		select {
+		case output <- unit.StreamChunk{Type: "content", Data: map[string]any{"chunk": i}}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHandleStream_CancelByRequestID(t *testing.T) {
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(&endlessStreamCommand{testAdapterCommand{name: "test.endless", domain: "test"}})
+
+	streams := unit.NewStreamTracker()
+	_ = registry.RegisterCommand(inference.NewCancelCommand(streams))
+	gateway := NewGateway(registry, WithStreamTracker(streams))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := gateway.HandleStream(unit.WithRequestID(ctx, "req-cancel-me"), &Request{
+		Type: TypeCommand,
+		Unit: "test.endless",
+	})
+	if err != nil {
+		t.Fatalf("HandleStream() error = %v", err)
+	}
+
+	// Wait for the stream to produce output before cancelling it.
+	if first := <-stream; first.Done || first.Error != nil {
+		t.Fatalf("unexpected first chunk: %+v", first)
+	}
+	if streams.Active() != 1 {
+		t.Fatalf("expected 1 tracked stream, got %d", streams.Active())
+	}
+
+	resp := gateway.Handle(ctx, &Request{
+		Type:  TypeCommand,
+		Unit:  "inference.cancel",
+		Input: map[string]any{"request_id": "req-cancel-me"},
+	})
+	if !resp.Success {
+		t.Fatalf("inference.cancel failed: %+v", resp.Error)
+	}
+
+	var last StreamResponse
+	for chunk := range stream {
+		last = chunk
+	}
+	if !last.Cancelled || !last.Done {
+		t.Errorf("expected cancelled terminal chunk, got %+v", last)
+	}
+	if last.RequestID != "req-cancel-me" {
+		t.Errorf("expected request ID req-cancel-me, got %q", last.RequestID)
+	}
+	if last.Error != nil {
+		t.Errorf("cancelled stream should not report an error, got %+v", last.Error)
+	}
+	if streams.Active() != 0 {
+		t.Errorf("expected no tracked streams after cancel, got %d", streams.Active())
+	}
+
+	// A second cancel finds nothing to stop.
+	resp = gateway.Handle(ctx, &Request{
+		Type:  TypeCommand,
+		Unit:  "inference.cancel",
+		Input: map[string]any{"request_id": "req-cancel-me"},
+	})
+	if resp.Success {
+		t.Error("expected cancelling a finished stream to fail")
+	}
+}
@@ -0,0 +1,66 @@
+package gateway
+
+import "maps"
+
+// WithSystemPrompts injects a system message into command inputs that carry
+// "messages", keyed by unit domain (e.g. {"inference": "Answer politely."}).
+// The prompt is only added when the caller sent no system message of its
+// own, so caller-provided system messages are left untouched.
+func WithSystemPrompts(prompts map[string]string) GatewayOption {
+	return func(g *Gateway) {
+		g.systemPrompts = prompts
+	}
+}
+
+// injectSystemPrompt returns req with the configured system prompt for its
+// domain prepended to the input messages. req itself is never modified; it
+// is returned as-is when nothing needs injecting.
+func (g *Gateway) injectSystemPrompt(req *Request) *Request {
+	if req.Type != TypeCommand {
+		return req
+	}
+	prompt := g.systemPrompts[unitDomain(req.Unit)]
+	if prompt == "" {
+		return req
+	}
+
+	messages, ok := prependSystemMessage(req.Input["messages"], prompt)
+	if !ok {
+		return req
+	}
+
+	injected := *req
+	injected.Input = maps.Clone(req.Input)
+	injected.Input["messages"] = messages
+	return &injected
+}
+
+// prependSystemMessage returns raw with a system message first. It reports
+// false when raw is not a message list or already has a system message.
+func prependSystemMessage(raw any, prompt string) (any, bool) {
+	switch msgs := raw.(type) {
+	case []any:
+		for _, m := range msgs {
+			if mm, ok := m.(map[string]any); ok && mm["role"] == "system" {
+				return nil, false
+			}
+		}
+		return append([]any{map[string]any{"role": "system", "content": prompt}}, msgs...), true
+	case []map[string]any:
+		for _, m := range msgs {
+			if m["role"] == "system" {
+				return nil, false
+			}
+		}
+		return append([]map[string]any{{"role": "system", "content": prompt}}, msgs...), true
+	case []map[string]string:
+		for _, m := range msgs {
+			if m["role"] == "system" {
+				return nil, false
+			}
+		}
+		return append([]map[string]string{{"role": "system", "content": prompt}}, msgs...), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+func TestHandle_SystemPrompt(t *testing.T) {
+	var got []any
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(&mockCommand{
+		name:   "inference.chat",
+		domain: "inference",
+		execute: func(ctx context.Context, input any) (any, error) {
+			got = input.(map[string]any)["messages"].([]any)
+			return map[string]any{"content": "ok"}, nil
+		},
+	})
+	g := NewGateway(registry, WithSystemPrompts(map[string]string{"inference": "Be safe."}))
+
+	t.Run("injected when absent", func(t *testing.T) {
+		input := map[string]any{"model": "llama3", "messages": []any{
+			map[string]any{"role": "user", "content": "Hi"},
+		}}
+		resp := g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "inference.chat", Input: input})
+		if !resp.Success {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+		if len(got) != 2 {
+			t.Fatalf("expected the system prompt to be prepended, got %v", got)
+		}
+		if first := got[0].(map[string]any); first["role"] != "system" || first["content"] != "Be safe." {
+			t.Errorf("expected the configured system prompt first, got %v", first)
+		}
+		if len(input["messages"].([]any)) != 1 {
+			t.Error("expected the caller's input to be left unmodified")
+		}
+	})
+
+	t.Run("no-op when present", func(t *testing.T) {
+		input := map[string]any{"model": "llama3", "messages": []any{
+			map[string]any{"role": "system", "content": "Talk like a pirate."},
+			map[string]any{"role": "user", "content": "Hi"},
+		}}
+		resp := g.Handle(context.Background(), &Request{Type: TypeCommand, Unit: "inference.chat", Input: input})
+		if !resp.Success {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+		if len(got) != 2 || got[0].(map[string]any)["content"] != "Talk like a pirate." {
+			t.Errorf("expected the caller's system message to be kept as-is, got %v", got)
+		}
+	})
+}
+
+func TestPrependSystemMessage_TypedSlices(t *testing.T) {
+	out, ok := prependSystemMessage([]map[string]string{{"role": "user", "content": "Hi"}}, "Be safe.")
+	if msgs, _ := out.([]map[string]string); !ok || len(msgs) != 2 || msgs[0]["role"] != "system" {
+		t.Errorf("expected a system message prepended to []map[string]string, got %v", out)
+	}
+
+	if _, ok := prependSystemMessage([]map[string]any{{"role": "system", "content": "x"}}, "Be safe."); ok {
+		t.Error("expected an existing system message to prevent injection")
+	}
+	if _, ok := prependSystemMessage("not a list", "Be safe."); ok {
+		t.Error("expected non-list input to be ignored")
+	}
+}
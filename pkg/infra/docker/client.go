@@ -1,6 +1,12 @@
 package docker
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
 
 // ContainerEvent represents a Docker container lifecycle event.
 type ContainerEvent struct {
@@ -12,6 +18,57 @@ type ContainerEvent struct {
 	Status string
 }
 
+// DefaultNetwork is the bridge network AIMA places engine containers on so
+// they can reach each other by container name.
+const DefaultNetwork = "aima"
+
+// IsNamedVolume reports whether a ContainerOptions.Volumes source refers to a
+// named Docker volume rather than a host path.
+func IsNamedVolume(source string) bool {
+	return source != "" && !strings.HasPrefix(source, "/") && !strings.HasPrefix(source, ".") && !strings.HasPrefix(source, "~")
+}
+
+// envName matches a portable environment variable name.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// unsafeEnv lists variables that let a value load arbitrary code into every
+// process in the container, so services may not set them.
+var unsafeEnv = map[string]bool{
+	"LD_PRELOAD":            true,
+	"LD_AUDIT":              true,
+	"LD_LIBRARY_PATH":       true,
+	"DYLD_INSERT_LIBRARIES": true,
+	"DYLD_LIBRARY_PATH":     true,
+}
+
+// ValidateEnv checks that env holds valid variable names, none of the
+// loader variables in unsafeEnv, and no values with NUL bytes or line
+// breaks, which could smuggle extra variables or arguments.
+func ValidateEnv(env map[string]string) error {
+	for name, value := range env {
+		if !envName.MatchString(name) {
+			return fmt.Errorf("invalid environment variable name %q", name)
+		}
+		if unsafeEnv[strings.ToUpper(name)] {
+			return fmt.Errorf("environment variable %s is not allowed", name)
+		}
+		if strings.ContainsAny(value, "\x00\r\n") {
+			return fmt.Errorf("environment variable %s has a value with control characters", name)
+		}
+	}
+	return nil
+}
+
+// EnvList converts env to ContainerOptions.Env entries, sorted by name.
+func EnvList(env map[string]string) []string {
+	list := make([]string, 0, len(env))
+	for name, value := range env {
+		list = append(list, name+"="+value)
+	}
+	sort.Strings(list)
+	return list
+}
+
 // PortConflict describes a container that is occupying a specific host port.
 type PortConflict struct {
 	ContainerID string
@@ -21,10 +78,36 @@ type PortConflict struct {
 	IsAIMA bool
 }
 
+// ContainerInfo describes a container as reported by InspectContainer.
+type ContainerInfo struct {
+	ID     string
+	Name   string
+	Image  string
+	Status string
+	Labels map[string]string
+	// Ports maps published host ports to container ports.
+	Ports map[string]string
+}
+
+// PullProgress is a single progress update reported while pulling an image.
+type PullProgress struct {
+	// LayerID is the short ID of the layer the update refers to.
+	LayerID string
+	// Status is Docker's status for the layer, e.g. "Downloading" or "Pull complete".
+	Status string
+	// Current and Total are byte counts for the layer; Total is 0 when unknown.
+	Current int64
+	Total   int64
+}
+
+// PullProgressFunc receives progress updates during PullImage. It is called
+// synchronously from the pulling goroutine.
+type PullProgressFunc func(PullProgress)
+
 // Client is the interface for Docker container lifecycle and image operations.
 type Client interface {
-	// PullImage pulls a Docker image.
-	PullImage(ctx context.Context, image string) error
+	// PullImage pulls a Docker image. onProgress may be nil.
+	PullImage(ctx context.Context, image string, onProgress PullProgressFunc) error
 
 	// CreateAndStartContainer creates and starts a container, returning its ID.
 	CreateAndStartContainer(ctx context.Context, name, image string, opts ContainerOptions) (string, error)
@@ -52,6 +135,31 @@ type Client interface {
 	// FindContainersByPort returns all containers (any labels) that publish the
 	// given host port. IsAIMA is set when aima.managed=true is present.
 	FindContainersByPort(ctx context.Context, port int) ([]PortConflict, error)
+
+	// CreateNetwork creates a bridge network labelled aima.managed=true and
+	// returns its ID. An existing network with the same name is reused.
+	CreateNetwork(ctx context.Context, name string) (string, error)
+
+	// ConnectContainer attaches a running container to a network, making it
+	// reachable from the network's other containers by its name.
+	ConnectContainer(ctx context.Context, network, containerID string) error
+
+	// CreateVolume creates a named volume labelled aima.managed=true and
+	// returns its name. An existing volume with the same name is reused.
+	CreateVolume(ctx context.Context, name string) (string, error)
+
+	// RemoveVolume removes a named volume. It fails while a container still
+	// uses the volume.
+	RemoveVolume(ctx context.Context, name string) error
+
+	// Exec runs cmd inside a running container and returns its output and
+	// exit code. A non-zero exit code is not an error; err is set only when
+	// the command could not be run.
+	Exec(ctx context.Context, containerID string, cmd []string) (stdout, stderr string, exitCode int, err error)
+
+	// InspectContainer returns the labels, published ports and status of a
+	// container.
+	InspectContainer(ctx context.Context, containerID string) (*ContainerInfo, error)
 }
 
 // Compile-time assertion: SimpleClient must implement Client.
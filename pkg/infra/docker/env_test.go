@@ -0,0 +1,46 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{"typical engine env", map[string]string{"HF_TOKEN": "hf_abc", "VLLM_ATTENTION_BACKEND": "FLASHINFER"}, false},
+		{"empty value", map[string]string{"CUDA_VISIBLE_DEVICES": ""}, false},
+		{"invalid name", map[string]string{"BAD-NAME": "x"}, true},
+		{"name with equals", map[string]string{"A=B": "x"}, true},
+		{"loader preload", map[string]string{"LD_PRELOAD": "/tmp/evil.so"}, true},
+		{"loader path lowercase", map[string]string{"ld_library_path": "/tmp"}, true},
+		{"newline in value", map[string]string{"HF_TOKEN": "abc\nLD_PRELOAD=/tmp/evil.so"}, true},
+		{"nul in value", map[string]string{"HF_TOKEN": "abc\x00"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEnv(tt.env)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMockClient_PassesEnv(t *testing.T) {
+	c := NewMockClient()
+	env := map[string]string{"VLLM_ATTENTION_BACKEND": "FLASHINFER", "HF_TOKEN": "hf_abc"}
+	require.NoError(t, ValidateEnv(env))
+
+	id, err := c.CreateAndStartContainer(context.Background(), "aima-vllm", "vllm/vllm-openai:latest", ContainerOptions{Env: EnvList(env)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HF_TOKEN=hf_abc", "VLLM_ATTENTION_BACKEND=FLASHINFER"}, c.Containers[id].Env)
+}
@@ -0,0 +1,63 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockClient_Exec(t *testing.T) {
+	c := NewMockClient()
+	ctx := context.Background()
+
+	id, err := c.CreateAndStartContainer(ctx, "aima-vllm", "vllm:latest", ContainerOptions{})
+	require.NoError(t, err)
+
+	c.ExecResults["nvidia-smi -L"] = MockExecResult{
+		Stdout: "GPU 0: NVIDIA GB10\n",
+	}
+	c.ExecResults["cat /missing"] = MockExecResult{
+		Stderr:   "cat: /missing: No such file or directory\n",
+		ExitCode: 1,
+	}
+
+	stdout, stderr, code, err := c.Exec(ctx, id, []string{"nvidia-smi", "-L"})
+	require.NoError(t, err)
+	assert.Equal(t, "GPU 0: NVIDIA GB10\n", stdout)
+	assert.Empty(t, stderr)
+	assert.Equal(t, 0, code)
+
+	// A non-zero exit code is reported, not returned as an error.
+	stdout, stderr, code, err = c.Exec(ctx, id, []string{"cat", "/missing"})
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "No such file")
+	assert.Equal(t, 1, code)
+
+	// Unscripted commands succeed with no output.
+	_, _, code, err = c.Exec(ctx, id, []string{"true"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+}
+
+func TestMockClient_Exec_Errors(t *testing.T) {
+	c := NewMockClient()
+	ctx := context.Background()
+
+	_, _, _, err := c.Exec(ctx, "missing", []string{"ls"})
+	assert.Error(t, err, "unknown container")
+
+	id, err := c.CreateContainer(ctx, "aima-tts", "tts:latest", ContainerOptions{})
+	require.NoError(t, err)
+	_, _, _, err = c.Exec(ctx, id, []string{"ls"})
+	assert.Error(t, err, "container not running")
+
+	require.NoError(t, c.StartContainer(ctx, id))
+	execErr := errors.New("exec failed")
+	c.ExecResults["ls"] = MockExecResult{Err: execErr}
+	_, _, _, err = c.Exec(ctx, id, []string{"ls"})
+	assert.ErrorIs(t, err, execErr)
+}
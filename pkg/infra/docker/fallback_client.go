@@ -0,0 +1,181 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	cerrdefs "github.com/containerd/errdefs"
+	dockerclient "github.com/docker/docker/client"
+)
+
+// FallbackClient runs every operation on a primary client, normally the SDK
+// client, and retries it on a fallback client, normally the CLI-based
+// SimpleClient, when the primary fails in a way the CLI may not: the daemon
+// is unreachable over the API socket, rejects the negotiated API version, or
+// does not implement the call. Errors about the operation itself (not found,
+// conflicts, bad arguments) and cancellation are returned as-is.
+type FallbackClient struct {
+	primary  Client
+	fallback Client
+}
+
+// NewFallbackClient wraps primary so failed operations are retried on
+// fallback.
+func NewFallbackClient(primary, fallback Client) *FallbackClient {
+	return &FallbackClient{primary: primary, fallback: fallback}
+}
+
+// shouldFallback reports whether err from the primary client is worth
+// retrying on the fallback client.
+func shouldFallback(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if dockerclient.IsErrConnectionFailed(err) || cerrdefs.IsNotImplemented(err) || cerrdefs.IsUnavailable(err) {
+		return true
+	}
+	// API version mismatches surface as plain bad-request errors, e.g.
+	// "client version 1.47 is too new. Maximum supported API version is 1.43".
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "client version") || strings.Contains(msg, "api version")
+}
+
+func (c *FallbackClient) logFallback(op string, err error) {
+	slog.Warn("docker SDK operation failed, retrying with CLI client", "operation", op, "error", err)
+}
+
+// fallbackCall runs call on the primary client and, if it fails with a
+// fallback error, once more on the fallback client.
+func fallbackCall[T any](c *FallbackClient, op string, call func(Client) (T, error)) (T, error) {
+	result, err := call(c.primary)
+	if !shouldFallback(err) {
+		return result, err
+	}
+	c.logFallback(op, err)
+	return call(c.fallback)
+}
+
+func (c *FallbackClient) PullImage(ctx context.Context, image string, onProgress PullProgressFunc) error {
+	_, err := fallbackCall(c, "PullImage", func(cl Client) (struct{}, error) {
+		return struct{}{}, cl.PullImage(ctx, image, onProgress)
+	})
+	return err
+}
+
+func (c *FallbackClient) CreateAndStartContainer(ctx context.Context, name, image string, opts ContainerOptions) (string, error) {
+	return fallbackCall(c, "CreateAndStartContainer", func(cl Client) (string, error) {
+		return cl.CreateAndStartContainer(ctx, name, image, opts)
+	})
+}
+
+func (c *FallbackClient) StopContainer(ctx context.Context, containerID string, timeout int) error {
+	_, err := fallbackCall(c, "StopContainer", func(cl Client) (struct{}, error) {
+		return struct{}{}, cl.StopContainer(ctx, containerID, timeout)
+	})
+	return err
+}
+
+func (c *FallbackClient) GetContainerStatus(ctx context.Context, containerID string) (string, error) {
+	return fallbackCall(c, "GetContainerStatus", func(cl Client) (string, error) {
+		return cl.GetContainerStatus(ctx, containerID)
+	})
+}
+
+func (c *FallbackClient) GetContainerLogs(ctx context.Context, containerID string, tail int) (string, error) {
+	return fallbackCall(c, "GetContainerLogs", func(cl Client) (string, error) {
+		return cl.GetContainerLogs(ctx, containerID, tail)
+	})
+}
+
+// StreamLogs falls back only when the primary failed before forwarding any
+// line, so a stream that breaks midway is not replayed from the start.
+func (c *FallbackClient) StreamLogs(ctx context.Context, containerID string, since string, out chan<- string) error {
+	relay := make(chan string)
+	done := make(chan error, 1)
+	go func() {
+		defer close(relay)
+		done <- c.primary.StreamLogs(ctx, containerID, since, relay)
+	}()
+
+	forwarded := false
+	for line := range relay {
+		select {
+		case out <- line:
+			forwarded = true
+		case <-ctx.Done():
+		}
+	}
+
+	err := <-done
+	if forwarded || !shouldFallback(err) {
+		return err
+	}
+	c.logFallback("StreamLogs", err)
+	return c.fallback.StreamLogs(ctx, containerID, since, out)
+}
+
+func (c *FallbackClient) ListContainers(ctx context.Context, labels map[string]string) ([]string, error) {
+	return fallbackCall(c, "ListContainers", func(cl Client) ([]string, error) {
+		return cl.ListContainers(ctx, labels)
+	})
+}
+
+func (c *FallbackClient) ContainerEvents(ctx context.Context, filters map[string]string) (<-chan ContainerEvent, error) {
+	return fallbackCall(c, "ContainerEvents", func(cl Client) (<-chan ContainerEvent, error) {
+		return cl.ContainerEvents(ctx, filters)
+	})
+}
+
+func (c *FallbackClient) FindContainersByPort(ctx context.Context, port int) ([]PortConflict, error) {
+	return fallbackCall(c, "FindContainersByPort", func(cl Client) ([]PortConflict, error) {
+		return cl.FindContainersByPort(ctx, port)
+	})
+}
+
+func (c *FallbackClient) CreateNetwork(ctx context.Context, name string) (string, error) {
+	return fallbackCall(c, "CreateNetwork", func(cl Client) (string, error) {
+		return cl.CreateNetwork(ctx, name)
+	})
+}
+
+func (c *FallbackClient) ConnectContainer(ctx context.Context, network, containerID string) error {
+	_, err := fallbackCall(c, "ConnectContainer", func(cl Client) (struct{}, error) {
+		return struct{}{}, cl.ConnectContainer(ctx, network, containerID)
+	})
+	return err
+}
+
+func (c *FallbackClient) CreateVolume(ctx context.Context, name string) (string, error) {
+	return fallbackCall(c, "CreateVolume", func(cl Client) (string, error) {
+		return cl.CreateVolume(ctx, name)
+	})
+}
+
+func (c *FallbackClient) RemoveVolume(ctx context.Context, name string) error {
+	_, err := fallbackCall(c, "RemoveVolume", func(cl Client) (struct{}, error) {
+		return struct{}{}, cl.RemoveVolume(ctx, name)
+	})
+	return err
+}
+
+func (c *FallbackClient) Exec(ctx context.Context, containerID string, cmd []string) (string, string, int, error) {
+	type execResult struct {
+		stdout, stderr string
+		exitCode       int
+	}
+	r, err := fallbackCall(c, "Exec", func(cl Client) (execResult, error) {
+		stdout, stderr, exitCode, err := cl.Exec(ctx, containerID, cmd)
+		return execResult{stdout, stderr, exitCode}, err
+	})
+	return r.stdout, r.stderr, r.exitCode, err
+}
+
+func (c *FallbackClient) InspectContainer(ctx context.Context, containerID string) (*ContainerInfo, error) {
+	return fallbackCall(c, "InspectContainer", func(cl Client) (*ContainerInfo, error) {
+		return cl.InspectContainer(ctx, containerID)
+	})
+}
+
+var _ Client = (*FallbackClient)(nil)
@@ -0,0 +1,197 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+)
+
+// failingClient fails every operation with err, after first sending lines
+// from StreamLogs.
+type failingClient struct {
+	err   error
+	lines []string
+	calls int
+}
+
+func (f *failingClient) PullImage(ctx context.Context, image string, onProgress PullProgressFunc) error {
+	f.calls++
+	return f.err
+}
+
+func (f *failingClient) CreateAndStartContainer(ctx context.Context, name, image string, opts ContainerOptions) (string, error) {
+	f.calls++
+	return "", f.err
+}
+
+func (f *failingClient) StopContainer(ctx context.Context, containerID string, timeout int) error {
+	f.calls++
+	return f.err
+}
+
+func (f *failingClient) GetContainerStatus(ctx context.Context, containerID string) (string, error) {
+	f.calls++
+	return "", f.err
+}
+
+func (f *failingClient) GetContainerLogs(ctx context.Context, containerID string, tail int) (string, error) {
+	f.calls++
+	return "", f.err
+}
+
+func (f *failingClient) StreamLogs(ctx context.Context, containerID string, since string, out chan<- string) error {
+	f.calls++
+	for _, line := range f.lines {
+		out <- line
+	}
+	return f.err
+}
+
+func (f *failingClient) ListContainers(ctx context.Context, labels map[string]string) ([]string, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *failingClient) ContainerEvents(ctx context.Context, filters map[string]string) (<-chan ContainerEvent, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *failingClient) FindContainersByPort(ctx context.Context, port int) ([]PortConflict, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *failingClient) CreateNetwork(ctx context.Context, name string) (string, error) {
+	f.calls++
+	return "", f.err
+}
+
+func (f *failingClient) ConnectContainer(ctx context.Context, network, containerID string) error {
+	f.calls++
+	return f.err
+}
+
+func (f *failingClient) CreateVolume(ctx context.Context, name string) (string, error) {
+	f.calls++
+	return "", f.err
+}
+
+func (f *failingClient) RemoveVolume(ctx context.Context, name string) error {
+	f.calls++
+	return f.err
+}
+
+func (f *failingClient) Exec(ctx context.Context, containerID string, cmd []string) (string, string, int, error) {
+	f.calls++
+	return "", "", 0, f.err
+}
+
+func (f *failingClient) InspectContainer(ctx context.Context, containerID string) (*ContainerInfo, error) {
+	f.calls++
+	return nil, f.err
+}
+
+var errAPIVersion = errors.New("Error response from daemon: client version 1.47 is too new. Maximum supported API version is 1.43")
+
+func TestFallbackClient_RetriesOnCLI(t *testing.T) {
+	ctx := context.Background()
+	cli := NewMockClient()
+	client := NewFallbackClient(&failingClient{err: errAPIVersion}, cli)
+
+	if err := client.PullImage(ctx, "vllm/vllm-openai:latest", nil); err != nil {
+		t.Fatalf("PullImage should succeed via CLI: %v", err)
+	}
+	if _, ok := cli.Images["vllm/vllm-openai:latest"]; !ok {
+		t.Error("expected the CLI client to pull the image")
+	}
+
+	id, err := client.CreateAndStartContainer(ctx, "vllm-1", "vllm/vllm-openai:latest", ContainerOptions{})
+	if err != nil {
+		t.Fatalf("CreateAndStartContainer should succeed via CLI: %v", err)
+	}
+	status, err := client.GetContainerStatus(ctx, id)
+	if err != nil || status != "running" {
+		t.Errorf("GetContainerStatus = %q, %v; want running via CLI", status, err)
+	}
+}
+
+func TestFallbackClient_FallbackErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		fallback bool
+	}{
+		{"api version mismatch", errAPIVersion, true},
+		{"unavailable", fmt.Errorf("ping: %w", cerrdefs.ErrUnavailable), true},
+		{"not implemented", cerrdefs.ErrNotImplemented, true},
+		{"not found", cerrdefs.ErrNotFound, false},
+		{"conflict", cerrdefs.ErrConflict, false},
+		{"cancelled", context.Canceled, false},
+		{"deadline", fmt.Errorf("pull: %w", context.DeadlineExceeded), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &failingClient{err: tt.err}
+			cli := NewMockClient()
+			client := NewFallbackClient(primary, cli)
+
+			_, err := client.ListContainers(context.Background(), nil)
+			if tt.fallback && err != nil {
+				t.Errorf("expected CLI fallback to succeed, got %v", err)
+			}
+			if !tt.fallback && !errors.Is(err, tt.err) {
+				t.Errorf("expected primary error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestFallbackClient_PrimarySuccessSkipsCLI(t *testing.T) {
+	primary := NewMockClient()
+	cli := &failingClient{err: errors.New("cli should not be called")}
+	client := NewFallbackClient(primary, cli)
+
+	if err := client.PullImage(context.Background(), "ollama/ollama:latest", nil); err != nil {
+		t.Fatalf("PullImage failed: %v", err)
+	}
+	if cli.calls != 0 {
+		t.Errorf("expected no CLI calls, got %d", cli.calls)
+	}
+}
+
+func TestFallbackClient_StreamLogs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("falls back before any line", func(t *testing.T) {
+		cli := NewMockClient()
+		id, _ := cli.CreateAndStartContainer(ctx, "ollama", "ollama/ollama:latest", ContainerOptions{})
+		client := NewFallbackClient(&failingClient{err: errAPIVersion}, cli)
+
+		out := make(chan string, 4)
+		if err := client.StreamLogs(ctx, id, "", out); err != nil {
+			t.Fatalf("StreamLogs should succeed via CLI: %v", err)
+		}
+		if len(out) != 1 {
+			t.Errorf("expected 1 line from the CLI client, got %d", len(out))
+		}
+	})
+
+	t.Run("does not replay after partial stream", func(t *testing.T) {
+		cli := &failingClient{}
+		primary := &failingClient{err: errAPIVersion, lines: []string{"line 1", "line 2"}}
+		client := NewFallbackClient(primary, cli)
+
+		out := make(chan string, 4)
+		if err := client.StreamLogs(ctx, "c1", "", out); !errors.Is(err, errAPIVersion) {
+			t.Errorf("expected the primary error after a partial stream, got %v", err)
+		}
+		if len(out) != 2 || cli.calls != 0 {
+			t.Errorf("expected 2 forwarded lines and no CLI call, got %d lines and %d calls", len(out), cli.calls)
+		}
+	})
+}
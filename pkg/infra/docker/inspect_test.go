@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockClient_InspectContainer_LabeledContainers(t *testing.T) {
+	c := NewMockClient()
+	ctx := context.Background()
+
+	vllm, err := c.CreateAndStartContainer(ctx, "aima-vllm-1", "vllm:latest", ContainerOptions{
+		Ports:  map[string]string{"8000": "8000"},
+		Labels: map[string]string{"aima.managed": "true", "aima.engine": "vllm"},
+	})
+	require.NoError(t, err)
+	asr, err := c.CreateContainer(ctx, "aima-asr-1", "asr:latest", ContainerOptions{
+		Ports:  map[string]string{"8001": "9000"},
+		Labels: map[string]string{"aima.managed": "true", "aima.engine": "asr"},
+	})
+	require.NoError(t, err)
+	ext, err := c.CreateAndStartContainer(ctx, "nginx", "nginx:latest", ContainerOptions{})
+	require.NoError(t, err)
+
+	info, err := c.InspectContainer(ctx, vllm)
+	require.NoError(t, err)
+	assert.Equal(t, "aima-vllm-1", info.Name)
+	assert.Equal(t, "running", info.Status)
+	assert.Equal(t, "vllm", info.Labels["aima.engine"])
+	assert.Equal(t, map[string]string{"8000": "8000"}, info.Ports)
+
+	info, err = c.InspectContainer(ctx, asr)
+	require.NoError(t, err)
+	assert.Equal(t, "created", info.Status)
+	assert.Equal(t, "asr", info.Labels["aima.engine"])
+	assert.Equal(t, map[string]string{"8001": "9000"}, info.Ports)
+
+	info, err = c.InspectContainer(ctx, ext)
+	require.NoError(t, err)
+	assert.Empty(t, info.Labels["aima.managed"])
+	assert.Empty(t, info.Ports)
+
+	_, err = c.InspectContainer(ctx, "missing")
+	assert.Error(t, err)
+}
+
+func TestParseInspectOutput(t *testing.T) {
+	output := []byte(`{
+		"Id": "3f2a9c",
+		"Name": "/aima-vllm-1",
+		"State": {"Status": "running"},
+		"Config": {"Image": "vllm/vllm-openai:v0.8.5", "Labels": {"aima.managed": "true", "aima.engine": "vllm"}},
+		"NetworkSettings": {"Ports": {"8000/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8000"}], "9000/tcp": null}}
+	}`)
+
+	info, err := parseInspectOutput(output)
+	require.NoError(t, err)
+	assert.Equal(t, "3f2a9c", info.ID)
+	assert.Equal(t, "aima-vllm-1", info.Name)
+	assert.Equal(t, "running", info.Status)
+	assert.Equal(t, "vllm/vllm-openai:v0.8.5", info.Image)
+	assert.Equal(t, "vllm", info.Labels["aima.engine"])
+	assert.Equal(t, map[string]string{"8000": "8000"}, info.Ports)
+
+	_, err = parseInspectOutput([]byte("not json"))
+	assert.Error(t, err)
+}
@@ -13,18 +13,38 @@ type ContainerOptions struct {
 	Env        []string
 	Cmd        []string
 	Ports      map[string]string
-	Volumes    map[string]string
+	Volumes    map[string]string // 宿主机路径或命名卷 -> 容器内路径
 	Labels     map[string]string
 	WorkingDir string
 	GPU        bool
 	Memory     string // e.g., "4g", "512m"
 	CPU        string // e.g., "2.0"
+	// Network 容器创建时加入的网络名称，为空则使用默认网络
+	Network string
 }
 
 // MockClient 用于测试的 Mock Docker 客户端
 type MockClient struct {
 	Containers map[string]*MockContainer
 	Images     map[string]*MockImage
+	// PullErrors 按镜像引用设置 PullImage 返回的错误
+	PullErrors map[string]error
+	// PullProgress 按镜像引用设置 PullImage 依次上报的进度
+	PullProgress map[string][]PullProgress
+	// Networks 按名称记录已创建的网络
+	Networks map[string]*MockNetwork
+	// Volumes 按名称记录已创建的命名卷
+	Volumes map[string]*MockVolume
+	// ExecResults 按命令（以空格连接）设置 Exec 返回的脚本化结果，未设置时返回空输出和退出码 0
+	ExecResults map[string]MockExecResult
+}
+
+// MockExecResult Exec 的脚本化结果
+type MockExecResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Err      error
 }
 
 // MockContainer 模拟容器
@@ -38,6 +58,22 @@ type MockContainer struct {
 	Ports   []string
 	Volumes []string
 	Labels  map[string]string
+	// Networks 容器已加入的网络名称
+	Networks []string
+}
+
+// MockNetwork 模拟网络
+type MockNetwork struct {
+	ID     string
+	Name   string
+	Driver string
+	Labels map[string]string
+}
+
+// MockVolume 模拟命名卷
+type MockVolume struct {
+	Name   string
+	Labels map[string]string
 }
 
 // MockImage 模拟镜像
@@ -50,8 +86,11 @@ type MockImage struct {
 // NewMockClient 创建新的 Mock Docker 客户端
 func NewMockClient() *MockClient {
 	return &MockClient{
-		Containers: make(map[string]*MockContainer),
-		Images:     make(map[string]*MockImage),
+		Containers:  make(map[string]*MockContainer),
+		Images:      make(map[string]*MockImage),
+		Networks:    make(map[string]*MockNetwork),
+		Volumes:     make(map[string]*MockVolume),
+		ExecResults: make(map[string]MockExecResult),
 	}
 }
 
@@ -63,6 +102,14 @@ func (c *MockClient) CreateContainer(ctx context.Context, name, image string, op
 	default:
 	}
 
+	var networks []string
+	if opts.Network != "" {
+		if _, ok := c.Networks[opts.Network]; !ok {
+			return "", fmt.Errorf("network %s not found", opts.Network)
+		}
+		networks = []string{opts.Network}
+	}
+
 	containerID := fmt.Sprintf("mock-container-%d", len(c.Containers)+1)
 
 	ports := make([]string, 0)
@@ -72,6 +119,12 @@ func (c *MockClient) CreateContainer(ctx context.Context, name, image string, op
 
 	volumes := make([]string, 0)
 	for hostPath, containerPath := range opts.Volumes {
+		// 与 Docker 一致，引用不存在的命名卷时自动创建
+		if IsNamedVolume(hostPath) {
+			if _, ok := c.Volumes[hostPath]; !ok {
+				c.Volumes[hostPath] = &MockVolume{Name: hostPath, Labels: map[string]string{}}
+			}
+		}
 		volumes = append(volumes, fmt.Sprintf("%s:%s", hostPath, containerPath))
 	}
 
@@ -81,15 +134,16 @@ func (c *MockClient) CreateContainer(ctx context.Context, name, image string, op
 	}
 
 	container := &MockContainer{
-		ID:      containerID,
-		Name:    name,
-		Image:   image,
-		Status:  "created",
-		Env:     opts.Env,
-		Cmd:     opts.Cmd,
-		Ports:   ports,
-		Volumes: volumes,
-		Labels:  labels,
+		ID:       containerID,
+		Name:     name,
+		Image:    image,
+		Status:   "created",
+		Env:      opts.Env,
+		Cmd:      opts.Cmd,
+		Ports:    ports,
+		Volumes:  volumes,
+		Labels:   labels,
+		Networks: networks,
 	}
 
 	c.Containers[containerID] = container
@@ -187,13 +241,23 @@ func (c *MockClient) GetContainer(ctx context.Context, containerID string) (*Moc
 }
 
 // PullImage 拉取镜像
-func (c *MockClient) PullImage(ctx context.Context, imageRef string) error {
+func (c *MockClient) PullImage(ctx context.Context, imageRef string, onProgress PullProgressFunc) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
 	}
 
+	if err, ok := c.PullErrors[imageRef]; ok {
+		return err
+	}
+
+	if onProgress != nil {
+		for _, update := range c.PullProgress[imageRef] {
+			onProgress(update)
+		}
+	}
+
 	imageID := fmt.Sprintf("mock-image-%d", len(c.Images)+1)
 	c.Images[imageRef] = &MockImage{
 		ID:       imageID,
@@ -267,11 +331,6 @@ func (c *MockClient) ExecInContainer(ctx context.Context, containerID string, cm
 	return fmt.Sprintf("Executed %v in container %s", cmd, containerID), nil
 }
 
-// InspectContainer 检查容器详情
-func (c *MockClient) InspectContainer(ctx context.Context, containerID string) (*MockContainer, error) {
-	return c.GetContainer(ctx, containerID)
-}
-
 // WaitContainer 等待容器结束
 func (c *MockClient) WaitContainer(ctx context.Context, containerID string) (int64, error) {
 	select {
@@ -599,5 +658,147 @@ func (c *MockClient) FindContainersByPort(ctx context.Context, port int) ([]Port
 	return conflicts, nil
 }
 
+// CreateNetwork implements docker.Client: creates a bridge network, reusing
+// an existing one with the same name.
+func (c *MockClient) CreateNetwork(ctx context.Context, name string) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+	if n, ok := c.Networks[name]; ok {
+		return n.ID, nil
+	}
+	n := &MockNetwork{
+		ID:     fmt.Sprintf("mock-network-%d", len(c.Networks)+1),
+		Name:   name,
+		Driver: "bridge",
+		Labels: map[string]string{"aima.managed": "true"},
+	}
+	c.Networks[name] = n
+	return n.ID, nil
+}
+
+// ConnectContainer implements docker.Client: attaches a container to a network
+// given by name or ID.
+func (c *MockClient) ConnectContainer(ctx context.Context, network, containerID string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	var target *MockNetwork
+	for _, n := range c.Networks {
+		if n.Name == network || n.ID == network {
+			target = n
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("network %s not found", network)
+	}
+	container, exists := c.Containers[containerID]
+	if !exists {
+		return fmt.Errorf("container %s not found", containerID)
+	}
+	for _, name := range container.Networks {
+		if name == target.Name {
+			return fmt.Errorf("container %s is already attached to network %s", containerID, target.Name)
+		}
+	}
+	container.Networks = append(container.Networks, target.Name)
+	return nil
+}
+
+// CreateVolume implements docker.Client: creates a named volume, reusing an
+// existing one with the same name.
+func (c *MockClient) CreateVolume(ctx context.Context, name string) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+	if _, ok := c.Volumes[name]; !ok {
+		c.Volumes[name] = &MockVolume{
+			Name:   name,
+			Labels: map[string]string{"aima.managed": "true"},
+		}
+	}
+	return name, nil
+}
+
+// RemoveVolume implements docker.Client: removes a named volume that no
+// container uses.
+func (c *MockClient) RemoveVolume(ctx context.Context, name string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if _, ok := c.Volumes[name]; !ok {
+		return fmt.Errorf("volume %s not found", name)
+	}
+	for _, ct := range c.Containers {
+		for _, v := range ct.Volumes {
+			if strings.HasPrefix(v, name+":") {
+				return fmt.Errorf("volume %s is in use by container %s", name, ct.ID)
+			}
+		}
+	}
+	delete(c.Volumes, name)
+	return nil
+}
+
+// Exec implements docker.Client: returns the scripted result for cmd from a
+// running container.
+func (c *MockClient) Exec(ctx context.Context, containerID string, cmd []string) (string, string, int, error) {
+	select {
+	case <-ctx.Done():
+		return "", "", 0, ctx.Err()
+	default:
+	}
+	container, exists := c.Containers[containerID]
+	if !exists {
+		return "", "", 0, fmt.Errorf("container %s not found", containerID)
+	}
+	if container.Status != "running" {
+		return "", "", 0, fmt.Errorf("container %s is not running", containerID)
+	}
+	r := c.ExecResults[strings.Join(cmd, " ")]
+	return r.Stdout, r.Stderr, r.ExitCode, r.Err
+}
+
+// InspectContainer implements docker.Client: returns the container's labels,
+// ports and status.
+func (c *MockClient) InspectContainer(ctx context.Context, containerID string) (*ContainerInfo, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	container, exists := c.Containers[containerID]
+	if !exists {
+		return nil, fmt.Errorf("container %s not found", containerID)
+	}
+
+	info := &ContainerInfo{
+		ID:     container.ID,
+		Name:   container.Name,
+		Image:  container.Image,
+		Status: container.Status,
+		Labels: make(map[string]string, len(container.Labels)),
+		Ports:  make(map[string]string, len(container.Ports)),
+	}
+	for k, v := range container.Labels {
+		info.Labels[k] = v
+	}
+	for _, mapping := range container.Ports {
+		if hostPort, containerPort, ok := strings.Cut(mapping, ":"); ok {
+			info.Ports[hostPort] = containerPort
+		}
+	}
+	return info, nil
+}
+
 // Compile-time assertion: MockClient must implement docker.Client.
 var _ Client = (*MockClient)(nil)
@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockClient_CreateNetwork(t *testing.T) {
+	c := NewMockClient()
+	ctx := context.Background()
+
+	id, err := c.CreateNetwork(ctx, DefaultNetwork)
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	n := c.Networks[DefaultNetwork]
+	require.NotNil(t, n)
+	assert.Equal(t, "bridge", n.Driver)
+	assert.Equal(t, "true", n.Labels["aima.managed"])
+
+	// Creating the same network again reuses it.
+	again, err := c.CreateNetwork(ctx, DefaultNetwork)
+	require.NoError(t, err)
+	assert.Equal(t, id, again)
+	assert.Len(t, c.Networks, 1)
+}
+
+func TestMockClient_CreateContainerOnNetwork(t *testing.T) {
+	c := NewMockClient()
+	ctx := context.Background()
+
+	_, err := c.CreateAndStartContainer(ctx, "aima-vllm", "vllm:latest", ContainerOptions{Network: DefaultNetwork})
+	assert.Error(t, err, "unknown network should be rejected")
+
+	_, err = c.CreateNetwork(ctx, DefaultNetwork)
+	require.NoError(t, err)
+
+	id, err := c.CreateAndStartContainer(ctx, "aima-vllm", "vllm:latest", ContainerOptions{Network: DefaultNetwork})
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultNetwork}, c.Containers[id].Networks)
+}
+
+func TestMockClient_ConnectContainer(t *testing.T) {
+	c := NewMockClient()
+	ctx := context.Background()
+
+	netID, err := c.CreateNetwork(ctx, DefaultNetwork)
+	require.NoError(t, err)
+	id, err := c.CreateAndStartContainer(ctx, "aima-whisper", "whisper:latest", ContainerOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, c.ConnectContainer(ctx, DefaultNetwork, id))
+	assert.Equal(t, []string{DefaultNetwork}, c.Containers[id].Networks)
+
+	assert.Error(t, c.ConnectContainer(ctx, netID, id), "already attached, by network ID")
+	assert.Error(t, c.ConnectContainer(ctx, "missing", id), "unknown network")
+	assert.Error(t, c.ConnectContainer(ctx, DefaultNetwork, "missing"), "unknown container")
+}
@@ -3,6 +3,8 @@ package docker
 import (
 	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -14,7 +16,10 @@ import (
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 )
 
@@ -47,7 +52,7 @@ func (c *SDKClient) CreateAndStartContainer(ctx context.Context, name, image str
 		portBindings[p] = []nat.PortBinding{{HostPort: hostPort}}
 	}
 
-	// Build volume bindings from opts.Volumes (hostPath -> containerPath).
+	// Build volume bindings from opts.Volumes (host path or volume name -> containerPath).
 	binds := make([]string, 0, len(opts.Volumes))
 	for hostPath, containerPath := range opts.Volumes {
 		binds = append(binds, hostPath+":"+containerPath)
@@ -80,7 +85,7 @@ func (c *SDKClient) CreateAndStartContainer(ctx context.Context, name, image str
 
 	// Memory limit.
 	if opts.Memory != "" {
-		mem, err := parseMemory(opts.Memory)
+		mem, err := ParseMemory(opts.Memory)
 		if err == nil {
 			hostCfg.Memory = mem
 		}
@@ -93,6 +98,10 @@ func (c *SDKClient) CreateAndStartContainer(ctx context.Context, name, image str
 		}
 	}
 
+	if opts.Network != "" {
+		hostCfg.NetworkMode = container.NetworkMode(opts.Network)
+	}
+
 	// GPU support — equivalent to `--gpus all`.
 	if opts.GPU {
 		hostCfg.DeviceRequests = []container.DeviceRequest{
@@ -292,20 +301,59 @@ func (c *SDKClient) FindContainersByPort(ctx context.Context, port int) ([]PortC
 	return conflicts, nil
 }
 
-// PullImage pulls a Docker image using the SDK.
-func (c *SDKClient) PullImage(ctx context.Context, img string) error {
+// PullImage pulls a Docker image using the SDK, forwarding layer progress
+// decoded from the pull's JSON stream to onProgress.
+func (c *SDKClient) PullImage(ctx context.Context, img string, onProgress PullProgressFunc) error {
 	rc, err := c.cli.ImagePull(ctx, img, image.PullOptions{})
 	if err != nil {
 		return fmt.Errorf("docker ImagePull %s: %w", img, err)
 	}
 	defer rc.Close()
-	// Drain the reader to complete the pull; output is JSON progress (discarded).
-	_, _ = io.Copy(io.Discard, rc)
+	if err := decodePullStream(rc, onProgress); err != nil {
+		return fmt.Errorf("docker ImagePull %s: %w", img, err)
+	}
 	return nil
 }
 
-// parseMemory converts strings like "4g", "512m", "1024k" to bytes.
-func parseMemory(s string) (int64, error) {
+// pullMessage is one JSON object of the stream returned by ImagePull.
+type pullMessage struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	ProgressDetail struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	Error string `json:"error"`
+}
+
+// decodePullStream reads pull messages until EOF. Layer messages are passed
+// to onProgress; an error message in the stream fails the pull.
+func decodePullStream(r io.Reader, onProgress PullProgressFunc) error {
+	dec := json.NewDecoder(r)
+	for {
+		var msg pullMessage
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("decode pull progress: %w", err)
+		}
+		if msg.Error != "" {
+			return errors.New(msg.Error)
+		}
+		if onProgress != nil && msg.ID != "" {
+			onProgress(PullProgress{
+				LayerID: msg.ID,
+				Status:  msg.Status,
+				Current: msg.ProgressDetail.Current,
+				Total:   msg.ProgressDetail.Total,
+			})
+		}
+	}
+}
+
+// ParseMemory converts strings like "4g", "512m", "1024k" to bytes.
+func ParseMemory(s string) (int64, error) {
 	if len(s) == 0 {
 		return 0, fmt.Errorf("empty memory string")
 	}
@@ -331,4 +379,133 @@ func parseMemory(s string) (int64, error) {
 }
 
 // Compile-time assertion: SDKClient must implement Client.
+// CreateNetwork creates a bridge network labelled aima.managed=true, reusing
+// an existing network with the same name.
+func (c *SDKClient) CreateNetwork(ctx context.Context, name string) (string, error) {
+	existing, err := c.cli.NetworkInspect(ctx, name, network.InspectOptions{})
+	if err == nil {
+		return existing.ID, nil
+	}
+	if !cerrdefs.IsNotFound(err) {
+		return "", fmt.Errorf("docker NetworkInspect %s: %w", name, err)
+	}
+
+	resp, err := c.cli.NetworkCreate(ctx, name, network.CreateOptions{
+		Driver: "bridge",
+		Labels: map[string]string{"aima.managed": "true"},
+	})
+	if err != nil {
+		return "", fmt.Errorf("docker NetworkCreate %s: %w", name, err)
+	}
+	return resp.ID, nil
+}
+
+// ConnectContainer attaches a container to a network.
+func (c *SDKClient) ConnectContainer(ctx context.Context, networkName, containerID string) error {
+	if err := c.cli.NetworkConnect(ctx, networkName, containerID, nil); err != nil {
+		return fmt.Errorf("docker NetworkConnect %s: %w", networkName, err)
+	}
+	return nil
+}
+
+// CreateVolume creates a named volume labelled aima.managed=true, reusing an
+// existing volume with the same name.
+func (c *SDKClient) CreateVolume(ctx context.Context, name string) (string, error) {
+	existing, err := c.cli.VolumeInspect(ctx, name)
+	if err == nil {
+		return existing.Name, nil
+	}
+	if !cerrdefs.IsNotFound(err) {
+		return "", fmt.Errorf("docker VolumeInspect %s: %w", name, err)
+	}
+
+	vol, err := c.cli.VolumeCreate(ctx, volume.CreateOptions{
+		Name:   name,
+		Labels: map[string]string{"aima.managed": "true"},
+	})
+	if err != nil {
+		return "", fmt.Errorf("docker VolumeCreate %s: %w", name, err)
+	}
+	return vol.Name, nil
+}
+
+// RemoveVolume removes a named volume.
+func (c *SDKClient) RemoveVolume(ctx context.Context, name string) error {
+	if err := c.cli.VolumeRemove(ctx, name, false); err != nil {
+		return fmt.Errorf("docker VolumeRemove %s: %w", name, err)
+	}
+	return nil
+}
+
+// Exec runs cmd inside a running container and waits for it to finish.
+func (c *SDKClient) Exec(ctx context.Context, containerID string, cmd []string) (string, string, int, error) {
+	created, err := c.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", "", 0, fmt.Errorf("docker ContainerExecCreate: %w", err)
+	}
+
+	attach, err := c.cli.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", "", 0, fmt.Errorf("docker ContainerExecAttach: %w", err)
+	}
+	defer attach.Close()
+
+	var stdout, stderr strings.Builder
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, attach.Reader); err != nil {
+		return "", "", 0, fmt.Errorf("reading exec output: %w", err)
+	}
+
+	inspect, err := c.cli.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("docker ContainerExecInspect: %w", err)
+	}
+	return stdout.String(), stderr.String(), inspect.ExitCode, nil
+}
+
+// InspectContainer returns the labels, published ports and status of a
+// container.
+func (c *SDKClient) InspectContainer(ctx context.Context, containerID string) (*ContainerInfo, error) {
+	info, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("docker ContainerInspect: %w", err)
+	}
+
+	result := &ContainerInfo{
+		ID:     info.ID,
+		Name:   strings.TrimPrefix(info.Name, "/"),
+		Labels: map[string]string{},
+		Ports:  map[string]string{},
+	}
+	if info.State != nil {
+		result.Status = info.State.Status
+	}
+	if info.Config != nil {
+		result.Image = info.Config.Image
+		for k, v := range info.Config.Labels {
+			result.Labels[k] = v
+		}
+	}
+	// Running containers report actual bindings; fall back to the requested
+	// ones for stopped containers.
+	bindings := nat.PortMap{}
+	if info.HostConfig != nil {
+		bindings = info.HostConfig.PortBindings
+	}
+	if info.NetworkSettings != nil && len(info.NetworkSettings.Ports) > 0 {
+		bindings = info.NetworkSettings.Ports
+	}
+	for port, binds := range bindings {
+		for _, b := range binds {
+			if b.HostPort != "" {
+				result.Ports[b.HostPort] = port.Port()
+			}
+		}
+	}
+	return result, nil
+}
+
 var _ Client = (*SDKClient)(nil)
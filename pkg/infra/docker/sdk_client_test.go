@@ -0,0 +1,41 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodePullStream_ReportsLayerProgress(t *testing.T) {
+	stream := strings.NewReader(`{"status":"Pulling from vllm/vllm-openai","id":"latest"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"a1b2c3"}
+{"status":"Downloading","progressDetail":{"current":512,"total":2048},"id":"a1b2c3"}
+{"status":"Pull complete","progressDetail":{},"id":"a1b2c3"}
+{"status":"Digest: sha256:deadbeef"}
+{"status":"Status: Downloaded newer image for vllm/vllm-openai:latest"}
+`)
+
+	var updates []PullProgress
+	require.NoError(t, decodePullStream(stream, func(p PullProgress) {
+		updates = append(updates, p)
+	}))
+
+	assert.Equal(t, []PullProgress{
+		{LayerID: "latest", Status: "Pulling from vllm/vllm-openai"},
+		{LayerID: "a1b2c3", Status: "Pulling fs layer"},
+		{LayerID: "a1b2c3", Status: "Downloading", Current: 512, Total: 2048},
+		{LayerID: "a1b2c3", Status: "Pull complete"},
+	}, updates)
+}
+
+func TestDecodePullStream_ErrorMessageFailsPull(t *testing.T) {
+	stream := strings.NewReader(`{"status":"Pulling fs layer","id":"a1b2c3"}
+{"errorDetail":{"message":"manifest unknown"},"error":"manifest unknown"}
+`)
+
+	err := decodePullStream(stream, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "manifest unknown")
+}
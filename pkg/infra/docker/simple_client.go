@@ -3,6 +3,8 @@ package docker
 import (
 	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os/exec"
@@ -17,8 +19,9 @@ func NewSimpleClient() *SimpleClient {
 	return &SimpleClient{}
 }
 
-// PullImage pulls a Docker image
-func (c *SimpleClient) PullImage(ctx context.Context, image string) error {
+// PullImage pulls a Docker image. The docker CLI does not expose byte-level
+// progress, so onProgress is never called.
+func (c *SimpleClient) PullImage(ctx context.Context, image string, onProgress PullProgressFunc) error {
 	cmd := exec.CommandContext(ctx, "docker", "pull", image)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -36,7 +39,7 @@ func (c *SimpleClient) CreateAndStartContainer(ctx context.Context, name, image
 		args = append(args, "-p", fmt.Sprintf("%s:%s", hostPort, containerPort))
 	}
 
-	// Add volumes
+	// Add volumes (host paths or named volumes)
 	for hostPath, containerPath := range opts.Volumes {
 		args = append(args, "-v", fmt.Sprintf("%s:%s", hostPath, containerPath))
 	}
@@ -56,6 +59,10 @@ func (c *SimpleClient) CreateAndStartContainer(ctx context.Context, name, image
 		args = append(args, "--gpus", "all")
 	}
 
+	if opts.Network != "" {
+		args = append(args, "--network", opts.Network)
+	}
+
 	// Add working directory
 	if opts.WorkingDir != "" {
 		args = append(args, "-w", opts.WorkingDir)
@@ -193,6 +200,129 @@ func (c *SimpleClient) FindContainersByPort(ctx context.Context, port int) ([]Po
 	return conflicts, nil
 }
 
+// CreateNetwork creates a bridge network, reusing an existing one with the
+// same name.
+func (c *SimpleClient) CreateNetwork(ctx context.Context, name string) (string, error) {
+	inspect := exec.CommandContext(ctx, "docker", "network", "inspect", "-f", "{{.Id}}", name)
+	if output, err := inspect.Output(); err == nil {
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	cmd := exec.CommandContext(ctx, "docker", "network", "create", "--driver", "bridge", "--label", "aima.managed=true", name)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("docker network create %s failed: %w\nOutput: %s", name, err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ConnectContainer attaches a container to a network
+func (c *SimpleClient) ConnectContainer(ctx context.Context, network, containerID string) error {
+	cmd := exec.CommandContext(ctx, "docker", "network", "connect", network, containerID)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("docker network connect failed: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
+
+// CreateVolume creates a named volume, reusing an existing one with the same
+// name.
+func (c *SimpleClient) CreateVolume(ctx context.Context, name string) (string, error) {
+	inspect := exec.CommandContext(ctx, "docker", "volume", "inspect", "-f", "{{.Name}}", name)
+	if output, err := inspect.Output(); err == nil {
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	cmd := exec.CommandContext(ctx, "docker", "volume", "create", "--label", "aima.managed=true", name)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("docker volume create %s failed: %w\nOutput: %s", name, err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RemoveVolume removes a named volume
+func (c *SimpleClient) RemoveVolume(ctx context.Context, name string) error {
+	cmd := exec.CommandContext(ctx, "docker", "volume", "rm", name)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("docker volume rm %s failed: %w\nOutput: %s", name, err, string(output))
+	}
+	return nil
+}
+
+// Exec runs a command inside a running container
+func (c *SimpleClient) Exec(ctx context.Context, containerID string, cmd []string) (string, string, int, error) {
+	args := append([]string{"exec", containerID}, cmd...)
+	execCmd := exec.CommandContext(ctx, "docker", args...)
+	var stdout, stderr strings.Builder
+	execCmd.Stdout = &stdout
+	execCmd.Stderr = &stderr
+
+	if err := execCmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return stdout.String(), stderr.String(), exitErr.ExitCode(), nil
+		}
+		return "", "", 0, fmt.Errorf("docker exec failed: %w", err)
+	}
+	return stdout.String(), stderr.String(), 0, nil
+}
+
+// InspectContainer returns the labels, published ports and status of a container
+func (c *SimpleClient) InspectContainer(ctx context.Context, containerID string) (*ContainerInfo, error) {
+	cmd := exec.CommandContext(ctx, "docker", "inspect", "--type", "container", "--format", "{{json .}}", containerID)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker inspect %s failed: %w", containerID, err)
+	}
+	return parseInspectOutput(output)
+}
+
+// parseInspectOutput decodes the JSON printed by `docker inspect --format
+// '{{json .}}'` into a ContainerInfo.
+func parseInspectOutput(output []byte) (*ContainerInfo, error) {
+	var raw struct {
+		ID    string `json:"Id"`
+		Name  string `json:"Name"`
+		State struct {
+			Status string `json:"Status"`
+		} `json:"State"`
+		Config struct {
+			Image  string            `json:"Image"`
+			Labels map[string]string `json:"Labels"`
+		} `json:"Config"`
+		NetworkSettings struct {
+			Ports map[string][]struct {
+				HostPort string `json:"HostPort"`
+			} `json:"Ports"`
+		} `json:"NetworkSettings"`
+	}
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("parse docker inspect output: %w", err)
+	}
+
+	info := &ContainerInfo{
+		ID:     raw.ID,
+		Name:   strings.TrimPrefix(raw.Name, "/"),
+		Image:  raw.Config.Image,
+		Status: raw.State.Status,
+		Labels: map[string]string{},
+		Ports:  map[string]string{},
+	}
+	for k, v := range raw.Config.Labels {
+		info.Labels[k] = v
+	}
+	for port, binds := range raw.NetworkSettings.Ports {
+		containerPort, _, _ := strings.Cut(port, "/")
+		for _, b := range binds {
+			if b.HostPort != "" {
+				info.Ports[b.HostPort] = containerPort
+			}
+		}
+	}
+	return info, nil
+}
+
 // CheckDocker checks if Docker is available
 func CheckDocker() error {
 	cmd := exec.Command("docker", "version")
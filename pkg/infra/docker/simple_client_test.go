@@ -36,7 +36,7 @@ func TestSimpleClient_PullImage_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // cancel immediately
 
-	err := c.PullImage(ctx, "nonexistent-image:latest")
+	err := c.PullImage(ctx, "nonexistent-image:latest", nil)
 	assert.Error(t, err)
 }
 
@@ -89,7 +89,7 @@ func TestMockClient_PullImage_Success(t *testing.T) {
 	mc := NewMockClient()
 	ctx := context.Background()
 
-	err := mc.PullImage(ctx, "nginx:latest")
+	err := mc.PullImage(ctx, "nginx:latest", nil)
 	require.NoError(t, err)
 
 	images, err := mc.ListImages(ctx)
@@ -104,7 +104,7 @@ func TestMockClient_PullImage_MultiplePulls(t *testing.T) {
 
 	refs := []string{"nginx:latest", "redis:7", "postgres:15"}
 	for _, ref := range refs {
-		require.NoError(t, mc.PullImage(ctx, ref))
+		require.NoError(t, mc.PullImage(ctx, ref, nil))
 	}
 
 	images, err := mc.ListImages(ctx)
@@ -117,7 +117,7 @@ func TestMockClient_PullImage_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	err := mc.PullImage(ctx, "nginx:latest")
+	err := mc.PullImage(ctx, "nginx:latest", nil)
 	assert.ErrorIs(t, err, context.Canceled)
 }
 
@@ -125,7 +125,7 @@ func TestMockClient_PullImage_SetsImageSize(t *testing.T) {
 	mc := NewMockClient()
 	ctx := context.Background()
 
-	require.NoError(t, mc.PullImage(ctx, "ubuntu:22.04"))
+	require.NoError(t, mc.PullImage(ctx, "ubuntu:22.04", nil))
 
 	images, err := mc.ListImages(ctx)
 	require.NoError(t, err)
@@ -326,20 +326,23 @@ func TestMockClient_GetContainer_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
-func TestMockClient_InspectContainer_DelegatesToGetContainer(t *testing.T) {
+func TestMockClient_InspectContainer_MatchesGetContainer(t *testing.T) {
 	mc := NewMockClient()
 	ctx := context.Background()
 
 	id, err := mc.CreateContainer(ctx, "inspect-ctr", "alpine:latest", ContainerOptions{})
 	require.NoError(t, err)
 
-	ctr1, err := mc.GetContainer(ctx, id)
+	ctr, err := mc.GetContainer(ctx, id)
 	require.NoError(t, err)
 
-	ctr2, err := mc.InspectContainer(ctx, id)
+	info, err := mc.InspectContainer(ctx, id)
 	require.NoError(t, err)
 
-	assert.Equal(t, ctr1, ctr2)
+	assert.Equal(t, ctr.ID, info.ID)
+	assert.Equal(t, ctr.Name, info.Name)
+	assert.Equal(t, ctr.Image, info.Image)
+	assert.Equal(t, ctr.Status, info.Status)
 }
 
 // ---------------------------------------------------------------------------
@@ -714,7 +717,7 @@ func TestMockClient_RemoveImage_Success(t *testing.T) {
 	mc := NewMockClient()
 	ctx := context.Background()
 
-	require.NoError(t, mc.PullImage(ctx, "alpine:latest"))
+	require.NoError(t, mc.PullImage(ctx, "alpine:latest", nil))
 
 	images, _ := mc.ListImages(ctx)
 	require.Len(t, images, 1)
@@ -740,7 +743,7 @@ func TestMockClient_TagImage_Success(t *testing.T) {
 	mc := NewMockClient()
 	ctx := context.Background()
 
-	require.NoError(t, mc.PullImage(ctx, "nginx:latest"))
+	require.NoError(t, mc.PullImage(ctx, "nginx:latest", nil))
 
 	err := mc.TagImage(ctx, "nginx:latest", "nginx:stable")
 	require.NoError(t, err)
@@ -775,7 +778,7 @@ func TestMockClient_PushImage_Success(t *testing.T) {
 	mc := NewMockClient()
 	ctx := context.Background()
 
-	require.NoError(t, mc.PullImage(ctx, "myrepo/myimage:v1"))
+	require.NoError(t, mc.PullImage(ctx, "myrepo/myimage:v1", nil))
 
 	err := mc.PushImage(ctx, "myrepo/myimage:v1")
 	assert.NoError(t, err)
@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNamedVolume(t *testing.T) {
+	assert.True(t, IsNamedVolume("aima-model-cache"))
+	assert.False(t, IsNamedVolume("/data/models"))
+	assert.False(t, IsNamedVolume("./models"))
+	assert.False(t, IsNamedVolume("~/models"))
+	assert.False(t, IsNamedVolume(""))
+}
+
+func TestMockClient_VolumeLifecycle(t *testing.T) {
+	c := NewMockClient()
+	ctx := context.Background()
+
+	name, err := c.CreateVolume(ctx, "aima-model-cache")
+	require.NoError(t, err)
+	assert.Equal(t, "aima-model-cache", name)
+	assert.Equal(t, "true", c.Volumes[name].Labels["aima.managed"])
+
+	// Creating the same volume again reuses it.
+	_, err = c.CreateVolume(ctx, "aima-model-cache")
+	require.NoError(t, err)
+	assert.Len(t, c.Volumes, 1)
+
+	require.NoError(t, c.RemoveVolume(ctx, "aima-model-cache"))
+	assert.Empty(t, c.Volumes)
+	assert.Error(t, c.RemoveVolume(ctx, "aima-model-cache"), "removing a missing volume should fail")
+}
+
+func TestMockClient_NamedVolumeMount(t *testing.T) {
+	c := NewMockClient()
+	ctx := context.Background()
+
+	_, err := c.CreateVolume(ctx, "aima-model-cache")
+	require.NoError(t, err)
+
+	id, err := c.CreateAndStartContainer(ctx, "aima-vllm", "vllm:latest", ContainerOptions{
+		Volumes: map[string]string{
+			"aima-model-cache":   "/root/.cache/huggingface",
+			"/data/models/llama": "/models",
+		},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"aima-model-cache:/root/.cache/huggingface",
+		"/data/models/llama:/models",
+	}, c.Containers[id].Volumes)
+
+	assert.Error(t, c.RemoveVolume(ctx, "aima-model-cache"), "a mounted volume should not be removable")
+
+	require.NoError(t, c.StopContainer(ctx, id, 10))
+	require.NoError(t, c.RemoveVolume(ctx, "aima-model-cache"))
+}
+
+func TestMockClient_NamedVolumeAutoCreated(t *testing.T) {
+	c := NewMockClient()
+
+	_, err := c.CreateAndStartContainer(context.Background(), "aima-vllm", "vllm:latest", ContainerOptions{
+		Volumes: map[string]string{"hf-cache": "/root/.cache/huggingface"},
+	})
+	require.NoError(t, err)
+	require.Contains(t, c.Volumes, "hf-cache")
+	assert.Empty(t, c.Volumes["hf-cache"].Labels)
+	assert.NotContains(t, c.Volumes, "/data/models/llama")
+}
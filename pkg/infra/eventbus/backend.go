@@ -0,0 +1,185 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// PersistenceBackend stores the events a PersistentEventBus publishes.
+// SQLiteEventStore is the default implementation; other stores, such as a
+// shared Postgres database, can be plugged in with WithBackend.
+//
+// Implementations must be safe for concurrent use and honour this contract:
+//
+//   - Append stores a batch of events in order. It either stores the whole
+//     batch or returns an error; the bus does not retry failed batches.
+//   - Query returns the events matching every non-zero field of filter,
+//     newest first, at most filter.Limit of them when Limit > 0. StartTime
+//     and EndTime are inclusive.
+//   - Prune deletes events with a timestamp strictly before the cutoff and
+//     returns how many were deleted.
+//   - Trim deletes all but the newest keep events and returns how many were
+//     deleted.
+type PersistenceBackend interface {
+	Append(ctx context.Context, events []unit.Event) error
+	Query(ctx context.Context, filter EventQueryFilter) ([]unit.Event, error)
+	Prune(ctx context.Context, before time.Time) (int, error)
+	Trim(ctx context.Context, keep int) (int, error)
+}
+
+// Append implements PersistenceBackend.
+func (s *SQLiteEventStore) Append(ctx context.Context, events []unit.Event) error {
+	return s.SaveBatch(ctx, events)
+}
+
+// Prune implements PersistenceBackend.
+func (s *SQLiteEventStore) Prune(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM events WHERE timestamp < ?", before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("prune events: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
+
+// Trim implements PersistenceBackend.
+func (s *SQLiteEventStore) Trim(ctx context.Context, keep int) (int, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM events WHERE id NOT IN (
+			SELECT id FROM events ORDER BY timestamp DESC LIMIT ?
+		)
+	`, keep)
+	if err != nil {
+		return 0, fmt.Errorf("trim events: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
+
+var _ PersistenceBackend = (*SQLiteEventStore)(nil)
+
+// eventStoreBackend adapts an EventStore that is not a PersistenceBackend.
+// It saves events one by one and cannot prune or trim.
+type eventStoreBackend struct {
+	store EventStore
+}
+
+func (b eventStoreBackend) Append(ctx context.Context, events []unit.Event) error {
+	for _, event := range events {
+		if err := b.store.Save(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b eventStoreBackend) Query(ctx context.Context, filter EventQueryFilter) ([]unit.Event, error) {
+	return b.store.Query(ctx, filter)
+}
+
+func (b eventStoreBackend) Prune(ctx context.Context, before time.Time) (int, error) {
+	return 0, fmt.Errorf("event store %T does not support pruning", b.store)
+}
+
+func (b eventStoreBackend) Trim(ctx context.Context, keep int) (int, error) {
+	return 0, fmt.Errorf("event store %T does not support trimming", b.store)
+}
+
+// backendFor returns store as a PersistenceBackend.
+func backendFor(store EventStore) PersistenceBackend {
+	if store == nil {
+		return nil
+	}
+	if backend, ok := store.(PersistenceBackend); ok {
+		return backend
+	}
+	return eventStoreBackend{store: store}
+}
+
+// MemoryBackend is a PersistenceBackend that keeps events in memory. It is
+// meant for tests and single-process setups that do not need durability.
+type MemoryBackend struct {
+	mu     sync.RWMutex
+	events []unit.Event
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{}
+}
+
+func (m *MemoryBackend) Append(ctx context.Context, events []unit.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, events...)
+	return nil
+}
+
+func (m *MemoryBackend) Query(ctx context.Context, filter EventQueryFilter) ([]unit.Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []unit.Event
+	for _, e := range m.events {
+		if filter.Domain != "" && e.Domain() != filter.Domain {
+			continue
+		}
+		if filter.Type != "" && e.Type() != filter.Type {
+			continue
+		}
+		if filter.CorrelationID != "" && e.CorrelationID() != filter.CorrelationID {
+			continue
+		}
+		if !filter.StartTime.IsZero() && e.Timestamp().Before(filter.StartTime) {
+			continue
+		}
+		if !filter.EndTime.IsZero() && e.Timestamp().After(filter.EndTime) {
+			continue
+		}
+		result = append(result, e)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp().After(result[j].Timestamp())
+	})
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func (m *MemoryBackend) Prune(ctx context.Context, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.events[:0]
+	for _, e := range m.events {
+		if !e.Timestamp().Before(before) {
+			kept = append(kept, e)
+		}
+	}
+	pruned := len(m.events) - len(kept)
+	m.events = kept
+	return pruned, nil
+}
+
+func (m *MemoryBackend) Trim(ctx context.Context, keep int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.events) <= keep {
+		return 0, nil
+	}
+	sort.SliceStable(m.events, func(i, j int) bool {
+		return m.events[i].Timestamp().Before(m.events[j].Timestamp())
+	})
+	trimmed := len(m.events) - keep
+	m.events = append([]unit.Event(nil), m.events[trimmed:]...)
+	return trimmed, nil
+}
+
+var _ PersistenceBackend = (*MemoryBackend)(nil)
@@ -0,0 +1,195 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestMemoryBackend_Query(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend()
+	now := time.Now()
+
+	require.NoError(t, backend.Append(ctx, []unit.Event{
+		&testEvent{eventType: "model.created", domain: "model", correlationID: "c1", timestamp: now.Add(-3 * time.Minute)},
+		&testEvent{eventType: "model.deleted", domain: "model", correlationID: "c1", timestamp: now.Add(-2 * time.Minute)},
+		&testEvent{eventType: "service.started", domain: "service", correlationID: "c2", timestamp: now.Add(-1 * time.Minute)},
+	}))
+
+	all, err := backend.Query(ctx, EventQueryFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "service.started", all[0].Type(), "events should be newest first")
+
+	byDomain, err := backend.Query(ctx, EventQueryFilter{Domain: "model"})
+	require.NoError(t, err)
+	assert.Len(t, byDomain, 2)
+
+	byCorrelation, err := backend.Query(ctx, EventQueryFilter{CorrelationID: "c1", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, byCorrelation, 1)
+	assert.Equal(t, "model.deleted", byCorrelation[0].Type())
+
+	inRange, err := backend.Query(ctx, EventQueryFilter{StartTime: now.Add(-150 * time.Second), EndTime: now})
+	require.NoError(t, err)
+	assert.Len(t, inRange, 2)
+}
+
+func TestMemoryBackend_Prune(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend()
+	now := time.Now()
+
+	require.NoError(t, backend.Append(ctx, []unit.Event{
+		&testEvent{eventType: "old", domain: "test", timestamp: now.Add(-2 * time.Hour)},
+		&testEvent{eventType: "new", domain: "test", timestamp: now},
+	}))
+
+	pruned, err := backend.Prune(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	events, err := backend.Query(ctx, EventQueryFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "new", events[0].Type())
+
+	require.NoError(t, backend.Append(ctx, []unit.Event{
+		&testEvent{eventType: "newer", domain: "test", timestamp: now.Add(time.Minute)},
+		&testEvent{eventType: "newest", domain: "test", timestamp: now.Add(2 * time.Minute)},
+	}))
+	trimmed, err := backend.Trim(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, trimmed)
+
+	events, err = backend.Query(ctx, EventQueryFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "newest", events[0].Type())
+}
+
+func TestPersistentEventBus_WithBackend(t *testing.T) {
+	backend := NewMemoryBackend()
+	bus := NewPersistentEventBus(nil, WithBackend(backend), WithFlushPeriod(10*time.Millisecond))
+	defer func() { _ = bus.Close() }()
+
+	require.NoError(t, bus.Publish(&testEvent{eventType: "test.event", domain: "test", correlationID: "corr-1", timestamp: time.Now()}))
+
+	assert.Eventually(t, func() bool {
+		events, err := bus.Query(context.Background(), EventQueryFilter{CorrelationID: "corr-1"})
+		return err == nil && len(events) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPersistentEventBus_PrunerRetention(t *testing.T) {
+	// The clock is a day ahead, so events stamped "now" by the test are
+	// already older than the one-hour retention once the pruner ticks.
+	clock := time.Now().Add(24 * time.Hour)
+	backend := NewMemoryBackend()
+	require.NoError(t, backend.Append(context.Background(), []unit.Event{
+		&testEvent{eventType: "expired", domain: "test", timestamp: time.Now()},
+		&testEvent{eventType: "fresh", domain: "test", timestamp: clock.Add(-time.Minute)},
+	}))
+
+	bus := NewPersistentEventBus(nil,
+		WithBackend(backend),
+		WithRetention(time.Hour),
+		WithPruneInterval(10*time.Millisecond),
+		WithClock(func() time.Time { return clock }),
+	)
+	defer func() { _ = bus.Close() }()
+
+	assert.Eventually(t, func() bool {
+		events, err := bus.Query(context.Background(), EventQueryFilter{})
+		return err == nil && len(events) == 1 && events[0].Type() == "fresh"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPersistentEventBus_PrunerMaxEvents(t *testing.T) {
+	backend := NewMemoryBackend()
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, backend.Append(context.Background(), []unit.Event{
+			&testEvent{eventType: "test.event", domain: "test", timestamp: now.Add(time.Duration(i) * time.Second)},
+		}))
+	}
+
+	bus := NewPersistentEventBus(nil,
+		WithBackend(backend),
+		WithMaxEvents(2),
+		WithPruneInterval(10*time.Millisecond),
+	)
+	defer func() { _ = bus.Close() }()
+
+	assert.Eventually(t, func() bool {
+		events, err := bus.Query(context.Background(), EventQueryFilter{})
+		return err == nil && len(events) == 2 && events[0].Timestamp().Equal(now.Add(4*time.Second))
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPersistentEventBus_PrunerStopsOnClose(t *testing.T) {
+	backend := NewMemoryBackend()
+	bus := NewPersistentEventBus(nil, WithBackend(backend), WithRetention(time.Hour), WithPruneInterval(time.Millisecond))
+
+	done := make(chan struct{})
+	go func() {
+		_ = bus.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not stop the pruner")
+	}
+}
+
+func TestSQLiteEventStore_Prune(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer func() { _ = db.Close() }()
+
+	_, err = db.Exec(`CREATE TABLE events (
+		id TEXT PRIMARY KEY, type TEXT NOT NULL, domain TEXT NOT NULL,
+		correlation_id TEXT, payload BLOB, timestamp INTEGER NOT NULL
+	)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	var backend PersistenceBackend = NewSQLiteEventStore(db)
+	now := time.Now()
+	require.NoError(t, backend.Append(ctx, []unit.Event{
+		&testEvent{eventType: "old", domain: "test", timestamp: now.Add(-2 * time.Hour)},
+		&testEvent{eventType: "new", domain: "test", timestamp: now},
+	}))
+
+	pruned, err := backend.Prune(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	events, err := backend.Query(ctx, EventQueryFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "new", events[0].Type())
+
+	require.NoError(t, backend.Append(ctx, []unit.Event{
+		&testEvent{eventType: "newer", domain: "test", timestamp: now.Add(time.Minute)},
+		&testEvent{eventType: "newest", domain: "test", timestamp: now.Add(2 * time.Minute)},
+	}))
+	trimmed, err := backend.Trim(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, trimmed)
+
+	events, err = backend.Query(ctx, EventQueryFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "newest", events[0].Type())
+}
@@ -0,0 +1,93 @@
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// DefaultDedupeWindow is the number of event IDs a Deduper remembers when
+// none is given.
+const DefaultDedupeWindow = 10000
+
+// EventID returns the ID of event if it is a unit.IdentifiedEvent, or "".
+func EventID(event unit.Event) string {
+	if e, ok := event.(unit.IdentifiedEvent); ok {
+		return e.ID()
+	}
+	return ""
+}
+
+// Deduper drops events whose ID it has already seen. Delivery is
+// at-least-once: after a restart, a persistent bus may replay events that
+// were mid-processing, so handlers with side effects should be wrapped in a
+// Deduper. It remembers the most recent window IDs; events without an ID
+// are always delivered.
+type Deduper struct {
+	mu     sync.Mutex
+	window int
+	seen   map[string]struct{}
+	order  []string
+	next   int
+}
+
+// NewDeduper returns a Deduper that remembers the last window event IDs,
+// or DefaultDedupeWindow if window is not positive.
+func NewDeduper(window int) *Deduper {
+	if window <= 0 {
+		window = DefaultDedupeWindow
+	}
+	return &Deduper{
+		window: window,
+		seen:   make(map[string]struct{}, window),
+		order:  make([]string, 0, window),
+	}
+}
+
+// Seen records id and reports whether it was already recorded. An empty id
+// is never seen.
+func (d *Deduper) Seen(id string) bool {
+	if id == "" {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.seen[id]; ok {
+		return true
+	}
+	if len(d.order) < d.window {
+		d.order = append(d.order, id)
+	} else {
+		delete(d.seen, d.order[d.next])
+		d.order[d.next] = id
+		d.next = (d.next + 1) % d.window
+	}
+	d.seen[id] = struct{}{}
+	return false
+}
+
+// Forget removes id so a later delivery of it is handled again.
+func (d *Deduper) Forget(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.seen, id)
+}
+
+// Handler wraps next so it runs once per event ID. If next fails, the ID is
+// forgotten so a redelivery can retry it.
+func (d *Deduper) Handler(next EventHandler) EventHandler {
+	return func(event unit.Event) error {
+		id := EventID(event)
+		if d.Seen(id) {
+			return nil
+		}
+		if err := next(event); err != nil {
+			if id != "" {
+				d.Forget(id)
+			}
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executionEvent(id string) *unit.ExecutionEvent {
+	return &unit.ExecutionEvent{
+		EventID:            id,
+		EventType:          string(unit.ExecutionCompleted),
+		EventDomain:        "test",
+		UnitName:           "test.unit",
+		EventTimestamp:     time.Now(),
+		EventCorrelationID: "corr-" + id,
+	}
+}
+
+func TestDeduper_DuplicateEventRunsOnce(t *testing.T) {
+	d := NewDeduper(10)
+	calls := 0
+	handler := d.Handler(func(event unit.Event) error {
+		calls++
+		return nil
+	})
+
+	require.NoError(t, handler(executionEvent("evt-1")))
+	require.NoError(t, handler(executionEvent("evt-1")))
+	require.NoError(t, handler(executionEvent("evt-2")))
+	assert.Equal(t, 2, calls)
+
+	// Events without an ID cannot be deduplicated and are always handled.
+	anonymous := &testEvent{eventType: "test.event", domain: "test", timestamp: time.Now()}
+	require.NoError(t, handler(anonymous))
+	require.NoError(t, handler(anonymous))
+	assert.Equal(t, 4, calls)
+}
+
+func TestDeduper_FailedHandlerIsRetried(t *testing.T) {
+	d := NewDeduper(10)
+	calls := 0
+	handler := d.Handler(func(event unit.Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+
+	assert.Error(t, handler(executionEvent("evt-1")))
+	require.NoError(t, handler(executionEvent("evt-1")))
+	require.NoError(t, handler(executionEvent("evt-1")))
+	assert.Equal(t, 2, calls)
+}
+
+func TestDeduper_BoundedWindow(t *testing.T) {
+	d := NewDeduper(3)
+	for i := 0; i < 4; i++ {
+		assert.False(t, d.Seen(fmt.Sprintf("evt-%d", i)))
+	}
+	assert.Len(t, d.seen, 3)
+	assert.False(t, d.Seen("evt-0"), "the oldest ID should have been evicted")
+	assert.True(t, d.Seen("evt-3"))
+}
+
+func TestDeduper_PersistedEventKeepsID(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+	event := executionEvent("evt-persisted")
+
+	require.NoError(t, store.Save(ctx, event))
+	// A redelivery of the same event is stored once.
+	require.NoError(t, store.SaveBatch(ctx, []unit.Event{event}))
+
+	events, err := store.Query(ctx, EventQueryFilter{CorrelationID: event.CorrelationID()})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "evt-persisted", EventID(events[0]))
+
+	d := NewDeduper(0)
+	calls := 0
+	handler := d.Handler(func(unit.Event) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, handler(event))
+	require.NoError(t, handler(events[0]))
+	assert.Equal(t, 1, calls, "a replayed event should be recognized as the live one")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -12,12 +13,31 @@ import (
 
 type SubscriptionID string
 
+// ErrClosed is returned by Publish and Subscribe once the bus is closed.
+var ErrClosed = errors.New("eventbus is closed")
+
 type EventHandler func(event unit.Event) error
 
 type EventFilter func(event unit.Event) bool
 
+// EventBus fans events out to subscribers.
+//
+// Publish is asynchronous: it queues the event and returns, and bus workers
+// deliver it later. Events published from one goroutine are dequeued in
+// order, but with more than one worker a subscriber may see them out of
+// order. Use it for fire-and-forget notifications such as progress updates.
+//
+// PublishSync delivers the event to every current subscriber before it
+// returns: handlers have run and channel subscribers' buffers have accepted
+// it. Successive PublishSync calls from one goroutine therefore reach every
+// subscriber in call order.
+//
+// Delivery is at-least-once: a persistent bus may deliver an event again
+// after a restart or replay. Events that implement unit.IdentifiedEvent keep
+// their ID across redeliveries; wrap handlers in a Deduper to run them once.
 type EventBus interface {
 	Publish(event unit.Event) error
+	PublishSync(event unit.Event) error
 	Subscribe(handler EventHandler, filters ...EventFilter) (SubscriptionID, error)
 	Unsubscribe(id SubscriptionID) error
 	Close() error
@@ -29,16 +49,60 @@ type InMemoryEventBus struct {
 	eventChan   chan unit.Event
 	workerCount int
 	bufferSize  int
+	types       *TypeRegistry
+	validation  ValidationMode
 	ctx         context.Context
 	cancel      context.CancelFunc
 	wg          sync.WaitGroup
 	closed      bool
+	closeOnce   sync.Once
+	// syncWG tracks PublishSync calls in flight so Close waits for them.
+	syncWG sync.WaitGroup
+	// publishWG tracks Publish calls in flight so Close only closes
+	// eventChan once nothing can send on it.
+	publishWG sync.WaitGroup
 }
 
 type subscription struct {
 	id      SubscriptionID
 	handler EventHandler
 	filters []EventFilter
+
+	// ch is set for SubscribeChannel subscriptions. It is closed exactly once,
+	// on Unsubscribe or Close, after done has stopped any pending delivery.
+	ch        chan unit.Event
+	done      chan struct{}
+	mu        sync.Mutex
+	closed    bool
+	closeOnce sync.Once
+}
+
+// deliver sends event to a channel subscription, giving up when the
+// subscription or the bus shuts down.
+func (s *subscription) deliver(ctx context.Context, event unit.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- event:
+	case <-s.done:
+	case <-ctx.Done():
+	}
+}
+
+func (s *subscription) close() {
+	if s.ch == nil {
+		return
+	}
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		s.closed = true
+		close(s.ch)
+		s.mu.Unlock()
+	})
 }
 
 func NewInMemoryEventBus(opts ...Option) *InMemoryEventBus {
@@ -58,6 +122,8 @@ func NewInMemoryEventBus(opts ...Option) *InMemoryEventBus {
 		eventChan:   make(chan unit.Event, config.bufferSize),
 		workerCount: config.workerCount,
 		bufferSize:  config.bufferSize,
+		types:       config.types,
+		validation:  config.validation,
 		ctx:         ctx,
 		cancel:      cancel,
 	}
@@ -73,6 +139,8 @@ func NewInMemoryEventBus(opts ...Option) *InMemoryEventBus {
 type config struct {
 	bufferSize  int
 	workerCount int
+	types       *TypeRegistry
+	validation  ValidationMode
 }
 
 type Option func(*config)
@@ -99,86 +167,149 @@ func WithWorkerCount(count int) Option {
 	}
 }
 
+// Publish queues event for delivery. It returns ErrClosed once Close has
+// been called, including to publishers blocked on a full queue.
 func (b *InMemoryEventBus) Publish(event unit.Event) error {
 	if event == nil {
 		return fmt.Errorf("event cannot be nil")
 	}
+	if err := checkPayload(b.types, b.validation, event); err != nil {
+		return err
+	}
 
+	// The send happens outside the lock so a full queue does not hold up
+	// Subscribe and Unsubscribe; publishWG keeps Close from closing
+	// eventChan underneath it.
 	b.mu.RLock()
-	closed := b.closed
-	b.mu.RUnlock()
-
-	if closed {
-		return fmt.Errorf("eventbus is closed")
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
 	}
+	b.publishWG.Add(1)
+	b.mu.RUnlock()
+	defer b.publishWG.Done()
 
 	select {
 	case b.eventChan <- event:
 		return nil
 	case <-b.ctx.Done():
-		return fmt.Errorf("eventbus is closed")
+		return ErrClosed
 	}
 }
 
+// PublishSync delivers event to every current subscriber on the calling
+// goroutine and returns once all of them have received it.
+func (b *InMemoryEventBus) PublishSync(event unit.Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+	if err := checkPayload(b.types, b.validation, event); err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	b.syncWG.Add(1)
+	b.mu.RUnlock()
+	defer b.syncWG.Done()
+
+	b.dispatchEvent(event)
+	return nil
+}
+
 func (b *InMemoryEventBus) Subscribe(handler EventHandler, filters ...EventFilter) (SubscriptionID, error) {
 	if handler == nil {
 		return "", fmt.Errorf("handler cannot be nil")
 	}
+	return b.subscribe(&subscription{handler: handler, filters: filters})
+}
+
+// SubscribeChannel delivers matching events on a channel with the given
+// buffer size. A full channel blocks delivery to it, and so one bus worker,
+// until the reader catches up. The channel is closed by Unsubscribe or Close.
+func (b *InMemoryEventBus) SubscribeChannel(buffer int, filters ...EventFilter) (SubscriptionID, <-chan unit.Event, error) {
+	if buffer < 0 {
+		buffer = 0
+	}
+	sub := &subscription{
+		filters: filters,
+		ch:      make(chan unit.Event, buffer),
+		done:    make(chan struct{}),
+	}
+	id, err := b.subscribe(sub)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, sub.ch, nil
+}
 
+func (b *InMemoryEventBus) subscribe(sub *subscription) (SubscriptionID, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	if b.closed {
-		return "", fmt.Errorf("eventbus is closed")
+		return "", ErrClosed
 	}
 
-	id := SubscriptionID(generateID())
-	b.subscribers[id] = &subscription{
-		id:      id,
-		handler: handler,
-		filters: filters,
-	}
-
-	return id, nil
+	sub.id = SubscriptionID(generateID())
+	b.subscribers[sub.id] = sub
+	return sub.id, nil
 }
 
 func (b *InMemoryEventBus) Unsubscribe(id SubscriptionID) error {
 	b.mu.Lock()
-	defer b.mu.Unlock()
+	sub, exists := b.subscribers[id]
+	delete(b.subscribers, id)
+	b.mu.Unlock()
 
-	if _, exists := b.subscribers[id]; !exists {
+	if !exists {
 		return fmt.Errorf("subscription %s not found", id)
 	}
-
-	delete(b.subscribers, id)
+	sub.close()
 	return nil
 }
 
+// Done returns a channel that is closed when the bus starts shutting down.
+func (b *InMemoryEventBus) Done() <-chan struct{} {
+	return b.ctx.Done()
+}
+
+// Close stops accepting events, delivers those already queued, waits for the
+// workers to exit and closes every channel subscription. It is safe to call
+// more than once.
 func (b *InMemoryEventBus) Close() error {
-	b.mu.Lock()
-	if b.closed {
-		b.mu.Unlock()
-		return nil
-	}
-	b.closed = true
-	b.mu.Unlock()
+	b.closeOnce.Do(func() {
+		// Cancel first so publishers blocked on a full queue return.
+		b.cancel()
 
-	b.cancel()
+		b.mu.Lock()
+		b.closed = true
+		b.mu.Unlock()
 
-	close(b.eventChan)
+		b.publishWG.Wait()
+		close(b.eventChan)
 
-	done := make(chan struct{})
-	go func() {
 		b.wg.Wait()
-		close(done)
-	}()
+		b.syncWG.Wait()
 
-	<-done
+		// Workers stop draining once the bus is cancelled, so a publisher
+		// that won the race with cancel may have queued after they left.
+		for event := range b.eventChan {
+			b.dispatchEvent(event)
+		}
 
-	b.mu.Lock()
-	b.subscribers = make(map[SubscriptionID]*subscription)
-	b.mu.Unlock()
+		b.mu.Lock()
+		subs := b.subscribers
+		b.subscribers = make(map[SubscriptionID]*subscription)
+		b.mu.Unlock()
 
+		for _, sub := range subs {
+			sub.close()
+		}
+	})
 	return nil
 }
 
@@ -224,6 +355,10 @@ func (b *InMemoryEventBus) dispatchEvent(event unit.Event) {
 			continue
 		}
 
+		if sub.ch != nil {
+			sub.deliver(b.ctx, event)
+			continue
+		}
 		_ = sub.handler(event)
 	}
 }
@@ -254,6 +389,12 @@ func FilterByDomain(domain string) EventFilter {
 	}
 }
 
+func FilterByCorrelationID(correlationID string) EventFilter {
+	return func(event unit.Event) bool {
+		return event.CorrelationID() == correlationID
+	}
+}
+
 func FilterByTypes(types ...string) EventFilter {
 	typeSet := make(map[string]bool)
 	for _, t := range types {
@@ -286,6 +427,11 @@ func NewEventPublisherAdapter(bus EventBus) *EventPublisherAdapter {
 	return &EventPublisherAdapter{bus: bus}
 }
 
+// Bus returns the wrapped EventBus.
+func (a *EventPublisherAdapter) Bus() EventBus {
+	return a.bus
+}
+
 // Publish implements unit.EventPublisher. It accepts `any` and attempts a type assertion
 // to unit.Event before forwarding to the underlying EventBus.
 func (a *EventPublisherAdapter) Publish(event any) error {
@@ -2,6 +2,7 @@ package eventbus
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -368,6 +369,209 @@ func TestInMemoryEventBus_CloseIdempotent(t *testing.T) {
 	}
 }
 
+func TestInMemoryEventBus_CloseClosesSubscriberChannels(t *testing.T) {
+	bus := NewInMemoryEventBus()
+
+	_, ch, err := bus.SubscribeChannel(1)
+	if err != nil {
+		t.Fatalf("SubscribeChannel failed: %v", err)
+	}
+	// A second subscriber that never reads must not block Close.
+	_, _, err = bus.SubscribeChannel(0)
+	if err != nil {
+		t.Fatalf("SubscribeChannel failed: %v", err)
+	}
+
+	if err := bus.Publish(newMockEvent("test.event", "test")); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("expected the event on the subscriber channel")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		_ = bus.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not return")
+	}
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected the subscriber channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber channel was not closed")
+	}
+
+	if err := bus.Publish(newMockEvent("test.event", "test")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish after Close = %v, want ErrClosed", err)
+	}
+	if _, _, err := bus.SubscribeChannel(1); !errors.Is(err, ErrClosed) {
+		t.Errorf("SubscribeChannel after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestInMemoryEventBus_UnsubscribeClosesChannel(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	defer func() { _ = bus.Close() }()
+
+	id, ch, err := bus.SubscribeChannel(0)
+	if err != nil {
+		t.Fatalf("SubscribeChannel failed: %v", err)
+	}
+	if err := bus.Unsubscribe(id); err != nil {
+		t.Fatalf("Unsubscribe failed: %v", err)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected the channel to be closed after Unsubscribe")
+	}
+}
+
+func TestInMemoryEventBus_PublishDuringClose(t *testing.T) {
+	bus := NewInMemoryEventBus(WithBufferSize(1), WithWorkerCount(1))
+	_, err := bus.Subscribe(func(event unit.Event) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := bus.Publish(newMockEvent("test.event", "test")); err != nil && !errors.Is(err, ErrClosed) {
+					t.Errorf("unexpected Publish error: %v", err)
+				}
+			}
+		}()
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	_ = bus.Close()
+	wg.Wait()
+}
+
+func TestInMemoryEventBus_BlockedPublishDoesNotBlockSubscribe(t *testing.T) {
+	bus := NewInMemoryEventBus(WithBufferSize(1), WithWorkerCount(1))
+	defer bus.Close()
+
+	release := make(chan struct{})
+	_, err := bus.Subscribe(func(event unit.Event) error {
+		<-release
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer close(release)
+
+	// The worker blocks on the first event, the second fills the queue and
+	// the third blocks its publisher.
+	for i := 0; i < 2; i++ {
+		if err := bus.Publish(newMockEvent("test.event", "test")); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	go func() { _ = bus.Publish(newMockEvent("test.event", "test")) }()
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := bus.Subscribe(func(event unit.Event) error { return nil })
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Subscribe blocked behind a publisher waiting on a full queue")
+	}
+}
+
+func TestInMemoryEventBus_PublishSyncOrdering(t *testing.T) {
+	bus := NewInMemoryEventBus(WithWorkerCount(4))
+	defer func() { _ = bus.Close() }()
+
+	var mu sync.Mutex
+	var first, second []int
+	record := func(dst *[]int) EventHandler {
+		return func(event unit.Event) error {
+			mu.Lock()
+			*dst = append(*dst, event.Payload().(int))
+			mu.Unlock()
+			return nil
+		}
+	}
+	if _, err := bus.Subscribe(record(&first)); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if _, err := bus.Subscribe(record(&second)); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	const n = 100
+	for i := 0; i < n; i++ {
+		event := newMockEvent("test.progress", "test")
+		event.payload = i
+		if err := bus.PublishSync(event); err != nil {
+			t.Fatalf("PublishSync failed: %v", err)
+		}
+		// Delivery has finished by the time PublishSync returns.
+		mu.Lock()
+		got := len(first) == i+1 && len(second) == i+1
+		mu.Unlock()
+		if !got {
+			t.Fatalf("event %d not delivered to both subscribers before PublishSync returned", i)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if first[i] != i || second[i] != i {
+			t.Fatalf("out of order at %d: first=%d second=%d", i, first[i], second[i])
+		}
+	}
+}
+
+func TestInMemoryEventBus_PublishSyncChannel(t *testing.T) {
+	bus := NewInMemoryEventBus()
+
+	_, ch, err := bus.SubscribeChannel(2)
+	if err != nil {
+		t.Fatalf("SubscribeChannel failed: %v", err)
+	}
+
+	for _, eventType := range []string{"a", "b"} {
+		if err := bus.PublishSync(newMockEvent(eventType, "test")); err != nil {
+			t.Fatalf("PublishSync failed: %v", err)
+		}
+	}
+	if len(ch) != 2 {
+		t.Fatalf("expected both events buffered on return, got %d", len(ch))
+	}
+	if e := <-ch; e.Type() != "a" {
+		t.Errorf("first event = %s, want a", e.Type())
+	}
+
+	_ = bus.Close()
+	if err := bus.PublishSync(newMockEvent("c", "test")); !errors.Is(err, ErrClosed) {
+		t.Errorf("PublishSync after Close = %v, want ErrClosed", err)
+	}
+}
+
 func TestInMemoryEventBus_Options(t *testing.T) {
 	bus := NewInMemoryEventBus(
 		WithBufferSize(500),
@@ -3,6 +3,7 @@ package eventbus
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -11,10 +12,14 @@ import (
 
 type PersistentEventBus struct {
 	memory      *InMemoryEventBus
-	store       EventStore
+	backend     PersistenceBackend
 	buffer      chan unit.Event
 	batchSize   int
 	flushPeriod time.Duration
+	retention   time.Duration
+	maxEvents   int
+	pruneEvery  time.Duration
+	now         func() time.Time
 	wg          sync.WaitGroup
 	ctx         context.Context
 	cancel      context.CancelFunc
@@ -22,12 +27,18 @@ type PersistentEventBus struct {
 	mu          sync.RWMutex
 }
 
+// NewPersistentEventBus returns a bus that delivers events in memory and
+// persists them to store in batches. WithBackend replaces store with any
+// PersistenceBackend; store may then be nil.
 func NewPersistentEventBus(store EventStore, opts ...PersistentOption) *PersistentEventBus {
 	config := &persistentConfig{
 		bufferSize:  1000,
 		batchSize:   100,
 		flushPeriod: 1 * time.Second,
 		workerCount: 4,
+		backend:     backendFor(store),
+		pruneEvery:  5 * time.Minute,
+		now:         time.Now,
 	}
 
 	for _, opt := range opts {
@@ -37,11 +48,19 @@ func NewPersistentEventBus(store EventStore, opts ...PersistentOption) *Persiste
 	ctx, cancel := context.WithCancel(context.Background())
 
 	bus := &PersistentEventBus{
-		memory:      NewInMemoryEventBus(WithBufferSize(config.bufferSize), WithWorkerCount(config.workerCount)),
-		store:       store,
+		memory: NewInMemoryEventBus(
+			WithBufferSize(config.bufferSize),
+			WithWorkerCount(config.workerCount),
+			WithTypeRegistry(config.types, config.validation),
+		),
+		backend:     config.backend,
 		buffer:      make(chan unit.Event, config.bufferSize),
 		batchSize:   config.batchSize,
 		flushPeriod: config.flushPeriod,
+		retention:   config.retention,
+		maxEvents:   config.maxEvents,
+		pruneEvery:  config.pruneEvery,
+		now:         config.now,
 		ctx:         ctx,
 		cancel:      cancel,
 	}
@@ -49,6 +68,11 @@ func NewPersistentEventBus(store EventStore, opts ...PersistentOption) *Persiste
 	bus.wg.Add(1)
 	go bus.persistenceWorker()
 
+	if bus.retention > 0 || bus.maxEvents > 0 {
+		bus.wg.Add(1)
+		go bus.pruner()
+	}
+
 	return bus
 }
 
@@ -57,6 +81,13 @@ type persistentConfig struct {
 	batchSize   int
 	flushPeriod time.Duration
 	workerCount int
+	backend     PersistenceBackend
+	retention   time.Duration
+	maxEvents   int
+	pruneEvery  time.Duration
+	now         func() time.Time
+	types       *TypeRegistry
+	validation  ValidationMode
 }
 
 type PersistentOption func(*persistentConfig)
@@ -94,17 +125,75 @@ func WithPersistentWorkerCount(count int) PersistentOption {
 	}
 }
 
+// WithBackend persists events to backend instead of the store passed to
+// NewPersistentEventBus.
+func WithBackend(backend PersistenceBackend) PersistentOption {
+	return func(c *persistentConfig) {
+		if backend != nil {
+			c.backend = backend
+		}
+	}
+}
+
+// WithPersistentTypeRegistry validates published payloads against registry
+// using mode; rejected events are neither delivered nor persisted.
+func WithPersistentTypeRegistry(registry *TypeRegistry, mode ValidationMode) PersistentOption {
+	return func(c *persistentConfig) {
+		c.types = registry
+		c.validation = mode
+	}
+}
+
+// WithRetention deletes persisted events older than maxAge. Pruning runs in
+// the background every prune interval; without a retention or WithMaxEvents
+// events are kept forever.
+func WithRetention(maxAge time.Duration) PersistentOption {
+	return func(c *persistentConfig) {
+		if maxAge > 0 {
+			c.retention = maxAge
+		}
+	}
+}
+
+// WithMaxEvents trims the backend to the newest n events on every prune.
+func WithMaxEvents(n int) PersistentOption {
+	return func(c *persistentConfig) {
+		if n > 0 {
+			c.maxEvents = n
+		}
+	}
+}
+
+// WithPruneInterval sets how often retention and max-events pruning runs.
+// The default is five minutes.
+func WithPruneInterval(interval time.Duration) PersistentOption {
+	return func(c *persistentConfig) {
+		if interval > 0 {
+			c.pruneEvery = interval
+		}
+	}
+}
+
+// WithClock replaces time.Now when computing the retention cutoff.
+func WithClock(now func() time.Time) PersistentOption {
+	return func(c *persistentConfig) {
+		if now != nil {
+			c.now = now
+		}
+	}
+}
+
 func (b *PersistentEventBus) Publish(event unit.Event) error {
 	if event == nil {
 		return fmt.Errorf("event cannot be nil")
 	}
 
+	// Held across the send so Close cannot close buffer underneath it.
 	b.mu.RLock()
-	closed := b.closed
-	b.mu.RUnlock()
+	defer b.mu.RUnlock()
 
-	if closed {
-		return fmt.Errorf("eventbus is closed")
+	if b.closed {
+		return ErrClosed
 	}
 
 	if err := b.memory.Publish(event); err != nil {
@@ -115,7 +204,33 @@ func (b *PersistentEventBus) Publish(event unit.Event) error {
 	case b.buffer <- event:
 		return nil
 	case <-b.ctx.Done():
-		return fmt.Errorf("eventbus is closed")
+		return ErrClosed
+	}
+}
+
+// PublishSync delivers event to subscribers before returning; persisting it
+// stays asynchronous.
+func (b *PersistentEventBus) PublishSync(event unit.Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	if err := b.memory.PublishSync(event); err != nil {
+		return err
+	}
+
+	select {
+	case b.buffer <- event:
+		return nil
+	case <-b.ctx.Done():
+		return ErrClosed
 	}
 }
 
@@ -123,12 +238,26 @@ func (b *PersistentEventBus) Subscribe(handler EventHandler, filters ...EventFil
 	return b.memory.Subscribe(handler, filters...)
 }
 
+func (b *PersistentEventBus) SubscribeChannel(buffer int, filters ...EventFilter) (SubscriptionID, <-chan unit.Event, error) {
+	return b.memory.SubscribeChannel(buffer, filters...)
+}
+
+// Done returns a channel that is closed when the bus starts shutting down.
+func (b *PersistentEventBus) Done() <-chan struct{} {
+	return b.ctx.Done()
+}
+
 func (b *PersistentEventBus) Unsubscribe(id SubscriptionID) error {
 	return b.memory.Unsubscribe(id)
 }
 
 func (b *PersistentEventBus) Query(ctx context.Context, filter EventQueryFilter) ([]unit.Event, error) {
-	return b.store.Query(ctx, filter)
+	return b.backend.Query(ctx, filter)
+}
+
+// Prune deletes persisted events older than before.
+func (b *PersistentEventBus) Prune(ctx context.Context, before time.Time) (int, error) {
+	return b.backend.Prune(ctx, before)
 }
 
 func (b *PersistentEventBus) Replay(ctx context.Context, correlationID string, handler EventHandler) error {
@@ -136,7 +265,7 @@ func (b *PersistentEventBus) Replay(ctx context.Context, correlationID string, h
 		return fmt.Errorf("handler cannot be nil")
 	}
 
-	events, err := b.store.Query(ctx, EventQueryFilter{
+	events, err := b.backend.Query(ctx, EventQueryFilter{
 		CorrelationID: correlationID,
 	})
 	if err != nil {
@@ -153,17 +282,18 @@ func (b *PersistentEventBus) Replay(ctx context.Context, correlationID string, h
 }
 
 func (b *PersistentEventBus) Close() error {
+	// Cancel before taking the lock so publishers blocked on a full buffer
+	// return and release their read locks.
+	b.cancel()
+
 	b.mu.Lock()
 	if b.closed {
 		b.mu.Unlock()
 		return nil
 	}
 	b.closed = true
-	b.mu.Unlock()
-
-	b.cancel()
-
 	close(b.buffer)
+	b.mu.Unlock()
 
 	done := make(chan struct{})
 	go func() {
@@ -191,12 +321,8 @@ func (b *PersistentEventBus) persistenceWorker() {
 			return
 		}
 
-		if store, ok := b.store.(*SQLiteEventStore); ok {
-			_ = store.SaveBatch(context.Background(), batch)
-		} else {
-			for _, event := range batch {
-				_ = b.store.Save(context.Background(), event)
-			}
+		if err := b.backend.Append(context.Background(), batch); err != nil {
+			slog.Warn("failed to persist events", "count", len(batch), "error", err)
 		}
 		batch = batch[:0]
 	}
@@ -217,3 +343,38 @@ func (b *PersistentEventBus) persistenceWorker() {
 		}
 	}
 }
+
+// pruner applies the retention and max-events limits every prune interval
+// until the bus is closed.
+func (b *PersistentEventBus) pruner() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.pruneEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-ticker.C:
+			b.prune(b.ctx)
+		}
+	}
+}
+
+func (b *PersistentEventBus) prune(ctx context.Context) {
+	if b.retention > 0 {
+		if n, err := b.backend.Prune(ctx, b.now().Add(-b.retention)); err != nil {
+			slog.Warn("failed to prune expired events", "error", err)
+		} else if n > 0 {
+			slog.Debug("pruned expired events", "count", n)
+		}
+	}
+	if b.maxEvents > 0 {
+		if n, err := b.backend.Trim(ctx, b.maxEvents); err != nil {
+			slog.Warn("failed to trim events", "max_events", b.maxEvents, "error", err)
+		} else if n > 0 {
+			slog.Debug("trimmed events", "count", n)
+		}
+	}
+}
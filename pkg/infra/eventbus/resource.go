@@ -0,0 +1,182 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+const eventStreamURIPrefix = "asms://events/"
+
+// eventStreamBuffer bounds how far a slow watcher may fall behind before the
+// bus worker delivering to it blocks.
+const eventStreamBuffer = 64
+
+// EventStreamResource exposes the events sharing a correlation ID as a
+// watchable resource at asms://events/{correlation_id}.
+type EventStreamResource struct {
+	correlationID string
+	bus           EventBus
+}
+
+func NewEventStreamResource(correlationID string, bus EventBus) *EventStreamResource {
+	return &EventStreamResource{correlationID: correlationID, bus: bus}
+}
+
+// EventStreamResourceFactory creates EventStreamResource instances for
+// asms://events/{correlation_id} URIs.
+type EventStreamResourceFactory struct {
+	bus EventBus
+}
+
+func NewEventStreamResourceFactory(bus EventBus) *EventStreamResourceFactory {
+	return &EventStreamResourceFactory{bus: bus}
+}
+
+func (f *EventStreamResourceFactory) CanCreate(uri string) bool {
+	return strings.HasPrefix(uri, eventStreamURIPrefix)
+}
+
+func (f *EventStreamResourceFactory) Create(uri string) (unit.Resource, error) {
+	correlationID := strings.TrimPrefix(uri, eventStreamURIPrefix)
+	if correlationID == "" || strings.Contains(correlationID, "/") {
+		return nil, fmt.Errorf("invalid events URI: %s", uri)
+	}
+	return NewEventStreamResource(correlationID, f.bus), nil
+}
+
+func (f *EventStreamResourceFactory) Pattern() string {
+	return eventStreamURIPrefix + "*"
+}
+
+func (r *EventStreamResource) URI() string {
+	return eventStreamURIPrefix + r.correlationID
+}
+
+func (r *EventStreamResource) Domain() string {
+	return "events"
+}
+
+func (r *EventStreamResource) Schema() unit.Schema {
+	return unit.Schema{
+		Type:        "object",
+		Description: "Live event stream for a correlation ID",
+		Properties: map[string]unit.Field{
+			"correlation_id": {Name: "correlation_id", Schema: unit.Schema{Type: "string"}},
+			"events":         {Name: "events", Schema: unit.Schema{Type: "array", Items: &unit.Schema{Type: "object"}}},
+		},
+	}
+}
+
+// Get returns the events recorded so far when the bus is persistent. Use
+// Watch to follow new events.
+func (r *EventStreamResource) Get(ctx context.Context) (any, error) {
+	events := []map[string]any{}
+	if q, ok := r.bus.(interface {
+		Query(ctx context.Context, filter EventQueryFilter) ([]unit.Event, error)
+	}); ok {
+		stored, err := q.Query(ctx, EventQueryFilter{CorrelationID: r.correlationID})
+		if err != nil {
+			return nil, fmt.Errorf("query events: %w", err)
+		}
+		for _, e := range stored {
+			events = append(events, map[string]any{
+				"type":      e.Type(),
+				"domain":    e.Domain(),
+				"payload":   e.Payload(),
+				"timestamp": e.Timestamp(),
+			})
+		}
+	}
+
+	return map[string]any{
+		"correlation_id": r.correlationID,
+		"events":         events,
+	}, nil
+}
+
+// Watch subscribes to events carrying the resource's correlation ID and
+// streams them in delivery order. The channel closes after an
+// execution_completed or execution_failed event, when ctx is done, or when
+// the bus is closed.
+func (r *EventStreamResource) Watch(ctx context.Context) (<-chan unit.ResourceUpdate, error) {
+	if r.bus == nil {
+		return nil, fmt.Errorf("event bus not configured")
+	}
+
+	ch := make(chan unit.ResourceUpdate, eventStreamBuffer)
+	done := make(chan struct{})
+	var once sync.Once
+	var mu sync.Mutex
+	closed := false
+	finish := func() {
+		once.Do(func() {
+			close(done)
+			mu.Lock()
+			closed = true
+			close(ch)
+			mu.Unlock()
+		})
+	}
+
+	// Buses that report shutdown end the watch when they close, so watchers
+	// are not left waiting on a bus that will never deliver again.
+	var busDone <-chan struct{}
+	if d, ok := r.bus.(interface{ Done() <-chan struct{} }); ok {
+		busDone = d.Done()
+	}
+
+	uri := r.URI()
+	id, err := r.bus.Subscribe(func(event unit.Event) error {
+		update := unit.ResourceUpdate{
+			URI:       uri,
+			Timestamp: event.Timestamp(),
+			Operation: event.Type(),
+			Data:      event.Payload(),
+		}
+
+		mu.Lock()
+		if closed {
+			mu.Unlock()
+			return nil
+		}
+		select {
+		case ch <- update:
+		case <-ctx.Done():
+		case <-busDone:
+		}
+		mu.Unlock()
+
+		if isTerminalEvent(event) {
+			go finish()
+		}
+		return nil
+	}, FilterByCorrelationID(r.correlationID))
+	if err != nil {
+		return nil, fmt.Errorf("subscribe: %w", err)
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		case <-busDone:
+		}
+		_ = r.bus.Unsubscribe(id)
+		finish()
+	}()
+
+	return ch, nil
+}
+
+func isTerminalEvent(event unit.Event) bool {
+	switch unit.ExecutionEventType(event.Type()) {
+	case unit.ExecutionCompleted, unit.ExecutionFailed:
+		return true
+	default:
+		return false
+	}
+}
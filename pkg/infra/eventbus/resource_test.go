@@ -0,0 +1,128 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+func TestEventStreamResourceFactory(t *testing.T) {
+	f := NewEventStreamResourceFactory(nil)
+
+	if !f.CanCreate("asms://events/abc") {
+		t.Error("expected factory to handle asms://events/ URIs")
+	}
+	if f.CanCreate("asms://model/abc") {
+		t.Error("expected factory to ignore other URIs")
+	}
+	if _, err := f.Create("asms://events/"); err == nil {
+		t.Error("expected error for missing correlation ID")
+	}
+
+	res, err := f.Create("asms://events/abc")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if res.URI() != "asms://events/abc" {
+		t.Errorf("unexpected URI %s", res.URI())
+	}
+}
+
+func TestEventStreamResource_WatchStreamsInOrder(t *testing.T) {
+	// A single worker keeps delivery in publish order.
+	bus := NewInMemoryEventBus(WithWorkerCount(1))
+	defer func() { _ = bus.Close() }()
+
+	res := NewEventStreamResource("pull-1", bus)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	updates, err := res.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	progress := []float64{0.1, 0.5, 1}
+	for _, p := range progress {
+		_ = bus.Publish(model.NewPullProgressEventWithCorrelation(&model.PullProgress{Status: "downloading", Progress: p}, "pull-1"))
+		_ = bus.Publish(model.NewPullProgressEventWithCorrelation(&model.PullProgress{Status: "downloading", Progress: p}, "pull-2"))
+	}
+	_ = bus.Publish(&unit.ExecutionEvent{
+		EventType:          string(unit.ExecutionCompleted),
+		EventDomain:        "model",
+		UnitName:           "model.pull",
+		EventTimestamp:     time.Now(),
+		EventCorrelationID: "pull-1",
+	})
+
+	var got []unit.ResourceUpdate
+	for update := range updates {
+		got = append(got, update)
+	}
+
+	if len(got) != len(progress)+1 {
+		t.Fatalf("expected %d updates, got %d", len(progress)+1, len(got))
+	}
+	for i, p := range progress {
+		if got[i].Operation != model.EventTypePullProgress {
+			t.Errorf("update %d: expected %s, got %s", i, model.EventTypePullProgress, got[i].Operation)
+		}
+		payload := got[i].Data.(map[string]any)
+		if payload["progress"] != p {
+			t.Errorf("update %d: expected progress %v, got %v", i, p, payload["progress"])
+		}
+	}
+	if got[len(got)-1].Operation != string(unit.ExecutionCompleted) {
+		t.Errorf("expected stream to end with completion, got %s", got[len(got)-1].Operation)
+	}
+}
+
+func TestEventStreamResource_WatchStopsOnCancel(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	defer func() { _ = bus.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := NewEventStreamResource("pull-1", bus).Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Error("expected no updates after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected channel to close after cancel")
+	}
+
+	bus.mu.RLock()
+	subscribers := len(bus.subscribers)
+	bus.mu.RUnlock()
+	if subscribers != 0 {
+		t.Errorf("expected subscription removed, got %d subscribers", subscribers)
+	}
+}
+
+func TestEventStreamResource_WatchStopsOnBusClose(t *testing.T) {
+	bus := NewInMemoryEventBus()
+
+	updates, err := NewEventStreamResource("pull-1", bus).Watch(context.Background())
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	_ = bus.Close()
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Error("expected no updates after the bus closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected channel to close when the bus closes")
+	}
+}
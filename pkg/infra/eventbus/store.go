@@ -34,13 +34,14 @@ type storedEvent struct {
 	timestamp     time.Time
 }
 
+func (e *storedEvent) ID() string            { return e.id }
 func (e *storedEvent) Type() string          { return e.eventType }
 func (e *storedEvent) Domain() string        { return e.domain }
 func (e *storedEvent) Payload() any          { return e.payload }
 func (e *storedEvent) Timestamp() time.Time  { return e.timestamp }
 func (e *storedEvent) CorrelationID() string { return e.correlationID }
 
-var _ unit.Event = (*storedEvent)(nil)
+var _ unit.IdentifiedEvent = (*storedEvent)(nil)
 
 type SQLiteEventStore struct {
 	db *sql.DB
@@ -50,6 +51,15 @@ func NewSQLiteEventStore(db *sql.DB) *SQLiteEventStore {
 	return &SQLiteEventStore{db: db}
 }
 
+// storedID returns the ID to persist event under: its own ID when it has
+// one, so a redelivered event is stored once, or a new one.
+func storedID(event unit.Event) string {
+	if id := EventID(event); id != "" {
+		return id
+	}
+	return generateID()
+}
+
 func (s *SQLiteEventStore) Save(ctx context.Context, event unit.Event) error {
 	payload, err := json.Marshal(event.Payload())
 	if err != nil {
@@ -57,9 +67,9 @@ func (s *SQLiteEventStore) Save(ctx context.Context, event unit.Event) error {
 	}
 
 	_, err = s.db.ExecContext(ctx, `
-		INSERT INTO events (id, type, domain, correlation_id, payload, timestamp)
+		INSERT OR IGNORE INTO events (id, type, domain, correlation_id, payload, timestamp)
 		VALUES (?, ?, ?, ?, ?, ?)
-	`, generateID(), event.Type(), event.Domain(), event.CorrelationID(), payload, event.Timestamp().Unix())
+	`, storedID(event), event.Type(), event.Domain(), event.CorrelationID(), payload, event.Timestamp().Unix())
 
 	if err != nil {
 		return fmt.Errorf("insert event: %w", err)
@@ -151,7 +161,7 @@ func (s *SQLiteEventStore) SaveBatch(ctx context.Context, events []unit.Event) e
 	defer func() { _ = tx.Rollback() }()
 
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO events (id, type, domain, correlation_id, payload, timestamp)
+		INSERT OR IGNORE INTO events (id, type, domain, correlation_id, payload, timestamp)
 		VALUES (?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
@@ -165,7 +175,7 @@ func (s *SQLiteEventStore) SaveBatch(ctx context.Context, events []unit.Event) e
 			return fmt.Errorf("marshal event payload: %w", err)
 		}
 
-		_, err = stmt.ExecContext(ctx, generateID(), event.Type(), event.Domain(), event.CorrelationID(), payload, event.Timestamp().Unix())
+		_, err = stmt.ExecContext(ctx, storedID(event), event.Type(), event.Domain(), event.CorrelationID(), payload, event.Timestamp().Unix())
 		if err != nil {
 			return fmt.Errorf("insert event: %w", err)
 		}
@@ -0,0 +1,91 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// ErrInvalidPayload is returned by Publish in strict validation mode when an
+// event's payload does not match the schema registered for its type.
+var ErrInvalidPayload = errors.New("invalid event payload")
+
+// ValidationMode controls what a bus does with events whose payload does not
+// match their registered schema.
+type ValidationMode int
+
+const (
+	// ValidationOff skips payload validation.
+	ValidationOff ValidationMode = iota
+	// ValidationWarn logs mismatches and publishes the event anyway.
+	ValidationWarn
+	// ValidationStrict rejects mismatching events with ErrInvalidPayload.
+	ValidationStrict
+)
+
+// TypeRegistry records the payload schema each event type is expected to
+// carry, so a misspelt key is caught at publish time instead of silently
+// breaking subscribers. Event types without a schema are not checked.
+type TypeRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string]unit.Schema
+}
+
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{schemas: make(map[string]unit.Schema)}
+}
+
+// Register sets the payload schema for eventType, replacing any previous one.
+func (r *TypeRegistry) Register(eventType string, schema unit.Schema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[eventType] = schema
+}
+
+// Schema returns the payload schema registered for eventType.
+func (r *TypeRegistry) Schema(eventType string) (unit.Schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, ok := r.schemas[eventType]
+	return schema, ok
+}
+
+// Validate checks event's payload against the schema registered for its
+// type. Unregistered types always pass.
+func (r *TypeRegistry) Validate(event unit.Event) error {
+	schema, ok := r.Schema(event.Type())
+	if !ok {
+		return nil
+	}
+	if err := schema.Validate(event.Payload()); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidPayload, event.Type(), err)
+	}
+	return nil
+}
+
+// WithTypeRegistry validates published payloads against registry using mode.
+func WithTypeRegistry(registry *TypeRegistry, mode ValidationMode) Option {
+	return func(c *config) {
+		c.types = registry
+		c.validation = mode
+	}
+}
+
+// checkPayload applies the bus's validation mode to event.
+func checkPayload(registry *TypeRegistry, mode ValidationMode, event unit.Event) error {
+	if registry == nil || mode == ValidationOff {
+		return nil
+	}
+	err := registry.Validate(event)
+	if err == nil {
+		return nil
+	}
+	if mode == ValidationStrict {
+		return err
+	}
+	slog.Warn("event payload does not match its schema", "type", event.Type(), "error", err)
+	return nil
+}
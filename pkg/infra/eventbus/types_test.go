@@ -0,0 +1,102 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+func modelCreatedRegistry() *TypeRegistry {
+	registry := NewTypeRegistry()
+	registry.Register("model.created", unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"model_id": {Name: "model_id", Schema: unit.Schema{Type: "string"}},
+			"name":     {Name: "name", Schema: unit.Schema{Type: "string"}},
+		},
+		Required: []string{"model_id"},
+	})
+	return registry
+}
+
+func payloadEvent(eventType string, payload map[string]any) *mockEvent {
+	e := newMockEvent(eventType, "model")
+	e.payload = payload
+	return e
+}
+
+func TestTypeRegistry_Validate(t *testing.T) {
+	registry := modelCreatedRegistry()
+
+	if err := registry.Validate(payloadEvent("model.created", map[string]any{"model_id": "m1"})); err != nil {
+		t.Errorf("valid payload rejected: %v", err)
+	}
+	if err := registry.Validate(payloadEvent("model.created", map[string]any{"modelId": "m1"})); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("missing model_id = %v, want ErrInvalidPayload", err)
+	}
+	if err := registry.Validate(payloadEvent("model.created", map[string]any{"model_id": 42})); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("non-string model_id = %v, want ErrInvalidPayload", err)
+	}
+	if err := registry.Validate(payloadEvent("model.deleted", map[string]any{"anything": true})); err != nil {
+		t.Errorf("unregistered type should pass, got %v", err)
+	}
+}
+
+func TestInMemoryEventBus_StrictValidation(t *testing.T) {
+	bus := NewInMemoryEventBus(WithTypeRegistry(modelCreatedRegistry(), ValidationStrict))
+	defer func() { _ = bus.Close() }()
+
+	var delivered int64
+	if _, err := bus.Subscribe(func(event unit.Event) error {
+		atomic.AddInt64(&delivered, 1)
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	bad := payloadEvent("model.created", map[string]any{"modelId": "m1"})
+	if err := bus.Publish(bad); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("Publish = %v, want ErrInvalidPayload", err)
+	}
+	if err := bus.PublishSync(bad); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("PublishSync = %v, want ErrInvalidPayload", err)
+	}
+
+	if err := bus.PublishSync(payloadEvent("model.created", map[string]any{"model_id": "m1"})); err != nil {
+		t.Fatalf("PublishSync of a valid event failed: %v", err)
+	}
+	if got := atomic.LoadInt64(&delivered); got != 1 {
+		t.Errorf("delivered %d events, want only the valid one", got)
+	}
+}
+
+func TestInMemoryEventBus_WarnValidation(t *testing.T) {
+	bus := NewInMemoryEventBus(WithTypeRegistry(modelCreatedRegistry(), ValidationWarn))
+	defer func() { _ = bus.Close() }()
+
+	if err := bus.Publish(payloadEvent("model.created", map[string]any{"modelId": "m1"})); err != nil {
+		t.Errorf("warn mode should publish mismatching events, got %v", err)
+	}
+}
+
+func TestPersistentEventBus_StrictValidation(t *testing.T) {
+	backend := NewMemoryBackend()
+	bus := NewPersistentEventBus(nil,
+		WithBackend(backend),
+		WithFlushPeriod(10*time.Millisecond),
+		WithPersistentTypeRegistry(modelCreatedRegistry(), ValidationStrict),
+	)
+
+	if err := bus.Publish(payloadEvent("model.created", map[string]any{"modelId": "m1"})); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("Publish = %v, want ErrInvalidPayload", err)
+	}
+	_ = bus.Close()
+
+	if events, _ := backend.Query(context.Background(), EventQueryFilter{}); len(events) != 0 {
+		t.Errorf("rejected event was persisted: %d events", len(events))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -67,11 +68,12 @@ type smiInterface interface {
 }
 
 type Provider struct {
-	smi       smiInterface
-	cache     map[string]*hal.HardwareInfo
-	cacheMu   sync.RWMutex
-	cacheTTL  time.Duration
-	cacheTime time.Time
+	smi         smiInterface
+	cache       map[string]*hal.HardwareInfo
+	cacheMu     sync.RWMutex
+	cacheTTL    time.Duration
+	cacheTime   time.Time
+	cudaVersion string // guarded by cacheMu; reported once per nvidia-smi log
 }
 
 type Option func(*Provider)
@@ -136,35 +138,24 @@ func (p *Provider) Detect(ctx context.Context) ([]device.DeviceInfo, error) {
 			Type:         hal.DeviceTypeGPU,
 			Architecture: gpuArchitecture(gpu.ProductArchitecture, gpu.ProductBrand),
 			Memory:       effectiveMemory(parseMemory(gpu.FBMemoryUsage.Total)),
+			Driver:       output.DriverVersion,
 			DiscoveredAt: time.Now(),
 		}
 
 		p.cache[info.ID] = info
 
-		devices = append(devices, device.DeviceInfo{
-			ID:           info.ID,
-			Name:         info.Name,
-			Vendor:       info.Vendor,
-			Type:         info.Type,
-			Architecture: info.Architecture,
-			Memory:       info.Memory,
-		})
+		devices = append(devices, p.toDeviceInfo(info, output.CUDAVersion))
 	}
 
+	p.cudaVersion = output.CUDAVersion
 	p.cacheTime = time.Now()
 	return devices, nil
 }
 
 func (p *Provider) GetDevice(ctx context.Context, deviceID string) (*device.DeviceInfo, error) {
 	if info := p.getCachedInfo(deviceID); info != nil {
-		return &device.DeviceInfo{
-			ID:           info.ID,
-			Name:         info.Name,
-			Vendor:       info.Vendor,
-			Type:         info.Type,
-			Architecture: info.Architecture,
-			Memory:       info.Memory,
-		}, nil
+		d := p.toDeviceInfo(info, p.cachedCUDAVersion())
+		return &d, nil
 	}
 
 	output, err := p.smi.QueryDevice(ctx, p.toSMIID(deviceID))
@@ -184,23 +175,45 @@ func (p *Provider) GetDevice(ctx context.Context, deviceID string) (*device.Devi
 		Type:         hal.DeviceTypeGPU,
 		Architecture: gpuArchitecture(gpu.ProductArchitecture, gpu.ProductBrand),
 		Memory:       effectiveMemory(parseMemory(gpu.FBMemoryUsage.Total)),
+		Driver:       output.DriverVersion,
 		DiscoveredAt: time.Now(),
 	}
 
 	p.cacheMu.Lock()
 	p.cache[deviceID] = info
+	p.cudaVersion = output.CUDAVersion
 	p.cacheMu.Unlock()
 
-	return &device.DeviceInfo{
-		ID:           info.ID,
-		Name:         info.Name,
-		Vendor:       info.Vendor,
-		Type:         info.Type,
-		Architecture: info.Architecture,
-		Memory:       info.Memory,
+	d := p.toDeviceInfo(info, output.CUDAVersion)
+	return &d, nil
+}
+
+// GetHostInfo reports the host CPU core count and total RAM.
+func (p *Provider) GetHostInfo(_ context.Context) (*device.HostInfo, error) {
+	return &device.HostInfo{
+		CPUCores:    runtime.NumCPU(),
+		TotalMemory: systemMemoryBytes(),
 	}, nil
 }
 
+func (p *Provider) toDeviceInfo(info *hal.HardwareInfo, cudaVersion string) device.DeviceInfo {
+	index := p.extractIndex(info.ID)
+	if index < 0 {
+		index = 0
+	}
+	return device.DeviceInfo{
+		ID:            info.ID,
+		Index:         index,
+		Name:          info.Name,
+		Vendor:        info.Vendor,
+		Type:          info.Type,
+		Architecture:  info.Architecture,
+		Memory:        info.Memory,
+		DriverVersion: info.Driver,
+		CUDAVersion:   cudaVersion,
+	}
+}
+
 func (p *Provider) GetMetrics(ctx context.Context, deviceID string) (*device.DeviceMetrics, error) {
 	output, err := p.smi.QueryDevice(ctx, p.toSMIID(deviceID))
 	if err != nil {
@@ -296,6 +309,12 @@ func (p *Provider) getCachedInfo(deviceID string) *hal.HardwareInfo {
 	return p.cache[deviceID]
 }
 
+func (p *Provider) cachedCUDAVersion() string {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	return p.cudaVersion
+}
+
 func (p *Provider) toSMIID(deviceID string) string {
 	if idx := p.extractIndex(deviceID); idx >= 0 {
 		return fmt.Sprintf("%d", idx)
@@ -466,6 +466,8 @@ func TestProvider_Detect_Success(t *testing.T) {
 	mock.queryResult.GPUs[1].FBMemoryUsage.Total = "16384 MiB"
 	mock.queryResult.GPUs[1].FBMemoryUsage.Used = "512 MiB"
 	mock.queryResult.GPUs[1].FBMemoryUsage.Free = "15872 MiB"
+	mock.queryResult.DriverVersion = "570.86.15"
+	mock.queryResult.CUDAVersion = "12.8"
 
 	p := NewProvider(withSMI(mock))
 
@@ -475,7 +477,16 @@ func TestProvider_Detect_Success(t *testing.T) {
 	}
 
 	if len(devices) != 2 {
-		t.Errorf("expected 2 devices, got %d", len(devices))
+		t.Fatalf("expected 2 devices, got %d", len(devices))
+	}
+
+	for i, d := range devices {
+		if d.Index != i {
+			t.Errorf("device %d: expected index %d, got %d", i, i, d.Index)
+		}
+		if d.DriverVersion != "570.86.15" || d.CUDAVersion != "12.8" {
+			t.Errorf("device %d: unexpected driver/CUDA version %s/%s", i, d.DriverVersion, d.CUDAVersion)
+		}
 	}
 
 	if devices[0].Name != "NVIDIA GeForce RTX 4090" {
@@ -63,7 +63,9 @@ func (s *SMI) SetTimeout(d time.Duration) {
 }
 
 type smiOutput struct {
-	AttachedGPUs int `xml:"attached_gpus"`
+	DriverVersion string `xml:"driver_version"`
+	CUDAVersion   string `xml:"cuda_version"`
+	AttachedGPUs  int    `xml:"attached_gpus"`
 	GPUs         []struct {
 		ID                  string `xml:"id,attr"`
 		ProductName         string `xml:"product_name"`
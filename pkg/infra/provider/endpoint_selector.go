@@ -0,0 +1,30 @@
+package provider
+
+import "hash/fnv"
+
+// selectEndpoint picks the endpoint that serves a request. Without a session
+// ID it returns the first endpoint. With one it uses rendezvous hashing: every
+// endpoint is scored by hashing it with the session ID and the highest score
+// wins. The same session therefore keeps hitting the same replica, which lets
+// vLLM reuse its prefix cache, and when replicas are added or removed only the
+// sessions whose winning endpoint changed move.
+func selectEndpoint(endpoints []string, sessionID string) string {
+	if len(endpoints) == 0 {
+		return ""
+	}
+	if sessionID == "" {
+		return endpoints[0]
+	}
+
+	best, bestScore := endpoints[0], uint64(0)
+	for i, endpoint := range endpoints {
+		h := fnv.New64a()
+		h.Write([]byte(sessionID))
+		h.Write([]byte{0})
+		h.Write([]byte(endpoint))
+		if score := h.Sum64(); i == 0 || score > bestScore {
+			best, bestScore = endpoint, score
+		}
+	}
+	return best
+}
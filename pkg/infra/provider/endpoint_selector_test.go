@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+func TestSelectEndpoint_NoSessionUsesFirst(t *testing.T) {
+	endpoints := []string{"http://a:8000", "http://b:8000", "http://c:8000"}
+	if got := selectEndpoint(endpoints, ""); got != "http://a:8000" {
+		t.Errorf("expected first endpoint without a session, got %s", got)
+	}
+	if got := selectEndpoint(nil, "session-1"); got != "" {
+		t.Errorf("expected no endpoint for an empty list, got %s", got)
+	}
+}
+
+func TestSelectEndpoint_StickySessions(t *testing.T) {
+	endpoints := []string{"http://a:8000", "http://b:8000", "http://c:8000"}
+
+	hits := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		session := fmt.Sprintf("conversation-%d", i)
+		first := selectEndpoint(endpoints, session)
+		for j := 0; j < 5; j++ {
+			if got := selectEndpoint(endpoints, session); got != first {
+				t.Fatalf("session %s moved from %s to %s", session, first, got)
+			}
+		}
+		// Order of the replica list must not matter.
+		reversed := []string{endpoints[2], endpoints[1], endpoints[0]}
+		if got := selectEndpoint(reversed, session); got != first {
+			t.Fatalf("session %s depends on endpoint order: %s vs %s", session, first, got)
+		}
+		hits[first]++
+	}
+	if len(hits) != len(endpoints) {
+		t.Errorf("expected sessions to spread over all replicas, got %v", hits)
+	}
+}
+
+func TestSelectEndpoint_RebalancesOnMembershipChange(t *testing.T) {
+	endpoints := []string{"http://a:8000", "http://b:8000", "http://c:8000"}
+	grown := append(append([]string{}, endpoints...), "http://d:8000")
+	shrunk := endpoints[:2]
+
+	for i := 0; i < 200; i++ {
+		session := fmt.Sprintf("conversation-%d", i)
+		before := selectEndpoint(endpoints, session)
+
+		// Adding a replica only moves sessions onto the new replica.
+		if after := selectEndpoint(grown, session); after != before && after != "http://d:8000" {
+			t.Fatalf("session %s moved from %s to existing replica %s", session, before, after)
+		}
+		// Removing a replica only moves the sessions it was serving.
+		if after := selectEndpoint(shrunk, session); before != "http://c:8000" && after != before {
+			t.Fatalf("session %s moved from surviving replica %s to %s", session, before, after)
+		}
+	}
+}
+
+func TestProxyInferenceProvider_ResolveEndpointSession(t *testing.T) {
+	ctx := context.Background()
+	models := model.NewMemoryStore()
+	services := service.NewMemoryStore()
+	_ = models.Create(ctx, &model.Model{ID: "model-1", Name: "llama3"})
+	_ = services.Create(ctx, &service.ModelService{ID: "svc-1", ModelID: "model-1", Status: service.ServiceStatusRunning, Endpoints: []string{"http://a:8000"}})
+	_ = services.Create(ctx, &service.ModelService{ID: "svc-2", ModelID: "model-1", Status: service.ServiceStatusRunning, Endpoints: []string{"http://b:8000"}})
+
+	p := NewProxyInferenceProvider(services, models)
+
+	first, _, err := p.resolveEndpoint(ctx, "llama3", "conversation-42")
+	if err != nil {
+		t.Fatalf("resolveEndpoint: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		got, _, err := p.resolveEndpoint(ctx, "llama3", "conversation-42")
+		if err != nil {
+			t.Fatalf("resolveEndpoint: %v", err)
+		}
+		if got != first {
+			t.Fatalf("expected session to stick to %s, got %s", first, got)
+		}
+	}
+	if want := selectEndpoint([]string{"http://a:8000", "http://b:8000"}, "conversation-42"); first != want {
+		t.Errorf("expected the session to be hashed across both services' endpoints, got %s want %s", first, want)
+	}
+}
+
+func TestProxyInferenceProvider_ResolveEndpointSkipsDraining(t *testing.T) {
+	ctx := context.Background()
+	models := model.NewMemoryStore()
+	services := service.NewMemoryStore()
+	_ = models.Create(ctx, &model.Model{ID: "model-1", Name: "llama3"})
+	_ = services.Create(ctx, &service.ModelService{ID: "svc-1", ModelID: "model-1", Status: service.ServiceStatusRunning, Endpoints: []string{"http://a:8000"}})
+	_ = services.Create(ctx, &service.ModelService{ID: "svc-2", ModelID: "model-1", Status: service.ServiceStatusRunning, Endpoints: []string{"http://b:8000"}})
+
+	tracker := NewHybridServiceProvider(models, services)
+	tracker.draining["svc-1"] = true
+	p := NewProxyInferenceProvider(services, models)
+	p.SetRequestTracker(tracker)
+
+	for i := 0; i < 10; i++ {
+		endpoint, release, err := p.resolveEndpoint(ctx, "llama3", fmt.Sprintf("conversation-%d", i))
+		if err != nil {
+			t.Fatalf("resolveEndpoint: %v", err)
+		}
+		if endpoint != "http://b:8000" {
+			t.Fatalf("expected the draining service to be skipped, got %s", endpoint)
+		}
+		if got := tracker.InFlight("svc-2"); got != 1 {
+			t.Fatalf("expected the request tracked against svc-2, got %d in flight", got)
+		}
+		release()
+	}
+	if got := tracker.InFlight("svc-2"); got != 0 {
+		t.Errorf("expected released requests to be untracked, got %d in flight", got)
+	}
+
+	tracker.draining["svc-2"] = true
+	if _, _, err := p.resolveEndpoint(ctx, "llama3", ""); !errors.Is(err, ErrServiceDraining) {
+		t.Errorf("expected ErrServiceDraining with every service draining, got %v", err)
+	}
+}
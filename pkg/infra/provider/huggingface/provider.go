@@ -25,6 +25,7 @@ type Provider struct {
 	baseURL     string
 	httpClient  *http.Client
 	downloadDir string
+	resolver    *model.PathResolver
 	mu          sync.RWMutex
 	modelCache  map[string]*model.Model
 }
@@ -55,6 +56,14 @@ func WithDownloadDir(dir string) ProviderOption {
 	}
 }
 
+// WithPathResolver places each pulled model in the directory the resolver
+// assigns to it instead of a directory derived from the repo name alone.
+func WithPathResolver(r *model.PathResolver) ProviderOption {
+	return func(p *Provider) {
+		p.resolver = r
+	}
+}
+
 func NewProvider(opts ...ProviderOption) *Provider {
 	p := &Provider{
 		baseURL:     "https://huggingface.co",
@@ -118,9 +127,9 @@ func (p *Provider) Pull(ctx context.Context, source, repo, tag string, progressC
 		Type:      model.ModelType(DetectModelType(info)),
 	}
 
-	downloadDir := filepath.Join(p.downloadDir, strings.ReplaceAll(repo, "/", "_"))
-	if err := os.MkdirAll(downloadDir, 0755); err != nil {
-		return nil, fmt.Errorf("create download directory: %w", err)
+	downloadDir, err := p.modelDir(m.ID, repo)
+	if err != nil {
+		return nil, err
 	}
 
 	var totalSize int64
@@ -720,3 +729,14 @@ func parseInt64(s string) int64 {
 	}
 	return result
 }
+
+func (p *Provider) modelDir(modelID, repo string) (string, error) {
+	if p.resolver != nil {
+		return p.resolver.Ensure(modelID, repo)
+	}
+	dir := filepath.Join(p.downloadDir, strings.ReplaceAll(repo, "/", "_"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create download directory: %w", err)
+	}
+	return dir, nil
+}
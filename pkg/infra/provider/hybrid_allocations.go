@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/docker"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+// ServiceAllocations reports the resources claimed by each running service:
+// the container limits of its engine times its replicas, and for GPU
+// services the fraction of GPU memory the engine may use.
+func (p *HybridServiceProvider) ServiceAllocations(ctx context.Context) ([]resource.ServiceAllocation, error) {
+	services, _, err := p.serviceStore.List(ctx, service.ServiceFilter{Status: service.ServiceStatusRunning})
+	if err != nil {
+		return nil, fmt.Errorf("list running services: %w", err)
+	}
+
+	allocations := make([]resource.ServiceAllocation, 0, len(services))
+	for i := range services {
+		a, err := p.serviceAllocation(&services[i])
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, a)
+	}
+	return allocations, nil
+}
+
+// serviceAllocation returns the resources svc claims when running.
+func (p *HybridServiceProvider) serviceAllocation(svc *service.ModelService) (resource.ServiceAllocation, error) {
+	a, gpuShare, err := p.serviceClaim(svc)
+	if err == nil {
+		a.GPUFraction = gpuShare * float64(replicaCount(svc))
+	}
+	return a, err
+}
+
+// serviceClaim returns the memory and CPU svc claims summed over its
+// replicas, and the share of a single GPU each replica uses.
+func (p *HybridServiceProvider) serviceClaim(svc *service.ModelService) (a resource.ServiceAllocation, gpuShare float64, err error) {
+	engineType, _ := svc.Config["engine_type"].(string)
+	if engineType == "" {
+		if sid, err := service.ParseServiceID(svc.ID); err == nil {
+			engineType = sid.EngineType
+		}
+	}
+	limits, err := applyResourceOverrides(p.hybridProvider.resourceLimitsFor(engineType), svc.Config)
+	if err != nil {
+		return resource.ServiceAllocation{}, 0, fmt.Errorf("service %s: %w", svc.ID, err)
+	}
+	if gpu, ok := svc.Config["gpu"].(bool); ok {
+		limits.GPU = gpu
+	}
+
+	replicas := replicaCount(svc)
+	a = resource.ServiceAllocation{ServiceID: svc.ID, CPU: limits.CPU * float64(replicas)}
+	if mem, err := docker.ParseMemory(limits.Memory); err == nil && mem > 0 {
+		a.Memory = uint64(mem) * uint64(replicas)
+	}
+	if limits.GPU {
+		gpuShare, err = p.resolveGPUMemoryUtilization(svc.Config)
+		if err != nil {
+			return a, 0, fmt.Errorf("service %s: %w", svc.ID, err)
+		}
+	}
+	return a, gpuShare, nil
+}
+
+// replicaCount returns the number of replicas svc runs, at least one.
+func replicaCount(svc *service.ModelService) int {
+	return max(svc.Replicas, 1)
+}
+
+// SetReservationLedger makes service starts reserve their resources in
+// ledger before the engine starts, so concurrent starts cannot overcommit.
+// The reservation is released when the start fails or the service stops.
+func (p *HybridServiceProvider) SetReservationLedger(ledger *resource.Ledger) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ledger = ledger
+}
+
+// reserve claims the resources of serviceID in the reservation ledger, if
+// one is set. The returned release undoes the claim.
+func (p *HybridServiceProvider) reserve(ctx context.Context, serviceID string, svc *service.ModelService) (release func(), err error) {
+	p.mu.Lock()
+	ledger := p.ledger
+	p.mu.Unlock()
+	if ledger == nil {
+		return func() {}, nil
+	}
+
+	if svc == nil {
+		svc = &service.ModelService{ID: serviceID}
+	}
+	a, gpuShare, err := p.serviceClaim(svc)
+	if err != nil {
+		return nil, err
+	}
+	if err := ledger.Reserve(ctx, serviceID, a.Memory, gpuShare, replicaCount(svc)); err != nil {
+		return nil, fmt.Errorf("reserve resources for %s: %w", serviceID, err)
+	}
+	return func() { ledger.Release(serviceID) }, nil
+}
+
+// releaseReservation drops the ledger claim of serviceID, if any.
+func (p *HybridServiceProvider) releaseReservation(serviceID string) {
+	p.mu.Lock()
+	ledger := p.ledger
+	p.mu.Unlock()
+	if ledger != nil {
+		ledger.Release(serviceID)
+	}
+}
+
+var _ resource.ServiceAllocationProvider = (*HybridServiceProvider)(nil)
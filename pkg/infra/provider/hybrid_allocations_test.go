@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+func TestHybridServiceProvider_ServiceAllocations(t *testing.T) {
+	ctx := context.Background()
+	store := service.NewMemoryStore()
+	for _, svc := range []*service.ModelService{
+		{ID: "svc-vllm-model-1", Status: service.ServiceStatusRunning, Replicas: 1, Config: map[string]any{"engine_type": "vllm", "gpu_memory_utilization": 0.5}},
+		{ID: "svc-whisper-model-2", Status: service.ServiceStatusRunning, Replicas: 2, Config: map[string]any{"engine_type": "whisper"}},
+		{ID: "svc-vllm-model-3", Status: service.ServiceStatusStopped, Replicas: 1},
+	} {
+		require.NoError(t, store.Create(ctx, svc))
+	}
+
+	p := NewHybridServiceProvider(newMockModelStore(), store)
+	p.hybridProvider.resourceLimits = map[string]ResourceLimits{
+		"vllm":    {Memory: "0", GPU: true},
+		"whisper": {Memory: "4g", CPU: 2},
+	}
+
+	allocations, err := p.ServiceAllocations(ctx)
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+
+	byID := map[string]float64{}
+	for _, a := range allocations {
+		switch a.ServiceID {
+		case "svc-vllm-model-1":
+			assert.Equal(t, 0.5, a.GPUFraction)
+			assert.Zero(t, a.Memory)
+		case "svc-whisper-model-2":
+			assert.Equal(t, uint64(8<<30), a.Memory)
+			assert.Equal(t, 4.0, a.CPU)
+			assert.Zero(t, a.GPUFraction)
+		}
+		byID[a.ServiceID] = a.GPUFraction
+	}
+	assert.NotContains(t, byID, "svc-vllm-model-3", "stopped services claim nothing")
+}
+
+func TestHybridServiceProvider_StartAsync_ReservationLedger(t *testing.T) {
+	ctx := context.Background()
+	models := newMockModelStore()
+	store := service.NewMemoryStore()
+	for _, id := range []string{"model-a", "model-b"} {
+		require.NoError(t, models.Create(ctx, &model.Model{ID: id, Name: id, Type: model.ModelTypeLLM}))
+		require.NoError(t, store.Create(ctx, &service.ModelService{
+			ID:       "svc-vllm-" + id,
+			Replicas: 1,
+			Config:   map[string]any{"engine_type": "vllm", "gpu": true, "gpu_memory_utilization": 0.6},
+		}))
+	}
+
+	p := NewHybridServiceProvider(models, store)
+	ledger := resource.NewLedger(nil)
+	p.SetReservationLedger(ledger)
+	p.startEngine = func(ctx context.Context, engineType string, config map[string]any) (*engine.StartResult, error) {
+		// Hold the start open so both services are admitted concurrently
+		// if the ledger lets them.
+		time.Sleep(20 * time.Millisecond)
+		return &engine.StartResult{ProcessID: "1234", Status: engine.EngineStatusRunning}, nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, id := range []string{"svc-vllm-model-a", "svc-vllm-model-b"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			errs <- p.Start(ctx, id)
+		}(id)
+	}
+	wg.Wait()
+	close(errs)
+
+	admitted := 0
+	for err := range errs {
+		if err == nil {
+			admitted++
+			continue
+		}
+		assert.True(t, errors.Is(err, resource.ErrResourceInsufficient), "unexpected error: %v", err)
+	}
+	assert.Equal(t, 1, admitted, "only one 60%-GPU service fits")
+	require.Len(t, ledger.Reservations(), 1)
+
+	// Stopping the admitted service frees its share for the other.
+	winner := ledger.Reservations()[0].ID
+	p.stopEngine = func(ctx context.Context, serviceID string, force bool) error { return nil }
+	require.NoError(t, p.Stop(ctx, winner, true))
+	assert.Empty(t, ledger.Reservations())
+}
+
+func TestHybridServiceProvider_StartAsync_ReservesPerReplica(t *testing.T) {
+	ctx := context.Background()
+	models := newMockModelStore()
+	require.NoError(t, models.Create(ctx, &model.Model{ID: "model-a", Name: "a", Type: model.ModelTypeLLM}))
+	store := service.NewMemoryStore()
+	require.NoError(t, store.Create(ctx, &service.ModelService{
+		ID:       "svc-vllm-model-a",
+		Replicas: 2,
+		Config:   map[string]any{"engine_type": "vllm", "gpu": true, "gpu_memory_utilization": 0.6},
+	}))
+
+	p := NewHybridServiceProvider(models, store)
+	p.startEngine = func(ctx context.Context, engineType string, config map[string]any) (*engine.StartResult, error) {
+		return &engine.StartResult{ProcessID: "1234", Status: engine.EngineStatusRunning}, nil
+	}
+
+	// Two 60% replicas cannot share one GPU...
+	p.SetReservationLedger(resource.NewLedger(nil))
+	err := p.Start(ctx, "svc-vllm-model-a")
+	assert.True(t, errors.Is(err, resource.ErrResourceInsufficient), "unexpected error: %v", err)
+
+	// ...but fit on two.
+	ledger := resource.NewLedger(nil).WithGPUCount(2)
+	p.SetReservationLedger(ledger)
+	require.NoError(t, p.Start(ctx, "svc-vllm-model-a"))
+	reservations := ledger.Reservations()
+	require.Len(t, reservations, 1)
+	assert.Equal(t, 0.6, reservations[0].GPUFraction)
+	assert.ElementsMatch(t, []int{0, 1}, reservations[0].Devices)
+}
+
+func TestHybridServiceProvider_StartAsync_ReleasesReservationOnFailure(t *testing.T) {
+	ctx := context.Background()
+	models := newMockModelStore()
+	require.NoError(t, models.Create(ctx, &model.Model{ID: "model-a", Name: "a", Type: model.ModelTypeLLM}))
+
+	p := NewHybridServiceProvider(models, service.NewMemoryStore())
+	ledger := resource.NewLedger(nil)
+	p.SetReservationLedger(ledger)
+	p.startEngine = func(ctx context.Context, engineType string, config map[string]any) (*engine.StartResult, error) {
+		return nil, errors.New("image not found")
+	}
+
+	require.Error(t, p.Start(ctx, "svc-vllm-model-a"))
+	assert.Empty(t, ledger.Reservations())
+}
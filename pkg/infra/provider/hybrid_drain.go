@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrServiceDraining is returned by BeginRequest while Stop is draining the
+// service.
+var ErrServiceDraining = errors.New("service is draining")
+
+// defaultDrainPoll is how often Stop checks whether a draining service has
+// finished its in-flight requests.
+const defaultDrainPoll = 100 * time.Millisecond
+
+// SetDrainTimeout makes Stop wait up to timeout for in-flight requests
+// before stopping a service. Forced stops never wait. A zero timeout, the
+// default, stops immediately.
+func (p *HybridServiceProvider) SetDrainTimeout(timeout time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.drainTimeout = timeout
+}
+
+// BeginRequest tracks a request routed to serviceID until release is called.
+// It fails with ErrServiceDraining while the service is being stopped, so
+// callers route the request elsewhere or report the service unavailable.
+func (p *HybridServiceProvider) BeginRequest(serviceID string) (release func(), err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.draining[serviceID] {
+		return nil, fmt.Errorf("%s: %w", serviceID, ErrServiceDraining)
+	}
+	p.inFlight[serviceID]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if p.inFlight[serviceID]--; p.inFlight[serviceID] <= 0 {
+				delete(p.inFlight, serviceID)
+			}
+		})
+	}, nil
+}
+
+// Draining reports whether Stop is draining serviceID, so request routing
+// can skip it.
+func (p *HybridServiceProvider) Draining(serviceID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.draining[serviceID]
+}
+
+// InFlight returns the number of tracked requests to serviceID.
+func (p *HybridServiceProvider) InFlight(serviceID string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inFlight[serviceID]
+}
+
+// drainRequests marks serviceID as draining and waits until its in-flight
+// requests finish, the drain timeout passes or ctx is done. It reports
+// whether the service drained.
+func (p *HybridServiceProvider) drainRequests(ctx context.Context, serviceID string) bool {
+	p.mu.Lock()
+	timeout, poll := p.drainTimeout, p.drainPoll
+	if timeout > 0 {
+		p.draining[serviceID] = true
+	}
+	p.mu.Unlock()
+	if timeout <= 0 {
+		return true
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for p.InFlight(serviceID) > 0 {
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// endDrain lets serviceID accept requests again once it is stopped, so a
+// later start can serve them.
+func (p *HybridServiceProvider) endDrain(serviceID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.draining, serviceID)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+// newDrainProvider returns a provider whose engine stop records the number
+// of in-flight requests at the moment it ran.
+func newDrainProvider(t *testing.T, timeout time.Duration) (*HybridServiceProvider, chan int) {
+	t.Helper()
+	p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+	p.SetDrainTimeout(timeout)
+	p.drainPoll = 5 * time.Millisecond
+	stopped := make(chan int, 1)
+	p.stopEngine = func(ctx context.Context, serviceID string, force bool) error {
+		stopped <- p.InFlight(serviceID)
+		return nil
+	}
+	return p, stopped
+}
+
+func isDraining(p *HybridServiceProvider, serviceID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.draining[serviceID]
+}
+
+func TestHybridServiceProvider_Stop_DrainsInFlightRequests(t *testing.T) {
+	p, stopped := newDrainProvider(t, time.Second)
+	release, err := p.BeginRequest("svc-vllm-m1")
+	require.NoError(t, err)
+
+	refused := make(chan error, 1)
+	go func() {
+		// Wait for Stop to start draining before finishing the request.
+		for !isDraining(p, "svc-vllm-m1") {
+			time.Sleep(time.Millisecond)
+		}
+		_, err := p.BeginRequest("svc-vllm-m1")
+		refused <- err
+		time.Sleep(20 * time.Millisecond)
+		release()
+	}()
+
+	start := time.Now()
+	require.NoError(t, p.Stop(context.Background(), "svc-vllm-m1", false))
+	assert.Equal(t, 0, <-stopped, "the engine should stop only after in-flight requests finish")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.ErrorIs(t, <-refused, ErrServiceDraining, "new requests should be refused while draining")
+
+	_, err = p.BeginRequest("svc-vllm-m1")
+	assert.NoError(t, err, "a stopped service should accept requests again once restarted")
+}
+
+func TestHybridServiceProvider_Stop_ForcesAfterDrainTimeout(t *testing.T) {
+	p, stopped := newDrainProvider(t, 50*time.Millisecond)
+	_, err := p.BeginRequest("svc-vllm-m1")
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, p.Stop(context.Background(), "svc-vllm-m1", false))
+	assert.Equal(t, 1, <-stopped, "the engine should be stopped with the stuck request still in flight")
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestHybridServiceProvider_Stop_ForceSkipsDrain(t *testing.T) {
+	p, stopped := newDrainProvider(t, time.Minute)
+	_, err := p.BeginRequest("svc-vllm-m1")
+	require.NoError(t, err)
+
+	require.NoError(t, p.Stop(context.Background(), "svc-vllm-m1", true))
+	assert.Equal(t, 1, <-stopped)
+}
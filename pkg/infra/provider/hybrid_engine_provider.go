@@ -4,22 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
+	"maps"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	catalogdata "github.com/jguan/ai-inference-managed-by-ai/catalog"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/docker"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/eventbus"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/catalog"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
 )
 
@@ -29,6 +35,14 @@ type fatalStartError struct{ cause error }
 func (e *fatalStartError) Error() string { return e.cause.Error() }
 func (e *fatalStartError) Unwrap() error  { return e.cause }
 
+// DefaultGPUMemoryUtilization is the fraction of GPU memory vLLM may claim
+// when neither the service nor the provider configures one.
+const DefaultGPUMemoryUtilization = 0.75
+
+// imagePullTimeout bounds the pull attempts Install makes across all image
+// candidates of an engine.
+const imagePullTimeout = 5 * time.Minute
+
 // ResourceLimits defines resource constraints for containers
 type ResourceLimits struct {
 	Memory    string  // e.g., "4g", "512m"
@@ -50,6 +64,7 @@ type HybridEngineProvider struct {
 	dockerClient    docker.Client
 	containers      map[string]string // name -> container ID
 	nativeProcesses map[string]*exec.Cmd
+	nativeRuns      map[string]*nativeRun // PID -> output and exit of a native process
 	serviceInfo     map[string]*ServiceInfo
 	modelStore      model.ModelStore
 
@@ -60,9 +75,17 @@ type HybridEngineProvider struct {
 	// Engine assets loaded from YAML files (keyed by engine type)
 	engineAssets map[string]catalog.EngineAsset
 
+	// healthInterval is how often engine health is polled during startup;
+	// zero means defaultHealthInterval.
+	healthInterval time.Duration
+
 	// Event publishing (optional)
 	eventBus eventbus.EventBus
 
+	// Named volume mounted as the Hugging Face cache of engines that download
+	// weights themselves; empty disables the shared cache.
+	modelCacheVolume string
+
 	// Concurrency protection
 	mu sync.RWMutex
 }
@@ -94,24 +117,77 @@ func NewHybridEngineProvider(modelStore model.ModelStore) *HybridEngineProvider
 func newHybridEngineProviderWithClient(modelStore model.ModelStore, dc docker.Client) *HybridEngineProvider {
 	assets, err := catalog.LoadEngineAssetsFromFS(catalogdata.EngineFS, "engines")
 	if err != nil {
-		slog.Warn("failed to load embedded engine assets, using hardcoded defaults", "error", err)
+		slog.Warn("failed to load embedded engine assets, using hardcoded defaults for them", "error", err)
+	}
+	if assets == nil {
 		assets = make(map[string]catalog.EngineAsset)
-	} else {
-		slog.Info("loaded engine assets from embedded YAML", "count", len(assets))
 	}
+	slog.Info("loaded engine assets from embedded YAML", "count", len(assets))
 
 	return &HybridEngineProvider{
 		dockerClient:    dc,
 		containers:      make(map[string]string),
 		nativeProcesses: make(map[string]*exec.Cmd),
+		nativeRuns:      make(map[string]*nativeRun),
 		serviceInfo:     make(map[string]*ServiceInfo),
 		modelStore:      modelStore,
-		resourceLimits:  getDefaultResourceLimits(),
+		resourceLimits:  getDefaultResourceLimits(assets),
 		startupConfigs:  getDefaultStartupConfigs(),
 		engineAssets:    assets,
 	}
 }
 
+// EnableCLIFallback makes Docker operations that fail on the SDK client
+// (daemon unreachable over the API, API version mismatch) retry through the
+// docker CLI. It has no effect when the provider already uses the CLI client.
+func (p *HybridEngineProvider) EnableCLIFallback() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.dockerClient.(*docker.SDKClient); ok {
+		p.dockerClient = docker.NewFallbackClient(p.dockerClient, docker.NewSimpleClient())
+	}
+}
+
+// modelCacheMountPath is where the shared model cache volume is mounted; it
+// is also exported as HF_HOME so the engine downloads into it.
+const modelCacheMountPath = "/root/.cache/huggingface"
+
+// modelCacheEngines lists the engine types that use the shared model cache.
+var modelCacheEngines = map[string]bool{
+	"vllm":         true,
+	"transformers": true,
+}
+
+// SetModelCacheVolume mounts the named Docker volume as the Hugging Face cache
+// of vLLM and transformers containers, so weights downloaded by one container
+// are reused by the next. An empty name disables the shared cache.
+func (p *HybridEngineProvider) SetModelCacheVolume(name string) {
+	p.mu.Lock()
+	p.modelCacheVolume = name
+	p.mu.Unlock()
+}
+
+// mountModelCache adds the shared model cache volume to opts for engine types
+// that use it, creating the volume on first use.
+func (p *HybridEngineProvider) mountModelCache(ctx context.Context, engineType string, opts *docker.ContainerOptions) error {
+	p.mu.RLock()
+	cacheVolume := p.modelCacheVolume
+	p.mu.RUnlock()
+	if cacheVolume == "" || !modelCacheEngines[engineType] {
+		return nil
+	}
+
+	if _, err := p.dockerClient.CreateVolume(ctx, cacheVolume); err != nil {
+		return fmt.Errorf("create model cache volume %s: %w", cacheVolume, err)
+	}
+	if opts.Volumes == nil {
+		opts.Volumes = make(map[string]string)
+	}
+	opts.Volumes[cacheVolume] = modelCacheMountPath
+	opts.Env = append(opts.Env, "HF_HOME="+modelCacheMountPath)
+	return nil
+}
+
 // AssetTypes returns the engine type keys from the loaded YAML assets.
 // This is used by the CLI to seed the EngineStore at startup.
 func (p *HybridEngineProvider) AssetTypes() []string {
@@ -124,6 +200,49 @@ func (p *HybridEngineProvider) AssetTypes() []string {
 	return types
 }
 
+// ReloadAssets re-reads engine assets so a new or changed engine YAML takes
+// effect without a restart. The assets in fsys, laid out like the catalog's
+// engines directory, are overlaid on the embedded catalog; a nil fsys
+// restores the embedded catalog alone. Nothing changes when any asset fails
+// to load. Resource defaults are recomputed, but running engines keep the
+// settings they were started with.
+func (p *HybridEngineProvider) ReloadAssets(fsys fs.FS) error {
+	assets, err := catalog.LoadEngineAssetsFromFS(catalogdata.EngineFS, "engines")
+	if err != nil {
+		return fmt.Errorf("load embedded engine assets: %w", err)
+	}
+	if fsys != nil {
+		extra, err := catalog.LoadEngineAssetsFromFS(fsys, ".")
+		if err != nil {
+			return fmt.Errorf("reload engine assets: %w", err)
+		}
+		maps.Copy(assets, extra)
+	}
+	limits := getDefaultResourceLimits(assets)
+
+	p.mu.Lock()
+	p.engineAssets = assets
+	p.resourceLimits = limits
+	p.mu.Unlock()
+	slog.Info("reloaded engine assets", "count", len(assets))
+	return nil
+}
+
+// engineAsset returns the loaded asset for engineType.
+func (p *HybridEngineProvider) engineAsset(engineType string) (catalog.EngineAsset, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	asset, ok := p.engineAssets[engineType]
+	return asset, ok
+}
+
+// resourceLimitsFor returns the default resource limits for engineType.
+func (p *HybridEngineProvider) resourceLimitsFor(engineType string) ResourceLimits {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.resourceLimits[engineType]
+}
+
 // SetEventBus injects an event bus so the provider can publish progress events.
 func (p *HybridEngineProvider) SetEventBus(bus eventbus.EventBus) {
 	p.mu.Lock()
@@ -143,9 +262,11 @@ func (p *HybridEngineProvider) publishProgress(serviceID, phase, message string,
 	_ = bus.Publish(engine.NewStartProgressEvent(serviceID, phase, message, progress))
 }
 
-// getDefaultResourceLimits returns default resource limits for each engine type
-// Can be overridden via environment variables: AIMA_{ENGINE}_MEMORY, AIMA_{ENGINE}_CPU, AIMA_{ENGINE}_GPU
-func getDefaultResourceLimits() map[string]ResourceLimits {
+// getDefaultResourceLimits returns default resource limits for each engine type.
+// Limits declared in an engine asset's resources section take precedence over
+// the built-in table, and can in turn be overridden via environment variables:
+// AIMA_{ENGINE}_MEMORY, AIMA_{ENGINE}_CPU, AIMA_{ENGINE}_GPU
+func getDefaultResourceLimits(assets map[string]catalog.EngineAsset) map[string]ResourceLimits {
 	limits := map[string]ResourceLimits{
 		"vllm": {
 			Memory:    "0", // 0 means no limit, vLLM manages its own memory
@@ -173,6 +294,10 @@ func getDefaultResourceLimits() map[string]ResourceLimits {
 		},
 	}
 
+	for engineType, asset := range assets {
+		limits[engineType] = assetResourceLimits(asset.Resources, limits[engineType])
+	}
+
 	// Override with environment variables
 	for engine := range limits {
 		if mem := os.Getenv(fmt.Sprintf("AIMA_%s_MEMORY", strings.ToUpper(engine))); mem != "" {
@@ -200,6 +325,24 @@ func getDefaultResourceLimits() map[string]ResourceLimits {
 	return limits
 }
 
+// assetResourceLimits overlays the resource defaults of an engine asset on
+// base; fields the asset leaves unset keep their value from base.
+func assetResourceLimits(r catalog.ResourceDefaults, base ResourceLimits) ResourceLimits {
+	if r.Memory != "" {
+		base.Memory = r.Memory
+	}
+	if r.CPU > 0 {
+		base.CPU = r.CPU
+	}
+	if r.GPU != nil {
+		base.GPU = *r.GPU
+	}
+	if r.GPUMemory != "" {
+		base.GPUMemory = r.GPUMemory
+	}
+	return base
+}
+
 // getDefaultStartupConfigs returns default startup configurations
 func getDefaultStartupConfigs() map[string]StartupConfig {
 	return map[string]StartupConfig{
@@ -249,16 +392,10 @@ func (p *HybridEngineProvider) Install(ctx context.Context, name string, version
 			}
 		}
 
-		// No local image found, try to pull the first candidate
-		image := candidates[0]
-		slog.Warn("no local image found, pulling", "image", image)
-		pullCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-		defer cancel()
-
-		if err := p.dockerClient.PullImage(pullCtx, image); err != nil {
-			slog.Warn("failed to pull image, will try existing image or native mode", "image", image, "error", err)
+		// No local image found, pull the first candidate that is available
+		if image, err := p.pullFirstAvailable(ctx, name, candidates); err != nil {
+			slog.Warn("failed to pull any image, will try native mode", "engine", name, "error", err)
 		} else {
-			slog.Info("Docker image pulled successfully", "image", image)
 			return &engine.InstallResult{
 				Success: true,
 				Path:    image,
@@ -269,7 +406,7 @@ func (p *HybridEngineProvider) Install(ctx context.Context, name string, version
 	}
 
 	// Fall back to native mode
-	if p.checkNativeBinary(name) {
+	if p.checkNativeBinary(p.nativeBinary(name)) {
 		slog.Info("native binary available", "engine", name)
 		return &engine.InstallResult{
 			Success: true,
@@ -285,6 +422,79 @@ func (p *HybridEngineProvider) Install(ctx context.Context, name string, version
 	}, nil
 }
 
+// pullFirstAvailable pulls candidates in order and returns the first image
+// that pulls successfully. All attempts share a single imagePullTimeout
+// deadline so a long candidate list cannot stall Install indefinitely.
+func (p *HybridEngineProvider) pullFirstAvailable(ctx context.Context, name string, candidates []string) (string, error) {
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no image candidates for engine %s", name)
+	}
+
+	pullCtx, cancel := context.WithTimeout(ctx, imagePullTimeout)
+	defer cancel()
+
+	var errs []error
+	for i, image := range candidates {
+		if err := pullCtx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("pull deadline exceeded before %s: %w", image, err))
+			break
+		}
+
+		slog.Info("pulling Docker image", "engine", name, "image", image, "attempt", i+1, "candidates", len(candidates))
+		p.publishProgress(name, "pulling", fmt.Sprintf("Pulling image %s (%d/%d)", image, i+1, len(candidates)), i*100/len(candidates))
+
+		if err := p.dockerClient.PullImage(pullCtx, image, p.pullProgressReporter(name, image)); err != nil {
+			slog.Warn("failed to pull image", "engine", name, "image", image, "error", err)
+			errs = append(errs, fmt.Errorf("pull %s: %w", image, err))
+			continue
+		}
+
+		slog.Info("Docker image pulled successfully", "image", image)
+		p.publishProgress(name, "pulling", "Image pulled: "+image, 100)
+		return image, nil
+	}
+
+	p.publishProgress(name, "failed", "No image could be pulled for "+name, -1)
+	return "", errors.Join(errs...)
+}
+
+// pullProgressReporter translates layer updates of an image pull into engine
+// progress events. A layer is reported when its status changes or its
+// download advances by at least 10%, so large pulls do not flood the bus.
+func (p *HybridEngineProvider) pullProgressReporter(name, image string) docker.PullProgressFunc {
+	type layerState struct {
+		status  string
+		percent int
+	}
+	layers := make(map[string]layerState)
+
+	return func(u docker.PullProgress) {
+		percent := 0
+		if u.Total > 0 {
+			percent = int(u.Current * 100 / u.Total)
+		}
+
+		last, seen := layers[u.LayerID]
+		if seen && last.status == u.Status && percent-last.percent < 10 {
+			return
+		}
+		layers[u.LayerID] = layerState{status: u.Status, percent: percent}
+
+		message := fmt.Sprintf("%s %s: %s", image, u.LayerID, u.Status)
+		if u.Total > 0 {
+			message += fmt.Sprintf(" %.1f/%.1f MB", float64(u.Current)/(1<<20), float64(u.Total)/(1<<20))
+		}
+
+		p.mu.RLock()
+		bus := p.eventBus
+		p.mu.RUnlock()
+		if bus == nil {
+			return
+		}
+		_ = bus.Publish(engine.NewPullProgressEvent(name, image, u.LayerID, message, u.Current, u.Total, percent))
+	}
+}
+
 // Start starts the engine service for a model
 func (p *HybridEngineProvider) Start(ctx context.Context, name string, config map[string]any) (*engine.StartResult, error) {
 	// Get startup config
@@ -316,7 +526,10 @@ func (p *HybridEngineProvider) Start(ctx context.Context, name string, config ma
 	}
 
 	// Get resource limits
-	limits := p.resourceLimits[engineType]
+	limits, err := applyResourceOverrides(p.resourceLimitsFor(engineType), config)
+	if err != nil {
+		return nil, fmt.Errorf("engine %s: %w", engineType, err)
+	}
 
 	// Override with config if provided
 	if device, ok := config["device"].(string); ok {
@@ -457,7 +670,7 @@ func (p *HybridEngineProvider) Start(ctx context.Context, name string, config ma
 	}
 
 	// Fall back to native mode
-	return p.startNative(ctx, engineType, modelPath, port, useGPU, config)
+	return p.startNativeAndWait(ctx, engineType, modelPath, port, useGPU, config, startupCfg, asyncMode)
 }
 
 // startDockerWithRetry starts engine in Docker container with resource limits
@@ -606,6 +819,11 @@ func (p *HybridEngineProvider) startDockerWithRetry(ctx context.Context, engineT
 		}
 	}
 
+	if err := p.mountModelCache(ctx, engineType, &opts); err != nil {
+		return nil, err
+	}
+	opts.Env = append(opts.Env, engineEnv(config)...)
+
 	// Build command based on engine type
 	opts.Cmd = p.buildDockerCommand(engineType, image, config, port)
 
@@ -640,6 +858,43 @@ func (p *HybridEngineProvider) startDockerWithRetry(ctx context.Context, engineT
 
 // waitForHealth waits for service to become healthy
 func (p *HybridEngineProvider) waitForHealth(ctx context.Context, engineType, containerID string, port int, healthPath string, timeout time.Duration) error {
+	err := p.pollHealth(ctx, engineType, port, healthPath, timeout, func() error {
+		status, err := p.dockerClient.GetContainerStatus(ctx, containerID)
+		if err != nil || status != "running" {
+			return fmt.Errorf("container not running (status: %s)", status)
+		}
+		return nil
+	})
+	if ctx.Err() == nil || !errors.Is(err, ctx.Err()) {
+		return err
+	}
+
+	// Context cancelled (e.g. gateway timeout) — clean up the container
+	// so the port is freed for subsequent start attempts.
+	shortID := containerID
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+	slog.Warn("health check cancelled, cleaning up container",
+		"container_id", shortID, "reason", ctx.Err())
+	cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cleanupCancel()
+	if stopErr := p.dockerClient.StopContainer(cleanupCtx, containerID, 10); stopErr != nil {
+		slog.Warn("failed to stop container during cleanup", "container_id", shortID, "error", stopErr)
+	}
+	p.mu.Lock()
+	delete(p.containers, engineType)
+	p.mu.Unlock()
+	return fmt.Errorf("health check cancelled, container cleaned up: %w", ctx.Err())
+}
+
+// defaultHealthInterval is how often engine health is polled during startup.
+const defaultHealthInterval = 2 * time.Second
+
+// pollHealth polls the engine's HTTP health endpoint until it returns 200,
+// alive reports the engine is gone, timeout passes or ctx is done, in which
+// case ctx.Err() is returned.
+func (p *HybridEngineProvider) pollHealth(ctx context.Context, engineType string, port int, healthPath string, timeout time.Duration, alive func() error) error {
 	if healthPath == "" {
 		healthPath = "/health"
 	}
@@ -649,29 +904,14 @@ func (p *HybridEngineProvider) waitForHealth(ctx context.Context, engineType, co
 	p.publishProgress(engineType, "loading", "Waiting for health check...", 75)
 
 	deadline := time.Now().Add(timeout)
-	checkInterval := 2 * time.Second
+	checkInterval := p.healthInterval
+	if checkInterval <= 0 {
+		checkInterval = defaultHealthInterval
+	}
 
 	for time.Now().Before(deadline) {
-		select {
-		case <-ctx.Done():
-			// Context cancelled (e.g. gateway timeout) — clean up the container
-			// so the port is freed for subsequent start attempts.
-			shortID := containerID
-			if len(shortID) > 12 {
-				shortID = shortID[:12]
-			}
-			slog.Warn("health check cancelled, cleaning up container",
-				"container_id", shortID, "reason", ctx.Err())
-			cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cleanupCancel()
-			if stopErr := p.dockerClient.StopContainer(cleanupCtx, containerID, 10); stopErr != nil {
-				slog.Warn("failed to stop container during cleanup", "container_id", shortID, "error", stopErr)
-			}
-			p.mu.Lock()
-			delete(p.containers, engineType)
-			p.mu.Unlock()
-			return fmt.Errorf("health check cancelled, container cleaned up: %w", ctx.Err())
-		default:
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
 		// Try HTTP health check
@@ -690,13 +930,16 @@ func (p *HybridEngineProvider) waitForHealth(ctx context.Context, engineType, co
 			}
 		}
 
-		// Check if container is still running
-		status, err := p.dockerClient.GetContainerStatus(ctx, containerID)
-		if err != nil || status != "running" {
-			return fmt.Errorf("container not running (status: %s)", status)
+		// Check if the engine is still running
+		if err := alive(); err != nil {
+			return err
 		}
 
-		time.Sleep(checkInterval)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(checkInterval):
+		}
 	}
 
 	return fmt.Errorf("health check timeout after %v", timeout)
@@ -706,62 +949,131 @@ func (p *HybridEngineProvider) waitForHealth(ctx context.Context, engineType, co
 func (p *HybridEngineProvider) startNative(ctx context.Context, engineType, modelPath string, port int, useGPU bool, config map[string]any) (*engine.StartResult, error) {
 	slog.Info("starting engine as native process", "engine", engineType)
 
-	// Check if vllm is available in PATH
-	if _, err := exec.LookPath("vllm"); err != nil {
-		return nil, fmt.Errorf("vllm not found in PATH and Docker not available")
-	}
-
-	// Build vllm command
-	args := []string{"serve"}
-	if modelPath != "" {
-		args = append(args, modelPath)
-	} else {
-		args = append(args, "--model", config["model"].(string))
+	binary, args, err := p.buildNativeCommand(engineType, modelPath, port, config)
+	if err != nil {
+		return nil, err
 	}
-
-	args = append(args, "--port", strconv.Itoa(port))
-
-	if gpuUtil, ok := config["gpu_memory_utilization"].(float64); ok {
-		args = append(args, "--gpu-memory-utilization", fmt.Sprintf("%.2f", gpuUtil))
-	} else {
-		args = append(args, "--gpu-memory-utilization", "0.9")
+	if _, err := exec.LookPath(binary); err != nil {
+		return nil, fmt.Errorf("%s not found in PATH and Docker not available", binary)
 	}
 
-	cmd := exec.CommandContext(ctx, "vllm", args...)
+	cmd := exec.CommandContext(ctx, binary, args...)
 
-	// Set environment
+	// Set environment. A nil Env inherits ours; keep doing so when adding
+	// variables, since engines need PATH, HOME and the like.
+	var env []string
 	if !useGPU {
-		cmd.Env = append(cmd.Env, "CUDA_VISIBLE_DEVICES=")
+		env = append(env, "CUDA_VISIBLE_DEVICES=")
 	}
+	env = append(env, engineEnv(config)...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	slog.Debug("native process command", "command", binary+" "+strings.Join(args, " "))
 
-	slog.Debug("native process command", "command", "vllm "+strings.Join(args, " "))
+	// Capture output so a failed start can be diagnosed via GetLogs. Don't
+	// let children that inherited the pipes hold up Wait once it exits.
+	run := &nativeRun{engine: engineType, logs: newLogRing(nativeLogLines), done: make(chan struct{})}
+	cmd.Stdout = run.logs
+	cmd.Stderr = run.logs
+	cmd.WaitDelay = 5 * time.Second
 
 	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start vllm: %w", err)
+		return nil, fmt.Errorf("failed to start %s: %w", binary, err)
 	}
+	pid := strconv.Itoa(cmd.Process.Pid)
 
 	p.mu.Lock()
 	p.nativeProcesses[engineType] = cmd
+	p.dropNativeRuns(engineType)
+	p.nativeRuns[pid] = run
 	p.mu.Unlock()
 
 	// Start goroutine to wait for process and clean up
 	go func() {
+		defer close(run.done)
 		if err := cmd.Wait(); err != nil {
 			slog.Error("native process exited with error", "engine", engineType, "error", err)
 		}
 		p.mu.Lock()
-		delete(p.nativeProcesses, engineType)
+		if p.nativeProcesses[engineType] == cmd {
+			delete(p.nativeProcesses, engineType)
+		}
 		p.mu.Unlock()
 	}()
 
 	slog.Info("native process started", "pid", cmd.Process.Pid, "endpoint", fmt.Sprintf("http://localhost:%d", port))
 
 	return &engine.StartResult{
-		ProcessID: strconv.Itoa(cmd.Process.Pid),
+		ProcessID: pid,
 		Status:    engine.EngineStatusRunning,
 	}, nil
 }
 
+// containerModelPaths are the model mount points used in engine asset
+// commands; native processes get the model's host path instead.
+var containerModelPaths = map[string]bool{"/models": true, "/model": true}
+
+// buildNativeCommand returns the executable and arguments that run
+// engineType as a native process. Engines with an asset run its startup
+// command and default args, with the model mount point replaced by
+// modelPath, the port substituted and, when set, native_binary in place of
+// the command's executable. vLLM without an asset keeps the built-in
+// command.
+func (p *HybridEngineProvider) buildNativeCommand(engineType, modelPath string, port int, config map[string]any) (string, []string, error) {
+	if asset, ok := p.engineAsset(engineType); ok && len(asset.BaseCommand) > 0 {
+		cmd := make([]string, 0, len(asset.BaseCommand)+len(asset.DefaultArgs)+2)
+		cmd = append(cmd, asset.BaseCommand...)
+		cmd = append(cmd, asset.DefaultArgs...)
+		if modelPath != "" {
+			for i, arg := range cmd {
+				if containerModelPaths[arg] {
+					cmd[i] = modelPath
+				}
+			}
+		}
+		if engineType == "vllm" {
+			cmd = applyVLLMOptions(cmd, config)
+		}
+		cmd = applyPortToArgs(cmd, port)
+		binary := cmd[0]
+		if asset.NativeBinary != "" {
+			binary = asset.NativeBinary
+		}
+		return binary, cmd[1:], nil
+	}
+
+	if engineType != "vllm" {
+		return "", nil, fmt.Errorf("engine %s has no native startup command", engineType)
+	}
+	args := []string{"serve"}
+	if modelPath != "" {
+		args = append(args, modelPath)
+	} else {
+		model, _ := config["model"].(string)
+		args = append(args, "--model", model)
+	}
+	args = append(args, "--port", strconv.Itoa(port))
+	if _, ok := config["gpu_memory_utilization"].(float64); !ok {
+		args = append(args, "--gpu-memory-utilization", "0.9")
+	}
+	return "vllm", applyVLLMOptions(args, config), nil
+}
+
+// nativeBinary returns the executable that runs engineType natively.
+func (p *HybridEngineProvider) nativeBinary(engineType string) string {
+	if asset, ok := p.engineAsset(engineType); ok {
+		if asset.NativeBinary != "" {
+			return asset.NativeBinary
+		}
+		if len(asset.BaseCommand) > 0 {
+			return asset.BaseCommand[0]
+		}
+	}
+	return engineType
+}
+
 // Stop stops the engine
 func (p *HybridEngineProvider) Stop(ctx context.Context, name string, force bool, timeout int) (*engine.StopResult, error) {
 	// Try Docker first
@@ -782,6 +1094,10 @@ func (p *HybridEngineProvider) Stop(ctx context.Context, name string, force bool
 	// Try native process
 	p.mu.RLock()
 	cmd, exists := p.nativeProcesses[name]
+	var run *nativeRun
+	if exists {
+		run = p.nativeRuns[strconv.Itoa(cmd.Process.Pid)]
+	}
 	p.mu.RUnlock()
 	if exists {
 		slog.Info("stopping native process", "pid", cmd.Process.Pid)
@@ -789,9 +1105,12 @@ func (p *HybridEngineProvider) Stop(ctx context.Context, name string, force bool
 			_ = cmd.Process.Kill()
 		} else {
 			_ = cmd.Process.Signal(os.Interrupt)
-			// Wait for graceful shutdown
-			done := make(chan error, 1)
-			go func() { done <- cmd.Wait() }()
+			// Wait for graceful shutdown; the goroutine started with the
+			// process is the one calling Wait.
+			var done <-chan struct{}
+			if run != nil {
+				done = run.done
+			}
 			select {
 			case <-done:
 			case <-time.After(time.Duration(timeout) * time.Second):
@@ -800,6 +1119,7 @@ func (p *HybridEngineProvider) Stop(ctx context.Context, name string, force bool
 		}
 		p.mu.Lock()
 		delete(p.nativeProcesses, name)
+		p.dropNativeRuns(name)
 		p.mu.Unlock()
 		return &engine.StopResult{Success: true}, nil
 	}
@@ -877,7 +1197,7 @@ func (p *HybridEngineProvider) getDockerImages(name, version string) []string {
 	}
 
 	// Use YAML-loaded asset when available.
-	if asset, ok := p.engineAssets[name]; ok && asset.ImageFullName != "" {
+	if asset, ok := p.engineAsset(name); ok && asset.ImageFullName != "" {
 		images := []string{asset.ImageFullName}
 		images = append(images, asset.AlternativeNames...)
 		return images
@@ -927,9 +1247,15 @@ func (p *HybridEngineProvider) getDockerImage(name, version string) string {
 	return candidates[0]
 }
 
+// DefaultPort returns the port an engine of engineType listens on when it
+// is started without one.
+func (p *HybridEngineProvider) DefaultPort(engineType string) int {
+	return p.getDefaultPort(engineType)
+}
+
 func (p *HybridEngineProvider) getDefaultPort(engineType string) int {
 	// Prefer port from YAML asset when available.
-	if asset, ok := p.engineAssets[engineType]; ok && asset.DefaultPort > 0 {
+	if asset, ok := p.engineAsset(engineType); ok && asset.DefaultPort > 0 {
 		return asset.DefaultPort
 	}
 
@@ -994,6 +1320,152 @@ func applyPortToArgs(args []string, port int) []string {
 	return append(result, "--port", portStr)
 }
 
+// setArgValue returns a copy of args with flag's value replaced, appending
+// the flag when it is not present.
+func setArgValue(args []string, flag, value string) []string {
+	result := make([]string, len(args))
+	copy(result, args)
+	for i, arg := range result {
+		if arg == flag && i+1 < len(result) {
+			result[i+1] = value
+			return result
+		}
+	}
+	return append(result, flag, value)
+}
+
+// vllmDTypes are the values vLLM accepts for --dtype.
+var vllmDTypes = map[string]bool{
+	"auto":     true,
+	"half":     true,
+	"float16":  true,
+	"bfloat16": true,
+	"float":    true,
+	"float32":  true,
+}
+
+// applyVLLMOptions overrides vLLM flags in args with the
+// gpu_memory_utilization, max_model_len and dtype set in config.
+func applyVLLMOptions(args []string, config map[string]any) []string {
+	if gpuUtil, ok := config["gpu_memory_utilization"].(float64); ok {
+		args = setArgValue(args, "--gpu-memory-utilization", fmt.Sprintf("%.2f", gpuUtil))
+	}
+	if maxLen, ok := config["max_model_len"].(int); ok {
+		args = setArgValue(args, "--max-model-len", strconv.Itoa(maxLen))
+	}
+	if dtype, ok := config["dtype"].(string); ok {
+		args = setArgValue(args, "--dtype", dtype)
+	}
+	return args
+}
+
+// parseMaxModelLen converts a configured max_model_len value and checks it
+// is positive.
+func parseMaxModelLen(v any) (int, error) {
+	var n int
+	switch val := v.(type) {
+	case int:
+		n = val
+	case int64:
+		n = int(val)
+	case float64:
+		if val != float64(int(val)) {
+			return 0, fmt.Errorf("max_model_len must be an integer, got %v", val)
+		}
+		n = int(val)
+	case string:
+		parsed, err := strconv.Atoi(val)
+		if err != nil {
+			return 0, fmt.Errorf("invalid max_model_len %q: %w", val, err)
+		}
+		n = parsed
+	default:
+		return 0, fmt.Errorf("invalid max_model_len type %T", v)
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("max_model_len must be positive, got %d", n)
+	}
+	return n, nil
+}
+
+// parseServiceEnv converts a service's configured env, a map of variable
+// names to values, and rejects unsafe entries. See docker.ValidateEnv.
+func parseServiceEnv(v any) (map[string]string, error) {
+	env := make(map[string]string)
+	switch val := v.(type) {
+	case map[string]string:
+		for name, value := range val {
+			env[name] = value
+		}
+	case map[string]any:
+		for name, value := range val {
+			switch value.(type) {
+			case string, bool, int, int64, float64:
+				env[name] = fmt.Sprint(value)
+			default:
+				return nil, fmt.Errorf("env %s must be a string, got %T", name, value)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid env type %T", v)
+	}
+	if err := docker.ValidateEnv(env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// engineEnv returns the validated service env in config as KEY=VALUE
+// entries.
+func engineEnv(config map[string]any) []string {
+	env, _ := config["env"].(map[string]string)
+	if len(env) == 0 {
+		return nil
+	}
+	return docker.EnvList(env)
+}
+
+// parseVLLMDType checks a configured dtype is one vLLM accepts.
+func parseVLLMDType(v any) (string, error) {
+	dtype, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid dtype type %T", v)
+	}
+	dtype = strings.ToLower(dtype)
+	if !vllmDTypes[dtype] {
+		return "", fmt.Errorf("unsupported dtype %q", dtype)
+	}
+	return dtype, nil
+}
+
+// parseGPUMemoryUtilization converts a configured gpu_memory_utilization
+// value and checks it lies in (0, 1].
+func parseGPUMemoryUtilization(v any) (float64, error) {
+	var util float64
+	switch val := v.(type) {
+	case float64:
+		util = val
+	case float32:
+		util = float64(val)
+	case int:
+		util = float64(val)
+	case int64:
+		util = float64(val)
+	case string:
+		parsed, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid gpu_memory_utilization %q: %w", val, err)
+		}
+		util = parsed
+	default:
+		return 0, fmt.Errorf("invalid gpu_memory_utilization type %T", v)
+	}
+	if util <= 0 || util > 1 {
+		return 0, fmt.Errorf("gpu_memory_utilization must be in (0, 1], got %v", util)
+	}
+	return util, nil
+}
+
 func (p *HybridEngineProvider) buildDockerCommand(engineType string, image string, config map[string]any, port int) []string {
 	// Image-specific overrides: custom images with their own CMD/ENTRYPOINT.
 	if strings.Contains(image, "aima-qwen3-omni-server") {
@@ -1001,10 +1473,13 @@ func (p *HybridEngineProvider) buildDockerCommand(engineType string, image strin
 	}
 
 	// Use YAML-asset command + DefaultArgs when available (with port substitution).
-	if asset, ok := p.engineAssets[engineType]; ok && len(asset.DefaultArgs) > 0 {
+	if asset, ok := p.engineAsset(engineType); ok && len(asset.DefaultArgs) > 0 {
 		cmd := make([]string, 0, len(asset.BaseCommand)+len(asset.DefaultArgs)+2)
 		cmd = append(cmd, asset.BaseCommand...)
 		cmd = append(cmd, asset.DefaultArgs...)
+		if engineType == "vllm" {
+			cmd = applyVLLMOptions(cmd, config)
+		}
 		return applyPortToArgs(cmd, port)
 	}
 
@@ -1015,20 +1490,17 @@ func (p *HybridEngineProvider) buildDockerCommand(engineType string, image strin
 			// GB10 compatible custom image uses nvidia_entrypoint.sh
 			// The entrypoint will handle vllm serve automatically
 			// We just need to pass the model path and port
-			return []string{
+			return applyVLLMOptions([]string{
 				"vllm", "serve", "/models",
 				"--port", strconv.Itoa(port),
-				"--gpu-memory-utilization", "0.75",
+				"--gpu-memory-utilization", fmt.Sprintf("%.2f", DefaultGPUMemoryUtilization),
 				"--max-model-len", "8192", // Limit context length for GB10
-			}
+			}, config)
 		}
-		cmd := []string{"--model", "/models", "--port", strconv.Itoa(port)}
-		if gpuUtil, ok := config["gpu_memory_utilization"].(float64); ok {
-			cmd = append(cmd, "--gpu-memory-utilization", fmt.Sprintf("%.2f", gpuUtil))
-		} else {
-			cmd = append(cmd, "--gpu-memory-utilization", "0.75") // Reduced from 0.9
-		}
-		return cmd
+		return applyVLLMOptions([]string{
+			"--model", "/models", "--port", strconv.Itoa(port),
+			"--gpu-memory-utilization", fmt.Sprintf("%.2f", DefaultGPUMemoryUtilization),
+		}, config)
 	case "whisper", "asr":
 		// Check which image is being used
 		if strings.Contains(image, "qujing-glm-asr-nano") {
@@ -1069,49 +1541,98 @@ type HybridServiceProvider struct {
 	hybridProvider *HybridEngineProvider
 	modelStore     model.ModelStore
 	serviceStore   service.ServiceStore
-	portCounter    int
+	portCounter    int // next port to try; see hybrid_ports.go
+	portMin        int
+	portMax        int
 	startupOrder   []string // Track startup order
+	idle           *idleMonitor
+
+	// stopService defaults to Stop; tests replace it to avoid touching
+	// Docker.
+	stopService func(ctx context.Context, serviceID string, force bool) error
+	// startEngine defaults to hybridProvider.Start; tests replace it to
+	// avoid touching Docker.
+	startEngine func(ctx context.Context, engineType string, config map[string]any) (*engine.StartResult, error)
+	// stopEngine defaults to stopContainers; tests replace it to avoid
+	// touching Docker.
+	stopEngine func(ctx context.Context, serviceID string, force bool) error
+	// starts coalesces concurrent StartAsync calls for the same service so
+	// they do not create two containers on the same port.
+	starts singleflight.Group
+
+	// inFlight counts requests tracked by BeginRequest per service, and
+	// draining marks services that Stop is draining. See hybrid_drain.go.
+	inFlight     map[string]int
+	draining     map[string]bool
+	drainTimeout time.Duration
+	drainPoll    time.Duration
+
+	// gpuMemoryUtilization is the default for vLLM services that do not set
+	// gpu_memory_utilization in their Config.
+	gpuMemoryUtilization float64
+
+	// ledger, when set, holds the resources of starting and running
+	// services. See hybrid_allocations.go.
+	ledger *resource.Ledger
 }
 
 // NewHybridServiceProvider creates a new hybrid service provider.
 // It scans existing services in serviceStore to resume port assignment after
 // the previous port used, avoiding port collisions on restart.
 func NewHybridServiceProvider(modelStore model.ModelStore, serviceStore service.ServiceStore) *HybridServiceProvider {
-	portCounter := 8000
+	portCounter := DefaultPortMin
 
 	// Scan existing services to find the highest port in use.
 	services, _, err := serviceStore.List(context.Background(), service.ServiceFilter{})
 	if err == nil {
 		for _, svc := range services {
-			if svc.Config == nil {
-				continue
-			}
-			portVal, ok := svc.Config["port"]
-			if !ok {
-				continue
-			}
-			var port int
-			switch v := portVal.(type) {
-			case int:
-				port = v
-			case int64:
-				port = int(v)
-			case float64:
-				port = int(v)
-			}
-			if port >= portCounter {
+			if port, ok := servicePort(svc.Config); ok && port >= portCounter {
 				portCounter = port + 1
 			}
 		}
 	}
 
-	return &HybridServiceProvider{
-		hybridProvider: NewHybridEngineProvider(modelStore),
-		modelStore:     modelStore,
-		serviceStore:   serviceStore,
-		portCounter:    portCounter,
-		startupOrder:   []string{},
+	p := &HybridServiceProvider{
+		hybridProvider:       NewHybridEngineProvider(modelStore),
+		modelStore:           modelStore,
+		serviceStore:         serviceStore,
+		portCounter:          portCounter,
+		portMin:              DefaultPortMin,
+		portMax:              DefaultPortMax,
+		startupOrder:         []string{},
+		gpuMemoryUtilization: DefaultGPUMemoryUtilization,
+		inFlight:             make(map[string]int),
+		draining:             make(map[string]bool),
+		drainPoll:            defaultDrainPoll,
+	}
+	p.stopService = p.Stop
+	p.startEngine = p.hybridProvider.Start
+	p.stopEngine = p.stopContainers
+	return p
+}
+
+// EnableDockerCLIFallback retries failed Docker SDK operations through the
+// docker CLI. See HybridEngineProvider.EnableCLIFallback.
+func (p *HybridServiceProvider) EnableDockerCLIFallback() {
+	p.hybridProvider.EnableCLIFallback()
+}
+
+// SetModelCacheVolume sets the named volume shared as the model cache. See
+// HybridEngineProvider.SetModelCacheVolume.
+func (p *HybridServiceProvider) SetModelCacheVolume(name string) {
+	p.hybridProvider.SetModelCacheVolume(name)
+}
+
+// SetGPUMemoryUtilization sets the GPU memory fraction used for vLLM
+// services that do not configure their own. It must be in (0, 1].
+func (p *HybridServiceProvider) SetGPUMemoryUtilization(util float64) error {
+	if _, err := parseGPUMemoryUtilization(util); err != nil {
+		return err
 	}
+	p.mu.Lock()
+	p.gpuMemoryUtilization = util
+	p.mu.Unlock()
+	return nil
 }
 
 // Create creates a service configuration
@@ -1122,14 +1643,14 @@ func (p *HybridServiceProvider) Create(ctx context.Context, modelID string, reso
 	}
 
 	engineType := p.hybridProvider.getEngineTypeForModel(m.Type)
-	p.mu.Lock()
-	port := p.portCounter
-	p.portCounter++
-	p.mu.Unlock()
+	port, err := p.allocatePort(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Determine device based on engine type and resource limits
 	device := "cpu"
-	limits := p.hybridProvider.resourceLimits[engineType]
+	limits := p.hybridProvider.resourceLimitsFor(engineType)
 	if limits.GPU {
 		device = "gpu"
 	}
@@ -1159,9 +1680,53 @@ func (p *HybridServiceProvider) Start(ctx context.Context, serviceID string) err
 	return p.StartAsync(ctx, serviceID, false)
 }
 
+// resolveGPUMemoryUtilization prefers the service's own
+// gpu_memory_utilization over the provider default.
+func (p *HybridServiceProvider) resolveGPUMemoryUtilization(svcConfig map[string]any) (float64, error) {
+	if v, ok := svcConfig["gpu_memory_utilization"]; ok {
+		return parseGPUMemoryUtilization(v)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.gpuMemoryUtilization, nil
+}
+
+// applyVLLMServiceConfig copies the validated max_model_len and dtype from a
+// service's Config into the engine start config.
+func applyVLLMServiceConfig(config, svcConfig map[string]any) error {
+	if v, ok := svcConfig["max_model_len"]; ok {
+		maxLen, err := parseMaxModelLen(v)
+		if err != nil {
+			return err
+		}
+		config["max_model_len"] = maxLen
+	}
+	if v, ok := svcConfig["dtype"]; ok {
+		dtype, err := parseVLLMDType(v)
+		if err != nil {
+			return err
+		}
+		config["dtype"] = dtype
+	}
+	return nil
+}
+
 // StartAsync starts the service with async mode support
 // For large models like Qwen3-Omni, async mode allows starting without waiting for health check
+//
+// Concurrent calls for the same service share a single start, run under the
+// context and async flag of the first caller, and all get its result.
 func (p *HybridServiceProvider) StartAsync(ctx context.Context, serviceID string, async bool) error {
+	_, err, shared := p.starts.Do(serviceID, func() (any, error) {
+		return nil, p.startAsync(ctx, serviceID, async)
+	})
+	if shared {
+		slog.Debug("joined in-flight service start", "service", serviceID)
+	}
+	return err
+}
+
+func (p *HybridServiceProvider) startAsync(ctx context.Context, serviceID string, async bool) error {
 	// Parse service ID to extract engine type and model ID
 	sid, parseErr := service.ParseServiceID(serviceID)
 	if parseErr != nil {
@@ -1178,7 +1743,7 @@ func (p *HybridServiceProvider) StartAsync(ctx context.Context, serviceID string
 	}
 
 	// Build config for engine start with resource limits
-	limits := p.hybridProvider.resourceLimits[engineType]
+	limits := p.hybridProvider.resourceLimitsFor(engineType)
 	config := map[string]any{
 		"model_id":   modelID,
 		"model_path": m.Path,
@@ -1187,27 +1752,66 @@ func (p *HybridServiceProvider) StartAsync(ctx context.Context, serviceID string
 		"async":      async, // Pass async flag to engine
 	}
 
+	// Read the persisted port assignment for this service from the store.
+	// This ensures two services with different ports don't both default to 8000.
+	var svcConfig map[string]any
+	svc, svcErr := p.serviceStore.Get(ctx, serviceID)
+	if svcErr != nil {
+		svc = nil
+	}
+	if svc != nil && svc.Config != nil {
+		svcConfig = svc.Config
+		if portVal, ok := svc.Config["port"]; ok {
+			config["port"] = portVal
+		}
+	}
+
 	// Only vLLM gets GPU by default
 	if engineType == "vllm" {
 		config["device"] = "gpu"
 		config["gpu"] = true
-		config["gpu_memory_utilization"] = 0.75 // Limit GPU memory
+		gpuUtil, err := p.resolveGPUMemoryUtilization(svcConfig)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", serviceID, err)
+		}
+		config["gpu_memory_utilization"] = gpuUtil
+
+		if err := applyVLLMServiceConfig(config, svcConfig); err != nil {
+			return fmt.Errorf("service %s: %w", serviceID, err)
+		}
 	}
 
-	// Read the persisted port assignment for this service from the store.
-	// This ensures two services with different ports don't both default to 8000.
-	if svc, svcErr := p.serviceStore.Get(ctx, serviceID); svcErr == nil && svc.Config != nil {
-		if portVal, ok := svc.Config["port"]; ok {
-			config["port"] = portVal
+	if _, err := applyResourceOverrides(limits, svcConfig); err != nil {
+		return fmt.Errorf("service %s: %w", serviceID, err)
+	}
+	for _, key := range []string{"memory", "cpu"} {
+		if v, ok := svcConfig[key]; ok {
+			config[key] = v
+		}
+	}
+
+	if v, ok := svcConfig["env"]; ok {
+		env, err := parseServiceEnv(v)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", serviceID, err)
 		}
+		config["env"] = env
+	}
+
+	release, err := p.reserve(ctx, serviceID, svc)
+	if err != nil {
+		return err
 	}
 
 	// Start the engine with retry and health check
-	result, err := p.hybridProvider.Start(ctx, engineType, config)
+	result, err := p.startEngine(ctx, engineType, config)
 	if err != nil {
+		release()
 		return fmt.Errorf("start engine %s: %w", engineType, err)
 	}
 
+	p.recordStarted(serviceID)
+
 	if async {
 		slog.Info("engine started in async mode", "engine", engineType, "container_id", result.ProcessID[:12])
 	} else {
@@ -1216,8 +1820,26 @@ func (p *HybridServiceProvider) StartAsync(ctx context.Context, serviceID string
 	return nil
 }
 
-// Stop stops the service
+// Stop stops the service. Unless force is set and when a drain timeout is
+// configured, new requests are refused and tracked in-flight requests get up
+// to that timeout to finish first.
 func (p *HybridServiceProvider) Stop(ctx context.Context, serviceID string, force bool) error {
+	if !force {
+		defer p.endDrain(serviceID)
+		if !p.drainRequests(ctx, serviceID) {
+			slog.Warn("stopping service with requests still in flight", "service", serviceID, "in_flight", p.InFlight(serviceID))
+		}
+	}
+	if err := p.stopEngine(ctx, serviceID, force); err != nil {
+		return err
+	}
+	p.releaseReservation(serviceID)
+	p.recordStopped(serviceID)
+	return nil
+}
+
+// stopContainers stops the engine container of serviceID.
+func (p *HybridServiceProvider) stopContainers(ctx context.Context, serviceID string, force bool) error {
 	// Parse engine type from service ID: svc-{engine_type}-{model_id}
 	// hybridProvider.Stop is keyed by engineType, not serviceID.
 	engineType := serviceID
@@ -1287,6 +1909,11 @@ func (p *HybridServiceProvider) GetRecommendation(ctx context.Context, modelID s
 		resourceClass = service.ResourceClassLarge
 	}
 
+	config, err := p.recommendedConfig(ctx, engineType, m)
+	if err != nil {
+		return nil, err
+	}
+
 	return &service.Recommendation{
 		ResourceClass:      resourceClass,
 		Replicas:           1,
@@ -1294,6 +1921,7 @@ func (p *HybridServiceProvider) GetRecommendation(ctx context.Context, modelID s
 		EngineType:         engineType,
 		DeviceType:         deviceType,
 		Reason:             fmt.Sprintf("Model type '%s' recommended to run on %s with %s engine (CPU forced for non-LLM models on unified memory systems)", m.Type, deviceType, engineType),
+		Config:             config,
 	}, nil
 }
 
@@ -1307,21 +1935,25 @@ func (p *HybridServiceProvider) IsRunning(ctx context.Context, serviceID string)
 		return false
 	}
 
-	// Check if Docker container is running
-	if len(info.ProcessID) == 64 {
-		status, err := p.hybridProvider.dockerClient.GetContainerStatus(ctx, info.ProcessID)
-		if err != nil {
-			return false
-		}
-		return status == "running"
+	// For native processes, ProcessID is the PID. Processes started in this
+	// session report their own exit; otherwise ask the OS.
+	if run, ok := p.hybridProvider.nativeRun(info.ProcessID); ok {
+		return !run.exited()
+	}
+	if pid, err := strconv.Atoi(info.ProcessID); err == nil {
+		return processAlive(pid)
 	}
 
-	// For native processes, check if process exists
-	// ProcessID is the PID for native processes
-	return true // Simplified check for now
+	// Anything else is a Docker container ID
+	status, err := p.hybridProvider.dockerClient.GetContainerStatus(ctx, info.ProcessID)
+	if err != nil {
+		return false
+	}
+	return status == "running"
 }
 
-// GetLogs returns the last tail lines of logs for the service container.
+// GetLogs returns the last tail lines of logs for the service container, or
+// the captured output of a native engine process.
 func (p *HybridServiceProvider) GetLogs(ctx context.Context, serviceID string, tail int) (string, error) {
 	// First: check in-memory service info (populated when service was started in this session)
 	p.hybridProvider.mu.RLock()
@@ -1329,6 +1961,10 @@ func (p *HybridServiceProvider) GetLogs(ctx context.Context, serviceID string, t
 	p.hybridProvider.mu.RUnlock()
 
 	if exists && info != nil && info.ProcessID != "" {
+		// Native engines report their PID and keep output in memory.
+		if logs, ok := p.hybridProvider.nativeLogs(info.ProcessID, tail); ok {
+			return logs, nil
+		}
 		logs, err := p.hybridProvider.dockerClient.GetContainerLogs(ctx, info.ProcessID, tail)
 		if err != nil {
 			return "", fmt.Errorf("get container logs for %s: %w", info.ProcessID, err)
@@ -1352,6 +1988,98 @@ func (p *HybridServiceProvider) GetLogs(ctx context.Context, serviceID string, t
 	return "", fmt.Errorf("no running container found for service %s", serviceID)
 }
 
+// Exec runs cmd inside the service's container. The container is located the
+// same way as GetLogs: from in-memory service info, then by engine label.
+func (p *HybridServiceProvider) Exec(ctx context.Context, serviceID string, cmd []string) (*service.ExecResult, error) {
+	p.hybridProvider.mu.RLock()
+	info, exists := p.hybridProvider.serviceInfo[serviceID]
+	dc := p.hybridProvider.dockerClient
+	p.hybridProvider.mu.RUnlock()
+
+	containerID := ""
+	if exists && info != nil && info.ProcessID != "" {
+		containerID = info.ProcessID
+	} else if sid, err := service.ParseServiceID(serviceID); err == nil {
+		containers, listErr := dc.ListContainers(ctx, map[string]string{"aima.engine": sid.EngineType})
+		if listErr == nil && len(containers) > 0 {
+			containerID = containers[0]
+		}
+	}
+	if containerID == "" {
+		return nil, fmt.Errorf("no running container found for service %s", serviceID)
+	}
+
+	stdout, stderr, exitCode, err := dc.Exec(ctx, containerID, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("exec in container %s: %w", containerID, err)
+	}
+	return &service.ExecResult{Stdout: stdout, Stderr: stderr, ExitCode: exitCode}, nil
+}
+
+// DiscoverContainers inspects every aima.managed=true container. ServiceID is
+// filled for containers started by this process; the service.discover query
+// matches the rest against the service store.
+func (p *HybridServiceProvider) DiscoverContainers(ctx context.Context) ([]service.ManagedContainer, error) {
+	p.hybridProvider.mu.RLock()
+	dc := p.hybridProvider.dockerClient
+	owners := make(map[string]string, len(p.hybridProvider.serviceInfo))
+	for serviceID, info := range p.hybridProvider.serviceInfo {
+		if info != nil && info.ProcessID != "" {
+			owners[info.ProcessID] = serviceID
+		}
+	}
+	p.hybridProvider.mu.RUnlock()
+
+	ids, err := dc.ListContainers(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list managed containers: %w", err)
+	}
+
+	containers := make([]service.ManagedContainer, 0, len(ids))
+	for _, id := range ids {
+		info, err := dc.InspectContainer(ctx, id)
+		if err != nil {
+			slog.Warn("skipping container that could not be inspected", "container_id", id, "error", err)
+			continue
+		}
+		if info.Labels["aima.managed"] != "true" {
+			continue
+		}
+
+		mc := service.ManagedContainer{
+			ContainerID: info.ID,
+			Name:        info.Name,
+			Engine:      info.Labels["aima.engine"],
+			Port:        lowestHostPort(info.Ports),
+			Status:      info.Status,
+		}
+		for processID, serviceID := range owners {
+			if strings.HasPrefix(info.ID, processID) || strings.HasPrefix(processID, info.ID) {
+				mc.ServiceID = serviceID
+				break
+			}
+		}
+		containers = append(containers, mc)
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
+	return containers, nil
+}
+
+// lowestHostPort returns the lowest numeric host port in ports, or 0.
+func lowestHostPort(ports map[string]string) int {
+	lowest := 0
+	for hostPort := range ports {
+		n, err := strconv.Atoi(hostPort)
+		if err != nil {
+			continue
+		}
+		if lowest == 0 || n < lowest {
+			lowest = n
+		}
+	}
+	return lowest
+}
+
 // GetEngineProvider returns the underlying engine provider
 func (p *HybridServiceProvider) GetEngineProvider() engine.EngineProvider {
 	return p.hybridProvider
@@ -4,15 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/docker"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/catalog"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
@@ -85,7 +91,7 @@ func (m *mockModelStore) addModel(mod *model.Model) {
 // ---- Tests for ResourceLimits and StartupConfig defaults ----
 
 func TestGetDefaultResourceLimits(t *testing.T) {
-	limits := getDefaultResourceLimits()
+	limits := getDefaultResourceLimits(nil)
 
 	tests := []struct {
 		engine    string
@@ -123,7 +129,7 @@ func TestGetDefaultResourceLimits_EnvOverride(t *testing.T) {
 	t.Run("memory override", func(t *testing.T) {
 		t.Setenv("AIMA_WHISPER_MEMORY", "8g")
 
-		limits := getDefaultResourceLimits()
+		limits := getDefaultResourceLimits(nil)
 		if limits["whisper"].Memory != "8g" {
 			t.Errorf("expected memory '8g', got %q", limits["whisper"].Memory)
 		}
@@ -132,7 +138,7 @@ func TestGetDefaultResourceLimits_EnvOverride(t *testing.T) {
 	t.Run("cpu override", func(t *testing.T) {
 		t.Setenv("AIMA_WHISPER_CPU", "4.0")
 
-		limits := getDefaultResourceLimits()
+		limits := getDefaultResourceLimits(nil)
 		if limits["whisper"].CPU != 4.0 {
 			t.Errorf("expected CPU 4.0, got %.1f", limits["whisper"].CPU)
 		}
@@ -141,7 +147,7 @@ func TestGetDefaultResourceLimits_EnvOverride(t *testing.T) {
 	t.Run("gpu override true", func(t *testing.T) {
 		t.Setenv("AIMA_WHISPER_GPU", "true")
 
-		limits := getDefaultResourceLimits()
+		limits := getDefaultResourceLimits(nil)
 		if !limits["whisper"].GPU {
 			t.Error("expected GPU=true after override")
 		}
@@ -150,7 +156,7 @@ func TestGetDefaultResourceLimits_EnvOverride(t *testing.T) {
 	t.Run("gpu override 1", func(t *testing.T) {
 		t.Setenv("AIMA_WHISPER_GPU", "1")
 
-		limits := getDefaultResourceLimits()
+		limits := getDefaultResourceLimits(nil)
 		if !limits["whisper"].GPU {
 			t.Error("expected GPU=true for value '1'")
 		}
@@ -159,7 +165,7 @@ func TestGetDefaultResourceLimits_EnvOverride(t *testing.T) {
 	t.Run("gpu override false", func(t *testing.T) {
 		t.Setenv("AIMA_VLLM_GPU", "false")
 
-		limits := getDefaultResourceLimits()
+		limits := getDefaultResourceLimits(nil)
 		if limits["vllm"].GPU {
 			t.Error("expected GPU=false after override")
 		}
@@ -169,13 +175,128 @@ func TestGetDefaultResourceLimits_EnvOverride(t *testing.T) {
 		t.Setenv("AIMA_WHISPER_CPU", "not-a-number")
 
 		// Should not panic, should keep default
-		limits := getDefaultResourceLimits()
+		limits := getDefaultResourceLimits(nil)
 		if limits["whisper"].CPU != 2.0 {
 			t.Errorf("expected default CPU 2.0 for invalid override, got %.1f", limits["whisper"].CPU)
 		}
 	})
 }
 
+func TestGetDefaultResourceLimits_AssetDefaults(t *testing.T) {
+	gpu := true
+	assets := map[string]catalog.EngineAsset{
+		"whisper":  {Type: "whisper", Resources: catalog.ResourceDefaults{Memory: "6g", CPU: 3}},
+		"llamacpp": {Type: "llamacpp", Resources: catalog.ResourceDefaults{Memory: "12g", CPU: 8, GPU: &gpu, GPUMemory: "16g"}},
+		"tts":      {Type: "tts"},
+	}
+
+	limits := getDefaultResourceLimits(assets)
+	assert.Equal(t, ResourceLimits{Memory: "6g", CPU: 3, GPU: false, GPUMemory: "0"}, limits["whisper"],
+		"asset memory and CPU replace the built-in defaults, unset fields are kept")
+	assert.Equal(t, ResourceLimits{Memory: "12g", CPU: 8, GPU: true, GPUMemory: "16g"}, limits["llamacpp"],
+		"engine types without built-in defaults use the asset's")
+	assert.Equal(t, ResourceLimits{Memory: "4g", CPU: 2.0, GPU: false, GPUMemory: "0"}, limits["tts"],
+		"an asset without resources keeps the built-in defaults")
+
+	t.Setenv("AIMA_WHISPER_MEMORY", "8g")
+	t.Setenv("AIMA_LLAMACPP_CPU", "4")
+	limits = getDefaultResourceLimits(assets)
+	assert.Equal(t, "8g", limits["whisper"].Memory, "env overrides win over asset defaults")
+	assert.Equal(t, 3.0, limits["whisper"].CPU)
+	assert.Equal(t, 4.0, limits["llamacpp"].CPU)
+}
+
+func TestHybridEngineProvider_ReloadAssets(t *testing.T) {
+	p := newHybridEngineProviderWithClient(newMockModelStore(), docker.NewMockClient())
+	assert.NotContains(t, p.AssetTypes(), "llamacpp")
+
+	operatorFS := fstest.MapFS{
+		"llamacpp/llamacpp-cpu.yaml": {Data: []byte(`
+name: llamacpp-cpu
+type: llamacpp
+image:
+  full_name: "ghcr.io/ggml-org/llama.cpp:server"
+startup:
+  command: ["llama-server"]
+  default_args: ["--port", "8085"]
+resources:
+  memory: "12g"
+  cpu: 6
+`)},
+	}
+	require.NoError(t, p.ReloadAssets(operatorFS))
+
+	assert.Contains(t, p.AssetTypes(), "llamacpp")
+	assert.Contains(t, p.AssetTypes(), "vllm", "embedded assets stay loaded")
+	assert.Equal(t, 8085, p.getDefaultPort("llamacpp"))
+	assert.Equal(t, []string{"ghcr.io/ggml-org/llama.cpp:server"}, p.getDockerImages("llamacpp", ""))
+	assert.Equal(t, "12g", p.resourceLimitsFor("llamacpp").Memory)
+	assert.Equal(t, 6.0, p.resourceLimitsFor("llamacpp").CPU)
+
+	broken := fstest.MapFS{"sglang.yaml": {Data: []byte("name: sglang\ntype: sglang\n")}}
+	err := p.ReloadAssets(broken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sglang.yaml")
+	assert.Contains(t, p.AssetTypes(), "llamacpp", "a failed reload keeps the previous assets")
+	assert.NotContains(t, p.AssetTypes(), "sglang")
+
+	require.NoError(t, p.ReloadAssets(nil))
+	assert.NotContains(t, p.AssetTypes(), "llamacpp", "a nil FS restores the embedded catalog")
+}
+
+func TestHybridEngineProvider_BuildNativeCommand(t *testing.T) {
+	p := newHybridEngineProviderWithClient(newMockModelStore(), docker.NewMockClient())
+	p.engineAssets = map[string]catalog.EngineAsset{
+		"whisper": {
+			Type:        "whisper",
+			BaseCommand: []string{"uvicorn", "main:app", "--host", "0.0.0.0"},
+			DefaultArgs: []string{"--model", "/model", "--port", "8001"},
+		},
+	}
+
+	binary, args, err := p.buildNativeCommand("whisper", "/data/models/sensevoice", 9001, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "uvicorn", binary)
+	assert.Equal(t, []string{"main:app", "--host", "0.0.0.0", "--model", "/data/models/sensevoice", "--port", "9001"}, args)
+
+	_, _, err = p.buildNativeCommand("transformers", "/data/models/bert", 9002, nil)
+	assert.ErrorContains(t, err, "no native startup command")
+
+	binary, args, err = p.buildNativeCommand("vllm", "/data/models/qwen", 9003, map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, "vllm", binary)
+	assert.Equal(t, []string{"serve", "/data/models/qwen", "--port", "9003", "--gpu-memory-utilization", "0.9"}, args)
+}
+
+func TestHybridEngineProvider_StartNative_FromAsset(t *testing.T) {
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + ".tmp && mv " + argsFile + ".tmp " + argsFile + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "fake-tts-server"), []byte(script), 0755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	p := newHybridEngineProviderWithClient(newMockModelStore(), docker.NewMockClient())
+	p.engineAssets = map[string]catalog.EngineAsset{
+		"tts": {
+			Type:         "tts",
+			BaseCommand:  []string{"uvicorn", "main:app", "--host", "0.0.0.0"},
+			NativeBinary: "fake-tts-server",
+			DefaultArgs:  []string{"--model", "/model", "--device", "cpu", "--port", "8002"},
+		},
+	}
+
+	result, err := p.startNative(context.Background(), "tts", "/data/models/qwen-tts", 18123, false, map[string]any{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.ProcessID)
+
+	var got []byte
+	require.Eventually(t, func() bool {
+		got, err = os.ReadFile(argsFile)
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, "main:app --host 0.0.0.0 --model /data/models/qwen-tts --device cpu --port 18123\n", string(got))
+}
+
 func TestGetDefaultStartupConfigs(t *testing.T) {
 	configs := getDefaultStartupConfigs()
 
@@ -691,6 +812,110 @@ func TestHybridEngineProvider_buildDockerCommand(t *testing.T) {
 	}
 }
 
+func argValue(args []string, flag string) string {
+	for i, arg := range args {
+		if arg == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func TestHybridServiceProvider_GPUMemoryUtilization(t *testing.T) {
+	tests := []struct {
+		name        string
+		image       string
+		svcConfig   map[string]any
+		providerSet float64
+		want        string
+		wantErr     bool
+	}{
+		{name: "default", image: "vllm/vllm-openai:v0.15.0", want: "0.75"},
+		{name: "provider default", image: "vllm/vllm-openai:v0.15.0", providerSet: 0.6, want: "0.60"},
+		{name: "service overrides provider default", image: "vllm/vllm-openai:v0.15.0", providerSet: 0.6, svcConfig: map[string]any{"gpu_memory_utilization": 0.92}, want: "0.92"},
+		{name: "service value from json string", image: "zhiwen-vllm:0128", svcConfig: map[string]any{"gpu_memory_utilization": "0.5"}, want: "0.50"},
+		{name: "zero is rejected", svcConfig: map[string]any{"gpu_memory_utilization": 0.0}, wantErr: true},
+		{name: "above one is rejected", svcConfig: map[string]any{"gpu_memory_utilization": 1.5}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+			if tt.providerSet > 0 {
+				require.NoError(t, p.SetGPUMemoryUtilization(tt.providerSet))
+			}
+
+			util, err := p.resolveGPUMemoryUtilization(tt.svcConfig)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			cmd := p.hybridProvider.buildDockerCommand("vllm", tt.image, map[string]any{"gpu_memory_utilization": util}, 8000)
+			assert.Equal(t, tt.want, argValue(cmd, "--gpu-memory-utilization"), "command: %v", cmd)
+		})
+	}
+}
+
+func TestHybridEngineProvider_buildDockerCommand_VLLMOptions(t *testing.T) {
+	p := NewHybridEngineProvider(newMockModelStore())
+	config := map[string]any{"max_model_len": 32768, "dtype": "bfloat16"}
+
+	for _, image := range []string{"zhiwen-vllm:0128", "vllm/vllm-openai:v0.15.0"} {
+		t.Run(image, func(t *testing.T) {
+			cmd := p.buildDockerCommand("vllm", image, config, 8000)
+			assert.Equal(t, "32768", argValue(cmd, "--max-model-len"), "command: %v", cmd)
+			assert.Equal(t, "bfloat16", argValue(cmd, "--dtype"), "command: %v", cmd)
+
+			count := 0
+			for _, arg := range cmd {
+				if arg == "--max-model-len" {
+					count++
+				}
+			}
+			assert.Equal(t, 1, count, "--max-model-len should replace the default, command: %v", cmd)
+		})
+	}
+}
+
+func TestApplyVLLMServiceConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		svcConfig map[string]any
+		want      map[string]any
+		wantErr   bool
+	}{
+		{name: "empty", svcConfig: nil, want: map[string]any{}},
+		{name: "json numbers", svcConfig: map[string]any{"max_model_len": float64(4096), "dtype": "Half"}, want: map[string]any{"max_model_len": 4096, "dtype": "half"}},
+		{name: "string length", svcConfig: map[string]any{"max_model_len": "16384"}, want: map[string]any{"max_model_len": 16384}},
+		{name: "negative length", svcConfig: map[string]any{"max_model_len": -1}, wantErr: true},
+		{name: "fractional length", svcConfig: map[string]any{"max_model_len": 1.5}, wantErr: true},
+		{name: "unknown dtype", svcConfig: map[string]any{"dtype": "int3"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{}
+			err := applyVLLMServiceConfig(config, tt.svcConfig)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, config)
+		})
+	}
+}
+
+func TestHybridServiceProvider_SetGPUMemoryUtilization_Invalid(t *testing.T) {
+	p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+
+	assert.Error(t, p.SetGPUMemoryUtilization(0))
+	assert.Error(t, p.SetGPUMemoryUtilization(1.01))
+	assert.NoError(t, p.SetGPUMemoryUtilization(1))
+}
+
 // ---- Tests for Stop (docker and native) ----
 
 func TestHybridEngineProvider_Stop_NoProcess(t *testing.T) {
@@ -741,6 +966,33 @@ func TestHybridEngineProvider_Stop_ConcurrentSafety(t *testing.T) {
 	wg.Wait()
 }
 
+func TestHybridEngineProvider_MountModelCache(t *testing.T) {
+	mc := docker.NewMockClient()
+	p := newHybridEngineProviderWithClient(newMockModelStore(), mc)
+	ctx := context.Background()
+
+	// Disabled by default.
+	opts := docker.ContainerOptions{}
+	require.NoError(t, p.mountModelCache(ctx, "vllm", &opts))
+	assert.Empty(t, opts.Volumes)
+	assert.Empty(t, mc.Volumes)
+
+	p.SetModelCacheVolume("aima-model-cache")
+
+	opts = docker.ContainerOptions{Volumes: map[string]string{"/data/models/llama": "/models"}}
+	require.NoError(t, p.mountModelCache(ctx, "vllm", &opts))
+	assert.Equal(t, modelCacheMountPath, opts.Volumes["aima-model-cache"])
+	assert.Equal(t, "/models", opts.Volumes["/data/models/llama"])
+	assert.Contains(t, opts.Env, "HF_HOME="+modelCacheMountPath)
+	require.Contains(t, mc.Volumes, "aima-model-cache")
+	assert.Equal(t, "true", mc.Volumes["aima-model-cache"].Labels["aima.managed"])
+
+	// Engines that do not download from Hugging Face are left alone.
+	opts = docker.ContainerOptions{}
+	require.NoError(t, p.mountModelCache(ctx, "asr", &opts))
+	assert.Empty(t, opts.Volumes)
+}
+
 // ---- Tests for Install ----
 
 func TestHybridEngineProvider_Install_FallbackBestEffort(t *testing.T) {
@@ -757,6 +1009,99 @@ func TestHybridEngineProvider_Install_FallbackBestEffort(t *testing.T) {
 	}
 }
 
+// Install only reaches the pull loop when the docker CLI is present, so the
+// candidate fallback is exercised through pullFirstAvailable directly.
+func TestHybridEngineProvider_PullFirstAvailable_FallsBackToNextCandidate(t *testing.T) {
+	mc := docker.NewMockClient()
+	mc.PullErrors = map[string]error{"vllm/vllm-openai:missing": errors.New("manifest unknown")}
+	bus := &recordingBus{}
+
+	p := newHybridEngineProviderWithClient(newMockModelStore(), mc)
+	p.SetEventBus(bus)
+
+	image, err := p.pullFirstAvailable(context.Background(), "vllm", []string{"vllm/vllm-openai:missing", "vllm/vllm-openai:v0.8.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "vllm/vllm-openai:v0.8.0", image)
+	assert.NotContains(t, mc.Images, "vllm/vllm-openai:missing")
+	assert.Contains(t, mc.Images, "vllm/vllm-openai:v0.8.0")
+
+	var messages []string
+	for _, e := range bus.events {
+		payload := e.Payload().(map[string]any)
+		assert.Equal(t, "pulling", payload["phase"])
+		messages = append(messages, payload["message"].(string))
+	}
+	assert.Equal(t, []string{
+		"Pulling image vllm/vllm-openai:missing (1/2)",
+		"Pulling image vllm/vllm-openai:v0.8.0 (2/2)",
+		"Image pulled: vllm/vllm-openai:v0.8.0",
+	}, messages)
+}
+
+func TestHybridEngineProvider_PullFirstAvailable_PublishesLayerProgress(t *testing.T) {
+	const image = "vllm/vllm-openai:v0.8.0"
+	mc := docker.NewMockClient()
+	mc.PullProgress = map[string][]docker.PullProgress{
+		image: {
+			{LayerID: "a1b2c3", Status: "Pulling fs layer"},
+			{LayerID: "a1b2c3", Status: "Downloading", Current: 10 << 20, Total: 100 << 20},
+			{LayerID: "a1b2c3", Status: "Downloading", Current: 15 << 20, Total: 100 << 20}, // <10% step, throttled
+			{LayerID: "a1b2c3", Status: "Downloading", Current: 60 << 20, Total: 100 << 20},
+			{LayerID: "a1b2c3", Status: "Pull complete"},
+		},
+	}
+	bus := &recordingBus{}
+
+	p := newHybridEngineProviderWithClient(newMockModelStore(), mc)
+	p.SetEventBus(bus)
+
+	_, err := p.pullFirstAvailable(context.Background(), "vllm", []string{image})
+	require.NoError(t, err)
+
+	var layerEvents []map[string]any
+	for _, e := range bus.events {
+		payload := e.Payload().(map[string]any)
+		if _, ok := payload["layer_id"]; ok {
+			assert.Equal(t, engine.EventTypeStartProgress, e.Type())
+			assert.Equal(t, "pulling", payload["phase"])
+			assert.Equal(t, image, payload["image"])
+			layerEvents = append(layerEvents, payload)
+		}
+	}
+	require.Len(t, layerEvents, 4)
+	assert.Equal(t, int64(10<<20), layerEvents[1]["current"])
+	assert.Equal(t, int64(100<<20), layerEvents[1]["total"])
+	assert.Equal(t, 10, layerEvents[1]["progress"])
+	assert.Equal(t, "vllm/vllm-openai:v0.8.0 a1b2c3: Downloading 60.0/100.0 MB", layerEvents[2]["message"])
+	assert.Equal(t, "vllm/vllm-openai:v0.8.0 a1b2c3: Pull complete", layerEvents[3]["message"])
+}
+
+func TestHybridEngineProvider_PullFirstAvailable_AllCandidatesFail(t *testing.T) {
+	mc := docker.NewMockClient()
+	mc.PullErrors = map[string]error{
+		"a:latest": errors.New("not found"),
+		"b:latest": errors.New("unauthorized"),
+	}
+	p := newHybridEngineProviderWithClient(newMockModelStore(), mc)
+
+	_, err := p.pullFirstAvailable(context.Background(), "vllm", []string{"a:latest", "b:latest"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	assert.Contains(t, err.Error(), "unauthorized")
+}
+
+func TestHybridEngineProvider_PullFirstAvailable_StopsAtDeadline(t *testing.T) {
+	mc := docker.NewMockClient()
+	p := newHybridEngineProviderWithClient(newMockModelStore(), mc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := p.pullFirstAvailable(ctx, "vllm", []string{"a:latest", "b:latest"})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, mc.Images)
+}
+
 // ---- Tests for concurrent access to containers and nativeProcesses maps ----
 
 func TestHybridEngineProvider_ConcurrentMapAccess(t *testing.T) {
@@ -1160,22 +1505,156 @@ func TestHybridServiceProvider_IsRunning_WithInfo(t *testing.T) {
 	p := NewHybridServiceProvider(store, service.NewMemoryStore())
 	ctx := context.Background()
 
-	// Inject service info with short ProcessID (not a Docker container ID)
+	live := exec.Command("sleep", "30")
+	require.NoError(t, live.Start())
+	t.Cleanup(func() {
+		_ = live.Process.Kill()
+		_ = live.Wait()
+	})
+
+	dead := exec.Command("true")
+	require.NoError(t, dead.Run())
+
+	// Inject service info with short ProcessIDs (native PIDs, not Docker)
 	p.hybridProvider.mu.Lock()
-	p.hybridProvider.serviceInfo["svc-test"] = &ServiceInfo{
-		ServiceID: "svc-test",
-		ModelID:   "model1",
-		Engine:    "vllm",
-		Port:      8000,
-		ProcessID: "12345", // short PID, not Docker
-	}
+	p.hybridProvider.serviceInfo["svc-live"] = &ServiceInfo{ServiceID: "svc-live", Engine: "vllm", ProcessID: strconv.Itoa(live.Process.Pid)}
+	p.hybridProvider.serviceInfo["svc-dead"] = &ServiceInfo{ServiceID: "svc-dead", Engine: "vllm", ProcessID: strconv.Itoa(dead.Process.Pid)}
+	p.hybridProvider.serviceInfo["svc-bad"] = &ServiceInfo{ServiceID: "svc-bad", Engine: "vllm", ProcessID: "not-a-pid"}
 	p.hybridProvider.mu.Unlock()
 
-	// For native processes with a short ProcessID, IsRunning returns true (simplified check)
-	running := p.IsRunning(ctx, "svc-test")
-	if !running {
-		t.Error("expected IsRunning=true for native process (simplified check)")
+	assert.True(t, p.IsRunning(ctx, "svc-live"), "expected a live native process to be running")
+	assert.False(t, p.IsRunning(ctx, "svc-dead"), "expected a terminated native process not to be running")
+	assert.False(t, p.IsRunning(ctx, "svc-bad"), "expected a malformed PID not to be running")
+}
+
+func TestHybridServiceProvider_IsRunning_NativeRun(t *testing.T) {
+	sp := newNativeTestProvider(t, "exec sleep 30\n")
+	p := sp.hybridProvider
+	ctx := context.Background()
+
+	result, err := p.startNative(ctx, "tts", "", freePort(t), false, map[string]any{})
+	require.NoError(t, err)
+	p.mu.Lock()
+	p.serviceInfo["svc-tts-m1"] = &ServiceInfo{ServiceID: "svc-tts-m1", Engine: "tts", ProcessID: result.ProcessID}
+	run := p.nativeRuns[result.ProcessID]
+	p.mu.Unlock()
+	assert.True(t, sp.IsRunning(ctx, "svc-tts-m1"))
+
+	pid, err := strconv.Atoi(result.ProcessID)
+	require.NoError(t, err)
+	proc, err := os.FindProcess(pid)
+	require.NoError(t, err)
+	require.NoError(t, proc.Kill())
+	select {
+	case <-run.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("native process did not exit")
 	}
+	assert.False(t, sp.IsRunning(ctx, "svc-tts-m1"))
+}
+
+func TestHybridServiceProvider_Exec(t *testing.T) {
+	ctx := context.Background()
+	mc := docker.NewMockClient()
+	id, err := mc.CreateAndStartContainer(ctx, "aima-vllm-1", "vllm:latest", docker.ContainerOptions{
+		Labels: map[string]string{"aima.engine": "vllm", "aima.managed": "true"},
+	})
+	require.NoError(t, err)
+	mc.ExecResults["nvidia-smi -L"] = docker.MockExecResult{Stdout: "GPU 0\n", ExitCode: 0}
+
+	p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+	p.hybridProvider.dockerClient = mc
+
+	// Located by engine label when the service was started in another session.
+	result, err := p.Exec(ctx, "svc-vllm-model-abc", []string{"nvidia-smi", "-L"})
+	require.NoError(t, err)
+	assert.Equal(t, "GPU 0\n", result.Stdout)
+
+	p.hybridProvider.mu.Lock()
+	p.hybridProvider.serviceInfo["svc-test"] = &ServiceInfo{ServiceID: "svc-test", ProcessID: id}
+	p.hybridProvider.mu.Unlock()
+	result, err = p.Exec(ctx, "svc-test", []string{"nvidia-smi", "-L"})
+	require.NoError(t, err)
+	assert.Equal(t, "GPU 0\n", result.Stdout)
+
+	_, err = p.Exec(ctx, "not-a-service", []string{"ls"})
+	assert.Error(t, err, "unknown service")
+}
+
+func TestHybridServiceProvider_DiscoverContainers(t *testing.T) {
+	ctx := context.Background()
+	mc := docker.NewMockClient()
+	vllmID, err := mc.CreateAndStartContainer(ctx, "aima-vllm-1", "vllm:latest", docker.ContainerOptions{
+		Ports:  map[string]string{"8000": "8000"},
+		Labels: map[string]string{"aima.engine": "vllm", "aima.managed": "true"},
+	})
+	require.NoError(t, err)
+	_, err = mc.CreateContainer(ctx, "aima-asr-1", "asr:latest", docker.ContainerOptions{
+		Ports:  map[string]string{"8002": "8000", "8001": "8001"},
+		Labels: map[string]string{"aima.engine": "asr", "aima.managed": "true"},
+	})
+	require.NoError(t, err)
+	_, err = mc.CreateAndStartContainer(ctx, "nginx", "nginx:latest", docker.ContainerOptions{})
+	require.NoError(t, err)
+
+	p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+	p.hybridProvider.dockerClient = mc
+	p.hybridProvider.serviceInfo["svc-vllm-model-1"] = &ServiceInfo{ServiceID: "svc-vllm-model-1", ProcessID: vllmID}
+
+	containers, err := p.DiscoverContainers(ctx)
+	require.NoError(t, err)
+	require.Len(t, containers, 2, "unmanaged containers are skipped")
+
+	assert.Equal(t, "aima-asr-1", containers[0].Name)
+	assert.Equal(t, "asr", containers[0].Engine)
+	assert.Equal(t, 8001, containers[0].Port)
+	assert.Equal(t, "created", containers[0].Status)
+	assert.Empty(t, containers[0].ServiceID)
+
+	assert.Equal(t, "aima-vllm-1", containers[1].Name)
+	assert.Equal(t, 8000, containers[1].Port)
+	assert.Equal(t, "running", containers[1].Status)
+	assert.Equal(t, "svc-vllm-model-1", containers[1].ServiceID)
+}
+
+func TestHybridServiceProvider_Describe(t *testing.T) {
+	ctx := context.Background()
+	mc := docker.NewMockClient()
+	id, err := mc.CreateAndStartContainer(ctx, "aima-vllm-1", "vllm:latest", docker.ContainerOptions{
+		Ports:  map[string]string{"8000": "8000"},
+		Labels: map[string]string{"aima.engine": "vllm", "aima.managed": "true"},
+	})
+	require.NoError(t, err)
+
+	services := service.NewMemoryStore()
+	require.NoError(t, services.Create(ctx, &service.ModelService{
+		ID:        "svc-vllm-model-1",
+		Name:      "vllm-qwen",
+		ModelID:   "model-1",
+		Status:    service.ServiceStatusRunning,
+		Replicas:  1,
+		Endpoints: []string{"http://localhost:8000"},
+		Config:    map[string]any{"engine_type": "vllm", "port": float64(8000)},
+	}))
+	p := NewHybridServiceProvider(newMockModelStore(), services)
+	p.hybridProvider.dockerClient = mc
+	p.hybridProvider.serviceInfo["svc-vllm-model-1"] = &ServiceInfo{ServiceID: "svc-vllm-model-1", ProcessID: id}
+
+	result, err := service.NewDescribeQuery(services, p).Execute(ctx, map[string]any{"service_id": "svc-vllm-model-1"})
+	require.NoError(t, err)
+	detail := result.(map[string]any)
+	assert.Equal(t, "model-1", detail["model_id"])
+	assert.Equal(t, "vllm", detail["engine"])
+	assert.Equal(t, 8000, detail["port"])
+	assert.Equal(t, "running", detail["status"])
+	assert.Equal(t, true, detail["running"])
+	assert.Equal(t, map[string]any{"container_id": id, "name": "aima-vllm-1", "status": "running"}, detail["container"])
+	assert.Contains(t, detail["logs"], "Mock logs for container "+id)
+
+	require.NoError(t, mc.StopContainer(ctx, id, 0))
+	result, err = service.NewDescribeQuery(services, p).Execute(ctx, map[string]any{"service_id": "svc-vllm-model-1"})
+	require.NoError(t, err)
+	assert.Equal(t, false, result.(map[string]any)["running"], "a stopped container is not running")
 }
 
 func TestHybridServiceProvider_IsRunning_EmptyProcessID(t *testing.T) {
@@ -1374,3 +1853,78 @@ func TestHybridServiceProvider_StartAsync_ModelNotFound(t *testing.T) {
 		t.Error("expected error when model not found")
 	}
 }
+
+func TestHybridServiceProvider_StartAsync_ServiceEnv(t *testing.T) {
+	ctx := context.Background()
+	models := newMockModelStore()
+	require.NoError(t, models.Create(ctx, &model.Model{ID: "model-qwen", Name: "qwen", Type: model.ModelTypeLLM}))
+	services := service.NewMemoryStore()
+	require.NoError(t, services.Create(ctx, &service.ModelService{
+		ID:     "svc-llamacpp-model-qwen",
+		Config: map[string]any{"env": map[string]any{"HF_TOKEN": "hf_abc", "OMP_NUM_THREADS": float64(4)}},
+	}))
+	require.NoError(t, services.Create(ctx, &service.ModelService{
+		ID:     "svc-vllm-model-qwen",
+		Config: map[string]any{"env": map[string]any{"LD_PRELOAD": "/tmp/evil.so"}},
+	}))
+
+	p := NewHybridServiceProvider(models, services)
+	var got []string
+	p.startEngine = func(ctx context.Context, engineType string, config map[string]any) (*engine.StartResult, error) {
+		got = engineEnv(config)
+		return &engine.StartResult{ProcessID: "container-123456789", Status: engine.EngineStatusRunning}, nil
+	}
+
+	require.NoError(t, p.StartAsync(ctx, "svc-llamacpp-model-qwen", false))
+	assert.Equal(t, []string{"HF_TOKEN=hf_abc", "OMP_NUM_THREADS=4"}, got)
+
+	err := p.StartAsync(ctx, "svc-vllm-model-qwen", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LD_PRELOAD")
+}
+
+func TestHybridServiceProvider_StartAsync_ConcurrentStartsCoalesce(t *testing.T) {
+	store := newMockModelStore()
+	require.NoError(t, store.Create(context.Background(), &model.Model{ID: "model-qwen", Name: "qwen", Type: model.ModelTypeLLM, Path: "/models/qwen"}))
+	p := NewHybridServiceProvider(store, service.NewMemoryStore())
+
+	mc := docker.NewMockClient()
+	var mu sync.Mutex
+	starts := 0
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	p.startEngine = func(ctx context.Context, engineType string, config map[string]any) (*engine.StartResult, error) {
+		mu.Lock()
+		starts++
+		if starts == 1 {
+			close(entered)
+		}
+		mu.Unlock()
+		<-release
+		id, err := mc.CreateAndStartContainer(ctx, "aima-"+engineType, "vllm/vllm-openai:latest", docker.ContainerOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &engine.StartResult{ProcessID: id, Status: engine.EngineStatusRunning}, nil
+	}
+
+	const callers = 5
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			errs <- p.StartAsync(context.Background(), "svc-vllm-model-qwen", true)
+		}()
+	}
+	<-entered
+	// Give the other callers time to join the in-flight start.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < callers; i++ {
+		require.NoError(t, <-errs)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, starts, "concurrent starts of one service should run once")
+	assert.Len(t, mc.Containers, 1)
+}
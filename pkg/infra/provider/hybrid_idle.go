@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+// StopReasonIdle is the service.stopped reason used when the idle monitor
+// stops an engine.
+const StopReasonIdle = "idle"
+
+// DefaultIdleCheckInterval is how often RunIdleMonitor sweeps for idle services.
+const DefaultIdleCheckInterval = time.Minute
+
+// idleMonitor tracks the last request per service so engines nobody is
+// using can be stopped to free memory.
+type idleMonitor struct {
+	timeout     time.Duration
+	autoStart   bool
+	now         func() time.Time
+	lastRequest map[string]time.Time
+	idleStopped map[string]bool
+
+	// stop and start default to the provider's Stop/Start; tests replace
+	// them to avoid touching Docker.
+	stop  func(ctx context.Context, serviceID string) error
+	start func(ctx context.Context, serviceID string) error
+}
+
+// SetIdleTimeout enables stopping running services that have not served a
+// request for timeout. When autoStart is set, a request for a model whose
+// service was stopped for being idle starts it again. A zero timeout
+// disables the monitor.
+func (p *HybridServiceProvider) SetIdleTimeout(timeout time.Duration, autoStart bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if timeout <= 0 {
+		p.idle = nil
+		return
+	}
+
+	p.idle = &idleMonitor{
+		timeout:     timeout,
+		autoStart:   autoStart,
+		now:         time.Now,
+		lastRequest: make(map[string]time.Time),
+		idleStopped: make(map[string]bool),
+		stop: func(ctx context.Context, serviceID string) error {
+			return p.Stop(ctx, serviceID, false)
+		},
+		start: p.Start,
+	}
+}
+
+// RecordActivity marks every service of modelID as just used. If one of them
+// was stopped by the idle monitor and auto-start is enabled, it is started
+// again before returning.
+func (p *HybridServiceProvider) RecordActivity(ctx context.Context, modelID string) error {
+	p.mu.Lock()
+	idle := p.idle
+	p.mu.Unlock()
+	if idle == nil {
+		return nil
+	}
+
+	services, _, err := p.serviceStore.List(ctx, service.ServiceFilter{ModelID: modelID})
+	if err != nil {
+		return fmt.Errorf("list services for model %s: %w", modelID, err)
+	}
+
+	for i := range services {
+		svc := &services[i]
+
+		p.mu.Lock()
+		idle.lastRequest[svc.ID] = idle.now()
+		restart := idle.autoStart && idle.idleStopped[svc.ID] && svc.Status == service.ServiceStatusStopped
+		if restart {
+			delete(idle.idleStopped, svc.ID)
+		}
+		p.mu.Unlock()
+
+		if !restart {
+			continue
+		}
+
+		slog.Info("restarting idle-stopped service", "service", svc.ID)
+		if err := idle.start(ctx, svc.ID); err != nil {
+			return fmt.Errorf("restart service %s: %w", svc.ID, err)
+		}
+		svc.Status = service.ServiceStatusRunning
+		svc.UpdatedAt = time.Now().Unix()
+		if err := p.serviceStore.Update(ctx, svc); err != nil {
+			return fmt.Errorf("update service %s: %w", svc.ID, err)
+		}
+	}
+	return nil
+}
+
+// CheckIdle stops running services whose last request is older than the idle
+// timeout and returns their IDs. Services seen for the first time start their
+// idle clock now. Pinned services are never stopped.
+func (p *HybridServiceProvider) CheckIdle(ctx context.Context) ([]string, error) {
+	p.mu.Lock()
+	idle := p.idle
+	p.mu.Unlock()
+	if idle == nil {
+		return nil, nil
+	}
+
+	services, _, err := p.serviceStore.List(ctx, service.ServiceFilter{Status: service.ServiceStatusRunning})
+	if err != nil {
+		return nil, fmt.Errorf("list running services: %w", err)
+	}
+
+	var stopped []string
+	for i := range services {
+		svc := &services[i]
+		if svc.Pinned {
+			continue
+		}
+
+		p.mu.Lock()
+		now := idle.now()
+		last, seen := idle.lastRequest[svc.ID]
+		if !seen {
+			idle.lastRequest[svc.ID] = now
+		}
+		p.mu.Unlock()
+
+		if !seen || now.Sub(last) < idle.timeout {
+			continue
+		}
+
+		slog.Info("stopping idle service", "service", svc.ID, "idle_for", now.Sub(last))
+		if err := idle.stop(ctx, svc.ID); err != nil {
+			slog.Warn("failed to stop idle service", "service", svc.ID, "error", err)
+			continue
+		}
+
+		svc.Status = service.ServiceStatusStopped
+		svc.UpdatedAt = time.Now().Unix()
+		if err := p.serviceStore.Update(ctx, svc); err != nil {
+			slog.Warn("failed to update idle service", "service", svc.ID, "error", err)
+		}
+
+		p.mu.Lock()
+		idle.idleStopped[svc.ID] = true
+		delete(idle.lastRequest, svc.ID)
+		p.mu.Unlock()
+
+		p.publishStopped(svc, StopReasonIdle)
+		stopped = append(stopped, svc.ID)
+	}
+	return stopped, nil
+}
+
+// RunIdleMonitor calls CheckIdle every interval until ctx is done. A
+// non-positive interval uses DefaultIdleCheckInterval.
+func (p *HybridServiceProvider) RunIdleMonitor(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultIdleCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.CheckIdle(ctx); err != nil {
+				slog.Warn("idle check failed", "error", err)
+			}
+		}
+	}
+}
+
+func (p *HybridServiceProvider) publishStopped(svc *service.ModelService, reason string) {
+	p.hybridProvider.mu.RLock()
+	bus := p.hybridProvider.eventBus
+	p.hybridProvider.mu.RUnlock()
+	if bus == nil {
+		return
+	}
+	_ = bus.Publish(service.NewStoppedEvent(svc, reason))
+}
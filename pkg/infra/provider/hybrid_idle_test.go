@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/eventbus"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+// recordingBus captures published events synchronously.
+type recordingBus struct {
+	mu     sync.Mutex
+	events []unit.Event
+}
+
+func (b *recordingBus) Publish(event unit.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *recordingBus) PublishSync(event unit.Event) error {
+	return b.Publish(event)
+}
+
+func (b *recordingBus) Subscribe(eventbus.EventHandler, ...eventbus.EventFilter) (eventbus.SubscriptionID, error) {
+	return "", nil
+}
+
+func (b *recordingBus) Unsubscribe(eventbus.SubscriptionID) error { return nil }
+
+func (b *recordingBus) Close() error { return nil }
+
+type idleFixture struct {
+	provider *HybridServiceProvider
+	store    service.ServiceStore
+	bus      *recordingBus
+	now      time.Time
+	stopped  []string
+	started  []string
+}
+
+func newIdleFixture(t *testing.T, autoStart bool) *idleFixture {
+	t.Helper()
+
+	f := &idleFixture{
+		store: service.NewMemoryStore(),
+		bus:   &recordingBus{},
+		now:   time.Unix(1_700_000_000, 0),
+	}
+	require.NoError(t, f.store.Create(context.Background(), &service.ModelService{
+		ID:      "svc-vllm-model-1",
+		ModelID: "model-1",
+		Status:  service.ServiceStatusRunning,
+	}))
+
+	f.provider = NewHybridServiceProvider(newMockModelStore(), f.store)
+	f.provider.hybridProvider.SetEventBus(f.bus)
+	f.provider.SetIdleTimeout(10*time.Minute, autoStart)
+	f.provider.idle.now = func() time.Time { return f.now }
+	f.provider.idle.stop = func(ctx context.Context, serviceID string) error {
+		f.stopped = append(f.stopped, serviceID)
+		return nil
+	}
+	f.provider.idle.start = func(ctx context.Context, serviceID string) error {
+		f.started = append(f.started, serviceID)
+		return nil
+	}
+	return f
+}
+
+func (f *idleFixture) advance(d time.Duration) { f.now = f.now.Add(d) }
+
+func TestHybridServiceProvider_CheckIdle_StopsAfterTimeout(t *testing.T) {
+	f := newIdleFixture(t, false)
+	ctx := context.Background()
+
+	require.NoError(t, f.provider.RecordActivity(ctx, "model-1"))
+
+	f.advance(9 * time.Minute)
+	stopped, err := f.provider.CheckIdle(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, stopped, "service should still be within the idle timeout")
+
+	f.advance(time.Minute)
+	stopped, err = f.provider.CheckIdle(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-vllm-model-1"}, stopped)
+	assert.Equal(t, []string{"svc-vllm-model-1"}, f.stopped)
+
+	svc, err := f.store.Get(ctx, "svc-vllm-model-1")
+	require.NoError(t, err)
+	assert.Equal(t, service.ServiceStatusStopped, svc.Status)
+
+	require.Len(t, f.bus.events, 1)
+	assert.Equal(t, service.EventTypeStopped, f.bus.events[0].Type())
+	payload := f.bus.events[0].Payload().(map[string]any)
+	assert.Equal(t, StopReasonIdle, payload["reason"])
+}
+
+func TestHybridServiceProvider_CheckIdle_NoStopWhenActive(t *testing.T) {
+	f := newIdleFixture(t, false)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, f.provider.RecordActivity(ctx, "model-1"))
+		f.advance(5 * time.Minute)
+		stopped, err := f.provider.CheckIdle(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, stopped)
+	}
+
+	assert.Empty(t, f.stopped)
+	assert.Empty(t, f.bus.events)
+}
+
+func TestHybridServiceProvider_CheckIdle_TracksUnseenServices(t *testing.T) {
+	f := newIdleFixture(t, false)
+	ctx := context.Background()
+
+	// The first sweep starts the idle clock for a service that never served
+	// a request instead of stopping it immediately.
+	stopped, err := f.provider.CheckIdle(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, stopped)
+
+	f.advance(10 * time.Minute)
+	stopped, err = f.provider.CheckIdle(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-vllm-model-1"}, stopped)
+}
+
+func TestHybridServiceProvider_CheckIdle_SkipsPinned(t *testing.T) {
+	f := newIdleFixture(t, false)
+	ctx := context.Background()
+	require.NoError(t, f.store.Create(ctx, &service.ModelService{
+		ID:      "svc-vllm-model-2",
+		ModelID: "model-2",
+		Status:  service.ServiceStatusRunning,
+		Pinned:  true,
+	}))
+
+	_, err := f.provider.CheckIdle(ctx)
+	require.NoError(t, err)
+
+	f.advance(30 * time.Minute)
+	stopped, err := f.provider.CheckIdle(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc-vllm-model-1"}, stopped)
+
+	svc, err := f.store.Get(ctx, "svc-vllm-model-2")
+	require.NoError(t, err)
+	assert.Equal(t, service.ServiceStatusRunning, svc.Status)
+}
+
+func TestHybridServiceProvider_RecordActivity_RestartsIdleStopped(t *testing.T) {
+	tests := []struct {
+		name        string
+		autoStart   bool
+		wantStarted []string
+		wantStatus  service.ServiceStatus
+	}{
+		{name: "auto-start enabled", autoStart: true, wantStarted: []string{"svc-vllm-model-1"}, wantStatus: service.ServiceStatusRunning},
+		{name: "auto-start disabled", autoStart: false, wantStarted: nil, wantStatus: service.ServiceStatusStopped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newIdleFixture(t, tt.autoStart)
+			ctx := context.Background()
+
+			require.NoError(t, f.provider.RecordActivity(ctx, "model-1"))
+			f.advance(15 * time.Minute)
+			_, err := f.provider.CheckIdle(ctx)
+			require.NoError(t, err)
+
+			require.NoError(t, f.provider.RecordActivity(ctx, "model-1"))
+			assert.Equal(t, tt.wantStarted, f.started)
+
+			svc, err := f.store.Get(ctx, "svc-vllm-model-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, svc.Status)
+		})
+	}
+}
+
+func TestHybridServiceProvider_IdleDisabled(t *testing.T) {
+	p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+
+	assert.NoError(t, p.RecordActivity(context.Background(), "model-1"))
+	stopped, err := p.CheckIdle(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, stopped)
+}
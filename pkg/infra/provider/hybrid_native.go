@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+)
+
+// nativeLogLines is how many lines of output are kept per native process.
+const nativeLogLines = 1000
+
+// logRing is an io.Writer that keeps the last complete lines written to it,
+// up to its size, plus any unterminated final line. Native
+// engine processes write their stdout and stderr here so failures can be
+// diagnosed the same way as container logs.
+type logRing struct {
+	mu      sync.Mutex
+	lines   []string
+	next    int
+	full    bool
+	partial []byte
+}
+
+func newLogRing(size int) *logRing {
+	return &logRing{lines: make([]string, size)}
+}
+
+func (r *logRing) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data := append(r.partial, b...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		r.add(strings.TrimSuffix(string(data[:i]), "\r"))
+		data = data[i+1:]
+	}
+	r.partial = append([]byte(nil), data...)
+	return len(b), nil
+}
+
+func (r *logRing) add(line string) {
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % len(r.lines)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Tail returns the last n lines, oldest first, including an unterminated
+// final line. A non-positive n returns every kept line.
+func (r *logRing) Tail(n int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var lines []string
+	if r.full {
+		lines = append(lines, r.lines[r.next:]...)
+	}
+	lines = append(lines, r.lines[:r.next]...)
+	if len(r.partial) > 0 {
+		lines = append(lines, string(r.partial))
+	}
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// nativeRun tracks the output and exit of a native engine process. Runs are
+// keyed by PID, the ProcessID reported for native engines, and kept after
+// the process exits so its output can still be read, until the engine is
+// stopped or started again.
+type nativeRun struct {
+	engine string
+	logs   *logRing
+	done   chan struct{}
+}
+
+func (r *nativeRun) exited() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *HybridEngineProvider) nativeRun(pid string) (*nativeRun, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	run, ok := p.nativeRuns[pid]
+	return run, ok
+}
+
+// nativeLogs returns the last tail lines of output from the native process
+// pid, and false if no such process was started.
+func (p *HybridEngineProvider) nativeLogs(pid string, tail int) (string, bool) {
+	run, ok := p.nativeRun(pid)
+	if !ok {
+		return "", false
+	}
+	return run.logs.Tail(tail), true
+}
+
+// dropNativeRuns forgets the runs of engineType. The caller must hold p.mu.
+func (p *HybridEngineProvider) dropNativeRuns(engineType string) {
+	for pid, run := range p.nativeRuns {
+		if run.engine == engineType {
+			delete(p.nativeRuns, pid)
+		}
+	}
+}
+
+// waitForNativeHealth polls the health endpoint of the native process pid
+// until it responds, the process exits or timeout passes.
+func (p *HybridEngineProvider) waitForNativeHealth(ctx context.Context, engineType, pid string, port int, healthPath string, timeout time.Duration) error {
+	run, ok := p.nativeRun(pid)
+	if !ok {
+		return fmt.Errorf("native process %s not found", pid)
+	}
+	return p.pollHealth(ctx, engineType, port, healthPath, timeout, func() error {
+		if run.exited() {
+			return fmt.Errorf("process %s exited", pid)
+		}
+		return nil
+	})
+}
+
+// startNativeAndWait starts engineType as a native process and, unless
+// async is set, waits for it to become healthy. A process that exits or
+// never becomes healthy is stopped, and the error carries its last output.
+func (p *HybridEngineProvider) startNativeAndWait(ctx context.Context, engineType, modelPath string, port int, useGPU bool, config map[string]any, startupCfg StartupConfig, async bool) (*engine.StartResult, error) {
+	result, err := p.startNative(ctx, engineType, modelPath, port, useGPU, config)
+	if err != nil || async {
+		return result, err
+	}
+
+	if err := p.waitForNativeHealth(ctx, engineType, result.ProcessID, port, startupCfg.HealthCheckURL, startupCfg.StartupTimeout); err != nil {
+		logs, _ := p.nativeLogs(result.ProcessID, 50)
+		_, _ = p.Stop(context.Background(), engineType, true, 10)
+		p.mu.Lock()
+		p.dropNativeRuns(engineType)
+		p.mu.Unlock()
+		p.publishProgress(engineType, "failed", err.Error(), -1)
+		if logs != "" {
+			slog.Warn("native process failed, last logs", "pid", result.ProcessID, "logs", logs)
+			return nil, fmt.Errorf("native %s: %w\nlast output:\n%s", engineType, err, logs)
+		}
+		return nil, fmt.Errorf("native %s: %w", engineType, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/catalog"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+func TestLogRing(t *testing.T) {
+	r := newLogRing(3)
+	_, _ = r.Write([]byte("one\ntw"))
+	_, _ = r.Write([]byte("o\r\nthree\n"))
+	assert.Equal(t, "one\ntwo\nthree", r.Tail(0))
+
+	_, _ = r.Write([]byte("four\nfive"))
+	assert.Equal(t, "two\nthree\nfour\nfive", r.Tail(0), "oldest lines are dropped and the partial line is kept")
+	assert.Equal(t, "four\nfive", r.Tail(2))
+}
+
+// newNativeTestProvider returns a service provider whose "tts" engine runs
+// script as its native binary, with health polled quickly.
+func newNativeTestProvider(t *testing.T, script string) *HybridServiceProvider {
+	t.Helper()
+	binDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "fake-tts-server"), []byte("#!/bin/sh\n"+script), 0755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	sp := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+	sp.hybridProvider.engineAssets = map[string]catalog.EngineAsset{
+		"tts": {Type: "tts", BaseCommand: []string{"fake-tts-server"}},
+	}
+	sp.hybridProvider.healthInterval = 10 * time.Millisecond
+	return sp
+}
+
+// freePort returns a port with nothing listening on it.
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+	return port
+}
+
+func TestHybridServiceProvider_GetLogs_NativeProcess(t *testing.T) {
+	sp := newNativeTestProvider(t, "echo loading model\necho 'fatal: no GPU found' >&2\nexit 1\n")
+	p := sp.hybridProvider
+
+	result, err := p.startNative(context.Background(), "tts", "", freePort(t), false, map[string]any{})
+	require.NoError(t, err)
+	run, ok := p.nativeRun(result.ProcessID)
+	require.True(t, ok)
+	select {
+	case <-run.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("native process did not exit")
+	}
+
+	p.mu.Lock()
+	p.serviceInfo["svc-tts-m1"] = &ServiceInfo{ServiceID: "svc-tts-m1", Engine: "tts", ProcessID: result.ProcessID}
+	p.mu.Unlock()
+
+	logs, err := sp.GetLogs(context.Background(), "svc-tts-m1", 100)
+	require.NoError(t, err)
+	assert.Contains(t, logs, "loading model")
+	assert.Contains(t, logs, "fatal: no GPU found")
+}
+
+func TestHybridEngineProvider_StartNativeAndWait(t *testing.T) {
+	cfg := StartupConfig{HealthCheckURL: "/health", StartupTimeout: 5 * time.Second}
+
+	t.Run("process exits before healthy", func(t *testing.T) {
+		p := newNativeTestProvider(t, "echo 'fatal: no GPU found' >&2\nexit 1\n").hybridProvider
+
+		start := time.Now()
+		_, err := p.startNativeAndWait(context.Background(), "tts", "", freePort(t), false, map[string]any{}, cfg, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exited")
+		assert.Contains(t, err.Error(), "fatal: no GPU found")
+		assert.Less(t, time.Since(start), cfg.StartupTimeout, "an exited process should not wait for the timeout")
+
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		assert.Empty(t, p.nativeProcesses)
+		assert.Empty(t, p.nativeRuns)
+	})
+
+	t.Run("healthy", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/health" {
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+		port, err := strconv.Atoi(srv.URL[len("http://127.0.0.1:"):])
+		require.NoError(t, err)
+
+		p := newNativeTestProvider(t, "echo ready\nexec sleep 30\n").hybridProvider
+		result, err := p.startNativeAndWait(context.Background(), "tts", "", port, false, map[string]any{}, cfg, false)
+		require.NoError(t, err)
+		t.Cleanup(func() { _, _ = p.Stop(context.Background(), "tts", true, 1) })
+
+		_, ok := p.nativeRun(result.ProcessID)
+		assert.True(t, ok)
+	})
+
+	t.Run("async skips health", func(t *testing.T) {
+		p := newNativeTestProvider(t, "exit 1\n").hybridProvider
+		result, err := p.startNativeAndWait(context.Background(), "tts", "", freePort(t), false, map[string]any{}, cfg, true)
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.ProcessID)
+	})
+}
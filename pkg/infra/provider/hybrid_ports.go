@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+// Default range of host ports assigned to services.
+const (
+	DefaultPortMin = 8000
+	DefaultPortMax = 8999
+)
+
+// ErrNoFreePort is returned by Create when every port in the configured range
+// is assigned to a service or in use by another process.
+var ErrNoFreePort = errors.New("no free port in range")
+
+// SetPortRange limits the host ports assigned to new services to
+// [min, max]. Assignment continues after the last assigned port if it lies
+// in the range, and from min otherwise.
+func (p *HybridServiceProvider) SetPortRange(min, max int) error {
+	if min < 1 || max > 65535 || min > max {
+		return fmt.Errorf("invalid port range %d-%d", min, max)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.portMin, p.portMax = min, max
+	if p.portCounter < min || p.portCounter > max {
+		p.portCounter = min
+	}
+	return nil
+}
+
+// allocatePort returns the next port in the range that is neither assigned
+// to a stored service nor in use on the host, wrapping around at the end of
+// the range.
+func (p *HybridServiceProvider) allocatePort(ctx context.Context) (int, error) {
+	return p.scanPort(ctx, true)
+}
+
+// scanPort finds the port allocatePort would return. Unless claim is set,
+// the next scan starts from the same place.
+func (p *HybridServiceProvider) scanPort(ctx context.Context, claim bool) (int, error) {
+	assigned := p.assignedPorts(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	next := p.portCounter
+	for i := 0; i <= p.portMax-p.portMin; i++ {
+		port := next
+		if port < p.portMin || port > p.portMax {
+			port = p.portMin
+		}
+		next = port + 1
+		if assigned[port] || !portFree(port) {
+			continue
+		}
+		if claim {
+			p.portCounter = next
+		}
+		return port, nil
+	}
+	if claim {
+		p.portCounter = next
+	}
+	return 0, fmt.Errorf("%w %d-%d", ErrNoFreePort, p.portMin, p.portMax)
+}
+
+// assignedPorts returns the ports recorded in stored service configs. They
+// stay reserved while the service is stopped.
+func (p *HybridServiceProvider) assignedPorts(ctx context.Context) map[int]bool {
+	ports := make(map[int]bool)
+	services, _, err := p.serviceStore.List(ctx, service.ServiceFilter{})
+	if err != nil {
+		return ports
+	}
+	for _, svc := range services {
+		if port, ok := servicePort(svc.Config); ok {
+			ports[port] = true
+		}
+	}
+	return ports
+}
+
+// servicePort reads the port from a service config, which holds an int when
+// created in this process and a float64 after a JSON round trip.
+func servicePort(config map[string]any) (int, bool) {
+	switch v := config["port"].(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// portFree reports whether port can be listened on, on all interfaces as
+// Docker publishes it.
+func portFree(port int) bool {
+	l, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+// bindPort listens on a free port until the test ends and returns it.
+func bindPort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestHybridServiceProvider_SetPortRange(t *testing.T) {
+	p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+
+	for _, r := range [][2]int{{0, 10}, {9000, 8000}, {60000, 70000}} {
+		assert.Error(t, p.SetPortRange(r[0], r[1]), "range %v", r)
+	}
+	require.NoError(t, p.SetPortRange(20000, 20010))
+	assert.Equal(t, 20000, p.portCounter, "a counter outside the new range restarts at min")
+}
+
+func TestHybridServiceProvider_Create_SkipsBoundPort(t *testing.T) {
+	bound := bindPort(t)
+	store := newMockModelStore()
+	store.addModel(&model.Model{ID: "model-llm-001", Name: "my-llm", Type: model.ModelTypeLLM, Path: "/models/my-llm"})
+	p := NewHybridServiceProvider(store, service.NewMemoryStore())
+	require.NoError(t, p.SetPortRange(bound, bound+20))
+
+	svc, err := p.Create(context.Background(), "model-llm-001", service.ResourceClassMedium, 1, false)
+	require.NoError(t, err)
+	port := svc.Config["port"].(int)
+	assert.NotEqual(t, bound, port, "a port in use on the host must be skipped")
+	assert.True(t, port > bound && port <= bound+20, "port %d outside range", port)
+}
+
+func TestHybridServiceProvider_AllocatePort(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("skips ports of stored services", func(t *testing.T) {
+		free := freePort(t)
+		services := service.NewMemoryStore()
+		require.NoError(t, services.Create(ctx, &service.ModelService{ID: "svc-vllm-a", Config: map[string]any{"port": float64(free)}}))
+		p := NewHybridServiceProvider(newMockModelStore(), services)
+		require.NoError(t, p.SetPortRange(free, free+20))
+
+		port, err := p.allocatePort(ctx)
+		require.NoError(t, err)
+		assert.NotEqual(t, free, port)
+	})
+
+	t.Run("wraps around", func(t *testing.T) {
+		free := freePort(t)
+		p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+		require.NoError(t, p.SetPortRange(free, free))
+
+		for i := 0; i < 3; i++ {
+			port, err := p.allocatePort(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, free, port)
+		}
+	})
+
+	t.Run("errors when exhausted", func(t *testing.T) {
+		bound := bindPort(t)
+		p := NewHybridServiceProvider(newMockModelStore(), service.NewMemoryStore())
+		require.NoError(t, p.SetPortRange(bound, bound))
+
+		_, err := p.allocatePort(ctx)
+		assert.ErrorIs(t, err, ErrNoFreePort)
+	})
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/docker"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+// paramSizePattern matches the parameter count in model names such as
+// "Qwen2.5-7B-Instruct" or "qwen3:0.6b".
+var paramSizePattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9.])(\d+(?:\.\d+)?)b(?:$|[^a-z0-9])`)
+
+// modelParamsB estimates the parameter count of m in billions, from its name
+// or else from its size on disk assuming 16-bit weights. It returns 0 when
+// neither is known.
+func modelParamsB(m *model.Model) float64 {
+	if match := paramSizePattern.FindStringSubmatch(m.Name); match != nil {
+		if b, err := strconv.ParseFloat(match[1], 64); err == nil && b > 0 {
+			return b
+		}
+	}
+	if m.Size > 0 {
+		return float64(m.Size) / 2e9
+	}
+	return 0
+}
+
+// recommendedConfig suggests a service config for running m on engineType,
+// using keys Start understands. Settings that depend on the model size are
+// omitted when the size is unknown.
+func (p *HybridServiceProvider) recommendedConfig(ctx context.Context, engineType string, m *model.Model) (map[string]any, error) {
+	config := make(map[string]any)
+	if port, err := p.scanPort(ctx, false); err == nil {
+		config["port"] = port
+	}
+
+	limits := p.hybridProvider.resourceLimitsFor(engineType)
+	paramsB := modelParamsB(m)
+	weightsGB := paramsB * 2
+
+	if engineType != "vllm" {
+		memGB := 0
+		if mem, err := docker.ParseMemory(limits.Memory); err == nil && mem > 0 {
+			memGB = int(math.Ceil(float64(mem) / (1 << 30)))
+		}
+		if weightsGB > 0 {
+			memGB = max(memGB, int(math.Ceil(weightsGB*1.2))+1)
+		}
+		if memGB > 0 {
+			config["memory"] = fmt.Sprintf("%dg", memGB)
+		}
+		if limits.CPU > 0 {
+			config["cpu"] = limits.CPU
+		}
+		return config, nil
+	}
+
+	if paramsB == 0 {
+		util, err := p.resolveGPUMemoryUtilization(nil)
+		if err != nil {
+			return nil, err
+		}
+		config["gpu_memory_utilization"] = util
+		return config, nil
+	}
+
+	// Weights plus a fifth for activations and a few GB of KV cache,
+	// as a share of the GPU memory the engine may use.
+	var util float64
+	if gpuMem, err := docker.ParseMemory(limits.GPUMemory); err == nil && gpuMem > 0 {
+		util = (weightsGB*1.2 + 4) / (float64(gpuMem) / (1 << 30))
+		util = math.Round(math.Min(math.Max(util, 0.3), 0.9)*100) / 100
+	}
+
+	switch {
+	case paramsB <= 8:
+		config["max_model_len"] = 32768
+		config["cpu"] = 4.0
+		if util == 0 {
+			util = 0.5
+		}
+	case paramsB <= 32:
+		config["max_model_len"] = 16384
+		config["cpu"] = 8.0
+		if util == 0 {
+			util = 0.7
+		}
+	default:
+		config["max_model_len"] = 8192
+		config["cpu"] = 16.0
+		if util == 0 {
+			util = 0.9
+		}
+	}
+	config["gpu_memory_utilization"] = util
+	config["memory"] = fmt.Sprintf("%dg", int(math.Ceil(weightsGB*1.2))+8)
+	return config, nil
+}
+
+// applyResourceOverrides replaces the memory and CPU of limits with the
+// "memory" and "cpu" of a start or service config, if set.
+func applyResourceOverrides(limits ResourceLimits, config map[string]any) (ResourceLimits, error) {
+	if v, ok := config["memory"]; ok {
+		mem, ok := v.(string)
+		if !ok {
+			return limits, fmt.Errorf("invalid memory type %T", v)
+		}
+		if _, err := docker.ParseMemory(mem); err != nil {
+			return limits, fmt.Errorf("invalid memory: %w", err)
+		}
+		limits.Memory = mem
+	}
+	if v, ok := config["cpu"]; ok {
+		var cpu float64
+		switch val := v.(type) {
+		case float64:
+			cpu = val
+		case int:
+			cpu = float64(val)
+		case int64:
+			cpu = float64(val)
+		default:
+			return limits, fmt.Errorf("invalid cpu type %T", v)
+		}
+		if cpu < 0 {
+			return limits, fmt.Errorf("cpu must not be negative, got %v", cpu)
+		}
+		limits.CPU = cpu
+	}
+	return limits, nil
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/docker"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+func TestModelParamsB(t *testing.T) {
+	tests := []struct {
+		model *model.Model
+		want  float64
+	}{
+		{&model.Model{Name: "Qwen2.5-7B-Instruct"}, 7},
+		{&model.Model{Name: "qwen3:0.6b"}, 0.6},
+		{&model.Model{Name: "llama-3-70b", Size: 1}, 70},
+		{&model.Model{Name: "bge-m3", Size: 4e9}, 2},
+		{&model.Model{Name: "kokoro-tts"}, 0},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, modelParamsB(tt.model), 1e-9, tt.model.Name)
+	}
+}
+
+func TestHybridServiceProvider_GetRecommendation_ConfigScalesWithModelSize(t *testing.T) {
+	ctx := context.Background()
+	store := newMockModelStore()
+	store.addModel(&model.Model{ID: "small", Name: "Qwen2.5-7B-Instruct", Type: model.ModelTypeLLM})
+	store.addModel(&model.Model{ID: "medium", Name: "qwen-coder", Type: model.ModelTypeLLM, Size: 28e9})
+	store.addModel(&model.Model{ID: "large", Name: "Llama-3-70B", Type: model.ModelTypeLLM})
+
+	p := NewHybridServiceProvider(store, service.NewMemoryStore())
+
+	configs := make([]map[string]any, 0, 3)
+	for _, id := range []string{"small", "medium", "large"} {
+		rec, err := p.GetRecommendation(ctx, id, "")
+		require.NoError(t, err)
+		require.NotNil(t, rec.Config, id)
+
+		port, ok := rec.Config["port"].(int)
+		require.True(t, ok, "%s: expected a port", id)
+		assert.GreaterOrEqual(t, port, DefaultPortMin)
+		assert.LessOrEqual(t, port, DefaultPortMax)
+
+		// The suggestion must be valid input for Start.
+		_, err = parseGPUMemoryUtilization(rec.Config["gpu_memory_utilization"])
+		require.NoError(t, err, id)
+		_, err = parseMaxModelLen(rec.Config["max_model_len"])
+		require.NoError(t, err, id)
+		_, err = applyResourceOverrides(ResourceLimits{}, rec.Config)
+		require.NoError(t, err, id)
+
+		configs = append(configs, rec.Config)
+	}
+
+	for i := 1; i < len(configs); i++ {
+		smaller, larger := configs[i-1], configs[i]
+		assert.Greater(t, larger["gpu_memory_utilization"], smaller["gpu_memory_utilization"])
+		assert.Greater(t, larger["cpu"], smaller["cpu"])
+		assert.Less(t, larger["max_model_len"], smaller["max_model_len"])
+
+		smallMem, err := docker.ParseMemory(smaller["memory"].(string))
+		require.NoError(t, err)
+		largeMem, err := docker.ParseMemory(larger["memory"].(string))
+		require.NoError(t, err)
+		assert.Greater(t, largeMem, smallMem)
+	}
+
+	// Recommending does not claim the port.
+	rec, err := p.GetRecommendation(ctx, "small", "")
+	require.NoError(t, err)
+	assert.Equal(t, configs[0]["port"], rec.Config["port"])
+}
+
+func TestHybridServiceProvider_GetRecommendation_UnknownSize(t *testing.T) {
+	store := newMockModelStore()
+	store.addModel(&model.Model{ID: "llm", Name: "mystery", Type: model.ModelTypeLLM})
+	store.addModel(&model.Model{ID: "tts", Name: "kokoro-tts", Type: model.ModelTypeTTS})
+	p := NewHybridServiceProvider(store, service.NewMemoryStore())
+
+	rec, err := p.GetRecommendation(context.Background(), "llm", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultGPUMemoryUtilization, rec.Config["gpu_memory_utilization"])
+	assert.NotContains(t, rec.Config, "max_model_len")
+	assert.NotContains(t, rec.Config, "memory")
+
+	rec, err = p.GetRecommendation(context.Background(), "tts", "")
+	require.NoError(t, err)
+	assert.Equal(t, "4g", rec.Config["memory"])
+	assert.Equal(t, 2.0, rec.Config["cpu"])
+}
+
+func TestHybridServiceProvider_StartAsync_AppliesResourceConfig(t *testing.T) {
+	ctx := context.Background()
+	models := newMockModelStore()
+	require.NoError(t, models.Create(ctx, &model.Model{ID: "model-a", Name: "a", Type: model.ModelTypeLLM}))
+	store := service.NewMemoryStore()
+	require.NoError(t, store.Create(ctx, &service.ModelService{
+		ID:     "svc-vllm-model-a",
+		Config: map[string]any{"port": 8001, "memory": "24g", "cpu": 4.0},
+	}))
+
+	p := NewHybridServiceProvider(models, store)
+	var got map[string]any
+	p.startEngine = func(ctx context.Context, engineType string, config map[string]any) (*engine.StartResult, error) {
+		got = config
+		return &engine.StartResult{ProcessID: "1234", Status: engine.EngineStatusRunning}, nil
+	}
+	require.NoError(t, p.Start(ctx, "svc-vllm-model-a"))
+	assert.Equal(t, "24g", got["memory"])
+	assert.Equal(t, 4.0, got["cpu"])
+
+	limits, err := applyResourceOverrides(p.hybridProvider.resourceLimitsFor("vllm"), got)
+	require.NoError(t, err)
+	assert.Equal(t, "24g", limits.Memory)
+	assert.Equal(t, 4.0, limits.CPU)
+
+	require.NoError(t, store.Update(ctx, &service.ModelService{ID: "svc-vllm-model-a", Config: map[string]any{"memory": "lots"}}))
+	require.Error(t, p.Start(ctx, "svc-vllm-model-a"))
+}
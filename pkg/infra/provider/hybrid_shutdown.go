@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+// StopReasonShutdown is the service.stopped reason used when StopAllOrdered
+// stops an engine.
+const StopReasonShutdown = "shutdown"
+
+// recordStarted moves serviceID to the end of the startup order.
+func (p *HybridServiceProvider) recordStarted(serviceID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.startupOrder = slices.DeleteFunc(p.startupOrder, func(id string) bool { return id == serviceID })
+	p.startupOrder = append(p.startupOrder, serviceID)
+}
+
+// recordStopped drops serviceID from the startup order.
+func (p *HybridServiceProvider) recordStopped(serviceID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.startupOrder = slices.DeleteFunc(p.startupOrder, func(id string) bool { return id == serviceID })
+}
+
+// StopAllOrdered stops every service this provider started, in reverse
+// startup order, so services that depend on engines started before them,
+// such as a gateway in front of backend engines, go down first. A failed
+// stop is logged and the remaining services are still stopped; the failures
+// are returned together. Services started by another process are not
+// tracked and left alone.
+func (p *HybridServiceProvider) StopAllOrdered(ctx context.Context) error {
+	p.mu.Lock()
+	order := slices.Clone(p.startupOrder)
+	p.mu.Unlock()
+
+	var errs []error
+	for i := len(order) - 1; i >= 0; i-- {
+		serviceID := order[i]
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		slog.Info("stopping service for shutdown", "service", serviceID)
+		if err := p.stopService(ctx, serviceID, false); err != nil {
+			slog.Warn("failed to stop service for shutdown", "service", serviceID, "error", err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", serviceID, err))
+			continue
+		}
+		p.recordStopped(serviceID)
+
+		svc, err := p.serviceStore.Get(ctx, serviceID)
+		if err != nil {
+			continue
+		}
+		svc.Status = service.ServiceStatusStopped
+		svc.UpdatedAt = time.Now().Unix()
+		if err := p.serviceStore.Update(ctx, svc); err != nil {
+			slog.Warn("failed to update stopped service", "service", serviceID, "error", err)
+		}
+		p.publishStopped(svc, StopReasonShutdown)
+	}
+	return errors.Join(errs...)
+}
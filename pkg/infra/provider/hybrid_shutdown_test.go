@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+func newShutdownProvider(t *testing.T, ids ...string) (*HybridServiceProvider, *[]string) {
+	t.Helper()
+	store := service.NewMemoryStore()
+	for _, id := range ids {
+		require.NoError(t, store.Create(context.Background(), &service.ModelService{ID: id, Status: service.ServiceStatusRunning}))
+	}
+
+	p := NewHybridServiceProvider(newMockModelStore(), store)
+	var stopped []string
+	p.stopService = func(ctx context.Context, serviceID string, force bool) error {
+		stopped = append(stopped, serviceID)
+		return nil
+	}
+	return p, &stopped
+}
+
+func TestHybridServiceProvider_StopAllOrdered_ReverseStartOrder(t *testing.T) {
+	p, stopped := newShutdownProvider(t, "svc-vllm-backend", "svc-whisper-asr", "svc-gateway-front")
+	ctx := context.Background()
+
+	p.recordStarted("svc-vllm-backend")
+	p.recordStarted("svc-whisper-asr")
+	p.recordStarted("svc-gateway-front")
+
+	require.NoError(t, p.StopAllOrdered(ctx))
+	assert.Equal(t, []string{"svc-gateway-front", "svc-whisper-asr", "svc-vllm-backend"}, *stopped)
+	assert.Empty(t, p.startupOrder)
+
+	svc, err := p.serviceStore.Get(ctx, "svc-vllm-backend")
+	require.NoError(t, err)
+	assert.Equal(t, service.ServiceStatusStopped, svc.Status)
+}
+
+func TestHybridServiceProvider_StopAllOrdered_RestartMovesToEnd(t *testing.T) {
+	p, stopped := newShutdownProvider(t)
+
+	p.recordStarted("svc-vllm-a")
+	p.recordStarted("svc-vllm-b")
+	p.recordStarted("svc-vllm-a")
+	p.recordStarted("svc-vllm-c")
+	p.recordStopped("svc-vllm-c")
+
+	require.NoError(t, p.StopAllOrdered(context.Background()))
+	assert.Equal(t, []string{"svc-vllm-a", "svc-vllm-b"}, *stopped)
+}
+
+func TestHybridServiceProvider_StopAllOrdered_ContinuesAfterFailure(t *testing.T) {
+	p, _ := newShutdownProvider(t)
+	var attempted []string
+	p.stopService = func(ctx context.Context, serviceID string, force bool) error {
+		attempted = append(attempted, serviceID)
+		if serviceID == "svc-vllm-b" {
+			return errors.New("container stuck")
+		}
+		return nil
+	}
+
+	p.recordStarted("svc-vllm-a")
+	p.recordStarted("svc-vllm-b")
+	p.recordStarted("svc-vllm-c")
+
+	err := p.StopAllOrdered(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "svc-vllm-b")
+	assert.Equal(t, []string{"svc-vllm-c", "svc-vllm-b", "svc-vllm-a"}, attempted)
+	assert.Equal(t, []string{"svc-vllm-b"}, p.startupOrder, "a service that failed to stop stays tracked")
+}
@@ -23,12 +23,22 @@ const maxResponseSize = 10 * 1024 * 1024 // 10 MB
 // Compile-time interface satisfaction check.
 var _ inference.InferenceProvider = (*ProxyInferenceProvider)(nil)
 
+// RequestTracker tracks the requests routed to each service so stopping a
+// service can drain them. HybridServiceProvider implements it.
+type RequestTracker interface {
+	// Draining reports whether serviceID is being stopped.
+	Draining(serviceID string) bool
+	// BeginRequest tracks a request to serviceID until release is called.
+	BeginRequest(serviceID string) (release func(), err error)
+}
+
 // ProxyInferenceProvider implements inference.InferenceProvider by forwarding
 // requests to running AIMA services (vLLM, Ollama, etc.).
 type ProxyInferenceProvider struct {
 	serviceStore service.ServiceStore
 	modelStore   model.ModelStore
 	httpClient   *http.Client
+	requests     RequestTracker
 }
 
 // NewProxyInferenceProvider creates a provider that proxies inference requests
@@ -41,14 +51,23 @@ func NewProxyInferenceProvider(serviceStore service.ServiceStore, modelStore mod
 	}
 }
 
+// SetRequestTracker makes the provider skip services that are draining and
+// track every request it proxies with requests until the response is read.
+func (p *ProxyInferenceProvider) SetRequestTracker(requests RequestTracker) {
+	p.requests = requests
+}
+
 // resolveEndpoint finds a running service for the given model name and returns
 // its endpoint URL. It searches models by name, then finds running services
-// referencing that model's ID.
-func (p *ProxyInferenceProvider) resolveEndpoint(ctx context.Context, modelName string) (string, error) {
+// referencing that model's ID, skipping those that are draining. With a
+// session ID the endpoint is picked from every replica by selectEndpoint;
+// without one the first endpoint is used. The request stays tracked against
+// the chosen service until release is called.
+func (p *ProxyInferenceProvider) resolveEndpoint(ctx context.Context, modelName, sessionID string) (endpoint string, release func(), err error) {
 	// First, try to find the model by name to get its ID
 	models, _, err := p.modelStore.List(ctx, model.ModelFilter{})
 	if err != nil {
-		return "", fmt.Errorf("list models: %w", err)
+		return "", nil, fmt.Errorf("list models: %w", err)
 	}
 
 	var modelID string
@@ -71,19 +90,42 @@ func (p *ProxyInferenceProvider) resolveEndpoint(ctx context.Context, modelName
 		ModelID: modelID,
 	})
 	if err != nil {
-		return "", fmt.Errorf("list services: %w", err)
+		return "", nil, fmt.Errorf("list services: %w", err)
 	}
 
 	if len(svcs) == 0 {
-		return "", fmt.Errorf("no running services found for model %q", modelName)
+		return "", nil, fmt.Errorf("no running services found for model %q", modelName)
 	}
 
-	svc := svcs[0]
-	if len(svc.Endpoints) == 0 {
-		return "", fmt.Errorf("service %q has no endpoints", svc.ID)
+	var endpoints []string
+	owners := make(map[string]string)
+	draining := 0
+	for _, svc := range svcs {
+		if p.requests != nil && p.requests.Draining(svc.ID) {
+			draining++
+			continue
+		}
+		for _, ep := range svc.Endpoints {
+			endpoints = append(endpoints, ep)
+			owners[ep] = svc.ID
+		}
+	}
+	if len(endpoints) == 0 {
+		if draining == len(svcs) {
+			return "", nil, fmt.Errorf("services for model %q: %w", modelName, ErrServiceDraining)
+		}
+		return "", nil, fmt.Errorf("service %q has no endpoints", svcs[0].ID)
 	}
 
-	return svc.Endpoints[0], nil
+	endpoint = selectEndpoint(endpoints, sessionID)
+	if p.requests == nil {
+		return endpoint, func() {}, nil
+	}
+	release, err = p.requests.BeginRequest(owners[endpoint])
+	if err != nil {
+		return "", nil, err
+	}
+	return endpoint, release, nil
 }
 
 // isOllamaEndpoint heuristically determines if an endpoint is Ollama (port 11434).
@@ -155,10 +197,11 @@ type ollamaChatResponse struct {
 
 // Chat sends a chat completion request to a running service.
 func (p *ProxyInferenceProvider) Chat(ctx context.Context, modelName string, messages []inference.Message, opts inference.ChatOptions) (*inference.ChatResponse, error) {
-	endpoint, err := p.resolveEndpoint(ctx, modelName)
+	endpoint, release, err := p.resolveEndpoint(ctx, modelName, opts.SessionID)
 	if err != nil {
 		return nil, fmt.Errorf("inference.Chat: %w", err)
 	}
+	defer release()
 
 	if isOllamaEndpoint(endpoint) {
 		return p.chatOllama(ctx, endpoint, modelName, messages, opts)
@@ -215,5 +215,13 @@ func (p *MultiEngineProvider) GetLogs(ctx context.Context, serviceID string, tai
 	return p.vllmProvider.GetLogs(ctx, serviceID, tail)
 }
 
+func (p *MultiEngineProvider) Exec(ctx context.Context, serviceID string, cmd []string) (*service.ExecResult, error) {
+	return p.vllmProvider.Exec(ctx, serviceID, cmd)
+}
+
+func (p *MultiEngineProvider) DiscoverContainers(ctx context.Context) ([]service.ManagedContainer, error) {
+	return p.vllmProvider.DiscoverContainers(ctx)
+}
+
 // Ensure MultiEngineProvider implements the interface
 var _ service.ServiceProvider = (*MultiEngineProvider)(nil)
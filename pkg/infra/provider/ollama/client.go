@@ -4,26 +4,130 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+)
+
+// Default connection tuning for the ollama client. Ollama normally runs on the
+// same host and serves many short requests, so a larger idle pool per host
+// avoids reconnecting under load. The request timeout stays long enough to
+// cover model pulls and slow first-token latency on large models.
+const (
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultRequestTimeout      = 30 * time.Minute
 )
 
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	retry      RetryPolicy
 }
 
-func NewClient(baseURL string) *Client {
+// RetryPolicy controls how transient ollama failures are retried. MaxAttempts
+// counts the first try, so a value of 1 or less disables retries.
+type RetryPolicy struct {
+	MaxAttempts     int
+	InitialBackoff  time.Duration
+	MaxBackoff      time.Duration
+	RetryableStatus []int
+}
+
+// DefaultRetryPolicy retries the statuses ollama returns while a model is
+// still loading or the server is overloaded. 500 is left out on purpose:
+// ollama uses it for permanent failures such as a corrupt model file.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+type clientConfig struct {
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	requestTimeout      time.Duration
+	retry               RetryPolicy
+}
+
+type ClientOption func(*clientConfig)
+
+// WithMaxIdleConnsPerHost sets how many idle keep-alive connections are kept
+// open to the ollama server.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *clientConfig) {
+		c.maxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle keep-alive connection is kept
+// before being closed.
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.idleConnTimeout = d
+	}
+}
+
+// WithRequestTimeout sets the overall timeout of a single request, including
+// reading the response body.
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.requestTimeout = d
+	}
+}
+
+// WithRetryPolicy overrides the retry behaviour of idempotent calls.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *clientConfig) {
+		c.retry = policy
+	}
+}
+
+func NewClient(baseURL string, opts ...ClientOption) *Client {
 	if baseURL == "" {
 		baseURL = "http://localhost:11434"
 	}
+
+	cfg := clientConfig{
+		maxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		idleConnTimeout:     DefaultIdleConnTimeout,
+		requestTimeout:      DefaultRequestTimeout,
+		retry:               DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = cfg.maxIdleConnsPerHost
+	if transport.MaxIdleConns < cfg.maxIdleConnsPerHost {
+		transport.MaxIdleConns = cfg.maxIdleConnsPerHost
+	}
+	transport.IdleConnTimeout = cfg.idleConnTimeout
+
 	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Minute,
+			Transport: transport,
+			Timeout:   cfg.requestTimeout,
 		},
+		retry: cfg.retry,
 	}
 }
 
@@ -142,6 +246,129 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// statusError is returned when ollama answers with an HTTP error status. It
+// keeps the status and any Retry-After hint so the retry loop can use them.
+type statusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	message    string
+	// cause is a typed error recognised from the message, if any.
+	cause error
+}
+
+func (e *statusError) Error() string {
+	return e.message
+}
+
+func (e *statusError) Unwrap() error {
+	return e.cause
+}
+
+// modelNotPulled matches the error ollama returns for a model it does not
+// have locally, e.g. `model "llama3" not found, try pulling it first`.
+var modelNotPulled = regexp.MustCompile(`model ['"]?([^'"\s]+)['"]? not found, try pulling it first`)
+
+func newStatusError(resp *http.Response, body []byte, includeBody bool) *statusError {
+	e := &statusError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	var errResp ErrorResponse
+	switch {
+	case json.Unmarshal(body, &errResp) == nil && errResp.Error != "":
+		e.message = fmt.Sprintf("ollama error: %s", errResp.Error)
+		if m := modelNotPulled.FindStringSubmatch(errResp.Error); m != nil {
+			e.cause = &inference.ModelNotPulledError{Model: m[1]}
+		}
+	case includeBody:
+		e.message = fmt.Sprintf("ollama error: status %d, body: %s", resp.StatusCode, string(body))
+	default:
+		e.message = fmt.Sprintf("ollama error: status %d", resp.StatusCode)
+	}
+	return e
+}
+
+// parseRetryAfter accepts both forms allowed by RFC 9110: delay seconds and
+// an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// retryDelay reports whether err is transient and how long to wait before the
+// next attempt. Retry-After from the server takes precedence over backoff.
+func (c *Client) retryDelay(ctx context.Context, err error, backoff time.Duration) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+
+	var se *statusError
+	if errors.As(err, &se) {
+		if !slices.Contains(c.retry.RetryableStatus, se.StatusCode) {
+			return 0, false
+		}
+		if se.RetryAfter > 0 {
+			return se.RetryAfter, true
+		}
+		return backoff, true
+	}
+
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return backoff, true
+	}
+	return 0, false
+}
+
+// withRetry runs fn until it succeeds, fails with a non-transient error, or
+// the retry policy is exhausted.
+func (c *Client) withRetry(ctx context.Context, fn func() error) error {
+	backoff := c.retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= c.retry.MaxAttempts {
+			return err
+		}
+
+		wait, ok := c.retryDelay(ctx, err, backoff)
+		if !ok {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if c.retry.MaxBackoff > 0 && backoff > c.retry.MaxBackoff {
+			backoff = c.retry.MaxBackoff
+		}
+	}
+}
+
+// doRequestWithRetry is doRequest for idempotent calls that may be retried on
+// transient failures.
+func (c *Client) doRequestWithRetry(ctx context.Context, method, path string, reqBody, respBody any) error {
+	return c.withRetry(ctx, func() error {
+		return c.doRequest(ctx, method, path, reqBody, respBody)
+	})
+}
+
 func (c *Client) doRequest(ctx context.Context, method, path string, reqBody, respBody any) error {
 	var body io.Reader
 	if reqBody != nil {
@@ -174,11 +401,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, reqBody, re
 	}
 
 	if httpResp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if json.Unmarshal(respData, &errResp) == nil && errResp.Error != "" {
-			return fmt.Errorf("ollama error: %s", errResp.Error)
-		}
-		return fmt.Errorf("ollama error: status %d, body: %s", httpResp.StatusCode, string(respData))
+		return newStatusError(httpResp, respData, true)
 	}
 
 	if respBody != nil {
@@ -196,27 +419,34 @@ func (c *Client) doStreamingRequest(ctx context.Context, method, path string, re
 		return fmt.Errorf("marshal request: %w", err)
 	}
 
-	url := c.baseURL + path
-	httpReq, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(jsonData))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
+	// Only establishing the stream is retried: once the first line has been
+	// handed to the caller a retry would replay it.
+	var httpResp *http.Response
+	err = c.withRetry(ctx, func() error {
+		httpReq, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(jsonData))
+		if err != nil {
+			return fmt.Errorf("create request: %w", err)
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("do request: %w", err)
-	}
-	defer func() { _ = httpResp.Body.Close() }()
+		resp, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			return fmt.Errorf("do request: %w", err)
+		}
 
-	if httpResp.StatusCode >= 400 {
-		respData, _ := io.ReadAll(httpResp.Body)
-		var errResp ErrorResponse
-		if json.Unmarshal(respData, &errResp) == nil && errResp.Error != "" {
-			return fmt.Errorf("ollama error: %s", errResp.Error)
+		if resp.StatusCode >= 400 {
+			respData, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return newStatusError(resp, respData, false)
 		}
-		return fmt.Errorf("ollama error: status %d", httpResp.StatusCode)
+
+		httpResp = resp
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	defer func() { _ = httpResp.Body.Close() }()
 
 	decoder := json.NewDecoder(httpResp.Body)
 	for {
@@ -254,7 +484,7 @@ func (c *Client) Pull(ctx context.Context, req *PullRequest, handler func(*PullR
 func (c *Client) Generate(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
 	req.Stream = false
 	var resp GenerateResponse
-	if err := c.doRequest(ctx, http.MethodPost, "/api/generate", req, &resp); err != nil {
+	if err := c.doRequestWithRetry(ctx, http.MethodPost, "/api/generate", req, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -263,7 +493,7 @@ func (c *Client) Generate(ctx context.Context, req *GenerateRequest) (*GenerateR
 func (c *Client) Chat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
 	req.Stream = false
 	var resp ChatResponse
-	if err := c.doRequest(ctx, http.MethodPost, "/api/chat", req, &resp); err != nil {
+	if err := c.doRequestWithRetry(ctx, http.MethodPost, "/api/chat", req, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -271,7 +501,7 @@ func (c *Client) Chat(ctx context.Context, req *ChatRequest) (*ChatResponse, err
 
 func (c *Client) Embedding(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error) {
 	var resp EmbeddingResponse
-	if err := c.doRequest(ctx, http.MethodPost, "/api/embeddings", req, &resp); err != nil {
+	if err := c.doRequestWithRetry(ctx, http.MethodPost, "/api/embeddings", req, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -297,8 +527,30 @@ func (c *Client) DeleteModel(ctx context.Context, req *DeleteModelRequest) error
 	return c.doRequest(ctx, http.MethodDelete, "/api/delete", req, nil)
 }
 
+// pingTimeout bounds liveness checks so an unreachable server is reported
+// quickly instead of after the full request timeout.
+const pingTimeout = 2 * time.Second
+
+// VersionResponse is the body of GET /api/version.
+type VersionResponse struct {
+	Version string `json:"version"`
+}
+
+// Version returns the version of the ollama server. It is not retried, so
+// it doubles as a quick reachability check.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	var resp VersionResponse
+	if err := c.doRequest(ctx, http.MethodGet, "/api/version", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Version, nil
+}
+
 func (c *Client) IsRunning(ctx context.Context) bool {
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL, nil)
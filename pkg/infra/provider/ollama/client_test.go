@@ -0,0 +1,242 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestNewClient_DefaultTransport(t *testing.T) {
+	c := NewClient("")
+
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", c.httpClient.Transport)
+	}
+	if transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Errorf("expected MaxIdleConnsPerHost %d, got %d", DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("expected IdleConnTimeout %v, got %v", DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	}
+	if c.httpClient.Timeout != DefaultRequestTimeout {
+		t.Errorf("expected Timeout %v, got %v", DefaultRequestTimeout, c.httpClient.Timeout)
+	}
+}
+
+func TestNewClient_WithOptions(t *testing.T) {
+	c := NewClient("http://localhost:11434",
+		WithMaxIdleConnsPerHost(200),
+		WithIdleConnTimeout(15*time.Second),
+		WithRequestTimeout(2*time.Minute),
+	)
+
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", c.httpClient.Transport)
+	}
+	if transport.MaxIdleConnsPerHost != 200 {
+		t.Errorf("expected MaxIdleConnsPerHost 200, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxIdleConns < 200 {
+		t.Errorf("expected MaxIdleConns >= 200, got %d", transport.MaxIdleConns)
+	}
+	if transport.IdleConnTimeout != 15*time.Second {
+		t.Errorf("expected IdleConnTimeout 15s, got %v", transport.IdleConnTimeout)
+	}
+	if c.httpClient.Timeout != 2*time.Minute {
+		t.Errorf("expected Timeout 2m, got %v", c.httpClient.Timeout)
+	}
+}
+
+func TestClient_SetHTTPClient(t *testing.T) {
+	c := NewClient("", WithMaxIdleConnsPerHost(4))
+	custom := &http.Client{Timeout: time.Second}
+
+	c.SetHTTPClient(custom)
+
+	if c.httpClient != custom {
+		t.Error("expected SetHTTPClient to replace the tuned client")
+	}
+}
+
+func TestClient_Version(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/version" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"version":"0.6.2"}`))
+	}))
+	defer server.Close()
+
+	version, err := NewClient(server.URL).Version(context.Background())
+	if err != nil {
+		t.Fatalf("Version failed: %v", err)
+	}
+	if version != "0.6.2" {
+		t.Errorf("expected version 0.6.2, got %q", version)
+	}
+}
+
+func TestClient_Chat_RetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "model is loading"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ChatResponse{
+			Model:   "llama3",
+			Message: &ChatMessage{Role: "assistant", Content: "hello"},
+			Done:    true,
+		})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, WithRetryPolicy(fastRetryPolicy()))
+	resp, err := c.Chat(context.Background(), &ChatRequest{Model: "llama3"})
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if resp.Message == nil || resp.Message.Content != "hello" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClient_Generate_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, WithRetryPolicy(fastRetryPolicy()))
+	_, err := c.Generate(context.Background(), &GenerateRequest{Model: "llama3", Prompt: "hi"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "status 502") {
+		t.Errorf("expected status in error, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClient_Embedding_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "model not found"})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, WithRetryPolicy(fastRetryPolicy()))
+	_, err := c.Embedding(context.Background(), &EmbeddingRequest{Model: "missing", Prompt: "hi"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestClient_RetryDelay_HonoursRetryAfter(t *testing.T) {
+	c := NewClient("")
+
+	wait, ok := c.retryDelay(context.Background(), &statusError{
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: 2 * time.Second,
+	}, 10*time.Millisecond)
+	if !ok {
+		t.Fatal("expected 429 to be retryable")
+	}
+	if wait != 2*time.Second {
+		t.Errorf("expected Retry-After to win over backoff, got %v", wait)
+	}
+
+	if _, ok := c.retryDelay(context.Background(), &statusError{StatusCode: http.StatusInternalServerError}, time.Millisecond); ok {
+		t.Error("expected 500 not to be retryable by default")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("3"); got != 3*time.Second {
+		t.Errorf("expected 3s, got %v", got)
+	}
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("expected 0, got %v", got)
+	}
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got <= 0 || got > time.Minute {
+		t.Errorf("expected positive delay up to 1m, got %v", got)
+	}
+}
+
+func TestClient_Pull_RetriesBeforeFirstByte(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(PullResponse{Status: "pulling"})
+		_ = json.NewEncoder(w).Encode(PullResponse{Status: "success"})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, WithRetryPolicy(fastRetryPolicy()))
+	var statuses []string
+	err := c.Pull(context.Background(), &PullRequest{Name: "llama3"}, func(resp *PullResponse) error {
+		statuses = append(statuses, resp.Status)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Errorf("expected 2 progress lines, got %v", statuses)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClient_Pull_NoRetryAfterFirstByte(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = json.NewEncoder(w).Encode(PullResponse{Status: "pulling"})
+		_, _ = w.Write([]byte("{broken"))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, WithRetryPolicy(fastRetryPolicy()))
+	err := c.Pull(context.Background(), &PullRequest{Name: "llama3"}, func(resp *PullResponse) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected decode error, got nil")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected stream not to be retried, got %d attempts", got)
+	}
+}
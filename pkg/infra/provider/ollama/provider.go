@@ -24,6 +24,12 @@ type Provider struct {
 	processes  map[string]*exec.Cmd
 	mu         sync.RWMutex
 	modelCache map[string]*model.Model
+
+	// minVersion gates features that need a newer server; serverVersion
+	// caches what the server reported.
+	minVersion        string
+	enforceMinVersion bool
+	serverVersion     string
 }
 
 func NewProvider(baseURL string) *Provider {
@@ -420,7 +426,7 @@ func (p *Provider) GetFeatures(ctx context.Context, name string) (*engine.Engine
 		SupportsStreaming:    true,
 		SupportsBatch:        false,
 		SupportsMultimodal:   true,
-		SupportsTools:        true,
+		SupportsTools:        p.CheckVersion(ctx, "tools") == nil,
 		SupportsEmbedding:    true,
 		MaxConcurrent:        10,
 		MaxContextLength:     128000,
@@ -430,6 +436,12 @@ func (p *Provider) GetFeatures(ctx context.Context, name string) (*engine.Engine
 	}, nil
 }
 
+// Ping reports whether the ollama server is reachable and returns its
+// version.
+func (p *Provider) Ping(ctx context.Context) (string, error) {
+	return p.client.Version(ctx)
+}
+
 func (p *Provider) Chat(ctx context.Context, modelName string, messages []inference.Message, opts inference.ChatOptions) (*inference.ChatResponse, error) {
 	chatMsgs := make([]ChatMessage, len(messages))
 	for i, m := range messages {
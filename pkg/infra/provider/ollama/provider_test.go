@@ -50,6 +50,36 @@ func TestProvider_New(t *testing.T) {
 	})
 }
 
+func TestProvider_Ping(t *testing.T) {
+	t.Run("reachable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/version" {
+				t.Errorf("expected /api/version, got %s", r.URL.Path)
+			}
+			_ = json.NewEncoder(w).Encode(VersionResponse{Version: "0.5.7"})
+		}))
+		defer server.Close()
+
+		version, err := NewProvider(server.URL).Ping(context.Background())
+		if err != nil {
+			t.Fatalf("Ping failed: %v", err)
+		}
+		if version != "0.5.7" {
+			t.Errorf("expected version 0.5.7, got %q", version)
+		}
+	})
+
+	t.Run("down", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+
+		if _, err := NewProvider(url).Ping(context.Background()); err == nil {
+			t.Error("expected an error for an unreachable server")
+		}
+	})
+}
+
 func TestProvider_Chat(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/chat" {
@@ -102,6 +132,28 @@ func TestProvider_Chat(t *testing.T) {
 	}
 }
 
+func TestProvider_ChatModelNotPulled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"model \"llama3\" not found, try pulling it first"}`))
+	}))
+	defer server.Close()
+
+	p := NewProvider(server.URL)
+	_, err := p.Chat(context.Background(), "llama3", []inference.Message{{Role: "user", Content: "Hi"}}, inference.ChatOptions{})
+
+	var notPulled *inference.ModelNotPulledError
+	if !errors.As(err, &notPulled) {
+		t.Fatalf("expected ModelNotPulledError, got %v", err)
+	}
+	if notPulled.Model != "llama3" {
+		t.Errorf("expected model llama3, got %q", notPulled.Model)
+	}
+	if !errors.Is(err, inference.ErrModelNotPulled) {
+		t.Error("expected errors.Is to match ErrModelNotPulled")
+	}
+}
+
 func TestProvider_ChatWithOptions(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var req ChatRequest
@@ -457,6 +509,64 @@ func TestProvider_GetFeatures(t *testing.T) {
 	}
 }
 
+func TestProvider_CheckVersion(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_ = json.NewEncoder(w).Encode(VersionResponse{Version: "0.3.9"})
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	p := NewProvider(server.URL)
+	if err := p.CheckVersion(ctx, "tools"); err != nil {
+		t.Errorf("expected no gate without a minimum, got %v", err)
+	}
+
+	p.SetMinVersion("0.4.0", false)
+	if err := p.CheckVersion(ctx, "tools"); err != nil {
+		t.Errorf("expected only a warning when not enforced, got %v", err)
+	}
+
+	p.SetMinVersion("0.4.0", true)
+	if err := p.CheckVersion(ctx, "tools"); !errors.Is(err, ErrVersionTooOld) {
+		t.Errorf("expected ErrVersionTooOld, got %v", err)
+	}
+	features, err := p.GetFeatures(ctx, "ollama")
+	if err != nil {
+		t.Fatalf("GetFeatures failed: %v", err)
+	}
+	if features.SupportsTools {
+		t.Error("expected tools to be disabled below the minimum version")
+	}
+
+	p.SetMinVersion("0.3.9", true)
+	if err := p.CheckVersion(ctx, "tools"); err != nil {
+		t.Errorf("expected the exact minimum to pass, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the server version to be cached, got %d requests", calls)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.5.7", "0.5.7", 0},
+		{"0.5.10", "0.5.9", 1},
+		{"0.4", "0.4.1", -1},
+		{"v1.0.0", "0.9.9", 1},
+		{"0.5.0-rc1", "0.5.0", 0},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
 func TestProvider_UnsupportedOperations(t *testing.T) {
 	p := NewProvider("")
 	ctx := context.Background()
@@ -0,0 +1,105 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// ErrVersionTooOld is returned by CheckVersion when the server is older than
+// the configured minimum and the minimum is enforced.
+var ErrVersionTooOld = errors.New("ollama version too old")
+
+// SetMinVersion sets the oldest ollama version version-gated features may be
+// used with. When enforce is false an older server only logs a warning.
+func (p *Provider) SetMinVersion(min string, enforce bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.minVersion = min
+	p.enforceMinVersion = enforce
+}
+
+// ServerVersion returns the version reported by the ollama server. A
+// successful lookup is cached for the lifetime of the provider.
+func (p *Provider) ServerVersion(ctx context.Context) (string, error) {
+	p.mu.RLock()
+	version := p.serverVersion
+	p.mu.RUnlock()
+	if version != "" {
+		return version, nil
+	}
+
+	version, err := p.client.Version(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get ollama version: %w", err)
+	}
+	p.mu.Lock()
+	p.serverVersion = version
+	p.mu.Unlock()
+	return version, nil
+}
+
+// CheckVersion reports whether feature may be used with the connected
+// server. Without a configured minimum, or when the version cannot be
+// determined, it allows the feature and leaves failures to the request
+// itself.
+func (p *Provider) CheckVersion(ctx context.Context, feature string) error {
+	p.mu.RLock()
+	min, enforce := p.minVersion, p.enforceMinVersion
+	p.mu.RUnlock()
+	if min == "" {
+		return nil
+	}
+
+	version, err := p.ServerVersion(ctx)
+	if err != nil {
+		slog.Warn("cannot check ollama version", "feature", feature, "error", err)
+		return nil
+	}
+	if compareVersions(version, min) >= 0 {
+		return nil
+	}
+	if enforce {
+		return fmt.Errorf("%w: %s requires %s, server is %s", ErrVersionTooOld, feature, min, version)
+	}
+	slog.Warn("ollama version below minimum", "feature", feature, "version", version, "min_version", min)
+	return nil
+}
+
+// compareVersions compares dotted versions such as "0.5.7" numerically,
+// ignoring a leading "v" and any pre-release suffix.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		n, _ := strconv.Atoi(s)
+		parts = append(parts, n)
+	}
+	return parts
+}
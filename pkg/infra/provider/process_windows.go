@@ -0,0 +1,20 @@
+//go:build windows
+
+package provider
+
+import "os"
+
+// processAlive reports whether a process with the given PID exists. On
+// Windows FindProcess opens a handle to the process and fails if there is
+// none.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = proc.Release()
+	return true
+}
@@ -74,5 +74,15 @@ func (s *ServiceProvider) GetLogs(ctx context.Context, serviceID string, tail in
 	return "", fmt.Errorf("service logs not supported by vLLM provider")
 }
 
+// Exec runs a command inside the service container
+func (s *ServiceProvider) Exec(ctx context.Context, serviceID string, cmd []string) (*service.ExecResult, error) {
+	return nil, fmt.Errorf("service exec not supported by vLLM provider")
+}
+
+// DiscoverContainers lists AIMA-managed containers
+func (s *ServiceProvider) DiscoverContainers(ctx context.Context) ([]service.ManagedContainer, error) {
+	return nil, fmt.Errorf("container discovery not supported by vLLM provider")
+}
+
 // Ensure ServiceProvider implements the interface
 var _ service.ServiceProvider = (*ServiceProvider)(nil)
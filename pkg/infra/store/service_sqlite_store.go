@@ -38,20 +38,23 @@ func (s *ServiceSQLiteStore) initSchema() error {
 		active_replicas INTEGER DEFAULT 0,
 		config TEXT,
 		created_at INTEGER NOT NULL,
-		updated_at INTEGER NOT NULL
+		updated_at INTEGER NOT NULL,
+		pinned INTEGER DEFAULT 0
 	);
 	CREATE INDEX IF NOT EXISTS idx_services_model_id ON services(model_id);
 	CREATE INDEX IF NOT EXISTS idx_services_status ON services(status);
 	`
-	_, err := s.db.Exec(query)
-	return err
+	if _, err := s.db.Exec(query); err != nil {
+		return err
+	}
+	return addColumnIfMissing(s.db, "services", "pinned", "INTEGER DEFAULT 0")
 }
 
 // Create implements ServiceStore.Create
 func (s *ServiceSQLiteStore) Create(ctx context.Context, svc *service.ModelService) error {
 	query := `
-		INSERT INTO services (id, name, model_id, status, replicas, resource_class, endpoints, active_replicas, config, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO services (id, name, model_id, status, replicas, resource_class, endpoints, active_replicas, config, created_at, updated_at, pinned)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	endpoints := ""
 	if len(svc.Endpoints) > 0 {
@@ -66,7 +69,7 @@ func (s *ServiceSQLiteStore) Create(ctx context.Context, svc *service.ModelServi
 	_, err := s.db.ExecContext(ctx, query,
 		svc.ID, svc.Name, svc.ModelID, string(svc.Status), svc.Replicas,
 		string(svc.ResourceClass), endpoints, svc.ActiveReplicas, configJSON,
-		svc.CreatedAt, svc.UpdatedAt,
+		svc.CreatedAt, svc.UpdatedAt, svc.Pinned,
 	)
 	if err != nil {
 		return fmt.Errorf("insert service: %w", err)
@@ -76,7 +79,7 @@ func (s *ServiceSQLiteStore) Create(ctx context.Context, svc *service.ModelServi
 
 // GetByName implements ServiceStore.GetByName
 func (s *ServiceSQLiteStore) GetByName(ctx context.Context, name string) (*service.ModelService, error) {
-	query := `SELECT id, name, model_id, status, replicas, resource_class, endpoints, active_replicas, config, created_at, updated_at FROM services WHERE name = ?`
+	query := `SELECT id, name, model_id, status, replicas, resource_class, endpoints, active_replicas, config, created_at, updated_at, pinned FROM services WHERE name = ?`
 	row := s.db.QueryRowContext(ctx, query, name)
 
 	svc := &service.ModelService{}
@@ -86,7 +89,7 @@ func (s *ServiceSQLiteStore) GetByName(ctx context.Context, name string) (*servi
 	err := row.Scan(
 		&svc.ID, &svc.Name, &svc.ModelID, &statusStr, &svc.Replicas,
 		&resourceClassStr, &endpoints, &svc.ActiveReplicas, &configJSON,
-		&svc.CreatedAt, &svc.UpdatedAt,
+		&svc.CreatedAt, &svc.UpdatedAt, &svc.Pinned,
 	)
 	if err == sql.ErrNoRows {
 		return nil, service.ErrServiceNotFound
@@ -109,7 +112,7 @@ func (s *ServiceSQLiteStore) GetByName(ctx context.Context, name string) (*servi
 
 // Get implements ServiceStore.Get
 func (s *ServiceSQLiteStore) Get(ctx context.Context, id string) (*service.ModelService, error) {
-	query := `SELECT id, name, model_id, status, replicas, resource_class, endpoints, active_replicas, config, created_at, updated_at FROM services WHERE id = ?`
+	query := `SELECT id, name, model_id, status, replicas, resource_class, endpoints, active_replicas, config, created_at, updated_at, pinned FROM services WHERE id = ?`
 	row := s.db.QueryRowContext(ctx, query, id)
 
 	svc := &service.ModelService{}
@@ -119,7 +122,7 @@ func (s *ServiceSQLiteStore) Get(ctx context.Context, id string) (*service.Model
 	err := row.Scan(
 		&svc.ID, &svc.Name, &svc.ModelID, &statusStr, &svc.Replicas,
 		&resourceClassStr, &endpoints, &svc.ActiveReplicas, &configJSON,
-		&svc.CreatedAt, &svc.UpdatedAt,
+		&svc.CreatedAt, &svc.UpdatedAt, &svc.Pinned,
 	)
 	if err == sql.ErrNoRows {
 		return nil, service.ErrServiceNotFound
@@ -163,7 +166,7 @@ func (s *ServiceSQLiteStore) List(ctx context.Context, filter service.ServiceFil
 
 	// Get paginated results
 	query := fmt.Sprintf(`
-		SELECT id, name, model_id, status, replicas, resource_class, endpoints, active_replicas, config, created_at, updated_at
+		SELECT id, name, model_id, status, replicas, resource_class, endpoints, active_replicas, config, created_at, updated_at, pinned
 		FROM services
 		WHERE %s
 		ORDER BY created_at DESC
@@ -191,7 +194,7 @@ func (s *ServiceSQLiteStore) List(ctx context.Context, filter service.ServiceFil
 		err := rows.Scan(
 			&svc.ID, &svc.Name, &svc.ModelID, &statusStr, &svc.Replicas,
 			&resourceClassStr, &endpoints, &svc.ActiveReplicas, &configJSON,
-			&svc.CreatedAt, &svc.UpdatedAt,
+			&svc.CreatedAt, &svc.UpdatedAt, &svc.Pinned,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("scan service: %w", err)
@@ -232,7 +235,7 @@ func (s *ServiceSQLiteStore) Update(ctx context.Context, svc *service.ModelServi
 	query := `
 		UPDATE services SET
 			name = ?, model_id = ?, status = ?, replicas = ?, resource_class = ?,
-			endpoints = ?, active_replicas = ?, config = ?, updated_at = ?
+			endpoints = ?, active_replicas = ?, config = ?, updated_at = ?, pinned = ?
 		WHERE id = ?
 	`
 	endpoints := ""
@@ -247,7 +250,7 @@ func (s *ServiceSQLiteStore) Update(ctx context.Context, svc *service.ModelServi
 	}
 	result, err := s.db.ExecContext(ctx, query,
 		svc.Name, svc.ModelID, string(svc.Status), svc.Replicas, string(svc.ResourceClass),
-		endpoints, svc.ActiveReplicas, configJSON, time.Now().Unix(),
+		endpoints, svc.ActiveReplicas, configJSON, time.Now().Unix(), svc.Pinned,
 		svc.ID,
 	)
 	if err != nil {
@@ -67,23 +67,72 @@ func (s *SQLiteStore) initSchema() error {
 	CREATE INDEX IF NOT EXISTS idx_models_status ON models(status);
 	CREATE INDEX IF NOT EXISTS idx_models_name ON models(name);
 	`
-	_, err := s.db.Exec(query)
-	return err
+	if _, err := s.db.Exec(query); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(s.db, "models", "manifest", "TEXT"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(s.db, "models", "prompt_template", "TEXT"); err != nil {
+		return err
+	}
+	return addColumnIfMissing(s.db, "models", "last_used_at", "INTEGER NOT NULL DEFAULT 0")
+}
+
+// addColumnIfMissing adds a column to a table created by an older version of
+// the schema.
+func addColumnIfMissing(db *sql.DB, table, column, columnType string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("read %s columns: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, typ        string
+			dflt             sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return fmt.Errorf("scan %s columns: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, columnType)); err != nil {
+		return fmt.Errorf("add %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+func marshalManifest(manifest []model.FileDigest) string {
+	if len(manifest) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(manifest)
+	return string(data)
 }
 
 // Create implements ModelStore.Create
 func (s *SQLiteStore) Create(ctx context.Context, m *model.Model) error {
-	// Serialize tags as JSON for storage
+	// Serialize tags and manifest as JSON for storage
 	tagsJSON, _ := json.Marshal(m.Tags)
+	manifestJSON := marshalManifest(m.Manifest)
 
 	query := `
-		INSERT INTO models (id, name, type, format, status, source, path, size, checksum, metadata, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO models (id, name, type, format, status, source, path, size, checksum, metadata, manifest, prompt_template, created_at, updated_at, last_used_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := s.db.ExecContext(ctx, query,
 		m.ID, m.Name, string(m.Type), string(m.Format), string(m.Status),
-		m.Source, m.Path, m.Size, m.Checksum, string(tagsJSON),
-		m.CreatedAt, m.UpdatedAt,
+		m.Source, m.Path, m.Size, m.Checksum, string(tagsJSON), manifestJSON, m.PromptTemplate,
+		m.CreatedAt, m.UpdatedAt, m.LastUsedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("insert model: %w", err)
@@ -93,17 +142,18 @@ func (s *SQLiteStore) Create(ctx context.Context, m *model.Model) error {
 
 // Get implements ModelStore.Get
 func (s *SQLiteStore) Get(ctx context.Context, id string) (*model.Model, error) {
-	query := `SELECT id, name, type, format, status, source, path, size, checksum, metadata, created_at, updated_at FROM models WHERE id = ?`
+	query := `SELECT id, name, type, format, status, source, path, size, checksum, metadata, manifest, prompt_template, created_at, updated_at, last_used_at FROM models WHERE id = ?`
 	row := s.db.QueryRowContext(ctx, query, id)
 
 	m := &model.Model{}
 	var tagsStr string
+	var manifestStr, promptTemplate sql.NullString
 	var typeStr, formatStr, statusStr string
 
 	err := row.Scan(
 		&m.ID, &m.Name, &typeStr, &formatStr, &statusStr,
-		&m.Source, &m.Path, &m.Size, &m.Checksum, &tagsStr,
-		&m.CreatedAt, &m.UpdatedAt,
+		&m.Source, &m.Path, &m.Size, &m.Checksum, &tagsStr, &manifestStr, &promptTemplate,
+		&m.CreatedAt, &m.UpdatedAt, &m.LastUsedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, model.ErrModelNotFound
@@ -119,6 +169,10 @@ func (s *SQLiteStore) Get(ctx context.Context, id string) (*model.Model, error)
 	if tagsStr != "" {
 		_ = json.Unmarshal([]byte(tagsStr), &m.Tags)
 	}
+	if manifestStr.String != "" {
+		_ = json.Unmarshal([]byte(manifestStr.String), &m.Manifest)
+	}
+	m.PromptTemplate = promptTemplate.String
 
 	return m, nil
 }
@@ -150,7 +204,7 @@ func (s *SQLiteStore) List(ctx context.Context, filter model.ModelFilter) ([]mod
 
 	// Get paginated results
 	query := fmt.Sprintf(`
-		SELECT id, name, type, format, status, source, path, size, checksum, metadata, created_at, updated_at
+		SELECT id, name, type, format, status, source, path, size, checksum, metadata, manifest, prompt_template, created_at, updated_at, last_used_at
 		FROM models
 		WHERE %s
 		ORDER BY created_at DESC
@@ -173,12 +227,13 @@ func (s *SQLiteStore) List(ctx context.Context, filter model.ModelFilter) ([]mod
 	for rows.Next() {
 		m := model.Model{}
 		var tagsStr string
+		var manifestStr, promptTemplate sql.NullString
 		var typeStr, formatStr, statusStr string
 
 		err := rows.Scan(
 			&m.ID, &m.Name, &typeStr, &formatStr, &statusStr,
-			&m.Source, &m.Path, &m.Size, &m.Checksum, &tagsStr,
-			&m.CreatedAt, &m.UpdatedAt,
+			&m.Source, &m.Path, &m.Size, &m.Checksum, &tagsStr, &manifestStr, &promptTemplate,
+			&m.CreatedAt, &m.UpdatedAt, &m.LastUsedAt,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("scan model: %w", err)
@@ -191,6 +246,10 @@ func (s *SQLiteStore) List(ctx context.Context, filter model.ModelFilter) ([]mod
 		if tagsStr != "" {
 			_ = json.Unmarshal([]byte(tagsStr), &m.Tags)
 		}
+		if manifestStr.String != "" {
+			_ = json.Unmarshal([]byte(manifestStr.String), &m.Manifest)
+		}
+		m.PromptTemplate = promptTemplate.String
 
 		models = append(models, m)
 	}
@@ -215,18 +274,19 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 
 // Update implements ModelStore.Update
 func (s *SQLiteStore) Update(ctx context.Context, m *model.Model) error {
-	// Serialize tags as JSON for storage
+	// Serialize tags and manifest as JSON for storage
 	tagsJSON, _ := json.Marshal(m.Tags)
+	manifestJSON := marshalManifest(m.Manifest)
 
 	query := `
 		UPDATE models SET 
 			name = ?, type = ?, format = ?, status = ?, source = ?, 
-			path = ?, size = ?, checksum = ?, metadata = ?, updated_at = ?
+			path = ?, size = ?, checksum = ?, metadata = ?, manifest = ?, prompt_template = ?, updated_at = ?, last_used_at = ?
 		WHERE id = ?
 	`
 	result, err := s.db.ExecContext(ctx, query,
 		m.Name, string(m.Type), string(m.Format), string(m.Status), m.Source,
-		m.Path, m.Size, m.Checksum, string(tagsJSON), time.Now().Unix(),
+		m.Path, m.Size, m.Checksum, string(tagsJSON), manifestJSON, m.PromptTemplate, time.Now().Unix(), m.LastUsedAt,
 		m.ID,
 	)
 	if err != nil {
@@ -0,0 +1,86 @@
+package tokenizer
+
+import (
+	"hash/fnv"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// approximateVocabSize bounds the IDs Approximate hands out.
+	approximateVocabSize = 100000
+	// approximateWordBytes is how many bytes of an ASCII word Approximate
+	// treats as one token; common English words fit in a single token.
+	approximateWordBytes = 8
+)
+
+// Approximate is a dictionary-free tokenizer that follows the shape of BPE
+// vocabularies: common words are one token, long words are split every few
+// bytes, non-ASCII letters cost a token each and digits group in threes.
+// IDs are stable hashes of each segment, not real vocabulary IDs.
+type Approximate struct{}
+
+func (Approximate) Count(text string) int {
+	count := 0
+	for _, piece := range pretokenize(text) {
+		count += len(approximateSegments(piece))
+	}
+	return count
+}
+
+func (Approximate) Encode(text string) []int {
+	var ids []int
+	for _, piece := range pretokenize(text) {
+		for _, seg := range approximateSegments(piece) {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(seg))
+			ids = append(ids, int(h.Sum32()%approximateVocabSize))
+		}
+	}
+	return ids
+}
+
+// approximateSegments splits one pre-token into the pieces counted as
+// tokens.
+func approximateSegments(piece string) []string {
+	body := piece
+	if len(piece) > 1 && piece[0] == ' ' {
+		body = piece[1:]
+	}
+	first, _ := utf8.DecodeRuneInString(body)
+
+	switch {
+	case unicode.IsSpace(first), unicode.IsNumber(first):
+		return []string{piece}
+	case unicode.IsLetter(first) && isASCII(body):
+		return splitBytes(piece, approximateWordBytes)
+	default:
+		// Non-ASCII letters and punctuation rarely merge; count each rune,
+		// keeping a leading space with the first one.
+		prefix := piece[:len(piece)-len(body)]
+		segs := make([]string, 0, utf8.RuneCountInString(body))
+		for _, r := range body {
+			segs = append(segs, prefix+string(r))
+			prefix = ""
+		}
+		return segs
+	}
+}
+
+func splitBytes(s string, size int) []string {
+	segs := make([]string, 0, (len(s)+size-1)/size)
+	for len(s) > size {
+		segs = append(segs, s[:size])
+		s = s[size:]
+	}
+	return append(segs, s)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
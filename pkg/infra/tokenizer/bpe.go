@@ -0,0 +1,103 @@
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BPE is a byte-level byte-pair-encoding tokenizer compatible with tiktoken
+// rank files, so counts match OpenAI-family models when loaded with their
+// vocabulary.
+type BPE struct {
+	ranks map[string]int
+}
+
+// NewBPE builds a tokenizer from token byte strings to ranks. Every single
+// byte must have a rank so any input can be encoded.
+func NewBPE(ranks map[string]int) (*BPE, error) {
+	for b := 0; b < 256; b++ {
+		if _, ok := ranks[string([]byte{byte(b)})]; !ok {
+			return nil, fmt.Errorf("bpe ranks missing byte 0x%02x", b)
+		}
+	}
+	return &BPE{ranks: ranks}, nil
+}
+
+// LoadTiktoken reads a tiktoken rank file, one "<base64 token> <rank>" pair
+// per line, such as cl100k_base.tiktoken.
+func LoadTiktoken(r io.Reader) (*BPE, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("tiktoken line %d: expected token and rank", line)
+		}
+		token, err := base64.StdEncoding.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("tiktoken line %d: decode token: %w", line, err)
+		}
+		rank, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("tiktoken line %d: parse rank: %w", line, err)
+		}
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read tiktoken ranks: %w", err)
+	}
+	return NewBPE(ranks)
+}
+
+func (t *BPE) Count(text string) int {
+	return len(t.Encode(text))
+}
+
+func (t *BPE) Encode(text string) []int {
+	var ids []int
+	for _, piece := range pretokenize(text) {
+		if rank, ok := t.ranks[piece]; ok {
+			ids = append(ids, rank)
+			continue
+		}
+		for _, part := range t.merge(piece) {
+			ids = append(ids, t.ranks[part])
+		}
+	}
+	return ids
+}
+
+// merge splits piece into bytes and repeatedly joins the adjacent pair with
+// the lowest rank until no joined pair is in the vocabulary.
+func (t *BPE) merge(piece string) []string {
+	parts := make([]string, len(piece))
+	for i := range parts {
+		parts[i] = piece[i : i+1]
+	}
+
+	for len(parts) > 1 {
+		best, bestRank := -1, math.MaxInt
+		for i := 0; i < len(parts)-1; i++ {
+			if rank, ok := t.ranks[parts[i]+parts[i+1]]; ok && rank < bestRank {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+		parts[best] += parts[best+1]
+		parts = append(parts[:best+1], parts[best+2:]...)
+	}
+	return parts
+}
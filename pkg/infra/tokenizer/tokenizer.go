@@ -0,0 +1,69 @@
+// Package tokenizer counts and encodes text as model tokens for context
+// length checks and usage accounting.
+package tokenizer
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Tokenizer splits text into model tokens.
+type Tokenizer interface {
+	Count(text string) int
+	Encode(text string) []int
+}
+
+// pretokenPattern approximates the cl100k pre-tokenizer: contractions,
+// letter runs and up to three digits with an optional leading space,
+// punctuation runs and whitespace. Go's regexp has no lookahead, so runs of
+// whitespace are kept whole instead of leaving the last space for the next
+// word.
+var pretokenPattern = regexp.MustCompile(`(?i:'s|'t|'re|'ve|'m|'ll|'d)| ?\p{L}+| ?\p{N}{1,3}| ?[^\s\p{L}\p{N}]+|\s+`)
+
+func pretokenize(text string) []string {
+	return pretokenPattern.FindAllString(text, -1)
+}
+
+// Registry selects a tokenizer per model family.
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]Tokenizer
+	fallback Tokenizer
+}
+
+// NewRegistry returns a registry that uses fallback for models with no
+// registered family. A nil fallback selects Approximate.
+func NewRegistry(fallback Tokenizer) *Registry {
+	if fallback == nil {
+		fallback = Approximate{}
+	}
+	return &Registry{
+		families: make(map[string]Tokenizer),
+		fallback: fallback,
+	}
+}
+
+// Register uses t for every model whose name contains family,
+// case-insensitively.
+func (r *Registry) Register(family string, t Tokenizer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families[strings.ToLower(family)] = t
+}
+
+// ForModel returns the tokenizer of the longest family found in modelName,
+// or the fallback when none matches.
+func (r *Registry) ForModel(modelName string) Tokenizer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name := strings.ToLower(modelName)
+	best, bestLen := r.fallback, 0
+	for family, t := range r.families {
+		if len(family) > bestLen && strings.Contains(name, family) {
+			best, bestLen = t, len(family)
+		}
+	}
+	return best
+}
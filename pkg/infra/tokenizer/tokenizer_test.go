@@ -0,0 +1,139 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestApproximate_CountKnownStrings(t *testing.T) {
+	// Ranges bracket the cl100k_base counts for each string.
+	tests := []struct {
+		text     string
+		min, max int
+	}{
+		{"", 0, 0},
+		{"Hello, world!", 3, 5},
+		{"The quick brown fox jumps over the lazy dog.", 9, 11},
+		{"internationalization", 2, 4},
+		{"1234567", 3, 3},
+		{"你好世界", 4, 8},
+		{"func main() {\n\tfmt.Println(\"hi\")\n}", 10, 18},
+		{strings.Repeat("word ", 100), 95, 105},
+	}
+
+	var tok Approximate
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := tok.Count(tt.text)
+			if got < tt.min || got > tt.max {
+				t.Errorf("Count(%q) = %d, want between %d and %d", tt.text, got, tt.min, tt.max)
+			}
+			if ids := tok.Encode(tt.text); len(ids) != got {
+				t.Errorf("Encode(%q) returned %d ids, Count returned %d", tt.text, len(ids), got)
+			}
+		})
+	}
+}
+
+func TestApproximate_EncodeIsStable(t *testing.T) {
+	var tok Approximate
+	a := tok.Encode("hello world")
+	b := tok.Encode("hello world")
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Errorf("expected stable ids, got %v and %v", a, b)
+	}
+}
+
+// byteRanks returns ranks 0-255 for single bytes followed by merges.
+func byteRanks(merges ...string) map[string]int {
+	ranks := make(map[string]int, 256+len(merges))
+	for b := 0; b < 256; b++ {
+		ranks[string([]byte{byte(b)})] = b
+	}
+	for i, m := range merges {
+		ranks[m] = 256 + i
+	}
+	return ranks
+}
+
+func TestBPE_Encode(t *testing.T) {
+	tok, err := NewBPE(byteRanks("he", "ll", "llo", "hello", " w", " wo"))
+	if err != nil {
+		t.Fatalf("NewBPE failed: %v", err)
+	}
+
+	tests := []struct {
+		text string
+		want []int
+	}{
+		{"hello", []int{259}},
+		{"hell", []int{256, 257}},
+		{" world", []int{261, 'r', 'l', 'd'}},
+		{"hello world", []int{259, 261, 'r', 'l', 'd'}},
+	}
+	for _, tt := range tests {
+		got := tok.Encode(tt.text)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("Encode(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+		if tok.Count(tt.text) != len(tt.want) {
+			t.Errorf("Count(%q) = %d, want %d", tt.text, tok.Count(tt.text), len(tt.want))
+		}
+	}
+}
+
+func TestNewBPE_RequiresAllBytes(t *testing.T) {
+	if _, err := NewBPE(map[string]int{"a": 0}); err == nil {
+		t.Fatal("expected error for ranks missing single bytes")
+	}
+}
+
+func TestLoadTiktoken(t *testing.T) {
+	var sb strings.Builder
+	for token, rank := range byteRanks("ab", "abc") {
+		fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank)
+	}
+
+	tok, err := LoadTiktoken(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("LoadTiktoken failed: %v", err)
+	}
+	if got := tok.Encode("abcab"); fmt.Sprint(got) != "[257 256]" {
+		t.Errorf("Encode = %v, want [257 256]", got)
+	}
+}
+
+func TestLoadTiktoken_Malformed(t *testing.T) {
+	for _, input := range []string{"YWI=\n", "!!! 1\n", "YWI= x\n"} {
+		if _, err := LoadTiktoken(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
+
+type fixedTokenizer int
+
+func (f fixedTokenizer) Count(string) int    { return int(f) }
+func (f fixedTokenizer) Encode(string) []int { return make([]int, f) }
+
+func TestRegistry_ForModel(t *testing.T) {
+	r := NewRegistry(nil)
+	r.Register("gpt", fixedTokenizer(1))
+	r.Register("gpt-4o", fixedTokenizer(2))
+
+	tests := []struct {
+		model string
+		want  int
+	}{
+		{"gpt-3.5-turbo", 1},
+		{"openai/GPT-4o-mini", 2},
+		{"llama3:8b", Approximate{}.Count("x y")},
+	}
+	for _, tt := range tests {
+		if got := r.ForModel(tt.model).Count("x y"); got != tt.want {
+			t.Errorf("ForModel(%q).Count = %d, want %d", tt.model, got, tt.want)
+		}
+	}
+}
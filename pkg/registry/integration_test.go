@@ -23,6 +23,8 @@ func TestRegistryIntegration(t *testing.T) {
 		{"model.pull command", "model.pull", "command"},
 		{"model.import command", "model.import", "command"},
 		{"model.verify command", "model.verify", "command"},
+		{"model.move command", "model.move", "command"},
+		{"model.prune command", "model.prune", "command"},
 		{"model.get query", "model.get", "query"},
 		{"model.list query", "model.list", "query"},
 		{"model.search query", "model.search", "query"},
@@ -51,8 +53,11 @@ func TestRegistryIntegration(t *testing.T) {
 		{"inference.generate_video command", "inference.generate_video", "command"},
 		{"inference.rerank command", "inference.rerank", "command"},
 		{"inference.detect command", "inference.detect", "command"},
+		{"inference.cancel command", "inference.cancel", "command"},
+		{"inference.benchmark command", "inference.benchmark", "command"},
 		{"inference.models query", "inference.models", "query"},
 		{"inference.voices query", "inference.voices", "query"},
+		{"provider.health query", "provider.health", "query"},
 
 		{"resource.allocate command", "resource.allocate", "command"},
 		{"resource.release command", "resource.release", "command"},
@@ -60,12 +65,16 @@ func TestRegistryIntegration(t *testing.T) {
 		{"resource.status query", "resource.status", "query"},
 		{"resource.budget query", "resource.budget", "query"},
 		{"resource.allocations query", "resource.allocations", "query"},
+		{"resource.summary query", "resource.summary", "query"},
 
 		{"service.create command", "service.create", "command"},
 		{"service.delete command", "service.delete", "command"},
 		{"service.scale command", "service.scale", "command"},
 		{"service.start command", "service.start", "command"},
 		{"service.stop command", "service.stop", "command"},
+		{"service.exec command", "service.exec", "command"},
+		{"service.pin command", "service.pin", "command"},
+		{"service.unpin command", "service.unpin", "command"},
 		{"service.get query", "service.get", "query"},
 		{"service.list query", "service.list", "query"},
 
@@ -100,6 +109,8 @@ func TestRegistryIntegration(t *testing.T) {
 		{"remote.exec command", "remote.exec", "command"},
 		{"remote.status query", "remote.status", "query"},
 		{"remote.audit query", "remote.audit", "query"},
+
+		{"billing.usage query", "billing.usage", "query"},
 	}
 
 	for _, tc := range testCases {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	catalogdata "github.com/jguan/ai-inference-managed-by-ai/catalog"
 	coreagent "github.com/jguan/ai-inference-managed-by-ai/pkg/agent"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/eventbus"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/service/billing"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 	unitagent "github.com/jguan/ai-inference-managed-by-ai/pkg/unit/agent"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/alert"
@@ -50,6 +53,23 @@ type Options struct {
 	Providers Providers
 	EventBus  unit.EventPublisher
 	Agent     *coreagent.Agent
+	// StreamTracker is shared with the gateway so inference.cancel can stop
+	// streams the gateway started.
+	StreamTracker *unit.StreamTracker
+	// BillingLedger backs billing.usage. RegisterAll subscribes it to the
+	// event bus; a zero-priced ledger is used when none is given.
+	BillingLedger *billing.Ledger
+	// ModelPaths lets model.import copy files into the models directory and
+	// model.delete remove the files it owns there.
+	ModelPaths *model.PathResolver
+	// MaxTokensCap clamps the max_tokens of inference.chat and
+	// inference.complete requests.
+	MaxTokensCap inference.MaxTokensCap
+	// TrimStopSequences makes inference.chat and inference.complete cut
+	// output at the first stop sequence when the engine does not.
+	TrimStopSequences bool
+	// EngineAssetReloader backs catalog.reload.
+	EngineAssetReloader catalog.EngineAssetReloader
 }
 
 type Option func(*Options)
@@ -138,6 +158,42 @@ func WithAgent(a *coreagent.Agent) Option {
 	}
 }
 
+func WithStreamTracker(t *unit.StreamTracker) Option {
+	return func(o *Options) {
+		o.StreamTracker = t
+	}
+}
+
+func WithBillingLedger(l *billing.Ledger) Option {
+	return func(o *Options) {
+		o.BillingLedger = l
+	}
+}
+
+func WithMaxTokensCap(c inference.MaxTokensCap) Option {
+	return func(o *Options) {
+		o.MaxTokensCap = c
+	}
+}
+
+func WithStopTrimming(enabled bool) Option {
+	return func(o *Options) {
+		o.TrimStopSequences = enabled
+	}
+}
+
+func WithEngineAssetReloader(r catalog.EngineAssetReloader) Option {
+	return func(o *Options) {
+		o.EngineAssetReloader = r
+	}
+}
+
+func WithModelPathResolver(r *model.PathResolver) Option {
+	return func(o *Options) {
+		o.ModelPaths = r
+	}
+}
+
 func WithModelProvider(p model.ModelProvider) Option {
 	return func(o *Options) {
 		o.Providers.ModelProvider = p
@@ -240,6 +296,14 @@ func RegisterAll(registry *unit.Registry, opts ...Option) error {
 		return fmt.Errorf("register skill domain: %w", err)
 	}
 
+	if err := registerBillingDomain(registry, options); err != nil {
+		return fmt.Errorf("register billing domain: %w", err)
+	}
+
+	if err := registerEventStreams(registry, options); err != nil {
+		return fmt.Errorf("register event streams: %w", err)
+	}
+
 	// Agent domain is only registered when an agent is explicitly provided.
 	// This allows two-phase setup: register all other domains first, create the
 	// gateway+MCPAdapter, then wire up the Agent and call RegisterAgentDomain.
@@ -260,6 +324,31 @@ func RegisterAgentDomain(registry *unit.Registry, a *coreagent.Agent) error {
 	return registerAgentDomain(registry, options)
 }
 
+// registerEventStreams exposes asms://events/{correlation_id} when the
+// configured publisher wraps a subscribable EventBus.
+func registerEventStreams(registry *unit.Registry, options *Options) error {
+	adapter, ok := options.EventBus.(*eventbus.EventPublisherAdapter)
+	if !ok {
+		return nil
+	}
+	return registry.RegisterResourceFactory(eventbus.NewEventStreamResourceFactory(adapter.Bus()))
+}
+
+func registerBillingDomain(registry *unit.Registry, options *Options) error {
+	ledger := options.BillingLedger
+	if ledger == nil {
+		ledger = billing.NewLedger(billing.PriceTable{})
+	}
+
+	if adapter, ok := options.EventBus.(*eventbus.EventPublisherAdapter); ok {
+		if _, err := ledger.Subscribe(adapter.Bus()); err != nil {
+			return fmt.Errorf("subscribe billing ledger: %w", err)
+		}
+	}
+
+	return registry.RegisterQuery(billing.NewUsageQueryWithEvents(ledger, options.EventBus))
+}
+
 func registerModelDomain(registry *unit.Registry, options *Options) error {
 	store := options.Stores.ModelStore
 	provider := options.Providers.ModelProvider
@@ -271,18 +360,35 @@ func registerModelDomain(registry *unit.Registry, options *Options) error {
 	if err := registry.RegisterCommand(model.NewCreateCommand(store)); err != nil {
 		return err
 	}
-	if err := registry.RegisterCommand(model.NewDeleteCommand(store)); err != nil {
+	if err := registry.RegisterCommand(model.NewDeleteCommandWithResolver(store, options.ModelPaths, nil)); err != nil {
 		return err
 	}
-	if err := registry.RegisterCommand(model.NewPullCommand(store, provider)); err != nil {
+	if err := registry.RegisterCommand(model.NewPullCommandWithEvents(store, provider, options.EventBus)); err != nil {
 		return err
 	}
-	if err := registry.RegisterCommand(model.NewImportCommand(store, provider)); err != nil {
+	if err := registry.RegisterCommand(model.NewImportCommandWithResolver(store, provider, options.ModelPaths, nil)); err != nil {
 		return err
 	}
 	if err := registry.RegisterCommand(model.NewVerifyCommand(store, provider)); err != nil {
 		return err
 	}
+	var usage model.ModelUsage
+	var refs model.ModelReferences
+	if options.Stores.ServiceStore != nil {
+		u := serviceModelUsage{store: options.Stores.ServiceStore}
+		usage, refs = u, u
+	}
+	if err := registry.RegisterCommand(model.NewMoveCommandWithEvents(store, usage, options.EventBus)); err != nil {
+		return err
+	}
+	if err := registry.RegisterCommand(model.NewPruneCommandWithEvents(store, refs, options.ModelPaths, options.EventBus)); err != nil {
+		return err
+	}
+	if adapter, ok := options.EventBus.(*eventbus.EventPublisherAdapter); ok {
+		if _, err := subscribeModelUsage(adapter.Bus(), store); err != nil {
+			return fmt.Errorf("subscribe model usage: %w", err)
+		}
+	}
 
 	if err := registry.RegisterQuery(model.NewGetQuery(store)); err != nil {
 		return err
@@ -381,10 +487,14 @@ func registerInferenceDomain(registry *unit.Registry, options *Options) error {
 	provider := options.Providers.InferenceProvider
 	events := options.EventBus
 
-	if err := registry.RegisterCommand(inference.NewChatCommandWithEvents(provider, events)); err != nil {
+	chat := inference.NewChatCommandWithEvents(provider, events).WithMaxTokensCap(options.MaxTokensCap).WithStopTrimming(options.TrimStopSequences)
+	if options.Stores.ModelStore != nil {
+		chat.WithPromptTemplates(model.NewPromptTemplates(options.Stores.ModelStore))
+	}
+	if err := registry.RegisterCommand(chat); err != nil {
 		return err
 	}
-	if err := registry.RegisterCommand(inference.NewCompleteCommandWithEvents(provider, events)); err != nil {
+	if err := registry.RegisterCommand(inference.NewCompleteCommandWithEvents(provider, events).WithMaxTokensCap(options.MaxTokensCap).WithStopTrimming(options.TrimStopSequences)); err != nil {
 		return err
 	}
 	if err := registry.RegisterCommand(inference.NewEmbedCommandWithEvents(provider, events)); err != nil {
@@ -408,6 +518,17 @@ func registerInferenceDomain(registry *unit.Registry, options *Options) error {
 	if err := registry.RegisterCommand(inference.NewDetectCommandWithEvents(provider, events)); err != nil {
 		return err
 	}
+	if err := registry.RegisterCommand(inference.NewBenchmarkCommandWithEvents(provider, events)); err != nil {
+		return err
+	}
+
+	var streams inference.StreamCanceller
+	if options.StreamTracker != nil {
+		streams = options.StreamTracker
+	}
+	if err := registry.RegisterCommand(inference.NewCancelCommandWithEvents(streams, events)); err != nil {
+		return err
+	}
 
 	if err := registry.RegisterQuery(inference.NewModelsQueryWithEvents(provider, events)); err != nil {
 		return err
@@ -416,6 +537,20 @@ func registerInferenceDomain(registry *unit.Registry, options *Options) error {
 		return err
 	}
 
+	pingers := make(map[string]inference.Pinger)
+	for name, p := range map[string]any{
+		"inference": options.Providers.InferenceProvider,
+		"model":     options.Providers.ModelProvider,
+		"engine":    options.Providers.EngineProvider,
+	} {
+		if pinger, ok := p.(inference.Pinger); ok {
+			pingers[name] = pinger
+		}
+	}
+	if err := registry.RegisterQuery(inference.NewHealthQueryWithEvents(pingers, events)); err != nil {
+		return err
+	}
+
 	// Register ResourceFactory for dynamic resource creation
 	if err := registry.RegisterResourceFactory(inference.NewInferenceResourceFactory(provider)); err != nil {
 		return err
@@ -442,6 +577,13 @@ func registerResourceDomain(registry *unit.Registry, options *Options) error {
 	if err := registry.RegisterCommand(resource.NewUpdateSlotCommandWithEvents(store, events)); err != nil {
 		return err
 	}
+	watch := resource.NewWatchCommandWithEvents(provider, events)
+	if devices := options.Providers.DeviceProvider; devices != nil {
+		watch.WithGPUSource(deviceGPUs{devices: devices})
+	}
+	if err := registry.RegisterCommand(watch); err != nil {
+		return err
+	}
 
 	if err := registry.RegisterQuery(resource.NewStatusQueryWithEvents(provider, store, events)); err != nil {
 		return err
@@ -452,6 +594,12 @@ func registerResourceDomain(registry *unit.Registry, options *Options) error {
 	if err := registry.RegisterQuery(resource.NewAllocationsQueryWithEvents(store, events)); err != nil {
 		return err
 	}
+	// Services count towards resource.summary when their provider can report
+	// what they claim.
+	allocations, _ := options.Providers.ServiceProvider.(resource.ServiceAllocationProvider)
+	if err := registry.RegisterQuery(resource.NewSummaryQueryWithEvents(provider, allocations, events)); err != nil {
+		return err
+	}
 
 	if provider != nil {
 		if err := registry.RegisterQuery(resource.NewCanAllocateQueryWithEvents(provider, events)); err != nil {
@@ -467,6 +615,82 @@ func registerResourceDomain(registry *unit.Registry, options *Options) error {
 	return nil
 }
 
+// deviceGPUs reports GPU usage from the device provider, for resource
+// providers that only see host memory.
+type deviceGPUs struct {
+	devices device.DeviceProvider
+}
+
+func (g deviceGPUs) GPUs(ctx context.Context) ([]resource.GPUInfo, error) {
+	devices, err := g.devices.Detect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gpus := make([]resource.GPUInfo, 0, len(devices))
+	for _, d := range devices {
+		metrics, err := g.devices.GetMetrics(ctx, d.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get metrics for %s: %w", d.ID, err)
+		}
+		gpus = append(gpus, resource.GPUInfo{
+			Index:       d.Index,
+			Name:        d.Name,
+			Utilization: metrics.Utilization,
+			MemoryTotal: metrics.MemoryTotal,
+			MemoryUsed:  metrics.MemoryUsed,
+			Temperature: metrics.Temperature,
+		})
+	}
+	return gpus, nil
+}
+
+// serviceModelUsage reports the services that are running or starting a
+// model, for model commands that must not touch files being served.
+type serviceModelUsage struct {
+	store service.ServiceStore
+}
+
+func (u serviceModelUsage) ServicesUsingModel(ctx context.Context, modelID string) ([]string, error) {
+	services, _, err := u.store.List(ctx, service.ServiceFilter{ModelID: modelID})
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, svc := range services {
+		if svc.Status == service.ServiceStatusRunning || svc.Status == service.ServiceStatusCreating {
+			ids = append(ids, svc.ID)
+		}
+	}
+	return ids, nil
+}
+
+// ServicesReferencingModel returns every service configured with the model,
+// running or not.
+func (u serviceModelUsage) ServicesReferencingModel(ctx context.Context, modelID string) ([]string, error) {
+	services, _, err := u.store.List(ctx, service.ServiceFilter{ModelID: modelID})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(services))
+	for _, svc := range services {
+		ids = append(ids, svc.ID)
+	}
+	return ids, nil
+}
+
+// subscribeModelUsage records the model of every completed inference request
+// as used, so model.prune can tell stale models from busy ones.
+func subscribeModelUsage(bus eventbus.EventBus, store model.ModelStore) (eventbus.SubscriptionID, error) {
+	return bus.Subscribe(func(event unit.Event) error {
+		payload, ok := event.Payload().(map[string]any)
+		if !ok {
+			return nil
+		}
+		ref, _ := payload["model"].(string)
+		return model.RecordUsage(context.Background(), store, ref, event.Timestamp())
+	}, eventbus.FilterByType(inference.EventTypeRequestCompleted))
+}
+
 func registerServiceDomain(registry *unit.Registry, options *Options) error {
 	store := options.Stores.ServiceStore
 	provider := options.Providers.ServiceProvider
@@ -491,6 +715,15 @@ func registerServiceDomain(registry *unit.Registry, options *Options) error {
 	if err := registry.RegisterCommand(service.NewStopCommandWithEvents(store, provider, events)); err != nil {
 		return err
 	}
+	if err := registry.RegisterCommand(service.NewExecCommandWithEvents(store, provider, events)); err != nil {
+		return err
+	}
+	if err := registry.RegisterCommand(service.NewPinCommandWithEvents(store, events)); err != nil {
+		return err
+	}
+	if err := registry.RegisterCommand(service.NewUnpinCommandWithEvents(store, events)); err != nil {
+		return err
+	}
 
 	if err := registry.RegisterQuery(service.NewGetQueryWithEvents(store, provider, events)); err != nil {
 		return err
@@ -508,6 +741,12 @@ func registerServiceDomain(registry *unit.Registry, options *Options) error {
 		if err := registry.RegisterQuery(service.NewLogsQueryWithEvents(store, provider, events)); err != nil {
 			return err
 		}
+		if err := registry.RegisterQuery(service.NewDiscoverQueryWithEvents(store, provider, events)); err != nil {
+			return err
+		}
+		if err := registry.RegisterQuery(service.NewDescribeQueryWithEvents(store, provider, events)); err != nil {
+			return err
+		}
 	}
 
 	// Register ResourceFactory for dynamic resource creation
@@ -731,6 +970,9 @@ func registerCatalogDomain(registry *unit.Registry, options *Options) error {
 	if err := registry.RegisterCommand(catalog.NewApplyRecipeCommandWithEvents(store, events)); err != nil {
 		return err
 	}
+	if err := registry.RegisterCommand(catalog.NewReloadCommandWithEvents(options.EngineAssetReloader, events)); err != nil {
+		return err
+	}
 
 	if err := registry.RegisterQuery(catalog.NewMatchQueryWithEvents(store, events)); err != nil {
 		return err
@@ -745,6 +987,19 @@ func registerCatalogDomain(registry *unit.Registry, options *Options) error {
 		return err
 	}
 
+	// The curated model catalog is embedded; catalog.pull reuses model.pull so
+	// progress events and store bookkeeping stay in one place.
+	modelAssets, err := catalog.LoadModelAssetsFromFS(catalogdata.ModelFS, "models")
+	if err != nil {
+		return fmt.Errorf("load embedded model catalog: %w", err)
+	}
+	if err := registry.RegisterQuery(catalog.NewModelsQueryWithEvents(modelAssets, events)); err != nil {
+		return err
+	}
+	if err := registry.RegisterCommand(catalog.NewPullCommandWithEvents(modelAssets, registry.GetCommand("model.pull"), events)); err != nil {
+		return err
+	}
+
 	if err := registry.RegisterResource(catalog.NewRecipesResource(store)); err != nil {
 		return err
 	}
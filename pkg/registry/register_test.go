@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/provider"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/alert"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/app"
@@ -247,6 +248,32 @@ func TestWithDeviceProvider(t *testing.T) {
 	}
 }
 
+func TestResourceWatchSamplesDeviceGPUs(t *testing.T) {
+	// The system resource provider reports host memory only, so GPUs must
+	// come from the device provider.
+	registry := unit.NewRegistry()
+	devices := device.NewMockProvider()
+	err := RegisterAll(registry,
+		WithResourceProvider(provider.NewSystemResourceProvider()),
+		WithDeviceProvider(devices),
+	)
+	if err != nil {
+		t.Fatalf("RegisterAll() error = %v", err)
+	}
+
+	result, err := registry.GetCommand("resource.watch").Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("resource.watch error = %v", err)
+	}
+	gpus := result.(map[string]any)["gpus"].([]map[string]any)
+	if len(gpus) != 1 {
+		t.Fatalf("expected the mock GPU, got %v", gpus)
+	}
+	if gpus[0]["name"] != "Mock GPU" || gpus[0]["utilization"] != devices.Metrics.Utilization {
+		t.Errorf("unexpected GPU sample %v", gpus[0])
+	}
+}
+
 func TestWithInferenceProvider(t *testing.T) {
 	registry := unit.NewRegistry()
 	provider := inference.NewMockProvider()
@@ -278,6 +305,9 @@ func TestWithServiceProvider(t *testing.T) {
 	if registry.GetQuery("service.recommend") == nil {
 		t.Error("Expected service.recommend query with provider")
 	}
+	if registry.GetQuery("service.discover") == nil {
+		t.Error("Expected service.discover query with provider")
+	}
 }
 
 func TestWithAppProvider(t *testing.T) {
@@ -0,0 +1,302 @@
+// Package billing prices completed inference requests and aggregates their
+// token usage and cost per principal.
+package billing
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/eventbus"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+)
+
+// Price is the cost of one million prompt and completion tokens.
+type Price struct {
+	PromptPerMillion     float64 `json:"prompt_per_million"`
+	CompletionPerMillion float64 `json:"completion_per_million"`
+}
+
+// Cost returns the price of the given token counts.
+func (p Price) Cost(promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*p.PromptPerMillion + float64(completionTokens)*p.CompletionPerMillion) / 1e6
+}
+
+// PriceTable maps model names to prices. A model matches its exact name
+// first, then the longest key it starts with, then Default.
+type PriceTable struct {
+	Models  map[string]Price `json:"models"`
+	Default Price            `json:"default"`
+}
+
+func (t PriceTable) lookup(model string) Price {
+	if p, ok := t.Models[model]; ok {
+		return p
+	}
+	best, bestLen := t.Default, 0
+	for prefix, p := range t.Models {
+		if len(prefix) > bestLen && strings.HasPrefix(model, prefix) {
+			best, bestLen = p, len(prefix)
+		}
+	}
+	return best
+}
+
+// Record is one priced request.
+type Record struct {
+	RequestID        string
+	Principal        string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Cost             float64
+	Timestamp        time.Time
+}
+
+// Summary aggregates the records of one principal.
+type Summary struct {
+	Principal        string  `json:"principal"`
+	Requests         int     `json:"requests"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	TotalTokens      int     `json:"total_tokens"`
+	Cost             float64 `json:"cost"`
+}
+
+func (s *Summary) add(r Record) {
+	s.Requests++
+	s.PromptTokens += r.PromptTokens
+	s.CompletionTokens += r.CompletionTokens
+	s.TotalTokens += r.TotalTokens
+	s.Cost += r.Cost
+}
+
+func (s *Summary) merge(o Summary) {
+	s.Requests += o.Requests
+	s.PromptTokens += o.PromptTokens
+	s.CompletionTokens += o.CompletionTokens
+	s.TotalTokens += o.TotalTokens
+	s.Cost += o.Cost
+}
+
+// Report is the usage of a time range. Partial is set when the range starts
+// or ends inside a UTC day whose dropped records only survive as a daily
+// total: those totals cannot be split, so they are left out and the report
+// undercounts that day.
+type Report struct {
+	Principals []Summary
+	Partial    bool
+}
+
+// rollupKey identifies the dropped records of one principal on one UTC day.
+type rollupKey struct {
+	principal string
+	day       time.Time
+}
+
+// rollupPeriod is the resolution dropped records are kept at.
+const rollupPeriod = 24 * time.Hour
+
+// DefaultMaxRecords is how many records a ledger keeps unless told
+// otherwise.
+const DefaultMaxRecords = 100000
+
+// Ledger prices inference.request_completed events and keeps the records
+// in memory for usage queries. Once it holds maxRecords records, each new
+// one moves the oldest into a per-principal total for its UTC day, so usage
+// keeps counting it at a daily rather than per-request resolution.
+type Ledger struct {
+	mu         sync.RWMutex
+	prices     PriceTable
+	records    []Record
+	maxRecords int
+	rollups    map[rollupKey]*Summary
+}
+
+func NewLedger(prices PriceTable) *Ledger {
+	return &Ledger{prices: prices, maxRecords: DefaultMaxRecords}
+}
+
+// WithMaxRecords caps the records the ledger keeps. A non-positive n keeps
+// every record.
+func (l *Ledger) WithMaxRecords(n int) *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxRecords = n
+	l.trim()
+	return l
+}
+
+// Subscribe feeds the ledger every completed inference request published
+// on bus.
+func (l *Ledger) Subscribe(bus eventbus.EventBus) (eventbus.SubscriptionID, error) {
+	return bus.Subscribe(l.HandleEvent, eventbus.FilterByType(inference.EventTypeRequestCompleted))
+}
+
+// HandleEvent records a completed request. Requests without a principal are
+// attributed to their correlation ID. Events that only report a total are
+// charged at the prompt rate.
+func (l *Ledger) HandleEvent(event unit.Event) error {
+	if event.Type() != inference.EventTypeRequestCompleted {
+		return nil
+	}
+	payload, ok := event.Payload().(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	rec := Record{
+		Timestamp:        event.Timestamp(),
+		PromptTokens:     toInt(payload["prompt_tokens"]),
+		CompletionTokens: toInt(payload["completion_tokens"]),
+		TotalTokens:      toInt(payload["total_tokens"]),
+	}
+	rec.RequestID, _ = payload["request_id"].(string)
+	rec.Model, _ = payload["model"].(string)
+	rec.Principal, _ = payload["principal"].(string)
+	if rec.Principal == "" {
+		rec.Principal = event.CorrelationID()
+	}
+	if rec.PromptTokens == 0 && rec.CompletionTokens == 0 {
+		rec.PromptTokens = rec.TotalTokens
+	}
+	if rec.TotalTokens == 0 {
+		rec.TotalTokens = rec.PromptTokens + rec.CompletionTokens
+	}
+
+	l.Record(rec)
+	return nil
+}
+
+// Record prices rec with the ledger's price table and stores it.
+func (l *Ledger) Record(rec Record) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rec.Cost = l.prices.lookup(rec.Model).Cost(rec.PromptTokens, rec.CompletionTokens)
+	l.records = append(l.records, rec)
+	l.trim()
+}
+
+// trim rolls the oldest records over the cap into their daily totals.
+// Re-slicing leaves the old backing array to be freed when append next
+// grows the slice.
+func (l *Ledger) trim() {
+	if l.maxRecords <= 0 || len(l.records) <= l.maxRecords {
+		return
+	}
+	excess := len(l.records) - l.maxRecords
+	if l.rollups == nil {
+		l.rollups = make(map[rollupKey]*Summary)
+	}
+	for _, rec := range l.records[:excess] {
+		key := rollupKey{principal: rec.Principal, day: rec.Timestamp.UTC().Truncate(rollupPeriod)}
+		s, ok := l.rollups[key]
+		if !ok {
+			s = &Summary{Principal: rec.Principal}
+			l.rollups[key] = s
+		}
+		s.add(rec)
+	}
+	l.records = l.records[excess:]
+}
+
+// Usage aggregates the records in [from, to) per principal, sorted by
+// principal. A zero from or to leaves that end of the range open; a
+// non-empty principal restricts the result to that principal. Dropped
+// records count through their daily totals; see UsageReport.
+func (l *Ledger) Usage(from, to time.Time, principal string) []Summary {
+	return l.UsageReport(from, to, principal).Principals
+}
+
+// UsageReport is Usage that also reports whether the range cuts through a
+// day of dropped records, whose total it then cannot include.
+func (l *Ledger) UsageReport(from, to time.Time, principal string) Report {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var report Report
+	byPrincipal := make(map[string]*Summary)
+	summary := func(name string) *Summary {
+		s, ok := byPrincipal[name]
+		if !ok {
+			s = &Summary{Principal: name}
+			byPrincipal[name] = s
+		}
+		return s
+	}
+
+	for _, rec := range l.records {
+		if !from.IsZero() && rec.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !rec.Timestamp.Before(to) {
+			continue
+		}
+		if principal != "" && rec.Principal != principal {
+			continue
+		}
+		summary(rec.Principal).add(rec)
+	}
+
+	for key, total := range l.rollups {
+		if principal != "" && key.principal != principal {
+			continue
+		}
+		start, end := key.day, key.day.Add(rollupPeriod)
+		if (!from.IsZero() && !end.After(from)) || (!to.IsZero() && !start.Before(to)) {
+			continue // the day lies outside the range
+		}
+		if (!from.IsZero() && start.Before(from)) || (!to.IsZero() && end.After(to)) {
+			report.Partial = true
+			continue
+		}
+		summary(key.principal).merge(*total)
+	}
+
+	report.Principals = make([]Summary, 0, len(byPrincipal))
+	for _, s := range byPrincipal {
+		report.Principals = append(report.Principals, *s)
+	}
+	sort.Slice(report.Principals, func(i, j int) bool { return report.Principals[i].Principal < report.Principals[j].Principal })
+	return report
+}
+
+// Prune drops records older than before, and daily totals of days that end
+// by then, and returns how many requests were removed.
+func (l *Ledger) Prune(before time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	kept := l.records[:0]
+	for _, rec := range l.records {
+		if !rec.Timestamp.Before(before) {
+			kept = append(kept, rec)
+		}
+	}
+	removed := len(l.records) - len(kept)
+	l.records = kept
+
+	for key, total := range l.rollups {
+		if !key.day.Add(rollupPeriod).After(before) {
+			removed += total.Requests
+			delete(l.rollups, key)
+		}
+	}
+	return removed
+}
+
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
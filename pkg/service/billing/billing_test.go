@@ -0,0 +1,182 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/eventbus"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+)
+
+var testPrices = PriceTable{
+	Models: map[string]Price{
+		"llama3":    {PromptPerMillion: 1, CompletionPerMillion: 2},
+		"llama3:8b": {PromptPerMillion: 0.5, CompletionPerMillion: 1},
+	},
+	Default: Price{PromptPerMillion: 10, CompletionPerMillion: 10},
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestLedger_AggregatesPublishedEvents(t *testing.T) {
+	ledger := NewLedger(testPrices)
+	bus := eventbus.NewInMemoryEventBus()
+	if _, err := ledger.Subscribe(bus); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	start := time.Now().Add(-time.Minute)
+	events := []*inference.RequestCompletedEvent{
+		inference.NewRequestCompletedMetricsEvent("c1", inference.RequestMetrics{RequestID: "r1", Model: "llama3:8b", Principal: "team-a", Duration: time.Second, Usage: inference.Usage{PromptTokens: 1000, CompletionTokens: 500, TotalTokens: 1500}}),
+		inference.NewRequestCompletedMetricsEvent("c2", inference.RequestMetrics{RequestID: "r2", Model: "llama3:70b", Principal: "team-a", Duration: time.Second, Usage: inference.Usage{PromptTokens: 2000, CompletionTokens: 1000, TotalTokens: 3000}}),
+		inference.NewRequestCompletedMetricsEvent("c3", inference.RequestMetrics{RequestID: "r3", Model: "qwen2", Principal: "team-b", Duration: time.Second, Usage: inference.Usage{PromptTokens: 4000, TotalTokens: 4000}}),
+		inference.NewRequestCompletedMetricsEvent("c4", inference.RequestMetrics{RequestID: "r4", Model: "llama3", Duration: time.Second, Usage: inference.Usage{PromptTokens: 100, CompletionTokens: 100, TotalTokens: 200}}),
+	}
+	for _, evt := range events {
+		if err := bus.Publish(evt); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	// Events that are not completions are ignored.
+	_ = bus.Publish(inference.NewRequestFailedEvent("r5", "boom"))
+	// Close drains queued events before returning.
+	_ = bus.Close()
+
+	out, err := NewUsageQuery(ledger).Execute(context.Background(), map[string]any{
+		"from": start.Format(time.RFC3339),
+		"to":   time.Now().Add(time.Minute).Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	output := out.(map[string]any)
+	principals := output["principals"].([]map[string]any)
+	if output["partial"] != false {
+		t.Errorf("expected a complete report, got partial=%v", output["partial"])
+	}
+
+	want := []struct {
+		principal string
+		requests  int
+		tokens    int
+		cost      float64
+	}{
+		{"c4", 1, 200, (100*1 + 100*2) / 1e6},
+		// llama3:8b matches exactly; llama3:70b falls back to the "llama3" prefix.
+		{"team-a", 2, 4500, (1000*0.5 + 500*1 + 2000*1 + 1000*2) / 1e6},
+		{"team-b", 1, 4000, 4000 * 10 / 1e6},
+	}
+	if len(principals) != len(want) {
+		t.Fatalf("expected %d principals, got %d: %v", len(want), len(principals), principals)
+	}
+	for i, w := range want {
+		got := principals[i]
+		if got["principal"] != w.principal || got["requests"] != w.requests || got["total_tokens"] != w.tokens {
+			t.Errorf("principal %d = %v, want %+v", i, got, w)
+		}
+		if !approxEqual(got["cost"].(float64), w.cost) {
+			t.Errorf("%s cost = %v, want %v", w.principal, got["cost"], w.cost)
+		}
+	}
+
+	total := output["total"].(map[string]any)
+	if total["requests"] != 4 || total["total_tokens"] != 8700 {
+		t.Errorf("unexpected total: %v", total)
+	}
+	if !approxEqual(total["cost"].(float64), 0.0453) {
+		t.Errorf("total cost = %v, want 0.0453", total["cost"])
+	}
+}
+
+func TestLedger_UsageTimeRange(t *testing.T) {
+	ledger := NewLedger(testPrices)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	ledger.Record(Record{Principal: "team-a", Model: "llama3", PromptTokens: 10, TotalTokens: 10, Timestamp: day.Add(-time.Hour)})
+	ledger.Record(Record{Principal: "team-a", Model: "llama3", PromptTokens: 20, TotalTokens: 20, Timestamp: day})
+	ledger.Record(Record{Principal: "team-b", Model: "llama3", PromptTokens: 40, TotalTokens: 40, Timestamp: day.Add(time.Hour)})
+	ledger.Record(Record{Principal: "team-a", Model: "llama3", PromptTokens: 80, TotalTokens: 80, Timestamp: day.Add(24 * time.Hour)})
+
+	got := ledger.Usage(day, day.Add(24*time.Hour), "")
+	if len(got) != 2 || got[0].TotalTokens != 20 || got[1].TotalTokens != 40 {
+		t.Errorf("unexpected usage in range: %+v", got)
+	}
+
+	got = ledger.Usage(time.Time{}, time.Time{}, "team-a")
+	if len(got) != 1 || got[0].Requests != 3 || got[0].TotalTokens != 110 {
+		t.Errorf("unexpected usage for team-a: %+v", got)
+	}
+
+	if removed := ledger.Prune(day); removed != 1 {
+		t.Errorf("Prune removed %d records, want 1", removed)
+	}
+	if got := ledger.Usage(time.Time{}, time.Time{}, "team-a"); got[0].Requests != 2 {
+		t.Errorf("expected 2 team-a records after prune, got %+v", got)
+	}
+}
+
+func TestLedger_MaxRecordsRollsUpOldest(t *testing.T) {
+	ledger := NewLedger(testPrices).WithMaxRecords(2)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 3; i++ {
+		ledger.Record(Record{Principal: "team-a", Model: "llama3", PromptTokens: i, TotalTokens: i, Timestamp: day.Add(time.Duration(i) * time.Hour)})
+	}
+	if len(ledger.records) != 2 {
+		t.Fatalf("expected 2 records kept, got %d", len(ledger.records))
+	}
+
+	report := ledger.UsageReport(time.Time{}, time.Time{}, "team-a")
+	if report.Partial || len(report.Principals) != 1 || report.Principals[0].Requests != 3 || report.Principals[0].TotalTokens != 6 {
+		t.Errorf("expected the dropped record to still count, got %+v", report)
+	}
+	report = ledger.UsageReport(day, day.Add(24*time.Hour), "")
+	if report.Partial || len(report.Principals) != 1 || report.Principals[0].Requests != 3 {
+		t.Errorf("expected a whole-day range to count the dropped record, got %+v", report)
+	}
+
+	report = ledger.UsageReport(day.Add(2*time.Hour), time.Time{}, "team-a")
+	if !report.Partial || len(report.Principals) != 1 || report.Principals[0].Requests != 2 {
+		t.Errorf("expected a range cutting the rolled-up day to be partial, got %+v", report)
+	}
+	if report := ledger.UsageReport(day.Add(2*time.Hour), time.Time{}, "team-b"); report.Partial {
+		t.Errorf("expected another principal's daily total not to mark the report partial, got %+v", report)
+	}
+
+	ledger.WithMaxRecords(0)
+	ledger.Record(Record{Principal: "team-a", Model: "llama3", PromptTokens: 4, TotalTokens: 4, Timestamp: day})
+	if got := ledger.Usage(time.Time{}, time.Time{}, "team-a"); got[0].Requests != 4 {
+		t.Errorf("expected no cap after WithMaxRecords(0), got %+v", got)
+	}
+
+	if removed := ledger.Prune(day.Add(24 * time.Hour)); removed != 4 {
+		t.Errorf("expected pruning the day to remove all 4 requests, got %d", removed)
+	}
+	if got := ledger.Usage(time.Time{}, time.Time{}, ""); len(got) != 0 {
+		t.Errorf("expected nothing left after pruning, got %+v", got)
+	}
+}
+
+func TestLedger_TotalOnlyEventChargedAtPromptRate(t *testing.T) {
+	ledger := NewLedger(testPrices)
+	_ = ledger.HandleEvent(inference.NewRequestCompletedEvent("r1", time.Second, 1000))
+
+	got := ledger.Usage(time.Time{}, time.Time{}, "")
+	if len(got) != 1 || got[0].TotalTokens != 1000 || !approxEqual(got[0].Cost, 1000*10/1e6) {
+		t.Errorf("unexpected usage: %+v", got)
+	}
+}
+
+func TestUsageQuery_Errors(t *testing.T) {
+	if _, err := NewUsageQuery(nil).Execute(context.Background(), map[string]any{}); !errors.Is(err, ErrLedgerNotSet) {
+		t.Errorf("expected ErrLedgerNotSet, got %v", err)
+	}
+
+	_, err := NewUsageQuery(NewLedger(PriceTable{})).Execute(context.Background(), map[string]any{"from": "yesterday"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+var (
+	ErrLedgerNotSet = unit.NewError(unit.ErrCodeInternalError, "billing ledger not set")
+	ErrInvalidInput = unit.NewError(unit.ErrCodeInvalidInput, "invalid input")
+)
+
+type UsageQuery struct {
+	ledger *Ledger
+	events unit.EventPublisher
+}
+
+func NewUsageQuery(ledger *Ledger) *UsageQuery {
+	return &UsageQuery{ledger: ledger}
+}
+
+func NewUsageQueryWithEvents(ledger *Ledger, events unit.EventPublisher) *UsageQuery {
+	return &UsageQuery{ledger: ledger, events: events}
+}
+
+func (q *UsageQuery) Name() string {
+	return "billing.usage"
+}
+
+func (q *UsageQuery) Domain() string {
+	return "billing"
+}
+
+func (q *UsageQuery) Description() string {
+	return "Aggregate inference token usage and cost per principal over a time range"
+}
+
+func (q *UsageQuery) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"from": {
+				Name: "from",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Start of the range, inclusive (RFC3339)",
+				},
+			},
+			"to": {
+				Name: "to",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "End of the range, exclusive (RFC3339)",
+				},
+			},
+			"principal": {
+				Name: "principal",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Only report this principal or correlation ID",
+				},
+			},
+		},
+	}
+}
+
+func (q *UsageQuery) OutputSchema() unit.Schema {
+	summary := unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"principal":         {Name: "principal", Schema: unit.Schema{Type: "string"}},
+			"requests":          {Name: "requests", Schema: unit.Schema{Type: "number"}},
+			"prompt_tokens":     {Name: "prompt_tokens", Schema: unit.Schema{Type: "number"}},
+			"completion_tokens": {Name: "completion_tokens", Schema: unit.Schema{Type: "number"}},
+			"total_tokens":      {Name: "total_tokens", Schema: unit.Schema{Type: "number"}},
+			"cost":              {Name: "cost", Schema: unit.Schema{Type: "number"}},
+		},
+	}
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"principals": {
+				Name:   "principals",
+				Schema: unit.Schema{Type: "array", Items: &summary},
+			},
+			"total": {Name: "total", Schema: summary},
+			"partial": {
+				Name: "partial",
+				Schema: unit.Schema{
+					Type:        "boolean",
+					Description: "The range starts or ends inside a day whose older requests are only kept as a daily total, which is left out",
+				},
+			},
+		},
+	}
+}
+
+func (q *UsageQuery) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input: map[string]any{"from": "2026-01-01T00:00:00Z", "to": "2026-02-01T00:00:00Z"},
+			Output: map[string]any{
+				"principals": []map[string]any{{"principal": "team-search", "requests": 120, "prompt_tokens": 48000, "completion_tokens": 12000, "total_tokens": 60000, "cost": 0.42}},
+				"total":      map[string]any{"requests": 120, "prompt_tokens": 48000, "completion_tokens": 12000, "total_tokens": 60000, "cost": 0.42},
+				"partial":    false,
+			},
+			Description: "Usage and cost for January",
+		},
+	}
+}
+
+func (q *UsageQuery) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(q.events, q.Domain(), q.Name())
+	ec.PublishStarted(input)
+
+	if q.ledger == nil {
+		err := ErrLedgerNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, _ := input.(map[string]any)
+
+	from, err := parseTime(inputMap, "from")
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, err
+	}
+	to, err := parseTime(inputMap, "to")
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, err
+	}
+	principal, _ := inputMap["principal"].(string)
+
+	report := q.ledger.UsageReport(from, to, principal)
+
+	var total Summary
+	principals := make([]map[string]any, len(report.Principals))
+	for i, s := range report.Principals {
+		principals[i] = summaryMap(s)
+		total.Requests += s.Requests
+		total.PromptTokens += s.PromptTokens
+		total.CompletionTokens += s.CompletionTokens
+		total.TotalTokens += s.TotalTokens
+		total.Cost += s.Cost
+	}
+	totalMap := summaryMap(total)
+	delete(totalMap, "principal")
+
+	output := map[string]any{
+		"principals": principals,
+		"total":      totalMap,
+		"partial":    report.Partial,
+	}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+func parseTime(inputMap map[string]any, key string) (time.Time, error) {
+	s, _ := inputMap[key].(string)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be RFC3339: %w", key, ErrInvalidInput)
+	}
+	return t, nil
+}
+
+func summaryMap(s Summary) map[string]any {
+	return map[string]any{
+		"principal":         s.Principal,
+		"requests":          s.Requests,
+		"prompt_tokens":     s.PromptTokens,
+		"completion_tokens": s.CompletionTokens,
+		"total_tokens":      s.TotalTokens,
+		"cost":              s.Cost,
+	}
+}
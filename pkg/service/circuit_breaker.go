@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitCooldown         = 30 * time.Second
+)
+
+type circuit struct {
+	state         CircuitState
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// CircuitBreaker tracks consecutive failures per engine endpoint. After
+// threshold failures the endpoint's circuit opens and calls are rejected with
+// ErrEngineNotAvailable until the cooldown elapses; then a single probe call
+// is let through (half-open) and its outcome closes or re-opens the circuit.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = DefaultCircuitFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitCooldown
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+func (b *CircuitBreaker) get(endpoint string) *circuit {
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[endpoint] = c
+	}
+	return c
+}
+
+// State returns the current state of the endpoint's circuit. An open circuit
+// whose cooldown has elapsed is reported as half-open.
+func (b *CircuitBreaker) State(endpoint string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && b.now().Sub(c.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// Available reports whether a call to endpoint would currently be admitted.
+// Unlike Allow it does not reserve the half-open probe, so routers can use it
+// to skip open endpoints without side effects.
+func (b *CircuitBreaker) Available(endpoint string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		return true
+	}
+	switch c.state {
+	case CircuitOpen:
+		return b.now().Sub(c.openedAt) >= b.cooldown
+	case CircuitHalfOpen:
+		return !c.probeInFlight
+	default:
+		return true
+	}
+}
+
+// Allow admits a call to endpoint. When the circuit is half-open only one
+// probe is admitted at a time; its caller must report the outcome with
+// RecordSuccess or RecordFailure.
+func (b *CircuitBreaker) Allow(endpoint string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.get(endpoint)
+	switch c.state {
+	case CircuitOpen:
+		if b.now().Sub(c.openedAt) < b.cooldown {
+			return false
+		}
+		c.state = CircuitHalfOpen
+		c.probeInFlight = true
+		return true
+	case CircuitHalfOpen:
+		if c.probeInFlight {
+			return false
+		}
+		c.probeInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) RecordSuccess(endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.get(endpoint)
+	c.state = CircuitClosed
+	c.failures = 0
+	c.probeInFlight = false
+}
+
+func (b *CircuitBreaker) RecordFailure(endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.get(endpoint)
+	c.probeInFlight = false
+	if c.state == CircuitHalfOpen {
+		c.state = CircuitOpen
+		c.openedAt = b.now()
+		return
+	}
+
+	c.failures++
+	if c.failures >= b.threshold {
+		c.state = CircuitOpen
+		c.openedAt = b.now()
+	}
+}
+
+// release frees a half-open probe without counting it either way, used when
+// the caller gave up before the endpoint could answer.
+func (b *CircuitBreaker) release(endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.circuits[endpoint]; ok {
+		c.probeInFlight = false
+	}
+}
+
+// guardEngine runs call through the breaker for endpoint. A nil breaker runs
+// call directly. Caller cancellation is not counted as an endpoint failure.
+func guardEngine[T any](b *CircuitBreaker, endpoint string, call func() (T, error)) (T, error) {
+	if b == nil {
+		return call()
+	}
+
+	if !b.Allow(endpoint) {
+		var zero T
+		return zero, fmt.Errorf("engine %s circuit open: %w", endpoint, ErrEngineNotAvailable)
+	}
+
+	result, err := call()
+	switch {
+	case err == nil:
+		b.RecordSuccess(endpoint)
+	case errors.Is(err, context.Canceled):
+		b.release(endpoint)
+	default:
+		b.RecordFailure(endpoint)
+	}
+	return result, err
+}
+
+// guardEngines runs call through guardEngine on each engine in turn until one
+// succeeds. It moves on to the next engine on any failure except
+// cancellation, and returns the last error when every engine failed.
+func guardEngines[T any](b *CircuitBreaker, engines []string, call func(engine string) (T, error)) (T, error) {
+	var (
+		result T
+		err    = ErrEngineNotAvailable
+	)
+	for _, name := range engines {
+		result, err = guardEngine(b, name, func() (T, error) {
+			return call(name)
+		})
+		if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return result, err
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	b := NewCircuitBreaker(threshold, cooldown)
+	b.now = clock.Now
+	return b, clock
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		b.RecordFailure("vllm")
+	}
+	if got := b.State("vllm"); got != CircuitClosed {
+		t.Fatalf("expected closed below threshold, got %s", got)
+	}
+
+	b.RecordFailure("vllm")
+	if got := b.State("vllm"); got != CircuitOpen {
+		t.Fatalf("expected open at threshold, got %s", got)
+	}
+	if b.Allow("vllm") {
+		t.Error("expected open circuit to reject calls")
+	}
+	if b.Available("vllm") {
+		t.Error("expected open circuit to be unavailable")
+	}
+	if !b.Allow("ollama") {
+		t.Error("expected other endpoints to be unaffected")
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(2, time.Minute)
+
+	b.RecordFailure("vllm")
+	b.RecordSuccess("vllm")
+	b.RecordFailure("vllm")
+
+	if got := b.State("vllm"); got != CircuitClosed {
+		t.Errorf("expected failures to reset after success, got %s", got)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenAfterCooldown(t *testing.T) {
+	b, clock := newTestBreaker(1, time.Minute)
+
+	b.RecordFailure("vllm")
+	clock.Advance(30 * time.Second)
+	if b.Allow("vllm") {
+		t.Fatal("expected circuit to stay open during cooldown")
+	}
+
+	clock.Advance(31 * time.Second)
+	if got := b.State("vllm"); got != CircuitHalfOpen {
+		t.Fatalf("expected half-open after cooldown, got %s", got)
+	}
+	if !b.Allow("vllm") {
+		t.Fatal("expected a probe to be admitted")
+	}
+	if b.Allow("vllm") {
+		t.Error("expected only one probe in flight")
+	}
+
+	b.RecordSuccess("vllm")
+	if got := b.State("vllm"); got != CircuitClosed {
+		t.Errorf("expected closed after successful probe, got %s", got)
+	}
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	b, clock := newTestBreaker(1, time.Minute)
+
+	b.RecordFailure("vllm")
+	clock.Advance(time.Minute)
+	if !b.Allow("vllm") {
+		t.Fatal("expected a probe to be admitted")
+	}
+
+	b.RecordFailure("vllm")
+	if got := b.State("vllm"); got != CircuitOpen {
+		t.Fatalf("expected open after failed probe, got %s", got)
+	}
+	if b.Allow("vllm") {
+		t.Error("expected cooldown to restart after failed probe")
+	}
+}
+
+func TestGuardEngine(t *testing.T) {
+	b, _ := newTestBreaker(1, time.Minute)
+	callErr := errors.New("connection refused")
+
+	_, err := guardEngine(b, "vllm", func() (int, error) { return 0, callErr })
+	if !errors.Is(err, callErr) {
+		t.Fatalf("expected call error, got %v", err)
+	}
+
+	called := false
+	_, err = guardEngine(b, "vllm", func() (int, error) {
+		called = true
+		return 1, nil
+	})
+	if !errors.Is(err, ErrEngineNotAvailable) {
+		t.Errorf("expected ErrEngineNotAvailable, got %v", err)
+	}
+	if called {
+		t.Error("expected call to be short-circuited")
+	}
+}
+
+func TestGuardEngine_CancelledCallDoesNotTrip(t *testing.T) {
+	b, _ := newTestBreaker(1, time.Minute)
+
+	_, _ = guardEngine(b, "vllm", func() (int, error) { return 0, context.Canceled })
+
+	if got := b.State("vllm"); got != CircuitClosed {
+		t.Errorf("expected cancellation not to count as failure, got %s", got)
+	}
+}
+
+func TestDefaultRouter_SkipsOpenEngines(t *testing.T) {
+	ctx := context.Background()
+	store := engine.NewMemoryStore()
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-a", Name: "ollama-a", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-b", Name: "ollama-b", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+
+	b, clock := newTestBreaker(1, time.Minute)
+	router := NewDefaultRouter(store).WithCircuitBreaker(b)
+
+	b.RecordFailure("ollama-a")
+	for i := 0; i < 10; i++ {
+		name, err := router.SelectEngine(model.ModelTypeLLM, model.FormatGGUF)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "ollama-b" {
+			t.Fatalf("expected open engine to be skipped, got %s", name)
+		}
+	}
+
+	b.RecordFailure("ollama-b")
+	if _, err := router.SelectEngine(model.ModelTypeLLM, model.FormatGGUF); !errors.Is(err, ErrEngineNotAvailable) {
+		t.Errorf("expected ErrEngineNotAvailable with all circuits open, got %v", err)
+	}
+
+	clock.Advance(time.Minute)
+	if _, err := router.SelectEngine(model.ModelTypeLLM, model.FormatGGUF); err != nil {
+		t.Errorf("expected engines to be selectable after cooldown, got %v", err)
+	}
+}
+
+type failingChatProvider struct {
+	*inference.MockProvider
+	err error
+}
+
+func (p *failingChatProvider) Chat(ctx context.Context, modelName string, messages []inference.Message, opts inference.ChatOptions) (*inference.ChatResponse, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.MockProvider.Chat(ctx, modelName, messages, opts)
+}
+
+func TestInferenceService_CircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	modelStore := model.NewMemoryStore()
+	engineStore := engine.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "test-model", Name: "Test Model", Type: model.ModelTypeLLM, Format: model.FormatGGUF})
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+
+	provider := &failingChatProvider{MockProvider: inference.NewMockProvider(), err: errors.New("upstream timeout")}
+	b, clock := newTestBreaker(2, time.Minute)
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), nil, provider).
+		WithCircuitBreaker(b)
+
+	req := ChatRequest{Model: "test-model", Messages: []inference.Message{{Role: "user", Content: "Hi"}}}
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Chat(ctx, req); err == nil {
+			t.Fatal("expected provider error")
+		}
+	}
+
+	if _, err := svc.Chat(ctx, req); !errors.Is(err, ErrEngineNotAvailable) {
+		t.Fatalf("expected ErrEngineNotAvailable once the circuit is open, got %v", err)
+	}
+
+	provider.err = nil
+	clock.Advance(time.Minute)
+	if _, err := svc.Chat(ctx, req); err != nil {
+		t.Fatalf("expected probe to succeed after cooldown, got %v", err)
+	}
+	if got := b.State("ollama"); got != CircuitClosed {
+		t.Errorf("expected circuit closed after successful probe, got %s", got)
+	}
+}
+
+func TestGuardEngines_TriesNextEngine(t *testing.T) {
+	b, _ := newTestBreaker(1, time.Minute)
+	var tried []string
+	name, err := guardEngines(b, []string{"vllm", "ollama"}, func(engine string) (string, error) {
+		tried = append(tried, engine)
+		if engine == "vllm" {
+			return "", errors.New("connection refused")
+		}
+		return engine, nil
+	})
+	if err != nil || name != "ollama" {
+		t.Fatalf("expected fallback to ollama, got %q, %v", name, err)
+	}
+	if len(tried) != 2 {
+		t.Errorf("expected both engines to be tried, got %v", tried)
+	}
+
+	tried = nil
+	_, _ = guardEngines(b, []string{"vllm", "ollama"}, func(engine string) (string, error) {
+		tried = append(tried, engine)
+		return engine, nil
+	})
+	if len(tried) != 1 || tried[0] != "ollama" {
+		t.Errorf("expected open vllm circuit to be skipped, got %v", tried)
+	}
+
+	tried = nil
+	_, err = guardEngines(nil, []string{"vllm", "ollama"}, func(engine string) (string, error) {
+		tried = append(tried, engine)
+		return "", context.Canceled
+	})
+	if !errors.Is(err, context.Canceled) || len(tried) != 1 {
+		t.Errorf("expected cancellation to stop the chain, got %v after %v", err, tried)
+	}
+
+	if _, err := guardEngines(nil, nil, func(string) (string, error) { return "", nil }); !errors.Is(err, ErrEngineNotAvailable) {
+		t.Errorf("expected ErrEngineNotAvailable without engines, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+)
+
+// defaultEngineProbeTimeout bounds a single health request.
+const defaultEngineProbeTimeout = 5 * time.Second
+
+// HTTPEngineProbe checks engines by requesting their health endpoint, so
+// the EngineReconciler sees engines that died after they started.
+//
+// The engine's stored config decides where to probe: "endpoint" is a base
+// URL, otherwise "port" on localhost, otherwise the default port of the
+// engine type. "health_path" overrides the per-type health path.
+type HTTPEngineProbe struct {
+	store       engine.EngineStore
+	client      *http.Client
+	defaultPort func(engineType string) int
+	now         func() time.Time
+}
+
+// NewHTTPEngineProbe returns a probe for the engines in store. defaultPort
+// gives the port of engines started without one; nil probes them on 8080.
+func NewHTTPEngineProbe(store engine.EngineStore, defaultPort func(engineType string) int) *HTTPEngineProbe {
+	if defaultPort == nil {
+		defaultPort = func(string) int { return 8080 }
+	}
+	return &HTTPEngineProbe{
+		store:       store,
+		client:      &http.Client{Timeout: defaultEngineProbeTimeout},
+		defaultPort: defaultPort,
+		now:         time.Now,
+	}
+}
+
+// IsHealthy requests the health endpoint of the engine named name. The
+// engine is healthy when it answers 200; unreachable engines are reported
+// unhealthy rather than as an error.
+func (p *HTTPEngineProbe) IsHealthy(ctx context.Context, name string) (*HealthStatus, error) {
+	e, err := p.store.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("get engine %s: %w", name, err)
+	}
+
+	url := p.endpoint(e) + healthPath(e)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build health request for engine %s: %w", name, err)
+	}
+
+	status := &HealthStatus{Timestamp: p.now().Unix()}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		status.Message = err.Error()
+		return status, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		status.Message = fmt.Sprintf("health check returned %d", resp.StatusCode)
+		return status, nil
+	}
+	status.Healthy = true
+	return status, nil
+}
+
+func (p *HTTPEngineProbe) endpoint(e *engine.Engine) string {
+	if endpoint, ok := e.Config["endpoint"].(string); ok && endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	port := configPort(e.Config["port"])
+	if port <= 0 {
+		port = p.defaultPort(string(e.Type))
+	}
+	return fmt.Sprintf("http://localhost:%d", port)
+}
+
+// healthPath returns the path answering 200 once an engine of e's type
+// serves requests.
+func healthPath(e *engine.Engine) string {
+	if path, ok := e.Config["health_path"].(string); ok && path != "" {
+		return path
+	}
+	switch e.Type {
+	case engine.EngineTypeOllama:
+		return "/api/version"
+	case engine.EngineTypeWhisper, "asr":
+		return "/"
+	default:
+		return "/health"
+	}
+}
+
+// configPort reads a port from engine config, which holds it as a number or
+// a string depending on where the config came from.
+func configPort(v any) int {
+	switch port := v.(type) {
+	case int:
+		return port
+	case int64:
+		return int(port)
+	case float64:
+		return int(port)
+	case string:
+		n, _ := strconv.Atoi(port)
+		return n
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+)
+
+func newProbeStore(t *testing.T, engines ...*engine.Engine) engine.EngineStore {
+	t.Helper()
+	store := engine.NewMemoryStore()
+	for _, e := range engines {
+		if err := store.Create(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func serverPort(t *testing.T, srv *httptest.Server) int {
+	t.Helper()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return port
+}
+
+func TestHTTPEngineProbe_IsHealthy(t *testing.T) {
+	var paths []string
+	healthy := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	store := newProbeStore(t,
+		&engine.Engine{ID: "engine-1", Name: "vllm", Type: engine.EngineTypeVLLM, Config: map[string]any{"port": serverPort(t, srv)}},
+		&engine.Engine{ID: "engine-2", Name: "ollama", Type: engine.EngineTypeOllama, Config: map[string]any{"endpoint": srv.URL + "/"}},
+	)
+	probe := NewHTTPEngineProbe(store, nil)
+
+	for _, name := range []string{"vllm", "ollama"} {
+		status, err := probe.IsHealthy(context.Background(), name)
+		if err != nil {
+			t.Fatalf("IsHealthy(%s) failed: %v", name, err)
+		}
+		if !status.Healthy {
+			t.Errorf("expected %s to be healthy, got %+v", name, status)
+		}
+	}
+	if len(paths) != 2 || paths[0] != "/health" || paths[1] != "/api/version" {
+		t.Errorf("expected the per-type health paths, got %v", paths)
+	}
+
+	healthy = false
+	status, err := probe.IsHealthy(context.Background(), "vllm")
+	if err != nil {
+		t.Fatalf("IsHealthy failed: %v", err)
+	}
+	if status.Healthy || status.Message == "" {
+		t.Errorf("expected a non-200 answer to be unhealthy, got %+v", status)
+	}
+}
+
+func TestHTTPEngineProbe_DefaultPort(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	port := serverPort(t, srv)
+
+	store := newProbeStore(t, &engine.Engine{ID: "engine-1", Name: "tts", Type: engine.EngineTypeTTS})
+	probe := NewHTTPEngineProbe(store, func(engineType string) int {
+		if engineType != "tts" {
+			t.Errorf("default port asked for %q", engineType)
+		}
+		return port
+	})
+
+	status, err := probe.IsHealthy(context.Background(), "tts")
+	if err != nil {
+		t.Fatalf("IsHealthy failed: %v", err)
+	}
+	if !status.Healthy {
+		t.Errorf("expected the engine on its default port to be healthy, got %+v", status)
+	}
+}
+
+func TestHTTPEngineProbe_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	endpoint := srv.URL
+	srv.Close()
+
+	store := newProbeStore(t, &engine.Engine{ID: "engine-1", Name: "vllm", Type: engine.EngineTypeVLLM, Config: map[string]any{"endpoint": endpoint}})
+	probe := NewHTTPEngineProbe(store, nil)
+
+	status, err := probe.IsHealthy(context.Background(), "vllm")
+	if err != nil {
+		t.Fatalf("expected an unreachable engine to be reported, not an error: %v", err)
+	}
+	if status.Healthy {
+		t.Error("expected an unreachable engine to be unhealthy")
+	}
+
+	if _, err := probe.IsHealthy(context.Background(), "missing"); err == nil {
+		t.Error("expected an error for an unknown engine")
+	}
+}
+
+func TestHTTPEngineProbe_ReconcilesDeadEngine(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	endpoint := srv.URL
+	srv.Close()
+
+	store := newProbeStore(t, &engine.Engine{
+		ID: "engine-1", Name: "vllm", Type: engine.EngineTypeVLLM,
+		Status: engine.EngineStatusRunning, Config: map[string]any{"endpoint": endpoint},
+	})
+	r := NewEngineReconciler(store, NewHTTPEngineProbe(store, nil), 0)
+
+	changed, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if len(changed) != 1 || engineStatus(t, store, "vllm") != engine.EngineStatusError {
+		t.Errorf("expected the dead engine to be marked as errored, changed %v", changed)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+)
+
+// DefaultEngineSyncInterval is how often the engine reconciler probes the
+// stored engines when no interval is given.
+const DefaultEngineSyncInterval = 30 * time.Second
+
+// EngineReconciler keeps engine statuses in the store in line with what the
+// engines actually do: a running engine that fails its health probe is
+// marked as errored, and an errored one that passes again as running, so the
+// router stops sending requests to dead engines. Engines that are starting,
+// stopping or stopped belong to the engine commands and are left alone.
+//
+// The health checker must probe the engine itself; EngineService.IsHealthy
+// only reports the stored status.
+type EngineReconciler struct {
+	store    engine.EngineStore
+	health   EngineHealthChecker
+	interval time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewEngineReconciler returns a reconciler probing every interval. A
+// non-positive interval uses DefaultEngineSyncInterval.
+func NewEngineReconciler(store engine.EngineStore, health EngineHealthChecker, interval time.Duration) *EngineReconciler {
+	if interval <= 0 {
+		interval = DefaultEngineSyncInterval
+	}
+	return &EngineReconciler{store: store, health: health, interval: interval, now: time.Now}
+}
+
+// Reconcile probes every running or errored engine once, updates the status
+// of those whose health disagrees with the store and returns their names.
+func (r *EngineReconciler) Reconcile(ctx context.Context) ([]string, error) {
+	engines, _, err := r.store.List(ctx, engine.EngineFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list engines: %w", err)
+	}
+
+	var changed []string
+	for _, e := range engines {
+		if e.Status != engine.EngineStatusRunning && e.Status != engine.EngineStatusError {
+			continue
+		}
+
+		want := engine.EngineStatusError
+		if r.isHealthy(ctx, e.Name) {
+			want = engine.EngineStatusRunning
+		}
+		if want == e.Status {
+			continue
+		}
+
+		// The engine may have been stopped or restarted while it was being
+		// probed; only the status that was probed is overwritten.
+		current, err := r.store.Get(ctx, e.Name)
+		if err != nil || current.Status != e.Status {
+			continue
+		}
+		updated := *current
+		updated.Status = want
+		updated.UpdatedAt = r.now().Unix()
+		if err := r.store.Update(ctx, &updated); err != nil {
+			slog.Warn("failed to update engine status", "engine", e.Name, "status", want, "error", err)
+			continue
+		}
+		slog.Info("engine status reconciled", "engine", e.Name, "from", e.Status, "to", want)
+		changed = append(changed, e.Name)
+	}
+	return changed, nil
+}
+
+func (r *EngineReconciler) isHealthy(ctx context.Context, name string) bool {
+	status, err := r.health.IsHealthy(ctx, name)
+	return err == nil && status != nil && status.Healthy
+}
+
+// Start runs Reconcile every interval in the background until Stop is
+// called or ctx is done. Starting a running reconciler does nothing.
+func (r *EngineReconciler) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	r.cancel, r.done = cancel, done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := r.Reconcile(ctx); err != nil {
+					slog.Warn("engine reconcile failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the background loop and waits for a sweep in progress to
+// finish.
+func (r *EngineReconciler) Stop() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+)
+
+// flippingHealthChecker reports engines healthy until they are marked down.
+// It is safe for the reconciler's background loop.
+type flippingHealthChecker struct {
+	mu   sync.Mutex
+	down map[string]bool
+	err  error
+}
+
+func (c *flippingHealthChecker) setDown(name string, down bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down == nil {
+		c.down = make(map[string]bool)
+	}
+	c.down[name] = down
+}
+
+func (c *flippingHealthChecker) IsHealthy(ctx context.Context, name string) (*HealthStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &HealthStatus{Healthy: !c.down[name]}, nil
+}
+
+func newReconcilerStore(t *testing.T) engine.EngineStore {
+	t.Helper()
+	store := engine.NewMemoryStore()
+	for _, e := range []*engine.Engine{
+		{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning},
+		{ID: "engine-2", Name: "vllm", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning},
+		{ID: "engine-3", Name: "whisper", Type: engine.EngineTypeWhisper, Status: engine.EngineStatusStopped},
+	} {
+		if err := store.Create(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func engineStatus(t *testing.T, store engine.EngineStore, name string) engine.EngineStatus {
+	t.Helper()
+	e, err := store.Get(context.Background(), name)
+	if err != nil {
+		t.Fatalf("get engine %s: %v", name, err)
+	}
+	return e.Status
+}
+
+func TestEngineReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	store := newReconcilerStore(t)
+	health := &flippingHealthChecker{}
+	r := NewEngineReconciler(store, health, time.Minute)
+
+	if changed, err := r.Reconcile(ctx); err != nil || len(changed) != 0 {
+		t.Fatalf("expected nothing to change while healthy, got %v, %v", changed, err)
+	}
+
+	health.setDown("vllm", true)
+	changed, err := r.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"vllm"}) {
+		t.Errorf("expected only vllm to change, got %v", changed)
+	}
+	if got := engineStatus(t, store, "vllm"); got != engine.EngineStatusError {
+		t.Errorf("expected the unhealthy engine to be marked as error, got %s", got)
+	}
+	if got := engineStatus(t, store, "ollama"); got != engine.EngineStatusRunning {
+		t.Errorf("expected the healthy engine to stay running, got %s", got)
+	}
+
+	// An engine that recovers is marked running again.
+	health.setDown("vllm", false)
+	if _, err := r.Reconcile(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := engineStatus(t, store, "vllm"); got != engine.EngineStatusRunning {
+		t.Errorf("expected the recovered engine to be running, got %s", got)
+	}
+}
+
+func TestEngineReconciler_LeavesStoppedEngines(t *testing.T) {
+	store := newReconcilerStore(t)
+	health := &flippingHealthChecker{err: errors.New("connection refused")}
+	r := NewEngineReconciler(store, health, time.Minute)
+
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Probe errors count as unhealthy, but stopped engines are not probed.
+	if got := engineStatus(t, store, "ollama"); got != engine.EngineStatusError {
+		t.Errorf("expected a failing probe to mark the engine as error, got %s", got)
+	}
+	if got := engineStatus(t, store, "whisper"); got != engine.EngineStatusStopped {
+		t.Errorf("expected the stopped engine to be left alone, got %s", got)
+	}
+}
+
+func TestEngineReconciler_StartStop(t *testing.T) {
+	store := newReconcilerStore(t)
+	health := &flippingHealthChecker{}
+	r := NewEngineReconciler(store, health, 5*time.Millisecond)
+
+	r.Start(context.Background())
+	r.Start(context.Background()) // already running
+	health.setDown("ollama", true)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for engineStatus(t, store, "ollama") != engine.EngineStatusError {
+		if time.Now().After(deadline) {
+			r.Stop()
+			t.Fatal("expected the background loop to mark the engine as error")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	r.Stop()
+	health.setDown("ollama", false)
+	time.Sleep(30 * time.Millisecond)
+	if got := engineStatus(t, store, "ollama"); got != engine.EngineStatusError {
+		t.Errorf("expected no reconciling after Stop, got %s", got)
+	}
+	r.Stop() // stopping twice is harmless
+}
+
+func TestNewEngineReconciler_DefaultInterval(t *testing.T) {
+	if r := NewEngineReconciler(engine.NewMemoryStore(), &flippingHealthChecker{}, 0); r.interval != DefaultEngineSyncInterval {
+		t.Errorf("expected the default interval, got %v", r.interval)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+const DefaultAutoPullTimeout = 30 * time.Minute
+
+// ollamaModelName matches names like "llama3", "qwen2.5:7b" or
+// "library/llama3:latest". Paths and multi-segment HuggingFace-style repos
+// are deliberately rejected so only ollama pulls are attempted.
+var ollamaModelName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*(/[a-z0-9][a-z0-9._-]*)?(:[A-Za-z0-9._-]+)?$`)
+
+type autoPuller struct {
+	models  *ModelService
+	timeout time.Duration
+	group   singleflight.Group
+}
+
+// WithAutoPull makes Chat pull missing ollama models through models and
+// continue once the pull succeeds. Concurrent requests for the same model
+// share a single pull. A zero timeout uses DefaultAutoPullTimeout.
+func (s *InferenceService) WithAutoPull(models *ModelService, timeout time.Duration) *InferenceService {
+	if timeout <= 0 {
+		timeout = DefaultAutoPullTimeout
+	}
+	s.autoPull = &autoPuller{models: models, timeout: timeout}
+	return s
+}
+
+func isModelNotFound(err error) bool {
+	return errors.Is(err, ErrModelNotFound) || errors.Is(err, model.ErrModelNotFound)
+}
+
+func splitOllamaName(name string) (repo, tag string) {
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// findByName looks a model up by its ollama name, treating a missing tag as
+// ":latest" the way ollama does.
+func (s *InferenceService) findByName(ctx context.Context, name string) *model.Model {
+	if s.modelStore == nil {
+		return nil
+	}
+	models, _, err := s.modelStore.List(ctx, model.ModelFilter{})
+	if err != nil {
+		return nil
+	}
+	for i := range models {
+		if models[i].Name == name || models[i].Name == name+":latest" {
+			return &models[i]
+		}
+	}
+	return nil
+}
+
+// pullMissingModel resolves a model that getModel could not find. It returns
+// cause unchanged unless auto-pull is enabled, cause is a not-found error and
+// name looks like an ollama model.
+func (s *InferenceService) pullMissingModel(ctx context.Context, name string, cause error) (*model.Model, error) {
+	if s.autoPull == nil || s.autoPull.models == nil || !isModelNotFound(cause) || !ollamaModelName.MatchString(name) {
+		return nil, cause
+	}
+
+	if m := s.findByName(ctx, name); m != nil {
+		return m, nil
+	}
+	return s.autoPullModel(ctx, name)
+}
+
+// pullNotPulled pulls the model named by a ModelNotPulledError in err, which
+// the engine returns for models that are registered but missing from its
+// local store. It reports whether the model was pulled and the request is
+// worth retrying; a failed pull is returned as the error.
+func (s *InferenceService) pullNotPulled(ctx context.Context, err error) (bool, error) {
+	var notPulled *inference.ModelNotPulledError
+	if s.autoPull == nil || s.autoPull.models == nil || !errors.As(err, &notPulled) {
+		return false, nil
+	}
+	if _, err := s.autoPullModel(ctx, notPulled.Model); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// autoPullModel pulls name from ollama through the configured ModelService.
+func (s *InferenceService) autoPullModel(ctx context.Context, name string) (*model.Model, error) {
+	// The pull is detached from the first caller's context so that one caller
+	// giving up does not fail every request waiting on the same pull.
+	resultCh := s.autoPull.group.DoChan(name, func() (any, error) {
+		pullCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.autoPull.timeout)
+		defer cancel()
+
+		models := s.autoPull.models
+		models.publishEvent(pullCtx, "model.auto_pull_started", map[string]any{"model": name})
+
+		repo, tag := splitOllamaName(name)
+		result, err := models.PullAndVerify(pullCtx, "ollama", repo, tag)
+		if err != nil {
+			models.publishEvent(pullCtx, "model.auto_pull_failed", map[string]any{"model": name, "error": err.Error()})
+			return nil, err
+		}
+
+		models.publishEvent(pullCtx, "model.auto_pull_completed", map[string]any{"model": name, "model_id": result.Model.ID})
+		return result.Model, nil
+	})
+
+	select {
+	case res := <-resultCh:
+		if res.Err != nil {
+			return nil, fmt.Errorf("auto-pull model %s: %w", name, res.Err)
+		}
+		return res.Val.(*model.Model), nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("auto-pull model %s: %w", name, ctx.Err())
+	}
+}
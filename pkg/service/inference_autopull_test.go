@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/eventbus"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+)
+
+func newAutoPullFixture(t *testing.T, pull func(ctx context.Context, input any) (any, error)) (*InferenceService, *eventbus.InMemoryEventBus) {
+	t.Helper()
+	ctx := context.Background()
+
+	modelStore := model.NewMemoryStore()
+	engineStore := engine.NewMemoryStore()
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+
+	bus := eventbus.NewInMemoryEventBus()
+	t.Cleanup(func() { _ = bus.Close() })
+
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(&mockCommand{
+		name: "model.pull",
+		execute: func(ctx context.Context, input any) (any, error) {
+			out, err := pull(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			m := &model.Model{ID: "model-pulled", Name: "llama3:latest", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady}
+			_ = modelStore.Create(ctx, m)
+			return out, nil
+		},
+	})
+
+	models := NewModelService(registry, modelStore, &model.MockProvider{}, bus)
+	svc := NewInferenceService(registry, modelStore, engineStore, resource.NewMemoryStore(), nil, inference.NewMockProvider()).
+		WithAutoPull(models, 0)
+	return svc, bus
+}
+
+func TestInferenceService_Chat_AutoPull(t *testing.T) {
+	var pulls atomic.Int32
+	svc, bus := newAutoPullFixture(t, func(ctx context.Context, input any) (any, error) {
+		pulls.Add(1)
+		in := input.(map[string]any)
+		if in["source"] != "ollama" || in["repo"] != "llama3" {
+			t.Errorf("unexpected pull input: %v", in)
+		}
+		return map[string]any{"model_id": "model-pulled", "status": "ready"}, nil
+	})
+
+	var mu sync.Mutex
+	var eventTypes []string
+	sub, _ := bus.Subscribe(func(e unit.Event) error {
+		mu.Lock()
+		eventTypes = append(eventTypes, e.Type())
+		mu.Unlock()
+		return nil
+	})
+	defer func() { _ = bus.Unsubscribe(sub) }()
+
+	req := ChatRequest{Model: "llama3", Messages: []inference.Message{{Role: "user", Content: "Hello"}}}
+	resp, err := svc.Chat(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected chat to succeed after auto-pull, got %v", err)
+	}
+	if resp.Content == "" {
+		t.Error("expected non-empty content")
+	}
+
+	if _, err := svc.Chat(context.Background(), req); err != nil {
+		t.Fatalf("expected second chat to reuse the pulled model, got %v", err)
+	}
+	if got := pulls.Load(); got != 1 {
+		t.Errorf("expected exactly one pull, got %d", got)
+	}
+
+	_ = bus.Close()
+	mu.Lock()
+	defer mu.Unlock()
+	if !containsString(eventTypes, "model.auto_pull_started") || !containsString(eventTypes, "model.auto_pull_completed") {
+		t.Errorf("expected auto-pull progress events, got %v", eventTypes)
+	}
+}
+
+func TestInferenceService_Chat_AutoPullFails(t *testing.T) {
+	pullErr := errors.New("registry unreachable")
+	svc, _ := newAutoPullFixture(t, func(ctx context.Context, input any) (any, error) {
+		return nil, pullErr
+	})
+
+	req := ChatRequest{Model: "llama3", Messages: []inference.Message{{Role: "user", Content: "Hello"}}}
+	_, err := svc.Chat(context.Background(), req)
+	if !errors.Is(err, pullErr) {
+		t.Errorf("expected pull error, got %v", err)
+	}
+}
+
+func TestInferenceService_Chat_AutoPullWhenEngineMissingModel(t *testing.T) {
+	var provider *inference.MockProvider
+	svc, _ := newAutoPullFixture(t, func(ctx context.Context, input any) (any, error) {
+		provider.SetChatError(nil)
+		return map[string]any{"model_id": "model-pulled", "status": "ready"}, nil
+	})
+	provider = svc.inferenceProv.(*inference.MockProvider)
+	provider.SetChatError(&inference.ModelNotPulledError{Model: "llama3"})
+	_ = svc.modelStore.Create(context.Background(), &model.Model{ID: "model-1", Name: "llama3", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady})
+
+	req := ChatRequest{Model: "llama3", Messages: []inference.Message{{Role: "user", Content: "Hello"}}}
+	if _, err := svc.Chat(context.Background(), req); err != nil {
+		t.Fatalf("expected chat to be retried after pulling, got %v", err)
+	}
+}
+
+func TestInferenceService_Chat_AutoPullSkipsNonOllamaNames(t *testing.T) {
+	var pulls atomic.Int32
+	svc, _ := newAutoPullFixture(t, func(ctx context.Context, input any) (any, error) {
+		pulls.Add(1)
+		return map[string]any{"model_id": "model-pulled"}, nil
+	})
+
+	req := ChatRequest{Model: "/models/Qwen2-7B", Messages: []inference.Message{{Role: "user", Content: "Hello"}}}
+	_, err := svc.Chat(context.Background(), req)
+	if !errors.Is(err, model.ErrModelNotFound) {
+		t.Errorf("expected model not found, got %v", err)
+	}
+	if pulls.Load() != 0 {
+		t.Error("expected no pull for a non-ollama model name")
+	}
+}
+
+func TestInferenceService_Chat_AutoPullSharedAcrossCallers(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var pulls atomic.Int32
+	svc, _ := newAutoPullFixture(t, func(ctx context.Context, input any) (any, error) {
+		if pulls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return map[string]any{"model_id": "model-pulled"}, nil
+	})
+
+	req := ChatRequest{Model: "llama3", Messages: []inference.Message{{Role: "user", Content: "Hello"}}}
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.Chat(context.Background(), req)
+			errs <- err
+		}()
+	}
+
+	<-started
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if got := pulls.Load(); got != 1 {
+		t.Errorf("expected a single shared pull, got %d", got)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	unitservice "github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+const DefaultAutoStartTimeout = 5 * time.Minute
+
+// ServiceStarter boots a defined service. With async false it must block
+// until the service is healthy or ctx is done.
+type ServiceStarter interface {
+	StartAsync(ctx context.Context, serviceID string, async bool) error
+}
+
+type autoStarter struct {
+	starter  ServiceStarter
+	services unitservice.ServiceStore
+	timeout  time.Duration
+	group    singleflight.Group
+}
+
+// WithAutoStart makes inference requests start the model's service through
+// starter when it is defined in services but stopped, waiting up to timeout
+// for it to become healthy. Concurrent requests share a single start. A zero
+// timeout uses DefaultAutoStartTimeout.
+func (s *InferenceService) WithAutoStart(starter ServiceStarter, services unitservice.ServiceStore, timeout time.Duration) *InferenceService {
+	if timeout <= 0 {
+		timeout = DefaultAutoStartTimeout
+	}
+	s.autoStart = &autoStarter{starter: starter, services: services, timeout: timeout}
+	return s
+}
+
+// stoppedService returns the model's stopped service, or nil when auto-start
+// is disabled, the model has no service or one is already running.
+func (s *InferenceService) stoppedService(ctx context.Context, modelID string) (*unitservice.ModelService, error) {
+	if s.autoStart == nil || s.autoStart.starter == nil || s.autoStart.services == nil {
+		return nil, nil
+	}
+
+	services, _, err := s.autoStart.services.List(ctx, unitservice.ServiceFilter{ModelID: modelID})
+	if err != nil {
+		return nil, fmt.Errorf("list services for model %s: %w", modelID, err)
+	}
+
+	var stopped *unitservice.ModelService
+	for i := range services {
+		switch services[i].Status {
+		case unitservice.ServiceStatusRunning, unitservice.ServiceStatusCreating:
+			return nil, nil
+		case unitservice.ServiceStatusStopped:
+			if stopped == nil {
+				stopped = &services[i]
+			}
+		}
+	}
+	return stopped, nil
+}
+
+// startStoppedService boots the model's stopped service, if any, before the
+// request is sent to it.
+func (s *InferenceService) startStoppedService(ctx context.Context, modelID string) error {
+	svc, err := s.stoppedService(ctx, modelID)
+	if err != nil || svc == nil {
+		return err
+	}
+
+	timeout := s.autoStart.timeout
+	// As with auto-pull, the start is detached from the first caller so one
+	// caller giving up does not fail the others waiting on it.
+	resultCh := s.autoStart.group.DoChan(svc.ID, func() (any, error) {
+		startCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+
+		if err := s.autoStart.starter.StartAsync(startCtx, svc.ID, false); err != nil {
+			if errors.Is(startCtx.Err(), context.DeadlineExceeded) {
+				return nil, ErrServiceStartTimeout
+			}
+			return nil, err
+		}
+
+		svc.Status = unitservice.ServiceStatusRunning
+		svc.UpdatedAt = time.Now().Unix()
+		if err := s.autoStart.services.Update(startCtx, svc); err != nil {
+			return nil, fmt.Errorf("update service: %w", err)
+		}
+		return nil, nil
+	})
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-resultCh:
+		if res.Err != nil {
+			return fmt.Errorf("auto-start service %s: %w", svc.ID, res.Err)
+		}
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("auto-start service %s: %w", svc.ID, ErrServiceStartTimeout)
+	case <-ctx.Done():
+		return fmt.Errorf("auto-start service %s: %w", svc.ID, ctx.Err())
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+	unitservice "github.com/jguan/ai-inference-managed-by-ai/pkg/unit/service"
+)
+
+type fakeStarter struct {
+	calls atomic.Int32
+	start func(ctx context.Context, serviceID string) error
+}
+
+func (f *fakeStarter) StartAsync(ctx context.Context, serviceID string, async bool) error {
+	f.calls.Add(1)
+	if async {
+		return errors.New("expected a blocking start")
+	}
+	if f.start == nil {
+		return nil
+	}
+	return f.start(ctx, serviceID)
+}
+
+func newAutoStartFixture(t *testing.T, starter *fakeStarter, timeout time.Duration) (*InferenceService, unitservice.ServiceStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	modelStore := model.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "test-model", Name: "Test Model", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady})
+	engineStore := engine.NewMemoryStore()
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+
+	services := unitservice.NewMemoryStore()
+	_ = services.Create(ctx, &unitservice.ModelService{ID: "svc-ollama-test-model", ModelID: "test-model", Status: unitservice.ServiceStatusStopped})
+
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), nil, inference.NewMockProvider()).
+		WithAutoStart(starter, services, timeout)
+	return svc, services
+}
+
+func chatRequest() ChatRequest {
+	return ChatRequest{Model: "test-model", Messages: []inference.Message{{Role: "user", Content: "Hello"}}}
+}
+
+func TestInferenceService_Chat_AutoStartsStoppedService(t *testing.T) {
+	starter := &fakeStarter{}
+	svc, services := newAutoStartFixture(t, starter, time.Second)
+
+	resp, err := svc.Chat(context.Background(), chatRequest())
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if resp.Content == "" {
+		t.Error("expected the request to be served after the start")
+	}
+	if starter.calls.Load() != 1 {
+		t.Errorf("expected 1 start, got %d", starter.calls.Load())
+	}
+
+	stored, _ := services.Get(context.Background(), "svc-ollama-test-model")
+	if stored.Status != unitservice.ServiceStatusRunning {
+		t.Errorf("expected service to be running, got %s", stored.Status)
+	}
+
+	// The service is running now, so later requests skip the start.
+	if _, err := svc.Chat(context.Background(), chatRequest()); err != nil {
+		t.Fatalf("second Chat failed: %v", err)
+	}
+	if starter.calls.Load() != 1 {
+		t.Errorf("expected no further starts, got %d", starter.calls.Load())
+	}
+}
+
+func TestInferenceService_Chat_AutoStartTimeout(t *testing.T) {
+	starter := &fakeStarter{start: func(ctx context.Context, serviceID string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	svc, services := newAutoStartFixture(t, starter, 20*time.Millisecond)
+
+	_, err := svc.Chat(context.Background(), chatRequest())
+	if !errors.Is(err, ErrServiceStartTimeout) {
+		t.Fatalf("expected ErrServiceStartTimeout, got %v", err)
+	}
+
+	stored, _ := services.Get(context.Background(), "svc-ollama-test-model")
+	if stored.Status != unitservice.ServiceStatusStopped {
+		t.Errorf("expected service to stay stopped, got %s", stored.Status)
+	}
+}
+
+func TestInferenceService_Chat_AutoStartSharedAcrossCallers(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var once sync.Once
+	starter := &fakeStarter{start: func(ctx context.Context, serviceID string) error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	}}
+	svc, _ := newAutoStartFixture(t, starter, time.Second)
+
+	const callers = 5
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			_, err := svc.Chat(context.Background(), chatRequest())
+			errs <- err
+		}()
+	}
+
+	<-started
+	// Give the remaining callers time to join the in-flight start.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < callers; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Chat failed: %v", err)
+		}
+	}
+	if starter.calls.Load() != 1 {
+		t.Errorf("expected a single shared start, got %d", starter.calls.Load())
+	}
+}
+
+func TestInferenceService_Chat_AutoStartSkipsRunningService(t *testing.T) {
+	starter := &fakeStarter{}
+	svc, services := newAutoStartFixture(t, starter, time.Second)
+	_ = services.Create(context.Background(), &unitservice.ModelService{ID: "svc-vllm-test-model", ModelID: "test-model", Status: unitservice.ServiceStatusRunning})
+
+	if _, err := svc.Chat(context.Background(), chatRequest()); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if starter.calls.Load() != 0 {
+		t.Errorf("expected no start while a service is running, got %d", starter.calls.Load())
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+)
+
+// DefaultEmbedBatchSize caps embedding batches for engines that report no
+// MaxBatchSize of their own.
+const DefaultEmbedBatchSize = 32
+
+// embedBatchSize returns the largest batch engineName accepts, or 0 when
+// batches should not be split because no feature provider is configured.
+func (s *InferenceService) embedBatchSize(ctx context.Context, engineName string) int {
+	if s.features == nil {
+		return 0
+	}
+	features, err := s.features.GetFeatures(ctx, engineName)
+	if err != nil || features == nil || features.MaxBatchSize <= 0 {
+		return DefaultEmbedBatchSize
+	}
+	return features.MaxBatchSize
+}
+
+// embedInBatches calls embed on consecutive slices of input holding at most
+// batchSize items and concatenates the results in order. A batchSize of 0
+// sends input in a single call.
+func embedInBatches(ctx context.Context, input []string, batchSize int, embed func(ctx context.Context, batch []string) (*inference.EmbeddingResponse, error)) (*inference.EmbeddingResponse, error) {
+	if batchSize <= 0 || len(input) <= batchSize {
+		return embed(ctx, input)
+	}
+
+	merged := &inference.EmbeddingResponse{Embeddings: make([][]float64, 0, len(input))}
+	for start := 0; start < len(input); start += batchSize {
+		end := min(start+batchSize, len(input))
+		resp, err := embed(ctx, input[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("batch %d-%d: %w", start, end, err)
+		}
+		if len(resp.Embeddings) != end-start {
+			return nil, fmt.Errorf("batch %d-%d returned %d embeddings", start, end, len(resp.Embeddings))
+		}
+		merged.Embeddings = append(merged.Embeddings, resp.Embeddings...)
+		merged.Usage.PromptTokens += resp.Usage.PromptTokens
+		merged.Usage.CompletionTokens += resp.Usage.CompletionTokens
+		merged.Usage.TotalTokens += resp.Usage.TotalTokens
+	}
+	return merged, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+)
+
+// embedRecordingProvider routes Embed to a recordingEmbedder so batch sizes
+// can be asserted.
+type embedRecordingProvider struct {
+	*inference.MockProvider
+	rec *recordingEmbedder
+}
+
+func (p *embedRecordingProvider) Embed(ctx context.Context, modelName string, input []string) (*inference.EmbeddingResponse, error) {
+	return p.rec.Embed(ctx, modelName, input)
+}
+
+func newBatchFixture(t *testing.T, features EngineFeatureProvider) (*InferenceService, *recordingEmbedder) {
+	t.Helper()
+	ctx := context.Background()
+
+	modelStore := model.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "embed-model", Type: model.ModelTypeEmbedding, Format: model.FormatGGUF, Status: model.StatusReady})
+	engineStore := engine.NewMemoryStore()
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "transformers", Type: engine.EngineTypeTransformers, Status: engine.EngineStatusRunning})
+
+	rec := &recordingEmbedder{}
+	provider := &embedRecordingProvider{MockProvider: inference.NewMockProvider(), rec: rec}
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), &resource.MockProvider{}, provider)
+	if features != nil {
+		svc.WithEngineFeatures(features)
+	}
+	return svc, rec
+}
+
+func docInputs(n int) []string {
+	input := make([]string, n)
+	for i := range input {
+		input[i] = fmt.Sprintf("doc-%d", i)
+	}
+	return input
+}
+
+func batchSizes(calls [][]string) []int {
+	sizes := make([]int, len(calls))
+	for i, c := range calls {
+		sizes[i] = len(c)
+	}
+	return sizes
+}
+
+func TestInferenceService_Embed_RespectsMaxBatchSize(t *testing.T) {
+	svc, rec := newBatchFixture(t, staticFeatures{"transformers": {MaxBatchSize: 64}})
+
+	resp, err := svc.Embed(context.Background(), EmbedRequest{Model: "embed-model", Input: docInputs(200)})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	if got := fmt.Sprint(batchSizes(rec.calls())); got != "[64 64 64 8]" {
+		t.Errorf("expected batches [64 64 64 8], got %s", got)
+	}
+	if len(resp.Embeddings) != 200 {
+		t.Fatalf("expected 200 embeddings, got %d", len(resp.Embeddings))
+	}
+	for i, emb := range resp.Embeddings {
+		if emb[0] != float64(i) {
+			t.Fatalf("embedding %d out of order: got %v", i, emb)
+		}
+	}
+	if resp.Usage.TotalTokens != 200 {
+		t.Errorf("expected merged usage of 200 tokens, got %d", resp.Usage.TotalTokens)
+	}
+}
+
+func TestInferenceService_Embed_DefaultBatchSizeWhenZero(t *testing.T) {
+	svc, rec := newBatchFixture(t, staticFeatures{"transformers": {}})
+
+	if _, err := svc.Embed(context.Background(), EmbedRequest{Model: "embed-model", Input: docInputs(70)}); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if got := fmt.Sprint(batchSizes(rec.calls())); got != "[32 32 6]" {
+		t.Errorf("expected default batches [32 32 6], got %s", got)
+	}
+}
+
+func TestInferenceService_Embed_NoFeaturesSendsSingleBatch(t *testing.T) {
+	svc, rec := newBatchFixture(t, nil)
+
+	if _, err := svc.Embed(context.Background(), EmbedRequest{Model: "embed-model", Input: docInputs(200)}); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if got := fmt.Sprint(batchSizes(rec.calls())); got != "[200]" {
+		t.Errorf("expected a single batch, got %s", got)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/tokenizer"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+)
+
+// ErrCodeContextLengthExceeded is the error code reported when a prompt and
+// its requested completion do not fit the engine's context window.
+const ErrCodeContextLengthExceeded unit.ErrorCode = "context_length_exceeded"
+
+var ErrContextLengthExceeded = unit.NewError(ErrCodeContextLengthExceeded, "prompt exceeds engine context length")
+
+// WithTokenizers counts prompt and completion tokens with the tokenizer
+// registered for each request's model family. Without it every model uses
+// tokenizer.Approximate.
+func (s *InferenceService) WithTokenizers(tokenizers *tokenizer.Registry) *InferenceService {
+	s.tokenizers = tokenizers
+	return s
+}
+
+func (s *InferenceService) tokenizerFor(modelName string) tokenizer.Tokenizer {
+	if s.tokenizers == nil {
+		return tokenizer.Approximate{}
+	}
+	return s.tokenizers.ForModel(modelName)
+}
+
+func (s *InferenceService) countChatTokens(modelName string, messages []inference.Message) int {
+	tok := s.tokenizerFor(modelName)
+	total := 0
+	for _, msg := range messages {
+		total += tok.Count(msg.Content)
+	}
+	return total
+}
+
+// fillUsage counts tokens locally when the provider reported no usage.
+func (s *InferenceService) fillUsage(usage *inference.Usage, modelName string, promptTokens func() int, completion string) {
+	if usage.PromptTokens != 0 || usage.CompletionTokens != 0 || usage.TotalTokens != 0 {
+		return
+	}
+	usage.PromptTokens = promptTokens()
+	usage.CompletionTokens = s.tokenizerFor(modelName).Count(completion)
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+}
+
+// checkContextLength rejects a request whose counted prompt tokens plus
+// requested max_tokens exceed the engine's MaxContextLength. Engines whose
+// features are unknown or report no limit are not checked.
+func (s *InferenceService) checkContextLength(ctx context.Context, engineName string, promptTokens int, maxTokens *int) error {
+	if s.features == nil {
+		return nil
+	}
+	features, err := s.features.GetFeatures(ctx, engineName)
+	if err != nil || features == nil || features.MaxContextLength <= 0 {
+		return nil
+	}
+
+	requested := 0
+	if maxTokens != nil {
+		requested = *maxTokens
+	}
+	available := features.MaxContextLength - requested
+	if promptTokens <= available {
+		return nil
+	}
+
+	return unit.NewError(ErrCodeContextLengthExceeded, ErrContextLengthExceeded.Message).
+		WithDetails("engine", engineName).
+		WithDetails("prompt_tokens", promptTokens).
+		WithDetails("max_tokens", requested).
+		WithDetails("max_context_length", features.MaxContextLength).
+		WithDetails("available_prompt_tokens", available)
+}
+
+// enginesWithinContext drops the engines whose context window cannot hold the
+// request, keeping the rest in order. When none can, it returns the first
+// engine's error.
+func (s *InferenceService) enginesWithinContext(ctx context.Context, engines []string, promptTokens int, maxTokens *int) ([]string, error) {
+	fit := make([]string, 0, len(engines))
+	var firstErr error
+	for _, name := range engines {
+		if err := s.checkContextLength(ctx, name, promptTokens, maxTokens); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		fit = append(fit, name)
+	}
+	if len(fit) == 0 {
+		return nil, firstErr
+	}
+	return fit, nil
+}
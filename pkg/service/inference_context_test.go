@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/tokenizer"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+)
+
+func newContextLengthFixture(t *testing.T, provider *inference.MockProvider, maxContext int) *InferenceService {
+	t.Helper()
+	ctx := context.Background()
+
+	modelStore := model.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "small-ctx", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady})
+	engineStore := engine.NewMemoryStore()
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), &resource.MockProvider{}, provider)
+	return svc.WithEngineFeatures(staticFeatures{"ollama": {MaxContextLength: maxContext}})
+}
+
+func TestInferenceService_Chat_ContextLengthExceeded(t *testing.T) {
+	provider := inference.NewMockProvider()
+	svc := newContextLengthFixture(t, provider, 16)
+	maxTokens := 8
+
+	// Eight words plus the trailing space are 9 tokens, which with 8
+	// requested tokens exceeds 16.
+	_, err := svc.Chat(context.Background(), ChatRequest{
+		Model:     "small-ctx",
+		Messages:  []inference.Message{{Role: "user", Content: strings.Repeat("word ", 8)}},
+		MaxTokens: &maxTokens,
+	})
+	if !errors.Is(err, ErrContextLengthExceeded) {
+		t.Fatalf("expected ErrContextLengthExceeded, got %v", err)
+	}
+
+	ue, ok := unit.AsUnitError(err)
+	if !ok || ue.Code != "context_length_exceeded" {
+		t.Fatalf("expected code context_length_exceeded, got %v", err)
+	}
+	want := map[string]any{"prompt_tokens": 9, "max_tokens": 8, "max_context_length": 16, "available_prompt_tokens": 8}
+	for key, value := range want {
+		if ue.Details[key] != value {
+			t.Errorf("details[%s] = %v, want %v", key, ue.Details[key], value)
+		}
+	}
+	if provider.ChatCalls() != 0 {
+		t.Error("oversized prompt should not reach the provider")
+	}
+}
+
+func TestInferenceService_Chat_ContextLengthWithinLimit(t *testing.T) {
+	provider := inference.NewMockProvider()
+	svc := newContextLengthFixture(t, provider, 16)
+
+	_, err := svc.Chat(context.Background(), ChatRequest{
+		Model:    "small-ctx",
+		Messages: []inference.Message{{Role: "user", Content: "short"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if provider.ChatCalls() != 1 {
+		t.Errorf("expected 1 provider call, got %d", provider.ChatCalls())
+	}
+}
+
+func TestInferenceService_Chat_ContextLengthSkipsSmallEngine(t *testing.T) {
+	provider := inference.NewMockProvider()
+	svc := newContextLengthFixture(t, provider, 16)
+	_ = svc.engineStore.Create(context.Background(), &engine.Engine{ID: "engine-2", Name: "vllm", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning})
+	svc.WithEngineFeatures(staticFeatures{"ollama": {MaxContextLength: 16}, "vllm": {MaxContextLength: 4096}})
+	maxTokens := 8
+
+	// The prompt does not fit ollama's window, so only vLLM is tried.
+	_, err := svc.Chat(context.Background(), ChatRequest{
+		Model:     "small-ctx",
+		Messages:  []inference.Message{{Role: "user", Content: strings.Repeat("word ", 8)}},
+		MaxTokens: &maxTokens,
+	})
+	if err != nil {
+		t.Fatalf("expected the larger engine to serve the chat, got %v", err)
+	}
+	if provider.ChatCalls() != 1 {
+		t.Errorf("expected 1 provider call, got %d", provider.ChatCalls())
+	}
+}
+
+func TestInferenceService_Complete_ContextLengthExceeded(t *testing.T) {
+	svc := newContextLengthFixture(t, inference.NewMockProvider(), 16)
+
+	_, err := svc.Complete(context.Background(), CompleteRequest{
+		Model:  "small-ctx",
+		Prompt: strings.Repeat("a long prompt ", 10),
+	})
+	if !errors.Is(err, ErrContextLengthExceeded) {
+		t.Fatalf("expected ErrContextLengthExceeded, got %v", err)
+	}
+}
+
+func TestInferenceService_Chat_NoContextLimitReported(t *testing.T) {
+	svc := newContextLengthFixture(t, inference.NewMockProvider(), 0)
+
+	_, err := svc.Chat(context.Background(), ChatRequest{
+		Model:    "small-ctx",
+		Messages: []inference.Message{{Role: "user", Content: strings.Repeat("word ", 100)}},
+	})
+	if err != nil {
+		t.Fatalf("engines without a reported limit should not be checked: %v", err)
+	}
+}
+
+func TestInferenceService_Chat_UsesRegisteredTokenizer(t *testing.T) {
+	tokenizers := tokenizer.NewRegistry(nil)
+	tokenizers.Register("small", fixedCountTokenizer(20))
+	svc := newContextLengthFixture(t, inference.NewMockProvider(), 16).WithTokenizers(tokenizers)
+
+	_, err := svc.Chat(context.Background(), ChatRequest{
+		Model:    "small-ctx",
+		Messages: []inference.Message{{Role: "user", Content: "short"}},
+	})
+	if !errors.Is(err, ErrContextLengthExceeded) {
+		t.Fatalf("expected the registered tokenizer's count to exceed the limit, got %v", err)
+	}
+}
+
+func TestInferenceService_Chat_FillsMissingUsage(t *testing.T) {
+	provider := inference.NewMockProvider()
+	provider.SetChatResponse(&inference.ChatResponse{Content: "Hello there, friend!", FinishReason: "stop"})
+	svc := newContextLengthFixture(t, provider, 0)
+
+	resp, err := svc.Chat(context.Background(), ChatRequest{
+		Model:    "small-ctx",
+		Messages: []inference.Message{{Role: "user", Content: "Say hello"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	want := inference.Usage{PromptTokens: 2, CompletionTokens: 5, TotalTokens: 7}
+	if resp.Usage != want {
+		t.Errorf("usage = %+v, want %+v", resp.Usage, want)
+	}
+}
+
+type fixedCountTokenizer int
+
+func (f fixedCountTokenizer) Count(string) int    { return int(f) }
+func (f fixedCountTokenizer) Encode(string) []int { return make([]int, f) }
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+)
+
+// Embedder serves embedding requests for a single engine endpoint.
+type Embedder interface {
+	Embed(ctx context.Context, model string, input []string) (*inference.EmbeddingResponse, error)
+}
+
+// EmbeddingEndpoint is one healthy engine able to embed for a model. Name
+// keys the circuit breaker.
+type EmbeddingEndpoint struct {
+	Name     string
+	Embedder Embedder
+}
+
+// EmbeddingEndpointResolver lists the healthy endpoints currently serving a
+// model's embeddings.
+type EmbeddingEndpointResolver interface {
+	EmbeddingEndpoints(ctx context.Context, modelID string) ([]EmbeddingEndpoint, error)
+}
+
+// WithEmbeddingFanOut makes Embed split a batch across every endpoint
+// resolver reports for the model, calling them concurrently and reassembling
+// the results in input order. With fewer than two usable endpoints Embed
+// keeps using the inference provider directly.
+func (s *InferenceService) WithEmbeddingFanOut(resolver EmbeddingEndpointResolver) *InferenceService {
+	s.embedEndpoints = resolver
+	return s
+}
+
+// fanOutEndpoints returns the endpoints to split input across, or nil when
+// fan-out is disabled or would not help.
+func (s *InferenceService) fanOutEndpoints(ctx context.Context, modelID string, inputs int) ([]EmbeddingEndpoint, error) {
+	if s.embedEndpoints == nil || inputs < 2 {
+		return nil, nil
+	}
+
+	endpoints, err := s.embedEndpoints.EmbeddingEndpoints(ctx, modelID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve embedding endpoints for %s: %w", modelID, err)
+	}
+
+	usable := make([]EmbeddingEndpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.Embedder == nil {
+			continue
+		}
+		if s.breaker != nil && !s.breaker.Available(ep.Name) {
+			continue
+		}
+		usable = append(usable, ep)
+	}
+	if len(usable) < 2 {
+		return nil, nil
+	}
+	if len(usable) > inputs {
+		usable = usable[:inputs]
+	}
+	return usable, nil
+}
+
+// embedFanOut partitions input into contiguous chunks, one per endpoint, and
+// embeds them concurrently, splitting each chunk further into batches of at
+// most batchSize. The first failure cancels the remaining calls.
+func (s *InferenceService) embedFanOut(ctx context.Context, modelName string, input []string, endpoints []EmbeddingEndpoint, batchSize int) (*inference.EmbeddingResponse, error) {
+	results := make([]*inference.EmbeddingResponse, len(endpoints))
+	bounds := partitionBatch(len(input), len(endpoints))
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, ep := range endpoints {
+		chunk := input[bounds[i]:bounds[i+1]]
+		g.Go(func() error {
+			resp, err := embedInBatches(gctx, chunk, batchSize, func(ctx context.Context, batch []string) (*inference.EmbeddingResponse, error) {
+				return guardEngine(s.breaker, ep.Name, func() (*inference.EmbeddingResponse, error) {
+					return ep.Embedder.Embed(ctx, modelName, batch)
+				})
+			})
+			if err != nil {
+				return fmt.Errorf("endpoint %s: %w", ep.Name, err)
+			}
+			if len(resp.Embeddings) != len(chunk) {
+				return fmt.Errorf("endpoint %s returned %d embeddings for %d inputs", ep.Name, len(resp.Embeddings), len(chunk))
+			}
+			results[i] = resp
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	merged := &inference.EmbeddingResponse{Embeddings: make([][]float64, 0, len(input))}
+	for _, r := range results {
+		merged.Embeddings = append(merged.Embeddings, r.Embeddings...)
+		merged.Usage.PromptTokens += r.Usage.PromptTokens
+		merged.Usage.CompletionTokens += r.Usage.CompletionTokens
+		merged.Usage.TotalTokens += r.Usage.TotalTokens
+	}
+	return merged, nil
+}
+
+// partitionBatch returns parts+1 offsets splitting n items into parts
+// contiguous chunks whose sizes differ by at most one.
+func partitionBatch(n, parts int) []int {
+	bounds := make([]int, parts+1)
+	size, extra := n/parts, n%parts
+	for i := 0; i < parts; i++ {
+		bounds[i+1] = bounds[i] + size
+		if i < extra {
+			bounds[i+1]++
+		}
+	}
+	return bounds
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+)
+
+// recordingEmbedder encodes each input's numeric suffix as its embedding so
+// reassembly order can be checked.
+type recordingEmbedder struct {
+	mu     sync.Mutex
+	inputs [][]string
+	err    error
+}
+
+func (e *recordingEmbedder) Embed(ctx context.Context, modelName string, input []string) (*inference.EmbeddingResponse, error) {
+	e.mu.Lock()
+	e.inputs = append(e.inputs, append([]string(nil), input...))
+	e.mu.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	embeddings := make([][]float64, len(input))
+	for i, text := range input {
+		var n float64
+		_, _ = fmt.Sscanf(strings.TrimPrefix(text, "doc-"), "%g", &n)
+		embeddings[i] = []float64{n}
+	}
+	return &inference.EmbeddingResponse{
+		Embeddings: embeddings,
+		Usage:      inference.Usage{PromptTokens: len(input), TotalTokens: len(input)},
+	}, nil
+}
+
+func (e *recordingEmbedder) calls() [][]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.inputs
+}
+
+type staticEndpoints []EmbeddingEndpoint
+
+func (s staticEndpoints) EmbeddingEndpoints(ctx context.Context, modelID string) ([]EmbeddingEndpoint, error) {
+	return s, nil
+}
+
+func newFanOutFixture(t *testing.T, provider inference.InferenceProvider, endpoints ...EmbeddingEndpoint) *InferenceService {
+	t.Helper()
+	ctx := context.Background()
+
+	modelStore := model.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "embed-model", Name: "Embed", Type: model.ModelTypeEmbedding})
+	engineStore := engine.NewMemoryStore()
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "transformers", Type: engine.EngineTypeTransformers, Status: engine.EngineStatusRunning})
+
+	return NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), nil, provider).
+		WithEmbeddingFanOut(staticEndpoints(endpoints))
+}
+
+func docs(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("doc-%d", i)
+	}
+	return out
+}
+
+func TestInferenceService_Embed_FanOutSplitsAndPreservesOrder(t *testing.T) {
+	a, b := &recordingEmbedder{}, &recordingEmbedder{}
+	svc := newFanOutFixture(t, inference.NewMockProvider(),
+		EmbeddingEndpoint{Name: "embed-a", Embedder: a},
+		EmbeddingEndpoint{Name: "embed-b", Embedder: b},
+	)
+
+	resp, err := svc.Embed(context.Background(), EmbedRequest{Model: "embed-model", Input: docs(5)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := a.calls(); len(got) != 1 || strings.Join(got[0], ",") != "doc-0,doc-1,doc-2" {
+		t.Errorf("endpoint a got %v, want first three inputs", got)
+	}
+	if got := b.calls(); len(got) != 1 || strings.Join(got[0], ",") != "doc-3,doc-4" {
+		t.Errorf("endpoint b got %v, want last two inputs", got)
+	}
+
+	if len(resp.Embeddings) != 5 {
+		t.Fatalf("expected 5 embeddings, got %d", len(resp.Embeddings))
+	}
+	for i, emb := range resp.Embeddings {
+		if emb[0] != float64(i) {
+			t.Errorf("embedding %d out of order: got %v", i, emb)
+		}
+	}
+	if resp.Usage.TotalTokens != 5 {
+		t.Errorf("expected usage summed across endpoints, got %d", resp.Usage.TotalTokens)
+	}
+}
+
+func TestInferenceService_Embed_SingleEndpointUsesProvider(t *testing.T) {
+	only := &recordingEmbedder{}
+	svc := newFanOutFixture(t, inference.NewMockProvider(), EmbeddingEndpoint{Name: "embed-a", Embedder: only})
+
+	resp, err := svc.Embed(context.Background(), EmbedRequest{Model: "embed-model", Input: docs(3)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Embeddings) != 3 {
+		t.Errorf("expected 3 embeddings, got %d", len(resp.Embeddings))
+	}
+	if len(only.calls()) != 0 {
+		t.Errorf("expected fan-out to be skipped with one endpoint, got calls %v", only.calls())
+	}
+}
+
+func TestInferenceService_Embed_FanOutFailure(t *testing.T) {
+	a, b := &recordingEmbedder{}, &recordingEmbedder{err: errors.New("endpoint down")}
+	svc := newFanOutFixture(t, inference.NewMockProvider(),
+		EmbeddingEndpoint{Name: "embed-a", Embedder: a},
+		EmbeddingEndpoint{Name: "embed-b", Embedder: b},
+	)
+
+	_, err := svc.Embed(context.Background(), EmbedRequest{Model: "embed-model", Input: docs(4)})
+	if err == nil || !strings.Contains(err.Error(), "endpoint down") {
+		t.Errorf("expected endpoint failure to surface, got %v", err)
+	}
+}
+
+func TestPartitionBatch(t *testing.T) {
+	tests := []struct {
+		n, parts int
+		want     []int
+	}{
+		{n: 5, parts: 2, want: []int{0, 3, 5}},
+		{n: 4, parts: 2, want: []int{0, 2, 4}},
+		{n: 7, parts: 3, want: []int{0, 3, 5, 7}},
+	}
+	for _, tt := range tests {
+		got := partitionBatch(tt.n, tt.parts)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("partitionBatch(%d, %d) = %v, want %v", tt.n, tt.parts, got, tt.want)
+		}
+	}
+}
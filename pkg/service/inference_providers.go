@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+// WithProviders serves requests from the providers in registry, chosen per
+// request by the gateway's provider option, the model or the engine, instead
+// of the single provider the service was created with. That provider still
+// serves requests the registry has no provider for.
+func (s *InferenceService) WithProviders(registry *ProviderRegistry) *InferenceService {
+	s.providers = registry
+	return s
+}
+
+// providerFor returns the provider serving a request in ctx for m on
+// engineName, the engine the router picked first.
+func (s *InferenceService) providerFor(ctx context.Context, m *model.Model, engineName string) (inference.InferenceProvider, error) {
+	override := unit.GetProvider(ctx)
+	if s.providers == nil {
+		if override != "" {
+			return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, override)
+		}
+		return s.inferenceProv, nil
+	}
+
+	prov, err := s.providers.Select(override, m, s.engineType(ctx, engineName))
+	if err != nil {
+		return nil, err
+	}
+	if prov == nil {
+		return s.inferenceProv, nil
+	}
+	return prov, nil
+}
+
+// providersFor resolves the provider for each of engines, so a fallback
+// engine is served by the backend routed for it rather than the first one's.
+func (s *InferenceService) providersFor(ctx context.Context, m *model.Model, engines []string) (map[string]inference.InferenceProvider, error) {
+	provs := make(map[string]inference.InferenceProvider, len(engines))
+	for _, name := range engines {
+		prov, err := s.providerFor(ctx, m, name)
+		if err != nil {
+			return nil, err
+		}
+		provs[name] = prov
+	}
+	return provs, nil
+}
+
+// engineType returns the type of the engine called name, or "" when it is
+// not in the store.
+func (s *InferenceService) engineType(ctx context.Context, name string) engine.EngineType {
+	if s.engineStore == nil || name == "" {
+		return ""
+	}
+	e, err := s.engineStore.Get(ctx, name)
+	if err != nil || e == nil {
+		return ""
+	}
+	return e.Type
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+)
+
+// newProvidersFixture returns a service with "ollama" and "openai" providers
+// registered, each answering chats with its own name. "test-model" is served
+// by a running ollama engine and "qwen-vllm" by a running vLLM engine.
+func newProvidersFixture(t *testing.T, defaultProvider string) (*InferenceService, *ProviderRegistry) {
+	t.Helper()
+	ctx := context.Background()
+
+	modelStore := model.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "test-model", Name: "llama3", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady})
+	_ = modelStore.Create(ctx, &model.Model{ID: "qwen-vllm", Name: "qwen2.5", Type: model.ModelTypeLLM, Format: model.FormatSafetensors, Status: model.StatusReady})
+	engineStore := engine.NewMemoryStore()
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-2", Name: "vllm", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning})
+
+	providers := NewProviderRegistry()
+	for _, name := range []string{"ollama", "openai"} {
+		prov := inference.NewMockProvider()
+		prov.SetChatResponse(&inference.ChatResponse{Content: "from " + name, FinishReason: "stop"})
+		if err := providers.Register(name, prov); err != nil {
+			t.Fatalf("register %s: %v", name, err)
+		}
+	}
+	if defaultProvider != "" {
+		if err := providers.SetDefault(defaultProvider); err != nil {
+			t.Fatalf("set default: %v", err)
+		}
+	}
+	builtin := inference.NewMockProvider()
+	builtin.SetChatResponse(&inference.ChatResponse{Content: "from builtin", FinishReason: "stop"})
+
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), nil, builtin).
+		WithProviders(providers)
+	return svc, providers
+}
+
+func chatContent(t *testing.T, svc *InferenceService, ctx context.Context, modelID string) string {
+	t.Helper()
+	resp, err := svc.Chat(ctx, ChatRequest{
+		Model:    modelID,
+		Messages: []inference.Message{{Role: "user", Content: "Hello"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	return resp.Content
+}
+
+func TestInferenceService_ProviderOverride(t *testing.T) {
+	svc, providers := newProvidersFixture(t, "ollama")
+	_ = providers.RouteModel("llama3", "ollama")
+
+	// The request's choice wins over the model's route.
+	ctx := unit.WithProvider(context.Background(), "openai")
+	if got := chatContent(t, svc, ctx, "test-model"); got != "from openai" {
+		t.Errorf("expected the requested provider to serve the chat, got %q", got)
+	}
+}
+
+func TestInferenceService_DefaultProvider(t *testing.T) {
+	svc, _ := newProvidersFixture(t, "openai")
+	if got := chatContent(t, svc, context.Background(), "test-model"); got != "from openai" {
+		t.Errorf("expected the default provider to serve the chat, got %q", got)
+	}
+
+	// Without a default the provider the service was created with is used.
+	svc, _ = newProvidersFixture(t, "")
+	if got := chatContent(t, svc, context.Background(), "test-model"); got != "from builtin" {
+		t.Errorf("expected the built-in provider to serve the chat, got %q", got)
+	}
+
+	// As it is without a registry.
+	svc.WithProviders(nil)
+	if got := chatContent(t, svc, context.Background(), "test-model"); got != "from builtin" {
+		t.Errorf("expected the built-in provider to serve the chat, got %q", got)
+	}
+}
+
+func TestInferenceService_ProviderRouting(t *testing.T) {
+	svc, providers := newProvidersFixture(t, "ollama")
+	if err := providers.RouteEngine(engine.EngineTypeVLLM, "openai"); err != nil {
+		t.Fatalf("route engine: %v", err)
+	}
+
+	if got := chatContent(t, svc, context.Background(), "test-model"); got != "from ollama" {
+		t.Errorf("expected the ollama-served model to use the ollama provider, got %q", got)
+	}
+	if got := chatContent(t, svc, context.Background(), "qwen-vllm"); got != "from openai" {
+		t.Errorf("expected the vLLM-served model to use the openai provider, got %q", got)
+	}
+
+	// A model route wins over its engine's route.
+	if err := providers.RouteModel("qwen2.5", "ollama"); err != nil {
+		t.Fatalf("route model: %v", err)
+	}
+	if got := chatContent(t, svc, context.Background(), "qwen-vllm"); got != "from ollama" {
+		t.Errorf("expected the model route to apply, got %q", got)
+	}
+}
+
+func TestInferenceService_UnknownProvider(t *testing.T) {
+	svc, _ := newProvidersFixture(t, "ollama")
+
+	ctx := unit.WithProvider(context.Background(), "anthropic")
+	_, err := svc.Chat(ctx, ChatRequest{
+		Model:    "test-model",
+		Messages: []inference.Message{{Role: "user", Content: "Hello"}},
+	})
+	if !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("expected ErrProviderNotFound, got %v", err)
+	}
+
+	stream := make(chan inference.ChatStreamChunk, 10)
+	err = svc.ChatStream(ctx, ChatRequest{
+		Model:    "test-model",
+		Messages: []inference.Message{{Role: "user", Content: "Hello"}},
+	}, stream)
+	if !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("expected ErrProviderNotFound from ChatStream, got %v", err)
+	}
+}
+
+func TestInferenceService_FallbackEngineServesRequest(t *testing.T) {
+	svc, providers := newProvidersFixture(t, "")
+	if err := providers.RouteEngine(engine.EngineTypeOllama, "ollama"); err != nil {
+		t.Fatalf("route engine: %v", err)
+	}
+	if err := providers.RouteEngine(engine.EngineTypeVLLM, "openai"); err != nil {
+		t.Fatalf("route engine: %v", err)
+	}
+	primary, _ := providers.Get("ollama")
+	primary.(*inference.MockProvider).SetChatError(errors.New("connection refused"))
+	breaker := NewCircuitBreaker(1, time.Minute)
+	svc.WithCircuitBreaker(breaker)
+
+	// The GGUF model runs on ollama first and falls back to vLLM.
+	if got := chatContent(t, svc, context.Background(), "test-model"); got != "from openai" {
+		t.Errorf("expected the fallback engine's provider to serve the chat, got %q", got)
+	}
+	if breaker.Available("ollama") {
+		t.Error("expected the failed primary engine's circuit to open")
+	}
+	if !breaker.Available("vllm") {
+		t.Error("expected the fallback engine's circuit to stay closed")
+	}
+}
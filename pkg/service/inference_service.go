@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/tokenizer"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
@@ -17,6 +18,8 @@ var (
 	ErrModelNotFound         = errors.New("model not found")
 	ErrEngineNotAvailable    = errors.New("engine not available")
 	ErrInvalidRequest        = errors.New("invalid request")
+	ErrServiceStartTimeout   = errors.New("service start timed out")
+	ErrProviderNotFound      = errors.New("inference provider not found")
 )
 
 type ChatRequest struct {
@@ -30,6 +33,7 @@ type ChatRequest struct {
 	PresencePenalty  *float64            `json:"presence_penalty,omitempty"`
 	Stop             []string            `json:"stop,omitempty"`
 	Stream           bool                `json:"stream,omitempty"`
+	SessionID        string              `json:"session_id,omitempty"`
 }
 
 type ChatResponse struct {
@@ -148,42 +152,167 @@ type EngineRouter interface {
 	SelectEngine(modelType model.ModelType, modelFormat model.ModelFormat) (string, error)
 }
 
+// ModelEngineRouter is implemented by routers that can take the model itself
+// into account, not just its type and format. InferenceService prefers it
+// when the router provides it.
+type ModelEngineRouter interface {
+	SelectEngineForModel(m *model.Model) (string, error)
+}
+
+// FallbackEngineRouter is implemented by routers that can rank every engine
+// able to serve a model, so callers can move on to the next one when the
+// first is unavailable.
+type FallbackEngineRouter interface {
+	SelectEngines(m *model.Model) ([]string, error)
+}
+
 type DefaultRouter struct {
 	engineStore engine.EngineStore
+	breaker     *CircuitBreaker
+	health      EngineHealthChecker
+	overrides   map[string]engine.EngineType
+}
+
+// EngineHealthChecker probes whether a named engine is actually serving.
+// EngineService satisfies it.
+type EngineHealthChecker interface {
+	IsHealthy(ctx context.Context, name string) (*HealthStatus, error)
 }
 
 func NewDefaultRouter(store engine.EngineStore) *DefaultRouter {
 	return &DefaultRouter{engineStore: store}
 }
 
+// WithCircuitBreaker makes the router skip engines whose circuit is open.
+func (r *DefaultRouter) WithCircuitBreaker(breaker *CircuitBreaker) *DefaultRouter {
+	r.breaker = breaker
+	return r
+}
+
+// WithHealthChecker makes the router probe running engines and skip those
+// that fail, even though the store still reports them as running.
+func (r *DefaultRouter) WithHealthChecker(checker EngineHealthChecker) *DefaultRouter {
+	r.health = checker
+	return r
+}
+
+// WithEngineOverrides pins models to an engine type regardless of their type
+// and format. Keys are model IDs or names; an ID match wins over a name match.
+func (r *DefaultRouter) WithEngineOverrides(overrides map[string]engine.EngineType) *DefaultRouter {
+	r.overrides = overrides
+	return r
+}
+
 func (r *DefaultRouter) SelectEngine(modelType model.ModelType, modelFormat model.ModelFormat) (string, error) {
-	ctx := context.Background()
+	return r.first(r.fallbackChain(r.mapModelToEngine(modelType, modelFormat)))
+}
 
-	engineType := r.mapModelToEngine(modelType, modelFormat)
+// SelectEngineForModel routes m to its overridden engine type if one is
+// configured, and otherwise by type and format like SelectEngine.
+func (r *DefaultRouter) SelectEngineForModel(m *model.Model) (string, error) {
+	return r.first(r.engineTypesFor(m))
+}
 
-	engines, _, err := r.engineStore.List(ctx, engine.EngineFilter{
-		Type:   engineType,
-		Status: engine.EngineStatusRunning,
-		Limit:  1,
-	})
+// SelectEngines returns every engine that can serve m, best first: running
+// engines of the preferred type, running engines of its fallback types, then
+// stopped engines in the same type order. An overridden model gets no
+// fallback types.
+func (r *DefaultRouter) SelectEngines(m *model.Model) ([]string, error) {
+	names, err := r.candidates(r.engineTypesFor(m), 0)
 	if err != nil {
-		return "", fmt.Errorf("list engines: %w", err)
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, ErrEngineNotAvailable
+	}
+	return names, nil
+}
+
+func (r *DefaultRouter) engineTypesFor(m *model.Model) []engine.EngineType {
+	if engineType, ok := r.override(m); ok {
+		return []engine.EngineType{engineType}
 	}
+	return r.fallbackChain(r.mapModelToEngine(m.Type, m.Format))
+}
 
-	if len(engines) == 0 {
-		engines, _, err = r.engineStore.List(ctx, engine.EngineFilter{
-			Type:  engineType,
-			Limit: 1,
-		})
-		if err != nil {
-			return "", fmt.Errorf("list engines: %w", err)
-		}
-		if len(engines) == 0 {
-			return "", ErrEngineNotAvailable
+func (r *DefaultRouter) override(m *model.Model) (engine.EngineType, bool) {
+	if engineType, ok := r.overrides[m.ID]; ok && engineType != "" {
+		return engineType, true
+	}
+	if engineType, ok := r.overrides[m.Name]; ok && engineType != "" {
+		return engineType, true
+	}
+	return "", false
+}
+
+// engineFallbacks lists, per engine type, the other types that can run the
+// same models, in order of preference.
+var engineFallbacks = map[engine.EngineType][]engine.EngineType{
+	engine.EngineTypeVLLM:   {engine.EngineTypeSGLang, engine.EngineTypeOllama},
+	engine.EngineTypeSGLang: {engine.EngineTypeVLLM, engine.EngineTypeOllama},
+	engine.EngineTypeOllama: {engine.EngineTypeVLLM},
+}
+
+func (r *DefaultRouter) fallbackChain(primary engine.EngineType) []engine.EngineType {
+	return append([]engine.EngineType{primary}, engineFallbacks[primary]...)
+}
+
+func (r *DefaultRouter) first(types []engine.EngineType) (string, error) {
+	names, err := r.candidates(types, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", ErrEngineNotAvailable
+	}
+	return names[0], nil
+}
+
+// candidates lists usable engines of the given types, stopping after limit
+// names when limit is positive.
+func (r *DefaultRouter) candidates(types []engine.EngineType, limit int) ([]string, error) {
+	ctx := context.Background()
+
+	// Running engines of every type are preferred; stopped ones are only a
+	// fallback. Engines seen in the first pass are not reconsidered.
+	var names []string
+	seen := make(map[string]bool)
+	for _, status := range []engine.EngineStatus{engine.EngineStatusRunning, ""} {
+		for _, engineType := range types {
+			engines, _, err := r.engineStore.List(ctx, engine.EngineFilter{
+				Type:   engineType,
+				Status: status,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("list engines: %w", err)
+			}
+			for _, e := range engines {
+				if seen[e.Name] {
+					continue
+				}
+				if r.breaker != nil && !r.breaker.Available(e.Name) {
+					continue
+				}
+				if e.Status == engine.EngineStatusRunning && r.health != nil {
+					seen[e.Name] = true
+					if !r.isHealthy(ctx, e.Name) {
+						continue
+					}
+				}
+				seen[e.Name] = true
+				names = append(names, e.Name)
+				if limit > 0 && len(names) >= limit {
+					return names, nil
+				}
+			}
 		}
 	}
+	return names, nil
+}
 
-	return engines[0].Name, nil
+func (r *DefaultRouter) isHealthy(ctx context.Context, name string) bool {
+	status, err := r.health.IsHealthy(ctx, name)
+	return err == nil && status != nil && status.Healthy
 }
 
 func (r *DefaultRouter) mapModelToEngine(modelType model.ModelType, modelFormat model.ModelFormat) engine.EngineType {
@@ -215,13 +344,25 @@ func (r *DefaultRouter) mapModelToEngine(modelType model.ModelType, modelFormat
 }
 
 type InferenceService struct {
-	registry      *unit.Registry
-	modelStore    model.ModelStore
-	engineStore   engine.EngineStore
-	resourceStore resource.ResourceStore
-	resourceProv  resource.ResourceProvider
-	inferenceProv inference.InferenceProvider
-	router        EngineRouter
+	registry       *unit.Registry
+	modelStore     model.ModelStore
+	engineStore    engine.EngineStore
+	resourceStore  resource.ResourceStore
+	resourceProv   resource.ResourceProvider
+	inferenceProv  inference.InferenceProvider
+	router         EngineRouter
+	breaker        *CircuitBreaker
+	autoPull       *autoPuller
+	activity       ActivityRecorder
+	autoStart      *autoStarter
+	embedEndpoints EmbeddingEndpointResolver
+	features       EngineFeatureProvider
+	streamFallback bool
+	tokenizers     *tokenizer.Registry
+	events         unit.EventPublisher
+
+	// providers, when set, choose the provider per request.
+	providers *ProviderRegistry
 }
 
 func NewInferenceService(
@@ -248,6 +389,79 @@ func (s *InferenceService) WithRouter(router EngineRouter) *InferenceService {
 	return s
 }
 
+// WithEngineOverrides pins models, by ID or name, to an engine type. It only
+// affects the default router.
+func (s *InferenceService) WithEngineOverrides(overrides map[string]engine.EngineType) *InferenceService {
+	if r, ok := s.router.(*DefaultRouter); ok {
+		r.WithEngineOverrides(overrides)
+	}
+	return s
+}
+
+// selectEngine picks the engine for m, letting routers that understand
+// per-model routing see the whole model.
+func (s *InferenceService) selectEngine(m *model.Model) (string, error) {
+	if r, ok := s.router.(ModelEngineRouter); ok {
+		return r.SelectEngineForModel(m)
+	}
+	return s.router.SelectEngine(m.Type, m.Format)
+}
+
+// selectEngines returns the engines to try for m, best first. Routers that
+// cannot rank alternatives yield a single engine.
+func (s *InferenceService) selectEngines(m *model.Model) ([]string, error) {
+	if r, ok := s.router.(FallbackEngineRouter); ok {
+		return r.SelectEngines(m)
+	}
+	name, err := s.selectEngine(m)
+	if err != nil {
+		return nil, err
+	}
+	return []string{name}, nil
+}
+
+// WithCircuitBreaker guards every inference call with breaker, keyed by the
+// selected engine. The default router is also told to skip open engines.
+func (s *InferenceService) WithCircuitBreaker(breaker *CircuitBreaker) *InferenceService {
+	s.breaker = breaker
+	if r, ok := s.router.(*DefaultRouter); ok {
+		r.WithCircuitBreaker(breaker)
+	}
+	return s
+}
+
+// ActivityRecorder is told about every inference request so idle engines
+// can be stopped and restarted on demand.
+type ActivityRecorder interface {
+	RecordActivity(ctx context.Context, modelID string) error
+}
+
+// WithActivityRecorder reports each request's model to recorder before the
+// inference call.
+func (s *InferenceService) WithActivityRecorder(recorder ActivityRecorder) *InferenceService {
+	s.activity = recorder
+	return s
+}
+
+// prepareService starts the model's service if auto-start finds it stopped,
+// then records the request as activity.
+func (s *InferenceService) prepareService(ctx context.Context, modelID string) error {
+	if err := s.startStoppedService(ctx, modelID); err != nil {
+		return err
+	}
+	return s.recordActivity(ctx, modelID)
+}
+
+func (s *InferenceService) recordActivity(ctx context.Context, modelID string) error {
+	if s.activity == nil {
+		return nil
+	}
+	if err := s.activity.RecordActivity(ctx, modelID); err != nil {
+		return fmt.Errorf("record activity for %s: %w", modelID, err)
+	}
+	return nil
+}
+
 func (s *InferenceService) getModel(ctx context.Context, modelID string) (*model.Model, error) {
 	if s.modelStore == nil {
 		return nil, ErrModelNotFound
@@ -281,30 +495,84 @@ func (s *InferenceService) checkResources(ctx context.Context, memoryRequired in
 }
 
 func (s *InferenceService) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	engines, m, opts, err := s.prepareChat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	provs, err := s.providersFor(ctx, m, engines)
+	if err != nil {
+		return nil, err
+	}
+
+	chat := func(engineName string) (*inference.ChatResponse, error) {
+		return s.chatOnce(ctx, provs[engineName], m, req, opts)
+	}
+	resp, err := guardEngines(s.breaker, engines, chat)
+	if err != nil {
+		pulled, pullErr := s.pullNotPulled(ctx, err)
+		if pullErr != nil {
+			return nil, fmt.Errorf("chat inference: %w", pullErr)
+		}
+		if pulled {
+			resp, err = guardEngines(s.breaker, engines, chat)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("chat inference: %w", err)
+	}
+	s.fillUsage(&resp.Usage, req.Model, func() int {
+		return s.countChatTokens(req.Model, req.Messages)
+	}, resp.Content)
+
+	return &ChatResponse{
+		Content:      resp.Content,
+		FinishReason: resp.FinishReason,
+		Usage:        resp.Usage,
+		Model:        resp.Model,
+		ID:           resp.ID,
+	}, nil
+}
+
+// prepareChat validates req, resolves and prepares the engines able to serve
+// its model and returns their names, best first, with the model and the
+// provider options.
+func (s *InferenceService) prepareChat(ctx context.Context, req ChatRequest) ([]string, *model.Model, inference.ChatOptions, error) {
 	if req.Model == "" {
-		return nil, fmt.Errorf("model is required: %w", ErrInvalidRequest)
+		return nil, nil, inference.ChatOptions{}, fmt.Errorf("model is required: %w", ErrInvalidRequest)
 	}
 	if len(req.Messages) == 0 {
-		return nil, fmt.Errorf("messages are required: %w", ErrInvalidRequest)
+		return nil, nil, inference.ChatOptions{}, fmt.Errorf("messages are required: %w", ErrInvalidRequest)
 	}
 
 	m, err := s.getModel(ctx, req.Model)
 	if err != nil {
-		return nil, err
+		m, err = s.pullMissingModel(ctx, req.Model, err)
+		if err != nil {
+			return nil, nil, inference.ChatOptions{}, err
+		}
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engines, err := s.selectEngines(m)
 	if err != nil {
-		return nil, fmt.Errorf("select engine: %w", err)
+		return nil, nil, inference.ChatOptions{}, fmt.Errorf("select engine: %w", err)
+	}
+
+	engines, err = s.enginesWithinContext(ctx, engines, s.countChatTokens(req.Model, req.Messages), req.MaxTokens)
+	if err != nil {
+		return nil, nil, inference.ChatOptions{}, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, nil, inference.ChatOptions{}, err
 	}
 
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
-			return nil, err
+			return nil, nil, inference.ChatOptions{}, err
 		}
 	}
 
-	opts := inference.ChatOptions{
+	return engines, m, inference.ChatOptions{
 		Temperature:      req.Temperature,
 		MaxTokens:        req.MaxTokens,
 		TopP:             req.TopP,
@@ -313,19 +581,7 @@ func (s *InferenceService) Chat(ctx context.Context, req ChatRequest) (*ChatResp
 		PresencePenalty:  req.PresencePenalty,
 		Stop:             req.Stop,
 		Stream:           req.Stream,
-	}
-
-	resp, err := s.inferenceProv.Chat(ctx, req.Model, req.Messages, opts)
-	if err != nil {
-		return nil, fmt.Errorf("chat inference: %w", err)
-	}
-
-	return &ChatResponse{
-		Content:      resp.Content,
-		FinishReason: resp.FinishReason,
-		Usage:        resp.Usage,
-		Model:        resp.Model,
-		ID:           resp.ID,
+		SessionID:        req.SessionID,
 	}, nil
 }
 
@@ -342,11 +598,25 @@ func (s *InferenceService) Complete(ctx context.Context, req CompleteRequest) (*
 		return nil, err
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engines, err := s.selectEngines(m)
 	if err != nil {
 		return nil, fmt.Errorf("select engine: %w", err)
 	}
 
+	engines, err = s.enginesWithinContext(ctx, engines, s.tokenizerFor(req.Model).Count(req.Prompt), req.MaxTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	provs, err := s.providersFor(ctx, m, engines)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, err
+	}
+
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
 			return nil, err
@@ -361,10 +631,15 @@ func (s *InferenceService) Complete(ctx context.Context, req CompleteRequest) (*
 		Stream:      req.Stream,
 	}
 
-	resp, err := s.inferenceProv.Complete(ctx, req.Model, req.Prompt, opts)
+	resp, err := guardEngines(s.breaker, engines, func(engineName string) (*inference.CompletionResponse, error) {
+		return provs[engineName].Complete(ctx, req.Model, req.Prompt, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("completion inference: %w", err)
 	}
+	s.fillUsage(&resp.Usage, req.Model, func() int {
+		return s.tokenizerFor(req.Model).Count(req.Prompt)
+	}, resp.Text)
 
 	return &CompleteResponse{
 		Text:         resp.Text,
@@ -386,18 +661,43 @@ func (s *InferenceService) Embed(ctx context.Context, req EmbedRequest) (*EmbedR
 		return nil, err
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engineName, err := s.selectEngine(m)
 	if err != nil {
 		return nil, fmt.Errorf("select engine: %w", err)
 	}
 
+	prov, err := s.providerFor(ctx, m, engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, err
+	}
+
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
 			return nil, err
 		}
 	}
 
-	resp, err := s.inferenceProv.Embed(ctx, req.Model, req.Input)
+	endpoints, err := s.fanOutEndpoints(ctx, m.ID, len(req.Input))
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize := s.embedBatchSize(ctx, engineName)
+
+	var resp *inference.EmbeddingResponse
+	if len(endpoints) > 0 {
+		resp, err = s.embedFanOut(ctx, req.Model, req.Input, endpoints, batchSize)
+	} else {
+		resp, err = embedInBatches(ctx, req.Input, batchSize, func(ctx context.Context, batch []string) (*inference.EmbeddingResponse, error) {
+			return guardEngine(s.breaker, engineName, func() (*inference.EmbeddingResponse, error) {
+				return prov.Embed(ctx, req.Model, batch)
+			})
+		})
+	}
 	if err != nil {
 		return nil, fmt.Errorf("embedding inference: %w", err)
 	}
@@ -421,18 +721,29 @@ func (s *InferenceService) Transcribe(ctx context.Context, req TranscribeRequest
 		return nil, err
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engineName, err := s.selectEngine(m)
 	if err != nil {
 		return nil, fmt.Errorf("select engine: %w", err)
 	}
 
+	prov, err := s.providerFor(ctx, m, engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, err
+	}
+
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
 			return nil, err
 		}
 	}
 
-	resp, err := s.inferenceProv.Transcribe(ctx, req.Model, req.Audio, req.Language)
+	resp, err := guardEngine(s.breaker, engineName, func() (*inference.TranscriptionResponse, error) {
+		return prov.Transcribe(ctx, req.Model, req.Audio, req.Language)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("transcription inference: %w", err)
 	}
@@ -458,18 +769,29 @@ func (s *InferenceService) Synthesize(ctx context.Context, req SynthesizeRequest
 		return nil, err
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engineName, err := s.selectEngine(m)
 	if err != nil {
 		return nil, fmt.Errorf("select engine: %w", err)
 	}
 
+	prov, err := s.providerFor(ctx, m, engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, err
+	}
+
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
 			return nil, err
 		}
 	}
 
-	resp, err := s.inferenceProv.Synthesize(ctx, req.Model, req.Text, req.Voice)
+	resp, err := guardEngine(s.breaker, engineName, func() (*inference.AudioResponse, error) {
+		return prov.Synthesize(ctx, req.Model, req.Text, req.Voice)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("synthesis inference: %w", err)
 	}
@@ -494,11 +816,20 @@ func (s *InferenceService) GenerateImage(ctx context.Context, req ImageRequest)
 		return nil, err
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engineName, err := s.selectEngine(m)
 	if err != nil {
 		return nil, fmt.Errorf("select engine: %w", err)
 	}
 
+	prov, err := s.providerFor(ctx, m, engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, err
+	}
+
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
 			return nil, err
@@ -514,7 +845,9 @@ func (s *InferenceService) GenerateImage(ctx context.Context, req ImageRequest)
 		Height:         req.Height,
 	}
 
-	resp, err := s.inferenceProv.GenerateImage(ctx, req.Model, req.Prompt, opts)
+	resp, err := guardEngine(s.breaker, engineName, func() (*inference.ImageGenerationResponse, error) {
+		return prov.GenerateImage(ctx, req.Model, req.Prompt, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("image generation inference: %w", err)
 	}
@@ -538,11 +871,20 @@ func (s *InferenceService) GenerateVideo(ctx context.Context, req VideoRequest)
 		return nil, err
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engineName, err := s.selectEngine(m)
 	if err != nil {
 		return nil, fmt.Errorf("select engine: %w", err)
 	}
 
+	prov, err := s.providerFor(ctx, m, engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, err
+	}
+
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
 			return nil, err
@@ -557,7 +899,9 @@ func (s *InferenceService) GenerateVideo(ctx context.Context, req VideoRequest)
 		Seed:     req.Seed,
 	}
 
-	resp, err := s.inferenceProv.GenerateVideo(ctx, req.Model, req.Prompt, opts)
+	resp, err := guardEngine(s.breaker, engineName, func() (*inference.VideoGenerationResponse, error) {
+		return prov.GenerateVideo(ctx, req.Model, req.Prompt, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("video generation inference: %w", err)
 	}
@@ -585,18 +929,29 @@ func (s *InferenceService) Rerank(ctx context.Context, req RerankRequest) (*Rera
 		return nil, err
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engineName, err := s.selectEngine(m)
 	if err != nil {
 		return nil, fmt.Errorf("select engine: %w", err)
 	}
 
+	prov, err := s.providerFor(ctx, m, engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, err
+	}
+
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
 			return nil, err
 		}
 	}
 
-	resp, err := s.inferenceProv.Rerank(ctx, req.Model, req.Query, req.Documents)
+	resp, err := guardEngine(s.breaker, engineName, func() (*inference.RerankResponse, error) {
+		return prov.Rerank(ctx, req.Model, req.Query, req.Documents)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("rerank inference: %w", err)
 	}
@@ -620,18 +975,29 @@ func (s *InferenceService) Detect(ctx context.Context, req DetectRequest) (*Dete
 		return nil, err
 	}
 
-	_, err = s.router.SelectEngine(m.Type, m.Format)
+	engineName, err := s.selectEngine(m)
 	if err != nil {
 		return nil, fmt.Errorf("select engine: %w", err)
 	}
 
+	prov, err := s.providerFor(ctx, m, engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepareService(ctx, m.ID); err != nil {
+		return nil, err
+	}
+
 	if m.Requirements != nil && m.Requirements.MemoryMin > 0 {
 		if err := s.checkResources(ctx, m.Requirements.MemoryMin); err != nil {
 			return nil, err
 		}
 	}
 
-	resp, err := s.inferenceProv.Detect(ctx, req.Model, req.Image)
+	resp, err := guardEngine(s.breaker, engineName, func() (*inference.DetectionResponse, error) {
+		return prov.Detect(ctx, req.Model, req.Image)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("detection inference: %w", err)
 	}
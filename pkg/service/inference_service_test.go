@@ -1087,3 +1087,208 @@ func TestInferenceService_ResourceCheck_Error(t *testing.T) {
 		t.Fatal("expected error for resource check failure")
 	}
 }
+
+type recordingActivity struct {
+	models []string
+	err    error
+}
+
+func (r *recordingActivity) RecordActivity(ctx context.Context, modelID string) error {
+	r.models = append(r.models, modelID)
+	return r.err
+}
+
+func TestInferenceService_Chat_RecordsActivity(t *testing.T) {
+	ctx := context.Background()
+	modelStore := model.NewMemoryStore()
+	engineStore := engine.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "test-model", Name: "Test Model", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady})
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+
+	recorder := &recordingActivity{}
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), nil, inference.NewMockProvider()).
+		WithActivityRecorder(recorder)
+
+	req := ChatRequest{Model: "test-model", Messages: []inference.Message{{Role: "user", Content: "Hello"}}}
+	if _, err := svc.Chat(ctx, req); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if len(recorder.models) != 1 || recorder.models[0] != "test-model" {
+		t.Errorf("expected activity for test-model, got %v", recorder.models)
+	}
+
+	recorder.err = errors.New("restart failed")
+	if _, err := svc.Chat(ctx, req); err == nil {
+		t.Error("expected error when the engine cannot be restarted")
+	}
+}
+
+type stubHealthChecker struct {
+	unhealthy map[string]bool
+	probes    map[string]int
+}
+
+func (c *stubHealthChecker) IsHealthy(ctx context.Context, name string) (*HealthStatus, error) {
+	if c.probes != nil {
+		c.probes[name]++
+	}
+	if c.unhealthy[name] {
+		return &HealthStatus{Healthy: false, Message: "probe failed"}, nil
+	}
+	return &HealthStatus{Healthy: true}, nil
+}
+
+func TestDefaultRouter_SkipsUnhealthyEngines(t *testing.T) {
+	ctx := context.Background()
+	store := engine.NewMemoryStore()
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-a", Name: "vllm-a", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning})
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-b", Name: "vllm-b", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning})
+
+	checker := &stubHealthChecker{unhealthy: map[string]bool{"vllm-a": true}}
+	router := NewDefaultRouter(store).WithHealthChecker(checker)
+
+	for i := 0; i < 10; i++ {
+		name, err := router.SelectEngine(model.ModelTypeLLM, model.FormatSafetensors)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "vllm-b" {
+			t.Fatalf("expected unhealthy engine to be skipped, got %s", name)
+		}
+	}
+}
+
+func TestDefaultRouter_UnhealthyFallsBackToStopped(t *testing.T) {
+	ctx := context.Background()
+	store := engine.NewMemoryStore()
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-a", Name: "vllm-a", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning})
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-b", Name: "vllm-b", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusStopped})
+
+	checker := &stubHealthChecker{unhealthy: map[string]bool{"vllm-a": true}, probes: map[string]int{}}
+	router := NewDefaultRouter(store).WithHealthChecker(checker)
+
+	name, err := router.SelectEngine(model.ModelTypeLLM, model.FormatSafetensors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "vllm-b" {
+		t.Errorf("expected fallback to stopped engine, got %s", name)
+	}
+	if checker.probes["vllm-a"] != 1 {
+		t.Errorf("expected running engine to be probed once, got %d", checker.probes["vllm-a"])
+	}
+	if checker.probes["vllm-b"] != 0 {
+		t.Errorf("expected stopped engine not to be probed, got %d", checker.probes["vllm-b"])
+	}
+
+	_ = store.Delete(ctx, "vllm-b")
+	if _, err := router.SelectEngine(model.ModelTypeLLM, model.FormatSafetensors); !errors.Is(err, ErrEngineNotAvailable) {
+		t.Errorf("expected ErrEngineNotAvailable with only unhealthy engines, got %v", err)
+	}
+}
+
+func TestDefaultRouter_EngineOverride(t *testing.T) {
+	ctx := context.Background()
+	store := engine.NewMemoryStore()
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-2", Name: "vllm", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning})
+
+	gguf := &model.Model{ID: "model-1", Name: "qwen-gguf", Type: model.ModelTypeLLM, Format: model.FormatGGUF}
+	other := &model.Model{ID: "model-2", Name: "llama-gguf", Type: model.ModelTypeLLM, Format: model.FormatGGUF}
+
+	tests := []struct {
+		name      string
+		overrides map[string]engine.EngineType
+		model     *model.Model
+		want      string
+	}{
+		{"no override", nil, gguf, "ollama"},
+		{"override by id", map[string]engine.EngineType{"model-1": engine.EngineTypeVLLM}, gguf, "vllm"},
+		{"override by name", map[string]engine.EngineType{"qwen-gguf": engine.EngineTypeVLLM}, gguf, "vllm"},
+		{"id wins over name", map[string]engine.EngineType{"model-1": engine.EngineTypeOllama, "qwen-gguf": engine.EngineTypeVLLM}, gguf, "ollama"},
+		{"other model unaffected", map[string]engine.EngineType{"model-1": engine.EngineTypeVLLM}, other, "ollama"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewDefaultRouter(store).WithEngineOverrides(tt.overrides)
+			name, err := router.SelectEngineForModel(tt.model)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, name)
+			}
+		})
+	}
+
+	router := NewDefaultRouter(engine.NewMemoryStore()).WithEngineOverrides(map[string]engine.EngineType{"model-1": engine.EngineTypeVLLM})
+	if _, err := router.SelectEngineForModel(gguf); !errors.Is(err, ErrEngineNotAvailable) {
+		t.Errorf("expected ErrEngineNotAvailable without a vllm engine, got %v", err)
+	}
+}
+
+func TestInferenceService_Chat_EngineOverride(t *testing.T) {
+	ctx := context.Background()
+	modelStore := model.NewMemoryStore()
+	engineStore := engine.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "test-model", Name: "Test Model", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady})
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-2", Name: "vllm", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning})
+
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), nil, inference.NewMockProvider()).
+		WithEngineOverrides(map[string]engine.EngineType{"test-model": engine.EngineTypeVLLM})
+
+	engines, _, _, err := svc.prepareChat(ctx, ChatRequest{Model: "test-model", Messages: []inference.Message{{Role: "user", Content: "Hello"}}})
+	if err != nil {
+		t.Fatalf("prepareChat failed: %v", err)
+	}
+	if len(engines) != 1 || engines[0] != "vllm" {
+		t.Errorf("expected override to route only to vllm, got %v", engines)
+	}
+}
+
+func TestDefaultRouter_FallbackChain(t *testing.T) {
+	ctx := context.Background()
+	store := engine.NewMemoryStore()
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-1", Name: "vllm", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusStopped})
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-2", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+	_ = store.Create(ctx, &engine.Engine{ID: "engine-3", Name: "whisper", Type: engine.EngineTypeWhisper, Status: engine.EngineStatusRunning})
+
+	router := NewDefaultRouter(store)
+	llm := &model.Model{ID: "model-1", Name: "llama", Type: model.ModelTypeLLM, Format: model.FormatSafetensors}
+
+	name, err := router.SelectEngine(llm.Type, llm.Format)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "ollama" {
+		t.Errorf("expected running ollama to be preferred over stopped vllm, got %s", name)
+	}
+
+	engines, err := router.SelectEngines(llm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(engines) != 2 || engines[0] != "ollama" || engines[1] != "vllm" {
+		t.Errorf("expected [ollama vllm], got %v", engines)
+	}
+
+	_ = store.Update(ctx, &engine.Engine{ID: "engine-1", Name: "vllm", Type: engine.EngineTypeVLLM, Status: engine.EngineStatusRunning})
+	engines, _ = router.SelectEngines(llm)
+	if len(engines) != 2 || engines[0] != "vllm" {
+		t.Errorf("expected running vllm first, got %v", engines)
+	}
+
+	router.WithEngineOverrides(map[string]engine.EngineType{"model-1": engine.EngineTypeOllama})
+	engines, _ = router.SelectEngines(llm)
+	if len(engines) != 1 || engines[0] != "ollama" {
+		t.Errorf("expected an override to disable fallbacks, got %v", engines)
+	}
+
+	asr := &model.Model{ID: "model-2", Name: "whisper-small", Type: model.ModelTypeASR}
+	engines, _ = router.SelectEngines(asr)
+	if len(engines) != 1 || engines[0] != "whisper" {
+		t.Errorf("expected no fallback for ASR, got %v", engines)
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+// ErrCodeStreamingUnsupported is the error code reported when the engine
+// serving a model cannot stream and buffered fallback is disabled.
+const ErrCodeStreamingUnsupported unit.ErrorCode = "streaming_unsupported"
+
+var ErrStreamingUnsupported = unit.NewError(ErrCodeStreamingUnsupported, "engine does not support streaming")
+
+// EngineFeatureProvider reports an engine's capabilities. EngineService
+// satisfies it.
+type EngineFeatureProvider interface {
+	GetFeatures(ctx context.Context, name string) (*engine.EngineFeatures, error)
+}
+
+// WithEngineFeatures enables checks against the selected engine's reported
+// capabilities, such as rejecting prompts longer than its MaxContextLength.
+func (s *InferenceService) WithEngineFeatures(features EngineFeatureProvider) *InferenceService {
+	s.features = features
+	return s
+}
+
+// WithStreamingNegotiation makes ChatStream check the selected engine's
+// SupportsStreaming feature first. For engines that cannot stream, fallback
+// selects between sending the buffered Chat result as a single chunk and
+// failing with ErrStreamingUnsupported.
+func (s *InferenceService) WithStreamingNegotiation(features EngineFeatureProvider, fallback bool) *InferenceService {
+	s.features = features
+	s.streamFallback = fallback
+	return s
+}
+
+// WithEvents makes ChatStream publish inference.request_completed for every
+// finished stream, with its duration and time to first token.
+func (s *InferenceService) WithEvents(events unit.EventPublisher) *InferenceService {
+	s.events = events
+	return s
+}
+
+// ChatStream streams a chat completion into stream, which the caller owns
+// and closes.
+func (s *InferenceService) ChatStream(ctx context.Context, req ChatRequest, stream chan<- inference.ChatStreamChunk) error {
+	ec := unit.NewExecutionContext(s.events, "inference", "inference.chat")
+	timed := make(chan inference.ChatStreamChunk)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(timed)
+		errCh <- s.chatStream(ctx, req, timed)
+	}()
+
+	metrics := inference.RequestMetrics{Model: req.Model}
+	for chunk := range timed {
+		if chunk.Content != "" && metrics.TimeToFirstToken == 0 {
+			metrics.TimeToFirstToken = time.Since(ec.StartTime)
+		}
+		if chunk.FinishReason != "" {
+			metrics.FinishReason = chunk.FinishReason
+		}
+		if chunk.Usage != nil {
+			metrics.Usage = *chunk.Usage
+		}
+		select {
+		case stream <- chunk:
+		case <-ctx.Done():
+			// Let the engine call see the cancellation and return.
+			go func() {
+				for range timed {
+				}
+			}()
+			return ctx.Err()
+		}
+	}
+	if err := <-errCh; err != nil {
+		return err
+	}
+
+	if s.events != nil {
+		metrics.RequestID = unit.GetRequestID(ctx)
+		metrics.Principal = unit.GetUserID(ctx)
+		metrics.Duration = time.Since(ec.StartTime)
+		_ = s.events.Publish(inference.NewRequestCompletedMetricsEvent(ec.CorrelationID, metrics))
+	}
+	return nil
+}
+
+// chatStream picks the engine for req and streams its reply into stream.
+func (s *InferenceService) chatStream(ctx context.Context, req ChatRequest, stream chan<- inference.ChatStreamChunk) error {
+	engines, m, opts, err := s.prepareChat(ctx, req)
+	if err != nil {
+		return err
+	}
+	prov, err := s.providerFor(ctx, m, engines[0])
+	if err != nil {
+		return err
+	}
+	// A stream cannot be replayed on another engine once chunks have been
+	// sent, so only the best engine is used.
+	engineName := engines[0]
+
+	// Templated models are served through Complete, which does not stream.
+	if m.PromptTemplate != "" {
+		return s.bufferedChatStream(ctx, prov, engineName, m, req, opts, stream)
+	}
+
+	if !s.engineCanStream(ctx, engineName) {
+		if !s.streamFallback {
+			return fmt.Errorf("engine %s: %w", engineName, ErrStreamingUnsupported)
+		}
+		return s.bufferedChatStream(ctx, prov, engineName, m, req, opts, stream)
+	}
+
+	opts.Stream = true
+	_, err = guardEngine(s.breaker, engineName, func() (struct{}, error) {
+		return struct{}{}, prov.ChatStream(ctx, req.Model, req.Messages, opts, stream)
+	})
+	if err != nil {
+		return fmt.Errorf("chat stream inference: %w", err)
+	}
+	return nil
+}
+
+// engineCanStream reports whether name supports streaming. Without a
+// feature provider, or when the lookup fails, streaming is attempted as
+// before negotiation existed.
+func (s *InferenceService) engineCanStream(ctx context.Context, name string) bool {
+	if s.features == nil {
+		return true
+	}
+	features, err := s.features.GetFeatures(ctx, name)
+	if err != nil || features == nil {
+		slog.Debug("engine features unavailable, assuming streaming support", "engine", name, "error", err)
+		return true
+	}
+	return features.SupportsStreaming
+}
+
+// bufferedChatStream runs a regular Chat call and sends its result as one
+// final chunk.
+func (s *InferenceService) bufferedChatStream(ctx context.Context, prov inference.InferenceProvider, engineName string, m *model.Model, req ChatRequest, opts inference.ChatOptions, stream chan<- inference.ChatStreamChunk) error {
+	opts.Stream = false
+	resp, err := guardEngine(s.breaker, engineName, func() (*inference.ChatResponse, error) {
+		return s.chatOnce(ctx, prov, m, req, opts)
+	})
+	if err != nil {
+		return fmt.Errorf("chat inference: %w", err)
+	}
+
+	usage := resp.Usage
+	select {
+	case stream <- inference.ChatStreamChunk{
+		ID:           resp.ID,
+		Model:        resp.Model,
+		Created:      resp.Created,
+		Content:      resp.Content,
+		FinishReason: resp.FinishReason,
+		Usage:        &usage,
+	}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+)
+
+type staticFeatures map[string]engine.EngineFeatures
+
+func (f staticFeatures) GetFeatures(ctx context.Context, name string) (*engine.EngineFeatures, error) {
+	features, ok := f[name]
+	if !ok {
+		return nil, errors.New("unknown engine")
+	}
+	return &features, nil
+}
+
+func newStreamFixture(t *testing.T, provider inference.InferenceProvider, supportsStreaming bool, fallback bool) *InferenceService {
+	t.Helper()
+	ctx := context.Background()
+
+	modelStore := model.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "chat-model", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady})
+	engineStore := engine.NewMemoryStore()
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), &resource.MockProvider{}, provider)
+	return svc.WithStreamingNegotiation(staticFeatures{"ollama": {SupportsStreaming: supportsStreaming}}, fallback)
+}
+
+func collectChatStream(t *testing.T, svc *InferenceService) ([]inference.ChatStreamChunk, error) {
+	t.Helper()
+	stream := make(chan inference.ChatStreamChunk, 16)
+	err := svc.ChatStream(context.Background(), ChatRequest{
+		Model:    "chat-model",
+		Messages: []inference.Message{{Role: "user", Content: "Hello"}},
+	}, stream)
+	close(stream)
+
+	var chunks []inference.ChatStreamChunk
+	for chunk := range stream {
+		chunks = append(chunks, chunk)
+	}
+	return chunks, err
+}
+
+func TestInferenceService_ChatStream_StreamingEngine(t *testing.T) {
+	provider := inference.NewMockProvider()
+	provider.SetChatStreamChunks([]inference.ChatStreamChunk{{Content: "Hel"}, {Content: "lo"}, {FinishReason: "stop"}})
+	svc := newStreamFixture(t, provider, true, false)
+
+	chunks, err := collectChatStream(t, svc)
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("expected the engine's 3 chunks, got %d", len(chunks))
+	}
+	if req := provider.LastChatRequest(); req == nil || !req.Options.Stream {
+		t.Errorf("expected a streaming provider call, got %+v", req)
+	}
+}
+
+func TestInferenceService_ChatStream_UnsupportedEngineFallsBack(t *testing.T) {
+	provider := inference.NewMockProvider()
+	provider.SetChatResponse(&inference.ChatResponse{Content: "Hello there", FinishReason: "stop", Usage: inference.Usage{TotalTokens: 7}})
+	svc := newStreamFixture(t, provider, false, true)
+
+	chunks, err := collectChatStream(t, svc)
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("expected a single buffered chunk, got %d", len(chunks))
+	}
+	if chunks[0].Content != "Hello there" || chunks[0].FinishReason != "stop" || chunks[0].Usage.TotalTokens != 7 {
+		t.Errorf("unexpected buffered chunk: %+v", chunks[0])
+	}
+	if req := provider.LastChatRequest(); req == nil || req.Options.Stream {
+		t.Errorf("expected a buffered provider call, got %+v", req)
+	}
+}
+
+func TestInferenceService_ChatStream_UnsupportedEngineRejected(t *testing.T) {
+	provider := inference.NewMockProvider()
+	svc := newStreamFixture(t, provider, false, false)
+
+	chunks, err := collectChatStream(t, svc)
+	if !errors.Is(err, ErrStreamingUnsupported) {
+		t.Fatalf("expected ErrStreamingUnsupported, got %v", err)
+	}
+	if ue, ok := unit.AsUnitError(err); !ok || ue.Code != "streaming_unsupported" {
+		t.Errorf("expected error code streaming_unsupported, got %v", err)
+	}
+	if len(chunks) != 0 || provider.ChatCalls() != 0 {
+		t.Errorf("expected no provider call, got %d chunks and %d calls", len(chunks), provider.ChatCalls())
+	}
+}
+
+// slowStartProvider streams "Hello" with a delay before the first chunk and
+// another before the rest.
+type slowStartProvider struct {
+	*inference.MockProvider
+	firstDelay, restDelay time.Duration
+}
+
+func (p *slowStartProvider) ChatStream(ctx context.Context, model string, messages []inference.Message, opts inference.ChatOptions, stream chan<- inference.ChatStreamChunk) error {
+	time.Sleep(p.firstDelay)
+	stream <- inference.ChatStreamChunk{Content: "Hel"}
+	time.Sleep(p.restDelay)
+	stream <- inference.ChatStreamChunk{Content: "lo"}
+	stream <- inference.ChatStreamChunk{FinishReason: "stop", Usage: &inference.Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}}
+	return nil
+}
+
+type capturingPublisher struct {
+	mu     sync.Mutex
+	events []any
+}
+
+func (p *capturingPublisher) Publish(event any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestInferenceService_ChatStream_TimeToFirstToken(t *testing.T) {
+	provider := &slowStartProvider{MockProvider: inference.NewMockProvider(), firstDelay: 30 * time.Millisecond, restDelay: 60 * time.Millisecond}
+	events := &capturingPublisher{}
+	svc := newStreamFixture(t, provider, true, false).WithEvents(events)
+
+	chunks, err := collectChatStream(t, svc)
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("expected all 3 chunks to be forwarded, got %d", len(chunks))
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected one event, got %d", len(events.events))
+	}
+	evt, ok := events.events[0].(*inference.RequestCompletedEvent)
+	if !ok || evt.Type() != inference.EventTypeRequestCompleted {
+		t.Fatalf("expected inference.request_completed, got %#v", events.events[0])
+	}
+	payload := evt.Payload().(map[string]any)
+	ttft, _ := payload["ttft_ms"].(int64)
+	duration, _ := payload["duration_ms"].(int64)
+	if ttft < 30 {
+		t.Errorf("expected ttft_ms to include the first-chunk delay, got %d", ttft)
+	}
+	if duration < ttft+60 {
+		t.Errorf("expected duration_ms %d to exceed ttft_ms %d by the later delay", duration, ttft)
+	}
+	if payload["model"] != "chat-model" || payload["finish_reason"] != "stop" || payload["total_tokens"] != 5 {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+}
+
+func TestInferenceService_ChatStream_NoEventOnFailure(t *testing.T) {
+	provider := inference.NewMockProvider()
+	provider.SetChatError(errors.New("engine exploded"))
+	events := &capturingPublisher{}
+	svc := newStreamFixture(t, provider, true, false).WithEvents(events)
+
+	if _, err := collectChatStream(t, svc); err == nil {
+		t.Fatal("expected the engine error")
+	}
+	if len(events.events) != 0 {
+		t.Errorf("expected no request_completed for a failed stream, got %d events", len(events.events))
+	}
+}
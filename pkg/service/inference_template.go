@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+// chatOnce runs one chat call. Models with a PromptTemplate are base models
+// without a chat endpoint: their messages are rendered into a prompt and
+// sent to Complete, and the completion is returned as the assistant reply.
+func (s *InferenceService) chatOnce(ctx context.Context, prov inference.InferenceProvider, m *model.Model, req ChatRequest, opts inference.ChatOptions) (*inference.ChatResponse, error) {
+	if m == nil || m.PromptTemplate == "" {
+		return prov.Chat(ctx, req.Model, req.Messages, opts)
+	}
+
+	prompt, err := inference.RenderPrompt(m.PromptTemplate, req.Messages)
+	if err != nil {
+		return nil, fmt.Errorf("model %s: %w", m.Name, err)
+	}
+	resp, err := prov.Complete(ctx, req.Model, prompt, inference.CompleteOptions{
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+		TopP:        opts.TopP,
+		Stop:        opts.Stop,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &inference.ChatResponse{
+		Model:        req.Model,
+		Content:      resp.Text,
+		FinishReason: resp.FinishReason,
+		Usage:        resp.Usage,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/resource"
+)
+
+const chatMLTemplate = `{{range .messages}}<|im_start|>{{.role}}
+{{.content}}<|im_end|>
+{{end}}<|im_start|>assistant
+`
+
+type promptRecordingProvider struct {
+	*inference.MockProvider
+	prompt   string
+	chatCall bool
+}
+
+func (p *promptRecordingProvider) Complete(ctx context.Context, modelName, prompt string, opts inference.CompleteOptions) (*inference.CompletionResponse, error) {
+	p.prompt = prompt
+	return p.MockProvider.Complete(ctx, modelName, prompt, opts)
+}
+
+func (p *promptRecordingProvider) Chat(ctx context.Context, modelName string, messages []inference.Message, opts inference.ChatOptions) (*inference.ChatResponse, error) {
+	p.chatCall = true
+	return p.MockProvider.Chat(ctx, modelName, messages, opts)
+}
+
+func TestInferenceService_Chat_PromptTemplate(t *testing.T) {
+	ctx := context.Background()
+	modelStore := model.NewMemoryStore()
+	engineStore := engine.NewMemoryStore()
+	_ = modelStore.Create(ctx, &model.Model{ID: "base-model", Name: "Base Model", Type: model.ModelTypeLLM, Format: model.FormatGGUF, Status: model.StatusReady, PromptTemplate: chatMLTemplate})
+	_ = engineStore.Create(ctx, &engine.Engine{ID: "engine-1", Name: "ollama", Type: engine.EngineTypeOllama, Status: engine.EngineStatusRunning})
+
+	provider := &promptRecordingProvider{MockProvider: inference.NewMockProvider()}
+	svc := NewInferenceService(unit.NewRegistry(), modelStore, engineStore, resource.NewMemoryStore(), nil, provider)
+
+	resp, err := svc.Chat(ctx, ChatRequest{Model: "base-model", Messages: []inference.Message{{Role: "user", Content: "Hello"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.chatCall {
+		t.Error("expected a templated model to be served through Complete")
+	}
+	if !strings.HasPrefix(provider.prompt, "<|im_start|>user\nHello<|im_end|>") {
+		t.Errorf("expected the rendered ChatML prompt, got %q", provider.prompt)
+	}
+	if resp.Content != "This is a mock completion response." || resp.FinishReason != "stop" {
+		t.Errorf("expected the completion as the reply, got %+v", resp)
+	}
+}
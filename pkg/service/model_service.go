@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/eventbus"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
@@ -14,15 +17,53 @@ type ModelService struct {
 	store    model.ModelStore
 	provider model.ModelProvider
 	bus      *eventbus.InMemoryEventBus
+	pulls    singleflight.Group
+	// pullSlots bounds the number of pulls running at once; nil means
+	// unbounded.
+	pullSlots chan struct{}
+	// pullTimeout bounds a shared pull, which outlives the callers waiting
+	// on it.
+	pullTimeout time.Duration
 }
 
+// DefaultPullTimeout bounds a pull started by PullAndVerify unless
+// WithPullTimeout sets another limit.
+const DefaultPullTimeout = 30 * time.Minute
+
 func NewModelService(registry *unit.Registry, store model.ModelStore, provider model.ModelProvider, bus *eventbus.InMemoryEventBus) *ModelService {
 	return &ModelService{
-		registry: registry,
-		store:    store,
-		provider: provider,
-		bus:      bus,
+		registry:    registry,
+		store:       store,
+		provider:    provider,
+		bus:         bus,
+		pullTimeout: DefaultPullTimeout,
+	}
+}
+
+// WithPullTimeout bounds how long a pull started by PullAndVerify may run.
+// A non-positive d uses DefaultPullTimeout.
+func (s *ModelService) WithPullTimeout(d time.Duration) *ModelService {
+	if d <= 0 {
+		d = DefaultPullTimeout
 	}
+	s.pullTimeout = d
+	return s
+}
+
+// PullStatusQueued is the progress status published for a pull waiting for
+// a free slot under the concurrent-pull limit.
+const PullStatusQueued = "queued"
+
+// WithMaxConcurrentPulls limits how many distinct pulls run at once. Further
+// pulls wait for a slot, publishing a "queued" progress event; their callers
+// stop waiting when their context ends. n <= 0 removes the limit.
+func (s *ModelService) WithMaxConcurrentPulls(n int) *ModelService {
+	if n <= 0 {
+		s.pullSlots = nil
+		return s
+	}
+	s.pullSlots = make(chan struct{}, n)
+	return s
 }
 
 type PullAndVerifyResult struct {
@@ -32,12 +73,41 @@ type PullAndVerifyResult struct {
 	Requirements *model.ModelRequirements
 }
 
+// PullAndVerify pulls and verifies a model. Concurrent calls for the same
+// source/repo/tag share one in-flight pull and all receive its result. Each
+// caller stops waiting when its own context ends; the shared pull is
+// detached from the caller that started it and bounded by the pull timeout.
 func (s *ModelService) PullAndVerify(ctx context.Context, source, repo, tag string) (*PullAndVerifyResult, error) {
+	key := source + "/" + repo + "/" + tag
+	resultCh := s.pulls.DoChan(key, func() (any, error) {
+		pullCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.pullTimeout)
+		defer cancel()
+		return s.pullAndVerify(pullCtx, source, repo, tag)
+	})
+
+	select {
+	case res := <-resultCh:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*PullAndVerifyResult), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *ModelService) pullAndVerify(ctx context.Context, source, repo, tag string) (*PullAndVerifyResult, error) {
 	pullCmd := s.registry.GetCommand("model.pull")
 	if pullCmd == nil {
 		return nil, fmt.Errorf("model.pull command not found")
 	}
 
+	release, err := s.acquirePullSlot(ctx, source, repo, tag)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	input := map[string]any{
 		"source": source,
 		"repo":   repo,
@@ -403,6 +473,34 @@ func (s *ModelService) List(ctx context.Context, filter model.ModelFilter) ([]mo
 	return s.store.List(ctx, filter)
 }
 
+// acquirePullSlot waits for a free pull slot and returns the function that
+// frees it again.
+func (s *ModelService) acquirePullSlot(ctx context.Context, source, repo, tag string) (func(), error) {
+	if s.pullSlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case s.pullSlots <- struct{}{}:
+		return func() { <-s.pullSlots }, nil
+	default:
+	}
+
+	s.publishEvent(ctx, model.EventTypePullProgress, map[string]any{
+		"source": source,
+		"repo":   repo,
+		"tag":    tag,
+		"status": PullStatusQueued,
+	})
+
+	select {
+	case s.pullSlots <- struct{}{}:
+		return func() { <-s.pullSlots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for pull slot: %w", ctx.Err())
+	}
+}
+
 func (s *ModelService) rollbackDelete(ctx context.Context, modelID string) error {
 	deleteCmd := s.registry.GetCommand("model.delete")
 	if deleteCmd == nil {
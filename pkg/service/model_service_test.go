@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/infra/eventbus"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
@@ -1218,3 +1221,206 @@ func TestModelService_PublishEvent_WithNilBus(t *testing.T) {
 		t.Error("expected successful result even with nil bus")
 	}
 }
+
+type blockingPullProvider struct {
+	model.MockProvider
+	pulls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingPullProvider) Pull(ctx context.Context, source, repo, tag string, progressCh chan<- model.PullProgress) (*model.Model, error) {
+	if p.pulls.Add(1) == 1 {
+		close(p.started)
+	}
+	<-p.release
+	if progressCh != nil {
+		progressCh <- model.PullProgress{Status: "downloading", Progress: 0.5}
+		progressCh <- model.PullProgress{Status: "completed", Progress: 1}
+	}
+	return p.MockProvider.Pull(ctx, source, repo, tag, nil)
+}
+
+func TestModelService_PullAndVerify_ConcurrentPullsDedupe(t *testing.T) {
+	store := model.NewMemoryStore()
+	provider := &blockingPullProvider{started: make(chan struct{}), release: make(chan struct{})}
+	bus := eventbus.NewInMemoryEventBus()
+
+	var progressEvents atomic.Int32
+	_, _ = bus.Subscribe(func(e unit.Event) error {
+		if e.Type() == model.EventTypePullProgress {
+			progressEvents.Add(1)
+		}
+		return nil
+	})
+
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(model.NewPullCommandWithEvents(store, provider, eventbus.NewEventPublisherAdapter(bus)))
+	svc := NewModelService(registry, store, provider, bus)
+
+	const callers = 10
+	var ready, wg sync.WaitGroup
+	results := make([]*PullAndVerifyResult, callers)
+	errs := make([]error, callers)
+	ready.Add(callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ready.Done()
+			results[i], errs[i] = svc.PullAndVerify(context.Background(), "ollama", "llama3", "latest")
+		}(i)
+	}
+
+	ready.Wait()
+	<-provider.started
+	// Give the remaining callers time to join the in-flight pull.
+	time.Sleep(50 * time.Millisecond)
+	close(provider.release)
+	wg.Wait()
+
+	if got := provider.pulls.Load(); got != 1 {
+		t.Fatalf("expected provider Pull to be invoked once, got %d", got)
+	}
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: unexpected error: %v", i, errs[i])
+		}
+		if results[i] != results[0] {
+			t.Errorf("caller %d: expected the shared result", i)
+		}
+	}
+
+	_ = bus.Close()
+	if got := progressEvents.Load(); got != 2 {
+		t.Errorf("expected a single merged progress stream of 2 events, got %d", got)
+	}
+}
+
+func TestModelService_PullAndVerify_FirstCallerCancels(t *testing.T) {
+	store := model.NewMemoryStore()
+	provider := &blockingPullProvider{started: make(chan struct{}), release: make(chan struct{})}
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(model.NewPullCommand(store, provider))
+	svc := NewModelService(registry, store, provider, nil)
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := svc.PullAndVerify(firstCtx, "ollama", "llama3", "latest")
+		first <- err
+	}()
+	<-provider.started
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := svc.PullAndVerify(context.Background(), "ollama", "llama3", "latest")
+		second <- err
+	}()
+	// Give the second caller time to join the in-flight pull.
+	time.Sleep(50 * time.Millisecond)
+
+	cancelFirst()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the first caller to end with its context, got %v", err)
+	}
+
+	close(provider.release)
+	if err := <-second; err != nil {
+		t.Errorf("expected the second caller to get the shared pull, got %v", err)
+	}
+	if got := provider.pulls.Load(); got != 1 {
+		t.Errorf("expected one shared pull, got %d", got)
+	}
+}
+
+func TestModelService_PullAndVerify_ConcurrencyLimit(t *testing.T) {
+	store := model.NewMemoryStore()
+	bus := eventbus.NewInMemoryEventBus()
+
+	var queuedEvents atomic.Int32
+	_, _ = bus.Subscribe(func(e unit.Event) error {
+		if payload, ok := e.Payload().(map[string]any); ok && payload["status"] == PullStatusQueued {
+			queuedEvents.Add(1)
+		}
+		return nil
+	})
+
+	var running, maxRunning atomic.Int32
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(&mockCommand{
+		name: "model.pull",
+		execute: func(ctx context.Context, input any) (any, error) {
+			n := running.Add(1)
+			for {
+				peak := maxRunning.Load()
+				if n <= peak || maxRunning.CompareAndSwap(peak, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			running.Add(-1)
+
+			repo := input.(map[string]any)["repo"].(string)
+			m := &model.Model{ID: "model-" + repo, Name: repo, Status: model.StatusReady}
+			_ = store.Create(ctx, m)
+			return map[string]any{"model_id": m.ID}, nil
+		},
+	})
+	svc := NewModelService(registry, store, &model.MockProvider{}, bus).WithMaxConcurrentPulls(2)
+
+	repos := []string{"llama3", "qwen2", "mistral", "phi3", "gemma"}
+	var wg sync.WaitGroup
+	errs := make([]error, len(repos))
+	for i, repo := range repos {
+		wg.Add(1)
+		go func(i int, repo string) {
+			defer wg.Done()
+			_, errs[i] = svc.PullAndVerify(context.Background(), "ollama", repo, "latest")
+		}(i, repo)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("pull %s: unexpected error: %v", repos[i], err)
+		}
+	}
+	if got := maxRunning.Load(); got != 2 {
+		t.Errorf("expected at most 2 pulls at once, got %d", got)
+	}
+
+	_ = bus.Close()
+	if queuedEvents.Load() == 0 {
+		t.Error("expected queued progress events for pulls over the limit")
+	}
+}
+
+func TestModelService_PullAndVerify_QueuedPullCancelled(t *testing.T) {
+	store := model.NewMemoryStore()
+	provider := &blockingPullProvider{started: make(chan struct{}), release: make(chan struct{})}
+	registry := unit.NewRegistry()
+	_ = registry.RegisterCommand(model.NewPullCommand(store, provider))
+	svc := NewModelService(registry, store, provider, nil).WithMaxConcurrentPulls(1)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.PullAndVerify(context.Background(), "ollama", "llama3", "latest")
+		done <- err
+	}()
+	<-provider.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := svc.PullAndVerify(ctx, "ollama", "qwen2", "latest"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the queued pull to end with its context, got %v", err)
+	}
+	if got := provider.pulls.Load(); got != 1 {
+		t.Errorf("expected the queued pull never to reach the provider, got %d pulls", got)
+	}
+
+	close(provider.release)
+	if err := <-done; err != nil {
+		t.Errorf("first pull: unexpected error: %v", err)
+	}
+}
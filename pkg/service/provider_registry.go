@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+var ErrProviderAlreadyRegistered = errors.New("inference provider already registered")
+
+// ProviderRegistry maps provider names, e.g. "ollama" or "openai", to the
+// inference backends behind them so several can serve requests side by side.
+// Models and engine types can be routed to a provider; everything else goes
+// to the default provider.
+type ProviderRegistry struct {
+	mu              sync.RWMutex
+	providers       map[string]inference.InferenceProvider
+	models          map[string]string
+	engines         map[engine.EngineType]string
+	defaultProvider string
+}
+
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{
+		providers: make(map[string]inference.InferenceProvider),
+		models:    make(map[string]string),
+		engines:   make(map[engine.EngineType]string),
+	}
+}
+
+// Register adds provider under name. Registering does not make it the
+// default; SetDefault does.
+func (r *ProviderRegistry) Register(name string, provider inference.InferenceProvider) error {
+	if name == "" || provider == nil {
+		return fmt.Errorf("provider name and implementation are required: %w", ErrInvalidRequest)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.providers[name]; exists {
+		return fmt.Errorf("%w: %s", ErrProviderAlreadyRegistered, name)
+	}
+	r.providers[name] = provider
+	return nil
+}
+
+// Get returns the provider registered as name.
+func (r *ProviderRegistry) Get(name string) (inference.InferenceProvider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+// Names lists the registered providers in name order.
+func (r *ProviderRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetDefault makes name serve requests no route or override applies to.
+func (r *ProviderRegistry) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.providers[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrProviderNotFound, name)
+	}
+	r.defaultProvider = name
+	return nil
+}
+
+// RouteModel sends requests for a model, by ID or name, to provider.
+func (r *ProviderRegistry) RouteModel(modelRef, provider string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.providers[provider]; !ok {
+		return fmt.Errorf("%w: %s", ErrProviderNotFound, provider)
+	}
+	r.models[modelRef] = provider
+	return nil
+}
+
+// RouteEngine sends requests served by engines of engineType to provider,
+// e.g. vLLM engines to an OpenAI-compatible provider.
+func (r *ProviderRegistry) RouteEngine(engineType engine.EngineType, provider string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.providers[provider]; !ok {
+		return fmt.Errorf("%w: %s", ErrProviderNotFound, provider)
+	}
+	r.engines[engineType] = provider
+	return nil
+}
+
+// Select returns the provider for a request: the override when one is given,
+// else the one routed for m by ID then name, else the one routed for
+// engineType, else the default. It returns nil without an error when nothing
+// applies and no provider is registered.
+func (r *ProviderRegistry) Select(override string, m *model.Model, engineType engine.EngineType) (inference.InferenceProvider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name := override
+	if name == "" && m != nil {
+		if routed, ok := r.models[m.ID]; ok {
+			name = routed
+		} else if routed, ok := r.models[m.Name]; ok {
+			name = routed
+		}
+	}
+	if name == "" && engineType != "" {
+		name = r.engines[engineType]
+	}
+	if name == "" {
+		name = r.defaultProvider
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, name)
+	}
+	return provider, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/engine"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/inference"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/model"
+)
+
+func TestProviderRegistry_Register(t *testing.T) {
+	r := NewProviderRegistry()
+	ollama := inference.NewMockProvider()
+	openai := inference.NewMockProvider()
+
+	if err := r.Register("ollama", ollama); err != nil {
+		t.Fatalf("register ollama: %v", err)
+	}
+	if err := r.Register("openai", openai); err != nil {
+		t.Fatalf("register openai: %v", err)
+	}
+	if err := r.Register("ollama", openai); !errors.Is(err, ErrProviderAlreadyRegistered) {
+		t.Errorf("expected ErrProviderAlreadyRegistered, got %v", err)
+	}
+	if err := r.Register("", openai); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest for an empty name, got %v", err)
+	}
+	if err := r.Register("vllm", nil); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest for a nil provider, got %v", err)
+	}
+
+	if got, ok := r.Get("openai"); !ok || got != openai {
+		t.Errorf("expected Get to return the openai provider, got %v, %v", got, ok)
+	}
+	if names := r.Names(); !reflect.DeepEqual(names, []string{"ollama", "openai"}) {
+		t.Errorf("expected [ollama openai], got %v", names)
+	}
+}
+
+func TestProviderRegistry_Select(t *testing.T) {
+	r := NewProviderRegistry()
+	ollama := inference.NewMockProvider()
+	openai := inference.NewMockProvider()
+	_ = r.Register("ollama", ollama)
+	_ = r.Register("openai", openai)
+	_ = r.RouteEngine(engine.EngineTypeVLLM, "openai")
+	_ = r.RouteModel("model-2", "ollama")
+
+	llama := &model.Model{ID: "model-1", Name: "llama3"}
+	qwen := &model.Model{ID: "model-2", Name: "qwen2.5"}
+	tests := []struct {
+		name       string
+		override   string
+		model      *model.Model
+		engineType engine.EngineType
+		want       inference.InferenceProvider
+	}{
+		{"no default without SetDefault", "", llama, engine.EngineTypeOllama, nil},
+		{"engine route", "", llama, engine.EngineTypeVLLM, openai},
+		{"model route wins over engine route", "", qwen, engine.EngineTypeVLLM, ollama},
+		{"override wins over routes", "openai", qwen, engine.EngineTypeOllama, openai},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Select(tt.override, tt.model, tt.engineType)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("selected the wrong provider")
+			}
+		})
+	}
+
+	if _, err := r.Select("anthropic", llama, ""); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("expected ErrProviderNotFound for an unknown override, got %v", err)
+	}
+	if err := r.SetDefault("openai"); err != nil {
+		t.Fatalf("set default: %v", err)
+	}
+	if got, _ := r.Select("", llama, engine.EngineTypeOllama); got != openai {
+		t.Error("expected SetDefault to change the default provider")
+	}
+}
+
+func TestProviderRegistry_UnknownProviders(t *testing.T) {
+	r := NewProviderRegistry()
+	_ = r.Register("ollama", inference.NewMockProvider())
+
+	if err := r.SetDefault("openai"); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("SetDefault: expected ErrProviderNotFound, got %v", err)
+	}
+	if err := r.RouteModel("llama3", "openai"); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("RouteModel: expected ErrProviderNotFound, got %v", err)
+	}
+	if err := r.RouteEngine(engine.EngineTypeVLLM, "openai"); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("RouteEngine: expected ErrProviderNotFound, got %v", err)
+	}
+
+	// An empty registry selects nothing, leaving the choice to the caller.
+	if got, err := NewProviderRegistry().Select("", nil, ""); got != nil || err != nil {
+		t.Errorf("expected no provider from an empty registry, got %v, %v", got, err)
+	}
+}
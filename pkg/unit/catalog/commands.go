@@ -3,7 +3,10 @@ package catalog
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"log/slog"
+	"os"
+	"sort"
 
 	"github.com/google/uuid"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
@@ -403,6 +406,234 @@ func (c *ApplyRecipeCommand) Execute(ctx context.Context, input any) (any, error
 	}, nil
 }
 
+// PullCommand pulls a curated catalog model by delegating to the model
+// domain's pull command with the entry's source and repository.
+type PullCommand struct {
+	assets []ModelAsset
+	puller unit.Command
+	events unit.EventPublisher
+}
+
+func NewPullCommand(assets []ModelAsset, puller unit.Command) *PullCommand {
+	return &PullCommand{assets: assets, puller: puller}
+}
+
+func NewPullCommandWithEvents(assets []ModelAsset, puller unit.Command, events unit.EventPublisher) *PullCommand {
+	return &PullCommand{assets: assets, puller: puller, events: events}
+}
+
+func (c *PullCommand) Name() string        { return "catalog.pull" }
+func (c *PullCommand) Domain() string      { return "catalog" }
+func (c *PullCommand) Description() string { return "Pull a model from the curated catalog by name" }
+
+func (c *PullCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"name": {
+				Name: "name",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Catalog model name (case-insensitive)",
+				},
+			},
+			"tag": {
+				Name: "tag",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Optional version tag passed to the provider",
+				},
+			},
+		},
+		Required: []string{"name"},
+	}
+}
+
+func (c *PullCommand) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"name":     {Name: "name", Schema: unit.Schema{Type: "string"}},
+			"source":   {Name: "source", Schema: unit.Schema{Type: "string"}},
+			"repo":     {Name: "repo", Schema: unit.Schema{Type: "string"}},
+			"model_id": {Name: "model_id", Schema: unit.Schema{Type: "string"}},
+			"status":   {Name: "status", Schema: unit.Schema{Type: "string"}},
+		},
+	}
+}
+
+func (c *PullCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input:       map[string]any{"name": "SenseVoiceSmall"},
+			Output:      map[string]any{"name": "SenseVoiceSmall", "source": "huggingface", "repo": "iic/SenseVoiceSmall", "model_id": "model-abc123", "status": "ready"},
+			Description: "Pull a curated ASR model",
+		},
+	}
+}
+
+func (c *PullCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.puller == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	name, _ := inputMap["name"].(string)
+	if name == "" {
+		err := fmt.Errorf("name is required: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	asset, found := FindModelAsset(c.assets, name)
+	if !found {
+		err := fmt.Errorf("model %s: %w", name, ErrModelAssetNotFound)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+	if asset.SourceType == "" || asset.SourceRepo == "" {
+		err := fmt.Errorf("model %s has no pull source: %w", asset.Name, ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	pullInput := map[string]any{
+		"source": asset.SourceType,
+		"repo":   asset.SourceRepo,
+	}
+	if tag, ok := inputMap["tag"].(string); ok && tag != "" {
+		pullInput["tag"] = tag
+	}
+
+	result, err := c.puller.Execute(ctx, pullInput)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("pull catalog model %s: %w", asset.Name, err)
+	}
+
+	output := map[string]any{
+		"name":   asset.Name,
+		"source": asset.SourceType,
+		"repo":   asset.SourceRepo,
+	}
+	if resultMap, ok := result.(map[string]any); ok {
+		for k, v := range resultMap {
+			if _, exists := output[k]; !exists {
+				output[k] = v
+			}
+		}
+	}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+// EngineAssetReloader re-reads engine assets at runtime.
+type EngineAssetReloader interface {
+	// ReloadAssets overlays the assets in fsys on the embedded catalog; a
+	// nil fsys restores the embedded catalog alone.
+	ReloadAssets(fsys fs.FS) error
+	// AssetTypes returns the engine types of the loaded assets.
+	AssetTypes() []string
+}
+
+type ReloadCommand struct {
+	reloader EngineAssetReloader
+	events   unit.EventPublisher
+}
+
+func NewReloadCommand(reloader EngineAssetReloader) *ReloadCommand {
+	return &ReloadCommand{reloader: reloader}
+}
+
+func NewReloadCommandWithEvents(reloader EngineAssetReloader, events unit.EventPublisher) *ReloadCommand {
+	return &ReloadCommand{reloader: reloader, events: events}
+}
+
+func (c *ReloadCommand) Name() string        { return "catalog.reload" }
+func (c *ReloadCommand) Domain() string      { return "catalog" }
+func (c *ReloadCommand) Description() string { return "Reload engine assets without a restart" }
+
+func (c *ReloadCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"dir": {
+				Name: "dir",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Directory of engine YAML files to overlay on the embedded catalog; empty restores the embedded catalog",
+				},
+			},
+		},
+	}
+}
+
+func (c *ReloadCommand) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"engine_types": {Name: "engine_types", Schema: unit.Schema{Type: "array", Items: &unit.Schema{Type: "string"}}},
+			"count":        {Name: "count", Schema: unit.Schema{Type: "number"}},
+		},
+	}
+}
+
+func (c *ReloadCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input:       map[string]any{"dir": "/etc/aima/engines"},
+			Output:      map[string]any{"engine_types": []string{"asr", "llamacpp", "tts", "vllm"}, "count": 4},
+			Description: "Pick up a llamacpp engine YAML added by the operator",
+		},
+	}
+}
+
+func (c *ReloadCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.reloader == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	var fsys fs.FS
+	if inputMap, ok := input.(map[string]any); ok {
+		if dir, _ := inputMap["dir"].(string); dir != "" {
+			info, err := os.Stat(dir)
+			if err != nil || !info.IsDir() {
+				err := fmt.Errorf("dir %s is not a directory: %w", dir, ErrInvalidInput)
+				ec.PublishFailed(err)
+				return nil, err
+			}
+			fsys = os.DirFS(dir)
+		}
+	}
+
+	if err := c.reloader.ReloadAssets(fsys); err != nil {
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	types := c.reloader.AssetTypes()
+	sort.Strings(types)
+	output := map[string]any{"engine_types": types, "count": len(types)}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
 // --- helpers ---
 
 func generateRecipeID() string {
@@ -2,6 +2,8 @@ package catalog
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -210,3 +212,119 @@ func TestApplyRecipeCommand(t *testing.T) {
 		assert.ErrorIs(t, err, ErrProviderNotSet)
 	})
 }
+
+func TestPullCommand(t *testing.T) {
+	assets := loadFixtureModelAssets(t)
+	ctx := context.Background()
+
+	t.Run("metadata", func(t *testing.T) {
+		cmd := NewPullCommand(assets, &fakePullCommand{})
+		assert.Equal(t, "catalog.pull", cmd.Name())
+		assert.Equal(t, "catalog", cmd.Domain())
+		assert.NotEmpty(t, cmd.Examples())
+	})
+
+	t.Run("maps catalog entry to provider pull", func(t *testing.T) {
+		puller := &fakePullCommand{output: map[string]any{"model_id": "model-1", "status": "ready"}}
+		cmd := NewPullCommand(assets, puller)
+
+		result, err := cmd.Execute(ctx, map[string]any{"name": "tiny-llm", "tag": "v2"})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{"source": "modelscope", "repo": "org/tiny-llm", "tag": "v2"}, puller.input)
+		m := result.(map[string]any)
+		assert.Equal(t, "Tiny-LLM", m["name"])
+		assert.Equal(t, "modelscope", m["source"])
+		assert.Equal(t, "org/tiny-llm", m["repo"])
+		assert.Equal(t, "model-1", m["model_id"])
+		assert.Equal(t, "ready", m["status"])
+	})
+
+	t.Run("unknown model", func(t *testing.T) {
+		cmd := NewPullCommand(assets, &fakePullCommand{})
+		_, err := cmd.Execute(ctx, map[string]any{"name": "missing"})
+		assert.ErrorIs(t, err, ErrModelAssetNotFound)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		cmd := NewPullCommand(assets, &fakePullCommand{})
+		_, err := cmd.Execute(ctx, map[string]any{})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("pull failure", func(t *testing.T) {
+		cmd := NewPullCommand(assets, &fakePullCommand{err: errors.New("network down")})
+		_, err := cmd.Execute(ctx, map[string]any{"name": "SenseVoiceSmall"})
+		assert.ErrorContains(t, err, "network down")
+	})
+
+	t.Run("nil puller", func(t *testing.T) {
+		cmd := NewPullCommand(assets, nil)
+		_, err := cmd.Execute(ctx, map[string]any{"name": "SenseVoiceSmall"})
+		assert.ErrorIs(t, err, ErrProviderNotSet)
+	})
+}
+
+type fakeAssetReloader struct {
+	fsys  fs.FS
+	calls int
+	types []string
+	err   error
+}
+
+func (f *fakeAssetReloader) ReloadAssets(fsys fs.FS) error {
+	f.calls++
+	f.fsys = fsys
+	return f.err
+}
+
+func (f *fakeAssetReloader) AssetTypes() []string { return f.types }
+
+func TestReloadCommand(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("metadata", func(t *testing.T) {
+		cmd := NewReloadCommand(nil)
+		assert.Equal(t, "catalog.reload", cmd.Name())
+		assert.Equal(t, "catalog", cmd.Domain())
+		assert.NotEmpty(t, cmd.Description())
+		assert.NotEmpty(t, cmd.Examples())
+	})
+
+	t.Run("reloads from dir", func(t *testing.T) {
+		reloader := &fakeAssetReloader{types: []string{"vllm", "llamacpp"}}
+		result, err := NewReloadCommand(reloader).Execute(ctx, map[string]any{"dir": t.TempDir()})
+		require.NoError(t, err)
+		assert.NotNil(t, reloader.fsys)
+
+		out := result.(map[string]any)
+		assert.Equal(t, []string{"llamacpp", "vllm"}, out["engine_types"])
+		assert.Equal(t, 2, out["count"])
+	})
+
+	t.Run("without dir restores the embedded catalog", func(t *testing.T) {
+		reloader := &fakeAssetReloader{}
+		_, err := NewReloadCommand(reloader).Execute(ctx, map[string]any{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, reloader.calls)
+		assert.Nil(t, reloader.fsys)
+	})
+
+	t.Run("missing dir", func(t *testing.T) {
+		reloader := &fakeAssetReloader{}
+		_, err := NewReloadCommand(reloader).Execute(ctx, map[string]any{"dir": "/nonexistent/engines"})
+		assert.True(t, errors.Is(err, ErrInvalidInput))
+		assert.Zero(t, reloader.calls)
+	})
+
+	t.Run("reload error", func(t *testing.T) {
+		reloader := &fakeAssetReloader{err: errors.New("engine asset bad.yaml: invalid engine asset")}
+		_, err := NewReloadCommand(reloader).Execute(ctx, map[string]any{})
+		assert.ErrorContains(t, err, "bad.yaml")
+	})
+
+	t.Run("no reloader", func(t *testing.T) {
+		_, err := NewReloadCommand(nil).Execute(ctx, map[string]any{})
+		assert.True(t, errors.Is(err, ErrProviderNotSet))
+	})
+}
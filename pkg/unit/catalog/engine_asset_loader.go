@@ -2,6 +2,8 @@ package catalog
 
 import (
 	"bufio"
+	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -18,6 +20,7 @@ type EngineAsset struct {
 	ImageFullName      string   // e.g. "zhiwen-vllm:0128"
 	AlternativeNames   []string // fallback images
 	BaseCommand        []string // startup.command (e.g. ["vllm", "serve", "/models"])
+	NativeBinary       string   // startup.native_binary, runs BaseCommand outside Docker
 	DefaultArgs        []string // startup.default_args
 	HealthCheckPath    string   // startup.health_check.path
 	HealthCheckTimeout string   // startup.health_check.timeout
@@ -25,6 +28,16 @@ type EngineAsset struct {
 	GPURequired        bool     // requirements.gpu.required
 	MemoryMin          string   // requirements.cpu.memory_min
 	CPUCoresMin        int      // requirements.cpu.cores_min
+	Resources          ResourceDefaults
+}
+
+// ResourceDefaults are the container resource limits an engine asset
+// declares under resources. Zero values mean the asset does not set them.
+type ResourceDefaults struct {
+	Memory    string  `yaml:"memory"`     // e.g. "4g"
+	CPU       float64 `yaml:"cpu"`        // cores, e.g. 2.0
+	GPU       *bool   `yaml:"gpu"`        // nil when not set
+	GPUMemory string  `yaml:"gpu_memory"` // e.g. "80g"
 }
 
 // engineAssetYAML mirrors the YAML structure for unmarshalling.
@@ -44,10 +57,12 @@ type engineAssetYAML struct {
 			MemoryMin string `yaml:"memory_min"`
 		} `yaml:"cpu"`
 	} `yaml:"requirements"`
-	Startup struct {
-		Command     []string `yaml:"command"`
-		DefaultArgs []string `yaml:"default_args"`
-		HealthCheck struct {
+	Resources ResourceDefaults `yaml:"resources"`
+	Startup   struct {
+		Command      []string `yaml:"command"`
+		NativeBinary string   `yaml:"native_binary"`
+		DefaultArgs  []string `yaml:"default_args"`
+		HealthCheck  struct {
 			Path    string `yaml:"path"`
 			Timeout string `yaml:"timeout"`
 		} `yaml:"health_check"`
@@ -98,6 +113,7 @@ func parseEngineAssetBytes(raw []byte) (EngineAsset, error) {
 		ImageFullName:      y.Image.FullName,
 		AlternativeNames:   y.Image.AlternativeNames,
 		BaseCommand:        y.Startup.Command,
+		NativeBinary:       y.Startup.NativeBinary,
 		DefaultArgs:        y.Startup.DefaultArgs,
 		HealthCheckPath:    y.Startup.HealthCheck.Path,
 		HealthCheckTimeout: y.Startup.HealthCheck.Timeout,
@@ -105,6 +121,7 @@ func parseEngineAssetBytes(raw []byte) (EngineAsset, error) {
 		GPURequired:        y.Requirements.GPU.Required,
 		MemoryMin:          y.Requirements.CPU.MemoryMin,
 		CPUCoresMin:        y.Requirements.CPU.CoresMin,
+		Resources:          y.Resources,
 	}, nil
 }
 
@@ -119,40 +136,27 @@ func LoadEngineAsset(path string) (EngineAsset, error) {
 
 // LoadEngineAssets reads all *.yaml files under dir (recursively), parses each
 // into an EngineAsset, and returns a map keyed by engine type (e.g. "vllm").
-// If multiple files share the same type, the last one parsed wins.
+// If multiple files share the same type, the last one parsed wins. Files that
+// cannot be parsed or fail Validate are left out of the map and reported
+// together in the returned error, alongside the assets that did load.
 func LoadEngineAssets(dir string) (map[string]EngineAsset, error) {
-	assets := make(map[string]EngineAsset)
-
-	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() || !strings.HasSuffix(d.Name(), ".yaml") {
-			return nil
-		}
-
-		asset, parseErr := LoadEngineAsset(path)
-		if parseErr != nil {
-			// Skip files that cannot be parsed rather than aborting.
-			return nil
-		}
-
-		if asset.Type != "" {
-			assets[asset.Type] = asset
-		}
-		return nil
-	})
-	if err != nil {
+	if _, err := os.Stat(dir); err != nil {
 		return nil, err
 	}
-
-	return assets, nil
+	return loadEngineAssets(os.DirFS(dir), ".", dir)
 }
 
 // LoadEngineAssetsFromFS is like LoadEngineAssets but reads from an fs.FS
 // (e.g. an embed.FS). dir is the root directory within the FS to walk.
 func LoadEngineAssetsFromFS(fsys fs.FS, dir string) (map[string]EngineAsset, error) {
+	return loadEngineAssets(fsys, dir, "")
+}
+
+// loadEngineAssets walks dir in fsys; prefix is joined to file paths in
+// errors so they name the file on disk.
+func loadEngineAssets(fsys fs.FS, dir, prefix string) (map[string]EngineAsset, error) {
 	assets := make(map[string]EngineAsset)
+	var problems []error
 
 	err := fs.WalkDir(fsys, dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -162,26 +166,64 @@ func LoadEngineAssetsFromFS(fsys fs.FS, dir string) (map[string]EngineAsset, err
 			return nil
 		}
 
-		raw, readErr := fs.ReadFile(fsys, path)
-		if readErr != nil {
+		asset, loadErr := loadEngineAssetFile(fsys, path)
+		if loadErr != nil {
+			problems = append(problems, fmt.Errorf("engine asset %s: %w", filepath.Join(prefix, path), loadErr))
 			return nil
 		}
-
-		asset, parseErr := parseEngineAssetBytes(raw)
-		if parseErr != nil {
-			return nil
-		}
-
-		if asset.Type != "" {
-			assets[asset.Type] = asset
-		}
+		assets[asset.Type] = asset
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return assets, nil
+	return assets, errors.Join(problems...)
+}
+
+func loadEngineAssetFile(fsys fs.FS, path string) (EngineAsset, error) {
+	raw, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return EngineAsset{}, err
+	}
+	asset, err := parseEngineAssetBytes(raw)
+	if err != nil {
+		return EngineAsset{}, err
+	}
+	return asset, asset.Validate()
+}
+
+// Validate checks that the asset has what starting an engine needs: a type,
+// an image, a startup command and, wherever --port is passed, a valid port.
+func (a EngineAsset) Validate() error {
+	var problems []string
+	if a.Type == "" {
+		problems = append(problems, "type is required")
+	}
+	if a.ImageFullName == "" {
+		problems = append(problems, "image.full_name is required")
+	}
+	if len(a.BaseCommand) == 0 {
+		problems = append(problems, "startup.command is required")
+	}
+	for _, args := range [][]string{a.BaseCommand, a.DefaultArgs} {
+		for i, arg := range args {
+			if arg != "--port" {
+				continue
+			}
+			if i+1 == len(args) {
+				problems = append(problems, "--port has no value")
+				continue
+			}
+			if port, err := strconv.Atoi(args[i+1]); err != nil || port <= 0 || port > 65535 {
+				problems = append(problems, fmt.Sprintf("--port %q is not a valid port", args[i+1]))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid engine asset: %s", strings.Join(problems, "; "))
+	}
+	return nil
 }
 
 // ToRecipeEngine converts an EngineAsset to the catalog.RecipeEngine type.
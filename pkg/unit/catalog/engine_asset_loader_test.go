@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -72,6 +73,42 @@ func TestLoadEngineAsset_tts(t *testing.T) {
 	assert.Equal(t, 8002, asset.DefaultPort)
 }
 
+func TestParseEngineAsset_resources(t *testing.T) {
+	asset, err := parseEngineAssetBytes([]byte(`
+## 默认资源限制
+name: llamacpp-cpu
+type: llamacpp
+resources:
+  memory: "6g"
+  cpu: 3.5
+  gpu: false
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "6g", asset.Resources.Memory)
+	assert.Equal(t, 3.5, asset.Resources.CPU)
+	require.NotNil(t, asset.Resources.GPU)
+	assert.False(t, *asset.Resources.GPU)
+	assert.Empty(t, asset.Resources.GPUMemory)
+
+	asset, err = parseEngineAssetBytes([]byte("name: bare\ntype: tts\n"))
+	require.NoError(t, err)
+	assert.Nil(t, asset.Resources.GPU, "an asset without resources leaves GPU unset")
+}
+
+func TestParseEngineAsset_nativeBinary(t *testing.T) {
+	asset, err := parseEngineAssetBytes([]byte(`
+name: whisper-native
+type: whisper
+startup:
+  command: ["uvicorn", "main:app"]
+  native_binary: /opt/whisper/bin/uvicorn
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/whisper/bin/uvicorn", asset.NativeBinary)
+	assert.Equal(t, []string{"uvicorn", "main:app"}, asset.BaseCommand)
+}
+
 func TestLoadEngineAssets_allEngines(t *testing.T) {
 	dir := filepath.Join(projectRoot(), "catalog", "engines")
 	assets, err := LoadEngineAssets(dir)
@@ -96,6 +133,57 @@ func TestLoadEngineAssets_missingDir(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestLoadEngineAssets_malformed(t *testing.T) {
+	assets, err := LoadEngineAssets(filepath.Join("testdata", "engines-malformed"))
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), filepath.Join("testdata", "engines-malformed", "bad-port.yaml"))
+	assert.Contains(t, err.Error(), `--port "eighty" is not a valid port`)
+	assert.Contains(t, err.Error(), filepath.Join("testdata", "engines-malformed", "no-image.yaml"))
+	assert.Contains(t, err.Error(), "image.full_name is required")
+	assert.Contains(t, err.Error(), "startup.command is required")
+	assert.NotContains(t, err.Error(), "llamacpp-ok.yaml")
+
+	require.Len(t, assets, 1, "valid assets are still returned")
+	assert.Equal(t, 8080, assets["llamacpp"].DefaultPort)
+}
+
+func TestLoadEngineAssetsFromFS_malformed(t *testing.T) {
+	assets, err := LoadEngineAssetsFromFS(os.DirFS("testdata"), "engines-malformed")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "engines-malformed/bad-port.yaml")
+	assert.Contains(t, err.Error(), "engines-malformed/no-image.yaml")
+	assert.Contains(t, assets, "llamacpp")
+	assert.NotContains(t, assets, "ollama")
+	assert.NotContains(t, assets, "sglang")
+}
+
+func TestEngineAsset_Validate(t *testing.T) {
+	valid := EngineAsset{Type: "vllm", ImageFullName: "vllm/vllm-openai:latest", BaseCommand: []string{"vllm", "serve"}}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name  string
+		edit  func(*EngineAsset)
+		wants string
+	}{
+		{"missing type", func(a *EngineAsset) { a.Type = "" }, "type is required"},
+		{"zero port", func(a *EngineAsset) { a.DefaultArgs = []string{"--port", "0"} }, `--port "0"`},
+		{"port out of range", func(a *EngineAsset) { a.BaseCommand = append(a.BaseCommand, "--port", "70000") }, `--port "70000"`},
+		{"dangling port flag", func(a *EngineAsset) { a.DefaultArgs = []string{"--port"} }, "--port has no value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := valid
+			a.BaseCommand = append([]string(nil), valid.BaseCommand...)
+			tt.edit(&a)
+			err := a.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wants)
+		})
+	}
+}
+
 func TestEngineAsset_ToRecipeEngine(t *testing.T) {
 	asset := EngineAsset{
 		Type:             "vllm",
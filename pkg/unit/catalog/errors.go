@@ -8,6 +8,7 @@ var (
 	ErrRecipeAlreadyExists = unit.NewDomainError("catalog", unit.ErrCodeRecipeAlreadyExists, "recipe already exists")
 	ErrRecipeInvalid       = unit.NewDomainError("catalog", unit.ErrCodeRecipeInvalid, "recipe is invalid")
 	ErrRecipeApplyFailed   = unit.NewDomainError("catalog", unit.ErrCodeRecipeApplyFailed, "recipe apply failed")
+	ErrModelAssetNotFound  = unit.NewDomainError("catalog", unit.ErrCodeNotFound, "catalog model not found")
 
 	ErrInvalidInput   = unit.NewError(unit.ErrCodeInvalidInput, "invalid input")
 	ErrProviderNotSet = unit.NewError(unit.ErrCodeInternalError, "provider not set")
@@ -1,5 +1,14 @@
 package catalog
 
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/stretchr/testify/require"
+)
+
 // createTestRecipe builds a minimal Recipe for use in tests.
 func createTestRecipe(id, name, gpuVendor string) *Recipe {
 	return &Recipe{
@@ -24,3 +33,66 @@ func createTestRecipe(id, name, gpuVendor string) *Recipe {
 		Tags:     []string{"test"},
 	}
 }
+
+// fixtureModelFS is a small model catalog laid out like catalog/models/.
+var fixtureModelFS = fstest.MapFS{
+	"models/asr/sensevoice.yaml": &fstest.MapFile{Data: []byte(`# SenseVoice
+name: SenseVoiceSmall
+vendor: Alibaba
+type: asr
+spec:
+  parameters: "230M"
+  format: safetensors
+  size_gb: 1.8
+engines:
+  - name: funasr
+    recommended: "funasr-sensevoice-cpu"
+  - name: whisper
+source:
+  type: huggingface
+  repo: "iic/SenseVoiceSmall"
+tags: [asr, chinese]
+`)},
+	"models/llm/tiny.yaml": &fstest.MapFile{Data: []byte(`name: Tiny-LLM
+type: llm
+spec:
+  size_gb: 0.5
+engines:
+  - name: vllm
+    recommended: "vllm-0.14.0-cu131-gb10"
+source:
+  type: modelscope
+  repo: "org/tiny-llm"
+`)},
+	"models/llm/broken.yaml": &fstest.MapFile{Data: []byte("name: [unterminated\n")},
+	"models/README.md":       &fstest.MapFile{Data: []byte("not yaml")},
+}
+
+// loadFixtureModelAssets parses fixtureModelFS for tests.
+func loadFixtureModelAssets(t *testing.T) []ModelAsset {
+	t.Helper()
+	assets, err := LoadModelAssetsFromFS(fixtureModelFS, "models")
+	require.NoError(t, err)
+	return assets
+}
+
+// fakePullCommand records the input it receives and returns a canned result.
+type fakePullCommand struct {
+	input  map[string]any
+	output map[string]any
+	err    error
+}
+
+func (f *fakePullCommand) Name() string              { return "model.pull" }
+func (f *fakePullCommand) Domain() string            { return "model" }
+func (f *fakePullCommand) Description() string       { return "fake pull" }
+func (f *fakePullCommand) InputSchema() unit.Schema  { return unit.Schema{} }
+func (f *fakePullCommand) OutputSchema() unit.Schema { return unit.Schema{} }
+func (f *fakePullCommand) Examples() []unit.Example  { return nil }
+func (f *fakePullCommand) Execute(_ context.Context, input any) (any, error) {
+	f.input, _ = input.(map[string]any)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.output, nil
+}
@@ -0,0 +1,139 @@
+package catalog
+
+import (
+	"io/fs"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ModelAsset represents a parsed model asset YAML file from the curated
+// model catalog.
+type ModelAsset struct {
+	Name              string   // e.g. "GLM-4.7-Flash"
+	Vendor            string   // e.g. "Zhipu AI (智谱)"
+	Type              string   // e.g. "llm", "asr", "tts"
+	Parameters        string   // spec.parameters, e.g. "62B"
+	Format            string   // spec.format, e.g. "safetensors"
+	SizeGB            float64  // spec.size_gb
+	Engine            string   // first entry of engines, e.g. "vllm"
+	EngineAsset       string   // engines[0].recommended, e.g. "vllm-0.14.0-cu131-gb10"
+	SourceType        string   // source.type, e.g. "huggingface"
+	SourceRepo        string   // source.repo, e.g. "iic/SenseVoiceSmall"
+	Tags              []string // tags
+	CompatibleEngines []string // names of all listed engines
+}
+
+// modelAssetYAML mirrors the YAML structure for unmarshalling.
+type modelAssetYAML struct {
+	Name   string `yaml:"name"`
+	Vendor string `yaml:"vendor"`
+	Type   string `yaml:"type"`
+	Spec   struct {
+		Parameters string  `yaml:"parameters"`
+		Format     string  `yaml:"format"`
+		SizeGB     float64 `yaml:"size_gb"`
+	} `yaml:"spec"`
+	Engines []struct {
+		Name        string `yaml:"name"`
+		Recommended string `yaml:"recommended"`
+	} `yaml:"engines"`
+	Source struct {
+		Type string `yaml:"type"`
+		Repo string `yaml:"repo"`
+	} `yaml:"source"`
+	Tags []string `yaml:"tags"`
+}
+
+// parseModelAssetBytes parses raw YAML bytes into a ModelAsset.
+func parseModelAssetBytes(raw []byte) (ModelAsset, error) {
+	cleaned := stripMarkdownHeaders(raw)
+
+	var y modelAssetYAML
+	if err := yaml.Unmarshal(cleaned, &y); err != nil {
+		return ModelAsset{}, err
+	}
+
+	asset := ModelAsset{
+		Name:       y.Name,
+		Vendor:     y.Vendor,
+		Type:       y.Type,
+		Parameters: y.Spec.Parameters,
+		Format:     y.Spec.Format,
+		SizeGB:     y.Spec.SizeGB,
+		SourceType: y.Source.Type,
+		SourceRepo: y.Source.Repo,
+		Tags:       y.Tags,
+	}
+	for _, e := range y.Engines {
+		asset.CompatibleEngines = append(asset.CompatibleEngines, e.Name)
+	}
+	if len(y.Engines) > 0 {
+		asset.Engine = y.Engines[0].Name
+		asset.EngineAsset = y.Engines[0].Recommended
+	}
+	return asset, nil
+}
+
+// LoadModelAssetsFromFS reads all *.yaml files under dir in fsys (e.g. an
+// embed.FS) and returns the parsed model assets sorted by name. Files that
+// cannot be parsed or have no name are skipped.
+func LoadModelAssetsFromFS(fsys fs.FS, dir string) ([]ModelAsset, error) {
+	var assets []ModelAsset
+
+	err := fs.WalkDir(fsys, dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".yaml") {
+			return nil
+		}
+
+		raw, readErr := fs.ReadFile(fsys, path)
+		if readErr != nil {
+			return nil
+		}
+
+		asset, parseErr := parseModelAssetBytes(raw)
+		if parseErr != nil || asset.Name == "" {
+			return nil
+		}
+
+		assets = append(assets, asset)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Name < assets[j].Name })
+	return assets, nil
+}
+
+// FindModelAsset returns the asset whose name matches name, ignoring case.
+func FindModelAsset(assets []ModelAsset, name string) (ModelAsset, bool) {
+	for _, a := range assets {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+	return ModelAsset{}, false
+}
+
+func modelAssetToMap(a ModelAsset) map[string]any {
+	return map[string]any{
+		"name":               a.Name,
+		"vendor":             a.Vendor,
+		"type":               a.Type,
+		"parameters":         a.Parameters,
+		"format":             a.Format,
+		"size_gb":            a.SizeGB,
+		"engine":             a.Engine,
+		"engine_asset":       a.EngineAsset,
+		"compatible_engines": a.CompatibleEngines,
+		"source":             a.SourceType,
+		"repo":               a.SourceRepo,
+		"tags":               a.Tags,
+	}
+}
@@ -0,0 +1,52 @@
+package catalog
+
+import (
+	"testing"
+
+	catalogdata "github.com/jguan/ai-inference-managed-by-ai/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadModelAssetsFromFS_fixture(t *testing.T) {
+	assets := loadFixtureModelAssets(t)
+
+	// broken.yaml and README.md are skipped; results are sorted by name.
+	require.Len(t, assets, 2)
+	assert.Equal(t, "SenseVoiceSmall", assets[0].Name)
+	assert.Equal(t, "Tiny-LLM", assets[1].Name)
+
+	sv := assets[0]
+	assert.Equal(t, "asr", sv.Type)
+	assert.Equal(t, "230M", sv.Parameters)
+	assert.Equal(t, 1.8, sv.SizeGB)
+	assert.Equal(t, "funasr", sv.Engine)
+	assert.Equal(t, "funasr-sensevoice-cpu", sv.EngineAsset)
+	assert.Equal(t, []string{"funasr", "whisper"}, sv.CompatibleEngines)
+	assert.Equal(t, "huggingface", sv.SourceType)
+	assert.Equal(t, "iic/SenseVoiceSmall", sv.SourceRepo)
+	assert.Equal(t, []string{"asr", "chinese"}, sv.Tags)
+}
+
+func TestLoadModelAssetsFromFS_embedded(t *testing.T) {
+	assets, err := LoadModelAssetsFromFS(catalogdata.ModelFS, "models")
+	require.NoError(t, err)
+	require.NotEmpty(t, assets)
+
+	for _, a := range assets {
+		assert.NotEmpty(t, a.Name)
+		assert.NotEmpty(t, a.SourceType, "model %s has no source type", a.Name)
+		assert.NotEmpty(t, a.SourceRepo, "model %s has no source repo", a.Name)
+	}
+}
+
+func TestFindModelAsset(t *testing.T) {
+	assets := loadFixtureModelAssets(t)
+
+	a, ok := FindModelAsset(assets, "sensevoicesmall")
+	require.True(t, ok)
+	assert.Equal(t, "SenseVoiceSmall", a.Name)
+
+	_, ok = FindModelAsset(assets, "missing")
+	assert.False(t, ok)
+}
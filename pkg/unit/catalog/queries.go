@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/ptrs"
@@ -585,6 +586,111 @@ func (q *CheckStatusQuery) Execute(ctx context.Context, input any) (any, error)
 	return output, nil
 }
 
+// ModelsQuery lists the curated model catalog embedded in the binary.
+type ModelsQuery struct {
+	assets []ModelAsset
+	events unit.EventPublisher
+}
+
+func NewModelsQuery(assets []ModelAsset) *ModelsQuery {
+	return &ModelsQuery{assets: assets}
+}
+
+func NewModelsQueryWithEvents(assets []ModelAsset, events unit.EventPublisher) *ModelsQuery {
+	return &ModelsQuery{assets: assets, events: events}
+}
+
+func (q *ModelsQuery) Name() string        { return "catalog.models" }
+func (q *ModelsQuery) Domain() string      { return "catalog" }
+func (q *ModelsQuery) Description() string { return "List curated models available in the offline catalog" }
+
+func (q *ModelsQuery) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"type": {
+				Name: "type",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Filter by model type (llm, asr, tts)",
+				},
+			},
+			"engine": {
+				Name: "engine",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Filter by compatible engine",
+				},
+			},
+		},
+	}
+}
+
+func (q *ModelsQuery) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"models": {
+				Name: "models",
+				Schema: unit.Schema{
+					Type:  "array",
+					Items: &unit.Schema{Type: "object"},
+				},
+			},
+			"total": {
+				Name:   "total",
+				Schema: unit.Schema{Type: "number"},
+			},
+		},
+	}
+}
+
+func (q *ModelsQuery) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input:       map[string]any{"type": "asr"},
+			Output:      map[string]any{"models": []map[string]any{{"name": "SenseVoiceSmall", "type": "asr", "size_gb": 1.8, "engine": "funasr", "source": "huggingface"}}, "total": 1},
+			Description: "List curated ASR models",
+		},
+	}
+}
+
+func (q *ModelsQuery) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(q.events, q.Domain(), q.Name())
+	ec.PublishStarted(input)
+
+	inputMap, _ := input.(map[string]any)
+	modelType, _ := inputMap["type"].(string)
+	engineName, _ := inputMap["engine"].(string)
+
+	items := make([]map[string]any, 0, len(q.assets))
+	for _, a := range q.assets {
+		if modelType != "" && !strings.EqualFold(a.Type, modelType) {
+			continue
+		}
+		if engineName != "" && !containsFold(a.CompatibleEngines, engineName) {
+			continue
+		}
+		items = append(items, modelAssetToMap(a))
+	}
+
+	output := map[string]any{
+		"models": items,
+		"total":  len(items),
+	}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
+
 // recipeToMap converts a Recipe to a map for JSON serialisation.
 func recipeToMap(r Recipe) map[string]any {
 	models := make([]map[string]any, len(r.Models))
@@ -305,3 +305,46 @@ func TestCheckStatusQuery(t *testing.T) {
 		assert.ErrorIs(t, err, ErrProviderNotSet)
 	})
 }
+
+func TestModelsQuery(t *testing.T) {
+	q := NewModelsQuery(loadFixtureModelAssets(t))
+	ctx := context.Background()
+
+	t.Run("metadata", func(t *testing.T) {
+		assert.Equal(t, "catalog.models", q.Name())
+		assert.Equal(t, "catalog", q.Domain())
+		assert.NotEmpty(t, q.Examples())
+	})
+
+	t.Run("list all", func(t *testing.T) {
+		result, err := q.Execute(ctx, map[string]any{})
+		require.NoError(t, err)
+
+		m := result.(map[string]any)
+		assert.Equal(t, 2, m["total"])
+		models := m["models"].([]map[string]any)
+		assert.Equal(t, "SenseVoiceSmall", models[0]["name"])
+		assert.Equal(t, "asr", models[0]["type"])
+		assert.Equal(t, 1.8, models[0]["size_gb"])
+		assert.Equal(t, "funasr", models[0]["engine"])
+		assert.Equal(t, "huggingface", models[0]["source"])
+	})
+
+	t.Run("filter by type", func(t *testing.T) {
+		result, err := q.Execute(ctx, map[string]any{"type": "LLM"})
+		require.NoError(t, err)
+
+		models := result.(map[string]any)["models"].([]map[string]any)
+		require.Len(t, models, 1)
+		assert.Equal(t, "Tiny-LLM", models[0]["name"])
+	})
+
+	t.Run("filter by compatible engine", func(t *testing.T) {
+		result, err := q.Execute(ctx, map[string]any{"engine": "whisper"})
+		require.NoError(t, err)
+
+		models := result.(map[string]any)["models"].([]map[string]any)
+		require.Len(t, models, 1)
+		assert.Equal(t, "SenseVoiceSmall", models[0]["name"])
+	})
+}
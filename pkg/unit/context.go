@@ -14,6 +14,8 @@ const (
 	RequestIDKey contextKey = "request_id"
 	TraceIDKey   contextKey = "trace_id"
 	UserIDKey    contextKey = "user_id"
+	PrincipalKey contextKey = "principal"
+	ProviderKey  contextKey = "provider"
 	StartTimeKey contextKey = "start_time"
 	MetadataKey  contextKey = "metadata"
 )
@@ -30,6 +32,18 @@ func WithUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, UserIDKey, userID)
 }
 
+// WithPrincipal records the authenticated caller of the request, e.g. the
+// API key it was made with.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, PrincipalKey, principal)
+}
+
+// WithProvider records the inference provider the request asked for, e.g.
+// "ollama" or "openai".
+func WithProvider(ctx context.Context, provider string) context.Context {
+	return context.WithValue(ctx, ProviderKey, provider)
+}
+
 func WithStartTime(ctx context.Context, t time.Time) context.Context {
 	return context.WithValue(ctx, StartTimeKey, t)
 }
@@ -65,6 +79,24 @@ func GetUserID(ctx context.Context) string {
 	return ""
 }
 
+func GetPrincipal(ctx context.Context) string {
+	if v := ctx.Value(PrincipalKey); v != nil {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func GetProvider(ctx context.Context) string {
+	if v := ctx.Value(ProviderKey); v != nil {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
 func GetStartTime(ctx context.Context) time.Time {
 	if v := ctx.Value(StartTimeKey); v != nil {
 		if t, ok := v.(time.Time); ok {
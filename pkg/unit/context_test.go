@@ -52,6 +52,35 @@ func TestWithUserID(t *testing.T) {
 	}
 }
 
+func TestWithPrincipal(t *testing.T) {
+	ctx := context.Background()
+	principal := "apikey:0123456789ab"
+
+	newCtx := WithPrincipal(ctx, principal)
+
+	if GetPrincipal(newCtx) != principal {
+		t.Errorf("GetPrincipal() = %q, want %q", GetPrincipal(newCtx), principal)
+	}
+
+	if GetPrincipal(ctx) != "" {
+		t.Errorf("GetPrincipal() on original context should return empty string")
+	}
+}
+
+func TestWithProvider(t *testing.T) {
+	ctx := context.Background()
+
+	newCtx := WithProvider(ctx, "openai")
+
+	if GetProvider(newCtx) != "openai" {
+		t.Errorf("GetProvider() = %q, want %q", GetProvider(newCtx), "openai")
+	}
+
+	if GetProvider(ctx) != "" {
+		t.Error("original context should not have provider")
+	}
+}
+
 func TestWithStartTime(t *testing.T) {
 	ctx := context.Background()
 	startTime := time.Date(2026, 2, 16, 10, 30, 0, 0, time.UTC)
@@ -0,0 +1,39 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Decode converts a command input, normally the map[string]any decoded from
+// a request body, into a typed request struct by round-tripping it through
+// JSON, so the struct's json tags name the fields. Fields missing from the
+// input keep their zero values. A value of the wrong type is reported as
+// ErrInvalidInput naming the offending field.
+func Decode[T any](input any) (T, error) {
+	var out T
+	if v, ok := input.(T); ok {
+		return v, nil
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return out, fmt.Errorf("encode input: %v: %w", err, ErrInvalidInput)
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		if errors.Is(err, ErrInvalidInput) {
+			return out, err
+		}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			field := typeErr.Field
+			if field == "" {
+				field = "input"
+			}
+			return out, fmt.Errorf("%s must be %s, got %s: %w", field, typeErr.Type, typeErr.Value, ErrInvalidInput)
+		}
+		return out, fmt.Errorf("decode input: %v: %w", err, ErrInvalidInput)
+	}
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package unit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type decodeRequest struct {
+	Model   string            `json:"model"`
+	Texts   []string          `json:"texts"`
+	Limit   int               `json:"limit"`
+	Stream  bool              `json:"stream"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+func TestDecode(t *testing.T) {
+	req, err := Decode[decodeRequest](map[string]any{
+		"model":   "bge-m3",
+		"texts":   []any{"a", "b"},
+		"limit":   float64(10),
+		"stream":  true,
+		"options": map[string]any{"pooling": "mean"},
+		"unknown": "ignored",
+	})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if req.Model != "bge-m3" || len(req.Texts) != 2 || req.Texts[1] != "b" || req.Limit != 10 || !req.Stream {
+		t.Errorf("Decode() = %+v", req)
+	}
+	if req.Options["pooling"] != "mean" {
+		t.Errorf("Options = %v, want pooling=mean", req.Options)
+	}
+
+	empty, err := Decode[decodeRequest](map[string]any{})
+	if err != nil || empty.Model != "" || empty.Texts != nil {
+		t.Errorf("Decode(empty) = %+v, %v; want zero value", empty, err)
+	}
+
+	typed := decodeRequest{Model: "llama3"}
+	if got, err := Decode[decodeRequest](typed); err != nil || got.Model != "llama3" {
+		t.Errorf("Decode(typed) = %+v, %v; want the value unchanged", got, err)
+	}
+}
+
+func TestDecode_TypeMismatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		field string
+	}{
+		{"string for int", map[string]any{"limit": "ten"}, "limit"},
+		{"number for string", map[string]any{"model": float64(3)}, "model"},
+		{"bad list element", map[string]any{"texts": []any{"a", true}}, "texts"},
+		{"fractional int", map[string]any{"limit": 1.5}, "limit"},
+		{"not an object", "llama3", "input"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode[decodeRequest](tt.input)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("Decode() error = %v, want ErrInvalidInput", err)
+			}
+			if !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Decode() error = %q, want it to name %q", err, tt.field)
+			}
+		})
+	}
+
+	if _, err := Decode[decodeRequest](map[string]any{"model": make(chan int)}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Decode(unencodable) error = %v, want ErrInvalidInput", err)
+	}
+}
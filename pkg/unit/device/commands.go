@@ -18,6 +18,18 @@ type DeviceProvider interface {
 	SetPowerLimit(ctx context.Context, deviceID string, limitWatts float64) error
 }
 
+// HostInfo describes the host the devices are attached to.
+type HostInfo struct {
+	CPUCores    int    `json:"cpu_cores"`
+	TotalMemory uint64 `json:"total_memory"`
+}
+
+// HostInfoProvider is implemented by device providers that can also report
+// host CPU and RAM. device.info includes host details when it is available.
+type HostInfoProvider interface {
+	GetHostInfo(ctx context.Context) (*HostInfo, error)
+}
+
 type DeviceMetrics struct {
 	Utilization float64 `json:"utilization"`
 	Temperature float64 `json:"temperature"`
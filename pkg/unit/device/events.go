@@ -13,13 +13,16 @@ const (
 )
 
 type DeviceInfo struct {
-	ID           string   `json:"id"`
-	Name         string   `json:"name"`
-	Vendor       string   `json:"vendor"`
-	Type         string   `json:"type"`
-	Architecture string   `json:"architecture,omitempty"`
-	Memory       uint64   `json:"memory,omitempty"`
-	Capabilities []string `json:"capabilities,omitempty"`
+	ID            string   `json:"id"`
+	Index         int      `json:"index"`
+	Name          string   `json:"name"`
+	Vendor        string   `json:"vendor"`
+	Type          string   `json:"type"`
+	Architecture  string   `json:"architecture,omitempty"`
+	Memory        uint64   `json:"memory,omitempty"`
+	Capabilities  []string `json:"capabilities,omitempty"`
+	DriverVersion string   `json:"driver_version,omitempty"`
+	CUDAVersion   string   `json:"cuda_version,omitempty"`
 }
 
 type DetectedEvent struct {
@@ -4,12 +4,15 @@ import "context"
 
 // MockProvider implements DeviceProvider for testing purposes.
 type MockProvider struct {
-	Devices    []DeviceInfo
-	Health     *DeviceHealth
-	Metrics    *DeviceMetrics
-	Err        error
-	PowerLimit float64
-	PowerSetID string
+	Devices []DeviceInfo
+	Health  *DeviceHealth
+	Metrics *DeviceMetrics
+	// DeviceMetrics overrides Metrics for individual device IDs.
+	DeviceMetrics map[string]*DeviceMetrics
+	Host          *HostInfo
+	Err           error
+	PowerLimit    float64
+	PowerSetID    string
 }
 
 func NewMockProvider() *MockProvider {
@@ -36,6 +39,10 @@ func NewMockProvider() *MockProvider {
 			MemoryUsed:  8192000000,
 			MemoryTotal: 24564000000,
 		},
+		Host: &HostInfo{
+			CPUCores:    16,
+			TotalMemory: 64 * 1024 * 1024 * 1024,
+		},
 	}
 }
 
@@ -62,9 +69,22 @@ func (m *MockProvider) GetMetrics(ctx context.Context, deviceID string) (*Device
 	if m.Err != nil {
 		return nil, m.Err
 	}
+	if metrics, ok := m.DeviceMetrics[deviceID]; ok {
+		return metrics, nil
+	}
 	return m.Metrics, nil
 }
 
+func (m *MockProvider) GetHostInfo(ctx context.Context) (*HostInfo, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	if m.Host == nil {
+		return &HostInfo{}, nil
+	}
+	return m.Host, nil
+}
+
 func (m *MockProvider) GetHealth(ctx context.Context, deviceID string) (*DeviceHealth, error) {
 	if m.Err != nil {
 		return nil, m.Err
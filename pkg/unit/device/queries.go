@@ -47,12 +47,37 @@ func (q *InfoQuery) OutputSchema() unit.Schema {
 	return unit.Schema{
 		Type: "object",
 		Properties: map[string]unit.Field{
-			"id":           {Name: "id", Schema: unit.Schema{Type: "string"}},
-			"name":         {Name: "name", Schema: unit.Schema{Type: "string"}},
-			"vendor":       {Name: "vendor", Schema: unit.Schema{Type: "string"}},
-			"architecture": {Name: "architecture", Schema: unit.Schema{Type: "string"}},
-			"capabilities": {Name: "capabilities", Schema: unit.Schema{Type: "array", Items: &unit.Schema{Type: "string"}}},
-			"memory":       {Name: "memory", Schema: unit.Schema{Type: "number"}},
+			"id":             {Name: "id", Schema: unit.Schema{Type: "string"}},
+			"index":          {Name: "index", Schema: unit.Schema{Type: "number"}},
+			"name":           {Name: "name", Schema: unit.Schema{Type: "string"}},
+			"vendor":         {Name: "vendor", Schema: unit.Schema{Type: "string"}},
+			"architecture":   {Name: "architecture", Schema: unit.Schema{Type: "string"}},
+			"capabilities":   {Name: "capabilities", Schema: unit.Schema{Type: "array", Items: &unit.Schema{Type: "string"}}},
+			"memory":         {Name: "memory", Schema: unit.Schema{Type: "number"}},
+			"driver_version": {Name: "driver_version", Schema: unit.Schema{Type: "string"}},
+			"cuda_version":   {Name: "cuda_version", Schema: unit.Schema{Type: "string"}},
+			"devices":        {Name: "devices", Schema: unit.Schema{Type: "array", Items: &unit.Schema{Type: "object"}}},
+			"gpus": {
+				Name: "gpus",
+				Schema: unit.Schema{
+					Type: "array",
+					Items: &unit.Schema{
+						Type: "object",
+						Properties: map[string]unit.Field{
+							"index":          {Name: "index", Schema: unit.Schema{Type: "number"}},
+							"id":             {Name: "id", Schema: unit.Schema{Type: "string"}},
+							"name":           {Name: "name", Schema: unit.Schema{Type: "string"}},
+							"memory_total":   {Name: "memory_total", Schema: unit.Schema{Type: "number"}},
+							"memory_free":    {Name: "memory_free", Schema: unit.Schema{Type: "number"}},
+							"utilization":    {Name: "utilization", Schema: unit.Schema{Type: "number"}},
+							"driver_version": {Name: "driver_version", Schema: unit.Schema{Type: "string"}},
+							"cuda_version":   {Name: "cuda_version", Schema: unit.Schema{Type: "string"}},
+						},
+					},
+				},
+			},
+			"cpu_cores":    {Name: "cpu_cores", Schema: unit.Schema{Type: "number"}},
+			"total_memory": {Name: "total_memory", Schema: unit.Schema{Type: "number"}},
 		},
 	}
 }
@@ -65,9 +90,16 @@ func (q *InfoQuery) Examples() []unit.Example {
 			Description: "Get info for a specific device",
 		},
 		{
-			Input:       map[string]any{},
-			Output:      map[string]any{"devices": []map[string]any{{"id": "gpu-0", "name": "NVIDIA RTX 4090"}}},
-			Description: "Get info for all devices when device_id is not provided",
+			Input: map[string]any{},
+			Output: map[string]any{
+				"devices": []map[string]any{{"id": "gpu-0", "name": "NVIDIA RTX 4090"}},
+				"gpus": []map[string]any{
+					{"index": 0, "id": "gpu-0", "name": "NVIDIA RTX 4090", "memory_total": 25757220864, "memory_free": 17179869184, "utilization": 35.0, "driver_version": "570.86", "cuda_version": "12.8"},
+				},
+				"cpu_cores":    16,
+				"total_memory": 68719476736,
+			},
+			Description: "Get per-GPU and host info when device_id is not provided",
 		},
 	}
 }
@@ -86,12 +118,15 @@ func (q *InfoQuery) Execute(ctx context.Context, input any) (any, error) {
 			return nil, fmt.Errorf("get device %s: %w", deviceID, err)
 		}
 		return map[string]any{
-			"id":           device.ID,
-			"name":         device.Name,
-			"vendor":       device.Vendor,
-			"architecture": device.Architecture,
-			"capabilities": device.Capabilities,
-			"memory":       device.Memory,
+			"id":             device.ID,
+			"index":          device.Index,
+			"name":           device.Name,
+			"vendor":         device.Vendor,
+			"architecture":   device.Architecture,
+			"capabilities":   device.Capabilities,
+			"memory":         device.Memory,
+			"driver_version": device.DriverVersion,
+			"cuda_version":   device.CUDAVersion,
 		}, nil
 	}
 
@@ -101,6 +136,7 @@ func (q *InfoQuery) Execute(ctx context.Context, input any) (any, error) {
 	}
 
 	result := make([]map[string]any, len(devices))
+	gpus := make([]map[string]any, 0, len(devices))
 	for i, d := range devices {
 		result[i] = map[string]any{
 			"id":           d.ID,
@@ -110,9 +146,51 @@ func (q *InfoQuery) Execute(ctx context.Context, input any) (any, error) {
 			"capabilities": d.Capabilities,
 			"memory":       d.Memory,
 		}
+		if d.Type == "gpu" {
+			gpus = append(gpus, q.gpuInfo(ctx, d))
+		}
+	}
+
+	output := map[string]any{"devices": result, "gpus": gpus}
+	if hp, ok := q.provider.(HostInfoProvider); ok {
+		host, err := hp.GetHostInfo(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get host info: %w", err)
+		}
+		output["cpu_cores"] = host.CPUCores
+		output["total_memory"] = host.TotalMemory
+	}
+	return output, nil
+}
+
+// gpuInfo combines a GPU's static info with its live metrics. Metrics are
+// best-effort: a GPU whose metrics cannot be read is still listed.
+func (q *InfoQuery) gpuInfo(ctx context.Context, d DeviceInfo) map[string]any {
+	info := map[string]any{
+		"index":          d.Index,
+		"id":             d.ID,
+		"name":           d.Name,
+		"memory_total":   d.Memory,
+		"memory_free":    uint64(0),
+		"utilization":    0.0,
+		"driver_version": d.DriverVersion,
+		"cuda_version":   d.CUDAVersion,
 	}
 
-	return map[string]any{"devices": result}, nil
+	metrics, err := q.provider.GetMetrics(ctx, d.ID)
+	if err != nil || metrics == nil {
+		return info
+	}
+	total := metrics.MemoryTotal
+	if total == 0 {
+		total = d.Memory
+	}
+	info["memory_total"] = total
+	if total > metrics.MemoryUsed {
+		info["memory_free"] = total - metrics.MemoryUsed
+	}
+	info["utilization"] = metrics.Utilization
+	return info
 }
 
 type MetricsQuery struct {
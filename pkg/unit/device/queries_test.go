@@ -405,3 +405,85 @@ func TestQueryImplementsInterface(t *testing.T) {
 	var _ unit.Query = NewMetricsQuery(nil)
 	var _ unit.Query = NewHealthQuery(nil)
 }
+
+func TestInfoQuery_Execute_MultiGPU(t *testing.T) {
+	provider := NewMockProvider()
+	provider.Devices = []DeviceInfo{
+		{ID: "nvidia-0", Index: 0, Name: "NVIDIA A100", Vendor: "NVIDIA", Type: "gpu", Memory: 80000, DriverVersion: "570.86", CUDAVersion: "12.8"},
+		{ID: "nvidia-1", Index: 1, Name: "NVIDIA A100", Vendor: "NVIDIA", Type: "gpu", Memory: 80000, DriverVersion: "570.86", CUDAVersion: "12.8"},
+		{ID: "cpu-0", Name: "Host CPU", Vendor: "Generic", Type: "cpu"},
+	}
+	provider.DeviceMetrics = map[string]*DeviceMetrics{
+		"nvidia-0": {Utilization: 90, MemoryUsed: 60000, MemoryTotal: 80000},
+		"nvidia-1": {Utilization: 10, MemoryUsed: 5000, MemoryTotal: 80000},
+	}
+	provider.Host = &HostInfo{CPUCores: 64, TotalMemory: 512 * 1024 * 1024 * 1024}
+
+	result, err := NewInfoQuery(provider).Execute(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := result.(map[string]any)
+
+	gpus, ok := output["gpus"].([]map[string]any)
+	if !ok || len(gpus) != 2 {
+		t.Fatalf("expected 2 gpus, got %v", output["gpus"])
+	}
+	want := []struct {
+		index       int
+		free        uint64
+		utilization float64
+	}{
+		{index: 0, free: 20000, utilization: 90},
+		{index: 1, free: 75000, utilization: 10},
+	}
+	for i, w := range want {
+		g := gpus[i]
+		if g["index"] != w.index {
+			t.Errorf("gpu %d: expected index %d, got %v", i, w.index, g["index"])
+		}
+		if g["name"] != "NVIDIA A100" {
+			t.Errorf("gpu %d: unexpected name %v", i, g["name"])
+		}
+		if g["memory_total"] != uint64(80000) {
+			t.Errorf("gpu %d: expected memory_total 80000, got %v", i, g["memory_total"])
+		}
+		if g["memory_free"] != w.free {
+			t.Errorf("gpu %d: expected memory_free %d, got %v", i, w.free, g["memory_free"])
+		}
+		if g["utilization"] != w.utilization {
+			t.Errorf("gpu %d: expected utilization %v, got %v", i, w.utilization, g["utilization"])
+		}
+		if g["driver_version"] != "570.86" || g["cuda_version"] != "12.8" {
+			t.Errorf("gpu %d: unexpected versions %v/%v", i, g["driver_version"], g["cuda_version"])
+		}
+	}
+
+	if output["cpu_cores"] != 64 {
+		t.Errorf("expected cpu_cores 64, got %v", output["cpu_cores"])
+	}
+	if output["total_memory"] != uint64(512*1024*1024*1024) {
+		t.Errorf("expected total_memory 512GiB, got %v", output["total_memory"])
+	}
+	if devices := output["devices"].([]map[string]any); len(devices) != 3 {
+		t.Errorf("expected all 3 devices to be listed, got %d", len(devices))
+	}
+}
+
+func TestInfoQuery_Execute_WithoutHostInfo(t *testing.T) {
+	provider := &mockProvider{
+		devices: []DeviceInfo{{ID: "gpu-0", Name: "RTX 4090", Type: "gpu", Memory: 24564}},
+	}
+
+	result, err := NewInfoQuery(provider).Execute(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := result.(map[string]any)
+	if _, ok := output["cpu_cores"]; ok {
+		t.Error("expected cpu_cores to be omitted when the provider cannot report host info")
+	}
+	if gpus := output["gpus"].([]map[string]any); len(gpus) != 1 {
+		t.Errorf("expected 1 gpu, got %d", len(gpus))
+	}
+}
@@ -0,0 +1,26 @@
+package unit
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Encode converts a typed command output into the map returned from
+// Execute by round-tripping it through JSON, so the keys are the struct's
+// json tags and match the output schema. Nested structs and slices become
+// map[string]any and []any, and numbers become float64, exactly as an HTTP
+// client would see them.
+func Encode(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode output: %w", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("encode output: %T is not an object: %w", v, err)
+	}
+	if out == nil {
+		return nil, fmt.Errorf("encode output: %T is not an object", v)
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package unit
+
+import (
+	"testing"
+)
+
+type encodeItem struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+type encodeOutput struct {
+	Items   []encodeItem `json:"items"`
+	Total   int          `json:"total"`
+	Cursor  string       `json:"cursor,omitempty"`
+	private string
+}
+
+func TestEncode(t *testing.T) {
+	out, err := Encode(encodeOutput{Items: []encodeItem{{Name: "a", Score: 0.5}}, Total: 1, private: "x"})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if len(out) != 2 {
+		t.Errorf("Encode() keys = %v, want items and total only", out)
+	}
+	if out["total"] != float64(1) {
+		t.Errorf("total = %#v, want float64(1)", out["total"])
+	}
+	items, ok := out["items"].([]any)
+	if !ok || len(items) != 1 {
+		t.Fatalf("items = %#v, want one item", out["items"])
+	}
+	item, ok := items[0].(map[string]any)
+	if !ok || item["name"] != "a" || item["score"] != 0.5 {
+		t.Errorf("items[0] = %#v", items[0])
+	}
+
+	schema := ObjectSchema(map[string]Field{
+		"items": NewField("items", ArraySchema(ObjectSchema(map[string]Field{
+			"name":  NewField("name", StringSchema()),
+			"score": NewField("score", NumberSchema()),
+		}, []string{"name", "score"}))),
+		"total":  NewField("total", NumberSchema()),
+		"cursor": NewField("cursor", StringSchema()),
+	}, []string{"items", "total"})
+	if err := schema.Validate(out); err != nil {
+		t.Errorf("encoded output does not match its schema: %v", err)
+	}
+	if unknown := schema.UnknownFields(out); len(unknown) != 0 {
+		t.Errorf("encoded output has fields missing from the schema: %v", unknown)
+	}
+}
+
+func TestEncode_NotAnObject(t *testing.T) {
+	for _, v := range []any{nil, []int{1}, "text", 3, make(chan int)} {
+		if _, err := Encode(v); err == nil {
+			t.Errorf("Encode(%#v) expected an error", v)
+		}
+	}
+	if out, err := Encode(map[string]int{"n": 1}); err != nil || out["n"] != float64(1) {
+		t.Errorf("Encode(map) = %v, %v", out, err)
+	}
+}
@@ -168,6 +168,19 @@ func NewStartProgressEvent(serviceID, phase, message string, progress int) *Star
 	}
 }
 
+// NewPullProgressEvent reports image layer download progress as a "pulling"
+// start-progress event, carrying the layer's byte counts alongside the
+// human-readable message.
+func NewPullProgressEvent(serviceID, image, layerID, message string, current, total int64, progress int) *StartProgressEvent {
+	e := NewStartProgressEvent(serviceID, "pulling", message, progress)
+	payload := e.payload.(map[string]any)
+	payload["image"] = image
+	payload["layer_id"] = layerID
+	payload["current"] = current
+	payload["total"] = total
+	return e
+}
+
 func (e *StartProgressEvent) Type() string          { return e.eventType }
 func (e *StartProgressEvent) Domain() string        { return e.domain }
 func (e *StartProgressEvent) Payload() any          { return e.payload }
@@ -32,6 +32,7 @@ const (
 	ErrCodeModelVerifyFailed  ErrorCode = "00103"
 	ErrCodeModelImportFailed  ErrorCode = "00104"
 	ErrCodeModelDeleteFailed  ErrorCode = "00105"
+	ErrCodeModelInUse         ErrorCode = "00106"
 )
 
 // 引擎领域错误码 (200-299)
@@ -243,7 +244,7 @@ func ErrorToHTTPStatus(code ErrorCode) int {
 		ErrCodeDeviceNotFound, ErrCodeResourceSlotNotFound,
 		ErrCodeRecipeNotFound, ErrCodeSkillNotFound, ErrCodeConversationNotFound:
 		return http.StatusNotFound
-	case ErrCodeModelAlreadyExists, ErrCodeEngineAlreadyRunning,
+	case ErrCodeModelAlreadyExists, ErrCodeModelInUse, ErrCodeEngineAlreadyRunning,
 		ErrCodeRecipeAlreadyExists, ErrCodeSkillAlreadyExists:
 		return http.StatusConflict
 	case ErrCodeRecipeInvalid, ErrCodeSkillInvalid, ErrCodeBuiltinSkillImmutable:
@@ -20,6 +20,7 @@ const (
 
 // ExecutionEvent represents an event during command/query execution
 type ExecutionEvent struct {
+	EventID           string    `json:"id"`
 	EventType         string    `json:"event_type"`
 	EventDomain       string    `json:"domain"`
 	UnitName          string    `json:"unit_name"`
@@ -31,6 +32,9 @@ type ExecutionEvent struct {
 	DurationMs        int64     `json:"duration_ms,omitempty"`
 }
 
+// ID returns the unique event ID
+func (e *ExecutionEvent) ID() string { return e.EventID }
+
 // Type returns the event type
 func (e *ExecutionEvent) Type() string { return e.EventType }
 
@@ -78,6 +82,7 @@ func (ec *ExecutionContext) PublishStarted(input any) {
 	}
 
 	event := &ExecutionEvent{
+		EventID:            uuid.New().String(),
 		EventType:          string(ExecutionStarted),
 		EventDomain:        ec.Domain,
 		UnitName:           ec.UnitName,
@@ -98,6 +103,7 @@ func (ec *ExecutionContext) PublishCompleted(output any) {
 	duration := time.Since(ec.StartTime).Milliseconds()
 
 	event := &ExecutionEvent{
+		EventID:            uuid.New().String(),
 		EventType:          string(ExecutionCompleted),
 		EventDomain:        ec.Domain,
 		UnitName:           ec.UnitName,
@@ -123,6 +129,7 @@ func (ec *ExecutionContext) PublishFailed(err error) {
 	}
 
 	event := &ExecutionEvent{
+		EventID:            uuid.New().String(),
 		EventType:          string(ExecutionFailed),
 		EventDomain:        ec.Domain,
 		UnitName:           ec.UnitName,
@@ -0,0 +1,344 @@
+package inference
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/ptrs"
+)
+
+const (
+	defaultBenchmarkRuns      = 5
+	defaultBenchmarkWarmup    = 1
+	defaultBenchmarkMaxTokens = 64
+	maxBenchmarkRuns          = 100
+	defaultBenchmarkPrompt    = "Write a short paragraph about the ocean."
+)
+
+// BenchmarkCommand measures the latency and throughput of a model by running
+// a number of short chat generations one after another. Warmup runs are made
+// first and left out of the results so model loading and cache warmup do not
+// skew them.
+type BenchmarkCommand struct {
+	provider InferenceProvider
+	events   unit.EventPublisher
+	now      func() time.Time
+}
+
+func NewBenchmarkCommand(provider InferenceProvider) *BenchmarkCommand {
+	return &BenchmarkCommand{provider: provider, now: time.Now}
+}
+
+func NewBenchmarkCommandWithEvents(provider InferenceProvider, events unit.EventPublisher) *BenchmarkCommand {
+	return &BenchmarkCommand{provider: provider, events: events, now: time.Now}
+}
+
+func (c *BenchmarkCommand) Name() string {
+	return "inference.benchmark"
+}
+
+func (c *BenchmarkCommand) Domain() string {
+	return "inference"
+}
+
+func (c *BenchmarkCommand) Description() string {
+	return "Benchmark a model with short generations, reporting latency percentiles, tokens/sec and time to first token"
+}
+
+func (c *BenchmarkCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"model": {
+				Name: "model",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Model identifier",
+				},
+			},
+			"prompt": {
+				Name: "prompt",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "User message sent on every run",
+					Default:     defaultBenchmarkPrompt,
+				},
+			},
+			"runs": {
+				Name: "runs",
+				Schema: unit.Schema{
+					Type:        "number",
+					Description: "Number of measured generations",
+					Min:         ptrs.Float64(1),
+					Max:         ptrs.Float64(maxBenchmarkRuns),
+					Default:     defaultBenchmarkRuns,
+				},
+			},
+			"warmup": {
+				Name: "warmup",
+				Schema: unit.Schema{
+					Type:        "number",
+					Description: "Number of unmeasured generations run first",
+					Min:         ptrs.Float64(0),
+					Max:         ptrs.Float64(maxBenchmarkRuns),
+					Default:     defaultBenchmarkWarmup,
+				},
+			},
+			"max_tokens": {
+				Name: "max_tokens",
+				Schema: unit.Schema{
+					Type:        "number",
+					Description: "Maximum tokens to generate per run",
+					Min:         ptrs.Float64(1),
+					Default:     defaultBenchmarkMaxTokens,
+				},
+			},
+			"stream": {
+				Name: "stream",
+				Schema: unit.Schema{
+					Type:        "boolean",
+					Description: "Stream the generations to also measure time to first token",
+					Default:     false,
+				},
+			},
+		},
+		Required: []string{"model"},
+	}
+}
+
+func (c *BenchmarkCommand) OutputSchema() unit.Schema {
+	percentiles := unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"p50":  {Name: "p50", Schema: unit.Schema{Type: "number"}},
+			"p95":  {Name: "p95", Schema: unit.Schema{Type: "number"}},
+			"mean": {Name: "mean", Schema: unit.Schema{Type: "number"}},
+		},
+	}
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"model":             {Name: "model", Schema: unit.Schema{Type: "string"}},
+			"runs":              {Name: "runs", Schema: unit.Schema{Type: "number"}},
+			"warmup":            {Name: "warmup", Schema: unit.Schema{Type: "number"}},
+			"stream":            {Name: "stream", Schema: unit.Schema{Type: "boolean"}},
+			"completion_tokens": {Name: "completion_tokens", Schema: unit.Schema{Type: "number", Description: "Tokens generated across the measured runs"}},
+			"tokens_per_sec":    {Name: "tokens_per_sec", Schema: unit.Schema{Type: "number", Description: "Generated tokens per second of generation time"}},
+			"latency_ms":        {Name: "latency_ms", Schema: percentiles},
+			"ttft_ms":           {Name: "ttft_ms", Schema: percentiles},
+		},
+	}
+}
+
+func (c *BenchmarkCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input: map[string]any{"model": "qwen2.5-7b", "runs": 10, "stream": true},
+			Output: map[string]any{
+				"model":             "qwen2.5-7b",
+				"runs":              10,
+				"warmup":            1,
+				"stream":            true,
+				"completion_tokens": 640,
+				"tokens_per_sec":    42.7,
+				"latency_ms":        map[string]any{"p50": 1490.2, "p95": 1620.8, "mean": 1499.1},
+				"ttft_ms":           map[string]any{"p50": 85.3, "p95": 120.4, "mean": 90.2},
+			},
+			Description: "Measure streaming latency and throughput over ten runs",
+		},
+	}
+}
+
+// benchmarkRun is the timing of one generation.
+type benchmarkRun struct {
+	latency time.Duration
+	ttft    time.Duration
+	tokens  int
+}
+
+func (c *BenchmarkCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.provider == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	model, _ := inputMap["model"].(string)
+	if model == "" {
+		err := fmt.Errorf("model is required: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	prompt := defaultBenchmarkPrompt
+	if p, _ := inputMap["prompt"].(string); p != "" {
+		prompt = p
+	}
+
+	runs := defaultBenchmarkRuns
+	if v, ok := toInt(inputMap["runs"]); ok {
+		runs = v
+	}
+	warmup := defaultBenchmarkWarmup
+	if v, ok := toInt(inputMap["warmup"]); ok {
+		warmup = v
+	}
+	maxTokens := defaultBenchmarkMaxTokens
+	if v, ok := toInt(inputMap["max_tokens"]); ok {
+		maxTokens = v
+	}
+	var err error
+	switch {
+	case runs < 1 || runs > maxBenchmarkRuns:
+		err = fmt.Errorf("runs must be between 1 and %d, got %d: %w", maxBenchmarkRuns, runs, ErrInvalidInput)
+	case warmup < 0 || warmup > maxBenchmarkRuns:
+		err = fmt.Errorf("warmup must be between 0 and %d, got %d: %w", maxBenchmarkRuns, warmup, ErrInvalidInput)
+	case maxTokens < 1:
+		err = fmt.Errorf("max_tokens must be at least 1, got %d: %w", maxTokens, ErrInvalidInput)
+	}
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, err
+	}
+	stream, _ := inputMap["stream"].(bool)
+
+	messages := []Message{{Role: "user", Content: prompt}}
+	opts := ChatOptions{MaxTokens: &maxTokens, Stream: stream}
+
+	results := make([]benchmarkRun, 0, runs)
+	for i := 0; i < warmup+runs; i++ {
+		if err := ctx.Err(); err != nil {
+			ec.PublishFailed(err)
+			return nil, fmt.Errorf("benchmark cancelled after %d runs: %w", i, err)
+		}
+		run, err := c.run(ctx, model, messages, opts)
+		if err != nil {
+			ec.PublishFailed(err)
+			return nil, fmt.Errorf("benchmark run %d: %w", i+1, err)
+		}
+		if i >= warmup {
+			results = append(results, run)
+		}
+	}
+
+	var latencies, ttfts []time.Duration
+	var total time.Duration
+	tokens := 0
+	for _, r := range results {
+		latencies = append(latencies, r.latency)
+		ttfts = append(ttfts, r.ttft)
+		total += r.latency
+		tokens += r.tokens
+	}
+
+	output := map[string]any{
+		"model":             model,
+		"runs":              runs,
+		"warmup":            warmup,
+		"stream":            stream,
+		"completion_tokens": tokens,
+		"tokens_per_sec":    0.0,
+		"latency_ms":        durationStats(latencies),
+	}
+	if total > 0 {
+		output["tokens_per_sec"] = math.Round(float64(tokens)/total.Seconds()*100) / 100
+	}
+	if stream {
+		output["ttft_ms"] = durationStats(ttfts)
+	}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+// run makes one generation and times it. Streamed runs also record the time
+// to the first content chunk, and count content chunks as tokens when the
+// provider reports no usage.
+func (c *BenchmarkCommand) run(ctx context.Context, model string, messages []Message, opts ChatOptions) (benchmarkRun, error) {
+	start := c.now()
+	if !opts.Stream {
+		resp, err := c.provider.Chat(ctx, model, messages, opts)
+		if err != nil {
+			return benchmarkRun{}, err
+		}
+		return benchmarkRun{latency: c.now().Sub(start), tokens: resp.Usage.CompletionTokens}, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chunks := make(chan ChatStreamChunk, 16)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(chunks)
+		defer recoverProvider(func(err error) { errCh <- err })
+		errCh <- c.provider.ChatStream(ctx, model, messages, opts, chunks)
+	}()
+
+	var run benchmarkRun
+	contentChunks, usageTokens := 0, -1
+	for chunk := range chunks {
+		if chunk.Content != "" {
+			if contentChunks == 0 {
+				run.ttft = c.now().Sub(start)
+			}
+			contentChunks++
+		}
+		if chunk.Usage != nil {
+			usageTokens = chunk.Usage.CompletionTokens
+		}
+	}
+	run.latency = c.now().Sub(start)
+	if err := <-errCh; err != nil {
+		return benchmarkRun{}, err
+	}
+
+	run.tokens = contentChunks
+	if usageTokens >= 0 {
+		run.tokens = usageTokens
+	}
+	return run, nil
+}
+
+// durationStats returns the p50, p95 and mean of ds in milliseconds.
+func durationStats(ds []time.Duration) map[string]any {
+	if len(ds) == 0 {
+		return map[string]any{"p50": 0.0, "p95": 0.0, "mean": 0.0}
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+	return map[string]any{
+		"p50":  millis(percentile(sorted, 50)),
+		"p95":  millis(percentile(sorted, 95)),
+		"mean": millis(sum / time.Duration(len(sorted))),
+	}
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func millis(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*100) / 100
+}
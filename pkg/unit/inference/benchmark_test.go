@@ -0,0 +1,203 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// timedProvider advances a fake clock as it generates: each call takes
+// firstToken before the first token, cycling through the listed delays for
+// successive calls, and perToken for every token after it. Streams wait for
+// the clock to be read after the first token so time to first token is
+// measured before the clock moves on.
+type timedProvider struct {
+	*MockProvider
+	mu         sync.Mutex
+	now        time.Time
+	reads      chan struct{}
+	firstToken []time.Duration
+	perToken   time.Duration
+	tokens     int
+	calls      int
+	cancel     func()
+	cancelAt   int
+}
+
+func (p *timedProvider) clock() time.Time {
+	p.mu.Lock()
+	now := p.now
+	p.mu.Unlock()
+	select {
+	case p.reads <- struct{}{}:
+	default:
+	}
+	return now
+}
+
+func (p *timedProvider) advance(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.now = p.now.Add(d)
+}
+
+func (p *timedProvider) next() time.Duration {
+	p.calls++
+	if p.cancel != nil && p.calls == p.cancelAt {
+		p.cancel()
+	}
+	return p.firstToken[(p.calls-1)%len(p.firstToken)]
+}
+
+func (p *timedProvider) Chat(ctx context.Context, model string, messages []Message, opts ChatOptions) (*ChatResponse, error) {
+	p.advance(p.next() + time.Duration(p.tokens-1)*p.perToken)
+	return &ChatResponse{Content: "ok", Usage: Usage{CompletionTokens: p.tokens}}, nil
+}
+
+func (p *timedProvider) ChatStream(ctx context.Context, model string, messages []Message, opts ChatOptions, stream chan<- ChatStreamChunk) error {
+	for drained := false; !drained; {
+		select {
+		case <-p.reads:
+		default:
+			drained = true
+		}
+	}
+
+	delay := p.next()
+	for i := 0; i < p.tokens; i++ {
+		p.advance(delay)
+		delay = p.perToken
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case stream <- ChatStreamChunk{Content: "tok "}:
+		}
+		if i == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-p.reads:
+			}
+		}
+	}
+	return nil
+}
+
+func newTimedProvider(tokens int, perToken time.Duration, firstToken ...time.Duration) *timedProvider {
+	return &timedProvider{
+		MockProvider: NewMockProvider(),
+		now:          time.Unix(0, 0),
+		reads:        make(chan struct{}, 1),
+		firstToken:   firstToken,
+		perToken:     perToken,
+		tokens:       tokens,
+	}
+}
+
+func newTimedBenchmark(p *timedProvider) *BenchmarkCommand {
+	cmd := NewBenchmarkCommand(p)
+	cmd.now = p.clock
+	return cmd
+}
+
+func TestBenchmarkCommand_Name(t *testing.T) {
+	if got := NewBenchmarkCommand(nil).Name(); got != "inference.benchmark" {
+		t.Errorf("expected name 'inference.benchmark', got '%s'", got)
+	}
+}
+
+func TestBenchmarkCommand_Execute(t *testing.T) {
+	// Ten tokens per run: latency is the first-token delay plus 9 x 10ms.
+	p := newTimedProvider(10, 10*time.Millisecond,
+		5*time.Second, 10*time.Millisecond, 20*time.Millisecond, 30*time.Millisecond, 40*time.Millisecond, 110*time.Millisecond)
+
+	result, err := newTimedBenchmark(p).Execute(context.Background(), map[string]any{"model": "llama3", "runs": 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := result.(map[string]any)
+
+	if p.calls != 6 {
+		t.Errorf("expected one warmup and five measured runs, got %d calls", p.calls)
+	}
+	latency := out["latency_ms"].(map[string]any)
+	if latency["p50"] != 120.0 || latency["p95"] != 200.0 || latency["mean"] != 132.0 {
+		t.Errorf("expected the warmup to be excluded from latency, got %v", latency)
+	}
+	if out["completion_tokens"] != 50 {
+		t.Errorf("expected 50 completion tokens, got %v", out["completion_tokens"])
+	}
+	// 50 tokens in 660ms.
+	if got := out["tokens_per_sec"]; got != 75.76 {
+		t.Errorf("expected 75.76 tokens/sec, got %v", got)
+	}
+	if _, ok := out["ttft_ms"]; ok {
+		t.Error("expected no time to first token without streaming")
+	}
+}
+
+func TestBenchmarkCommand_ExecuteStream(t *testing.T) {
+	p := newTimedProvider(5, 25*time.Millisecond, 100*time.Millisecond, 200*time.Millisecond)
+
+	result, err := newTimedBenchmark(p).Execute(context.Background(), map[string]any{"model": "llama3", "runs": 4, "warmup": 0, "stream": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := result.(map[string]any)
+
+	ttft := out["ttft_ms"].(map[string]any)
+	if ttft["p50"] != 100.0 || ttft["p95"] != 200.0 || ttft["mean"] != 150.0 {
+		t.Errorf("unexpected time to first token: %v", ttft)
+	}
+	latency := out["latency_ms"].(map[string]any)
+	if latency["p50"] != 200.0 || latency["p95"] != 300.0 {
+		t.Errorf("unexpected latency: %v", latency)
+	}
+	if out["completion_tokens"] != 20 {
+		t.Errorf("expected content chunks to be counted as tokens, got %v", out["completion_tokens"])
+	}
+}
+
+func TestBenchmarkCommand_Cancelled(t *testing.T) {
+	for _, stream := range []bool{false, true} {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := newTimedProvider(3, time.Millisecond, time.Millisecond)
+		p.cancel, p.cancelAt = cancel, 3
+
+		_, err := newTimedBenchmark(p).Execute(ctx, map[string]any{"model": "llama3", "runs": 10, "stream": stream})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("stream=%v: expected context.Canceled, got %v", stream, err)
+		}
+		if p.calls != 3 {
+			t.Errorf("stream=%v: expected no runs after cancellation, got %d calls", stream, p.calls)
+		}
+		cancel()
+	}
+}
+
+func TestBenchmarkCommand_Errors(t *testing.T) {
+	failing := NewMockProvider()
+	failing.SetChatError(errors.New("engine down"))
+
+	tests := []struct {
+		name     string
+		provider InferenceProvider
+		input    any
+	}{
+		{"nil provider", nil, map[string]any{"model": "llama3"}},
+		{"invalid input type", NewMockProvider(), "llama3"},
+		{"missing model", NewMockProvider(), map[string]any{}},
+		{"too many runs", NewMockProvider(), map[string]any{"model": "llama3", "runs": 1000}},
+		{"negative warmup", NewMockProvider(), map[string]any{"model": "llama3", "warmup": -1}},
+		{"provider error", failing, map[string]any{"model": "llama3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBenchmarkCommand(tt.provider).Execute(context.Background(), tt.input); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
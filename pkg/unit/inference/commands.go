@@ -2,7 +2,12 @@ package inference
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/ptrs"
@@ -10,58 +15,114 @@ import (
 
 // parseMessages extracts messages from the input map, handling both []any
 // (from JSON HTTP body unmarshalling) and []map[string]string (from CLI direct invocation).
+// Roles are validated and aliases normalized by parseMessage.
 func parseMessages(inputMap map[string]any) ([]Message, error) {
 	raw, exists := inputMap["messages"]
 	if !exists {
 		return nil, fmt.Errorf("messages are required: %w", ErrInvalidInput)
 	}
 
+	var entries []map[string]any
 	switch v := raw.(type) {
 	case []any:
-		if len(v) == 0 {
-			return nil, fmt.Errorf("messages are required: %w", ErrInvalidInput)
-		}
-		msgs := make([]Message, len(v))
+		entries = make([]map[string]any, len(v))
 		for i, m := range v {
 			mMap, ok := m.(map[string]any)
 			if !ok {
 				return nil, fmt.Errorf("invalid message format at index %d: %w", i, ErrInvalidInput)
 			}
-			msgs[i] = Message{
-				Role:    fmt.Sprintf("%v", mMap["role"]),
-				Content: fmt.Sprintf("%v", mMap["content"]),
-			}
+			entries[i] = mMap
 		}
-		return msgs, nil
 	case []map[string]string:
-		if len(v) == 0 {
-			return nil, fmt.Errorf("messages are required: %w", ErrInvalidInput)
-		}
-		msgs := make([]Message, len(v))
+		entries = make([]map[string]any, len(v))
 		for i, m := range v {
-			msgs[i] = Message{Role: m["role"], Content: m["content"]}
+			entries[i] = map[string]any{"role": m["role"], "content": m["content"]}
 		}
-		return msgs, nil
 	case []map[string]any:
-		if len(v) == 0 {
-			return nil, fmt.Errorf("messages are required: %w", ErrInvalidInput)
+		entries = v
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("messages are required: %w", ErrInvalidInput)
+	}
+
+	msgs := make([]Message, len(entries))
+	for i, m := range entries {
+		msg, err := parseMessage(m)
+		if err != nil {
+			return nil, fmt.Errorf("messages[%d]: %w", i, err)
 		}
-		msgs := make([]Message, len(v))
-		for i, m := range v {
-			msgs[i] = Message{
-				Role:    fmt.Sprintf("%v", m["role"]),
-				Content: fmt.Sprintf("%v", m["content"]),
-			}
+		msgs[i] = msg
+	}
+	return msgs, nil
+}
+
+// messageRoles lists the accepted chat roles; roleAliases maps common
+// alternative spellings onto them.
+var (
+	messageRoles = map[string]bool{"system": true, "user": true, "assistant": true, "tool": true}
+	roleAliases  = map[string]string{"human": "user", "ai": "assistant"}
+)
+
+// parseMessage validates a single message. Content may only be empty on
+// assistant turns that carry tool calls.
+func parseMessage(m map[string]any) (Message, error) {
+	role, ok := m["role"].(string)
+	if !ok && m["role"] != nil {
+		return Message{}, fmt.Errorf("role must be a string, got %T: %w", m["role"], ErrInvalidInput)
+	}
+	role = strings.ToLower(strings.TrimSpace(role))
+	if alias, ok := roleAliases[role]; ok {
+		role = alias
+	}
+	if role == "" {
+		return Message{}, fmt.Errorf("role is required: %w", ErrInvalidInput)
+	}
+	if !messageRoles[role] {
+		return Message{}, fmt.Errorf("invalid role %q, must be one of system, user, assistant, tool: %w", m["role"], ErrInvalidInput)
+	}
+
+	content, ok := m["content"].(string)
+	if !ok && m["content"] != nil {
+		return Message{}, fmt.Errorf("content must be a string, got %T: %w", m["content"], ErrInvalidInput)
+	}
+	if content == "" && !(role == "assistant" && hasToolCalls(m["tool_calls"])) {
+		return Message{}, fmt.Errorf("content is required for %s messages: %w", role, ErrInvalidInput)
+	}
+
+	return Message{Role: role, Content: content}, nil
+}
+
+// stringList converts a JSON array to strings, naming the offending element
+// of field when one is not a string.
+func stringList(field string, v []any) ([]string, error) {
+	out := make([]string, len(v))
+	for i, item := range v {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s[%d] must be a string, got %T: %w", field, i, item, ErrInvalidInput)
 		}
-		return msgs, nil
+		out[i] = s
+	}
+	return out, nil
+}
+
+func hasToolCalls(v any) bool {
+	switch calls := v.(type) {
+	case []any:
+		return len(calls) > 0
+	case []map[string]any:
+		return len(calls) > 0
 	default:
-		return nil, fmt.Errorf("messages are required: %w", ErrInvalidInput)
+		return false
 	}
 }
 
 type ChatCommand struct {
-	provider InferenceProvider
-	events   unit.EventPublisher
+	provider  InferenceProvider
+	events    unit.EventPublisher
+	maxTokens MaxTokensCap
+	trimStop  bool
+	templates PromptTemplates
 }
 
 func NewChatCommand(provider InferenceProvider) *ChatCommand {
@@ -72,6 +133,19 @@ func NewChatCommandWithEvents(provider InferenceProvider, events unit.EventPubli
 	return &ChatCommand{provider: provider, events: events}
 }
 
+// WithMaxTokensCap clamps the max_tokens of every chat request to limits.
+func (c *ChatCommand) WithMaxTokensCap(limits MaxTokensCap) *ChatCommand {
+	c.maxTokens = limits
+	return c
+}
+
+// WithStopTrimming makes the command cut the reply at the first stop
+// sequence itself, as a safety net for engines that ignore stop.
+func (c *ChatCommand) WithStopTrimming(enabled bool) *ChatCommand {
+	c.trimStop = enabled
+	return c
+}
+
 func (c *ChatCommand) Name() string {
 	return "inference.chat"
 }
@@ -104,7 +178,7 @@ func (c *ChatCommand) InputSchema() unit.Schema {
 						Type: "object",
 						Properties: map[string]unit.Field{
 							"role":    {Name: "role", Schema: unit.Schema{Type: "string", Enum: []any{"system", "user", "assistant"}}},
-							"content": {Name: "content", Schema: unit.Schema{Type: "string"}},
+							"content": {Name: "content", Schema: unit.Schema{Type: "string", Sensitive: true}},
 						},
 					},
 				},
@@ -176,6 +250,13 @@ func (c *ChatCommand) InputSchema() unit.Schema {
 					Description: "Enable streaming response",
 				},
 			},
+			"session_id": {
+				Name: "session_id",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Conversation ID; turns with the same ID are routed to the same replica",
+				},
+			},
 		},
 		Required: []string{"model", "messages"},
 	}
@@ -279,6 +360,7 @@ func (c *ChatCommand) Execute(ctx context.Context, input any) (any, error) {
 			opts.MaxTokens = &i
 		}
 	}
+	opts.MaxTokens = c.maxTokens.clamp(model, opts.MaxTokens)
 	if v, ok := inputMap["top_p"]; ok {
 		if f, ok := toFloat64(v); ok {
 			opts.TopP = &f
@@ -300,24 +382,47 @@ func (c *ChatCommand) Execute(ctx context.Context, input any) (any, error) {
 		}
 	}
 	if v, ok := inputMap["stop"].([]any); ok {
-		opts.Stop = make([]string, len(v))
-		for i, s := range v {
-			opts.Stop[i] = s.(string)
+		stop, err := stringList("stop", v)
+		if err != nil {
+			ec.PublishFailed(err)
+			return nil, err
 		}
+		opts.Stop = stop
 	}
 	if v, ok := inputMap["stream"].(bool); ok {
 		opts.Stream = v
 	}
+	if v, ok := inputMap["session_id"].(string); ok {
+		opts.SessionID = v
+	}
+
+	prompt, err := c.promptFor(ctx, model, messages)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, err
+	}
 
-	resp, err := c.provider.Chat(ctx, model, messages, opts)
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*ChatResponse, error) {
+		if prompt != "" {
+			return c.completeChat(ctx, model, prompt, opts)
+		}
+		return c.provider.Chat(ctx, model, messages, opts)
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("chat completion failed: %w", err)
 	}
 
+	content, finishReason := resp.Content, resp.FinishReason
+	if c.trimStop {
+		if cut, ok := trimAtStop(content, opts.Stop); ok {
+			content, finishReason = cut, "stop"
+		}
+	}
+
 	output := map[string]any{
-		"content":       resp.Content,
-		"finish_reason": resp.FinishReason,
+		"content":       content,
+		"finish_reason": finishReason,
 		"usage": map[string]any{
 			"prompt_tokens":     resp.Usage.PromptTokens,
 			"completion_tokens": resp.Usage.CompletionTokens,
@@ -327,6 +432,7 @@ func (c *ChatCommand) Execute(ctx context.Context, input any) (any, error) {
 		"id":    resp.ID,
 	}
 	ec.PublishCompleted(output)
+	publishRequestCompleted(ctx, ec, RequestMetrics{Model: model, FinishReason: finishReason, Usage: resp.Usage})
 	return output, nil
 }
 
@@ -367,23 +473,90 @@ func (c *ChatCommand) ExecuteStream(ctx context.Context, input any, stream chan<
 			opts.MaxTokens = &i
 		}
 	}
+	opts.MaxTokens = c.maxTokens.clamp(model, opts.MaxTokens)
+	if v, ok := inputMap["stop"].([]any); ok {
+		stop, err := stringList("stop", v)
+		if err != nil {
+			return err
+		}
+		opts.Stop = stop
+	}
+	if v, ok := inputMap["session_id"].(string); ok {
+		opts.SessionID = v
+	}
+
+	prompt, err := c.promptFor(ctx, model, messages)
+	if err != nil {
+		return err
+	}
+	if prompt != "" {
+		return c.streamTemplatedChat(ctx, model, prompt, opts, stream)
+	}
 
 	// Create internal channel for provider stream
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
 	providerStream := make(chan ChatStreamChunk, 10)
-	defer close(providerStream)
 
-	// Run provider stream in goroutine
+	// Run provider stream in goroutine. Closing the channel once the
+	// provider returns lets the loop below forward every buffered chunk
+	// before reporting the provider's result.
+	// Once a trimmed stop sequence is reached the provider is cancelled, and
+	// its cancellation error is not reported.
+	providerCtx, cancelProvider := context.WithCancel(ctx)
+	defer cancelProvider()
 	errChan := make(chan error, 1)
 	go func() {
-		errChan <- c.provider.ChatStream(ctx, model, messages, opts, providerStream)
+		defer close(providerStream)
+		defer recoverProvider(func(err error) { errChan <- err })
+		errChan <- c.provider.ChatStream(providerCtx, model, messages, opts, providerStream)
 	}()
 
-	// Forward chunks from provider to unit stream
+	var trimmer *stopTrimmer
+	if c.trimStop && len(opts.Stop) > 0 {
+		trimmer = &stopTrimmer{stop: opts.Stop}
+	}
+
+	// Forward chunks from provider to unit stream, then report the usage
+	// from the last provider chunk that carried one and publish
+	// inference.request_completed once that usage chunk is sent.
+	var usage *Usage
+	metrics := RequestMetrics{Model: model}
 	for {
 		select {
 		case chunk, ok := <-providerStream:
 			if !ok {
-				return <-errChan
+				if err := <-errChan; err != nil && !(trimmer != nil && trimmer.stopped && ctx.Err() == nil) {
+					return failStream(ctx, stream, err)
+				}
+				if rest := trimmer.flush(); rest != "" {
+					stream <- unit.StreamChunk{Type: "content", Data: rest}
+				}
+				if err := sendUsageChunk(ctx, stream, usage); err != nil {
+					return err
+				}
+				if usage != nil {
+					metrics.Usage = *usage
+				}
+				publishRequestCompleted(ctx, ec, metrics)
+				return nil
+			}
+			if chunk.Usage != nil {
+				usage = chunk.Usage
+			}
+			if trimmer != nil {
+				if trimmer.stopped {
+					continue
+				}
+				chunk.Content, chunk.FinishReason = trimmer.chunk(chunk.Content, chunk.FinishReason)
+				if trimmer.stopped {
+					cancelProvider()
+				}
+			}
+			if chunk.Content != "" && metrics.TimeToFirstToken == 0 {
+				metrics.TimeToFirstToken = time.Since(ec.StartTime)
+			}
+			if chunk.FinishReason != "" {
+				metrics.FinishReason = chunk.FinishReason
 			}
 			stream <- unit.StreamChunk{
 				Type: "content",
@@ -394,17 +567,48 @@ func (c *ChatCommand) ExecuteStream(ctx context.Context, input any, stream chan<
 					"id":            chunk.ID,
 				},
 			}
-		case err := <-errChan:
-			return err
 		case <-ctx.Done():
 			return ctx.Err()
 		}
 	}
 }
 
+// failStream ends a stream whose provider failed with an "error" chunk
+// after the chunks already forwarded, and returns err.
+func failStream(ctx context.Context, stream chan<- unit.StreamChunk, err error) error {
+	select {
+	case stream <- unit.ErrorChunk(err):
+	case <-ctx.Done():
+	}
+	return err
+}
+
+// sendUsageChunk ends a stream with a "usage" chunk holding the token counts
+// reported by the provider, or zeros when it reported none.
+func sendUsageChunk(ctx context.Context, stream chan<- unit.StreamChunk, usage *Usage) error {
+	if usage == nil {
+		usage = &Usage{}
+	}
+	select {
+	case stream <- unit.StreamChunk{
+		Type: "usage",
+		Data: map[string]any{
+			"prompt_tokens":     usage.PromptTokens,
+			"completion_tokens": usage.CompletionTokens,
+			"total_tokens":      usage.TotalTokens,
+		},
+	}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 type CompleteCommand struct {
-	provider InferenceProvider
-	events   unit.EventPublisher
+	provider  InferenceProvider
+	events    unit.EventPublisher
+	maxTokens MaxTokensCap
+	trimStop  bool
 }
 
 func NewCompleteCommand(provider InferenceProvider) *CompleteCommand {
@@ -415,6 +619,19 @@ func NewCompleteCommandWithEvents(provider InferenceProvider, events unit.EventP
 	return &CompleteCommand{provider: provider, events: events}
 }
 
+// WithMaxTokensCap clamps the max_tokens of every complete request to limits.
+func (c *CompleteCommand) WithMaxTokensCap(limits MaxTokensCap) *CompleteCommand {
+	c.maxTokens = limits
+	return c
+}
+
+// WithStopTrimming makes the command cut the text at the first stop
+// sequence itself, as a safety net for engines that ignore stop.
+func (c *CompleteCommand) WithStopTrimming(enabled bool) *CompleteCommand {
+	c.trimStop = enabled
+	return c
+}
+
 func (c *CompleteCommand) Name() string {
 	return "inference.complete"
 }
@@ -443,6 +660,7 @@ func (c *CompleteCommand) InputSchema() unit.Schema {
 				Schema: unit.Schema{
 					Type:        "string",
 					Description: "The prompt to complete",
+					Sensitive:   true,
 				},
 			},
 			"temperature": {
@@ -561,30 +779,42 @@ func (c *CompleteCommand) Execute(ctx context.Context, input any) (any, error) {
 			opts.MaxTokens = &i
 		}
 	}
+	opts.MaxTokens = c.maxTokens.clamp(model, opts.MaxTokens)
 	if v, ok := inputMap["top_p"]; ok {
 		if f, ok := toFloat64(v); ok {
 			opts.TopP = &f
 		}
 	}
 	if v, ok := inputMap["stop"].([]any); ok {
-		opts.Stop = make([]string, len(v))
-		for i, s := range v {
-			opts.Stop[i] = s.(string)
+		stop, err := stringList("stop", v)
+		if err != nil {
+			ec.PublishFailed(err)
+			return nil, err
 		}
+		opts.Stop = stop
 	}
 	if v, ok := inputMap["stream"].(bool); ok {
 		opts.Stream = v
 	}
 
-	resp, err := c.provider.Complete(ctx, model, prompt, opts)
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*CompletionResponse, error) {
+		return c.provider.Complete(ctx, model, prompt, opts)
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("completion failed: %w", err)
 	}
 
+	text, finishReason := resp.Text, resp.FinishReason
+	if c.trimStop {
+		if cut, ok := trimAtStop(text, opts.Stop); ok {
+			text, finishReason = cut, "stop"
+		}
+	}
+
 	output := map[string]any{
-		"text":          resp.Text,
-		"finish_reason": resp.FinishReason,
+		"text":          text,
+		"finish_reason": finishReason,
 		"usage": map[string]any{
 			"prompt_tokens":     resp.Usage.PromptTokens,
 			"completion_tokens": resp.Usage.CompletionTokens,
@@ -592,6 +822,7 @@ func (c *CompleteCommand) Execute(ctx context.Context, input any) (any, error) {
 		},
 	}
 	ec.PublishCompleted(output)
+	publishRequestCompleted(ctx, ec, RequestMetrics{Model: model, FinishReason: finishReason, Usage: resp.Usage})
 	return output, nil
 }
 
@@ -632,23 +863,79 @@ func (c *CompleteCommand) ExecuteStream(ctx context.Context, input any, stream c
 			opts.MaxTokens = &i
 		}
 	}
+	opts.MaxTokens = c.maxTokens.clamp(model, opts.MaxTokens)
+	if v, ok := inputMap["stop"].([]any); ok {
+		stop, err := stringList("stop", v)
+		if err != nil {
+			return err
+		}
+		opts.Stop = stop
+	}
 
 	// Create internal channel for provider stream
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
 	providerStream := make(chan CompleteStreamChunk, 10)
-	defer close(providerStream)
 
-	// Run provider stream in goroutine
+	// Run provider stream in goroutine. Closing the channel once the
+	// provider returns lets the loop below forward every buffered chunk
+	// before reporting the provider's result.
+	// Once a trimmed stop sequence is reached the provider is cancelled, and
+	// its cancellation error is not reported.
+	providerCtx, cancelProvider := context.WithCancel(ctx)
+	defer cancelProvider()
 	errChan := make(chan error, 1)
 	go func() {
-		errChan <- c.provider.CompleteStream(ctx, model, prompt, opts, providerStream)
+		defer close(providerStream)
+		defer recoverProvider(func(err error) { errChan <- err })
+		errChan <- c.provider.CompleteStream(providerCtx, model, prompt, opts, providerStream)
 	}()
 
-	// Forward chunks from provider to unit stream
+	var trimmer *stopTrimmer
+	if c.trimStop && len(opts.Stop) > 0 {
+		trimmer = &stopTrimmer{stop: opts.Stop}
+	}
+
+	// Forward chunks from provider to unit stream, then report the usage
+	// from the last provider chunk that carried one and publish
+	// inference.request_completed once that usage chunk is sent.
+	var usage *Usage
+	metrics := RequestMetrics{Model: model}
 	for {
 		select {
 		case chunk, ok := <-providerStream:
 			if !ok {
-				return <-errChan
+				if err := <-errChan; err != nil && !(trimmer != nil && trimmer.stopped && ctx.Err() == nil) {
+					return failStream(ctx, stream, err)
+				}
+				if rest := trimmer.flush(); rest != "" {
+					stream <- unit.StreamChunk{Type: "content", Data: rest}
+				}
+				if err := sendUsageChunk(ctx, stream, usage); err != nil {
+					return err
+				}
+				if usage != nil {
+					metrics.Usage = *usage
+				}
+				publishRequestCompleted(ctx, ec, metrics)
+				return nil
+			}
+			if chunk.Usage != nil {
+				usage = chunk.Usage
+			}
+			if trimmer != nil {
+				if trimmer.stopped {
+					continue
+				}
+				chunk.Text, chunk.FinishReason = trimmer.chunk(chunk.Text, chunk.FinishReason)
+				if trimmer.stopped {
+					cancelProvider()
+				}
+			}
+			if chunk.Text != "" && metrics.TimeToFirstToken == 0 {
+				metrics.TimeToFirstToken = time.Since(ec.StartTime)
+			}
+			if chunk.FinishReason != "" {
+				metrics.FinishReason = chunk.FinishReason
 			}
 			stream <- unit.StreamChunk{
 				Type: "content",
@@ -659,8 +946,6 @@ func (c *CompleteCommand) ExecuteStream(ctx context.Context, input any, stream c
 					"id":            chunk.ID,
 				},
 			}
-		case err := <-errChan:
-			return err
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -754,6 +1039,38 @@ func (c *EmbedCommand) Examples() []unit.Example {
 	}
 }
 
+// embedRequest is the decoded input of inference.embed.
+type embedRequest struct {
+	Model string       `json:"model"`
+	Input stringOrList `json:"input"`
+}
+
+// stringOrList accepts either a single string or an array of strings, as
+// the embedding input does.
+type stringOrList []string
+
+func (l *stringOrList) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case nil:
+		*l = nil
+	case string:
+		*l = stringOrList{v}
+	case []any:
+		texts, err := stringList("input", v)
+		if err != nil {
+			return err
+		}
+		*l = texts
+	default:
+		return fmt.Errorf("input must be string or array: %w", ErrInvalidInput)
+	}
+	return nil
+}
+
 func (c *EmbedCommand) Execute(ctx context.Context, input any) (any, error) {
 	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
 	ec.PublishStarted(input)
@@ -764,38 +1081,25 @@ func (c *EmbedCommand) Execute(ctx context.Context, input any) (any, error) {
 		return nil, err
 	}
 
-	inputMap, ok := input.(map[string]any)
-	if !ok {
-		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+	req, err := unit.Decode[embedRequest](input)
+	if err != nil {
 		ec.PublishFailed(err)
 		return nil, err
 	}
-
-	model, _ := inputMap["model"].(string)
-	if model == "" {
+	if req.Model == "" {
 		err := ErrModelNotSpecified
 		ec.PublishFailed(err)
 		return nil, err
 	}
-
-	var texts []string
-	switch v := inputMap["input"].(type) {
-	case string:
-		texts = []string{v}
-	case []any:
-		texts = make([]string, len(v))
-		for i, s := range v {
-			texts[i] = s.(string)
-		}
-	case []string:
-		texts = v
-	default:
+	if req.Input == nil {
 		err := fmt.Errorf("input must be string or array: %w", ErrInvalidInput)
 		ec.PublishFailed(err)
 		return nil, err
 	}
 
-	resp, err := c.provider.Embed(ctx, model, texts)
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*EmbeddingResponse, error) {
+		return c.provider.Embed(ctx, req.Model, req.Input)
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("embedding failed: %w", err)
@@ -809,6 +1113,7 @@ func (c *EmbedCommand) Execute(ctx context.Context, input any) (any, error) {
 		},
 	}
 	ec.PublishCompleted(output)
+	publishRequestCompleted(ctx, ec, RequestMetrics{Model: req.Model, Usage: resp.Usage})
 	return output, nil
 }
 
@@ -929,7 +1234,9 @@ func (c *TranscribeCommand) Execute(ctx context.Context, input any) (any, error)
 	audio := []byte(audioRaw)
 	language, _ := inputMap["language"].(string)
 
-	resp, err := c.provider.Transcribe(ctx, model, audio, language)
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*TranscriptionResponse, error) {
+		return c.provider.Transcribe(ctx, model, audio, language)
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("transcription failed: %w", err)
@@ -1071,7 +1378,9 @@ func (c *SynthesizeCommand) Execute(ctx context.Context, input any) (any, error)
 
 	voice, _ := inputMap["voice"].(string)
 
-	resp, err := c.provider.Synthesize(ctx, model, text, voice)
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*AudioResponse, error) {
+		return c.provider.Synthesize(ctx, model, text, voice)
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("synthesis failed: %w", err)
@@ -1256,7 +1565,9 @@ func (c *GenerateImageCommand) Execute(ctx context.Context, input any) (any, err
 		opts.Seed = &v
 	}
 
-	resp, err := c.provider.GenerateImage(ctx, model, prompt, opts)
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*ImageGenerationResponse, error) {
+		return c.provider.GenerateImage(ctx, model, prompt, opts)
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("image generation failed: %w", err)
@@ -1424,7 +1735,9 @@ func (c *GenerateVideoCommand) Execute(ctx context.Context, input any) (any, err
 		opts.Seed = &v
 	}
 
-	resp, err := c.provider.GenerateVideo(ctx, model, prompt, opts)
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*VideoGenerationResponse, error) {
+		return c.provider.GenerateVideo(ctx, model, prompt, opts)
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("video generation failed: %w", err)
@@ -1502,8 +1815,16 @@ func (c *RerankCommand) OutputSchema() unit.Schema {
 			"results": {
 				Name: "results",
 				Schema: unit.Schema{
-					Type:  "array",
-					Items: &unit.Schema{Type: "object"},
+					Type:        "array",
+					Description: "Documents with their relevance scores",
+					Items: &unit.Schema{
+						Type: "object",
+						Properties: map[string]unit.Field{
+							"document": {Name: "document", Schema: unit.Schema{Type: "string"}},
+							"score":    {Name: "score", Schema: unit.Schema{Type: "number"}},
+							"index":    {Name: "index", Schema: unit.Schema{Type: "number"}},
+						},
+					},
 				},
 			},
 		},
@@ -1529,6 +1850,11 @@ func (c *RerankCommand) Examples() []unit.Example {
 	}
 }
 
+// rerankOutput is the result of inference.rerank.
+type rerankOutput struct {
+	Results []RerankResult `json:"results"`
+}
+
 func (c *RerankCommand) Execute(ctx context.Context, input any) (any, error) {
 	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
 	ec.PublishStarted(input)
@@ -1567,27 +1893,29 @@ func (c *RerankCommand) Execute(ctx context.Context, input any) (any, error) {
 		return nil, err
 	}
 
-	documents := make([]string, len(docsRaw))
-	for i, d := range docsRaw {
-		documents[i] = d.(string)
+	documents, err := stringList("documents", docsRaw)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, err
 	}
 
-	resp, err := c.provider.Rerank(ctx, model, query, documents)
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*RerankResponse, error) {
+		return c.provider.Rerank(ctx, model, query, documents)
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("rerank failed: %w", err)
 	}
 
-	results := make([]map[string]any, len(resp.Results))
-	for i, r := range resp.Results {
-		results[i] = map[string]any{
-			"document": r.Document,
-			"score":    r.Score,
-			"index":    r.Index,
-		}
+	results := resp.Results
+	if results == nil {
+		results = []RerankResult{}
+	}
+	output, err := unit.Encode(rerankOutput{Results: results})
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, err
 	}
-
-	output := map[string]any{"results": results}
 	ec.PublishCompleted(output)
 	return output, nil
 }
@@ -1710,7 +2038,9 @@ func (c *DetectCommand) Execute(ctx context.Context, input any) (any, error) {
 		return nil, err
 	}
 
-	resp, err := c.provider.Detect(ctx, model, []byte(imageRaw))
+	resp, err := awaitProvider(ctx, func(ctx context.Context) (*DetectionResponse, error) {
+		return c.provider.Detect(ctx, model, []byte(imageRaw))
+	})
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("detection failed: %w", err)
@@ -1730,6 +2060,159 @@ func (c *DetectCommand) Execute(ctx context.Context, input any) (any, error) {
 	return output, nil
 }
 
+// StreamCanceller stops an in-flight streaming execution by the request ID
+// the gateway assigned to it. *unit.StreamTracker satisfies it.
+type StreamCanceller interface {
+	Cancel(requestID string) bool
+}
+
+type CancelCommand struct {
+	streams StreamCanceller
+	events  unit.EventPublisher
+}
+
+func NewCancelCommand(streams StreamCanceller) *CancelCommand {
+	return &CancelCommand{streams: streams}
+}
+
+func NewCancelCommandWithEvents(streams StreamCanceller, events unit.EventPublisher) *CancelCommand {
+	return &CancelCommand{streams: streams, events: events}
+}
+
+func (c *CancelCommand) Name() string {
+	return "inference.cancel"
+}
+
+func (c *CancelCommand) Domain() string {
+	return "inference"
+}
+
+func (c *CancelCommand) Description() string {
+	return "Cancel an in-flight streaming generation by request ID"
+}
+
+func (c *CancelCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"request_id": {
+				Name: "request_id",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Request ID returned when the stream was started",
+				},
+			},
+		},
+		Required: []string{"request_id"},
+	}
+}
+
+func (c *CancelCommand) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"request_id": {Name: "request_id", Schema: unit.Schema{Type: "string"}},
+			"cancelled":  {Name: "cancelled", Schema: unit.Schema{Type: "boolean"}},
+		},
+	}
+}
+
+func (c *CancelCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input:       map[string]any{"request_id": "req-1a2b3c"},
+			Output:      map[string]any{"request_id": "req-1a2b3c", "cancelled": true},
+			Description: "Stop a running chat stream",
+		},
+	}
+}
+
+func (c *CancelCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.streams == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	requestID, _ := inputMap["request_id"].(string)
+	if requestID == "" {
+		err := fmt.Errorf("request_id is required: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	if !c.streams.Cancel(requestID) {
+		err := fmt.Errorf("request %s: %w", requestID, ErrStreamNotFound)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	output := map[string]any{"request_id": requestID, "cancelled": true}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+// publishRequestCompleted publishes inference.request_completed under the
+// execution's correlation ID, filling in the request ID, caller and duration
+// from ctx and ec.
+func publishRequestCompleted(ctx context.Context, ec *unit.ExecutionContext, metrics RequestMetrics) {
+	if ec.Publisher == nil {
+		return
+	}
+	metrics.RequestID = unit.GetRequestID(ctx)
+	metrics.Principal = unit.GetUserID(ctx)
+	metrics.Duration = time.Since(ec.StartTime)
+	_ = ec.Publisher.Publish(NewRequestCompletedMetricsEvent(ec.CorrelationID, metrics))
+}
+
+// awaitProvider runs a buffered provider call and returns as soon as either the
+// call finishes or ctx is done, so a wedged provider cannot hold a request past
+// its deadline. The result channel is buffered, letting the goroutine exit once
+// the provider eventually returns even if nobody is waiting anymore. A provider
+// that panics fails the request instead of the process.
+func awaitProvider[T any](ctx context.Context, call func(ctx context.Context) (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+
+	resultCh := make(chan result, 1)
+	go func() {
+		defer recoverProvider(func(err error) { resultCh <- result{err: err} })
+		value, err := call(ctx)
+		resultCh <- result{value: value, err: err}
+	}()
+
+	select {
+	case r := <-resultCh:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, fmt.Errorf("%w: %w", ErrInferenceTimeout, ctx.Err())
+	}
+}
+
+// recoverProvider reports a panic in a provider call running on its own
+// goroutine to fail as an ErrInferenceEngineError. Middleware only recovers
+// panics on the handler goroutine, so without it a provider panic would
+// crash the server. It must be deferred directly.
+func recoverProvider(fail func(err error)) {
+	if r := recover(); r != nil {
+		slog.Error("inference provider panicked", "panic", r, "stack", string(debug.Stack()))
+		fail(fmt.Errorf("%w: provider panic: %v", ErrInferenceEngineError, r))
+	}
+}
+
 func toFloat64(v any) (float64, bool) {
 	switch val := v.(type) {
 	case float64:
@@ -3,7 +3,10 @@ package inference
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 )
@@ -136,6 +139,275 @@ func TestChatCommand_Execute(t *testing.T) {
 	}
 }
 
+// blockingChatProvider ignores ctx and blocks Chat until release is closed,
+// simulating a wedged provider.
+type blockingChatProvider struct {
+	*MockProvider
+	release chan struct{}
+}
+
+func (p *blockingChatProvider) Chat(ctx context.Context, model string, messages []Message, opts ChatOptions) (*ChatResponse, error) {
+	<-p.release
+	return &ChatResponse{Content: "late"}, nil
+}
+
+// recordingPublisher collects published events for assertions.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []any
+}
+
+func (p *recordingPublisher) Publish(event any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) requestCompleted(t *testing.T) *RequestCompletedEvent {
+	t.Helper()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, e := range p.events {
+		if evt, ok := e.(*RequestCompletedEvent); ok {
+			return evt
+		}
+	}
+	t.Fatal("no inference.request_completed event published")
+	return nil
+}
+
+func TestChatCommand_Execute_MessageValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []any
+		wantErr  string
+	}{
+		{
+			name:     "missing role",
+			messages: []any{map[string]any{"role": "user", "content": "Hi"}, map[string]any{"content": "Hello"}},
+			wantErr:  "messages[1]: role is required",
+		},
+		{
+			name:     "invalid role",
+			messages: []any{map[string]any{"role": "narrator", "content": "Hi"}},
+			wantErr:  `messages[0]: invalid role "narrator"`,
+		},
+		{
+			name:     "empty content",
+			messages: []any{map[string]any{"role": "user", "content": ""}},
+			wantErr:  "messages[0]: content is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewChatCommand(NewMockProvider())
+			_, err := cmd.Execute(context.Background(), map[string]any{"model": "llama3", "messages": tt.messages})
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("alias and tool call turn", func(t *testing.T) {
+		provider := NewMockProvider()
+		cmd := NewChatCommand(provider)
+		_, err := cmd.Execute(context.Background(), map[string]any{"model": "llama3", "messages": []any{
+			map[string]any{"role": "Human", "content": "What's the weather?"},
+			map[string]any{"role": "assistant", "tool_calls": []any{map[string]any{"name": "weather"}}},
+			map[string]any{"role": "tool", "content": "sunny"},
+		}})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		last := provider.LastChatRequest()
+		if last == nil || last.Messages[0].Role != "user" {
+			t.Errorf("expected human to be normalized to user, got %+v", last)
+		}
+	})
+}
+
+func TestCommands_WrongTypedFields(t *testing.T) {
+	provider := NewMockProvider()
+	tests := []struct {
+		name    string
+		cmd     unit.Command
+		input   map[string]any
+		wantErr string
+	}{
+		{
+			name:    "chat role",
+			cmd:     NewChatCommand(provider),
+			input:   map[string]any{"model": "llama3", "messages": []any{map[string]any{"role": 1, "content": "Hi"}}},
+			wantErr: "messages[0]: role must be a string",
+		},
+		{
+			name:    "chat content",
+			cmd:     NewChatCommand(provider),
+			input:   map[string]any{"model": "llama3", "messages": []any{map[string]any{"role": "user", "content": 42.0}}},
+			wantErr: "messages[0]: content must be a string",
+		},
+		{
+			name:    "chat stop",
+			cmd:     NewChatCommand(provider),
+			input:   map[string]any{"model": "llama3", "messages": []any{map[string]any{"role": "user", "content": "Hi"}}, "stop": []any{"\n", 7.0}},
+			wantErr: "stop[1] must be a string",
+		},
+		{
+			name:    "embed input",
+			cmd:     NewEmbedCommand(provider),
+			input:   map[string]any{"model": "bge-m3", "input": []any{"text", true}},
+			wantErr: "input[1] must be a string",
+		},
+		{
+			name:    "rerank documents",
+			cmd:     NewRerankCommand(provider),
+			input:   map[string]any{"model": "bge-reranker", "query": "q", "documents": []any{3.0}},
+			wantErr: "documents[0] must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.cmd.Execute(context.Background(), tt.input)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestChatCommand_Execute_PublishesRequestCompleted(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatResponse(&ChatResponse{
+		Content:      "hi",
+		FinishReason: "length",
+		Model:        "llama3",
+		Usage:        Usage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15},
+	})
+	events := &recordingPublisher{}
+	cmd := NewChatCommandWithEvents(provider, events)
+
+	ctx := unit.WithUserID(unit.WithRequestID(context.Background(), "req-42"), "team-a")
+	_, err := cmd.Execute(ctx, map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "hello"}},
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	evt := events.requestCompleted(t)
+	payload := evt.Payload().(map[string]any)
+	want := map[string]any{
+		"request_id":        "req-42",
+		"correlation_id":    evt.CorrelationID(),
+		"model":             "llama3",
+		"principal":         "team-a",
+		"finish_reason":     "length",
+		"prompt_tokens":     12,
+		"completion_tokens": 3,
+		"total_tokens":      15,
+	}
+	for key, value := range want {
+		if payload[key] != value {
+			t.Errorf("payload[%s] = %v, want %v", key, payload[key], value)
+		}
+	}
+	if _, ok := payload["duration_ms"].(int64); !ok {
+		t.Errorf("payload[duration_ms] = %v, want int64", payload["duration_ms"])
+	}
+	if evt.CorrelationID() == "" {
+		t.Error("expected the execution's correlation ID")
+	}
+}
+
+func TestChatCommand_Execute_FailureSkipsRequestCompleted(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatError(errors.New("engine down"))
+	events := &recordingPublisher{}
+	cmd := NewChatCommandWithEvents(provider, events)
+
+	_, _ = cmd.Execute(context.Background(), map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "hello"}},
+	})
+
+	for _, e := range events.events {
+		if _, ok := e.(*RequestCompletedEvent); ok {
+			t.Fatal("failed requests must not publish inference.request_completed")
+		}
+	}
+}
+
+func TestChatCommand_Execute_ContextCancelled(t *testing.T) {
+	provider := &blockingChatProvider{MockProvider: NewMockProvider(), release: make(chan struct{})}
+	defer close(provider.release)
+
+	cmd := NewChatCommand(provider)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := cmd.Execute(ctx, map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+	})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !unit.IsTimeout(err) {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("expected Execute to return promptly after cancellation, took %v", elapsed)
+	}
+}
+
+// panickingProvider panics in every chat call, like a provider with a bug.
+type panickingProvider struct {
+	*MockProvider
+}
+
+func (p *panickingProvider) Chat(ctx context.Context, modelName string, messages []Message, opts ChatOptions) (*ChatResponse, error) {
+	panic("provider bug")
+}
+
+func (p *panickingProvider) ChatStream(ctx context.Context, modelName string, messages []Message, opts ChatOptions, stream chan<- ChatStreamChunk) error {
+	panic("provider bug")
+}
+
+func TestChatCommand_ProviderPanicFailsRequest(t *testing.T) {
+	cmd := NewChatCommand(&panickingProvider{MockProvider: NewMockProvider()})
+	input := map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+	}
+
+	_, err := cmd.Execute(context.Background(), input)
+	if !errors.Is(err, ErrInferenceEngineError) || !strings.Contains(err.Error(), "provider bug") {
+		t.Errorf("expected the panic as an engine error, got %v", err)
+	}
+
+	stream := make(chan unit.StreamChunk, 10)
+	err = cmd.ExecuteStream(context.Background(), input, stream)
+	if !errors.Is(err, ErrInferenceEngineError) {
+		t.Errorf("expected the streamed panic as an engine error, got %v", err)
+	}
+}
+
 func TestCompleteCommand_Name(t *testing.T) {
 	cmd := NewCompleteCommand(nil)
 	if cmd.Name() != "inference.complete" {
@@ -256,6 +528,30 @@ func TestEmbedCommand_Execute(t *testing.T) {
 			input:    map[string]any{"model": "text-embedding-3-small", "input": "test"},
 			wantErr:  true,
 		},
+		{
+			name:     "json array of texts",
+			provider: NewMockProvider(),
+			input:    map[string]any{"model": "text-embedding-3-small", "input": []any{"Hello", "World"}},
+			wantErr:  false,
+		},
+		{
+			name:     "missing input",
+			provider: NewMockProvider(),
+			input:    map[string]any{"model": "text-embedding-3-small"},
+			wantErr:  true,
+		},
+		{
+			name:     "non-string array element",
+			provider: NewMockProvider(),
+			input:    map[string]any{"model": "text-embedding-3-small", "input": []any{"Hello", 42}},
+			wantErr:  true,
+		},
+		{
+			name:     "model of wrong type",
+			provider: NewMockProvider(),
+			input:    map[string]any{"model": 42, "input": "test"},
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -677,6 +973,47 @@ func TestRerankCommand_Execute(t *testing.T) {
 	}
 }
 
+func TestRerankCommand_OutputMatchesSchema(t *testing.T) {
+	cmd := NewRerankCommand(NewMockProvider())
+	result, err := cmd.Execute(context.Background(), map[string]any{
+		"model":     "rerank-1",
+		"query":     "What is AI?",
+		"documents": []any{"AI is technology", "Dogs are animals"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := result.(map[string]any)
+	schema := cmd.OutputSchema()
+	if err := schema.Validate(output); err != nil {
+		t.Errorf("output does not match the output schema: %v", err)
+	}
+	if unknown := schema.UnknownFields(output); len(unknown) != 0 {
+		t.Errorf("output has fields missing from the schema: %v", unknown)
+	}
+
+	itemSchema := schema.Properties["results"].Schema.Items
+	results, ok := output["results"].([]any)
+	if !ok || len(results) != 2 {
+		t.Fatalf("expected two results, got %#v", output["results"])
+	}
+	for i, r := range results {
+		item := r.(map[string]any)
+		if unknown := itemSchema.UnknownFields(item); len(unknown) != 0 {
+			t.Errorf("results[%d] has fields missing from the schema: %v", i, unknown)
+		}
+		for name := range itemSchema.Properties {
+			if _, ok := item[name]; !ok {
+				t.Errorf("results[%d] is missing schema field %q", i, name)
+			}
+		}
+	}
+	if first := results[0].(map[string]any); first["document"] != "AI is technology" || first["index"] != float64(0) {
+		t.Errorf("unexpected first result: %v", first)
+	}
+}
+
 func TestDetectCommand_Name(t *testing.T) {
 	cmd := NewDetectCommand(nil)
 	if cmd.Name() != "inference.detect" {
@@ -747,6 +1084,44 @@ func TestDetectCommand_Execute(t *testing.T) {
 	}
 }
 
+func TestCancelCommand_Execute(t *testing.T) {
+	streams := unit.NewStreamTracker()
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	streams.Track("req-1", cancel)
+
+	cmd := NewCancelCommand(streams)
+	if cmd.Name() != "inference.cancel" {
+		t.Errorf("expected name 'inference.cancel', got '%s'", cmd.Name())
+	}
+
+	result, err := cmd.Execute(context.Background(), map[string]any{"request_id": "req-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.(map[string]any)["cancelled"] != true {
+		t.Errorf("expected cancelled=true, got %v", result)
+	}
+	if !errors.Is(context.Cause(ctx), unit.ErrStreamCancelled) {
+		t.Errorf("expected stream context cancelled with ErrStreamCancelled, got %v", context.Cause(ctx))
+	}
+
+	_, err = cmd.Execute(context.Background(), map[string]any{"request_id": "req-1"})
+	if !errors.Is(err, ErrStreamNotFound) {
+		t.Errorf("expected ErrStreamNotFound, got %v", err)
+	}
+
+	_, err = cmd.Execute(context.Background(), map[string]any{})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+
+	_, err = NewCancelCommand(nil).Execute(context.Background(), map[string]any{"request_id": "req-1"})
+	if !errors.Is(err, ErrProviderNotSet) {
+		t.Errorf("expected ErrProviderNotSet, got %v", err)
+	}
+}
+
 func TestCommand_Description(t *testing.T) {
 	if NewChatCommand(nil).Description() == "" {
 		t.Error("expected non-empty description for ChatCommand")
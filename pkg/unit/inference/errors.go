@@ -1,16 +1,22 @@
 package inference
 
-import "github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+import (
+	"fmt"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
 
 // Inference domain errors
 var (
 	// Resource errors
 	ErrInferenceModelNotLoaded = unit.NewDomainError("inference", unit.ErrCodeInferenceModelNotLoaded, "model not loaded")
+	ErrModelNotPulled          = unit.NewDomainError("inference", unit.ErrCodeModelNotFound, "model not pulled")
 
 	// Operation errors
 	ErrInferenceEngineError = unit.NewDomainError("inference", unit.ErrCodeInferenceEngineError, "inference engine error")
 	ErrInferenceTimeout     = unit.NewDomainError("inference", unit.ErrCodeInferenceTimeout, "inference timeout")
 	ErrInferenceRateLimited = unit.NewDomainError("inference", unit.ErrCodeInferenceRateLimited, "inference rate limited")
+	ErrStreamNotFound       = unit.NewError(unit.ErrCodeNotFound, "stream not found")
 
 	// Input errors (backward compatibility)
 	ErrInvalidInput      = unit.NewError(unit.ErrCodeInvalidInput, "invalid input")
@@ -20,3 +26,18 @@ var (
 	ErrUnsupportedModel  = unit.NewError(unit.ErrCodeInvalidInput, "unsupported model type")
 	ErrProviderNotSet    = unit.NewError(unit.ErrCodeInternalError, "provider not set")
 )
+
+// ModelNotPulledError reports that the engine has no local copy of Model and
+// it has to be pulled before it can serve requests. It matches
+// ErrModelNotPulled with errors.Is.
+type ModelNotPulledError struct {
+	Model string
+}
+
+func (e *ModelNotPulledError) Error() string {
+	return fmt.Sprintf("model %s not pulled", e.Model)
+}
+
+func (e *ModelNotPulledError) Unwrap() error {
+	return ErrModelNotPulled
+}
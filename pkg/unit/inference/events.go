@@ -63,6 +63,47 @@ func NewRequestCompletedEvent(requestID string, duration time.Duration, tokens i
 	}
 }
 
+// RequestMetrics describes a finished inference request.
+type RequestMetrics struct {
+	RequestID    string
+	Model        string
+	Principal    string
+	FinishReason string
+	Duration     time.Duration
+	// TimeToFirstToken is how long a streamed request took to produce its
+	// first content; zero for requests that were not streamed.
+	TimeToFirstToken time.Duration
+	Usage            Usage
+}
+
+// NewRequestCompletedMetricsEvent reports a completed request with the model,
+// token usage and timing that billing and metrics subscribers consume.
+// Principal identifies the caller and may be empty. ttft_ms is included only
+// for streamed requests.
+func NewRequestCompletedMetricsEvent(correlationID string, metrics RequestMetrics) *RequestCompletedEvent {
+	payload := map[string]any{
+		"request_id":        metrics.RequestID,
+		"correlation_id":    correlationID,
+		"model":             metrics.Model,
+		"principal":         metrics.Principal,
+		"finish_reason":     metrics.FinishReason,
+		"duration_ms":       metrics.Duration.Milliseconds(),
+		"prompt_tokens":     metrics.Usage.PromptTokens,
+		"completion_tokens": metrics.Usage.CompletionTokens,
+		"total_tokens":      metrics.Usage.TotalTokens,
+	}
+	if metrics.TimeToFirstToken > 0 {
+		payload["ttft_ms"] = metrics.TimeToFirstToken.Milliseconds()
+	}
+	return &RequestCompletedEvent{
+		eventType:     EventTypeRequestCompleted,
+		domain:        "inference",
+		payload:       payload,
+		timestamp:     time.Now(),
+		correlationID: correlationID,
+	}
+}
+
 func (e *RequestCompletedEvent) Type() string          { return e.eventType }
 func (e *RequestCompletedEvent) Domain() string        { return e.domain }
 func (e *RequestCompletedEvent) Payload() any          { return e.payload }
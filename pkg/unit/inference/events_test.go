@@ -78,6 +78,50 @@ func TestNewRequestCompletedEvent(t *testing.T) {
 	var _ unit.Event = evt
 }
 
+func TestNewRequestCompletedMetricsEvent(t *testing.T) {
+	evt := NewRequestCompletedMetricsEvent("corr-1", RequestMetrics{
+		RequestID:    "req-4",
+		Model:        "llama3",
+		Principal:    "team-a",
+		FinishReason: "stop",
+		Duration:     250 * time.Millisecond,
+		Usage:        Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+	})
+
+	if evt.Type() != EventTypeRequestCompleted {
+		t.Errorf("Type() = %q, want %q", evt.Type(), EventTypeRequestCompleted)
+	}
+	if evt.CorrelationID() != "corr-1" {
+		t.Errorf("CorrelationID() = %q, want 'corr-1'", evt.CorrelationID())
+	}
+
+	payload := evt.Payload().(map[string]any)
+	want := map[string]any{
+		"request_id":        "req-4",
+		"correlation_id":    "corr-1",
+		"model":             "llama3",
+		"principal":         "team-a",
+		"finish_reason":     "stop",
+		"duration_ms":       int64(250),
+		"prompt_tokens":     10,
+		"completion_tokens": 5,
+		"total_tokens":      15,
+	}
+	for key, value := range want {
+		if payload[key] != value {
+			t.Errorf("payload[%s] = %v, want %v", key, payload[key], value)
+		}
+	}
+	if _, ok := payload["ttft_ms"]; ok {
+		t.Error("expected no ttft_ms for a request that was not streamed")
+	}
+
+	streamed := NewRequestCompletedMetricsEvent("corr-2", RequestMetrics{Duration: time.Second, TimeToFirstToken: 80 * time.Millisecond})
+	if got := streamed.Payload().(map[string]any)["ttft_ms"]; got != int64(80) {
+		t.Errorf("payload[ttft_ms] = %v, want 80", got)
+	}
+}
+
 func TestNewRequestFailedEvent(t *testing.T) {
 	evt := NewRequestFailedEvent("req-3", "context deadline exceeded")
 
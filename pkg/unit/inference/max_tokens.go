@@ -0,0 +1,48 @@
+package inference
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// MaxTokensCap limits the max_tokens of chat and completion requests on the
+// server side, independent of the engine's own limit. A zero cap means no
+// limit.
+type MaxTokensCap struct {
+	// Default applies to models without an entry in Models.
+	Default int
+	// Models maps a model name, or a prefix such as "llama3", to its cap.
+	Models map[string]int
+}
+
+// Limit returns the cap for model: an exact entry, else the longest matching
+// prefix, else Default.
+func (c MaxTokensCap) Limit(model string) int {
+	if limit, ok := c.Models[model]; ok {
+		return limit
+	}
+	best, bestLen := c.Default, 0
+	for prefix, limit := range c.Models {
+		if len(prefix) > bestLen && strings.HasPrefix(model, prefix) {
+			best, bestLen = limit, len(prefix)
+		}
+	}
+	return best
+}
+
+// clamp returns requested lowered to the cap for model. A request without
+// max_tokens gets the cap, so the engine's default cannot exceed it.
+func (c MaxTokensCap) clamp(model string, requested *int) *int {
+	limit := c.Limit(model)
+	if limit <= 0 {
+		return requested
+	}
+	if requested == nil {
+		return &limit
+	}
+	if *requested > limit {
+		slog.Info("max_tokens clamped to server cap", "model", model, "requested", *requested, "cap", limit)
+		return &limit
+	}
+	return requested
+}
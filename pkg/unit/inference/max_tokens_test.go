@@ -0,0 +1,59 @@
+package inference
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMaxTokensCap_Limit(t *testing.T) {
+	limits := MaxTokensCap{Default: 2048, Models: map[string]int{"llama3": 4096, "llama3:70b": 1024}}
+
+	tests := []struct {
+		model string
+		want  int
+	}{
+		{"llama3:70b", 1024},
+		{"llama3:8b", 4096},
+		{"qwen2.5", 2048},
+	}
+	for _, tt := range tests {
+		if got := limits.Limit(tt.model); got != tt.want {
+			t.Errorf("Limit(%q) = %d, want %d", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestChatCommand_Execute_MaxTokensCap(t *testing.T) {
+	provider := NewMockProvider()
+	cmd := NewChatCommand(provider).WithMaxTokensCap(MaxTokensCap{Default: 1000})
+	messages := []any{map[string]any{"role": "user", "content": "Hi"}}
+
+	tests := []struct {
+		name  string
+		input map[string]any
+		want  int
+	}{
+		{"above cap is clamped", map[string]any{"model": "llama3", "messages": messages, "max_tokens": 100000}, 1000},
+		{"below cap is untouched", map[string]any{"model": "llama3", "messages": messages, "max_tokens": 200}, 200},
+		{"missing gets the cap", map[string]any{"model": "llama3", "messages": messages}, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := cmd.Execute(context.Background(), tt.input); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			got := provider.LastChatRequest().Options.MaxTokens
+			if got == nil || *got != tt.want {
+				t.Errorf("expected max_tokens %d, got %v", tt.want, got)
+			}
+		})
+	}
+
+	uncapped := NewChatCommand(provider)
+	if _, err := uncapped.Execute(context.Background(), map[string]any{"model": "llama3", "messages": messages, "max_tokens": 100000}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := provider.LastChatRequest().Options.MaxTokens; got == nil || *got != 100000 {
+		t.Errorf("expected no cap by default, got %v", got)
+	}
+}
@@ -2,6 +2,7 @@ package inference
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,6 +28,12 @@ type InferenceProvider interface {
 	CompleteStream(ctx context.Context, model string, prompt string, opts CompleteOptions, stream chan<- CompleteStreamChunk) error
 }
 
+// Pinger is implemented by providers that can check whether the backend they
+// talk to is reachable. Ping returns the backend version when it reports one.
+type Pinger interface {
+	Ping(ctx context.Context) (version string, err error)
+}
+
 type ChatOptions struct {
 	Temperature      *float64
 	MaxTokens        *int
@@ -36,6 +43,9 @@ type ChatOptions struct {
 	PresencePenalty  *float64
 	Stop             []string
 	Stream           bool
+	// SessionID identifies a conversation. Providers with several replicas
+	// send every turn of a session to the same one so its KV cache is reused.
+	SessionID string
 }
 
 type CompleteOptions struct {
@@ -64,7 +74,25 @@ type VideoOptions struct {
 	Seed     *int64
 }
 
+// ChatRequest records the arguments of a Chat or ChatStream call received by
+// MockProvider.
+type ChatRequest struct {
+	Model    string
+	Messages []Message
+	Options  ChatOptions
+}
+
+// MockProvider returns canned results. Chat behavior can be scripted with
+// SetChatResponse, SetChatError, SetChatStreamChunks and SetChatStreamError;
+// unscripted calls keep the canned behavior.
 type MockProvider struct {
+	mu               sync.Mutex
+	chatResponse     *ChatResponse
+	chatStreamChunks []ChatStreamChunk
+	chatStreamErr    error
+	lastChatRequest  *ChatRequest
+	chatCalls        int
+
 	chatErr       error
 	completeErr   error
 	embedErr      error
@@ -82,9 +110,71 @@ func NewMockProvider() *MockProvider {
 	return &MockProvider{}
 }
 
+// SetChatResponse makes Chat return a copy of resp.
+func (m *MockProvider) SetChatResponse(resp *ChatResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chatResponse = resp
+}
+
+// SetChatError makes Chat and ChatStream fail with err; nil clears it.
+func (m *MockProvider) SetChatError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chatErr = err
+}
+
+// SetChatStreamChunks makes ChatStream send exactly chunks, in order.
+func (m *MockProvider) SetChatStreamChunks(chunks []ChatStreamChunk) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chatStreamChunks = chunks
+}
+
+// SetChatStreamError makes ChatStream fail with err after sending the chunks
+// set with SetChatStreamChunks, as a provider that breaks mid-stream would.
+func (m *MockProvider) SetChatStreamError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chatStreamErr = err
+}
+
+// LastChatRequest returns the most recent Chat or ChatStream call, or nil if
+// there has been none.
+func (m *MockProvider) LastChatRequest() *ChatRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastChatRequest
+}
+
+// ChatCalls returns how many Chat and ChatStream calls were received.
+func (m *MockProvider) ChatCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.chatCalls
+}
+
+// recordChat stores the call and returns the scripted chat behavior.
+func (m *MockProvider) recordChat(model string, messages []Message, opts ChatOptions) (*ChatResponse, []ChatStreamChunk, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chatCalls++
+	m.lastChatRequest = &ChatRequest{
+		Model:    model,
+		Messages: append([]Message(nil), messages...),
+		Options:  opts,
+	}
+	return m.chatResponse, m.chatStreamChunks, m.chatErr
+}
+
 func (m *MockProvider) Chat(ctx context.Context, model string, messages []Message, opts ChatOptions) (*ChatResponse, error) {
-	if m.chatErr != nil {
-		return nil, m.chatErr
+	scripted, _, chatErr := m.recordChat(model, messages, opts)
+	if chatErr != nil {
+		return nil, chatErr
+	}
+	if scripted != nil {
+		resp := *scripted
+		return &resp, nil
 	}
 
 	promptTokens := 0
@@ -302,8 +392,21 @@ func (m *MockProvider) ListVoices(ctx context.Context, model string) ([]Voice, e
 
 // ChatStream streams chat completion results through the channel
 func (m *MockProvider) ChatStream(ctx context.Context, model string, messages []Message, opts ChatOptions, stream chan<- ChatStreamChunk) error {
-	if m.chatErr != nil {
-		return m.chatErr
+	_, scripted, chatErr := m.recordChat(model, messages, opts)
+	if chatErr != nil {
+		return chatErr
+	}
+	if scripted != nil {
+		for _, chunk := range scripted {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case stream <- chunk:
+			}
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.chatStreamErr
 	}
 
 	promptTokens := 0
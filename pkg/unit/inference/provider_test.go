@@ -0,0 +1,120 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+func TestMockProvider_ScriptedChatResponse(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatResponse(&ChatResponse{Content: "scripted", FinishReason: "length", Model: "llama3"})
+	cmd := NewChatCommand(provider)
+
+	result, err := cmd.Execute(context.Background(), map[string]any{
+		"model":       "llama3",
+		"messages":    []any{map[string]any{"role": "user", "content": "Hi"}},
+		"temperature": 0.2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := result.(map[string]any)
+	if out["content"] != "scripted" || out["finish_reason"] != "length" {
+		t.Errorf("expected scripted response, got %v", out)
+	}
+
+	req := provider.LastChatRequest()
+	if req == nil {
+		t.Fatal("expected recorded chat request")
+	}
+	if req.Model != "llama3" || len(req.Messages) != 1 || req.Messages[0].Content != "Hi" {
+		t.Errorf("unexpected recorded request: %+v", req)
+	}
+	if req.Options.Temperature == nil || *req.Options.Temperature != 0.2 {
+		t.Errorf("expected temperature 0.2 to reach the provider, got %v", req.Options.Temperature)
+	}
+	if provider.ChatCalls() != 1 {
+		t.Errorf("expected 1 chat call, got %d", provider.ChatCalls())
+	}
+}
+
+func TestMockProvider_ScriptedChatError(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  error
+		wantErr bool
+	}{
+		{name: "engine failure", script: ErrInferenceEngineError, wantErr: true},
+		{name: "rate limited", script: ErrInferenceRateLimited, wantErr: true},
+		{name: "cleared error", script: nil, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewMockProvider()
+			provider.SetChatError(errors.New("stale"))
+			provider.SetChatError(tt.script)
+
+			_, err := NewChatCommand(provider).Execute(context.Background(), map[string]any{
+				"model":    "llama3",
+				"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, tt.script) {
+				t.Errorf("expected %v in error chain, got %v", tt.script, err)
+			}
+		})
+	}
+}
+
+func TestMockProvider_ScriptedChatStream(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatStreamChunks([]ChatStreamChunk{
+		{Content: "Hel", Model: "llama3"},
+		{Content: "lo", Model: "llama3"},
+		{FinishReason: "stop", Model: "llama3"},
+	})
+	cmd := NewChatCommand(provider)
+
+	stream := make(chan unit.StreamChunk, 10)
+	err := cmd.ExecuteStream(context.Background(), map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Say hello"}},
+	}, stream)
+	close(stream)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	var content string
+	for chunk := range stream {
+		if s, ok := chunk.Data.(string); ok {
+			content += s
+		}
+	}
+	if content != "Hello" {
+		t.Errorf("expected streamed content %q, got %q", "Hello", content)
+	}
+	if req := provider.LastChatRequest(); req == nil || req.Messages[0].Content != "Say hello" {
+		t.Errorf("unexpected recorded request: %+v", req)
+	}
+}
+
+func TestMockProvider_ScriptedChatStreamError(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatError(ErrInferenceTimeout)
+
+	stream := make(chan unit.StreamChunk, 10)
+	err := NewChatCommand(provider).ExecuteStream(context.Background(), map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+	}, stream)
+	if !errors.Is(err, ErrInferenceTimeout) {
+		t.Errorf("expected ErrInferenceTimeout, got %v", err)
+	}
+}
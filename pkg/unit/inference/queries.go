@@ -3,6 +3,8 @@ package inference
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 )
@@ -219,3 +221,107 @@ func (q *VoicesQuery) Execute(ctx context.Context, input any) (any, error) {
 	ec.PublishCompleted(output)
 	return output, nil
 }
+
+// HealthQuery reports whether each configured provider can reach its backend.
+// It lives with the inference units but answers as provider.health, since it
+// covers every provider that implements Pinger, not only inference ones.
+type HealthQuery struct {
+	providers map[string]Pinger
+	events    unit.EventPublisher
+}
+
+// NewHealthQuery checks providers, keyed by the name reported in the output.
+func NewHealthQuery(providers map[string]Pinger) *HealthQuery {
+	return &HealthQuery{providers: providers}
+}
+
+func NewHealthQueryWithEvents(providers map[string]Pinger, events unit.EventPublisher) *HealthQuery {
+	return &HealthQuery{providers: providers, events: events}
+}
+
+func (q *HealthQuery) Name() string {
+	return "provider.health"
+}
+
+func (q *HealthQuery) Domain() string {
+	return "provider"
+}
+
+func (q *HealthQuery) Description() string {
+	return "Check reachability and version of each registered provider"
+}
+
+func (q *HealthQuery) InputSchema() unit.Schema {
+	return unit.Schema{Type: "object"}
+}
+
+func (q *HealthQuery) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"healthy": {Name: "healthy", Schema: unit.Schema{Type: "boolean", Description: "Whether every provider is reachable"}},
+			"providers": {
+				Name: "providers",
+				Schema: unit.Schema{
+					Type: "array",
+					Items: &unit.Schema{
+						Type: "object",
+						Properties: map[string]unit.Field{
+							"name":       {Name: "name", Schema: unit.Schema{Type: "string"}},
+							"reachable":  {Name: "reachable", Schema: unit.Schema{Type: "boolean"}},
+							"version":    {Name: "version", Schema: unit.Schema{Type: "string"}},
+							"latency_ms": {Name: "latency_ms", Schema: unit.Schema{Type: "number"}},
+							"error":      {Name: "error", Schema: unit.Schema{Type: "string"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (q *HealthQuery) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input: map[string]any{},
+			Output: map[string]any{
+				"healthy":   true,
+				"providers": []map[string]any{{"name": "inference", "reachable": true, "version": "0.5.7", "latency_ms": 3}},
+			},
+			Description: "Check provider health",
+		},
+	}
+}
+
+func (q *HealthQuery) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(q.events, q.Domain(), q.Name())
+	ec.PublishStarted(input)
+
+	names := make([]string, 0, len(q.providers))
+	for name := range q.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	healthy := true
+	items := make([]map[string]any, 0, len(names))
+	for _, name := range names {
+		start := time.Now()
+		version, err := q.providers[name].Ping(ctx)
+		item := map[string]any{
+			"name":       name,
+			"reachable":  err == nil,
+			"version":    version,
+			"latency_ms": time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			healthy = false
+			item["error"] = err.Error()
+		}
+		items = append(items, item)
+	}
+
+	output := map[string]any{"healthy": healthy, "providers": items}
+	ec.PublishCompleted(output)
+	return output, nil
+}
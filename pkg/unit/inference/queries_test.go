@@ -209,4 +209,50 @@ func TestQuery_Examples(t *testing.T) {
 func TestQueryImplementsInterface(t *testing.T) {
 	var _ unit.Query = NewModelsQuery(nil)
 	var _ unit.Query = NewVoicesQuery(nil)
+	var _ unit.Query = NewHealthQuery(nil)
+}
+
+type fakePinger struct {
+	version string
+	err     error
+}
+
+func (f fakePinger) Ping(ctx context.Context) (string, error) {
+	return f.version, f.err
+}
+
+func TestHealthQuery_Execute(t *testing.T) {
+	q := NewHealthQuery(map[string]Pinger{
+		"inference": fakePinger{version: "0.5.7"},
+		"engine":    fakePinger{err: errors.New("connection refused")},
+	})
+	if q.Name() != "provider.health" {
+		t.Errorf("expected name provider.health, got %s", q.Name())
+	}
+
+	result, err := q.Execute(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	out := result.(map[string]any)
+	if out["healthy"] != false {
+		t.Error("expected healthy=false when a provider is down")
+	}
+
+	items := out["providers"].([]map[string]any)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 providers, got %d", len(items))
+	}
+	engine, inf := items[0], items[1]
+	if engine["name"] != "engine" || engine["reachable"] != false || engine["error"] != "connection refused" {
+		t.Errorf("unexpected engine entry: %v", engine)
+	}
+	if inf["name"] != "inference" || inf["reachable"] != true || inf["version"] != "0.5.7" {
+		t.Errorf("unexpected inference entry: %v", inf)
+	}
+
+	result, _ = NewHealthQuery(nil).Execute(context.Background(), nil)
+	if result.(map[string]any)["healthy"] != true {
+		t.Error("expected no providers to count as healthy")
+	}
 }
@@ -0,0 +1,80 @@
+package inference
+
+import "strings"
+
+// trimAtStop cuts content at the earliest occurrence of any stop sequence,
+// excluding the sequence itself, and reports whether it found one.
+func trimAtStop(content string, stop []string) (string, bool) {
+	cut := -1
+	for _, s := range stop {
+		if s == "" {
+			continue
+		}
+		if i := strings.Index(content, s); i >= 0 && (cut < 0 || i < cut) {
+			cut = i
+		}
+	}
+	if cut < 0 {
+		return content, false
+	}
+	return content[:cut], true
+}
+
+// stopTrimmer applies stop sequences to streamed content. A stop sequence
+// can span chunks, so the tail of a chunk that could start one is held back
+// until the next chunk shows whether it does.
+type stopTrimmer struct {
+	stop    []string
+	pending string
+	stopped bool
+}
+
+// push returns the part of text that is safe to emit and whether a stop
+// sequence has been reached; after that every push returns nothing.
+func (t *stopTrimmer) push(text string) (string, bool) {
+	if t.stopped {
+		return "", true
+	}
+	buf := t.pending + text
+	if cut, ok := trimAtStop(buf, t.stop); ok {
+		t.pending, t.stopped = "", true
+		return cut, true
+	}
+	hold := 0
+	for _, s := range t.stop {
+		for n := min(len(s)-1, len(buf)); n > hold; n-- {
+			if strings.HasSuffix(buf, s[:n]) {
+				hold = n
+				break
+			}
+		}
+	}
+	t.pending = buf[len(buf)-hold:]
+	return buf[:len(buf)-hold], false
+}
+
+// flush returns the content still held back when the stream ends. It is
+// safe to call on a nil trimmer.
+func (t *stopTrimmer) flush() string {
+	if t == nil {
+		return ""
+	}
+	rest := t.pending
+	t.pending = ""
+	return rest
+}
+
+// chunk trims the content of one streamed chunk and returns the content to
+// forward with the chunk's finish reason, which becomes "stop" once a stop
+// sequence is reached. Held-back content is released with the chunk that
+// carries a finish reason.
+func (t *stopTrimmer) chunk(content, finishReason string) (string, string) {
+	out, stopped := t.push(content)
+	if stopped {
+		return out, "stop"
+	}
+	if finishReason != "" {
+		out += t.flush()
+	}
+	return out, finishReason
+}
@@ -0,0 +1,138 @@
+package inference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+func TestTrimAtStop(t *testing.T) {
+	tests := []struct {
+		content string
+		stop    []string
+		want    string
+		cut     bool
+	}{
+		{"Hello\nUser: hi", []string{"\nUser:"}, "Hello", true},
+		{"one two three", []string{"three", "two"}, "one ", true},
+		{"no stop here", []string{"###"}, "no stop here", false},
+		{"empty stop is ignored", []string{""}, "empty stop is ignored", false},
+		{"###", []string{"###"}, "", true},
+	}
+	for _, tt := range tests {
+		got, cut := trimAtStop(tt.content, tt.stop)
+		if got != tt.want || cut != tt.cut {
+			t.Errorf("trimAtStop(%q, %q) = %q, %v; want %q, %v", tt.content, tt.stop, got, cut, tt.want, tt.cut)
+		}
+	}
+}
+
+func TestStopTrimmer_SpansChunks(t *testing.T) {
+	tr := &stopTrimmer{stop: []string{"</s>"}}
+	var out string
+	for _, chunk := range []string{"Hello <", "/", "s> tail"} {
+		text, stopped := tr.push(chunk)
+		out += text
+		if stopped {
+			break
+		}
+	}
+	if out != "Hello " || !tr.stopped {
+		t.Errorf("got %q (stopped=%v), want %q", out, tr.stopped, "Hello ")
+	}
+
+	tr = &stopTrimmer{stop: []string{"</s>"}}
+	out, _ = tr.push("a <")
+	more, _ := tr.push("b")
+	if out+more != "a <b" || tr.flush() != "" {
+		t.Errorf("expected a false stop prefix to be released, got %q", out+more)
+	}
+
+	tr = &stopTrimmer{stop: []string{"</s>"}}
+	out, _ = tr.push("ends with <")
+	if out+tr.flush() != "ends with <" {
+		t.Errorf("expected held-back content to be flushed at the end")
+	}
+}
+
+func TestChatCommand_Execute_StopTrimming(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatResponse(&ChatResponse{Content: "The answer is 42.\nUser: and then?", FinishReason: "length"})
+	input := map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+		"stop":     []any{"\nUser:"},
+	}
+
+	result, err := NewChatCommand(provider).WithStopTrimming(true).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	out := result.(map[string]any)
+	if out["content"] != "The answer is 42." || out["finish_reason"] != "stop" {
+		t.Errorf("expected output trimmed at the stop sequence, got %q (%v)", out["content"], out["finish_reason"])
+	}
+
+	result, err = NewChatCommand(provider).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if out := result.(map[string]any); out["content"] != "The answer is 42.\nUser: and then?" {
+		t.Errorf("expected no trimming by default, got %q", out["content"])
+	}
+}
+
+func TestChatCommand_ExecuteStream_StopTrimming(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatStreamChunks([]ChatStreamChunk{
+		{Model: "llama3", Content: "The answer"},
+		{Model: "llama3", Content: " is 42.\nUs"},
+		{Model: "llama3", Content: "er: and then?"},
+		{Model: "llama3", Content: " more", FinishReason: "length"},
+	})
+	input := map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+		"stop":     []any{"\nUser:"},
+	}
+
+	result, err := CollectStream(context.Background(), NewChatCommand(provider).WithStopTrimming(true), input)
+	if err != nil {
+		t.Fatalf("CollectStream: %v", err)
+	}
+	if result.Content != "The answer is 42." || result.FinishReason != "stop" {
+		t.Errorf("expected the stream trimmed at the stop sequence, got %q (%s)", result.Content, result.FinishReason)
+	}
+	if stop := provider.LastChatRequest().Options.Stop; len(stop) != 1 || stop[0] != "\nUser:" {
+		t.Errorf("expected the stop sequences to reach the provider, got %q", stop)
+	}
+}
+
+func TestCompleteCommand_StopTrimming(t *testing.T) {
+	cmd := NewCompleteCommand(NewMockProvider()).WithStopTrimming(true)
+	input := map[string]any{"model": "llama3", "prompt": "Once", "stop": []any{" mock"}}
+
+	result, err := cmd.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if out := result.(map[string]any); out["text"] != "This is a" || out["finish_reason"] != "stop" {
+		t.Errorf("expected text trimmed at the stop sequence, got %q (%v)", out["text"], out["finish_reason"])
+	}
+
+	stream := make(chan unit.StreamChunk, 16)
+	if err := cmd.ExecuteStream(context.Background(), input, stream); err != nil {
+		t.Fatalf("ExecuteStream: %v", err)
+	}
+	close(stream)
+	var text string
+	for chunk := range stream {
+		if chunk.Type == "content" {
+			text += chunk.Data.(string)
+		}
+	}
+	if text != "This is a" {
+		t.Errorf("expected streamed text trimmed at the stop sequence, got %q", text)
+	}
+}
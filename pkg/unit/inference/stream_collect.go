@@ -0,0 +1,63 @@
+package inference
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// StreamResult is a streamed chat or completion buffered into one response.
+type StreamResult struct {
+	ID           string `json:"id,omitempty"`
+	Model        string `json:"model,omitempty"`
+	Content      string `json:"content"`
+	FinishReason string `json:"finish_reason,omitempty"`
+	Usage        Usage  `json:"usage"`
+}
+
+// CollectStream runs cmd.ExecuteStream, such as that of inference.chat or
+// inference.complete, and concatenates the content chunks into a single
+// result for callers that want the streaming path, and with it the ability
+// to stop generation by cancelling ctx, without handling chunks themselves.
+// When the stream fails or ctx is cancelled, the error is returned together
+// with whatever content arrived before it.
+func CollectStream(ctx context.Context, cmd unit.StreamingCommand, input any) (*StreamResult, error) {
+	stream := make(chan unit.StreamChunk, 16)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(stream)
+		errCh <- cmd.ExecuteStream(ctx, input, stream)
+	}()
+
+	result := &StreamResult{}
+	var content strings.Builder
+	for chunk := range stream {
+		switch chunk.Type {
+		case "content":
+			text, _ := chunk.Data.(string)
+			content.WriteString(text)
+			meta, _ := chunk.Metadata.(map[string]any)
+			if v, _ := meta["id"].(string); v != "" {
+				result.ID = v
+			}
+			if v, _ := meta["model"].(string); v != "" {
+				result.Model = v
+			}
+			if v, _ := meta["finish_reason"].(string); v != "" {
+				result.FinishReason = v
+			}
+		case "usage":
+			data, _ := chunk.Data.(map[string]any)
+			result.Usage.PromptTokens, _ = toInt(data["prompt_tokens"])
+			result.Usage.CompletionTokens, _ = toInt(data["completion_tokens"])
+			result.Usage.TotalTokens, _ = toInt(data["total_tokens"])
+		}
+	}
+	result.Content = content.String()
+
+	if err := <-errCh; err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -2,6 +2,7 @@ package inference
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -41,9 +42,15 @@ func TestChatCommand_ExecuteStream(t *testing.T) {
 		}
 	}()
 
-	var receivedChunks int
+	var chunks []unit.StreamChunk
 	for chunk := range stream {
-		receivedChunks++
+		chunks = append(chunks, chunk)
+	}
+
+	if len(chunks) < 2 {
+		t.Fatalf("expected content chunks and a usage chunk, got %d chunks", len(chunks))
+	}
+	for _, chunk := range chunks[:len(chunks)-1] {
 		if chunk.Type != "content" {
 			t.Errorf("expected type 'content', got %s", chunk.Type)
 		}
@@ -51,10 +58,7 @@ func TestChatCommand_ExecuteStream(t *testing.T) {
 			t.Error("expected non-nil data")
 		}
 	}
-
-	if receivedChunks == 0 {
-		t.Error("expected to receive chunks, got none")
-	}
+	assertUsageChunk(t, chunks[len(chunks)-1])
 }
 
 func TestChatCommand_ExecuteStream_NilProvider(t *testing.T) {
@@ -139,9 +143,15 @@ func TestCompleteCommand_ExecuteStream(t *testing.T) {
 		}
 	}()
 
-	var receivedChunks int
+	var chunks []unit.StreamChunk
 	for chunk := range stream {
-		receivedChunks++
+		chunks = append(chunks, chunk)
+	}
+
+	if len(chunks) < 2 {
+		t.Fatalf("expected content chunks and a usage chunk, got %d chunks", len(chunks))
+	}
+	for _, chunk := range chunks[:len(chunks)-1] {
 		if chunk.Type != "content" {
 			t.Errorf("expected type 'content', got %s", chunk.Type)
 		}
@@ -149,10 +159,7 @@ func TestCompleteCommand_ExecuteStream(t *testing.T) {
 			t.Error("expected non-nil data")
 		}
 	}
-
-	if receivedChunks == 0 {
-		t.Error("expected to receive chunks, got none")
-	}
+	assertUsageChunk(t, chunks[len(chunks)-1])
 }
 
 func TestCompleteCommand_ExecuteStream_NilProvider(t *testing.T) {
@@ -324,3 +331,214 @@ func TestMockProvider_ChatStream_ContextCancel(t *testing.T) {
 		// Drain channel
 	}
 }
+
+// assertUsageChunk checks that chunk is the final usage chunk carrying the
+// mock provider's token counts.
+func assertUsageChunk(t *testing.T, chunk unit.StreamChunk) {
+	t.Helper()
+	if chunk.Type != "usage" {
+		t.Fatalf("expected the last chunk to be 'usage', got %s", chunk.Type)
+	}
+	usage, ok := chunk.Data.(map[string]any)
+	if !ok {
+		t.Fatalf("expected usage data map, got %T", chunk.Data)
+	}
+	prompt, completion, total := usage["prompt_tokens"].(int), usage["completion_tokens"].(int), usage["total_tokens"].(int)
+	if completion == 0 || total != prompt+completion {
+		t.Errorf("unexpected usage: %v", usage)
+	}
+}
+
+func TestCollectStream(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatStreamChunks([]ChatStreamChunk{
+		{ID: "chatcmpl-1", Model: "llama3", Content: "Hel"},
+		{ID: "chatcmpl-1", Model: "llama3", Content: "lo"},
+		{ID: "chatcmpl-1", Model: "llama3", FinishReason: "stop", Usage: &Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}},
+	})
+	input := map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+	}
+
+	result, err := CollectStream(context.Background(), NewChatCommand(provider), input)
+	if err != nil {
+		t.Fatalf("CollectStream failed: %v", err)
+	}
+	if result.Content != "Hello" || result.FinishReason != "stop" || result.Model != "llama3" || result.ID != "chatcmpl-1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Usage != (Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}) {
+		t.Errorf("unexpected usage: %+v", result.Usage)
+	}
+}
+
+func TestCollectStream_Errors(t *testing.T) {
+	input := map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+	}
+
+	provider := NewMockProvider()
+	provider.SetChatError(errors.New("engine exploded"))
+	if _, err := CollectStream(context.Background(), NewChatCommand(provider), input); err == nil || err.Error() != "engine exploded" {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+
+	if _, err := CollectStream(context.Background(), NewChatCommand(NewMockProvider()), map[string]any{}); !errors.Is(err, ErrModelNotSpecified) {
+		t.Errorf("expected ErrModelNotSpecified, got %v", err)
+	}
+}
+
+// stallingStreamProvider streams one chunk, then cancels the request and
+// waits for the cancellation to arrive.
+type stallingStreamProvider struct {
+	*MockProvider
+	cancel context.CancelFunc
+}
+
+func (p *stallingStreamProvider) ChatStream(ctx context.Context, model string, messages []Message, opts ChatOptions, stream chan<- ChatStreamChunk) error {
+	stream <- ChatStreamChunk{Model: model, Content: "partial"}
+	p.cancel()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCollectStream_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	provider := &stallingStreamProvider{MockProvider: NewMockProvider(), cancel: cancel}
+	input := map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+	}
+
+	result, err := CollectStream(ctx, NewChatCommand(provider), input)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if result == nil || (result.Content != "" && result.Content != "partial") {
+		t.Errorf("expected at most the content sent before cancellation, got %+v", result)
+	}
+}
+
+func TestChatCommand_ExecuteStream_ProviderFailsMidStream(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatStreamChunks([]ChatStreamChunk{{Content: "Hel"}, {Content: "lo"}})
+	providerErr := errors.New("connection reset by upstream")
+	provider.SetChatStreamError(providerErr)
+	cmd := NewChatCommand(provider)
+
+	input := map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hello"}},
+	}
+
+	stream := make(chan unit.StreamChunk, 10)
+	err := cmd.ExecuteStream(context.Background(), input, stream)
+	close(stream)
+	if !errors.Is(err, providerErr) {
+		t.Fatalf("expected the provider error, got %v", err)
+	}
+
+	var chunks []unit.StreamChunk
+	for chunk := range stream {
+		chunks = append(chunks, chunk)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("expected two content chunks and an error chunk, got %d: %+v", len(chunks), chunks)
+	}
+	for _, chunk := range chunks[:2] {
+		if chunk.Type != "content" {
+			t.Errorf("expected type 'content', got %s", chunk.Type)
+		}
+	}
+	last := chunks[2]
+	if last.Type != "error" || last.Error == nil {
+		t.Fatalf("expected a final error chunk, got %+v", last)
+	}
+	if last.Error.Code != string(unit.ErrCodeInternalError) || last.Error.Message != providerErr.Error() {
+		t.Errorf("unexpected error chunk: %+v", last.Error)
+	}
+}
+
+func TestChatCommand_ExecuteStream_PublishesRequestCompleted(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatStreamChunks([]ChatStreamChunk{
+		{Content: "Hel"},
+		{Content: "lo", FinishReason: "length", Usage: &Usage{PromptTokens: 7, CompletionTokens: 2, TotalTokens: 9}},
+	})
+	events := &recordingPublisher{}
+	cmd := NewChatCommandWithEvents(provider, events)
+
+	ctx := unit.WithUserID(unit.WithRequestID(context.Background(), "req-7"), "team-a")
+	stream := make(chan unit.StreamChunk, 10)
+	err := cmd.ExecuteStream(ctx, map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hello"}},
+	}, stream)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	payload := events.requestCompleted(t).Payload().(map[string]any)
+	want := map[string]any{
+		"request_id":        "req-7",
+		"model":             "llama3",
+		"principal":         "team-a",
+		"finish_reason":     "length",
+		"prompt_tokens":     7,
+		"completion_tokens": 2,
+		"total_tokens":      9,
+	}
+	for key, value := range want {
+		if payload[key] != value {
+			t.Errorf("payload[%s] = %v, want %v", key, payload[key], value)
+		}
+	}
+	if _, ok := payload["duration_ms"].(int64); !ok {
+		t.Errorf("payload[duration_ms] = %v, want int64", payload["duration_ms"])
+	}
+}
+
+func TestChatCommand_ExecuteStream_FailureSkipsRequestCompleted(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetChatStreamChunks([]ChatStreamChunk{{Content: "Hel"}})
+	provider.SetChatStreamError(errors.New("connection reset by upstream"))
+	events := &recordingPublisher{}
+	cmd := NewChatCommandWithEvents(provider, events)
+
+	stream := make(chan unit.StreamChunk, 10)
+	_ = cmd.ExecuteStream(context.Background(), map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hello"}},
+	}, stream)
+
+	for _, e := range events.events {
+		if _, ok := e.(*RequestCompletedEvent); ok {
+			t.Fatal("failed streams must not publish inference.request_completed")
+		}
+	}
+}
+
+func TestCompleteCommand_ExecuteStream_PublishesRequestCompleted(t *testing.T) {
+	events := &recordingPublisher{}
+	cmd := NewCompleteCommandWithEvents(NewMockProvider(), events)
+
+	stream := make(chan unit.StreamChunk, 10)
+	err := cmd.ExecuteStream(context.Background(), map[string]any{
+		"model":  "llama3",
+		"prompt": "Once upon a time",
+	}, stream)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+
+	payload := events.requestCompleted(t).Payload().(map[string]any)
+	if payload["model"] != "llama3" || payload["finish_reason"] != "stop" {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+	if tokens, _ := payload["total_tokens"].(int); tokens == 0 {
+		t.Errorf("expected the streamed usage, got %v", payload["total_tokens"])
+	}
+}
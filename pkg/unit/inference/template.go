@@ -0,0 +1,108 @@
+package inference
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+// PromptTemplates looks up the prompt template of a model. Models with one
+// are base models without a chat endpoint: their chat requests are rendered
+// into a prompt and served by Complete. An empty template means the model
+// chats normally.
+type PromptTemplates interface {
+	PromptTemplate(ctx context.Context, model string) (string, error)
+}
+
+// RenderPrompt executes a model's prompt template over messages, which the
+// template sees as .messages, each with .role and .content.
+func RenderPrompt(text string, messages []Message) (string, error) {
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse prompt template: %w", err)
+	}
+
+	msgs := make([]map[string]string, len(messages))
+	for i, msg := range messages {
+		msgs[i] = map[string]string{"role": msg.Role, "content": msg.Content}
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, map[string]any{"messages": msgs}); err != nil {
+		return "", fmt.Errorf("render prompt template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// WithPromptTemplates makes chats with models that have a prompt template go
+// through Complete with the rendered prompt, streamed or not.
+func (c *ChatCommand) WithPromptTemplates(templates PromptTemplates) *ChatCommand {
+	c.templates = templates
+	return c
+}
+
+// promptFor returns the rendered prompt for a chat with a templated model,
+// or "" when the model has no template.
+func (c *ChatCommand) promptFor(ctx context.Context, model string, messages []Message) (string, error) {
+	if c.templates == nil {
+		return "", nil
+	}
+	text, err := c.templates.PromptTemplate(ctx, model)
+	if err != nil {
+		return "", fmt.Errorf("look up prompt template for %s: %w", model, err)
+	}
+	if text == "" {
+		return "", nil
+	}
+	prompt, err := RenderPrompt(text, messages)
+	if err != nil {
+		return "", fmt.Errorf("model %s: %w", model, err)
+	}
+	return prompt, nil
+}
+
+// completeChat serves a chat with a templated model by completing prompt,
+// returning the completion as the assistant reply.
+func (c *ChatCommand) completeChat(ctx context.Context, model, prompt string, opts ChatOptions) (*ChatResponse, error) {
+	resp, err := c.provider.Complete(ctx, model, prompt, CompleteOptions{
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+		TopP:        opts.TopP,
+		Stop:        opts.Stop,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ChatResponse{
+		Model:        model,
+		Content:      resp.Text,
+		FinishReason: resp.FinishReason,
+		Usage:        resp.Usage,
+	}, nil
+}
+
+// streamTemplatedChat streams a chat with a templated model as the
+// completion of prompt, with the same limits and stop handling.
+func (c *ChatCommand) streamTemplatedChat(ctx context.Context, model, prompt string, opts ChatOptions, stream chan<- unit.StreamChunk) error {
+	input := map[string]any{"model": model, "prompt": prompt}
+	if opts.Temperature != nil {
+		input["temperature"] = *opts.Temperature
+	}
+	if opts.MaxTokens != nil {
+		input["max_tokens"] = *opts.MaxTokens
+	}
+	if len(opts.Stop) > 0 {
+		stop := make([]any, len(opts.Stop))
+		for i, s := range opts.Stop {
+			stop[i] = s
+		}
+		input["stop"] = stop
+	}
+	complete := NewCompleteCommandWithEvents(c.provider, c.events).
+		WithMaxTokensCap(c.maxTokens).
+		WithStopTrimming(c.trimStop)
+	return complete.ExecuteStream(ctx, input, stream)
+}
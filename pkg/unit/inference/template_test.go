@@ -0,0 +1,160 @@
+package inference
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+)
+
+const chatMLTemplate = `{{range .messages}}<|im_start|>{{.role}}
+{{.content}}<|im_end|>
+{{end}}<|im_start|>assistant
+`
+
+// staticTemplates gives each listed model its prompt template.
+type staticTemplates map[string]string
+
+func (t staticTemplates) PromptTemplate(ctx context.Context, model string) (string, error) {
+	return t[model], nil
+}
+
+// promptRecordingProvider records the prompts sent to Complete and whether
+// Chat was called.
+type promptRecordingProvider struct {
+	*MockProvider
+	prompt   string
+	chatCall bool
+}
+
+func (p *promptRecordingProvider) Complete(ctx context.Context, modelName, prompt string, opts CompleteOptions) (*CompletionResponse, error) {
+	p.prompt = prompt
+	return p.MockProvider.Complete(ctx, modelName, prompt, opts)
+}
+
+func (p *promptRecordingProvider) CompleteStream(ctx context.Context, modelName, prompt string, opts CompleteOptions, stream chan<- CompleteStreamChunk) error {
+	p.prompt = prompt
+	return p.MockProvider.CompleteStream(ctx, modelName, prompt, opts, stream)
+}
+
+func (p *promptRecordingProvider) Chat(ctx context.Context, modelName string, messages []Message, opts ChatOptions) (*ChatResponse, error) {
+	p.chatCall = true
+	return p.MockProvider.Chat(ctx, modelName, messages, opts)
+}
+
+func (p *promptRecordingProvider) ChatStream(ctx context.Context, modelName string, messages []Message, opts ChatOptions, stream chan<- ChatStreamChunk) error {
+	p.chatCall = true
+	return p.MockProvider.ChatStream(ctx, modelName, messages, opts, stream)
+}
+
+func TestRenderPrompt_ChatML(t *testing.T) {
+	prompt, err := RenderPrompt(chatMLTemplate, []Message{
+		{Role: "system", Content: "You are helpful."},
+		{Role: "user", Content: "Hi"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "<|im_start|>system\nYou are helpful.<|im_end|>\n<|im_start|>user\nHi<|im_end|>\n<|im_start|>assistant\n"
+	if prompt != want {
+		t.Errorf("prompt = %q, want %q", prompt, want)
+	}
+}
+
+func TestRenderPrompt_Errors(t *testing.T) {
+	if _, err := RenderPrompt("{{range .messages}", nil); err == nil {
+		t.Error("expected a parse error")
+	}
+	if _, err := RenderPrompt("{{.prompt}}", nil); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+}
+
+func TestChatCommand_Execute_PromptTemplate(t *testing.T) {
+	provider := &promptRecordingProvider{MockProvider: NewMockProvider()}
+	cmd := NewChatCommand(provider).WithPromptTemplates(staticTemplates{"base-model": chatMLTemplate})
+
+	result, err := cmd.Execute(context.Background(), map[string]any{
+		"model":    "base-model",
+		"messages": []any{map[string]any{"role": "user", "content": "Hello"}},
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if provider.chatCall {
+		t.Error("expected a templated model to be served through Complete")
+	}
+	if !strings.HasPrefix(provider.prompt, "<|im_start|>user\nHello<|im_end|>") {
+		t.Errorf("expected the rendered ChatML prompt, got %q", provider.prompt)
+	}
+	output := result.(map[string]any)
+	if output["content"] != "This is a mock completion response." || output["finish_reason"] != "stop" {
+		t.Errorf("expected the completion as the reply, got %+v", output)
+	}
+
+	// Models without a template chat as before.
+	provider.prompt = ""
+	if _, err := cmd.Execute(context.Background(), map[string]any{
+		"model":    "llama3",
+		"messages": []any{map[string]any{"role": "user", "content": "Hello"}},
+	}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !provider.chatCall || provider.prompt != "" {
+		t.Error("expected an untemplated model to be served through Chat")
+	}
+}
+
+func TestChatCommand_ExecuteStream_PromptTemplate(t *testing.T) {
+	provider := &promptRecordingProvider{MockProvider: NewMockProvider()}
+	events := &recordingPublisher{}
+	cmd := NewChatCommandWithEvents(provider, events).WithPromptTemplates(staticTemplates{"base-model": chatMLTemplate})
+
+	stream := make(chan unit.StreamChunk, 20)
+	err := cmd.ExecuteStream(context.Background(), map[string]any{
+		"model":    "base-model",
+		"messages": []any{map[string]any{"role": "user", "content": "Hello"}},
+	}, stream)
+	close(stream)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+	if provider.chatCall {
+		t.Error("expected a templated model to be streamed through CompleteStream")
+	}
+	if !strings.HasPrefix(provider.prompt, "<|im_start|>user\nHello<|im_end|>") {
+		t.Errorf("expected the rendered ChatML prompt, got %q", provider.prompt)
+	}
+
+	var content strings.Builder
+	var chunks []unit.StreamChunk
+	for chunk := range stream {
+		chunks = append(chunks, chunk)
+		if s, ok := chunk.Data.(string); ok && chunk.Type == "content" {
+			content.WriteString(s)
+		}
+	}
+	if content.String() != "This is a mock completion response." {
+		t.Errorf("expected the streamed completion as the reply, got %q", content.String())
+	}
+	assertUsageChunk(t, chunks[len(chunks)-1])
+	events.requestCompleted(t)
+}
+
+func TestChatCommand_PromptTemplateRenderError(t *testing.T) {
+	provider := &promptRecordingProvider{MockProvider: NewMockProvider()}
+	cmd := NewChatCommand(provider).WithPromptTemplates(staticTemplates{"base-model": "{{.prompt}}"})
+
+	_, err := cmd.Execute(context.Background(), map[string]any{
+		"model":    "base-model",
+		"messages": []any{map[string]any{"role": "user", "content": "Hello"}},
+	})
+	if err == nil {
+		t.Fatal("expected the template error")
+	}
+	if provider.chatCall || provider.prompt != "" {
+		t.Error("expected no provider call for a broken template")
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,6 +41,12 @@ type ModelProvider interface {
 	EstimateResources(ctx context.Context, modelID string) (*ModelRequirements, error)
 }
 
+// ModelUsage reports the services currently running a model, so commands
+// that touch a model's files can refuse while it is being served.
+type ModelUsage interface {
+	ServicesUsingModel(ctx context.Context, modelID string) ([]string, error)
+}
+
 // EventPublisher interface for publishing events
 type EventPublisher interface {
 	Publish(event any) error
@@ -110,6 +119,13 @@ func (c *CreateCommand) InputSchema() unit.Schema {
 					Description: "Local path to model files",
 				},
 			},
+			"prompt_template": {
+				Name: "prompt_template",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Go text/template rendering .messages into a prompt, for base models served as completions",
+				},
+			},
 		},
 		Required: []string{"name"},
 	}
@@ -175,6 +191,12 @@ func (c *CreateCommand) Execute(ctx context.Context, input any) (any, error) {
 	if p, ok := inputMap["path"].(string); ok {
 		model.Path = p
 	}
+	if pt, ok := inputMap["prompt_template"].(string); ok && pt != "" {
+		if _, err := template.New("prompt").Parse(pt); err != nil {
+			return nil, fmt.Errorf("invalid prompt_template: %v: %w", err, ErrInvalidInput)
+		}
+		model.PromptTemplate = pt
+	}
 
 	if err := c.store.Create(ctx, model); err != nil {
 		return nil, fmt.Errorf("create model: %w", err)
@@ -192,8 +214,9 @@ func (c *CreateCommand) Execute(ctx context.Context, input any) (any, error) {
 }
 
 type DeleteCommand struct {
-	store  ModelStore
-	events EventPublisher
+	store    ModelStore
+	events   EventPublisher
+	resolver *PathResolver
 }
 
 func NewDeleteCommand(store ModelStore) *DeleteCommand {
@@ -204,6 +227,12 @@ func NewDeleteCommandWithEvents(store ModelStore, events EventPublisher) *Delete
 	return &DeleteCommand{store: store, events: events}
 }
 
+// NewDeleteCommandWithResolver returns a delete command that also removes
+// the model's files when they live in a directory resolver created for it.
+func NewDeleteCommandWithResolver(store ModelStore, resolver *PathResolver, events EventPublisher) *DeleteCommand {
+	return &DeleteCommand{store: store, events: events, resolver: resolver}
+}
+
 func (c *DeleteCommand) Name() string {
 	return "model.delete"
 }
@@ -247,6 +276,10 @@ func (c *DeleteCommand) OutputSchema() unit.Schema {
 				Name:   "success",
 				Schema: unit.Schema{Type: "boolean"},
 			},
+			"files_removed": {
+				Name:   "files_removed",
+				Schema: unit.Schema{Type: "boolean"},
+			},
 		},
 	}
 }
@@ -290,6 +323,15 @@ func (c *DeleteCommand) Execute(ctx context.Context, input any) (any, error) {
 		return nil, fmt.Errorf("delete model %s: %w", modelID, err)
 	}
 
+	output := map[string]any{"success": true}
+	if c.resolver != nil {
+		removed, err := c.resolver.Remove(modelID, model.Path)
+		if err != nil {
+			slog.Warn("failed to remove model files", "model_id", modelID, "path", model.Path, "error", err)
+		}
+		output["files_removed"] = removed
+	}
+
 	// Publish event if event publisher is set
 	if c.events != nil {
 		if err := c.events.Publish(NewDeletedEvent(modelID, model.Name)); err != nil {
@@ -297,34 +339,48 @@ func (c *DeleteCommand) Execute(ctx context.Context, input any) (any, error) {
 		}
 	}
 
-	return map[string]any{"success": true}, nil
+	return output, nil
 }
 
+// DefaultPullProgressInterval is the minimum time between two progress
+// events published for the same pull.
+const DefaultPullProgressInterval = 500 * time.Millisecond
+
 type PullCommand struct {
-	store    ModelStore
-	provider ModelProvider
-	progress map[string]bool
-	mu       sync.Mutex
-	events   unit.EventPublisher
+	store            ModelStore
+	provider         ModelProvider
+	progress         map[string]bool
+	mu               sync.Mutex
+	events           unit.EventPublisher
+	progressInterval time.Duration
 }
 
 func NewPullCommand(store ModelStore, provider ModelProvider) *PullCommand {
 	return &PullCommand{
-		store:    store,
-		provider: provider,
-		progress: make(map[string]bool),
+		store:            store,
+		provider:         provider,
+		progress:         make(map[string]bool),
+		progressInterval: DefaultPullProgressInterval,
 	}
 }
 
 func NewPullCommandWithEvents(store ModelStore, provider ModelProvider, events unit.EventPublisher) *PullCommand {
 	return &PullCommand{
-		store:    store,
-		provider: provider,
-		progress: make(map[string]bool),
-		events:   events,
+		store:            store,
+		provider:         provider,
+		progress:         make(map[string]bool),
+		events:           events,
+		progressInterval: DefaultPullProgressInterval,
 	}
 }
 
+// SetProgressInterval sets the minimum time between two progress events of
+// one pull. Terminal updates are always published; d <= 0 publishes every
+// update.
+func (c *PullCommand) SetProgressInterval(d time.Duration) {
+	c.progressInterval = d
+}
+
 func (c *PullCommand) Name() string {
 	return "model.pull"
 }
@@ -387,6 +443,10 @@ func (c *PullCommand) OutputSchema() unit.Schema {
 				Name:   "status",
 				Schema: unit.Schema{Type: "string"},
 			},
+			"correlation_id": {
+				Name:   "correlation_id",
+				Schema: unit.Schema{Type: "string", Description: "Correlation ID of the pull's events (asms://events/{correlation_id})"},
+			},
 		},
 	}
 }
@@ -408,6 +468,11 @@ func (c *PullCommand) Examples() []unit.Example {
 
 func (c *PullCommand) Execute(ctx context.Context, input any) (any, error) {
 	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	// Correlate pull events with the request's trace ID so clients can follow
+	// them on asms://events/{trace_id} while the pull runs.
+	if traceID := unit.GetTraceID(ctx); traceID != "" {
+		ec.CorrelationID = traceID
+	}
 	ec.PublishStarted(input)
 
 	if c.store == nil || c.provider == nil {
@@ -450,11 +515,12 @@ func (c *PullCommand) Execute(ctx context.Context, input any) (any, error) {
 		c.mu.Unlock()
 	}()
 
-	model, err := c.provider.Pull(ctx, source, repo, tag, nil)
+	model, err := c.pull(ctx, source, repo, tag, ec.CorrelationID)
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("pull model from %s: %w", source, err)
 	}
+	attachManifest(model)
 
 	if err := c.store.Create(ctx, model); err != nil {
 		ec.PublishFailed(err)
@@ -462,17 +528,63 @@ func (c *PullCommand) Execute(ctx context.Context, input any) (any, error) {
 	}
 
 	output := map[string]any{
-		"model_id": model.ID,
-		"status":   string(model.Status),
+		"model_id":       model.ID,
+		"status":         string(model.Status),
+		"correlation_id": ec.CorrelationID,
 	}
 	ec.PublishCompleted(output)
 	return output, nil
 }
 
+// pull forwards provider progress as PullProgressEvents when the command has
+// an event publisher, so every subscriber sees a single progress stream.
+// Updates are throttled to one per progress interval; terminal updates, and
+// the last update of the stream, are always published.
+func (c *PullCommand) pull(ctx context.Context, source, repo, tag, correlationID string) (*Model, error) {
+	if c.events == nil {
+		return c.provider.Pull(ctx, source, repo, tag, nil)
+	}
+
+	progressCh := make(chan PullProgress, 16)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var last time.Time
+		var pending *PullProgress
+		for p := range progressCh {
+			if !isTerminalProgress(p) && c.progressInterval > 0 && !last.IsZero() && time.Since(last) < c.progressInterval {
+				pending = &p
+				continue
+			}
+			_ = c.events.Publish(NewPullProgressEventWithCorrelation(&p, correlationID))
+			last = time.Now()
+			pending = nil
+		}
+		if pending != nil {
+			_ = c.events.Publish(NewPullProgressEventWithCorrelation(pending, correlationID))
+		}
+	}()
+
+	model, err := c.provider.Pull(ctx, source, repo, tag, progressCh)
+	close(progressCh)
+	<-done
+	return model, err
+}
+
+// isTerminalProgress reports whether p is the last update of a pull.
+func isTerminalProgress(p PullProgress) bool {
+	switch p.Status {
+	case "completed", "success", "error", "failed":
+		return true
+	}
+	return p.Error != "" || p.Progress >= 100
+}
+
 type ImportCommand struct {
 	store    ModelStore
 	provider ModelProvider
 	events   unit.EventPublisher
+	resolver *PathResolver
 }
 
 func NewImportCommand(store ModelStore, provider ModelProvider) *ImportCommand {
@@ -483,6 +595,12 @@ func NewImportCommandWithEvents(store ModelStore, provider ModelProvider, events
 	return &ImportCommand{store: store, provider: provider, events: events}
 }
 
+// NewImportCommandWithResolver returns an import command that can copy the
+// imported files into the models directory managed by resolver.
+func NewImportCommandWithResolver(store ModelStore, provider ModelProvider, resolver *PathResolver, events unit.EventPublisher) *ImportCommand {
+	return &ImportCommand{store: store, provider: provider, events: events, resolver: resolver}
+}
+
 func (c *ImportCommand) Name() string {
 	return "model.import"
 }
@@ -527,6 +645,13 @@ func (c *ImportCommand) InputSchema() unit.Schema {
 					Description: "Auto-detect model type and format",
 				},
 			},
+			"copy": {
+				Name: "copy",
+				Schema: unit.Schema{
+					Type:        "boolean",
+					Description: "Copy the files into the models directory so delete can clean them up",
+				},
+			},
 		},
 		Required: []string{"path"},
 	}
@@ -601,7 +726,25 @@ func (c *ImportCommand) Execute(ctx context.Context, input any) (any, error) {
 		model.Type = ModelType(t)
 	}
 
+	if copyFiles, _ := inputMap["copy"].(bool); copyFiles {
+		if c.resolver == nil {
+			err := fmt.Errorf("copy requires a models directory: %w", ErrProviderNotSet)
+			ec.PublishFailed(err)
+			return nil, err
+		}
+		dir, err := c.resolver.CopyInto(model.ID, model.Name, path)
+		if err != nil {
+			ec.PublishFailed(err)
+			return nil, fmt.Errorf("copy model files: %w", err)
+		}
+		model.Path = dir
+	}
+	attachManifest(model)
+
 	if err := c.store.Create(ctx, model); err != nil {
+		if model.Path != path && c.resolver != nil {
+			c.resolver.Remove(model.ID, model.Path)
+		}
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("save imported model: %w", err)
 	}
@@ -675,6 +818,14 @@ func (c *VerifyCommand) OutputSchema() unit.Schema {
 					Items: &unit.Schema{Type: "string"},
 				},
 			},
+			"changed_files": {
+				Name: "changed_files",
+				Schema: unit.Schema{
+					Type:        "array",
+					Items:       &unit.Schema{Type: "string"},
+					Description: "Files that differ from the manifest recorded at import or pull",
+				},
+			},
 		},
 	}
 }
@@ -718,14 +869,22 @@ func (c *VerifyCommand) Execute(ctx context.Context, input any) (any, error) {
 		return nil, err
 	}
 
-	if _, err := c.store.Get(ctx, modelID); err != nil {
+	model, err := c.store.Get(ctx, modelID)
+	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("get model %s: %w", modelID, err)
 	}
 
 	checksum, _ := inputMap["checksum"].(string)
 
-	result, err := c.provider.Verify(ctx, modelID, checksum)
+	// Models with a stored manifest are checked file by file; the rest are
+	// left to the provider that owns them.
+	var result *VerificationResult
+	if len(model.Manifest) > 0 && model.Path != "" {
+		result, err = verifyManifest(model)
+	} else {
+		result, err = c.provider.Verify(ctx, modelID, checksum)
+	}
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("verify model %s: %w", modelID, err)
@@ -735,6 +894,174 @@ func (c *VerifyCommand) Execute(ctx context.Context, input any) (any, error) {
 		"valid":  result.Valid,
 		"issues": result.Issues,
 	}
+	if len(result.ChangedFiles) > 0 {
+		output["changed_files"] = result.ChangedFiles
+	}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+type MoveCommand struct {
+	store  ModelStore
+	usage  ModelUsage
+	events unit.EventPublisher
+}
+
+func NewMoveCommand(store ModelStore, usage ModelUsage) *MoveCommand {
+	return &MoveCommand{store: store, usage: usage}
+}
+
+func NewMoveCommandWithEvents(store ModelStore, usage ModelUsage, events unit.EventPublisher) *MoveCommand {
+	return &MoveCommand{store: store, usage: usage, events: events}
+}
+
+func (c *MoveCommand) Name() string {
+	return "model.move"
+}
+
+func (c *MoveCommand) Domain() string {
+	return "model"
+}
+
+func (c *MoveCommand) Description() string {
+	return "Move a model's files to a new path and update its stored location"
+}
+
+func (c *MoveCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"model_id": {
+				Name: "model_id",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Model identifier",
+				},
+			},
+			"path": {
+				Name: "path",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "New absolute path for the model files; must not exist yet",
+				},
+			},
+		},
+		Required: []string{"model_id", "path"},
+	}
+}
+
+func (c *MoveCommand) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"model_id": {
+				Name:   "model_id",
+				Schema: unit.Schema{Type: "string"},
+			},
+			"from": {
+				Name:   "from",
+				Schema: unit.Schema{Type: "string"},
+			},
+			"to": {
+				Name:   "to",
+				Schema: unit.Schema{Type: "string"},
+			},
+		},
+	}
+}
+
+func (c *MoveCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input:       map[string]any{"model_id": "model-abc123", "path": "/mnt/data/models/llama3"},
+			Output:      map[string]any{"model_id": "model-abc123", "from": "/home/user/.aima/models/llama3", "to": "/mnt/data/models/llama3"},
+			Description: "Move a model to another disk",
+		},
+	}
+}
+
+func (c *MoveCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.store == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	modelID, _ := inputMap["model_id"].(string)
+	if modelID == "" {
+		err := ErrInvalidModelID
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	dst, _ := inputMap["path"].(string)
+	if dst == "" || !filepath.IsAbs(dst) {
+		err := fmt.Errorf("path must be an absolute path: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+	dst = filepath.Clean(dst)
+
+	model, err := c.store.Get(ctx, modelID)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("get model %s: %w", modelID, err)
+	}
+	if model.Path == "" {
+		err := fmt.Errorf("model %s has no files on disk: %w", modelID, ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	if c.usage != nil {
+		services, err := c.usage.ServicesUsingModel(ctx, modelID)
+		if err != nil {
+			ec.PublishFailed(err)
+			return nil, fmt.Errorf("check services using model %s: %w", modelID, err)
+		}
+		if len(services) > 0 {
+			err := fmt.Errorf("model %s is used by %s: %w", modelID, strings.Join(services, ", "), ErrModelInUse)
+			ec.PublishFailed(err)
+			return nil, err
+		}
+	}
+
+	src := model.Path
+	if err := moveFiles(src, dst); err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("move model %s: %w", modelID, err)
+	}
+
+	moved := *model
+	moved.Path = dst
+	moved.UpdatedAt = time.Now().Unix()
+	if err := c.store.Update(ctx, &moved); err != nil {
+		// Put the files back so the store and the disk stay consistent.
+		if rbErr := moveFiles(dst, src); rbErr != nil {
+			slog.Error("failed to restore model files after store update failed",
+				"model_id", modelID, "path", dst, "error", rbErr)
+		}
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("update model %s: %w", modelID, err)
+	}
+
+	if c.events != nil {
+		if err := c.events.Publish(NewMovedEvent(modelID, src, dst)); err != nil {
+			slog.Warn("failed to publish model.moved event", "error", err)
+		}
+	}
+
+	output := map[string]any{"model_id": modelID, "from": src, "to": dst}
 	ec.PublishCompleted(output)
 	return output, nil
 }
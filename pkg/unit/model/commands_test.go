@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 )
@@ -565,3 +566,103 @@ func TestCommandImplementsInterface(t *testing.T) {
 	var _ unit.Command = NewImportCommand(nil, nil)
 	var _ unit.Command = NewVerifyCommand(nil, nil)
 }
+
+type progressPullProvider struct {
+	MockProvider
+}
+
+func (p *progressPullProvider) Pull(ctx context.Context, source, repo, tag string, progressCh chan<- PullProgress) (*Model, error) {
+	if progressCh != nil {
+		progressCh <- PullProgress{Status: "downloading", Progress: 0.5}
+	}
+	return p.MockProvider.Pull(ctx, source, repo, tag, nil)
+}
+
+func TestPullCommand_Execute_CorrelatesEventsWithTraceID(t *testing.T) {
+	publisher := &mockPublisher{}
+	cmd := NewPullCommandWithEvents(NewMemoryStore(), &progressPullProvider{}, publisher)
+
+	ctx := unit.WithTraceID(context.Background(), "trace-123")
+	result, err := cmd.Execute(ctx, map[string]any{"source": "ollama", "repo": "llama3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.(map[string]any)["correlation_id"]; got != "trace-123" {
+		t.Errorf("expected correlation_id trace-123, got %v", got)
+	}
+
+	var sawProgress bool
+	for _, e := range publisher.events {
+		event := e.(unit.Event)
+		if event.CorrelationID() != "trace-123" {
+			t.Errorf("event %s: expected correlation ID trace-123, got %s", event.Type(), event.CorrelationID())
+		}
+		if event.Type() == EventTypePullProgress {
+			sawProgress = true
+		}
+	}
+	if !sawProgress {
+		t.Error("expected pull progress to be published")
+	}
+}
+
+type floodPullProvider struct {
+	MockProvider
+	updates int
+}
+
+func (p *floodPullProvider) Pull(ctx context.Context, source, repo, tag string, progressCh chan<- PullProgress) (*Model, error) {
+	for i := 0; i < p.updates; i++ {
+		progressCh <- PullProgress{Status: "downloading", Progress: float64(i) * 100 / float64(p.updates)}
+	}
+	progressCh <- PullProgress{Status: "completed", Progress: 100}
+	return p.MockProvider.Pull(ctx, source, repo, tag, nil)
+}
+
+func TestPullCommand_Execute_ThrottlesProgress(t *testing.T) {
+	publisher := &mockPublisher{}
+	cmd := NewPullCommandWithEvents(NewMemoryStore(), &floodPullProvider{updates: 1000}, publisher)
+	cmd.SetProgressInterval(time.Hour)
+
+	if _, err := cmd.Execute(context.Background(), map[string]any{"source": "ollama", "repo": "llama3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var progress []map[string]any
+	for _, e := range publisher.events {
+		if event := e.(unit.Event); event.Type() == EventTypePullProgress {
+			progress = append(progress, event.Payload().(map[string]any))
+		}
+	}
+	if len(progress) != 2 {
+		t.Fatalf("expected the first and the terminal progress event, got %d", len(progress))
+	}
+	if last := progress[len(progress)-1]; last["status"] != "completed" || last["progress"] != 100.0 {
+		t.Errorf("expected the terminal event to be preserved, got %v", last)
+	}
+}
+
+func TestCreateCommand_Execute_PromptTemplate(t *testing.T) {
+	store := NewMemoryStore()
+	cmd := NewCreateCommand(store)
+
+	result, err := cmd.Execute(context.Background(), map[string]any{
+		"name":            "base-llm",
+		"prompt_template": "{{range .messages}}{{.role}}: {{.content}}\n{{end}}assistant:",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m, err := store.Get(context.Background(), result.(map[string]any)["model_id"].(string))
+	if err != nil {
+		t.Fatalf("get model: %v", err)
+	}
+	if m.PromptTemplate == "" {
+		t.Error("expected the prompt template to be stored")
+	}
+
+	_, err = cmd.Execute(context.Background(), map[string]any{"name": "broken", "prompt_template": "{{range .messages}"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unparsable template, got %v", err)
+	}
+}
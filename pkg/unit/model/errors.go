@@ -13,10 +13,14 @@ var (
 	ErrModelVerifyFailed = unit.NewDomainError("model", unit.ErrCodeModelVerifyFailed, "model verify failed")
 	ErrModelImportFailed = unit.NewDomainError("model", unit.ErrCodeModelImportFailed, "model import failed")
 	ErrModelDeleteFailed = unit.NewDomainError("model", unit.ErrCodeModelDeleteFailed, "model delete failed")
+	ErrModelInUse        = unit.NewDomainError("model", unit.ErrCodeModelInUse, "model is in use by a service")
 
 	// Input errors (backward compatibility)
 	ErrInvalidModelID = unit.NewError(unit.ErrCodeInvalidInput, "invalid model id")
 	ErrInvalidInput   = unit.NewError(unit.ErrCodeInvalidInput, "invalid input")
 	ErrPullInProgress = unit.NewError(unit.ErrCodeAlreadyExists, "pull already in progress")
 	ErrProviderNotSet = unit.NewError(unit.ErrCodeInternalError, "provider not set")
+
+	// Estimation errors
+	ErrSafetensorsMetadataMissing = unit.NewError(unit.ErrCodeNotFound, "safetensors metadata not found")
 )
@@ -11,6 +11,7 @@ const (
 	EventTypeDeleted      = "model.deleted"
 	EventTypePullProgress = "model.pull_progress"
 	EventTypeVerified     = "model.verified"
+	EventTypeMoved        = "model.moved"
 )
 
 type CreatedEvent struct {
@@ -97,6 +98,14 @@ func NewPullProgressEvent(progress *PullProgress) *PullProgressEvent {
 	}
 }
 
+// NewPullProgressEventWithCorrelation creates a PullProgressEvent that shares
+// the correlation ID of the pull that produced it.
+func NewPullProgressEventWithCorrelation(progress *PullProgress, correlationID string) *PullProgressEvent {
+	e := NewPullProgressEvent(progress)
+	e.correlationID = correlationID
+	return e
+}
+
 func (e *PullProgressEvent) Type() string          { return e.eventType }
 func (e *PullProgressEvent) Domain() string        { return e.domain }
 func (e *PullProgressEvent) Payload() any          { return e.payload }
@@ -130,3 +139,31 @@ func (e *VerifiedEvent) Domain() string        { return e.domain }
 func (e *VerifiedEvent) Payload() any          { return e.payload }
 func (e *VerifiedEvent) Timestamp() time.Time  { return e.timestamp }
 func (e *VerifiedEvent) CorrelationID() string { return e.correlationID }
+
+type MovedEvent struct {
+	eventType     string
+	domain        string
+	payload       any
+	timestamp     time.Time
+	correlationID string
+}
+
+func NewMovedEvent(modelID, from, to string) *MovedEvent {
+	return &MovedEvent{
+		eventType: EventTypeMoved,
+		domain:    "model",
+		payload: map[string]any{
+			"model_id": modelID,
+			"from":     from,
+			"to":       to,
+		},
+		timestamp:     time.Now(),
+		correlationID: uuid.New().String(),
+	}
+}
+
+func (e *MovedEvent) Type() string          { return e.eventType }
+func (e *MovedEvent) Domain() string        { return e.domain }
+func (e *MovedEvent) Payload() any          { return e.payload }
+func (e *MovedEvent) Timestamp() time.Time  { return e.timestamp }
+func (e *MovedEvent) CorrelationID() string { return e.correlationID }
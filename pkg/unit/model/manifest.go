@@ -0,0 +1,139 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// BuildManifest computes a SHA-256 digest for every regular file under
+// path, or for path itself when it is a single file. Paths in the manifest
+// are relative to path and use forward slashes; entries are sorted.
+func BuildManifest(path string) ([]FileDigest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		digest, err := digestFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []FileDigest{{Path: filepath.Base(path), Size: info.Size(), SHA256: digest}}, nil
+	}
+
+	var manifest []FileDigest
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || d.Name() == ownerFile {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		digest, err := digestFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		manifest = append(manifest, FileDigest{Path: filepath.ToSlash(rel), Size: info.Size(), SHA256: digest})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(manifest, func(i, j int) bool { return manifest[i].Path < manifest[j].Path })
+	return manifest, nil
+}
+
+// CompareManifest reports how the files in current differ from the stored
+// manifest.
+func CompareManifest(stored, current []FileDigest) ManifestDiff {
+	byPath := make(map[string]FileDigest, len(current))
+	for _, f := range current {
+		byPath[f.Path] = f
+	}
+
+	var diff ManifestDiff
+	for _, want := range stored {
+		got, ok := byPath[want.Path]
+		switch {
+		case !ok:
+			diff.Missing = append(diff.Missing, want.Path)
+		case got.Size != want.Size || got.SHA256 != want.SHA256:
+			diff.Changed = append(diff.Changed, want.Path)
+		}
+		delete(byPath, want.Path)
+	}
+	for p := range byPath {
+		diff.Added = append(diff.Added, p)
+	}
+	sort.Strings(diff.Added)
+	return diff
+}
+
+// verifyManifest recomputes the manifest of a model's files and compares it
+// with the stored one.
+func verifyManifest(m *Model) (*VerificationResult, error) {
+	current, err := BuildManifest(m.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read model files at %s: %w", m.Path, err)
+	}
+
+	diff := CompareManifest(m.Manifest, current)
+	result := &VerificationResult{Valid: diff.Empty(), Issues: []string{}}
+	for _, p := range diff.Changed {
+		result.Issues = append(result.Issues, "file changed: "+p)
+	}
+	for _, p := range diff.Missing {
+		result.Issues = append(result.Issues, "file missing: "+p)
+	}
+	for _, p := range diff.Added {
+		result.Issues = append(result.Issues, "unexpected file: "+p)
+	}
+	result.ChangedFiles = append(append(append([]string{}, diff.Changed...), diff.Missing...), diff.Added...)
+	return result, nil
+}
+
+// attachManifest records the manifest of a model whose files are on local
+// disk. Models without local files, such as Ollama models, are left as is.
+func attachManifest(m *Model) {
+	if m.Path == "" {
+		return
+	}
+	if _, err := os.Stat(m.Path); err != nil {
+		return
+	}
+	manifest, err := BuildManifest(m.Path)
+	if err != nil {
+		slog.Warn("failed to compute model manifest", "model_id", m.ID, "path", m.Path, "error", err)
+		return
+	}
+	m.Manifest = manifest
+}
+
+func digestFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
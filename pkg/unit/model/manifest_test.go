@@ -0,0 +1,134 @@
+package model
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeModelFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBuildManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeModelFiles(t, dir, map[string]string{
+		"config.json":               "{}",
+		"weights/model.safetensors": "weights",
+		ownerFile:                   "model-1",
+	})
+
+	manifest, err := BuildManifest(dir)
+	if err != nil {
+		t.Fatalf("BuildManifest failed: %v", err)
+	}
+
+	var paths []string
+	for _, f := range manifest {
+		paths = append(paths, f.Path)
+	}
+	if want := []string{"config.json", "weights/model.safetensors"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("manifest paths = %v, want %v", paths, want)
+	}
+	if manifest[0].SHA256 != "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a" {
+		t.Errorf("config.json digest = %s", manifest[0].SHA256)
+	}
+
+	single, err := BuildManifest(filepath.Join(dir, "config.json"))
+	if err != nil || len(single) != 1 || single[0].Path != "config.json" {
+		t.Errorf("single-file manifest = %+v, %v", single, err)
+	}
+}
+
+func TestCompareManifest(t *testing.T) {
+	stored := []FileDigest{
+		{Path: "a", Size: 1, SHA256: "aa"},
+		{Path: "b", Size: 1, SHA256: "bb"},
+		{Path: "c", Size: 1, SHA256: "cc"},
+	}
+	current := []FileDigest{
+		{Path: "a", Size: 1, SHA256: "aa"},
+		{Path: "b", Size: 1, SHA256: "xx"},
+		{Path: "d", Size: 1, SHA256: "dd"},
+	}
+
+	diff := CompareManifest(stored, current)
+	want := ManifestDiff{Changed: []string{"b"}, Missing: []string{"c"}, Added: []string{"d"}}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("CompareManifest = %+v, want %+v", diff, want)
+	}
+	if !CompareManifest(stored, stored).Empty() {
+		t.Error("identical manifests should have an empty diff")
+	}
+}
+
+func TestVerifyCommand_DetectsMutatedFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeModelFiles(t, dir, map[string]string{
+		"config.json":       "{}",
+		"model.safetensors": "original weights",
+	})
+
+	store := NewMemoryStore()
+	provider := &MockProvider{}
+	result, err := NewImportCommand(store, provider).Execute(ctx, map[string]any{"path": dir})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	modelID := result.(map[string]any)["model_id"].(string)
+
+	m, _ := store.Get(ctx, modelID)
+	if len(m.Manifest) != 2 {
+		t.Fatalf("expected a manifest of 2 files after import, got %+v", m.Manifest)
+	}
+
+	verify := NewVerifyCommand(store, provider)
+	out, err := verify.Execute(ctx, map[string]any{"model_id": modelID})
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if out.(map[string]any)["valid"] != true {
+		t.Fatalf("untouched model should verify, got %+v", out)
+	}
+
+	writeModelFiles(t, dir, map[string]string{"model.safetensors": "corrupted weights"})
+
+	out, err = verify.Execute(ctx, map[string]any{"model_id": modelID})
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	outMap := out.(map[string]any)
+	if outMap["valid"] != false {
+		t.Error("mutated model should fail verification")
+	}
+	if changed, _ := outMap["changed_files"].([]string); !reflect.DeepEqual(changed, []string{"model.safetensors"}) {
+		t.Errorf("changed_files = %v, want [model.safetensors]", outMap["changed_files"])
+	}
+}
+
+func TestVerifyCommand_WithoutManifestUsesProvider(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	_ = store.Create(ctx, createTestModel("model-1", "llama3"))
+	provider := &MockProvider{verifyRes: &VerificationResult{Valid: false, Issues: []string{"checksum mismatch"}}}
+
+	out, err := NewVerifyCommand(store, provider).Execute(ctx, map[string]any{"model_id": "model-1"})
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if out.(map[string]any)["valid"] != false {
+		t.Error("expected the provider result to be used")
+	}
+}
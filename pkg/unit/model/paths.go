@@ -0,0 +1,236 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// ownerFile marks a model directory with the ID of the model that owns it,
+// so two models whose names map to the same directory never share files and
+// delete only removes what the model itself created.
+const ownerFile = ".aima-model-id"
+
+// PathResolver computes canonical on-disk locations for models under a single
+// storage directory. A model lives in <root>/<name>, where name is the model
+// name made safe for the filesystem; when that directory already belongs to
+// another model, the model ID is appended.
+type PathResolver struct {
+	root string
+}
+
+// NewPathResolver returns a resolver rooted at dir.
+func NewPathResolver(dir string) *PathResolver {
+	return &PathResolver{root: filepath.Clean(dir)}
+}
+
+// Root returns the storage directory.
+func (r *PathResolver) Root() string {
+	return r.root
+}
+
+// Validate creates the storage directory if needed and checks that it is a
+// writable directory.
+func (r *PathResolver) Validate() error {
+	if err := os.MkdirAll(r.root, 0755); err != nil {
+		return fmt.Errorf("create models dir %s: %w", r.root, err)
+	}
+	info, err := os.Stat(r.root)
+	if err != nil {
+		return fmt.Errorf("stat models dir %s: %w", r.root, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("models dir %s is not a directory", r.root)
+	}
+	probe, err := os.CreateTemp(r.root, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("models dir %s is not writable: %w", r.root, err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// Dir returns the canonical directory for a model without creating it.
+func (r *PathResolver) Dir(modelID, name string) string {
+	dir := filepath.Join(r.root, safeName(name, modelID))
+	if owner := readOwner(dir); owner == "" && !exists(dir) || owner == modelID {
+		return dir
+	}
+	return filepath.Join(r.root, safeName(name, modelID)+"-"+safeName(modelID, "model"))
+}
+
+// Ensure creates the canonical directory for a model and records the model
+// as its owner.
+func (r *PathResolver) Ensure(modelID, name string) (string, error) {
+	dir := r.Dir(modelID, name)
+	if owner := readOwner(dir); owner != "" && owner != modelID {
+		return "", fmt.Errorf("model directory %s is owned by %s", dir, owner)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create model directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ownerFile), []byte(modelID), 0644); err != nil {
+		return "", fmt.Errorf("claim model directory: %w", err)
+	}
+	return dir, nil
+}
+
+// Owns reports whether path is a directory under the storage root that was
+// created for modelID.
+func (r *PathResolver) Owns(modelID, path string) bool {
+	if path == "" || modelID == "" {
+		return false
+	}
+	rel, err := filepath.Rel(r.root, filepath.Clean(path))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	return readOwner(path) == modelID
+}
+
+// Remove deletes a model's directory if the model owns it. Paths outside the
+// storage directory, such as models imported in place, are left untouched and
+// reported as not removed.
+func (r *PathResolver) Remove(modelID, path string) (bool, error) {
+	if !r.Owns(modelID, path) {
+		return false, nil
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return false, fmt.Errorf("remove model directory %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// CopyInto copies src, a model file or directory, into the model's canonical
+// directory and returns that directory.
+func (r *PathResolver) CopyInto(modelID, name, src string) (string, error) {
+	dir, err := r.Ensure(modelID, name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := copyTree(src, dir); err != nil {
+		return "", fmt.Errorf("copy %s into %s: %w", src, dir, err)
+	}
+	return dir, nil
+}
+
+// rename is os.Rename, replaceable in tests to simulate a move across
+// devices.
+var rename = os.Rename
+
+// moveFiles moves a model file or directory from src to dst, which must not
+// exist yet. When src and dst are on different devices the files are copied
+// and src is removed afterwards; a failed copy leaves src intact.
+func moveFiles(src, dst string) error {
+	if exists(dst) {
+		return fmt.Errorf("destination %s already exists", dst)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("create destination parent: %w", err)
+	}
+
+	err := rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if info, statErr := os.Stat(src); statErr == nil && info.IsDir() {
+		if err := os.MkdirAll(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return fmt.Errorf("copy across devices: %w", err)
+	}
+	return os.RemoveAll(src)
+}
+
+// copyTree copies the file src to dst/<base>, or the contents of the
+// directory src into the existing directory dst.
+func copyTree(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		if dirInfo, err := os.Stat(dst); err == nil && dirInfo.IsDir() {
+			dst = filepath.Join(dst, filepath.Base(src))
+		}
+		return copyFile(src, dst)
+	}
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target)
+	})
+}
+
+// safeName maps a model name such as "meta-llama/Llama-3-8B" to a single
+// path element, falling back to fallback when nothing usable is left.
+func safeName(name, fallback string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	s := strings.Trim(b.String(), "._")
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+func readOwner(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, ownerFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,332 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestPathResolver_Dir(t *testing.T) {
+	root := t.TempDir()
+	r := NewPathResolver(root)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"meta-llama/Llama-3-8B", "meta-llama_Llama-3-8B"},
+		{"qwen2.5:7b", "qwen2.5_7b"},
+		{"../../etc", "etc"},
+		{"", "model-1"},
+		{"///", "model-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Dir("model-1", tt.name)
+			if got != filepath.Join(root, tt.want) {
+				t.Errorf("Dir(%q) = %q, want %q", tt.name, got, filepath.Join(root, tt.want))
+			}
+		})
+	}
+}
+
+func TestPathResolver_Collision(t *testing.T) {
+	r := NewPathResolver(t.TempDir())
+
+	first, err := r.Ensure("model-1", "org/llama")
+	if err != nil {
+		t.Fatalf("Ensure(model-1) failed: %v", err)
+	}
+	second, err := r.Ensure("model-2", "org_llama")
+	if err != nil {
+		t.Fatalf("Ensure(model-2) failed: %v", err)
+	}
+
+	if first == second {
+		t.Fatalf("colliding names resolved to the same directory %q", first)
+	}
+	if second != filepath.Join(r.Root(), "org_llama-model-2") {
+		t.Errorf("second dir = %q, want org_llama-model-2", second)
+	}
+	if again := r.Dir("model-1", "org/llama"); again != first {
+		t.Errorf("Dir for the owner = %q, want stable %q", again, first)
+	}
+
+	// A directory that exists but was not created by the resolver is
+	// never claimed.
+	if err := os.MkdirAll(filepath.Join(r.Root(), "manual"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if dir := r.Dir("model-3", "manual"); dir != filepath.Join(r.Root(), "manual-model-3") {
+		t.Errorf("Dir over an unowned directory = %q, want manual-model-3", dir)
+	}
+}
+
+func TestPathResolver_Remove(t *testing.T) {
+	r := NewPathResolver(t.TempDir())
+
+	dir, err := r.Ensure("model-1", "llama")
+	if err != nil {
+		t.Fatalf("Ensure failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "weights.bin"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if removed, _ := r.Remove("model-2", dir); removed {
+		t.Error("Remove should refuse a directory owned by another model")
+	}
+	outside := t.TempDir()
+	if removed, _ := r.Remove("model-1", outside); removed {
+		t.Error("Remove should refuse a path outside the models directory")
+	}
+	if removed, _ := r.Remove("model-1", r.Root()); removed {
+		t.Error("Remove should refuse the models directory itself")
+	}
+
+	removed, err := r.Remove("model-1", dir)
+	if err != nil || !removed {
+		t.Fatalf("Remove = %v, %v; want true, nil", removed, err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed", dir)
+	}
+}
+
+func TestPathResolver_CopyInto(t *testing.T) {
+	r := NewPathResolver(t.TempDir())
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "config.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := r.CopyInto("model-1", "custom", src)
+	if err != nil {
+		t.Fatalf("CopyInto failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sub", "config.json")); err != nil {
+		t.Errorf("expected copied file: %v", err)
+	}
+	if !r.Owns("model-1", dir) {
+		t.Error("expected the copy to be owned by model-1")
+	}
+}
+
+func TestPathResolver_Validate(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "models")
+	if err := NewPathResolver(root).Validate(); err != nil {
+		t.Fatalf("Validate should create a missing directory: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewPathResolver(file).Validate(); err == nil {
+		t.Error("Validate should reject a regular file")
+	}
+}
+
+func TestDeleteCommand_RemovesOwnedFiles(t *testing.T) {
+	ctx := context.Background()
+	r := NewPathResolver(t.TempDir())
+	store := NewMemoryStore()
+
+	owned := createTestModel("model-1", "llama3")
+	owned.Path, _ = r.Ensure(owned.ID, owned.Name)
+	external := createTestModel("model-2", "custom")
+	external.Path = t.TempDir()
+	_ = store.Create(ctx, owned)
+	_ = store.Create(ctx, external)
+
+	cmd := NewDeleteCommandWithResolver(store, r, nil)
+
+	result, err := cmd.Execute(ctx, map[string]any{"model_id": "model-1"})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if removed := result.(map[string]any)["files_removed"]; removed != true {
+		t.Errorf("files_removed = %v, want true", removed)
+	}
+	if _, err := os.Stat(owned.Path); !os.IsNotExist(err) {
+		t.Error("expected the owned model directory to be removed")
+	}
+
+	result, err = cmd.Execute(ctx, map[string]any{"model_id": "model-2"})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if removed := result.(map[string]any)["files_removed"]; removed != false {
+		t.Errorf("files_removed = %v, want false", removed)
+	}
+	if _, err := os.Stat(external.Path); err != nil {
+		t.Error("expected files imported in place to be kept")
+	}
+}
+
+func TestImportCommand_Copy(t *testing.T) {
+	ctx := context.Background()
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "model.gguf"), []byte("gguf"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("without resolver", func(t *testing.T) {
+		cmd := NewImportCommand(NewMemoryStore(), &MockProvider{})
+		if _, err := cmd.Execute(ctx, map[string]any{"path": src, "copy": true}); err == nil {
+			t.Error("expected an error when copying without a models directory")
+		}
+	})
+
+	t.Run("with resolver", func(t *testing.T) {
+		r := NewPathResolver(t.TempDir())
+		store := NewMemoryStore()
+		cmd := NewImportCommandWithResolver(store, &MockProvider{}, r, nil)
+
+		result, err := cmd.Execute(ctx, map[string]any{"path": src, "name": "custom", "copy": true})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		m, err := store.Get(ctx, result.(map[string]any)["model_id"].(string))
+		if err != nil {
+			t.Fatalf("imported model not stored: %v", err)
+		}
+		if !r.Owns(m.ID, m.Path) {
+			t.Errorf("model path %q should be owned by %s", m.Path, m.ID)
+		}
+		if _, err := os.Stat(filepath.Join(m.Path, "model.gguf")); err != nil {
+			t.Errorf("expected the model file to be copied: %v", err)
+		}
+	})
+}
+
+type stubUsage struct {
+	services []string
+}
+
+func (s stubUsage) ServicesUsingModel(ctx context.Context, modelID string) ([]string, error) {
+	return s.services, nil
+}
+
+type recordingPublisher struct {
+	events []any
+}
+
+func (p *recordingPublisher) Publish(event any) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func newMoveFixture(t *testing.T) (*MemoryStore, string) {
+	t.Helper()
+	src := filepath.Join(t.TempDir(), "llama3")
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "weights.bin"), []byte("weights"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemoryStore()
+	m := createTestModel("model-1", "llama3")
+	m.Path = src
+	_ = store.Create(context.Background(), m)
+	return store, src
+}
+
+func TestMoveCommand_Rename(t *testing.T) {
+	ctx := context.Background()
+	store, src := newMoveFixture(t)
+	dst := filepath.Join(t.TempDir(), "disk2", "llama3")
+	events := &recordingPublisher{}
+
+	cmd := NewMoveCommandWithEvents(store, stubUsage{}, events)
+	if _, err := cmd.Execute(ctx, map[string]any{"model_id": "model-1", "path": dst}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	m, _ := store.Get(ctx, "model-1")
+	if m.Path != dst {
+		t.Errorf("stored path = %q, want %q", m.Path, dst)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "sub", "weights.bin")); err != nil {
+		t.Errorf("expected moved file: %v", err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Error("expected the source directory to be gone")
+	}
+
+	var moved bool
+	for _, e := range events.events {
+		if ev, ok := e.(*MovedEvent); ok && ev.Type() == EventTypeMoved {
+			moved = true
+		}
+	}
+	if !moved {
+		t.Error("expected a model.moved event")
+	}
+}
+
+func TestMoveCommand_CrossDevice(t *testing.T) {
+	orig := rename
+	rename = func(oldpath, newpath string) error {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+	}
+	defer func() { rename = orig }()
+
+	ctx := context.Background()
+	store, src := newMoveFixture(t)
+	dst := filepath.Join(t.TempDir(), "llama3")
+
+	cmd := NewMoveCommand(store, nil)
+	if _, err := cmd.Execute(ctx, map[string]any{"model_id": "model-1", "path": dst}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, "sub", "weights.bin"))
+	if err != nil || string(data) != "weights" {
+		t.Errorf("copied file = %q, %v; want weights", data, err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Error("expected the source directory to be removed after the copy")
+	}
+}
+
+func TestMoveCommand_Refuses(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("in use", func(t *testing.T) {
+		store, src := newMoveFixture(t)
+		cmd := NewMoveCommand(store, stubUsage{services: []string{"svc-1"}})
+
+		_, err := cmd.Execute(ctx, map[string]any{"model_id": "model-1", "path": filepath.Join(t.TempDir(), "x")})
+		if !errors.Is(err, ErrModelInUse) {
+			t.Errorf("expected ErrModelInUse, got %v", err)
+		}
+		if _, err := os.Stat(src); err != nil {
+			t.Error("files must stay in place when the move is refused")
+		}
+	})
+
+	t.Run("destination exists", func(t *testing.T) {
+		store, _ := newMoveFixture(t)
+		cmd := NewMoveCommand(store, nil)
+		if _, err := cmd.Execute(ctx, map[string]any{"model_id": "model-1", "path": t.TempDir()}); err == nil {
+			t.Error("expected an error when the destination exists")
+		}
+	})
+
+	t.Run("relative path", func(t *testing.T) {
+		store, _ := newMoveFixture(t)
+		cmd := NewMoveCommand(store, nil)
+		if _, err := cmd.Execute(ctx, map[string]any{"model_id": "model-1", "path": "models/llama3"}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput, got %v", err)
+		}
+	})
+}
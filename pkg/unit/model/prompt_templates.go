@@ -0,0 +1,31 @@
+package model
+
+import (
+	"context"
+	"errors"
+)
+
+// PromptTemplates looks up the prompt template of models in a store, so
+// inference can render chats with base models into a prompt.
+type PromptTemplates struct {
+	store ModelStore
+}
+
+// NewPromptTemplates returns a lookup backed by store.
+func NewPromptTemplates(store ModelStore) *PromptTemplates {
+	return &PromptTemplates{store: store}
+}
+
+// PromptTemplate returns the prompt template of the model with ID or name
+// ref, or "" when the model has none or is not in the store, as for models
+// an engine serves without AIMA managing them.
+func (t *PromptTemplates) PromptTemplate(ctx context.Context, ref string) (string, error) {
+	m, err := t.store.Get(ctx, ref)
+	if errors.Is(err, ErrModelNotFound) {
+		m, err = findModelByName(ctx, t.store, ref)
+	}
+	if err != nil || m == nil {
+		return "", err
+	}
+	return m.PromptTemplate, nil
+}
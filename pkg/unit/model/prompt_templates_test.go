@@ -0,0 +1,33 @@
+package model
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPromptTemplates(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	_ = store.Create(ctx, &Model{ID: "base-model", Name: "Base Model", PromptTemplate: "{{range .messages}}{{.content}}{{end}}"})
+	_ = store.Create(ctx, &Model{ID: "chat-model", Name: "llama3"})
+	templates := NewPromptTemplates(store)
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"base-model", "{{range .messages}}{{.content}}{{end}}"},
+		{"Base Model", "{{range .messages}}{{.content}}{{end}}"},
+		{"llama3", ""},
+		{"unmanaged", ""},
+	}
+	for _, tt := range tests {
+		got, err := templates.PromptTemplate(ctx, tt.ref)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.ref, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: template = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
+	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/ptrs"
+)
+
+// DefaultPruneAgeDays is how long a model must go without inference
+// requests before model.prune removes it.
+const DefaultPruneAgeDays = 30
+
+// usageRecordInterval limits how often RecordUsage rewrites a model, so a
+// busy model is not written to the store on every request.
+const usageRecordInterval = time.Minute
+
+// ModelReferences reports the services configured with a model, whatever
+// their state, so a model a stopped service would start is never pruned.
+type ModelReferences interface {
+	ServicesReferencingModel(ctx context.Context, modelID string) ([]string, error)
+}
+
+// RecordUsage marks the model ref names, by ID or else by name, as used at
+// t. Unknown models are ignored.
+func RecordUsage(ctx context.Context, store ModelStore, ref string, t time.Time) error {
+	if ref == "" {
+		return nil
+	}
+	m, err := store.Get(ctx, ref)
+	if err != nil {
+		if m, err = findModelByName(ctx, store, ref); err != nil || m == nil {
+			return err
+		}
+	}
+	if t.Unix()-m.LastUsedAt < int64(usageRecordInterval/time.Second) {
+		return nil
+	}
+	used := *m
+	used.LastUsedAt = t.Unix()
+	if err := store.Update(ctx, &used); err != nil {
+		return fmt.Errorf("record usage of model %s: %w", m.ID, err)
+	}
+	return nil
+}
+
+// findModelByName returns the model named name, or nil if there is none.
+func findModelByName(ctx context.Context, store ModelStore, name string) (*Model, error) {
+	models, err := listAllModels(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	for i := range models {
+		if models[i].Name == name {
+			return &models[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// listAllModels pages through the store, which may cap a single List.
+func listAllModels(ctx context.Context, store ModelStore) ([]Model, error) {
+	const pageSize = 100
+	var all []Model
+	for {
+		page, total, err := store.List(ctx, ModelFilter{Limit: pageSize, Offset: len(all)})
+		if err != nil {
+			return nil, fmt.Errorf("list models: %w", err)
+		}
+		all = append(all, page...)
+		if len(page) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+type PruneCommand struct {
+	store    ModelStore
+	refs     ModelReferences
+	resolver *PathResolver
+	events   unit.EventPublisher
+	now      func() time.Time
+}
+
+func NewPruneCommand(store ModelStore, refs ModelReferences, resolver *PathResolver) *PruneCommand {
+	return &PruneCommand{store: store, refs: refs, resolver: resolver, now: time.Now}
+}
+
+func NewPruneCommandWithEvents(store ModelStore, refs ModelReferences, resolver *PathResolver, events unit.EventPublisher) *PruneCommand {
+	return &PruneCommand{store: store, refs: refs, resolver: resolver, events: events, now: time.Now}
+}
+
+func (c *PruneCommand) Name() string {
+	return "model.prune"
+}
+
+func (c *PruneCommand) Domain() string {
+	return "model"
+}
+
+func (c *PruneCommand) Description() string {
+	return "Delete models no service references and no inference request has used recently"
+}
+
+func (c *PruneCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"older_than_days": {
+				Name: "older_than_days",
+				Schema: unit.Schema{
+					Type:        "number",
+					Description: "Prune models unused for at least this many days; models never used count from when they were added",
+					Min:         ptrs.Float64(1),
+					Default:     DefaultPruneAgeDays,
+				},
+			},
+			"dry_run": {
+				Name: "dry_run",
+				Schema: unit.Schema{
+					Type:        "boolean",
+					Description: "Report what would be removed without deleting anything",
+					Default:     false,
+				},
+			},
+		},
+	}
+}
+
+func (c *PruneCommand) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"removed": {
+				Name: "removed",
+				Schema: unit.Schema{
+					Type: "array",
+					Items: &unit.Schema{
+						Type: "object",
+						Properties: map[string]unit.Field{
+							"model_id":     {Name: "model_id", Schema: unit.Schema{Type: "string"}},
+							"name":         {Name: "name", Schema: unit.Schema{Type: "string"}},
+							"size":         {Name: "size", Schema: unit.Schema{Type: "number"}},
+							"last_used_at": {Name: "last_used_at", Schema: unit.Schema{Type: "number", Description: "Unix time of the last request, 0 if never used"}},
+						},
+					},
+				},
+			},
+			"freed_bytes": {
+				Name:   "freed_bytes",
+				Schema: unit.Schema{Type: "number", Description: "Total size of the removed models"},
+			},
+			"dry_run": {
+				Name:   "dry_run",
+				Schema: unit.Schema{Type: "boolean"},
+			},
+		},
+	}
+}
+
+func (c *PruneCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input: map[string]any{"older_than_days": 14, "dry_run": true},
+			Output: map[string]any{
+				"removed": []map[string]any{
+					{"model_id": "model-abc123", "name": "llama3", "size": int64(4661211808), "last_used_at": int64(1767225600)},
+				},
+				"freed_bytes": int64(4661211808),
+				"dry_run":     true,
+			},
+			Description: "List models unused for two weeks without deleting them",
+		},
+	}
+}
+
+func (c *PruneCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.store == nil || c.refs == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, ok := input.(map[string]any)
+	if !ok && input != nil {
+		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	days := DefaultPruneAgeDays
+	if v, ok := toInt(inputMap["older_than_days"]); ok {
+		days = v
+	}
+	if days < 1 {
+		err := fmt.Errorf("older_than_days must be at least 1, got %d: %w", days, ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+	dryRun, _ := inputMap["dry_run"].(bool)
+	cutoff := c.now().Add(-time.Duration(days) * 24 * time.Hour).Unix()
+
+	models, err := listAllModels(ctx, c.store)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, err
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+
+	removed := []map[string]any{}
+	var freed int64
+	for i := range models {
+		m := &models[i]
+		if m.Status == StatusPulling || lastActivity(m) > cutoff {
+			continue
+		}
+		services, err := c.refs.ServicesReferencingModel(ctx, m.ID)
+		if err != nil {
+			ec.PublishFailed(err)
+			return nil, fmt.Errorf("check services referencing model %s: %w", m.ID, err)
+		}
+		if len(services) > 0 {
+			slog.Debug("keeping model referenced by services", "model_id", m.ID, "services", strings.Join(services, ", "))
+			continue
+		}
+
+		if !dryRun {
+			if err := c.delete(ctx, m); err != nil {
+				ec.PublishFailed(err)
+				return nil, err
+			}
+		}
+		removed = append(removed, map[string]any{
+			"model_id":     m.ID,
+			"name":         m.Name,
+			"size":         m.Size,
+			"last_used_at": m.LastUsedAt,
+		})
+		freed += m.Size
+	}
+
+	output := map[string]any{"removed": removed, "freed_bytes": freed, "dry_run": dryRun}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+// delete removes m from the store and, like model.delete, its files when
+// they live in a directory the resolver created for it.
+func (c *PruneCommand) delete(ctx context.Context, m *Model) error {
+	if err := c.store.Delete(ctx, m.ID); err != nil {
+		return fmt.Errorf("delete model %s: %w", m.ID, err)
+	}
+	if c.resolver != nil {
+		if _, err := c.resolver.Remove(m.ID, m.Path); err != nil {
+			slog.Warn("failed to remove model files", "model_id", m.ID, "path", m.Path, "error", err)
+		}
+	}
+	if c.events != nil {
+		if err := c.events.Publish(NewDeletedEvent(m.ID, m.Name)); err != nil {
+			slog.Warn("failed to publish model.deleted event", "error", err)
+		}
+	}
+	return nil
+}
+
+// lastActivity is when m was last used, or added if it never was.
+func lastActivity(m *Model) int64 {
+	if m.LastUsedAt > 0 {
+		return m.LastUsedAt
+	}
+	return m.CreatedAt
+}
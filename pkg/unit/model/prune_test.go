@@ -0,0 +1,187 @@
+package model
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// stubReferences reports the services configured with each model.
+type stubReferences map[string][]string
+
+func (s stubReferences) ServicesReferencingModel(ctx context.Context, modelID string) ([]string, error) {
+	return s[modelID], nil
+}
+
+var pruneNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func daysAgo(days int) int64 {
+	return pruneNow.Add(-time.Duration(days) * 24 * time.Hour).Unix()
+}
+
+// newPruneFixture stores a referenced stale model, a stale unreferenced model
+// with files, a recently used model and a model added recently but never used.
+func newPruneFixture(t *testing.T) (*MemoryStore, *PathResolver, *PruneCommand) {
+	t.Helper()
+	ctx := context.Background()
+	store := NewMemoryStore()
+	resolver := NewPathResolver(t.TempDir())
+
+	stalePath, err := resolver.Ensure("stale", "old-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*Model{
+		{ID: "referenced", Name: "served", Size: 100, CreatedAt: daysAgo(90), LastUsedAt: daysAgo(60)},
+		{ID: "stale", Name: "old-model", Path: stalePath, Size: 4000, CreatedAt: daysAgo(90), LastUsedAt: daysAgo(45)},
+		{ID: "recent", Name: "busy", Size: 200, CreatedAt: daysAgo(90), LastUsedAt: daysAgo(2)},
+		{ID: "new", Name: "fresh", Size: 300, CreatedAt: daysAgo(1)},
+	} {
+		if err := store.Create(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := NewPruneCommand(store, stubReferences{"referenced": {"svc-vllm-served"}}, resolver)
+	cmd.now = func() time.Time { return pruneNow }
+	return store, resolver, cmd
+}
+
+func removedIDs(t *testing.T, result any) []string {
+	t.Helper()
+	var ids []string
+	for _, r := range result.(map[string]any)["removed"].([]map[string]any) {
+		ids = append(ids, r["model_id"].(string))
+	}
+	return ids
+}
+
+func TestPruneCommand_Name(t *testing.T) {
+	if got := NewPruneCommand(nil, nil, nil).Name(); got != "model.prune" {
+		t.Errorf("expected name 'model.prune', got '%s'", got)
+	}
+}
+
+func TestPruneCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	store, _, cmd := newPruneFixture(t)
+	stale, _ := store.Get(ctx, "stale")
+
+	result, err := cmd.Execute(ctx, map[string]any{"older_than_days": 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ids := removedIDs(t, result); len(ids) != 1 || ids[0] != "stale" {
+		t.Fatalf("expected only the stale unreferenced model to be pruned, got %v", ids)
+	}
+	if freed := result.(map[string]any)["freed_bytes"]; freed != int64(4000) {
+		t.Errorf("expected 4000 bytes freed, got %v", freed)
+	}
+	if _, err := store.Get(ctx, "stale"); err == nil {
+		t.Error("expected the stale model to be deleted")
+	}
+	if _, err := os.Stat(stale.Path); !os.IsNotExist(err) {
+		t.Errorf("expected the stale model's files to be removed, got %v", err)
+	}
+	for _, id := range []string{"referenced", "recent", "new"} {
+		if _, err := store.Get(ctx, id); err != nil {
+			t.Errorf("expected model %s to be kept: %v", id, err)
+		}
+	}
+}
+
+func TestPruneCommand_DryRun(t *testing.T) {
+	ctx := context.Background()
+	store, _, cmd := newPruneFixture(t)
+	stale, _ := store.Get(ctx, "stale")
+
+	result, err := cmd.Execute(ctx, map[string]any{"older_than_days": 30, "dry_run": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ids := removedIDs(t, result); len(ids) != 1 || ids[0] != "stale" {
+		t.Fatalf("expected the stale model to be reported, got %v", ids)
+	}
+	if result.(map[string]any)["dry_run"] != true {
+		t.Error("expected dry_run in the output")
+	}
+	if _, err := store.Get(ctx, "stale"); err != nil {
+		t.Errorf("expected a dry run to keep the model: %v", err)
+	}
+	if _, err := os.Stat(stale.Path); err != nil {
+		t.Errorf("expected a dry run to keep the files: %v", err)
+	}
+}
+
+func TestPruneCommand_Age(t *testing.T) {
+	_, _, cmd := newPruneFixture(t)
+
+	// With a 50-day age the model last used 45 days ago is not stale yet.
+	result, err := cmd.Execute(context.Background(), map[string]any{"older_than_days": 50, "dry_run": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := removedIDs(t, result); len(ids) != 0 {
+		t.Errorf("expected nothing to prune, got %v", ids)
+	}
+}
+
+func TestPruneCommand_Errors(t *testing.T) {
+	store := NewMemoryStore()
+	tests := []struct {
+		name  string
+		cmd   *PruneCommand
+		input any
+	}{
+		{"nil store", NewPruneCommand(nil, stubReferences{}, nil), map[string]any{}},
+		{"nil references", NewPruneCommand(store, nil, nil), map[string]any{}},
+		{"invalid input type", NewPruneCommand(store, stubReferences{}, nil), "stale"},
+		{"age below one day", NewPruneCommand(store, stubReferences{}, nil), map[string]any{"older_than_days": 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.cmd.Execute(context.Background(), tt.input); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestRecordUsage(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if err := store.Create(ctx, &Model{ID: "model-1", Name: "llama3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RecordUsage(ctx, store, "model-1", pruneNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m, _ := store.Get(ctx, "model-1")
+	if m.LastUsedAt != pruneNow.Unix() {
+		t.Errorf("expected last_used_at %d, got %d", pruneNow.Unix(), m.LastUsedAt)
+	}
+
+	// Requests within a minute of the last recorded one are not written.
+	if err := RecordUsage(ctx, store, "llama3", pruneNow.Add(30*time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m, _ = store.Get(ctx, "model-1"); m.LastUsedAt != pruneNow.Unix() {
+		t.Errorf("expected last_used_at to be unchanged, got %d", m.LastUsedAt)
+	}
+
+	later := pruneNow.Add(time.Hour)
+	if err := RecordUsage(ctx, store, "llama3", later); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m, _ = store.Get(ctx, "model-1"); m.LastUsedAt != later.Unix() {
+		t.Errorf("expected a model looked up by name to be updated, got %d", m.LastUsedAt)
+	}
+
+	if err := RecordUsage(ctx, store, "unknown", later); err != nil {
+		t.Errorf("expected unknown models to be ignored, got %v", err)
+	}
+}
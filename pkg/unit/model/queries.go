@@ -112,6 +112,9 @@ func (q *GetQuery) Execute(ctx context.Context, input any) (any, error) {
 		"status": string(model.Status),
 		"size":   model.Size,
 	}
+	if model.PromptTemplate != "" {
+		result["prompt_template"] = model.PromptTemplate
+	}
 
 	if model.Requirements != nil {
 		result["requirements"] = map[string]any{
@@ -520,6 +523,27 @@ func (q *EstimateResourcesQuery) OutputSchema() unit.Schema {
 					Description: "Recommended GPU type",
 				},
 			},
+			"parameters": {
+				Name: "parameters",
+				Schema: unit.Schema{
+					Type:        "number",
+					Description: "Parameter count, for local safetensors models",
+				},
+			},
+			"dtype": {
+				Name: "dtype",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Native weight dtype, for local safetensors models",
+				},
+			},
+			"memory_by_dtype": {
+				Name: "memory_by_dtype",
+				Schema: unit.Schema{
+					Type:        "object",
+					Description: "Weight memory in bytes for fp16, bf16, int8 and int4",
+				},
+			},
 		},
 	}
 }
@@ -574,6 +598,24 @@ func (q *EstimateResourcesQuery) Execute(ctx context.Context, input any) (any, e
 		return output, nil
 	}
 
+	// Local safetensors directories carry enough metadata to estimate without
+	// the provider; fall back to it when the files are absent.
+	if model.Format == FormatSafetensors && model.Path != "" {
+		if est, err := EstimateSafetensors(model.Path); err == nil {
+			req := est.Requirements()
+			output := map[string]any{
+				"memory_min":         req.MemoryMin,
+				"memory_recommended": req.MemoryRecommended,
+				"gpu_type":           req.GPUType,
+				"parameters":         est.Parameters,
+				"dtype":              est.DType,
+				"memory_by_dtype":    est.Memory,
+			}
+			ec.PublishCompleted(output)
+			return output, nil
+		}
+	}
+
 	req, err := q.provider.EstimateResources(ctx, modelID)
 	if err != nil {
 		ec.PublishFailed(err)
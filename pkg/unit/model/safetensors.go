@@ -0,0 +1,147 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	safetensorsConfigFile = "config.json"
+	safetensorsIndexFile  = "model.safetensors.index.json"
+)
+
+// SafetensorsDTypes lists the dtypes memory is estimated for, in the order
+// they are reported.
+var SafetensorsDTypes = []string{"fp16", "bf16", "int8", "int4"}
+
+var dtypeBits = map[string]int64{
+	"fp32": 32,
+	"fp16": 16,
+	"bf16": 16,
+	"int8": 8,
+	"int4": 4,
+}
+
+type SafetensorsEstimate struct {
+	Parameters int64            `json:"parameters"`
+	DType      string           `json:"dtype"`
+	Memory     map[string]int64 `json:"memory"`
+}
+
+type safetensorsConfig struct {
+	TorchDType        string `json:"torch_dtype"`
+	VocabSize         int64  `json:"vocab_size"`
+	HiddenSize        int64  `json:"hidden_size"`
+	IntermediateSize  int64  `json:"intermediate_size"`
+	NumHiddenLayers   int64  `json:"num_hidden_layers"`
+	NumAttentionHeads int64  `json:"num_attention_heads"`
+	NumKeyValueHeads  int64  `json:"num_key_value_heads"`
+	TieWordEmbeddings bool   `json:"tie_word_embeddings"`
+}
+
+type safetensorsIndex struct {
+	Metadata struct {
+		TotalSize int64 `json:"total_size"`
+	} `json:"metadata"`
+}
+
+// EstimateSafetensors derives the parameter count of a safetensors model
+// directory and the weight memory it needs for each of SafetensorsDTypes.
+// The index's total_size is preferred; otherwise the count is computed from
+// the decoder architecture in config.json.
+func EstimateSafetensors(dir string) (*SafetensorsEstimate, error) {
+	var cfg safetensorsConfig
+	hasConfig := readJSONFile(filepath.Join(dir, safetensorsConfigFile), &cfg) == nil
+
+	dtype := normalizeDType(cfg.TorchDType)
+	if dtype == "" {
+		dtype = "fp16"
+	}
+
+	var params int64
+	var index safetensorsIndex
+	if readJSONFile(filepath.Join(dir, safetensorsIndexFile), &index) == nil && index.Metadata.TotalSize > 0 {
+		params = index.Metadata.TotalSize * 8 / dtypeBits[dtype]
+	} else if hasConfig {
+		params = cfg.parameterCount()
+	}
+
+	if params <= 0 {
+		return nil, fmt.Errorf("estimate %s: %w", dir, ErrSafetensorsMetadataMissing)
+	}
+
+	memory := make(map[string]int64, len(SafetensorsDTypes))
+	for _, dt := range SafetensorsDTypes {
+		memory[dt] = params * dtypeBits[dt] / 8
+	}
+
+	return &SafetensorsEstimate{Parameters: params, DType: dtype, Memory: memory}, nil
+}
+
+// Requirements converts the estimate into ModelRequirements for the model's
+// native dtype, leaving 30% headroom for activations and KV cache.
+func (e *SafetensorsEstimate) Requirements() *ModelRequirements {
+	memMin := e.Parameters * dtypeBits[e.DType] / 8
+	return &ModelRequirements{
+		MemoryMin:         memMin,
+		MemoryRecommended: int64(float64(memMin) * 1.3),
+		GPUMemory:         memMin,
+	}
+}
+
+// parameterCount applies the Llama-style decoder layout: embeddings, grouped
+// query attention, a gated MLP and RMS norms, plus an untied LM head.
+func (c safetensorsConfig) parameterCount() int64 {
+	if c.HiddenSize <= 0 || c.NumHiddenLayers <= 0 || c.VocabSize <= 0 {
+		return 0
+	}
+
+	kvHeads := c.NumKeyValueHeads
+	if kvHeads <= 0 {
+		kvHeads = c.NumAttentionHeads
+	}
+	kvDim := c.HiddenSize
+	if c.NumAttentionHeads > 0 {
+		kvDim = c.HiddenSize / c.NumAttentionHeads * kvHeads
+	}
+
+	attention := 2*c.HiddenSize*c.HiddenSize + 2*c.HiddenSize*kvDim
+	mlp := 3 * c.HiddenSize * c.IntermediateSize
+	norms := 2 * c.HiddenSize
+	perLayer := attention + mlp + norms
+
+	embeddings := c.VocabSize * c.HiddenSize
+	params := embeddings + c.NumHiddenLayers*perLayer + c.HiddenSize
+	if !c.TieWordEmbeddings {
+		params += embeddings
+	}
+	return params
+}
+
+func normalizeDType(dtype string) string {
+	switch strings.ToLower(dtype) {
+	case "float16", "fp16", "half":
+		return "fp16"
+	case "bfloat16", "bf16":
+		return "bf16"
+	case "float32", "fp32", "float":
+		return "fp32"
+	case "int8":
+		return "int8"
+	case "int4":
+		return "int4"
+	default:
+		return ""
+	}
+}
+
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestEstimateSafetensors(t *testing.T) {
+	tests := []struct {
+		name       string
+		dir        string
+		wantParams int64
+		wantDType  string
+	}{
+		{
+			name:       "7B from config.json",
+			dir:        "llama-2-7b",
+			wantParams: 6738415616,
+			wantDType:  "fp16",
+		},
+		{
+			name:       "70B from safetensors index",
+			dir:        "llama-2-70b",
+			wantParams: 68976648192,
+			wantDType:  "bf16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est, err := EstimateSafetensors(filepath.Join("testdata", "safetensors", tt.dir))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if est.Parameters != tt.wantParams {
+				t.Errorf("expected %d parameters, got %d", tt.wantParams, est.Parameters)
+			}
+			if est.DType != tt.wantDType {
+				t.Errorf("expected dtype %s, got %s", tt.wantDType, est.DType)
+			}
+
+			want := map[string]int64{
+				"fp16": tt.wantParams * 2,
+				"bf16": tt.wantParams * 2,
+				"int8": tt.wantParams,
+				"int4": tt.wantParams / 2,
+			}
+			for dtype, bytes := range want {
+				if est.Memory[dtype] != bytes {
+					t.Errorf("expected %s memory %d, got %d", dtype, bytes, est.Memory[dtype])
+				}
+			}
+
+			req := est.Requirements()
+			if req.MemoryMin != tt.wantParams*2 {
+				t.Errorf("expected memory_min %d, got %d", tt.wantParams*2, req.MemoryMin)
+			}
+			if req.MemoryRecommended <= req.MemoryMin {
+				t.Error("expected recommended memory above the minimum")
+			}
+		})
+	}
+}
+
+func TestEstimateSafetensors_MissingMetadata(t *testing.T) {
+	_, err := EstimateSafetensors(t.TempDir())
+	if !errors.Is(err, ErrSafetensorsMetadataMissing) {
+		t.Errorf("expected ErrSafetensorsMetadataMissing, got %v", err)
+	}
+}
+
+func TestEstimateResourcesQuery_Safetensors(t *testing.T) {
+	store := NewMemoryStore()
+	m := createTestModel("model-7b", "llama-2-7b")
+	m.Format = FormatSafetensors
+	m.Path = filepath.Join("testdata", "safetensors", "llama-2-7b")
+	m.Requirements = nil
+	_ = store.Create(context.Background(), m)
+
+	q := NewEstimateResourcesQuery(store, &MockProvider{})
+	result, err := q.Execute(context.Background(), map[string]any{"model_id": "model-7b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := result.(map[string]any)
+	if out["parameters"] != int64(6738415616) {
+		t.Errorf("expected parameters from config.json, got %v", out["parameters"])
+	}
+	if out["memory_min"] != int64(6738415616*2) {
+		t.Errorf("expected fp16 memory_min, got %v", out["memory_min"])
+	}
+	byDType, ok := out["memory_by_dtype"].(map[string]int64)
+	if !ok || byDType["int4"] != 6738415616/2 {
+		t.Errorf("expected int4 memory in memory_by_dtype, got %v", out["memory_by_dtype"])
+	}
+}
+
+func TestEstimateResourcesQuery_SafetensorsFallsBackToProvider(t *testing.T) {
+	store := NewMemoryStore()
+	m := createTestModel("model-st", "empty")
+	m.Format = FormatSafetensors
+	m.Path = t.TempDir()
+	m.Requirements = nil
+	_ = store.Create(context.Background(), m)
+
+	q := NewEstimateResourcesQuery(store, &MockProvider{})
+	result, err := q.Execute(context.Background(), map[string]any{"model_id": "model-st"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := result.(map[string]any)
+	if _, ok := out["parameters"]; ok {
+		t.Error("expected provider estimate without parameter count")
+	}
+}
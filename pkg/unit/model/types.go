@@ -44,10 +44,19 @@ type Model struct {
 	Path         string             `json:"path,omitempty"`
 	Size         int64              `json:"size,omitempty"`
 	Checksum     string             `json:"checksum,omitempty"`
+	Manifest     []FileDigest       `json:"manifest,omitempty"`
 	Requirements *ModelRequirements `json:"requirements,omitempty"`
 	Tags         []string           `json:"tags,omitempty"`
-	CreatedAt    int64              `json:"created_at"`
-	UpdatedAt    int64              `json:"updated_at"`
+	// PromptTemplate, when set, marks a base model whose chat requests are
+	// rendered into a single prompt and served as completions. It is a Go
+	// text/template that sees the conversation as .messages, each message
+	// with .role and .content.
+	PromptTemplate string `json:"prompt_template,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+	// LastUsedAt is the Unix time of the last inference request for the
+	// model, zero if none was seen. model.prune uses it to find stale models.
+	LastUsedAt int64 `json:"last_used_at,omitempty"`
 }
 
 type ModelRequirements struct {
@@ -80,4 +89,28 @@ type PullProgress struct {
 type VerificationResult struct {
 	Valid  bool     `json:"valid"`
 	Issues []string `json:"issues,omitempty"`
+	// ChangedFiles lists files that differ from the stored manifest:
+	// modified, missing, or not present when the manifest was taken.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+}
+
+// FileDigest records the size and SHA-256 of one model file, relative to
+// the model's path.
+type FileDigest struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ManifestDiff is the difference between a stored manifest and the files on
+// disk.
+type ManifestDiff struct {
+	Changed []string `json:"changed,omitempty"`
+	Missing []string `json:"missing,omitempty"`
+	Added   []string `json:"added,omitempty"`
+}
+
+// Empty reports whether the files match the manifest.
+func (d ManifestDiff) Empty() bool {
+	return len(d.Changed) == 0 && len(d.Missing) == 0 && len(d.Added) == 0
 }
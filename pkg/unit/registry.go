@@ -3,6 +3,10 @@ package unit
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -14,15 +18,20 @@ var (
 	ErrCommandNotFound           = errors.New("command not found")
 	ErrQueryNotFound             = errors.New("query not found")
 	ErrResourceNotFound          = errors.New("resource not found")
+	ErrAliasConflict             = errors.New("alias conflicts with a registered unit")
+	ErrAliasTargetNotFound       = errors.New("alias target not found")
 )
 
 // Registry is the central registry for all atomic units (Commands, Queries, Resources).
 // It provides thread-safe registration and lookup operations.
 type Registry struct {
 	commands          map[string]Command
+	commandVersions   map[string]map[string]Command
 	queries           map[string]Query
 	resources         map[string]Resource
 	resourceFactories []ResourceFactory
+	resourceTemplates []resourceTemplate
+	aliases           map[string]string
 	mu                sync.RWMutex
 }
 
@@ -30,14 +39,19 @@ type Registry struct {
 func NewRegistry() *Registry {
 	return &Registry{
 		commands:          make(map[string]Command),
+		commandVersions:   make(map[string]map[string]Command),
 		queries:           make(map[string]Query),
 		resources:         make(map[string]Resource),
 		resourceFactories: make([]ResourceFactory, 0),
+		aliases:           make(map[string]string),
 	}
 }
 
 // RegisterCommand registers a Command with the registry.
-// Returns ErrCommandAlreadyRegistered if a command with the same name exists.
+// Commands implementing VersionedUnit may be registered once per version under
+// the same name; the highest version becomes the default.
+// Returns ErrCommandAlreadyRegistered if a command with the same name (and
+// version) exists.
 // Returns ErrCommandNotFound if cmd is nil.
 func (r *Registry) RegisterCommand(cmd Command) error {
 	if cmd == nil {
@@ -48,8 +62,25 @@ func (r *Registry) RegisterCommand(cmd Command) error {
 	defer r.mu.Unlock()
 
 	name := cmd.Name()
-	if _, exists := r.commands[name]; exists {
-		return ErrCommandAlreadyRegistered
+	version := unitVersion(cmd)
+	existing, exists := r.commands[name]
+	if exists {
+		if version == "" || unitVersion(existing) == "" {
+			return ErrCommandAlreadyRegistered
+		}
+		if _, dup := r.commandVersions[name][version]; dup {
+			return ErrCommandAlreadyRegistered
+		}
+	}
+
+	if version != "" {
+		if r.commandVersions[name] == nil {
+			r.commandVersions[name] = make(map[string]Command)
+		}
+		r.commandVersions[name][version] = cmd
+		if exists && CompareVersions(version, unitVersion(existing)) < 0 {
+			return nil
+		}
 	}
 
 	r.commands[name] = cmd
@@ -110,20 +141,137 @@ func (r *Registry) RegisterResourceFactory(factory ResourceFactory) error {
 	return nil
 }
 
-// GetCommand retrieves a Command by name. Returns nil if not found.
+// RegisterAlias registers old as a deprecated alias of the Command or Query
+// named target, so callers using a renamed unit keep working.
+// Returns ErrAliasConflict if old is already a unit or alias.
+// Returns ErrAliasTargetNotFound if target is not a registered Command or Query.
+func (r *Registry) RegisterAlias(old, target string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, isCommand := r.commands[old]
+	_, isQuery := r.queries[old]
+	_, isAlias := r.aliases[old]
+	if isCommand || isQuery || isAlias {
+		return ErrAliasConflict
+	}
+
+	_, isCommand = r.commands[target]
+	_, isQuery = r.queries[target]
+	if !isCommand && !isQuery {
+		return ErrAliasTargetNotFound
+	}
+
+	r.aliases[old] = target
+	return nil
+}
+
+// ListAliases returns a copy of the registered aliases, keyed by alias name.
+func (r *Registry) ListAliases() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]string, len(r.aliases))
+	for old, target := range r.aliases {
+		result[old] = target
+	}
+	return result
+}
+
+// resolve returns the unit name an alias points to, or name itself.
+// The caller must hold r.mu.
+func (r *Registry) resolve(name string) string {
+	if target, ok := r.aliases[name]; ok {
+		return target
+	}
+	return name
+}
+
+// DeprecationOf reports whether name is deprecated, either because it is an
+// alias or because the unit (or the "name@version" it selects) implements
+// DeprecatedUnit. Returns nil otherwise.
+func (r *Registry) DeprecationOf(name string) *Deprecation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name, version := SplitVersion(name)
+	if target, ok := r.aliases[name]; ok {
+		return &Deprecation{
+			Message:    fmt.Sprintf("%s is deprecated, use %s", name, target),
+			ReplacedBy: target,
+		}
+	}
+
+	var u any
+	if version != "" {
+		if cmd, ok := r.commandVersions[name][version]; ok {
+			u = cmd
+		}
+	} else if cmd, ok := r.commands[name]; ok {
+		u = cmd
+	} else if q, ok := r.queries[name]; ok {
+		u = q
+	}
+	if d, ok := u.(DeprecatedUnit); ok {
+		return d.Deprecation()
+	}
+	return nil
+}
+
+// CanonicalName returns the name of the unit ref refers to, without its
+// version and with aliases followed, e.g. "inference.chat" for
+// "inference.chat@v1".
+func (r *Registry) CanonicalName(ref string) string {
+	name, _ := SplitVersion(ref)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.resolve(name)
+}
+
+// GetCommand retrieves a Command by name, following aliases. A name of the
+// form "inference.chat@v1" selects that version; a bare name selects the
+// latest. Returns nil if not found.
 func (r *Registry) GetCommand(name string) Command {
+	base, version := SplitVersion(name)
+	return r.GetCommandVersion(base, version)
+}
+
+// GetCommandVersion retrieves a specific version of a Command, following
+// aliases. An empty version selects the latest. Returns nil if not found.
+func (r *Registry) GetCommandVersion(name, version string) Command {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name = r.resolve(name)
+	if version == "" {
+		return r.commands[name]
+	}
+	return r.commandVersions[name][version]
+}
+
+// CommandVersions returns the registered versions of a Command in ascending
+// order. Unversioned commands have no versions.
+func (r *Registry) CommandVersions(name string) []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	return r.commands[name]
+	versions := make([]string, 0, len(r.commandVersions[name]))
+	for v := range r.commandVersions[name] {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return CompareVersions(versions[i], versions[j]) < 0
+	})
+	return versions
 }
 
-// GetQuery retrieves a Query by name. Returns nil if not found.
+// GetQuery retrieves a Query by name, following aliases. Returns nil if not found.
 func (r *Registry) GetQuery(name string) Query {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	return r.queries[name]
+	return r.queries[r.resolve(name)]
 }
 
 // GetResource retrieves a Resource by URI. If not found directly, it tries
@@ -166,7 +314,8 @@ func (r *Registry) GetResourceWithFactory(uri string) Resource {
 	return nil
 }
 
-// ListCommands returns all registered Commands.
+// ListCommands returns all registered Commands, one per name. For versioned
+// commands this is the latest version; see ListCommandVersions.
 func (r *Registry) ListCommands() []Command {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -178,6 +327,26 @@ func (r *Registry) ListCommands() []Command {
 	return result
 }
 
+// ListCommandVersions returns every registered Command, including all
+// versions of versioned commands.
+func (r *Registry) ListCommandVersions() []Command {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Command, 0, len(r.commands))
+	for name, cmd := range r.commands {
+		versions, ok := r.commandVersions[name]
+		if !ok {
+			result = append(result, cmd)
+			continue
+		}
+		for _, v := range versions {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 // ListQueries returns all registered Queries.
 func (r *Registry) ListQueries() []Query {
 	r.mu.RLock()
@@ -241,6 +410,7 @@ func (r *Registry) UnregisterCommand(name string) bool {
 
 	if _, exists := r.commands[name]; exists {
 		delete(r.commands, name)
+		delete(r.commandVersions, name)
 		return true
 	}
 	return false
@@ -272,6 +442,56 @@ func (r *Registry) UnregisterResource(uri string) bool {
 	return false
 }
 
+// ReplaceCommand registers cmd, atomically replacing any command of the same
+// name together with all of its versions, so callers never observe the name
+// as missing while a command is hot-swapped.
+// Returns ErrCommandNotFound if cmd is nil.
+func (r *Registry) ReplaceCommand(cmd Command) error {
+	if cmd == nil {
+		return ErrCommandNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := cmd.Name()
+	delete(r.commandVersions, name)
+	if version := unitVersion(cmd); version != "" {
+		r.commandVersions[name] = map[string]Command{version: cmd}
+	}
+	r.commands[name] = cmd
+	return nil
+}
+
+// ReplaceQuery registers q, atomically replacing any query of the same name.
+// Returns ErrQueryNotFound if q is nil.
+func (r *Registry) ReplaceQuery(q Query) error {
+	if q == nil {
+		return ErrQueryNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.queries[q.Name()] = q
+	return nil
+}
+
+// ReplaceResource registers res, atomically replacing any resource with the
+// same URI.
+// Returns ErrResourceNotFound if res is nil.
+func (r *Registry) ReplaceResource(res Resource) error {
+	if res == nil {
+		return ErrResourceNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resources[res.URI()] = res
+	return nil
+}
+
 // CommandCount returns the number of registered Commands.
 func (r *Registry) CommandCount() int {
 	r.mu.RLock()
@@ -295,3 +515,52 @@ func (r *Registry) ResourceCount() int {
 
 	return len(r.resources)
 }
+
+// SplitVersion splits a "name@version" unit reference into its parts.
+// A reference without "@" has an empty version. A reference ending in "@"
+// names no version and is returned whole, so it matches no unit rather than
+// the latest one.
+func SplitVersion(ref string) (name, version string) {
+	if i := strings.LastIndex(ref, "@"); i >= 0 && i < len(ref)-1 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// CompareVersions orders versions like "v1", "v2" and "v1.10" numerically,
+// falling back to string comparison for non-numeric parts. It returns -1, 0
+// or 1.
+func CompareVersions(a, b string) int {
+	ap := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bp := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(ap) || i < len(bp); i++ {
+		var x, y string
+		if i < len(ap) {
+			x = ap[i]
+		}
+		if i < len(bp) {
+			y = bp[i]
+		}
+		xn, xerr := strconv.Atoi(x)
+		yn, yerr := strconv.Atoi(y)
+		switch {
+		case xerr == nil && yerr == nil:
+			if xn != yn {
+				if xn < yn {
+					return -1
+				}
+				return 1
+			}
+		case x != y:
+			return strings.Compare(x, y)
+		}
+	}
+	return 0
+}
+
+func unitVersion(u any) string {
+	if v, ok := u.(VersionedUnit); ok {
+		return v.Version()
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package unit
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -327,6 +328,42 @@ func TestUnregisterQuery(t *testing.T) {
 	}
 }
 
+func TestReplaceCommand(t *testing.T) {
+	r := NewRegistry()
+	_ = r.RegisterCommand(&regTestCommand{name: "model.pull", domain: "model"})
+
+	replacement := &regTestCommand{name: "model.pull", domain: "model"}
+	if err := r.ReplaceCommand(replacement); err != nil {
+		t.Fatalf("ReplaceCommand failed: %v", err)
+	}
+	if r.GetCommand("model.pull") != replacement {
+		t.Error("expected the replacement to be returned")
+	}
+	if r.CommandCount() != 1 {
+		t.Errorf("expected 1 command, got %d", r.CommandCount())
+	}
+
+	if err := r.ReplaceCommand(&regTestCommand{name: "model.delete", domain: "model"}); err != nil {
+		t.Errorf("expected ReplaceCommand to register a new name, got %v", err)
+	}
+	if err := r.ReplaceCommand(nil); err != ErrCommandNotFound {
+		t.Errorf("expected ErrCommandNotFound for nil, got %v", err)
+	}
+}
+
+func TestReplaceQuery(t *testing.T) {
+	r := NewRegistry()
+	_ = r.RegisterQuery(&regTestQuery{name: "model.list", domain: "model"})
+
+	replacement := &regTestQuery{name: "model.list", domain: "model"}
+	if err := r.ReplaceQuery(replacement); err != nil {
+		t.Fatalf("ReplaceQuery failed: %v", err)
+	}
+	if r.GetQuery("model.list") != replacement || r.QueryCount() != 1 {
+		t.Error("expected the replacement to be the only model.list query")
+	}
+}
+
 func TestUnregisterResource(t *testing.T) {
 	r := NewRegistry()
 	res := &regTestResource{uri: "asms://model/test", domain: "model"}
@@ -402,3 +439,226 @@ func TestConcurrentAccess(t *testing.T) {
 		t.Error("concurrent access test timed out")
 	}
 }
+
+// TestConcurrentMixedOperations exercises every mutating and reading method
+// at once; run with -race to check that all map access is locked.
+func TestConcurrentMixedOperations(t *testing.T) {
+	r := NewRegistry()
+	const workers = 20
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func(idx int) {
+			defer wg.Done()
+			name := fmt.Sprintf("test.cmd%d", idx)
+			_ = r.RegisterCommand(&regTestCommand{name: name, domain: "test"})
+			_ = r.RegisterQuery(&regTestQuery{name: fmt.Sprintf("test.query%d", idx), domain: "test"})
+			_ = r.RegisterResource(&regTestResource{uri: fmt.Sprintf("asms://test/%d", idx), domain: "test"})
+			_ = r.RegisterAlias(fmt.Sprintf("old.cmd%d", idx), name)
+			_ = r.RegisterResourceTemplate(fmt.Sprintf("asms://tmpl%d/{id}", idx), func(ctx context.Context, params map[string]string) (any, error) {
+				return params, nil
+			})
+			_ = r.ReplaceCommand(&regTestCommand{name: name, domain: "test"})
+			_ = r.RegisterCommand(&regTestCommand{name: "test.churn", domain: "test"})
+			r.UnregisterCommand("test.churn")
+		}(i)
+
+		go func(idx int) {
+			defer wg.Done()
+			_ = r.GetCommand(fmt.Sprintf("old.cmd%d", idx))
+			_ = r.GetQuery(fmt.Sprintf("test.query%d", idx))
+			_ = r.GetResourceWithFactory(fmt.Sprintf("asms://test/%d", idx))
+			_, _, _ = r.MatchResourceTemplate(fmt.Sprintf("asms://tmpl%d/x", idx))
+			_ = r.DeprecationOf(fmt.Sprintf("old.cmd%d", idx))
+			_ = r.Get(fmt.Sprintf("test.cmd%d", idx))
+			_ = r.ListCommands()
+			_ = r.ListCommandVersions()
+			_ = r.ListQueries()
+			_ = r.ListResources()
+			_ = r.ListAliases()
+			_ = r.ListResourceTemplates()
+			_ = r.CommandCount() + r.QueryCount() + r.ResourceCount()
+		}(i)
+	}
+	wg.Wait()
+
+	if got := r.CommandCount(); got != workers {
+		t.Errorf("expected %d commands, got %d", workers, got)
+	}
+	if got := r.QueryCount(); got != workers {
+		t.Errorf("expected %d queries, got %d", workers, got)
+	}
+	if got := len(r.ListAliases()); got != workers {
+		t.Errorf("expected %d aliases, got %d", workers, got)
+	}
+	if got := len(r.ListResourceTemplates()); got != workers {
+		t.Errorf("expected %d resource templates, got %d", workers, got)
+	}
+}
+
+type regDeprecatedCommand struct {
+	regTestCommand
+}
+
+func (m *regDeprecatedCommand) Deprecation() *Deprecation {
+	return &Deprecation{Message: "use inference.generate", ReplacedBy: "inference.generate"}
+}
+
+func TestRegistry_RegisterAlias(t *testing.T) {
+	r := NewRegistry()
+	_ = r.RegisterCommand(&regTestCommand{name: "inference.generate", domain: "inference"})
+	_ = r.RegisterQuery(&regTestQuery{name: "model.list", domain: "model"})
+
+	if err := r.RegisterAlias("inference.complete", "inference.generate"); err != nil {
+		t.Fatalf("RegisterAlias failed: %v", err)
+	}
+	if err := r.RegisterAlias("model.ls", "model.list"); err != nil {
+		t.Fatalf("RegisterAlias for query failed: %v", err)
+	}
+
+	if cmd := r.GetCommand("inference.complete"); cmd == nil || cmd.Name() != "inference.generate" {
+		t.Errorf("expected alias to resolve to inference.generate, got %v", cmd)
+	}
+	if q := r.GetQuery("model.ls"); q == nil || q.Name() != "model.list" {
+		t.Errorf("expected alias to resolve to model.list, got %v", q)
+	}
+
+	if err := r.RegisterAlias("inference.complete", "inference.generate"); err != ErrAliasConflict {
+		t.Errorf("expected ErrAliasConflict for duplicate alias, got %v", err)
+	}
+	if err := r.RegisterAlias("model.list", "inference.generate"); err != ErrAliasConflict {
+		t.Errorf("expected ErrAliasConflict when shadowing a unit, got %v", err)
+	}
+	if err := r.RegisterAlias("old.name", "missing.unit"); err != ErrAliasTargetNotFound {
+		t.Errorf("expected ErrAliasTargetNotFound, got %v", err)
+	}
+
+	aliases := r.ListAliases()
+	if len(aliases) != 2 || aliases["inference.complete"] != "inference.generate" {
+		t.Errorf("unexpected aliases: %v", aliases)
+	}
+	if r.CommandCount() != 1 {
+		t.Errorf("expected aliases not to count as commands, got %d", r.CommandCount())
+	}
+}
+
+func TestRegistry_DeprecationOf(t *testing.T) {
+	r := NewRegistry()
+	_ = r.RegisterCommand(&regTestCommand{name: "inference.generate", domain: "inference"})
+	_ = r.RegisterCommand(&regDeprecatedCommand{regTestCommand{name: "inference.legacy", domain: "inference"}})
+	_ = r.RegisterAlias("inference.complete", "inference.generate")
+
+	if dep := r.DeprecationOf("inference.generate"); dep != nil {
+		t.Errorf("expected no deprecation, got %+v", dep)
+	}
+
+	dep := r.DeprecationOf("inference.complete")
+	if dep == nil || dep.ReplacedBy != "inference.generate" {
+		t.Errorf("expected alias deprecation pointing at inference.generate, got %+v", dep)
+	}
+
+	dep = r.DeprecationOf("inference.legacy")
+	if dep == nil || dep.ReplacedBy != "inference.generate" {
+		t.Errorf("expected unit-declared deprecation, got %+v", dep)
+	}
+}
+
+type regVersionedCommand struct {
+	regTestCommand
+	version string
+}
+
+func (m *regVersionedCommand) Version() string { return m.version }
+
+func TestRegistry_CommandVersions(t *testing.T) {
+	r := NewRegistry()
+	v2 := &regVersionedCommand{regTestCommand{name: "inference.chat", domain: "inference"}, "v2"}
+	v1 := &regVersionedCommand{regTestCommand{name: "inference.chat", domain: "inference"}, "v1"}
+
+	if err := r.RegisterCommand(v2); err != nil {
+		t.Fatalf("register v2: %v", err)
+	}
+	if err := r.RegisterCommand(v1); err != nil {
+		t.Fatalf("register v1: %v", err)
+	}
+	if err := r.RegisterCommand(&regVersionedCommand{regTestCommand{name: "inference.chat"}, "v1"}); err != ErrCommandAlreadyRegistered {
+		t.Errorf("expected ErrCommandAlreadyRegistered for duplicate version, got %v", err)
+	}
+	if err := r.RegisterCommand(&regTestCommand{name: "inference.chat"}); err != ErrCommandAlreadyRegistered {
+		t.Errorf("expected ErrCommandAlreadyRegistered for unversioned duplicate, got %v", err)
+	}
+
+	if got := r.GetCommand("inference.chat"); got != v2 {
+		t.Errorf("expected latest version by default, got %v", got)
+	}
+	if got := r.GetCommand("inference.chat@v1"); got != v1 {
+		t.Errorf("expected v1 for name@v1, got %v", got)
+	}
+	if got := r.GetCommandVersion("inference.chat", "v1"); got != v1 {
+		t.Errorf("expected v1 from GetCommandVersion, got %v", got)
+	}
+	if got := r.GetCommand("inference.chat@v3"); got != nil {
+		t.Errorf("expected nil for unknown version, got %v", got)
+	}
+	if got := r.GetCommand("inference.chat@"); got != nil {
+		t.Errorf("expected nil for an empty version, got %v", got)
+	}
+
+	versions := r.CommandVersions("inference.chat")
+	if len(versions) != 2 || versions[0] != "v1" || versions[1] != "v2" {
+		t.Errorf("expected [v1 v2], got %v", versions)
+	}
+	if n := len(r.ListCommandVersions()); n != 2 {
+		t.Errorf("expected both versions listed, got %d", n)
+	}
+	if n := len(r.ListCommands()); n != 1 {
+		t.Errorf("expected one command per name, got %d", n)
+	}
+
+	r.UnregisterCommand("inference.chat")
+	if r.GetCommand("inference.chat@v1") != nil {
+		t.Error("expected all versions removed on unregister")
+	}
+}
+
+func TestRegistry_CanonicalName(t *testing.T) {
+	r := NewRegistry()
+	_ = r.RegisterCommand(&regTestCommand{name: "inference.generate"})
+	_ = r.RegisterAlias("inference.complete", "inference.generate")
+
+	tests := map[string]string{
+		"inference.generate":    "inference.generate",
+		"inference.generate@v1": "inference.generate",
+		"inference.complete":    "inference.generate",
+		"inference.complete@v2": "inference.generate",
+		"inference.generate@":   "inference.generate@",
+		"unknown.unit":          "unknown.unit",
+	}
+	for ref, want := range tests {
+		if got := r.CanonicalName(ref); got != want {
+			t.Errorf("CanonicalName(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1", "v2", -1},
+		{"v2", "v1", 1},
+		{"v1", "v1", 0},
+		{"v2", "v10", -1},
+		{"v1.2", "v1.10", -1},
+		{"v1", "v1.1", -1},
+		{"v1beta", "v1alpha", 1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
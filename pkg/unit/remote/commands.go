@@ -55,7 +55,7 @@ func (c *EnableCommand) InputSchema() unit.Schema {
 					Description: "Optional tunnel configuration",
 					Properties: map[string]unit.Field{
 						"server":     {Name: "server", Schema: unit.Schema{Type: "string"}},
-						"token":      {Name: "token", Schema: unit.Schema{Type: "string"}},
+						"token":      {Name: "token", Schema: unit.Schema{Type: "string", Sensitive: true}},
 						"expose_api": {Name: "expose_api", Schema: unit.Schema{Type: "boolean"}},
 						"expose_mcp": {Name: "expose_mcp", Schema: unit.Schema{Type: "boolean"}},
 					},
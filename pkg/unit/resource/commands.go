@@ -423,6 +423,236 @@ func (c *UpdateSlotCommand) Execute(ctx context.Context, input any) (any, error)
 	return output, nil
 }
 
+const (
+	defaultWatchInterval = time.Second
+	minWatchInterval     = 100 * time.Millisecond
+)
+
+// WatchCommand streams resource telemetry by polling the provider at a fixed
+// interval until the caller cancels.
+// GPUSource reports the current usage of each GPU. resource.watch samples
+// it when the resource provider reports no GPUs of its own.
+type GPUSource interface {
+	GPUs(ctx context.Context) ([]GPUInfo, error)
+}
+
+type WatchCommand struct {
+	provider ResourceProvider
+	gpus     GPUSource
+	events   unit.EventPublisher
+}
+
+func NewWatchCommand(provider ResourceProvider) *WatchCommand {
+	return &WatchCommand{provider: provider}
+}
+
+func NewWatchCommandWithEvents(provider ResourceProvider, events unit.EventPublisher) *WatchCommand {
+	return &WatchCommand{provider: provider, events: events}
+}
+
+// WithGPUSource makes the command sample GPUs from gpus when the resource
+// provider reports none.
+func (c *WatchCommand) WithGPUSource(gpus GPUSource) *WatchCommand {
+	c.gpus = gpus
+	return c
+}
+
+func (c *WatchCommand) Name() string {
+	return "resource.watch"
+}
+
+func (c *WatchCommand) Domain() string {
+	return "resource"
+}
+
+func (c *WatchCommand) Description() string {
+	return "Stream live GPU and memory telemetry at a fixed interval"
+}
+
+func (c *WatchCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"interval_ms": {
+				Name: "interval_ms",
+				Schema: unit.Schema{
+					Type:        "number",
+					Description: "Polling interval in milliseconds (default 1000)",
+					Min:         ptrs.Float64(float64(minWatchInterval.Milliseconds())),
+				},
+			},
+		},
+	}
+}
+
+func (c *WatchCommand) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"timestamp": {Name: "timestamp", Schema: unit.Schema{Type: "number"}},
+			"memory": {
+				Name: "memory",
+				Schema: unit.Schema{
+					Type: "object",
+					Properties: map[string]unit.Field{
+						"total": {Name: "total", Schema: unit.Schema{Type: "number"}},
+						"used":  {Name: "used", Schema: unit.Schema{Type: "number"}},
+						"free":  {Name: "free", Schema: unit.Schema{Type: "number"}},
+					},
+				},
+			},
+			"gpus": {
+				Name: "gpus",
+				Schema: unit.Schema{
+					Type: "array",
+					Items: &unit.Schema{
+						Type: "object",
+						Properties: map[string]unit.Field{
+							"index":       {Name: "index", Schema: unit.Schema{Type: "number"}},
+							"name":        {Name: "name", Schema: unit.Schema{Type: "string"}},
+							"utilization": {Name: "utilization", Schema: unit.Schema{Type: "number"}},
+							"memory_used": {Name: "memory_used", Schema: unit.Schema{Type: "number"}},
+							"memory_free": {Name: "memory_free", Schema: unit.Schema{Type: "number"}},
+							"temperature": {Name: "temperature", Schema: unit.Schema{Type: "number"}},
+						},
+					},
+				},
+			},
+			"pressure":  {Name: "pressure", Schema: unit.Schema{Type: "string"}},
+			"gpu_error": {Name: "gpu_error", Schema: unit.Schema{Type: "string", Description: "Why GPUs could not be sampled, when they could not"}},
+		},
+	}
+}
+
+func (c *WatchCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input: map[string]any{"interval_ms": 2000},
+			Output: map[string]any{
+				"timestamp": 1700000000,
+				"memory":    map[string]any{"total": 68719476736, "used": 34359738368, "free": 34359738368},
+				"gpus": []map[string]any{
+					{"index": 0, "name": "NVIDIA GB10", "utilization": 42.0, "memory_used": 8589934592, "memory_free": 25769803776, "temperature": 55.0},
+				},
+				"pressure": "low",
+			},
+			Description: "Stream a telemetry sample every two seconds",
+		},
+	}
+}
+
+// Execute returns a single telemetry sample; use ExecuteStream for a live feed.
+func (c *WatchCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.provider == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	sample, err := c.sample(ctx)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, err
+	}
+	ec.PublishCompleted(sample)
+	return sample, nil
+}
+
+func (c *WatchCommand) SupportsStreaming() bool {
+	return true
+}
+
+// ExecuteStream emits a "content" chunk immediately and then once per
+// interval. It returns nil when ctx is cancelled.
+func (c *WatchCommand) ExecuteStream(ctx context.Context, input any, stream chan<- unit.StreamChunk) error {
+	if c.provider == nil {
+		return ErrProviderNotSet
+	}
+
+	interval := defaultWatchInterval
+	inputMap, _ := input.(map[string]any)
+	if ms, ok := toInt(inputMap["interval_ms"]); ok {
+		interval = time.Duration(ms) * time.Millisecond
+		if interval < minWatchInterval {
+			return fmt.Errorf("interval_ms must be at least %d: %w", minWatchInterval.Milliseconds(), ErrInvalidInterval)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sample, err := c.sample(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		select {
+		case stream <- unit.StreamChunk{Type: "content", Data: sample}:
+		case <-ctx.Done():
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *WatchCommand) sample(ctx context.Context) (map[string]any, error) {
+	status, err := c.provider.GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get resource status: %w", err)
+	}
+
+	// Hosts without GPUs, or whose GPU tooling fails, still get memory
+	// samples; the GPU error is reported alongside them.
+	gpuInfos := status.GPUs
+	var gpuErr error
+	if len(gpuInfos) == 0 && c.gpus != nil {
+		gpuInfos, gpuErr = c.gpus.GPUs(ctx)
+	}
+
+	gpus := make([]map[string]any, 0, len(gpuInfos))
+	for _, g := range gpuInfos {
+		free := uint64(0)
+		if g.MemoryTotal > g.MemoryUsed {
+			free = g.MemoryTotal - g.MemoryUsed
+		}
+		gpus = append(gpus, map[string]any{
+			"index":       g.Index,
+			"name":        g.Name,
+			"utilization": g.Utilization,
+			"memory_used": g.MemoryUsed,
+			"memory_free": free,
+			"temperature": g.Temperature,
+		})
+	}
+
+	sample := map[string]any{
+		"timestamp": time.Now().Unix(),
+		"memory": map[string]any{
+			"total": status.Memory.Total,
+			"used":  status.Memory.Used,
+			"free":  status.Memory.Available,
+		},
+		"gpus":     gpus,
+		"pressure": string(status.Pressure),
+	}
+	if gpuErr != nil {
+		sample["gpu_error"] = gpuErr.Error()
+	}
+	return sample, nil
+}
+
 func toFloat64(v any) (float64, bool) {
 	switch val := v.(type) {
 	case float64:
@@ -3,7 +3,9 @@ package resource
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 )
@@ -431,3 +433,140 @@ func TestCommandImplementsInterface(t *testing.T) {
 	var _ unit.Command = NewReleaseCommand(nil)
 	var _ unit.Command = NewUpdateSlotCommand(nil)
 }
+
+// sequenceProvider returns a GPU sample whose values change on every call.
+type sequenceProvider struct {
+	MockProvider
+	mu    sync.Mutex
+	calls int
+}
+
+func (p *sequenceProvider) GetStatus(ctx context.Context) (*ResourceStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	n := uint64(p.calls)
+	return &ResourceStatus{
+		Memory: MemoryInfo{Total: 100, Used: 10 * n, Available: 100 - 10*n},
+		GPUs: []GPUInfo{
+			{Index: 0, Name: "GPU0", Utilization: float64(20 * n), MemoryTotal: 1000, MemoryUsed: 100 * n, Temperature: float64(40 + n)},
+		},
+		Pressure: PressureLevelLow,
+	}, nil
+}
+
+func TestWatchCommand_ExecuteStream(t *testing.T) {
+	cmd := NewWatchCommand(&sequenceProvider{})
+	if cmd.Name() != "resource.watch" || !cmd.SupportsStreaming() {
+		t.Fatalf("unexpected command metadata: %s", cmd.Name())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := make(chan unit.StreamChunk)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cmd.ExecuteStream(ctx, map[string]any{"interval_ms": 100}, stream)
+	}()
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case chunk := <-stream:
+			if chunk.Type != "content" {
+				t.Fatalf("tick %d: expected content chunk, got %s", i, chunk.Type)
+			}
+			data := chunk.Data.(map[string]any)
+			mem := data["memory"].(map[string]any)
+			if mem["used"] != uint64(10*i) || mem["free"] != uint64(100-10*i) {
+				t.Errorf("tick %d: unexpected memory %v", i, mem)
+			}
+			gpu := data["gpus"].([]map[string]any)[0]
+			if gpu["utilization"] != float64(20*i) {
+				t.Errorf("tick %d: expected utilization %d, got %v", i, 20*i, gpu["utilization"])
+			}
+			if gpu["memory_free"] != uint64(1000-100*i) {
+				t.Errorf("tick %d: expected memory_free %d, got %v", i, 1000-100*i, gpu["memory_free"])
+			}
+			if gpu["temperature"] != float64(40+i) {
+				t.Errorf("tick %d: expected temperature %d, got %v", i, 40+i, gpu["temperature"])
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("tick %d: timed out waiting for chunk", i)
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Errorf("expected nil error on cancel, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not stop after cancel")
+	}
+}
+
+func TestWatchCommand_ExecuteStream_Errors(t *testing.T) {
+	stream := make(chan unit.StreamChunk, 1)
+
+	if err := NewWatchCommand(nil).ExecuteStream(context.Background(), nil, stream); !errors.Is(err, ErrProviderNotSet) {
+		t.Errorf("expected ErrProviderNotSet, got %v", err)
+	}
+
+	err := NewWatchCommand(&MockProvider{}).ExecuteStream(context.Background(), map[string]any{"interval_ms": 10}, stream)
+	if !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("expected ErrInvalidInterval, got %v", err)
+	}
+
+	err = NewWatchCommand(&MockProvider{statusErr: errors.New("nvml unavailable")}).ExecuteStream(context.Background(), nil, stream)
+	if err == nil {
+		t.Error("expected provider error to be returned")
+	}
+}
+
+// staticGPUs is a GPUSource reporting fixed GPUs or an error.
+type staticGPUs struct {
+	gpus []GPUInfo
+	err  error
+}
+
+func (s staticGPUs) GPUs(ctx context.Context) ([]GPUInfo, error) {
+	return s.gpus, s.err
+}
+
+func TestWatchCommand_GPUSource(t *testing.T) {
+	// MockProvider reports no GPUs, like the system resource provider.
+	cmd := NewWatchCommand(&MockProvider{}).WithGPUSource(staticGPUs{gpus: []GPUInfo{
+		{Index: 0, Name: "NVIDIA A100", Utilization: 75, MemoryTotal: 1000, MemoryUsed: 400, Temperature: 55},
+	}})
+
+	result, err := cmd.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	gpus := result.(map[string]any)["gpus"].([]map[string]any)
+	if len(gpus) != 1 || gpus[0]["name"] != "NVIDIA A100" || gpus[0]["memory_free"] != uint64(600) {
+		t.Errorf("expected the GPU from the source, got %v", gpus)
+	}
+
+	cmd = NewWatchCommand(&MockProvider{}).WithGPUSource(staticGPUs{err: errors.New("nvidia-smi not found")})
+	result, err = cmd.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected a GPU failure not to fail the sample: %v", err)
+	}
+	data := result.(map[string]any)
+	if data["gpu_error"] != "nvidia-smi not found" || len(data["gpus"].([]map[string]any)) != 0 {
+		t.Errorf("expected the GPU error in the sample, got %v", data)
+	}
+}
+
+func TestWatchCommand_Execute(t *testing.T) {
+	result, err := NewWatchCommand(&sequenceProvider{}).Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gpus := result.(map[string]any)["gpus"].([]map[string]any); len(gpus) != 1 {
+		t.Errorf("expected one GPU sample, got %d", len(gpus))
+	}
+}
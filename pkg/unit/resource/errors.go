@@ -19,4 +19,5 @@ var (
 	ErrSlotAlreadyExists  = unit.NewError(unit.ErrCodeAlreadyExists, "slot already exists")
 	ErrInvalidSlotType    = unit.NewError(unit.ErrCodeInvalidInput, "invalid slot type")
 	ErrInvalidSlotStatus  = unit.NewError(unit.ErrCodeInvalidInput, "invalid slot status")
+	ErrInvalidInterval    = unit.NewError(unit.ErrCodeInvalidInput, "invalid watch interval")
 )
@@ -0,0 +1,133 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// gpuEpsilon absorbs float rounding when GPU fractions add up to exactly 1.
+const gpuEpsilon = 1e-9
+
+// Reservation is the memory and GPU share held by one consumer, typically a
+// service that is starting or running. GPUFraction is the share of a single
+// GPU each replica holds, and Devices lists the GPU index each replica's
+// share was placed on.
+type Reservation struct {
+	ID          string  `json:"id"`
+	Memory      uint64  `json:"memory"`
+	GPUFraction float64 `json:"gpu_fraction"`
+	Devices     []int   `json:"devices,omitempty"`
+}
+
+// Ledger serializes admission decisions. CanAllocate alone is a
+// point-in-time check, so two starts racing each other can both pass it and
+// then overcommit; Reserve instead records the claim and checks it against
+// everything already reserved under one lock, and the claim stays until
+// Release.
+type Ledger struct {
+	mu           sync.Mutex
+	provider     ResourceProvider
+	gpus         int
+	reservations map[string]Reservation
+}
+
+// NewLedger returns an empty ledger for a host with one GPU. Memory claims
+// are checked with provider.CanAllocate, counting outstanding reservations as
+// used; a nil provider checks only GPU shares.
+func NewLedger(provider ResourceProvider) *Ledger {
+	return &Ledger{provider: provider, gpus: 1, reservations: make(map[string]Reservation)}
+}
+
+// WithGPUCount sets how many GPUs the ledger places shares on. Counts below
+// one are treated as one.
+func (l *Ledger) WithGPUCount(n int) *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.gpus = max(n, 1)
+	return l
+}
+
+// Reserve claims memory and, for each of replicas, gpuFraction of a single
+// GPU for id. A new claim for an id that already holds one replaces it. Each
+// replica's share is placed on the GPU with the most room left. It fails
+// with ErrResourceInsufficient, reserving nothing, when some share fits on
+// no GPU or the provider cannot fit the memory on top of what is already
+// reserved.
+func (l *Ledger) Reserve(ctx context.Context, id string, memory uint64, gpuFraction float64, replicas int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var reservedMemory uint64
+	used := make([]float64, l.gpus)
+	for other, r := range l.reservations {
+		if other == id {
+			continue
+		}
+		reservedMemory += r.Memory
+		for _, d := range r.Devices {
+			if d < len(used) {
+				used[d] += r.GPUFraction
+			}
+		}
+	}
+
+	var devices []int
+	if gpuFraction > 0 {
+		for i := 0; i < max(replicas, 1); i++ {
+			d := leastUsed(used)
+			if used[d]+gpuFraction > 1+gpuEpsilon {
+				return fmt.Errorf("%s needs %.2f of a GPU for replica %d but the freest GPU already has %.2f reserved: %w", id, gpuFraction, i+1, used[d], ErrResourceInsufficient)
+			}
+			used[d] += gpuFraction
+			devices = append(devices, d)
+		}
+	}
+	if memory > 0 && l.provider != nil {
+		result, err := l.provider.CanAllocate(ctx, reservedMemory+memory, 5)
+		if err != nil {
+			return fmt.Errorf("check allocation: %w", err)
+		}
+		if !result.CanAllocate {
+			reason := result.Reason
+			if reason == "" {
+				reason = "insufficient memory"
+			}
+			return fmt.Errorf("%s needs %d bytes with %d already reserved: %s: %w", id, memory, reservedMemory, reason, ErrResourceInsufficient)
+		}
+	}
+
+	l.reservations[id] = Reservation{ID: id, Memory: memory, GPUFraction: gpuFraction, Devices: devices}
+	return nil
+}
+
+// leastUsed returns the index of the GPU with the smallest reserved share.
+func leastUsed(used []float64) int {
+	best := 0
+	for d := range used {
+		if used[d] < used[best] {
+			best = d
+		}
+	}
+	return best
+}
+
+// Release drops the claim held by id, if any.
+func (l *Ledger) Release(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.reservations, id)
+}
+
+// Reservations returns the outstanding claims sorted by ID.
+func (l *Ledger) Reservations() []Reservation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]Reservation, 0, len(l.reservations))
+	for _, r := range l.reservations {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
@@ -0,0 +1,132 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// capacityProvider admits allocations up to a fixed number of bytes.
+type capacityProvider struct {
+	MockProvider
+	capacity uint64
+}
+
+func (p *capacityProvider) CanAllocate(ctx context.Context, memoryBytes uint64, priority int) (*CanAllocateResult, error) {
+	if memoryBytes > p.capacity {
+		return &CanAllocateResult{CanAllocate: false, Reason: "insufficient memory"}, nil
+	}
+	return &CanAllocateResult{CanAllocate: true}, nil
+}
+
+func TestLedger_ConcurrentGPUReservations(t *testing.T) {
+	l := NewLedger(nil)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, id := range []string{"svc-vllm-a", "svc-vllm-b"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			errs <- l.Reserve(context.Background(), id, 0, 0.6, 1)
+		}(id)
+	}
+	wg.Wait()
+	close(errs)
+
+	admitted, refused := 0, 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			admitted++
+		case errors.Is(err, ErrResourceInsufficient):
+			refused++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if admitted != 1 || refused != 1 {
+		t.Errorf("expected one admitted and one refused, got %d and %d", admitted, refused)
+	}
+	if got := l.Reservations(); len(got) != 1 {
+		t.Errorf("expected one reservation, got %+v", got)
+	}
+}
+
+func TestLedger_GPUsPerDevice(t *testing.T) {
+	ctx := context.Background()
+	l := NewLedger(nil).WithGPUCount(2)
+
+	// Each 0.6 share needs a GPU of its own.
+	if err := l.Reserve(ctx, "svc-a", 0, 0.6, 1); err != nil {
+		t.Fatalf("first service should fit: %v", err)
+	}
+	if err := l.Reserve(ctx, "svc-b", 0, 0.6, 1); err != nil {
+		t.Fatalf("second service should fit on the other GPU: %v", err)
+	}
+	if err := l.Reserve(ctx, "svc-c", 0, 0.6, 1); !errors.Is(err, ErrResourceInsufficient) {
+		t.Fatalf("expected ErrResourceInsufficient with both GPUs taken, got %v", err)
+	}
+	// A smaller share still fits next to either.
+	if err := l.Reserve(ctx, "svc-c", 0, 0.4, 1); err != nil {
+		t.Fatalf("a 0.4 share should fit: %v", err)
+	}
+}
+
+func TestLedger_Replicas(t *testing.T) {
+	ctx := context.Background()
+
+	// Two replicas of 0.6 cannot share one GPU.
+	if err := NewLedger(nil).Reserve(ctx, "svc-a", 0, 0.6, 2); !errors.Is(err, ErrResourceInsufficient) {
+		t.Fatalf("expected ErrResourceInsufficient on one GPU, got %v", err)
+	}
+
+	// On two GPUs each replica gets its own.
+	l := NewLedger(nil).WithGPUCount(2)
+	if err := l.Reserve(ctx, "svc-a", 0, 0.6, 2); err != nil {
+		t.Fatalf("two replicas should fit on two GPUs: %v", err)
+	}
+	got := l.Reservations()
+	if len(got) != 1 || len(got[0].Devices) != 2 || got[0].Devices[0] == got[0].Devices[1] {
+		t.Errorf("expected the replicas on distinct GPUs, got %+v", got)
+	}
+
+	// Two replicas of 0.3 share a single GPU.
+	if err := NewLedger(nil).Reserve(ctx, "svc-b", 0, 0.3, 2); err != nil {
+		t.Fatalf("two 0.3 replicas should share one GPU: %v", err)
+	}
+}
+
+func TestLedger_Memory(t *testing.T) {
+	ctx := context.Background()
+	l := NewLedger(&capacityProvider{capacity: 10})
+
+	if err := l.Reserve(ctx, "a", 6, 0, 1); err != nil {
+		t.Fatalf("first reservation: %v", err)
+	}
+	if err := l.Reserve(ctx, "b", 6, 0, 1); !errors.Is(err, ErrResourceInsufficient) {
+		t.Errorf("expected the second reservation to be refused, got %v", err)
+	}
+	if err := l.Reserve(ctx, "a", 8, 0, 1); err != nil {
+		t.Errorf("expected a new claim for the same id to replace the old one, got %v", err)
+	}
+
+	l.Release("a")
+	if err := l.Reserve(ctx, "b", 6, 0, 1); err != nil {
+		t.Errorf("expected release to free the memory, got %v", err)
+	}
+	if got := l.Reservations(); len(got) != 1 || got[0].ID != "b" || got[0].Memory != 6 {
+		t.Errorf("unexpected reservations: %+v", got)
+	}
+}
+
+func TestLedger_ProviderError(t *testing.T) {
+	l := NewLedger(&MockProvider{canAllocateErr: errors.New("metrics unavailable")})
+	if err := l.Reserve(context.Background(), "a", 1, 0, 1); err == nil {
+		t.Fatal("expected the provider error")
+	}
+	if got := l.Reservations(); len(got) != 0 {
+		t.Errorf("expected nothing reserved after an error, got %+v", got)
+	}
+}
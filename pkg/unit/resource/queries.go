@@ -3,6 +3,8 @@ package resource
 import (
 	"context"
 	"fmt"
+	"math"
+	"runtime"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/ptrs"
@@ -536,3 +538,190 @@ func (q *CanAllocateQuery) Execute(ctx context.Context, input any) (any, error)
 	ec.PublishCompleted(output)
 	return output, nil
 }
+
+// SummaryQuery compares the host's capacity with what running services have
+// claimed, for capacity planning.
+type SummaryQuery struct {
+	provider    ResourceProvider
+	allocations ServiceAllocationProvider
+	events      unit.EventPublisher
+}
+
+func NewSummaryQuery(provider ResourceProvider, allocations ServiceAllocationProvider) *SummaryQuery {
+	return &SummaryQuery{provider: provider, allocations: allocations}
+}
+
+func NewSummaryQueryWithEvents(provider ResourceProvider, allocations ServiceAllocationProvider, events unit.EventPublisher) *SummaryQuery {
+	return &SummaryQuery{provider: provider, allocations: allocations, events: events}
+}
+
+func (q *SummaryQuery) Name() string {
+	return "resource.summary"
+}
+
+func (q *SummaryQuery) Domain() string {
+	return "resource"
+}
+
+func (q *SummaryQuery) Description() string {
+	return "Summarize total versus allocated memory, CPU and GPU across running services, with headroom and overcommit"
+}
+
+func (q *SummaryQuery) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type:       "object",
+		Properties: map[string]unit.Field{},
+	}
+}
+
+func (q *SummaryQuery) OutputSchema() unit.Schema {
+	capacity := func(description string) unit.Field {
+		return unit.Field{
+			Schema: unit.Schema{
+				Type:        "object",
+				Description: description,
+				Properties: map[string]unit.Field{
+					"total":      {Name: "total", Schema: unit.Schema{Type: "number"}},
+					"allocated":  {Name: "allocated", Schema: unit.Schema{Type: "number"}},
+					"headroom":   {Name: "headroom", Schema: unit.Schema{Type: "number"}},
+					"overcommit": {Name: "overcommit", Schema: unit.Schema{Type: "number"}},
+				},
+			},
+		}
+	}
+	memory := capacity("Memory in bytes; total excludes the reserved budget")
+	memory.Name = "memory"
+	cpu := capacity("CPU cores")
+	cpu.Name = "cpu"
+	gpu := capacity("GPUs, with fractional allocations")
+	gpu.Name = "gpu"
+
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"memory": memory,
+			"cpu":    cpu,
+			"gpu":    gpu,
+			"services": {
+				Name: "services",
+				Schema: unit.Schema{
+					Type: "array",
+					Items: &unit.Schema{
+						Type: "object",
+						Properties: map[string]unit.Field{
+							"service_id":   {Name: "service_id", Schema: unit.Schema{Type: "string"}},
+							"memory":       {Name: "memory", Schema: unit.Schema{Type: "number"}},
+							"cpu":          {Name: "cpu", Schema: unit.Schema{Type: "number"}},
+							"gpu_fraction": {Name: "gpu_fraction", Schema: unit.Schema{Type: "number"}},
+						},
+					},
+				},
+			},
+			"overcommitted": {Name: "overcommitted", Schema: unit.Schema{Type: "boolean"}},
+		},
+	}
+}
+
+func (q *SummaryQuery) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input: map[string]any{},
+			Output: map[string]any{
+				"memory": map[string]any{"total": 51539607552, "allocated": 34359738368, "headroom": 17179869184, "overcommit": 0},
+				"cpu":    map[string]any{"total": 16, "allocated": 6, "headroom": 10, "overcommit": 0},
+				"gpu":    map[string]any{"total": 1, "allocated": 0.9, "headroom": 0.1, "overcommit": 0},
+				"services": []map[string]any{
+					{"service_id": "svc-vllm-model-abc", "memory": 34359738368, "cpu": 6, "gpu_fraction": 0.9},
+				},
+				"overcommitted": false,
+			},
+			Description: "Summarize capacity versus allocations",
+		},
+	}
+}
+
+func (q *SummaryQuery) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(q.events, q.Domain(), q.Name())
+	ec.PublishStarted(input)
+
+	if q.provider == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	status, err := q.provider.GetStatus(ctx)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("get resource status: %w", err)
+	}
+	budget, err := q.provider.GetBudget(ctx)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("get resource budget: %w", err)
+	}
+
+	var allocations []ServiceAllocation
+	if q.allocations != nil {
+		allocations, err = q.allocations.ServiceAllocations(ctx)
+		if err != nil {
+			ec.PublishFailed(err)
+			return nil, fmt.Errorf("get service allocations: %w", err)
+		}
+	}
+
+	var memAllocated uint64
+	var cpuAllocated, gpuAllocated float64
+	services := make([]map[string]any, 0, len(allocations))
+	for _, a := range allocations {
+		memAllocated += a.Memory
+		cpuAllocated += a.CPU
+		gpuAllocated += a.GPUFraction
+		services = append(services, map[string]any{
+			"service_id":   a.ServiceID,
+			"memory":       a.Memory,
+			"cpu":          a.CPU,
+			"gpu_fraction": a.GPUFraction,
+		})
+	}
+
+	var memTotal uint64
+	if budget.Total > budget.Reserved {
+		memTotal = budget.Total - budget.Reserved
+	}
+	memory := map[string]any{
+		"total":      memTotal,
+		"allocated":  memAllocated,
+		"headroom":   uint64(0),
+		"overcommit": uint64(0),
+	}
+	if memAllocated > memTotal {
+		memory["overcommit"] = memAllocated - memTotal
+	} else {
+		memory["headroom"] = memTotal - memAllocated
+	}
+
+	cpu := floatCapacity(float64(runtime.NumCPU()), cpuAllocated)
+	gpu := floatCapacity(float64(len(status.GPUs)), gpuAllocated)
+
+	output := map[string]any{
+		"memory":        memory,
+		"cpu":           cpu,
+		"gpu":           gpu,
+		"services":      services,
+		"overcommitted": memAllocated > memTotal || cpu["overcommit"].(float64) > 0 || gpu["overcommit"].(float64) > 0,
+	}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+// floatCapacity reports total, allocated, headroom and overcommit for a
+// resource counted in fractional units.
+func floatCapacity(total, allocated float64) map[string]any {
+	return map[string]any{
+		"total":      total,
+		"allocated":  allocated,
+		"headroom":   math.Max(total-allocated, 0),
+		"overcommit": math.Max(allocated-total, 0),
+	}
+}
@@ -426,3 +426,93 @@ func TestQueryImplementsInterface(t *testing.T) {
 	var _ unit.Query = NewAllocationsQuery(nil)
 	var _ unit.Query = NewCanAllocateQuery(nil)
 }
+
+type mockAllocationProvider struct {
+	allocations []ServiceAllocation
+	err         error
+}
+
+func (m *mockAllocationProvider) ServiceAllocations(ctx context.Context) ([]ServiceAllocation, error) {
+	return m.allocations, m.err
+}
+
+func TestSummaryQuery_Execute(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+	provider := &MockProvider{
+		status: &ResourceStatus{GPUs: []GPUInfo{{Index: 0}, {Index: 1}}},
+		budget: &ResourceBudget{Total: 64 * gib, Reserved: 16 * gib},
+	}
+	allocations := &mockAllocationProvider{allocations: []ServiceAllocation{
+		{ServiceID: "svc-vllm-a", Memory: 32 * gib, CPU: 4, GPUFraction: 1.5},
+		{ServiceID: "svc-vllm-b", Memory: 24 * gib, CPU: 2, GPUFraction: 1},
+	}}
+
+	result, err := NewSummaryQuery(provider, allocations).Execute(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := result.(map[string]any)
+
+	memory := out["memory"].(map[string]any)
+	if memory["total"] != uint64(48*gib) || memory["allocated"] != uint64(56*gib) {
+		t.Errorf("unexpected memory totals: %v", memory)
+	}
+	if memory["headroom"] != uint64(0) || memory["overcommit"] != uint64(8*gib) {
+		t.Errorf("expected 8GiB memory overcommit, got %v", memory)
+	}
+
+	gpu := out["gpu"].(map[string]any)
+	if gpu["total"] != 2.0 || gpu["allocated"] != 2.5 || gpu["headroom"] != 0.0 || gpu["overcommit"] != 0.5 {
+		t.Errorf("unexpected gpu summary: %v", gpu)
+	}
+
+	cpu := out["cpu"].(map[string]any)
+	if cpu["allocated"] != 6.0 || cpu["total"].(float64)-cpu["headroom"].(float64)+cpu["overcommit"].(float64) != 6.0 {
+		t.Errorf("unexpected cpu summary: %v", cpu)
+	}
+
+	if out["overcommitted"] != true {
+		t.Error("expected the host to be reported as overcommitted")
+	}
+	if services := out["services"].([]map[string]any); len(services) != 2 {
+		t.Errorf("expected 2 services, got %d", len(services))
+	}
+}
+
+func TestSummaryQuery_Execute_Headroom(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+	provider := &MockProvider{
+		status: &ResourceStatus{GPUs: []GPUInfo{{Index: 0}}},
+		budget: &ResourceBudget{Total: 64 * gib, Reserved: 16 * gib},
+	}
+	allocations := &mockAllocationProvider{allocations: []ServiceAllocation{
+		{ServiceID: "svc-vllm-a", Memory: 16 * gib, GPUFraction: 0.5},
+		{ServiceID: "svc-whisper-b", Memory: 4 * gib},
+	}}
+
+	result, err := NewSummaryQuery(provider, allocations).Execute(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := result.(map[string]any)
+
+	memory := out["memory"].(map[string]any)
+	if memory["headroom"] != uint64(28*gib) || memory["overcommit"] != uint64(0) {
+		t.Errorf("expected 28GiB memory headroom, got %v", memory)
+	}
+	gpu := out["gpu"].(map[string]any)
+	if gpu["headroom"] != 0.5 || gpu["overcommit"] != 0.0 {
+		t.Errorf("expected half a GPU of headroom, got %v", gpu)
+	}
+}
+
+func TestSummaryQuery_Execute_Errors(t *testing.T) {
+	if _, err := NewSummaryQuery(nil, nil).Execute(context.Background(), map[string]any{}); !errors.Is(err, ErrProviderNotSet) {
+		t.Errorf("expected ErrProviderNotSet, got %v", err)
+	}
+
+	allocations := &mockAllocationProvider{err: errors.New("store unavailable")}
+	if _, err := NewSummaryQuery(&MockProvider{}, allocations).Execute(context.Background(), map[string]any{}); err == nil {
+		t.Error("expected allocation errors to be returned")
+	}
+}
@@ -22,6 +22,12 @@ type ResourceProvider interface {
 	CanAllocate(ctx context.Context, memoryBytes uint64, priority int) (*CanAllocateResult, error)
 }
 
+// ServiceAllocationProvider reports what running services have claimed, for
+// resource.summary.
+type ServiceAllocationProvider interface {
+	ServiceAllocations(ctx context.Context) ([]ServiceAllocation, error)
+}
+
 type MemoryStore struct {
 	slots map[string]*ResourceSlot
 	mu    sync.RWMutex
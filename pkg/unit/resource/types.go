@@ -54,10 +54,21 @@ const (
 type ResourceStatus struct {
 	Memory   MemoryInfo     `json:"memory"`
 	Storage  StorageInfo    `json:"storage"`
+	GPUs     []GPUInfo      `json:"gpus,omitempty"`
 	Slots    []ResourceSlot `json:"slots"`
 	Pressure PressureLevel  `json:"pressure"`
 }
 
+// GPUInfo is a point-in-time telemetry sample for a single GPU.
+type GPUInfo struct {
+	Index       int     `json:"index"`
+	Name        string  `json:"name"`
+	Utilization float64 `json:"utilization"` // percent, 0-100
+	MemoryTotal uint64  `json:"memory_total"`
+	MemoryUsed  uint64  `json:"memory_used"`
+	Temperature float64 `json:"temperature"` // degrees Celsius
+}
+
 type ResourcePool struct {
 	Name      string `json:"name"`
 	Total     uint64 `json:"total"`
@@ -81,6 +92,15 @@ type Allocation struct {
 	Status      SlotStatus `json:"status"`
 }
 
+// ServiceAllocation is the share of host resources claimed by one running
+// service, summed over its replicas.
+type ServiceAllocation struct {
+	ServiceID   string  `json:"service_id"`
+	Memory      uint64  `json:"memory"`
+	CPU         float64 `json:"cpu"`
+	GPUFraction float64 `json:"gpu_fraction"`
+}
+
 type AllocateResult struct {
 	SlotID string `json:"slot_id"`
 }
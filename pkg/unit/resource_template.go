@@ -0,0 +1,157 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidResourceTemplate = errors.New("invalid resource template")
+
+// ResourceHandler serves a templated resource. params holds the values
+// extracted from the URI, keyed by placeholder name.
+type ResourceHandler func(ctx context.Context, params map[string]string) (any, error)
+
+type resourceTemplate struct {
+	pattern  string
+	segments []string
+	literals int
+	handler  ResourceHandler
+}
+
+// RegisterResourceTemplate registers a handler for URIs matching pattern,
+// e.g. "asms://model/{id}". Each "{name}" placeholder matches exactly one
+// non-empty path segment.
+// Returns ErrResourceAlreadyRegistered if the pattern is already registered.
+// Returns ErrInvalidResourceTemplate if the pattern is malformed.
+// Returns ErrResourceNotFound if handler is nil.
+func (r *Registry) RegisterResourceTemplate(pattern string, handler ResourceHandler) error {
+	if handler == nil {
+		return ErrResourceNotFound
+	}
+
+	tmpl, err := parseResourceTemplate(pattern)
+	if err != nil {
+		return err
+	}
+	tmpl.handler = handler
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.resourceTemplates {
+		if existing.pattern == pattern {
+			return ErrResourceAlreadyRegistered
+		}
+	}
+
+	r.resourceTemplates = append(r.resourceTemplates, tmpl)
+	return nil
+}
+
+// MatchResourceTemplate finds the registered template matching uri and
+// returns its handler with the extracted params. When several templates
+// match, the one with the most literal segments wins; ties go to the
+// earliest registered.
+func (r *Registry) MatchResourceTemplate(uri string) (ResourceHandler, map[string]string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	segments := splitResourceURI(uri)
+
+	var best *resourceTemplate
+	var bestParams map[string]string
+	for i := range r.resourceTemplates {
+		tmpl := &r.resourceTemplates[i]
+		params, ok := tmpl.match(segments)
+		if !ok {
+			continue
+		}
+		if best == nil || tmpl.literals > best.literals {
+			best, bestParams = tmpl, params
+		}
+	}
+
+	if best == nil {
+		return nil, nil, false
+	}
+	return best.handler, bestParams, true
+}
+
+// ListResourceTemplates returns the registered template patterns.
+func (r *Registry) ListResourceTemplates() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]string, 0, len(r.resourceTemplates))
+	for _, tmpl := range r.resourceTemplates {
+		result = append(result, tmpl.pattern)
+	}
+	return result
+}
+
+func parseResourceTemplate(pattern string) (resourceTemplate, error) {
+	segments := splitResourceURI(pattern)
+	if len(segments) == 0 {
+		return resourceTemplate{}, ErrInvalidResourceTemplate
+	}
+
+	tmpl := resourceTemplate{pattern: pattern, segments: segments}
+	seen := make(map[string]bool)
+	for _, seg := range segments {
+		name, isParam := templateParam(seg)
+		if !isParam {
+			if strings.ContainsAny(seg, "{}") {
+				return resourceTemplate{}, ErrInvalidResourceTemplate
+			}
+			tmpl.literals++
+			continue
+		}
+		if name == "" || seen[name] {
+			return resourceTemplate{}, ErrInvalidResourceTemplate
+		}
+		seen[name] = true
+	}
+	return tmpl, nil
+}
+
+func (t *resourceTemplate) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(t.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, seg := range t.segments {
+		if name, isParam := templateParam(seg); isParam {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[name] = segments[i]
+			continue
+		}
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// splitResourceURI splits "asms://model/abc" into ["asms:", "model", "abc"],
+// keeping the scheme as the first segment so it must match literally.
+func splitResourceURI(uri string) []string {
+	if uri == "" {
+		return nil
+	}
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		return strings.Split(uri, "/")
+	}
+	return append([]string{scheme + ":"}, strings.Split(rest, "/")...)
+}
+
+func templateParam(seg string) (string, bool) {
+	if len(seg) >= 2 && seg[0] == '{' && seg[len(seg)-1] == '}' {
+		return seg[1 : len(seg)-1], true
+	}
+	return "", false
+}
@@ -0,0 +1,78 @@
+package unit
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegisterResourceTemplate(t *testing.T) {
+	r := NewRegistry()
+	handler := func(ctx context.Context, params map[string]string) (any, error) { return params, nil }
+
+	if err := r.RegisterResourceTemplate("asms://model/{id}", handler); err != nil {
+		t.Fatalf("RegisterResourceTemplate failed: %v", err)
+	}
+	if err := r.RegisterResourceTemplate("asms://model/{id}", handler); err != ErrResourceAlreadyRegistered {
+		t.Errorf("expected ErrResourceAlreadyRegistered, got %v", err)
+	}
+	if err := r.RegisterResourceTemplate("asms://model/{id}/x", nil); err != ErrResourceNotFound {
+		t.Errorf("expected ErrResourceNotFound for nil handler, got %v", err)
+	}
+
+	for _, pattern := range []string{"", "asms://model/{}", "asms://model/{id}/{id}", "asms://model/x{id}"} {
+		if err := r.RegisterResourceTemplate(pattern, handler); err != ErrInvalidResourceTemplate {
+			t.Errorf("pattern %q: expected ErrInvalidResourceTemplate, got %v", pattern, err)
+		}
+	}
+
+	if got := r.ListResourceTemplates(); len(got) != 1 || got[0] != "asms://model/{id}" {
+		t.Errorf("unexpected templates: %v", got)
+	}
+}
+
+func TestMatchResourceTemplate(t *testing.T) {
+	r := NewRegistry()
+	byID := func(ctx context.Context, params map[string]string) (any, error) { return "by-id", nil }
+	status := func(ctx context.Context, params map[string]string) (any, error) { return "status", nil }
+	_ = r.RegisterResourceTemplate("asms://model/{id}", byID)
+	_ = r.RegisterResourceTemplate("asms://model/status", status)
+	_ = r.RegisterResourceTemplate("asms://engine/{name}/models/{id}", byID)
+
+	tests := []struct {
+		uri        string
+		wantOK     bool
+		wantResult string
+		wantParams map[string]string
+	}{
+		{"asms://model/abc123", true, "by-id", map[string]string{"id": "abc123"}},
+		{"asms://model/status", true, "status", map[string]string{}},
+		{"asms://engine/ollama/models/llama3", true, "by-id", map[string]string{"name": "ollama", "id": "llama3"}},
+		{"asms://model/", false, "", nil},
+		{"asms://model/abc/extra", false, "", nil},
+		{"other://model/abc123", false, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			handler, params, ok := r.MatchResourceTemplate(tt.uri)
+			if ok != tt.wantOK {
+				t.Fatalf("expected match=%v, got %v", tt.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			result, _ := handler(context.Background(), params)
+			if result != tt.wantResult {
+				t.Errorf("expected handler %s, got %v", tt.wantResult, result)
+			}
+			if len(params) != len(tt.wantParams) {
+				t.Fatalf("expected params %v, got %v", tt.wantParams, params)
+			}
+			for k, v := range tt.wantParams {
+				if params[k] != v {
+					t.Errorf("expected %s=%s, got %s", k, v, params[k])
+				}
+			}
+		})
+	}
+}
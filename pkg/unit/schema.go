@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 )
 
 func (s *Schema) Validate(input any) error {
@@ -27,6 +28,83 @@ func (s *Schema) Validate(input any) error {
 	}
 }
 
+// UnknownFields returns the sorted top-level keys of input that s does not
+// declare in Properties. It is independent of Validate so callers can reject
+// unexpected keys without changing required or type checks. Schemas that
+// declare no properties accept any key.
+func (s *Schema) UnknownFields(input map[string]any) []string {
+	if len(s.Properties) == 0 {
+		return nil
+	}
+	var unknown []string
+	for key := range input {
+		if _, ok := s.Properties[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Redacted replaces the value of a sensitive field in logged input.
+const Redacted = "[REDACTED]"
+
+// RedactSensitive returns a copy of input in which the value of every
+// property marked Sensitive is replaced by Redacted, descending into nested
+// objects and arrays of objects. input itself is not modified.
+func (s *Schema) RedactSensitive(input map[string]any) map[string]any {
+	if input == nil || !s.hasSensitive() {
+		return input
+	}
+	out := make(map[string]any, len(input))
+	for key, value := range input {
+		field, ok := s.Properties[key]
+		switch {
+		case !ok:
+			out[key] = value
+		case field.Sensitive:
+			out[key] = Redacted
+		default:
+			out[key] = field.Schema.redactValue(value)
+		}
+	}
+	return out
+}
+
+func (s *Schema) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return s.RedactSensitive(v)
+	case []any:
+		if s.Items == nil || !s.Items.hasSensitive() {
+			return v
+		}
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = s.Items.redactValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// hasSensitive reports whether s or any schema nested in it is Sensitive.
+func (s *Schema) hasSensitive() bool {
+	if s.Sensitive {
+		return true
+	}
+	if s.Items != nil && s.Items.hasSensitive() {
+		return true
+	}
+	for _, field := range s.Properties {
+		if field.Schema.hasSensitive() {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Schema) validateString(input any) error {
 	str, ok := input.(string)
 	if !ok {
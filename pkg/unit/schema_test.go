@@ -473,3 +473,68 @@ func TestSchema_Validate_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestSchema_UnknownFields(t *testing.T) {
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]Field{
+			"model":  {Name: "model", Schema: Schema{Type: "string"}},
+			"prompt": {Name: "prompt", Schema: Schema{Type: "string"}},
+		},
+		Required: []string{"model"},
+	}
+
+	got := schema.UnknownFields(map[string]any{"model": "llama3", "promt": "hi", "extra": 1})
+	if len(got) != 2 || got[0] != "extra" || got[1] != "promt" {
+		t.Errorf("expected [extra promt], got %v", got)
+	}
+	if got := schema.UnknownFields(map[string]any{"prompt": "hi"}); len(got) != 0 {
+		t.Errorf("missing required fields are not unknown fields, got %v", got)
+	}
+	if got := (&Schema{Type: "object"}).UnknownFields(map[string]any{"any": 1}); got != nil {
+		t.Errorf("expected a schema without properties to accept any key, got %v", got)
+	}
+}
+
+func TestSchema_RedactSensitive(t *testing.T) {
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]Field{
+			"model": {Name: "model", Schema: Schema{Type: "string"}},
+			"token": {Name: "token", Schema: Schema{Type: "string", Sensitive: true}},
+			"messages": {Name: "messages", Schema: Schema{
+				Type: "array",
+				Items: &Schema{
+					Type: "object",
+					Properties: map[string]Field{
+						"role":    {Name: "role", Schema: Schema{Type: "string"}},
+						"content": {Name: "content", Schema: Schema{Type: "string", Sensitive: true}},
+					},
+				},
+			}},
+		},
+	}
+	input := map[string]any{
+		"model":    "llama3",
+		"token":    "hf_secret",
+		"messages": []any{map[string]any{"role": "user", "content": "my password is hunter2"}},
+		"extra":    "kept",
+	}
+
+	got := schema.RedactSensitive(input)
+	if got["model"] != "llama3" || got["extra"] != "kept" || got["token"] != Redacted {
+		t.Errorf("unexpected top-level redaction: %v", got)
+	}
+	msg := got["messages"].([]any)[0].(map[string]any)
+	if msg["role"] != "user" || msg["content"] != Redacted {
+		t.Errorf("expected nested content to be redacted, got %v", msg)
+	}
+	if input["token"] != "hf_secret" || input["messages"].([]any)[0].(map[string]any)["content"] != "my password is hunter2" {
+		t.Errorf("expected the original input to be left unchanged, got %v", input)
+	}
+
+	plain := &Schema{Type: "object", Properties: map[string]Field{"model": {Name: "model", Schema: Schema{Type: "string"}}}}
+	if got := plain.RedactSensitive(input); got["token"] != "hf_secret" {
+		t.Errorf("expected a schema without sensitive fields to leave input as is, got %v", got)
+	}
+}
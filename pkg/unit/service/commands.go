@@ -75,6 +75,13 @@ func (c *CreateCommand) InputSchema() unit.Schema {
 					Default:     false,
 				},
 			},
+			"config": {
+				Name: "config",
+				Schema: unit.Schema{
+					Type:        "object",
+					Description: "Service config overrides, e.g. the config suggested by service.recommend (port, gpu_memory_utilization, max_model_len, memory, cpu)",
+				},
+			},
 		},
 		Required: []string{"model_id"},
 	}
@@ -146,12 +153,33 @@ func (c *CreateCommand) Execute(ctx context.Context, input any) (any, error) {
 		persistent = p
 	}
 
+	var overrides map[string]any
+	if v, ok := inputMap["config"]; ok && v != nil {
+		overrides, ok = v.(map[string]any)
+		if !ok {
+			err := fmt.Errorf("config must be an object: %w", ErrInvalidInput)
+			ec.PublishFailed(err)
+			return nil, err
+		}
+	}
+
 	result, err := c.provider.Create(ctx, modelID, resourceClass, replicas, persistent)
 	if err != nil {
 		ec.PublishFailed(err)
 		return nil, fmt.Errorf("create service: %w", err)
 	}
 
+	config := result.Config
+	if len(overrides) > 0 {
+		config = make(map[string]any, len(result.Config)+len(overrides))
+		for k, v := range result.Config {
+			config[k] = v
+		}
+		for k, v := range overrides {
+			config[k] = v
+		}
+	}
+
 	now := time.Now().Unix()
 	service := &ModelService{
 		ID:            result.ID,
@@ -161,7 +189,7 @@ func (c *CreateCommand) Execute(ctx context.Context, input any) (any, error) {
 		Replicas:      replicas,
 		ResourceClass: resourceClass,
 		Endpoints:     result.Endpoints,
-		Config:        result.Config, // persist port assignment and engine config
+		Config:        config, // persist port assignment and engine config
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
@@ -719,3 +747,252 @@ func (c *StopCommand) Execute(ctx context.Context, input any) (any, error) {
 	ec.PublishCompleted(output)
 	return output, nil
 }
+
+// PinCommand sets or clears a service's Pinned flag. It backs both
+// service.pin and service.unpin.
+type PinCommand struct {
+	store  ServiceStore
+	pin    bool
+	events unit.EventPublisher
+}
+
+func NewPinCommand(store ServiceStore) *PinCommand {
+	return &PinCommand{store: store, pin: true}
+}
+
+func NewPinCommandWithEvents(store ServiceStore, events unit.EventPublisher) *PinCommand {
+	return &PinCommand{store: store, pin: true, events: events}
+}
+
+func NewUnpinCommand(store ServiceStore) *PinCommand {
+	return &PinCommand{store: store}
+}
+
+func NewUnpinCommandWithEvents(store ServiceStore, events unit.EventPublisher) *PinCommand {
+	return &PinCommand{store: store, events: events}
+}
+
+func (c *PinCommand) Name() string {
+	if c.pin {
+		return "service.pin"
+	}
+	return "service.unpin"
+}
+
+func (c *PinCommand) Domain() string {
+	return "service"
+}
+
+func (c *PinCommand) Description() string {
+	if c.pin {
+		return "Pin a service so it is never stopped automatically, e.g. when idle"
+	}
+	return "Unpin a service so it can be stopped automatically again"
+}
+
+func (c *PinCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"service_id": {
+				Name: "service_id",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Service ID",
+					MinLength:   ptrs.Int(1),
+				},
+			},
+		},
+		Required: []string{"service_id"},
+	}
+}
+
+func (c *PinCommand) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"success": {Name: "success", Schema: unit.Schema{Type: "boolean"}},
+			"pinned":  {Name: "pinned", Schema: unit.Schema{Type: "boolean"}},
+		},
+	}
+}
+
+func (c *PinCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input:       map[string]any{"service_id": "svc-abc123"},
+			Output:      map[string]any{"success": true, "pinned": c.pin},
+			Description: c.Description(),
+		},
+	}
+}
+
+func (c *PinCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.store == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	serviceID, _ := inputMap["service_id"].(string)
+	if serviceID == "" {
+		err := fmt.Errorf("service_id is required: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	service, err := c.store.Get(ctx, serviceID)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("get service %s: %w", serviceID, err)
+	}
+
+	if service.Pinned != c.pin {
+		service.Pinned = c.pin
+		service.UpdatedAt = time.Now().Unix()
+		if err := c.store.Update(ctx, service); err != nil {
+			ec.PublishFailed(err)
+			return nil, fmt.Errorf("update service %s: %w", serviceID, err)
+		}
+	}
+
+	output := map[string]any{"success": true, "pinned": c.pin}
+	ec.PublishCompleted(output)
+	return output, nil
+}
+
+// ExecCommand runs a diagnostic command inside a service's container. It is an
+// admin operation and is forced to require authentication at the gateway.
+type ExecCommand struct {
+	store    ServiceStore
+	provider ServiceProvider
+	events   unit.EventPublisher
+}
+
+func NewExecCommand(store ServiceStore, provider ServiceProvider) *ExecCommand {
+	return &ExecCommand{store: store, provider: provider}
+}
+
+func NewExecCommandWithEvents(store ServiceStore, provider ServiceProvider, events unit.EventPublisher) *ExecCommand {
+	return &ExecCommand{store: store, provider: provider, events: events}
+}
+
+func (c *ExecCommand) Name() string {
+	return "service.exec"
+}
+
+func (c *ExecCommand) Domain() string {
+	return "service"
+}
+
+func (c *ExecCommand) Description() string {
+	return "Run a diagnostic command inside a service container (admin only)"
+}
+
+func (c *ExecCommand) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"service_id": {
+				Name: "service_id",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Service ID",
+					MinLength:   ptrs.Int(1),
+				},
+			},
+			"command": {
+				Name: "command",
+				Schema: unit.Schema{
+					Type:        "array",
+					Description: "Command and arguments, run without a shell",
+					Items:       &unit.Schema{Type: "string"},
+				},
+			},
+		},
+		Required: []string{"service_id", "command"},
+	}
+}
+
+func (c *ExecCommand) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"stdout":    {Name: "stdout", Schema: unit.Schema{Type: "string"}},
+			"stderr":    {Name: "stderr", Schema: unit.Schema{Type: "string"}},
+			"exit_code": {Name: "exit_code", Schema: unit.Schema{Type: "number"}},
+		},
+	}
+}
+
+func (c *ExecCommand) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input:       map[string]any{"service_id": "svc-vllm-model-abc123", "command": []string{"nvidia-smi"}},
+			Output:      map[string]any{"stdout": "+----------------------+\n| NVIDIA-SMI 550.54 ...", "stderr": "", "exit_code": 0},
+			Description: "Check GPU visibility inside the engine container",
+		},
+	}
+}
+
+func (c *ExecCommand) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(c.events, c.Domain(), c.Name())
+	ec.PublishStarted(input)
+
+	if c.store == nil || c.provider == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	serviceID, _ := inputMap["service_id"].(string)
+	if serviceID == "" {
+		err := fmt.Errorf("service_id is required: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	cmd, ok := toStringSlice(inputMap["command"])
+	if !ok || len(cmd) == 0 {
+		err := fmt.Errorf("command is required: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	if _, err := c.store.Get(ctx, serviceID); err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("get service %s: %w", serviceID, err)
+	}
+
+	slog.Info("running command in service container", "service_id", serviceID, "command", cmd, "user_id", unit.GetUserID(ctx))
+	result, err := c.provider.Exec(ctx, serviceID, cmd)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("exec in service %s: %w", serviceID, err)
+	}
+
+	output := map[string]any{
+		"stdout":    result.Stdout,
+		"stderr":    result.Stderr,
+		"exit_code": result.ExitCode,
+	}
+	ec.PublishCompleted(output)
+	return output, nil
+}
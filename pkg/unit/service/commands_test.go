@@ -106,6 +106,13 @@ func TestCreateCommand_Execute(t *testing.T) {
 			input:    "invalid",
 			wantErr:  true,
 		},
+		{
+			name:     "config not an object",
+			store:    NewMemoryStore(),
+			provider: &MockProvider{},
+			input:    map[string]any{"model_id": "llama3-70b", "config": "large"},
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -140,6 +147,27 @@ func TestCreateCommand_Execute(t *testing.T) {
 	}
 }
 
+func TestCreateCommand_ExecuteWithConfig(t *testing.T) {
+	store := NewMemoryStore()
+	cmd := NewCreateCommand(store, &MockProvider{})
+
+	result, err := cmd.Execute(context.Background(), map[string]any{
+		"model_id": "llama3-70b",
+		"config":   map[string]any{"port": 8123, "max_model_len": 8192, "memory": "176g"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc, err := store.Get(context.Background(), result.(map[string]any)["service_id"].(string))
+	if err != nil {
+		t.Fatalf("get service: %v", err)
+	}
+	if svc.Config["port"] != 8123 || svc.Config["max_model_len"] != 8192 || svc.Config["memory"] != "176g" {
+		t.Errorf("expected the config to be stored with the service, got %v", svc.Config)
+	}
+}
+
 func TestDeleteCommand_Name(t *testing.T) {
 	cmd := NewDeleteCommand(nil, nil)
 	if cmd.Name() != "service.delete" {
@@ -581,6 +609,140 @@ func TestStopCommand_Execute(t *testing.T) {
 	}
 }
 
+func TestExecCommand_Name(t *testing.T) {
+	cmd := NewExecCommand(nil, nil)
+	if cmd.Name() != "service.exec" {
+		t.Errorf("expected name 'service.exec', got '%s'", cmd.Name())
+	}
+}
+
+func TestExecCommand_Execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		store    ServiceStore
+		provider ServiceProvider
+		input    any
+		want     *ExecResult
+		wantErr  bool
+	}{
+		{
+			name:     "successful exec",
+			store:    createStoreWithService("svc-123", "model-1", ServiceStatusRunning),
+			provider: &MockProvider{execResult: &ExecResult{Stdout: "ok\n", Stderr: "warn\n", ExitCode: 3}},
+			input:    map[string]any{"service_id": "svc-123", "command": []any{"nvidia-smi", "-L"}},
+			want:     &ExecResult{Stdout: "ok\n", Stderr: "warn\n", ExitCode: 3},
+		},
+		{
+			name:     "command as string slice",
+			store:    createStoreWithService("svc-123", "model-1", ServiceStatusRunning),
+			provider: &MockProvider{},
+			input:    map[string]any{"service_id": "svc-123", "command": []string{"ls", "/models"}},
+			want:     &ExecResult{Stdout: "ls /models\n"},
+		},
+		{
+			name:     "nil provider",
+			store:    NewMemoryStore(),
+			provider: nil,
+			input:    map[string]any{"service_id": "svc-123", "command": []any{"ls"}},
+			wantErr:  true,
+		},
+		{
+			name:     "missing command",
+			store:    createStoreWithService("svc-123", "model-1", ServiceStatusRunning),
+			provider: &MockProvider{},
+			input:    map[string]any{"service_id": "svc-123"},
+			wantErr:  true,
+		},
+		{
+			name:     "non-string argument",
+			store:    createStoreWithService("svc-123", "model-1", ServiceStatusRunning),
+			provider: &MockProvider{},
+			input:    map[string]any{"service_id": "svc-123", "command": []any{"ls", 1}},
+			wantErr:  true,
+		},
+		{
+			name:     "service not found",
+			store:    NewMemoryStore(),
+			provider: &MockProvider{},
+			input:    map[string]any{"service_id": "nonexistent", "command": []any{"ls"}},
+			wantErr:  true,
+		},
+		{
+			name:     "provider error",
+			store:    createStoreWithService("svc-123", "model-1", ServiceStatusRunning),
+			provider: &MockProvider{execErr: errors.New("no running container")},
+			input:    map[string]any{"service_id": "svc-123", "command": []any{"ls"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewExecCommand(tt.store, tt.provider)
+			result, err := cmd.Execute(context.Background(), tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resultMap := result.(map[string]any)
+			if resultMap["stdout"] != tt.want.Stdout || resultMap["stderr"] != tt.want.Stderr || resultMap["exit_code"] != tt.want.ExitCode {
+				t.Errorf("result = %v, want %+v", resultMap, tt.want)
+			}
+		})
+	}
+}
+
+func TestPinCommand_Name(t *testing.T) {
+	if name := NewPinCommand(nil).Name(); name != "service.pin" {
+		t.Errorf("expected name 'service.pin', got '%s'", name)
+	}
+	if name := NewUnpinCommand(nil).Name(); name != "service.unpin" {
+		t.Errorf("expected name 'service.unpin', got '%s'", name)
+	}
+}
+
+func TestPinCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	store := createStoreWithService("svc-123", "model-1", ServiceStatusRunning)
+
+	if _, err := NewPinCommand(store).Execute(ctx, map[string]any{"service_id": "svc-123"}); err != nil {
+		t.Fatalf("pin: unexpected error: %v", err)
+	}
+	svc, _ := store.Get(ctx, "svc-123")
+	if !svc.Pinned {
+		t.Error("expected service to be pinned")
+	}
+
+	result, err := NewUnpinCommand(store).Execute(ctx, map[string]any{"service_id": "svc-123"})
+	if err != nil {
+		t.Fatalf("unpin: unexpected error: %v", err)
+	}
+	if result.(map[string]any)["pinned"] != false {
+		t.Errorf("expected pinned=false in result, got %v", result)
+	}
+	svc, _ = store.Get(ctx, "svc-123")
+	if svc.Pinned {
+		t.Error("expected service to be unpinned")
+	}
+
+	if _, err := NewPinCommand(store).Execute(ctx, map[string]any{"service_id": "nonexistent"}); err == nil {
+		t.Error("expected error for unknown service")
+	}
+	if _, err := NewPinCommand(store).Execute(ctx, map[string]any{}); err == nil {
+		t.Error("expected error for missing service_id")
+	}
+	if _, err := NewPinCommand(nil).Execute(ctx, map[string]any{"service_id": "svc-123"}); err == nil {
+		t.Error("expected error for nil store")
+	}
+}
+
 // TestStopCommand_StatusTransitions verifies Bug #25: stop on non-running
 // services transitions them to "stopped" in the store.
 func TestStopCommand_StatusTransitions(t *testing.T) {
@@ -625,6 +787,9 @@ func TestCommand_Description(t *testing.T) {
 	if NewStopCommand(nil, nil).Description() == "" {
 		t.Error("expected non-empty description for StopCommand")
 	}
+	if NewExecCommand(nil, nil).Description() == "" {
+		t.Error("expected non-empty description for ExecCommand")
+	}
 }
 
 func TestCommand_Examples(t *testing.T) {
@@ -643,6 +808,9 @@ func TestCommand_Examples(t *testing.T) {
 	if len(NewStopCommand(nil, nil).Examples()) == 0 {
 		t.Error("expected at least one example for StopCommand")
 	}
+	if len(NewExecCommand(nil, nil).Examples()) == 0 {
+		t.Error("expected at least one example for ExecCommand")
+	}
 }
 
 func TestCommandImplementsInterface(t *testing.T) {
@@ -651,6 +819,7 @@ func TestCommandImplementsInterface(t *testing.T) {
 	var _ unit.Command = NewScaleCommand(nil, nil)
 	var _ unit.Command = NewStartCommand(nil, nil)
 	var _ unit.Command = NewStopCommand(nil, nil)
+	var _ unit.Command = NewExecCommand(nil, nil)
 }
 
 func createStoreWithService(id string, modelID string, status ServiceStatus) ServiceStore {
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit"
 	"github.com/jguan/ai-inference-managed-by-ai/pkg/unit/ptrs"
@@ -368,6 +370,7 @@ func (q *RecommendQuery) OutputSchema() unit.Schema {
 			"engine_type":         {Name: "engine_type", Schema: unit.Schema{Type: "string", Description: "Recommended engine type: vllm, whisper, tts, ollama"}},
 			"device_type":         {Name: "device_type", Schema: unit.Schema{Type: "string", Description: "Recommended device: gpu, cpu"}},
 			"reason":              {Name: "reason", Schema: unit.Schema{Type: "string", Description: "Reason for recommendation"}},
+			"config":              {Name: "config", Schema: unit.Schema{Type: "object", Description: "Suggested service config (port, gpu_memory_utilization, max_model_len, memory, cpu) to pass to service.create"}},
 		},
 	}
 }
@@ -376,7 +379,7 @@ func (q *RecommendQuery) Examples() []unit.Example {
 	return []unit.Example{
 		{
 			Input:       map[string]any{"model_id": "llama3-70b"},
-			Output:      map[string]any{"resource_class": "large", "replicas": 2, "expected_throughput": 100.0, "engine_type": "vllm", "device_type": "gpu", "reason": "Large LLM model recommended for GPU acceleration with vLLM"},
+			Output:      map[string]any{"resource_class": "large", "replicas": 2, "expected_throughput": 100.0, "engine_type": "vllm", "device_type": "gpu", "reason": "Large LLM model recommended for GPU acceleration with vLLM", "config": map[string]any{"port": 8000, "gpu_memory_utilization": 0.9, "max_model_len": 8192, "memory": "176g", "cpu": 16.0}},
 			Description: "Get recommendation for llama3-70b",
 		},
 		{
@@ -432,6 +435,9 @@ func (q *RecommendQuery) Execute(ctx context.Context, input any) (any, error) {
 		"device_type":         rec.DeviceType,
 		"reason":              rec.Reason,
 	}
+	if len(rec.Config) > 0 {
+		output["config"] = rec.Config
+	}
 	ec.PublishCompleted(output)
 	return output, nil
 }
@@ -656,3 +662,366 @@ func (q *LogsQuery) Execute(ctx context.Context, input any) (any, error) {
 	ec.PublishCompleted(result)
 	return result, nil
 }
+
+// DiscoverQuery lists every AIMA-managed container and the service that owns
+// it, so orphaned containers can be spotted.
+type DiscoverQuery struct {
+	store    ServiceStore
+	provider ServiceProvider
+	events   unit.EventPublisher
+}
+
+func NewDiscoverQuery(store ServiceStore, provider ServiceProvider) *DiscoverQuery {
+	return &DiscoverQuery{store: store, provider: provider}
+}
+
+func NewDiscoverQueryWithEvents(store ServiceStore, provider ServiceProvider, events unit.EventPublisher) *DiscoverQuery {
+	return &DiscoverQuery{store: store, provider: provider, events: events}
+}
+
+func (q *DiscoverQuery) Name() string {
+	return "service.discover"
+}
+
+func (q *DiscoverQuery) Domain() string {
+	return "service"
+}
+
+func (q *DiscoverQuery) Description() string {
+	return "List AIMA-managed containers with their engine, port, status and owning service"
+}
+
+func (q *DiscoverQuery) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type:       "object",
+		Properties: map[string]unit.Field{},
+	}
+}
+
+func (q *DiscoverQuery) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"containers": {
+				Name: "containers",
+				Schema: unit.Schema{
+					Type: "array",
+					Items: &unit.Schema{
+						Type: "object",
+						Properties: map[string]unit.Field{
+							"container_id": {Name: "container_id", Schema: unit.Schema{Type: "string"}},
+							"name":         {Name: "name", Schema: unit.Schema{Type: "string"}},
+							"engine":       {Name: "engine", Schema: unit.Schema{Type: "string"}},
+							"port":         {Name: "port", Schema: unit.Schema{Type: "number"}},
+							"status":       {Name: "status", Schema: unit.Schema{Type: "string"}},
+							"service_id":   {Name: "service_id", Schema: unit.Schema{Type: "string"}},
+							"orphan":       {Name: "orphan", Schema: unit.Schema{Type: "boolean"}},
+						},
+					},
+				},
+			},
+			"total":   {Name: "total", Schema: unit.Schema{Type: "number"}},
+			"orphans": {Name: "orphans", Schema: unit.Schema{Type: "number"}},
+		},
+	}
+}
+
+func (q *DiscoverQuery) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input: map[string]any{},
+			Output: map[string]any{
+				"containers": []map[string]any{
+					{"container_id": "3f2a9c", "name": "aima-vllm-1767225600", "engine": "vllm", "port": 8000, "status": "running", "service_id": "svc-vllm-model-abc123", "orphan": false},
+					{"container_id": "8b1e47", "name": "aima-asr-1767139200", "engine": "asr", "port": 8001, "status": "exited", "service_id": "", "orphan": true},
+				},
+				"total":   2,
+				"orphans": 1,
+			},
+			Description: "Find a leftover ASR container with no owning service",
+		},
+	}
+}
+
+func (q *DiscoverQuery) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(q.events, q.Domain(), q.Name())
+	ec.PublishStarted(input)
+
+	if q.store == nil || q.provider == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	containers, err := q.provider.DiscoverContainers(ctx)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("discover containers: %w", err)
+	}
+
+	services, _, err := q.store.List(ctx, ServiceFilter{})
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("list services: %w", err)
+	}
+
+	items := make([]map[string]any, len(containers))
+	orphans := 0
+	for i, c := range containers {
+		serviceID := c.ServiceID
+		if serviceID == "" {
+			serviceID = matchService(services, c)
+		}
+		if serviceID == "" {
+			orphans++
+		}
+		items[i] = map[string]any{
+			"container_id": c.ContainerID,
+			"name":         c.Name,
+			"engine":       c.Engine,
+			"port":         c.Port,
+			"status":       c.Status,
+			"service_id":   serviceID,
+			"orphan":       serviceID == "",
+		}
+	}
+
+	result := map[string]any{
+		"containers": items,
+		"total":      len(items),
+		"orphans":    orphans,
+	}
+	ec.PublishCompleted(result)
+	return result, nil
+}
+
+// matchService returns the ID of the service whose engine type matches the
+// container's aima.engine label and whose endpoints use the container's port.
+func matchService(services []ModelService, c ManagedContainer) string {
+	if c.Engine == "" || c.Port == 0 {
+		return ""
+	}
+	port := strconv.Itoa(c.Port)
+	for _, svc := range services {
+		sid, err := ParseServiceID(svc.ID)
+		if err != nil || sid.EngineType != c.Engine {
+			continue
+		}
+		for _, endpoint := range svc.Endpoints {
+			if u, err := url.Parse(endpoint); err == nil && u.Port() == port {
+				return svc.ID
+			}
+		}
+	}
+	return ""
+}
+
+// DescribeQuery combines a service's stored configuration with its runtime
+// state: whether the engine is running, the container backing it and the
+// tail of its logs.
+type DescribeQuery struct {
+	store    ServiceStore
+	provider ServiceProvider
+	events   unit.EventPublisher
+}
+
+func NewDescribeQuery(store ServiceStore, provider ServiceProvider) *DescribeQuery {
+	return &DescribeQuery{store: store, provider: provider}
+}
+
+func NewDescribeQueryWithEvents(store ServiceStore, provider ServiceProvider, events unit.EventPublisher) *DescribeQuery {
+	return &DescribeQuery{store: store, provider: provider, events: events}
+}
+
+func (q *DescribeQuery) Name() string {
+	return "service.describe"
+}
+
+func (q *DescribeQuery) Domain() string {
+	return "service"
+}
+
+func (q *DescribeQuery) Description() string {
+	return "Describe a service's model, engine, port, status, container and recent logs"
+}
+
+func (q *DescribeQuery) InputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"service_id": {
+				Name: "service_id",
+				Schema: unit.Schema{
+					Type:        "string",
+					Description: "Service ID",
+				},
+			},
+			"tail": {
+				Name: "tail",
+				Schema: unit.Schema{
+					Type:        "number",
+					Description: "Number of log lines to include (default 20)",
+				},
+			},
+		},
+		Required: []string{"service_id"},
+	}
+}
+
+func (q *DescribeQuery) OutputSchema() unit.Schema {
+	return unit.Schema{
+		Type: "object",
+		Properties: map[string]unit.Field{
+			"id":              {Name: "id", Schema: unit.Schema{Type: "string"}},
+			"name":            {Name: "name", Schema: unit.Schema{Type: "string"}},
+			"model_id":        {Name: "model_id", Schema: unit.Schema{Type: "string"}},
+			"engine":          {Name: "engine", Schema: unit.Schema{Type: "string"}},
+			"port":            {Name: "port", Schema: unit.Schema{Type: "number"}},
+			"status":          {Name: "status", Schema: unit.Schema{Type: "string"}},
+			"running":         {Name: "running", Schema: unit.Schema{Type: "boolean"}},
+			"replicas":        {Name: "replicas", Schema: unit.Schema{Type: "number"}},
+			"active_replicas": {Name: "active_replicas", Schema: unit.Schema{Type: "number"}},
+			"resource_class":  {Name: "resource_class", Schema: unit.Schema{Type: "string"}},
+			"endpoints":       {Name: "endpoints", Schema: unit.Schema{Type: "array", Items: &unit.Schema{Type: "string"}}},
+			"container": {
+				Name: "container",
+				Schema: unit.Schema{
+					Type: "object",
+					Properties: map[string]unit.Field{
+						"container_id": {Name: "container_id", Schema: unit.Schema{Type: "string"}},
+						"name":         {Name: "name", Schema: unit.Schema{Type: "string"}},
+						"status":       {Name: "status", Schema: unit.Schema{Type: "string"}},
+					},
+				},
+			},
+			"logs": {Name: "logs", Schema: unit.Schema{Type: "string"}},
+		},
+	}
+}
+
+func (q *DescribeQuery) Examples() []unit.Example {
+	return []unit.Example{
+		{
+			Input: map[string]any{"service_id": "svc-vllm-model-abc123", "tail": 2},
+			Output: map[string]any{
+				"id":        "svc-vllm-model-abc123",
+				"model_id":  "model-abc123",
+				"engine":    "vllm",
+				"port":      8000,
+				"status":    "running",
+				"running":   true,
+				"replicas":  1,
+				"endpoints": []string{"http://localhost:8000"},
+				"container": map[string]any{"container_id": "3f2a9c", "name": "aima-vllm-1767225600", "status": "running"},
+				"logs":      "INFO Started server process\nINFO Application startup complete.",
+			},
+			Description: "Describe a running vLLM service",
+		},
+	}
+}
+
+func (q *DescribeQuery) Execute(ctx context.Context, input any) (any, error) {
+	ec := unit.NewExecutionContext(q.events, q.Domain(), q.Name())
+	ec.PublishStarted(input)
+
+	if q.store == nil || q.provider == nil {
+		err := ErrProviderNotSet
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		err := fmt.Errorf("invalid input type: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	serviceID, _ := inputMap["service_id"].(string)
+	if serviceID == "" {
+		err := fmt.Errorf("service_id is required: %w", ErrInvalidInput)
+		ec.PublishFailed(err)
+		return nil, err
+	}
+
+	tail := 20
+	if t, ok := inputMap["tail"].(float64); ok && t > 0 {
+		tail = int(t)
+	} else if t, ok := inputMap["tail"].(int); ok && t > 0 {
+		tail = t
+	}
+
+	svc, err := q.store.Get(ctx, serviceID)
+	if err != nil {
+		ec.PublishFailed(err)
+		return nil, fmt.Errorf("get service %s: %w", serviceID, err)
+	}
+
+	result := map[string]any{
+		"id":              svc.ID,
+		"name":            svc.Name,
+		"model_id":        svc.ModelID,
+		"engine":          serviceEngine(svc),
+		"port":            servicePort(svc),
+		"status":          string(svc.Status),
+		"running":         q.provider.IsRunning(ctx, serviceID),
+		"replicas":        svc.Replicas,
+		"active_replicas": svc.ActiveReplicas,
+		"resource_class":  string(svc.ResourceClass),
+		"endpoints":       svc.Endpoints,
+	}
+
+	// Runtime details are best effort: a service that never started has no
+	// container or logs, and Docker may be unavailable.
+	if containers, err := q.provider.DiscoverContainers(ctx); err == nil {
+		for _, c := range containers {
+			if c.ServiceID == serviceID || c.ServiceID == "" && matchService([]ModelService{*svc}, c) == serviceID {
+				result["container"] = map[string]any{
+					"container_id": c.ContainerID,
+					"name":         c.Name,
+					"status":       c.Status,
+				}
+				break
+			}
+		}
+	}
+	if logs, err := q.provider.GetLogs(ctx, serviceID, tail); err == nil {
+		result["logs"] = logs
+	}
+
+	ec.PublishCompleted(result)
+	return result, nil
+}
+
+// serviceEngine returns the engine type recorded in the service config, or
+// the one encoded in its ID.
+func serviceEngine(svc *ModelService) string {
+	if engine, _ := svc.Config["engine_type"].(string); engine != "" {
+		return engine
+	}
+	if sid, err := ParseServiceID(svc.ID); err == nil {
+		return sid.EngineType
+	}
+	return ""
+}
+
+// servicePort returns the port recorded in the service config, or the port of
+// its first endpoint, or 0.
+func servicePort(svc *ModelService) int {
+	switch v := svc.Config["port"].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	for _, endpoint := range svc.Endpoints {
+		if u, err := url.Parse(endpoint); err == nil {
+			if port, err := strconv.Atoi(u.Port()); err == nil {
+				return port
+			}
+		}
+	}
+	return 0
+}
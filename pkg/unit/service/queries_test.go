@@ -303,6 +303,63 @@ func TestRecommendQuery_Execute(t *testing.T) {
 	}
 }
 
+func TestDiscoverQuery_Name(t *testing.T) {
+	q := NewDiscoverQuery(nil, nil)
+	if q.Name() != "service.discover" {
+		t.Errorf("expected name 'service.discover', got '%s'", q.Name())
+	}
+}
+
+func TestDiscoverQuery_Execute(t *testing.T) {
+	store := NewMemoryStore()
+	svc := createTestService("svc-vllm-model-1", "model-1", ServiceStatusRunning)
+	svc.Endpoints = []string{"http://localhost:8000"}
+	_ = store.Create(context.Background(), svc)
+
+	provider := &MockProvider{containers: []ManagedContainer{
+		{ContainerID: "c1", Name: "aima-vllm-1", Engine: "vllm", Port: 8000, Status: "running"},
+		{ContainerID: "c2", Name: "aima-asr-1", Engine: "asr", Port: 8001, Status: "exited"},
+		{ContainerID: "c3", Name: "aima-tts-1", Engine: "tts", Port: 8002, Status: "running", ServiceID: "svc-tts-model-2"},
+		{ContainerID: "c4", Name: "aima-vllm-2", Engine: "vllm", Port: 8010, Status: "running"},
+	}}
+
+	result, err := NewDiscoverQuery(store, provider).Execute(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resultMap := result.(map[string]any)
+	if resultMap["total"] != 4 || resultMap["orphans"] != 2 {
+		t.Errorf("total=%v orphans=%v, want 4 and 2", resultMap["total"], resultMap["orphans"])
+	}
+
+	want := map[string]string{
+		"c1": "svc-vllm-model-1", // matched by engine and endpoint port
+		"c2": "",                 // no asr service
+		"c3": "svc-tts-model-2",  // reported by the provider
+		"c4": "",                 // vllm, but on a port no service uses
+	}
+	for _, item := range resultMap["containers"].([]map[string]any) {
+		id := item["container_id"].(string)
+		if item["service_id"] != want[id] {
+			t.Errorf("%s: service_id = %v, want %q", id, item["service_id"], want[id])
+		}
+		if item["orphan"] != (want[id] == "") {
+			t.Errorf("%s: orphan = %v", id, item["orphan"])
+		}
+	}
+}
+
+func TestDiscoverQuery_Errors(t *testing.T) {
+	if _, err := NewDiscoverQuery(NewMemoryStore(), nil).Execute(context.Background(), nil); err == nil {
+		t.Error("expected error with nil provider")
+	}
+
+	provider := &MockProvider{discoverErr: errors.New("docker unavailable")}
+	if _, err := NewDiscoverQuery(NewMemoryStore(), provider).Execute(context.Background(), nil); err == nil {
+		t.Error("expected provider error")
+	}
+}
+
 func TestQuery_Description(t *testing.T) {
 	if NewGetQuery(nil, nil).Description() == "" {
 		t.Error("expected non-empty description for GetQuery")
@@ -313,6 +370,9 @@ func TestQuery_Description(t *testing.T) {
 	if NewRecommendQuery(nil).Description() == "" {
 		t.Error("expected non-empty description for RecommendQuery")
 	}
+	if NewDiscoverQuery(nil, nil).Description() == "" {
+		t.Error("expected non-empty description for DiscoverQuery")
+	}
 }
 
 func TestQuery_Examples(t *testing.T) {
@@ -325,12 +385,16 @@ func TestQuery_Examples(t *testing.T) {
 	if len(NewRecommendQuery(nil).Examples()) == 0 {
 		t.Error("expected at least one example for RecommendQuery")
 	}
+	if len(NewDiscoverQuery(nil, nil).Examples()) == 0 {
+		t.Error("expected at least one example for DiscoverQuery")
+	}
 }
 
 func TestQueryImplementsInterface(t *testing.T) {
 	var _ unit.Query = NewGetQuery(nil, nil)
 	var _ unit.Query = NewListQuery(nil)
 	var _ unit.Query = NewRecommendQuery(nil)
+	var _ unit.Query = NewDiscoverQuery(nil, nil)
 }
 
 func createStoreWithMultipleServices() ServiceStore {
@@ -340,3 +404,64 @@ func createStoreWithMultipleServices() ServiceStore {
 	_ = store.Create(context.Background(), createTestService("svc-3", "model-2", ServiceStatusRunning))
 	return store
 }
+
+func TestDescribeQuery_Execute(t *testing.T) {
+	store := NewMemoryStore()
+	svc := createTestService("svc-vllm-model-1", "model-1", ServiceStatusRunning)
+	svc.Endpoints = []string{"http://localhost:8000"}
+	_ = store.Create(context.Background(), svc)
+
+	provider := &MockProvider{containers: []ManagedContainer{
+		{ContainerID: "c1", Name: "aima-asr-1", Engine: "asr", Port: 8001, Status: "running"},
+		{ContainerID: "c2", Name: "aima-vllm-1", Engine: "vllm", Port: 8000, Status: "running"},
+	}}
+
+	result, err := NewDescribeQuery(store, provider).Execute(context.Background(), map[string]any{"service_id": "svc-vllm-model-1", "tail": 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := result.(map[string]any)
+	if r["model_id"] != "model-1" || r["engine"] != "vllm" || r["port"] != 8000 || r["status"] != "running" || r["running"] != true || r["replicas"] != 1 {
+		t.Errorf("unexpected service detail: %+v", r)
+	}
+	container, ok := r["container"].(map[string]any)
+	if !ok || container["container_id"] != "c2" || container["status"] != "running" {
+		t.Errorf("expected the vllm container matched by port, got %+v", r["container"])
+	}
+	if r["logs"] != "mock logs for service svc-vllm-model-1 (last 5 lines)" {
+		t.Errorf("unexpected logs: %v", r["logs"])
+	}
+}
+
+func TestDescribeQuery_Errors(t *testing.T) {
+	store := createStoreWithService("svc-123", "model-1", ServiceStatusRunning)
+	tests := []struct {
+		name     string
+		store    ServiceStore
+		provider ServiceProvider
+		input    any
+		wantErr  error
+	}{
+		{"nil provider", store, nil, map[string]any{"service_id": "svc-123"}, ErrProviderNotSet},
+		{"missing service_id", store, &MockProvider{}, map[string]any{}, ErrInvalidInput},
+		{"unknown service", store, &MockProvider{}, map[string]any{"service_id": "svc-missing"}, ErrServiceNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDescribeQuery(tt.store, tt.provider).Execute(context.Background(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// Docker being unavailable only drops the container detail.
+	provider := &MockProvider{discoverErr: errors.New("docker unavailable")}
+	result, err := NewDescribeQuery(store, provider).Execute(context.Background(), map[string]any{"service_id": "svc-123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := result.(map[string]any)["container"]; ok {
+		t.Error("expected no container detail when discovery fails")
+	}
+}
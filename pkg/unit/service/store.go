@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -134,6 +135,11 @@ type ServiceProvider interface {
 	IsRunning(ctx context.Context, serviceID string) bool
 	// GetLogs returns the last tail lines of container/process logs for the service
 	GetLogs(ctx context.Context, serviceID string, tail int) (string, error)
+	// Exec runs a command inside the service container for diagnostics
+	Exec(ctx context.Context, serviceID string, cmd []string) (*ExecResult, error)
+	// DiscoverContainers lists every aima.managed=true container, filling
+	// ServiceID when the provider knows which service owns it
+	DiscoverContainers(ctx context.Context) ([]ManagedContainer, error)
 }
 
 type MockProvider struct {
@@ -143,8 +149,12 @@ type MockProvider struct {
 	scaleErr       error
 	metricsErr     error
 	recommendErr   error
+	execErr        error
+	discoverErr    error
 	metrics        *ServiceMetrics
 	recommendation *Recommendation
+	execResult     *ExecResult
+	containers     []ManagedContainer
 }
 
 func (m *MockProvider) Create(ctx context.Context, modelID string, resourceClass ResourceClass, replicas int, persistent bool) (*ModelService, error) {
@@ -214,6 +224,23 @@ func (m *MockProvider) GetLogs(ctx context.Context, serviceID string, tail int)
 	return fmt.Sprintf("mock logs for service %s (last %d lines)", serviceID, tail), nil
 }
 
+func (m *MockProvider) Exec(ctx context.Context, serviceID string, cmd []string) (*ExecResult, error) {
+	if m.execErr != nil {
+		return nil, m.execErr
+	}
+	if m.execResult != nil {
+		return m.execResult, nil
+	}
+	return &ExecResult{Stdout: strings.Join(cmd, " ") + "\n"}, nil
+}
+
+func (m *MockProvider) DiscoverContainers(ctx context.Context) ([]ManagedContainer, error) {
+	if m.discoverErr != nil {
+		return nil, m.discoverErr
+	}
+	return m.containers, nil
+}
+
 func createTestService(id string, modelID string, status ServiceStatus) *ModelService {
 	now := time.Now().Unix()
 	return &ModelService{
@@ -245,3 +272,22 @@ func toInt(v any) (int, bool) {
 		return 0, false
 	}
 }
+
+func toStringSlice(v any) ([]string, bool) {
+	switch val := v.(type) {
+	case []string:
+		return val, true
+	case []any:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			out = append(out, s)
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
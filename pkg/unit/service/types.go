@@ -30,6 +30,9 @@ type ModelService struct {
 	Metrics        *ServiceMetrics `json:"metrics,omitempty"`
 	CreatedAt      int64           `json:"created_at"`
 	UpdatedAt      int64           `json:"updated_at"`
+	// Pinned services are never stopped automatically, e.g. by the idle
+	// monitor; only an explicit stop or delete ends them.
+	Pinned bool `json:"pinned,omitempty"`
 }
 
 type ServiceMetrics struct {
@@ -67,6 +70,24 @@ type DeleteResult struct {
 	Success bool `json:"success"`
 }
 
+// ManagedContainer is an AIMA-managed container reported by service.discover.
+// ServiceID is empty for orphans that no known service owns.
+type ManagedContainer struct {
+	ContainerID string `json:"container_id"`
+	Name        string `json:"name"`
+	Engine      string `json:"engine"`
+	Port        int    `json:"port"`
+	Status      string `json:"status"`
+	ServiceID   string `json:"service_id,omitempty"`
+}
+
+// ExecResult is the outcome of a command run inside a service's container.
+type ExecResult struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+}
+
 type Recommendation struct {
 	ResourceClass      ResourceClass `json:"resource_class"`
 	Replicas           int           `json:"replicas"`
@@ -74,4 +95,7 @@ type Recommendation struct {
 	EngineType         string        `json:"engine_type"`         // 推荐引擎类型: vllm, whisper, tts, ollama
 	DeviceType         string        `json:"device_type"`         // 推荐设备: gpu, cpu
 	Reason             string        `json:"reason"`              // 推荐理由
+	// Config 是建议的服务配置(port, gpu_memory_utilization, max_model_len,
+	// memory, cpu),可直接作为 service.create 的 config 传入
+	Config map[string]any `json:"config,omitempty"`
 }
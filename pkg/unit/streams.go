@@ -0,0 +1,73 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrStreamCancelled is the cancellation cause of a stream stopped through
+// StreamTracker.Cancel.
+var ErrStreamCancelled = errors.New("stream cancelled by request")
+
+// StreamTracker maps the request IDs of in-flight streaming executions to
+// their cancel functions so they can be stopped explicitly. Request IDs come
+// from clients and need not be unique, so each stream is tracked under its
+// own token and finishing one never forgets another with the same ID.
+type StreamTracker struct {
+	mu      sync.Mutex
+	next    uint64
+	streams map[string]map[uint64]context.CancelCauseFunc
+}
+
+func NewStreamTracker() *StreamTracker {
+	return &StreamTracker{streams: make(map[string]map[uint64]context.CancelCauseFunc)}
+}
+
+// Track registers cancel under requestID and returns the function that
+// forgets it once its stream has finished.
+func (t *StreamTracker) Track(requestID string, cancel context.CancelCauseFunc) (untrack func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.next++
+	token := t.next
+	if t.streams[requestID] == nil {
+		t.streams[requestID] = make(map[uint64]context.CancelCauseFunc)
+	}
+	t.streams[requestID][token] = cancel
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.streams[requestID], token)
+		if len(t.streams[requestID]) == 0 {
+			delete(t.streams, requestID)
+		}
+	}
+}
+
+// Cancel stops every stream registered under requestID with
+// ErrStreamCancelled. It reports whether such a stream was in flight.
+func (t *StreamTracker) Cancel(requestID string) bool {
+	t.mu.Lock()
+	cancels := t.streams[requestID]
+	delete(t.streams, requestID)
+	t.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel(ErrStreamCancelled)
+	}
+	return len(cancels) > 0
+}
+
+// Active returns the number of tracked streams.
+func (t *StreamTracker) Active() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := 0
+	for _, cancels := range t.streams {
+		n += len(cancels)
+	}
+	return n
+}
@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStreamTracker_DuplicateRequestIDs(t *testing.T) {
+	streams := NewStreamTracker()
+	ctx1, cancel1 := context.WithCancelCause(context.Background())
+	defer cancel1(nil)
+	ctx2, cancel2 := context.WithCancelCause(context.Background())
+	defer cancel2(nil)
+
+	untrack1 := streams.Track("req-1", cancel1)
+	streams.Track("req-1", cancel2)
+	if streams.Active() != 2 {
+		t.Fatalf("Active() = %d, want 2", streams.Active())
+	}
+
+	// The first stream finishing must not forget the second.
+	untrack1()
+	if streams.Active() != 1 {
+		t.Fatalf("Active() = %d after untrack, want 1", streams.Active())
+	}
+
+	if !streams.Cancel("req-1") {
+		t.Fatal("expected the second stream to still be cancellable")
+	}
+	if ctx1.Err() != nil {
+		t.Error("expected the finished stream to be left alone")
+	}
+	if !errors.Is(context.Cause(ctx2), ErrStreamCancelled) {
+		t.Errorf("expected ErrStreamCancelled, got %v", context.Cause(ctx2))
+	}
+	if streams.Active() != 0 || streams.Cancel("req-1") {
+		t.Error("expected no streams after Cancel")
+	}
+}
+
+func TestStreamTracker_CancelStopsAllWithID(t *testing.T) {
+	streams := NewStreamTracker()
+	ctx1, cancel1 := context.WithCancelCause(context.Background())
+	defer cancel1(nil)
+	ctx2, cancel2 := context.WithCancelCause(context.Background())
+	defer cancel2(nil)
+
+	streams.Track("req-1", cancel1)
+	untrack2 := streams.Track("req-1", cancel2)
+	if !streams.Cancel("req-1") {
+		t.Fatal("expected Cancel to find the streams")
+	}
+	if ctx1.Err() == nil || ctx2.Err() == nil {
+		t.Error("expected every stream with the ID to be cancelled")
+	}
+
+	// Untracking after Cancel is harmless.
+	untrack2()
+	if streams.Active() != 0 {
+		t.Errorf("Active() = %d, want 0", streams.Active())
+	}
+}
@@ -23,6 +23,10 @@ type Schema struct {
 	Enum      []any    `json:"enum,omitempty"`
 	Default   any      `json:"default,omitempty"`
 	Examples  []any    `json:"examples,omitempty"`
+
+	// Sensitive marks values, such as tokens or user prompts, that must not
+	// be written to logs or recordings. See RedactSensitive.
+	Sensitive bool `json:"sensitive,omitempty"`
 }
 
 type Field struct {
@@ -72,6 +76,13 @@ type Event interface {
 	CorrelationID() string
 }
 
+// IdentifiedEvent is an Event with a unique ID that stays the same when the
+// event is persisted and replayed, so consumers can drop redeliveries.
+type IdentifiedEvent interface {
+	Event
+	ID() string
+}
+
 type Resource interface {
 	URI() string
 	Domain() string
@@ -80,6 +91,24 @@ type Resource interface {
 	Watch(ctx context.Context) (<-chan ResourceUpdate, error)
 }
 
+// Deprecation describes a deprecated unit or alias and what replaces it.
+type Deprecation struct {
+	Message    string `json:"message,omitempty"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
+}
+
+// DeprecatedUnit is implemented by Commands and Queries that are kept for
+// backward compatibility but should no longer be used.
+type DeprecatedUnit interface {
+	Deprecation() *Deprecation
+}
+
+// VersionedUnit is implemented by Commands that can be registered in several
+// versions under the same name (e.g. "v1", "v2").
+type VersionedUnit interface {
+	Version() string
+}
+
 // ResourceFactory creates Resource instances dynamically based on URI patterns.
 // It is used to handle dynamic URI patterns like asms://model/{id}.
 type ResourceFactory interface {
@@ -93,9 +122,27 @@ type ResourceFactory interface {
 
 // StreamChunk represents a single chunk in a streaming response.
 type StreamChunk struct {
-	Type     string `json:"type"`               // "content", "error", "done"
-	Data     any    `json:"data"`               // actual chunk data (e.g., string content)
-	Metadata any    `json:"metadata,omitempty"` // optional metadata (usage, finish_reason, etc.)
+	Type     string       `json:"type"`               // "content", "usage", "error", "done"
+	Data     any          `json:"data"`               // actual chunk data (e.g., string content)
+	Metadata any          `json:"metadata,omitempty"` // optional metadata (usage, finish_reason, etc.)
+	Error    *StreamError `json:"error,omitempty"`    // set on "error" chunks
+}
+
+// StreamError describes why a stream failed after it started.
+type StreamError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorChunk returns the "error" chunk a streaming command sends as its last
+// chunk when it fails mid-stream, so the failure reaches the client in order
+// after the chunks already sent.
+func ErrorChunk(err error) StreamChunk {
+	streamErr := &StreamError{Code: string(ErrCodeInternalError), Message: err.Error()}
+	if ue, ok := AsUnitError(err); ok {
+		streamErr.Code, streamErr.Message = string(ue.Code), ue.Message
+	}
+	return StreamChunk{Type: "error", Error: streamErr}
 }
 
 // StreamingCommand extends Command with streaming capabilities.
@@ -2,6 +2,8 @@ package unit
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -479,3 +481,18 @@ func ptr[T any](v T) *T {
 type assertError string
 
 func (e assertError) Error() string { return string(e) }
+
+func TestErrorChunk(t *testing.T) {
+	chunk := ErrorChunk(fmt.Errorf("chat: %w", NewError(ErrCodeTimeout, "provider timed out")))
+	if chunk.Type != "error" || chunk.Error == nil {
+		t.Fatalf("expected an error chunk, got %+v", chunk)
+	}
+	if chunk.Error.Code != string(ErrCodeTimeout) || chunk.Error.Message != "provider timed out" {
+		t.Errorf("expected the unit error's code and message, got %+v", chunk.Error)
+	}
+
+	chunk = ErrorChunk(errors.New("connection reset"))
+	if chunk.Error.Code != string(ErrCodeInternalError) || chunk.Error.Message != "connection reset" {
+		t.Errorf("expected other errors to be internal, got %+v", chunk.Error)
+	}
+}
@@ -112,6 +112,14 @@ func (m *MockServiceProvider) GetLogs(ctx context.Context, serviceID string, tai
 	return "mock log output", nil
 }
 
+func (m *MockServiceProvider) DiscoverContainers(ctx context.Context) ([]service.ManagedContainer, error) {
+	return nil, nil
+}
+
+func (m *MockServiceProvider) Exec(ctx context.Context, serviceID string, cmd []string) (*service.ExecResult, error) {
+	return &service.ExecResult{Stdout: "mock exec output"}, nil
+}
+
 type MockInferenceProvider struct{}
 
 func (m *MockInferenceProvider) Chat(ctx context.Context, modelID string, messages []inference.Message, opts inference.ChatOptions) (*inference.ChatResponse, error) {
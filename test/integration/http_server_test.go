@@ -340,7 +340,7 @@ func TestHTTPServerIntegrationMethodNotAllowed(t *testing.T) {
 	gw := createTestGateway(t)
 	adapter := gateway.NewHTTPAdapter(gw)
 
-	req := httptest.NewRequest(http.MethodGet, "/execute", nil)
+	req := httptest.NewRequest(http.MethodPut, "/execute", nil)
 	rec := httptest.NewRecorder()
 
 	adapter.ServeHTTP(rec, req)